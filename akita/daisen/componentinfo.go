@@ -24,7 +24,7 @@ type ComponentInfo struct {
 }
 
 func httpComponentNames(w http.ResponseWriter, r *http.Request) {
-	componentNames := traceReader.ListComponents()
+	componentNames := traceStore.ListComponents()
 
 	rsp, err := json.Marshal(componentNames)
 	dieOnErr(err)
@@ -105,46 +105,10 @@ func calculateReqIn(
 	startTime, endTime float64,
 	numDots int,
 ) *ComponentInfo {
-	info := &ComponentInfo{
-		Name:      compName,
-		InfoType:  "req_in",
-		StartTime: startTime,
-		EndTime:   endTime,
-	}
-
-	query := tracing.TaskQuery{
-		Where:            compName,
-		Kind:             "req_in",
-		EnableTimeRange:  true,
-		StartTime:        startTime,
-		EndTime:          endTime,
-		EnableParentTask: true,
-	}
-	reqs := traceReader.ListTasks(query)
-
-	totalDuration := endTime - startTime
-	binDuration := totalDuration / float64(numDots)
-	for i := 0; i < numDots; i++ {
-		binStartTime := float64(i)*binDuration + startTime
-		binEndTime := float64(i+1)*binDuration + startTime
-
-		reqCount := 0
-		for _, r := range reqs {
-			if float64(r.StartTime) > binStartTime &&
-				float64(r.StartTime) < binEndTime {
-				reqCount++
-			}
-		}
-
-		tv := TimeValue{
-			Time:  binStartTime + 0.5*binDuration,
-			Value: float64(reqCount) / binDuration,
-		}
-
-		info.Data = append(info.Data, tv)
-	}
-
-	return info
+	return aggregateReqIn(compName, "req_in", startTime, endTime, numDots,
+		func(bin tracing.TaskBin) float64 {
+			return float64(bin.NumStarted) / (bin.EndTime - bin.StartTime)
+		})
 }
 
 func calculateReqComplete(
@@ -152,94 +116,63 @@ func calculateReqComplete(
 	startTime, endTime float64,
 	numDots int,
 ) *ComponentInfo {
-	info := &ComponentInfo{
-		Name:      compName,
-		InfoType:  "req_complete",
-		StartTime: startTime,
-		EndTime:   endTime,
-	}
-
-	query := tracing.TaskQuery{
-		Where:            compName,
-		Kind:             "req_in",
-		EnableTimeRange:  true,
-		StartTime:        startTime,
-		EndTime:          endTime,
-		EnableParentTask: true,
-	}
-	reqs := traceReader.ListTasks(query)
-
-	totalDuration := endTime - startTime
-	binDuration := totalDuration / float64(numDots)
-	for i := 0; i < numDots; i++ {
-		binStartTime := float64(i)*binDuration + startTime
-		binEndTime := float64(i+1)*binDuration + startTime
-
-		reqCount := 0
-		for _, r := range reqs {
-			if float64(r.EndTime) > binStartTime &&
-				float64(r.EndTime) < binEndTime {
-				reqCount++
-			}
-		}
-
-		tv := TimeValue{
-			Time:  binStartTime + 0.5*binDuration,
-			Value: float64(reqCount) / binDuration,
-		}
-
-		info.Data = append(info.Data, tv)
-	}
-
-	return info
+	return aggregateReqIn(compName, "req_complete",
+		startTime, endTime, numDots,
+		func(bin tracing.TaskBin) float64 {
+			return float64(bin.NumCompleted) / (bin.EndTime - bin.StartTime)
+		})
 }
 
 func calculateAvgLatency(
 	compName string,
 	startTime, endTime float64,
 	numDots int,
+) *ComponentInfo {
+	return aggregateReqIn(compName, "avg_latency",
+		startTime, endTime, numDots,
+		func(bin tracing.TaskBin) float64 {
+			return bin.AvgLatency
+		})
+}
+
+// aggregateReqIn lets the trace store aggregate the incoming requests of a
+// component into time bins and converts each bin to a value.
+func aggregateReqIn(
+	compName, infoType string,
+	startTime, endTime float64,
+	numDots int,
+	value func(bin tracing.TaskBin) float64,
 ) *ComponentInfo {
 	info := &ComponentInfo{
 		Name:      compName,
-		InfoType:  "avg_latency",
+		InfoType:  infoType,
 		StartTime: startTime,
 		EndTime:   endTime,
 	}
 
-	query := tracing.TaskQuery{
-		Where:            compName,
-		Kind:             "req_in",
-		EnableTimeRange:  true,
-		StartTime:        startTime,
-		EndTime:          endTime,
-		EnableParentTask: true,
+	query := tracing.TaskAggregationQuery{
+		TraceStoreQuery: tracing.TraceStoreQuery{
+			TaskQuery: tracing.TaskQuery{
+				Where: compName,
+				Kind:  "req_in",
+			},
+		},
+		StartTime: startTime,
+		EndTime:   endTime,
+		NumBins:   numDots,
 	}
-	reqs := traceReader.ListTasks(query)
+	bins := traceStore.AggregateTasks(query)
 
-	totalDuration := endTime - startTime
-	binDuration := totalDuration / float64(numDots)
+	binDuration := (endTime - startTime) / float64(numDots)
 	for i := 0; i < numDots; i++ {
 		binStartTime := float64(i)*binDuration + startTime
-		binEndTime := float64(i+1)*binDuration + startTime
-
-		sum := 0.0
-		reqCount := 0
-		for _, r := range reqs {
-			if float64(r.EndTime) > binStartTime &&
-				float64(r.EndTime) < binEndTime {
-				sum += float64(r.EndTime - r.StartTime)
-				reqCount++
-			}
-		}
 
-		value := 0.0
-		if reqCount > 0 {
-			value = sum / float64(reqCount)
+		tv := TimeValue{
+			Time: binStartTime + 0.5*binDuration,
 		}
 
-		tv := TimeValue{
-			Time:  binStartTime + 0.5*binDuration,
-			Value: value,
+		if len(bins) > 0 {
+			tv.Value = value(bins[i])
 		}
 
 		info.Data = append(info.Data, tv)
@@ -291,7 +224,7 @@ func calculateTimeWeightedTaskCount(
 		EndTime:          endTime,
 		EnableParentTask: true,
 	}
-	tasks := traceReader.ListTasks(query)
+	tasks := traceStore.ListTasks(query)
 	tasks = filterTask(tasks, filter)
 
 	totalDuration := endTime - startTime
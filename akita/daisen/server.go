@@ -6,6 +6,7 @@ import (
 	"io"
 	"log"
 	"net/http"
+	"os"
 
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/sarchlab/akita/v3/daisen/static"
@@ -25,9 +26,15 @@ var (
 	sqliteFileName = flag.String("sqlite",
 		"",
 		"Name of the SQLite file to read from.")
+	storeFileName = flag.String("store",
+		"",
+		"Name of the indexed trace store file. If a trace is given with "+
+			"-mysql, -csv, or -sqlite, the trace is converted to the store "+
+			"first, unless the store file already exists. By default, the "+
+			"store is placed next to the trace file.")
 
-	traceReader tracing.TraceReader
-	fs          http.FileSystem
+	traceStore *tracing.TraceStore
+	fs         http.FileSystem
 )
 
 func main() {
@@ -56,9 +63,10 @@ func mustBeOneAndOnlyOneSource() {
 		numSources++
 	}
 
-	if numSources != 1 {
+	if numSources > 1 || (numSources == 0 && *storeFileName == "") {
 		flag.PrintDefaults()
-		panic("Must specify one and only one of -mysql, -csv, or -sqlite")
+		panic("Must specify one and only one of -mysql, -csv, or -sqlite, " +
+			"or an existing trace store with -store")
 	}
 }
 
@@ -68,19 +76,73 @@ func startServer() {
 }
 
 func connectToDB() {
+	storeFile := *storeFileName
+	var source tracing.TaskIterator
+
 	switch {
 	case *mySQLDBName != "":
 		db := tracing.NewMySQLTraceReader(*mySQLDBName)
 		db.Init()
-		traceReader = db
+		source = db
+		storeFile = defaultStoreFileName(storeFile, *mySQLDBName)
 	case *csvFileName != "":
-		db := tracing.NewCSVTraceReader(*csvFileName)
-		traceReader = db
+		source = tracing.NewCSVTraceReader(*csvFileName)
+		storeFile = defaultStoreFileName(storeFile, *csvFileName)
 	case *sqliteFileName != "":
 		db := tracing.NewSQLiteTraceReader(*sqliteFileName)
 		db.Init()
-		traceReader = db
+		source = db
+		storeFile = defaultStoreFileName(storeFile, *sqliteFileName)
+	}
+
+	stamp := traceStamp()
+
+	_, err := os.Stat(storeFile)
+	if err == nil {
+		traceStore = tracing.NewTraceStore(storeFile)
+		traceStore.Init()
+
+		if source == nil || traceStore.SourceStamp() == stamp {
+			return
+		}
+
+		fmt.Printf("The trace has changed since %s was built\n", storeFile)
+		dieOnErr(traceStore.Close())
+		dieOnErr(os.Remove(storeFile))
+	}
+
+	fmt.Printf("Indexing the trace into %s\n", storeFile)
+	traceStore = tracing.ConvertToTraceStore(source, storeFile)
+	traceStore.SetSourceStamp(stamp)
+}
+
+// traceStamp identifies the version of the trace file by its size and
+// modification time. MySQL databases are not stamped, as they are not
+// rewritten after the simulation ends.
+func traceStamp() string {
+	var filename string
+
+	switch {
+	case *csvFileName != "":
+		filename = *csvFileName
+	case *sqliteFileName != "":
+		filename = *sqliteFileName
+	default:
+		return ""
 	}
+
+	info, err := os.Stat(filename)
+	dieOnErr(err)
+
+	return fmt.Sprintf("%d:%d", info.Size(), info.ModTime().UnixNano())
+}
+
+func defaultStoreFileName(storeFile, traceName string) string {
+	if storeFile != "" {
+		return storeFile
+	}
+
+	return traceName + ".store.sqlite3"
 }
 
 func startAPIServer() {
@@ -2,6 +2,7 @@ package main
 
 import (
 	"encoding/json"
+	"math"
 	"net/http"
 	"strconv"
 
@@ -29,18 +30,31 @@ func httpTrace(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	query := tracing.TaskQuery{
-		ID:               r.FormValue("id"),
-		ParentID:         r.FormValue("parentid"),
-		Kind:             r.FormValue("kind"),
-		Where:            r.FormValue("where"),
-		StartTime:        startTime,
-		EndTime:          endTime,
-		EnableTimeRange:  useTimeRange,
-		EnableParentTask: false,
+	query := tracing.TraceStoreQuery{
+		TaskQuery: tracing.TaskQuery{
+			ID:               r.FormValue("id"),
+			ParentID:         r.FormValue("parentid"),
+			Kind:             r.FormValue("kind"),
+			Where:            r.FormValue("where"),
+			StartTime:        startTime,
+			EndTime:          endTime,
+			EnableTimeRange:  useTimeRange,
+			EnableParentTask: false,
+		},
+		WherePrefix: r.FormValue("whereprefix"),
+		WhereRegex:  r.FormValue("whereregex"),
+		What:        r.FormValue("what"),
+		Offset:      intFormValue(r, "offset", 0),
+		Limit:       intFormValue(r, "limit", 0),
 	}
 
-	tasks := traceReader.ListTasks(query)
+	if r.FormValue("mindepth") != "" || r.FormValue("maxdepth") != "" {
+		query.EnableDepthRange = true
+		query.MinDepth = intFormValue(r, "mindepth", 0)
+		query.MaxDepth = intFormValue(r, "maxdepth", math.MaxInt32)
+	}
+
+	tasks := traceStore.QueryTasks(query)
 
 	rsp, err := json.Marshal(tasks)
 	dieOnErr(err)
@@ -48,3 +62,15 @@ func httpTrace(w http.ResponseWriter, r *http.Request) {
 	_, err = w.Write(rsp)
 	dieOnErr(err)
 }
+
+func intFormValue(r *http.Request, key string, defaultValue int) int {
+	str := r.FormValue(key)
+	if str == "" {
+		return defaultValue
+	}
+
+	value, err := strconv.Atoi(str)
+	dieOnErr(err)
+
+	return value
+}
@@ -119,6 +119,27 @@ def main():
     error |= run_test("Write-back cache 6",
                       './writebackcache -max-address=1048576 -parallel -num-access=10000', 'acceptancetests/writebackcache')
 
+    error |= run_test("Sectored write-back cache 1",
+                      './writebackcache -max-address=64 -log2-sector-size=4 -num-access=10000', 'acceptancetests/writebackcache')
+    error |= run_test("Sectored write-back cache 2",
+                      './writebackcache -max-address=1024 -log2-sector-size=4 -num-access=10000', 'acceptancetests/writebackcache')
+    error |= run_test("Sectored write-back cache 3",
+                      './writebackcache -max-address=1048576 -log2-sector-size=5 -num-access=10000', 'acceptancetests/writebackcache')
+    error |= run_test("Sectored write-back cache 4",
+                      './writebackcache -max-address=64 -log2-sector-size=4 -parallel -num-access=10000', 'acceptancetests/writebackcache')
+    error |= run_test("Sectored write-back cache 5",
+                      './writebackcache -max-address=1024 -log2-sector-size=4 -parallel -num-access=10000', 'acceptancetests/writebackcache')
+    error |= run_test("Sectored write-back cache 6",
+                      './writebackcache -max-address=1048576 -log2-sector-size=5 -parallel -num-access=10000', 'acceptancetests/writebackcache')
+
+    error |= compile_test('acceptancetests/coherentwritebackcache')
+    error |= run_test("Coherent write-back cache 1",
+                      './coherentwritebackcache -max-address=1024 -num-access=10000', 'acceptancetests/coherentwritebackcache')
+    error |= run_test("Coherent write-back cache 2",
+                      './coherentwritebackcache -max-address=65536 -num-access=10000', 'acceptancetests/coherentwritebackcache')
+    error |= run_test("Coherent write-back cache 3",
+                      './coherentwritebackcache -max-address=1048576 -num-cache=8 -num-access=10000', 'acceptancetests/coherentwritebackcache')
+
     error |= compile_test('acceptancetests/dram')
     error |= run_test(
         "DRAM cache 1", './dram -max-address=64 -num-access=10000', 'acceptancetests/dram')
@@ -0,0 +1,266 @@
+package acceptancetests
+
+import (
+	"log"
+	"math/rand"
+	"reflect"
+
+	"github.com/sarchlab/akita/v3/mem/mem"
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+type writeRecord struct {
+	value     uint32
+	completed bool
+}
+
+// A MemValueTracker records the values that a group of
+// CoherentMemAccessAgents write to a shared memory.
+type MemValueTracker struct {
+	writes map[uint64][]*writeRecord
+}
+
+// NewMemValueTracker creates a new MemValueTracker.
+func NewMemValueTracker() *MemValueTracker {
+	return &MemValueTracker{
+		writes: make(map[uint64][]*writeRecord),
+	}
+}
+
+func (t *MemValueTracker) lastCompletedWrite(addr uint64) int {
+	records := t.writes[addr]
+	for i := len(records) - 1; i >= 0; i-- {
+		if records[i].completed {
+			return i
+		}
+	}
+
+	return -1
+}
+
+type coherentPendingRead struct {
+	req         *mem.ReadReq
+	oldestValid int
+}
+
+type coherentPendingWrite struct {
+	req    *mem.WriteReq
+	record *writeRecord
+}
+
+// A CoherentMemAccessAgent is a Component that tests whether a group of caches
+// that share a memory are coherent. Each agent writes to its own set of
+// addresses and reads from all the addresses. A read must not return a value
+// that has been overwritten before the read is issued.
+type CoherentMemAccessAgent struct {
+	*sim.TickingComponent
+
+	LowModule  sim.Port
+	MaxAddress uint64
+	AgentID    int
+	NumAgents  int
+
+	WriteLeft     int
+	ReadLeft      int
+	PendingReads  map[string]*coherentPendingRead
+	PendingWrites map[string]*coherentPendingWrite
+
+	tracker *MemValueTracker
+	memPort sim.Port
+}
+
+// Tick updates the states of the agent and issues new read and write requests.
+// The agent keeps ticking until all the requests are sent, as a randomly
+// selected address may not be ready to access.
+func (a *CoherentMemAccessAgent) Tick(now sim.VTimeInSec) bool {
+	madeProgress := false
+
+	madeProgress = a.processMsgRsp(now) || madeProgress
+
+	if a.ReadLeft == 0 && a.WriteLeft == 0 {
+		return madeProgress
+	}
+
+	if a.shouldRead() {
+		a.doRead(now)
+	} else {
+		a.doWrite(now)
+	}
+
+	return true
+}
+
+func (a *CoherentMemAccessAgent) processMsgRsp(now sim.VTimeInSec) bool {
+	msg := a.memPort.Retrieve(now)
+	if msg == nil {
+		return false
+	}
+
+	switch msg := msg.(type) {
+	case *mem.WriteDoneRsp:
+		write := a.PendingWrites[msg.RespondTo]
+		write.record.completed = true
+		delete(a.PendingWrites, msg.RespondTo)
+	case *mem.DataReadyRsp:
+		read := a.PendingReads[msg.RespondTo]
+		delete(a.PendingReads, msg.RespondTo)
+		a.checkReadResult(read, msg)
+	default:
+		log.Panicf("cannot process message of type %s", reflect.TypeOf(msg))
+	}
+
+	return true
+}
+
+func (a *CoherentMemAccessAgent) checkReadResult(
+	read *coherentPendingRead,
+	dataReady *mem.DataReadyRsp,
+) {
+	result := bytesToUint32(dataReady.Data)
+	records := a.tracker.writes[read.req.Address]
+
+	for _, r := range records[read.oldestValid:] {
+		if r.value == result {
+			return
+		}
+	}
+
+	log.Panicf("%s: stale or unknown value 0x%X read from 0x%X",
+		a.Name(), result, read.req.Address)
+}
+
+func (a *CoherentMemAccessAgent) shouldRead() bool {
+	if len(a.tracker.writes) == 0 || a.ReadLeft == 0 {
+		return false
+	}
+
+	if a.WriteLeft == 0 {
+		return true
+	}
+
+	return rand.Float64() > 0.5
+}
+
+func (a *CoherentMemAccessAgent) doRead(now sim.VTimeInSec) bool {
+	address, ok := a.randomReadAddress()
+	if !ok || a.isAddressInPendingReq(address) {
+		return false
+	}
+
+	oldestValid := a.tracker.lastCompletedWrite(address)
+	if oldestValid < 0 {
+		return false
+	}
+
+	readReq := mem.ReadReqBuilder{}.
+		WithSendTime(now).
+		WithSrc(a.memPort).
+		WithDst(a.LowModule).
+		WithAddress(address).
+		WithByteSize(4).
+		WithPID(1).
+		Build()
+
+	err := a.memPort.Send(readReq)
+	if err != nil {
+		return false
+	}
+
+	a.PendingReads[readReq.ID] = &coherentPendingRead{
+		req:         readReq,
+		oldestValid: oldestValid,
+	}
+	a.ReadLeft--
+
+	return true
+}
+
+func (a *CoherentMemAccessAgent) randomReadAddress() (uint64, bool) {
+	addr := rand.Uint64() % (a.MaxAddress / 4) * 4
+	_, written := a.tracker.writes[addr]
+
+	return addr, written
+}
+
+func (a *CoherentMemAccessAgent) doWrite(now sim.VTimeInSec) bool {
+	address := a.randomWriteAddress()
+	if a.isAddressInPendingReq(address) {
+		return false
+	}
+
+	data := rand.Uint32()
+	writeReq := mem.WriteReqBuilder{}.
+		WithSendTime(now).
+		WithSrc(a.memPort).
+		WithDst(a.LowModule).
+		WithAddress(address).
+		WithPID(1).
+		WithData(uint32ToBytes(data)).
+		Build()
+
+	err := a.memPort.Send(writeReq)
+	if err != nil {
+		return false
+	}
+
+	record := &writeRecord{value: data}
+	a.tracker.writes[address] = append(a.tracker.writes[address], record)
+	a.PendingWrites[writeReq.ID] = &coherentPendingWrite{
+		req:    writeReq,
+		record: record,
+	}
+	a.WriteLeft--
+
+	return true
+}
+
+// randomWriteAddress returns an address that only this agent writes to. The
+// addresses of different agents are interleaved at the 4-byte granularity, so
+// that the agents frequently write to the same cache lines.
+func (a *CoherentMemAccessAgent) randomWriteAddress() uint64 {
+	numWords := a.MaxAddress / 4 / uint64(a.NumAgents)
+	word := rand.Uint64()%numWords*uint64(a.NumAgents) + uint64(a.AgentID)
+
+	return word * 4
+}
+
+func (a *CoherentMemAccessAgent) isAddressInPendingReq(addr uint64) bool {
+	for _, write := range a.PendingWrites {
+		if write.req.Address == addr {
+			return true
+		}
+	}
+
+	for _, read := range a.PendingReads {
+		if read.req.Address == addr {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NewCoherentMemAccessAgent creates a new CoherentMemAccessAgent.
+func NewCoherentMemAccessAgent(
+	name string,
+	engine sim.Engine,
+	tracker *MemValueTracker,
+	agentID, numAgents int,
+) *CoherentMemAccessAgent {
+	agent := new(CoherentMemAccessAgent)
+	agent.TickingComponent = sim.NewTickingComponent(
+		name, engine, 1*sim.GHz, agent)
+
+	agent.memPort = sim.NewLimitNumMsgPort(agent, 1, name+".MemPort")
+	agent.AddPort("Mem", agent.memPort)
+
+	agent.tracker = tracker
+	agent.AgentID = agentID
+	agent.NumAgents = numAgents
+	agent.ReadLeft = 10000
+	agent.WriteLeft = 10000
+	agent.PendingReads = make(map[string]*coherentPendingRead)
+	agent.PendingWrites = make(map[string]*coherentPendingWrite)
+
+	return agent
+}
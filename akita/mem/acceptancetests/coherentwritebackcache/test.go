@@ -0,0 +1,117 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"time"
+
+	"github.com/sarchlab/akita/v3/mem/acceptancetests"
+	"github.com/sarchlab/akita/v3/mem/cache/coherence"
+	"github.com/sarchlab/akita/v3/mem/cache/writeback"
+	"github.com/sarchlab/akita/v3/mem/idealmemcontroller"
+	"github.com/sarchlab/akita/v3/mem/mem"
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+var seedFlag = flag.Int64("seed", 0, "Random Seed")
+var numAccessFlag = flag.Int("num-access", 20000,
+	"Number of accesses to generate per agent")
+var numCacheFlag = flag.Int("num-cache", 4, "Number of coherent caches")
+var maxAddressFlag = flag.Uint64("max-address", 65536, "Address range to use")
+
+var engine sim.Engine
+var agents []*acceptancetests.CoherentMemAccessAgent
+
+func main() {
+	flag.Parse()
+
+	initSeed()
+	buildEnvironment()
+	runSimulation()
+	allMsgsMustBeSent()
+}
+
+func initSeed() {
+	var seed int64
+	if *seedFlag == 0 {
+		seed = time.Now().UnixNano()
+	} else {
+		seed = *seedFlag
+	}
+	fmt.Fprintf(os.Stderr, "Seed %d\n", seed)
+	rand.Seed(seed)
+}
+
+func buildEnvironment() {
+	engine = sim.NewSerialEngine()
+
+	conn := sim.NewDirectConnection("Conn", engine, 1*sim.GHz)
+
+	dram := idealmemcontroller.MakeBuilder().
+		WithEngine(engine).
+		WithNewStorage(4 * mem.GB).
+		Build("DRAM")
+	conn.PlugIn(dram.GetPortByName("Top"), 16)
+
+	directory := coherence.MakeBuilder().
+		WithEngine(engine).
+		WithLog2BlockSize(6).
+		WithLowModuleFinder(&mem.SingleLowModuleFinder{
+			LowModule: dram.GetPortByName("Top"),
+		}).
+		Build("Directory")
+	conn.PlugIn(directory.GetPortByName("Top"), 16)
+	conn.PlugIn(directory.GetPortByName("Bottom"), 16)
+
+	builder := writeback.MakeBuilder().
+		WithEngine(engine).
+		WithLowModuleFinder(&mem.SingleLowModuleFinder{
+			LowModule: directory.GetPortByName("Top"),
+		}).
+		WithByteSize(16 * mem.KB).
+		WithLog2BlockSize(6).
+		WithWayAssociativity(4).
+		WithNumMSHREntry(4).
+		WithNumReqPerCycle(4).
+		WithCoherence()
+
+	tracker := acceptancetests.NewMemValueTracker()
+	for i := 0; i < *numCacheFlag; i++ {
+		cache := builder.Build(fmt.Sprintf("Cache[%d]", i))
+
+		agent := acceptancetests.NewCoherentMemAccessAgent(
+			fmt.Sprintf("Agent[%d]", i), engine, tracker, i, *numCacheFlag)
+		agent.MaxAddress = *maxAddressFlag
+		agent.WriteLeft = *numAccessFlag
+		agent.ReadLeft = *numAccessFlag
+		agent.LowModule = cache.GetPortByName("Top")
+		agents = append(agents, agent)
+
+		conn.PlugIn(agent.GetPortByName("Mem"), 16)
+		conn.PlugIn(cache.GetPortByName("Top"), 16)
+		conn.PlugIn(cache.GetPortByName("Bottom"), 16)
+
+		agent.TickLater(0)
+	}
+}
+
+func runSimulation() {
+	err := engine.Run()
+	if err != nil {
+		panic(err)
+	}
+}
+
+func allMsgsMustBeSent() {
+	for _, agent := range agents {
+		if len(agent.PendingWrites) > 0 || len(agent.PendingReads) > 0 {
+			panic("Not all req returned")
+		}
+
+		if agent.WriteLeft > 0 || agent.ReadLeft > 0 {
+			panic("more requests to send")
+		}
+	}
+}
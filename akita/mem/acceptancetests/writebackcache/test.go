@@ -28,6 +28,8 @@ var maxAddressFlag = flag.Uint64("max-address", 1048576, "Address range to use")
 var traceFileFlag = flag.String("trace", "", "Trace file")
 var traceWithStdoutFlag = flag.Bool("trace-stdout", false, "Trace with stdout")
 var parallelFlag = flag.Bool("parallel", false, "Test with parallel engine")
+var log2SectorSizeFlag = flag.Uint64("log2-sector-size", 0,
+	"The sector size as a power of 2. The cache is not sectored if it is 0.")
 
 var engine sim.Engine
 var agent *acceptancetests.MemAccessAgent
@@ -73,6 +75,7 @@ func buildEnvironment() {
 		WithLowModuleFinder(lowModuleFinder).
 		WithByteSize(16 * mem.KB).
 		WithLog2BlockSize(6).
+		WithLog2SectorSize(*log2SectorSizeFlag).
 		WithWayAssociativity(4).
 		WithNumMSHREntry(4).
 		WithNumReqPerCycle(16)
@@ -0,0 +1,48 @@
+package cache
+
+// bipThrottle is the number of insertions for each insertion that is placed
+// at the most recently used position.
+const bipThrottle = 32
+
+// BIPPolicy is the bimodal insertion policy. It is an LRU policy that inserts
+// most of the new lines at the least recently used position, so that a line
+// is evicted by the next miss to the set unless it is reused before that. One
+// in every 32 new lines is inserted at the most recently used position, so
+// that the cache can adapt when the working set changes. The policy never
+// skips the allocation of a block.
+type BIPPolicy struct {
+	LRUVictimFinder
+
+	insertCount int
+}
+
+// NewBIPPolicy creates a new BIPPolicy.
+func NewBIPPolicy() *BIPPolicy {
+	return &BIPPolicy{}
+}
+
+// OnInsert places the new line at the least recently used position, unless
+// the line is one of the few lines that are placed at the most recently used
+// position.
+func (p *BIPPolicy) OnInsert(set *Set, block *Block) {
+	p.insertCount++
+	if p.insertCount >= bipThrottle {
+		p.insertCount = 0
+		moveToLRUQueueEnd(set, block)
+
+		return
+	}
+
+	moveToLRUQueueFront(set, block)
+}
+
+func moveToLRUQueueFront(set *Set, block *Block) {
+	for i, b := range set.LRUQueue {
+		if b == block {
+			copy(set.LRUQueue[1:i+1], set.LRUQueue[:i])
+			set.LRUQueue[0] = block
+
+			return
+		}
+	}
+}
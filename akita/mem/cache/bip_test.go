@@ -0,0 +1,50 @@
+package cache
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BIP Policy", func() {
+	var (
+		policy    *BIPPolicy
+		directory *DirectoryImpl
+		set       *Set
+	)
+
+	BeforeEach(func() {
+		policy = NewBIPPolicy()
+		directory = NewDirectory(1, 4, 64, policy)
+		set = &directory.Sets[0]
+		for i, b := range set.Blocks {
+			b.IsValid = true
+			b.Tag = uint64(i * 64)
+			directory.Insert(b)
+			directory.Visit(b)
+		}
+	})
+
+	It("should make new lines the next victim", func() {
+		set.Blocks[2].Tag = 0x1000
+		directory.Insert(set.Blocks[2])
+
+		Expect(policy.FindVictim(set)).To(BeIdenticalTo(set.Blocks[2]))
+	})
+
+	It("should keep the lines that are reused", func() {
+		set.Blocks[2].Tag = 0x1000
+		directory.Insert(set.Blocks[2])
+		directory.Visit(set.Blocks[2])
+
+		Expect(set.LRUQueue[3]).To(BeIdenticalTo(set.Blocks[2]))
+	})
+
+	It("should insert some of the new lines at the MRU position", func() {
+		policy.insertCount = bipThrottle - 1
+
+		set.Blocks[2].Tag = 0x1000
+		directory.Insert(set.Blocks[2])
+
+		Expect(set.LRUQueue[3]).To(BeIdenticalTo(set.Blocks[2]))
+	})
+})
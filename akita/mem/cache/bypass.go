@@ -0,0 +1,37 @@
+package cache
+
+import "github.com/sarchlab/akita/v3/mem/vm"
+
+// BypassPolicy is an LRU policy that does not allocate blocks for most of the
+// lines that miss a full set. Such lines are sent to the low module without
+// replacing the lines that are already in the cache, so that a streaming
+// access pattern does not flush the working set out of the cache. One in
+// every 32 misses to a full set allocates a block at the most recently used
+// position, so that the cache can adapt when the working set changes. Misses
+// to a set that still has an empty block always allocate the block.
+type BypassPolicy struct {
+	LRUVictimFinder
+
+	missCount int
+}
+
+// NewBypassPolicy creates a new BypassPolicy.
+func NewBypassPolicy() *BypassPolicy {
+	return &BypassPolicy{}
+}
+
+// ShouldBypass returns true if the set has no empty block, unless the miss is
+// one of the few misses that allocate a block.
+func (p *BypassPolicy) ShouldBypass(set *Set, _ vm.PID, _ uint64) bool {
+	if findInvalidBlock(set) != nil {
+		return false
+	}
+
+	p.missCount++
+	if p.missCount >= bipThrottle {
+		p.missCount = 0
+		return false
+	}
+
+	return true
+}
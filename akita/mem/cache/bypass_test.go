@@ -0,0 +1,68 @@
+package cache
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Bypass Policy", func() {
+	var (
+		policy    *BypassPolicy
+		directory *DirectoryImpl
+		set       *Set
+	)
+
+	BeforeEach(func() {
+		policy = NewBypassPolicy()
+		directory = NewDirectory(1, 4, 64, policy)
+		set = &directory.Sets[0]
+	})
+
+	fill := func() {
+		for i, b := range set.Blocks {
+			b.IsValid = true
+			b.Tag = uint64(i * 64)
+			directory.Insert(b)
+		}
+	}
+
+	It("should not bypass if the set has an empty block", func() {
+		Expect(directory.ShouldBypass(1, 0x1000)).To(BeFalse())
+	})
+
+	It("should bypass if the set is full", func() {
+		fill()
+
+		Expect(directory.ShouldBypass(1, 0x1000)).To(BeTrue())
+	})
+
+	It("should bypass if the empty block is locked", func() {
+		fill()
+		set.Blocks[1].IsValid = false
+		set.Blocks[1].IsLocked = true
+
+		Expect(directory.ShouldBypass(1, 0x1000)).To(BeTrue())
+	})
+
+	It("should allocate some of the lines that miss a full set", func() {
+		fill()
+
+		numAllocated := 0
+		for i := 0; i < 64; i++ {
+			if !directory.ShouldBypass(1, uint64(0x1000+i*64)) {
+				numAllocated++
+			}
+		}
+
+		Expect(numAllocated).To(Equal(2))
+	})
+
+	It("should insert at the most recently used position", func() {
+		fill()
+
+		set.Blocks[0].Tag = 0x1000
+		directory.Insert(set.Blocks[0])
+
+		Expect(set.LRUQueue[3]).To(BeIdenticalTo(set.Blocks[0]))
+	})
+})
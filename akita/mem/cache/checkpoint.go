@@ -0,0 +1,142 @@
+package cache
+
+import (
+	"fmt"
+
+	"github.com/sarchlab/akita/v3/mem/vm"
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+type blockState struct {
+	PID          vm.PID
+	Tag          uint64
+	IsValid      bool
+	IsDirty      bool
+	DirtyMask    []bool
+	SectorValid  []bool
+	IsPrefetched bool
+}
+
+type setState struct {
+	Blocks   []blockState
+	LRUQueue []int
+}
+
+// SaveDirectoryAs saves the blocks of the directory in the checkpoint under
+// the key. Only the DirectoryImpl with the LRU replacement policy can be
+// saved. The blocks cannot be locked or being read, so the cache has to be
+// drained before saving.
+func SaveDirectoryAs(cp *sim.Checkpoint, key string, d Directory) error {
+	impl, err := checkpointableDirectory(d)
+	if err != nil {
+		return err
+	}
+
+	state := make([]setState, 0, len(impl.Sets))
+	for _, set := range impl.Sets {
+		s := setState{}
+
+		for _, block := range set.Blocks {
+			if !isEvictable(block) {
+				return fmt.Errorf("block %d of set %d is in use",
+					block.WayID, block.SetID)
+			}
+
+			s.Blocks = append(s.Blocks, blockState{
+				PID:          block.PID,
+				Tag:          block.Tag,
+				IsValid:      block.IsValid,
+				IsDirty:      block.IsDirty,
+				DirtyMask:    block.DirtyMask,
+				SectorValid:  block.SectorValid,
+				IsPrefetched: block.IsPrefetched,
+			})
+		}
+
+		for _, block := range set.LRUQueue {
+			s.LRUQueue = append(s.LRUQueue, block.WayID)
+		}
+
+		state = append(state, s)
+	}
+
+	return cp.Put(key, state)
+}
+
+// LoadDirectoryAs restores the blocks of the directory from the checkpoint.
+func LoadDirectoryAs(cp *sim.Checkpoint, key string, d Directory) error {
+	impl, err := checkpointableDirectory(d)
+	if err != nil {
+		return err
+	}
+
+	state := []setState{}
+	err = cp.Get(key, &state)
+	if err != nil {
+		return err
+	}
+
+	if len(state) != impl.NumSets {
+		return fmt.Errorf("directory %s does not match the checkpoint", key)
+	}
+
+	impl.Reset()
+
+	for i, s := range state {
+		set := &impl.Sets[i]
+		if len(s.Blocks) != impl.NumWays || len(s.LRUQueue) != impl.NumWays {
+			return fmt.Errorf("directory %s does not match the checkpoint",
+				key)
+		}
+
+		for j, bs := range s.Blocks {
+			block := set.Blocks[j]
+			block.PID = bs.PID
+			block.Tag = bs.Tag
+			block.IsValid = bs.IsValid
+			block.IsDirty = bs.IsDirty
+			block.DirtyMask = bs.DirtyMask
+			block.SectorValid = bs.SectorValid
+			block.IsPrefetched = bs.IsPrefetched
+		}
+
+		set.LRUQueue = set.LRUQueue[:0]
+		for _, wayID := range s.LRUQueue {
+			set.LRUQueue = append(set.LRUQueue, set.Blocks[wayID])
+		}
+	}
+
+	return nil
+}
+
+func checkpointableDirectory(d Directory) (*DirectoryImpl, error) {
+	impl, ok := d.(*DirectoryImpl)
+	if !ok {
+		return nil, fmt.Errorf("directory %T cannot be checkpointed", d)
+	}
+
+	if _, ok := impl.victimFinder.(*LRUVictimFinder); !ok {
+		return nil, fmt.Errorf("replacement policy %T cannot be checkpointed",
+			impl.victimFinder)
+	}
+
+	return impl, nil
+}
+
+// CheckCheckpointable returns an error if a cache with the directory and the
+// prefetcher cannot be saved in a checkpoint. Only the DirectoryImpl with the
+// LRU replacement policy can be saved, and the cache must have either no
+// prefetcher or the stateless next-line prefetcher.
+func CheckCheckpointable(d Directory, p Prefetcher) error {
+	_, err := checkpointableDirectory(d)
+	if err != nil {
+		return err
+	}
+
+	switch p.(type) {
+	case nil, *NextLinePrefetcher:
+		return nil
+	default:
+		return fmt.Errorf("prefetcher %T cannot be checkpointed", p)
+	}
+}
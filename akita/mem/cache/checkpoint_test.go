@@ -0,0 +1,86 @@
+package cache
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+type directoryHolder struct {
+	directory Directory
+}
+
+func (h *directoryHolder) Name() string {
+	return "Holder"
+}
+
+func (h *directoryHolder) SaveState(cp *sim.Checkpoint) error {
+	return SaveDirectoryAs(cp, "Holder.Directory", h.directory)
+}
+
+func (h *directoryHolder) LoadState(cp *sim.Checkpoint) error {
+	return LoadDirectoryAs(cp, "Holder.Directory", h.directory)
+}
+
+var _ = Describe("Directory Checkpoint", func() {
+	var (
+		directory    *DirectoryImpl
+		checkpointer *sim.Checkpointer
+	)
+
+	BeforeEach(func() {
+		directory = NewDirectory(4, 2, 64, NewLRUVictimFinder())
+		checkpointer = sim.NewCheckpointer(sim.NewSerialEngine())
+		checkpointer.Register(&directoryHolder{directory: directory})
+	})
+
+	It("should restore the blocks and the LRU order", func() {
+		block := directory.Sets[1].Blocks[0]
+		block.PID = 2
+		block.Tag = 0x40
+		block.IsValid = true
+		block.IsDirty = true
+		block.DirtyMask = []bool{true, false}
+		directory.Insert(block)
+
+		buf := bytes.NewBuffer(nil)
+		Expect(checkpointer.Save(buf)).To(Succeed())
+
+		directory.Reset()
+		Expect(checkpointer.Restore(buf)).To(Succeed())
+
+		Expect(directory.Lookup(2, 0x40)).To(
+			BeIdenticalTo(directory.Sets[1].Blocks[0]))
+		Expect(directory.Sets[1].Blocks[0].IsDirty).To(BeTrue())
+		Expect(directory.Sets[1].Blocks[0].DirtyMask).To(
+			Equal([]bool{true, false}))
+		Expect(directory.FindVictim(0x40)).To(
+			BeIdenticalTo(directory.Sets[1].Blocks[1]))
+	})
+
+	It("should not save a locked block", func() {
+		directory.Sets[0].Blocks[0].IsLocked = true
+
+		Expect(checkpointer.Save(bytes.NewBuffer(nil))).NotTo(Succeed())
+	})
+
+	It("should not save a policy that cannot be checkpointed", func() {
+		directory = NewDirectory(4, 2, 64, NewRandomPolicy(0))
+		checkpointer = sim.NewCheckpointer(sim.NewSerialEngine())
+		checkpointer.Register(&directoryHolder{directory: directory})
+
+		Expect(checkpointer.Save(bytes.NewBuffer(nil))).NotTo(Succeed())
+	})
+
+	It("should check if a configuration can be checkpointed", func() {
+		Expect(CheckCheckpointable(directory, nil)).To(Succeed())
+		Expect(CheckCheckpointable(directory,
+			NewNextLinePrefetcher(6, 1))).To(Succeed())
+		Expect(CheckCheckpointable(directory,
+			NewStridePrefetcher(6, 1))).NotTo(Succeed())
+		Expect(CheckCheckpointable(
+			NewDirectory(4, 2, 64, NewRandomPolicy(0)), nil)).NotTo(Succeed())
+	})
+})
@@ -0,0 +1,172 @@
+package cache
+
+import (
+	"github.com/sarchlab/akita/v3/mem/vm"
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+var invalidateReqByteOverhead = 12
+var invalidateRspByteOverhead = 4
+
+// CoherentFetchInfo is attached to the Info field of the read requests that a
+// coherent cache sends to fetch a cache line from a coherence directory.
+type CoherentFetchInfo struct {
+	// Exclusive is set when the cache fetches the line to write it. The
+	// directory invalidates all the other copies before responding.
+	Exclusive bool
+}
+
+// InvalidateReq is sent from a coherence directory to a cache to remove a
+// cache line from the cache.
+type InvalidateReq struct {
+	sim.MsgMeta
+	PID     vm.PID
+	Address uint64
+}
+
+// Meta returns the meta data associated with the message.
+func (r *InvalidateReq) Meta() *sim.MsgMeta {
+	return &r.MsgMeta
+}
+
+// InvalidateReqBuilder can build invalidate requests.
+type InvalidateReqBuilder struct {
+	sendTime sim.VTimeInSec
+	src, dst sim.Port
+	pid      vm.PID
+	address  uint64
+}
+
+// WithSendTime sets the send time of the message to build.
+func (b InvalidateReqBuilder) WithSendTime(
+	t sim.VTimeInSec,
+) InvalidateReqBuilder {
+	b.sendTime = t
+	return b
+}
+
+// WithSrc sets the source of the message to build.
+func (b InvalidateReqBuilder) WithSrc(src sim.Port) InvalidateReqBuilder {
+	b.src = src
+	return b
+}
+
+// WithDst sets the destination of the message to build.
+func (b InvalidateReqBuilder) WithDst(dst sim.Port) InvalidateReqBuilder {
+	b.dst = dst
+	return b
+}
+
+// WithPID sets the PID of the cache line to invalidate.
+func (b InvalidateReqBuilder) WithPID(pid vm.PID) InvalidateReqBuilder {
+	b.pid = pid
+	return b
+}
+
+// WithAddress sets the address of the cache line to invalidate.
+func (b InvalidateReqBuilder) WithAddress(addr uint64) InvalidateReqBuilder {
+	b.address = addr
+	return b
+}
+
+// Build creates a new InvalidateReq.
+func (b InvalidateReqBuilder) Build() *InvalidateReq {
+	r := &InvalidateReq{}
+	r.ID = sim.IDGeneratorFor(b.src).Generate()
+	r.Src = b.src
+	r.Dst = b.dst
+	r.SendTime = b.sendTime
+	r.TrafficBytes = invalidateReqByteOverhead
+	r.PID = b.pid
+	r.Address = b.address
+	return r
+}
+
+// InvalidateRsp is sent from a cache to a coherence directory after a cache
+// line is removed from the cache. If the cache line was dirty, the response
+// carries the whole cache line together with the dirty mask so that the
+// directory can write the data back.
+type InvalidateRsp struct {
+	sim.MsgMeta
+	RspTo     string
+	Data      []byte
+	DirtyMask []bool
+}
+
+// Meta returns the meta data associated with the message.
+func (r *InvalidateRsp) Meta() *sim.MsgMeta {
+	return &r.MsgMeta
+}
+
+// GetRspTo returns the ID of the request that the respond is responding to.
+func (r *InvalidateRsp) GetRspTo() string {
+	return r.RspTo
+}
+
+// HasData returns true if the response carries dirty data.
+func (r *InvalidateRsp) HasData() bool {
+	return r.Data != nil
+}
+
+// InvalidateRspBuilder can build invalidate responds.
+type InvalidateRspBuilder struct {
+	sendTime  sim.VTimeInSec
+	src, dst  sim.Port
+	rspTo     string
+	data      []byte
+	dirtyMask []bool
+}
+
+// WithSendTime sets the send time of the message to build.
+func (b InvalidateRspBuilder) WithSendTime(
+	t sim.VTimeInSec,
+) InvalidateRspBuilder {
+	b.sendTime = t
+	return b
+}
+
+// WithSrc sets the source of the message to build.
+func (b InvalidateRspBuilder) WithSrc(src sim.Port) InvalidateRspBuilder {
+	b.src = src
+	return b
+}
+
+// WithDst sets the destination of the message to build.
+func (b InvalidateRspBuilder) WithDst(dst sim.Port) InvalidateRspBuilder {
+	b.dst = dst
+	return b
+}
+
+// WithRspTo sets ID of the request that the respond to build is replying to.
+func (b InvalidateRspBuilder) WithRspTo(id string) InvalidateRspBuilder {
+	b.rspTo = id
+	return b
+}
+
+// WithData sets the dirty cache line data carried by the respond.
+func (b InvalidateRspBuilder) WithData(data []byte) InvalidateRspBuilder {
+	b.data = data
+	return b
+}
+
+// WithDirtyMask sets the dirty mask of the data carried by the respond.
+func (b InvalidateRspBuilder) WithDirtyMask(
+	mask []bool,
+) InvalidateRspBuilder {
+	b.dirtyMask = mask
+	return b
+}
+
+// Build creates a new InvalidateRsp.
+func (b InvalidateRspBuilder) Build() *InvalidateRsp {
+	r := &InvalidateRsp{}
+	r.ID = sim.IDGeneratorFor(b.src).Generate()
+	r.Src = b.src
+	r.Dst = b.dst
+	r.SendTime = b.sendTime
+	r.TrafficBytes = len(b.data) + invalidateRspByteOverhead
+	r.RspTo = b.rspTo
+	r.Data = b.data
+	r.DirtyMask = b.dirtyMask
+	return r
+}
@@ -0,0 +1,83 @@
+package coherence
+
+import (
+	"github.com/sarchlab/akita/v3/mem/mem"
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+// A Builder can build coherence directories.
+type Builder struct {
+	engine          sim.Engine
+	freq            sim.Freq
+	lowModuleFinder mem.LowModuleFinder
+	log2BlockSize   uint64
+	numReqPerCycle  int
+}
+
+// MakeBuilder creates a new builder with default configurations.
+func MakeBuilder() Builder {
+	return Builder{
+		freq:           1 * sim.GHz,
+		log2BlockSize:  6,
+		numReqPerCycle: 4,
+	}
+}
+
+// WithEngine sets the engine to be used by the directory.
+func (b Builder) WithEngine(engine sim.Engine) Builder {
+	b.engine = engine
+	return b
+}
+
+// WithFreq sets the frequency to be used by the directory.
+func (b Builder) WithFreq(freq sim.Freq) Builder {
+	b.freq = freq
+	return b
+}
+
+// WithLowModuleFinder sets the LowModuleFinder that finds the memory
+// controllers that hold the data.
+func (b Builder) WithLowModuleFinder(f mem.LowModuleFinder) Builder {
+	b.lowModuleFinder = f
+	return b
+}
+
+// WithLog2BlockSize sets the cache line size as the power of 2. It must match
+// the cache line size of the coherent caches.
+func (b Builder) WithLog2BlockSize(n uint64) Builder {
+	b.log2BlockSize = n
+	return b
+}
+
+// WithNumReqPerCycle sets the number of messages that the directory can
+// receive and send in each cycle.
+func (b Builder) WithNumReqPerCycle(n int) Builder {
+	b.numReqPerCycle = n
+	return b
+}
+
+// Build creates a new directory.
+func (b Builder) Build(name string) *Directory {
+	d := new(Directory)
+	d.TickingComponent = sim.NewTickingComponent(name, b.engine, b.freq, d)
+
+	d.lowModuleFinder = b.lowModuleFinder
+	d.log2BlockSize = b.log2BlockSize
+	d.numReqPerCycle = b.numReqPerCycle
+	d.entries = make(map[lineID]*entry)
+
+	d.topPort = sim.NewLimitNumMsgPort(d,
+		b.numReqPerCycle*2, name+".TopPort")
+	d.AddPort("Top", d.topPort)
+
+	d.bottomPort = sim.NewLimitNumMsgPort(d,
+		b.numReqPerCycle*2, name+".BottomPort")
+	d.AddPort("Bottom", d.bottomPort)
+
+	d.topSender = sim.NewBufferedSender(d.topPort,
+		sim.NewBuffer(name+".TopSenderBuffer", b.numReqPerCycle*4))
+	d.bottomSender = sim.NewBufferedSender(d.bottomPort,
+		sim.NewBuffer(name+".BottomSenderBuffer", b.numReqPerCycle*4))
+
+	return d
+}
@@ -0,0 +1,491 @@
+package coherence
+
+import (
+	"log"
+	"reflect"
+
+	"github.com/sarchlab/akita/v3/mem/cache"
+	"github.com/sarchlab/akita/v3/mem/mem"
+	"github.com/sarchlab/akita/v3/mem/vm"
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/akita/v3/tracing"
+)
+
+type lineID struct {
+	pid  vm.PID
+	addr uint64
+}
+
+// An entry records the caches that hold a copy of a cache line. The owner, if
+// not nil, is always one of the sharers.
+type entry struct {
+	sharers     []sim.Port
+	owner       sim.Port
+	activeTrans *transaction
+}
+
+func (e *entry) isSharer(p sim.Port) bool {
+	for _, s := range e.sharers {
+		if s == p {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (e *entry) addSharer(p sim.Port) {
+	if !e.isSharer(p) {
+		e.sharers = append(e.sharers, p)
+	}
+}
+
+func (e *entry) removeSharer(p sim.Port) {
+	for i, s := range e.sharers {
+		if s == p {
+			e.sharers = append(e.sharers[:i], e.sharers[i+1:]...)
+			return
+		}
+	}
+}
+
+type transaction struct {
+	read  *mem.ReadReq
+	write *mem.WriteReq
+	line  lineID
+
+	started   bool
+	fromOwner bool
+
+	invTargets         []sim.Port
+	pendingInvs        []*cache.InvalidateReq
+	writebacks         []*mem.WriteReq
+	inflightWritebacks []*mem.WriteReq
+
+	memReq mem.AccessReq
+	memRsp sim.Msg
+}
+
+func (t *transaction) req() mem.AccessReq {
+	if t.read != nil {
+		return t.read
+	}
+
+	return t.write
+}
+
+func (t *transaction) requester() sim.Port {
+	return t.req().Meta().Src
+}
+
+func (t *transaction) isExclusiveFetch() bool {
+	info, ok := t.read.Info.(*cache.CoherentFetchInfo)
+	return ok && info.Exclusive
+}
+
+func (t *transaction) isCoherentFetch() bool {
+	_, ok := t.read.Info.(*cache.CoherentFetchInfo)
+	return ok
+}
+
+// A Directory keeps the caches that share a memory coherent.
+type Directory struct {
+	*sim.TickingComponent
+
+	topPort    sim.Port
+	bottomPort sim.Port
+
+	topSender    sim.BufferedSender
+	bottomSender sim.BufferedSender
+
+	lowModuleFinder mem.LowModuleFinder
+	log2BlockSize   uint64
+	numReqPerCycle  int
+
+	entries      map[lineID]*entry
+	transactions []*transaction
+}
+
+// SetLowModuleFinder sets the LowModuleFinder used by the directory.
+func (d *Directory) SetLowModuleFinder(lmf mem.LowModuleFinder) {
+	d.lowModuleFinder = lmf
+}
+
+// Tick updates the internal states of the directory.
+func (d *Directory) Tick(now sim.VTimeInSec) bool {
+	madeProgress := false
+
+	for i := 0; i < d.numReqPerCycle; i++ {
+		madeProgress = d.topSender.Tick(now) || madeProgress
+		madeProgress = d.bottomSender.Tick(now) || madeProgress
+		madeProgress = d.parseBottom(now) || madeProgress
+		madeProgress = d.parseTop(now) || madeProgress
+	}
+
+	madeProgress = d.startTransactions() || madeProgress
+	madeProgress = d.progressTransactions(now) || madeProgress
+
+	return madeProgress
+}
+
+func (d *Directory) parseTop(now sim.VTimeInSec) bool {
+	msg := d.topPort.Retrieve(now)
+	if msg == nil {
+		return false
+	}
+
+	switch msg := msg.(type) {
+	case *mem.ReadReq:
+		d.acceptReq(&transaction{read: msg})
+	case *mem.WriteReq:
+		d.acceptReq(&transaction{write: msg})
+	case *cache.InvalidateRsp:
+		d.processInvalidateRsp(msg)
+	default:
+		log.Panicf("cannot process message of type %s", reflect.TypeOf(msg))
+	}
+
+	return true
+}
+
+func (d *Directory) acceptReq(t *transaction) {
+	req := t.req()
+	t.line = lineID{
+		pid:  req.GetPID(),
+		addr: d.cacheLineAddr(req.GetAddress()),
+	}
+
+	e := d.getEntry(t.line)
+	if t.write != nil && e.owner == t.requester() {
+		t.fromOwner = true
+		t.started = true
+	}
+
+	d.transactions = append(d.transactions, t)
+
+	tracing.TraceReqReceive(req, d)
+}
+
+func (d *Directory) processInvalidateRsp(rsp *cache.InvalidateRsp) {
+	for _, t := range d.transactions {
+		for i, inv := range t.pendingInvs {
+			if inv.ID != rsp.RspTo {
+				continue
+			}
+
+			t.pendingInvs = append(t.pendingInvs[:i], t.pendingInvs[i+1:]...)
+			tracing.TraceReqFinalize(inv, d)
+
+			if rsp.HasData() {
+				d.addWriteback(t, rsp)
+			}
+
+			return
+		}
+	}
+
+	panic("invalidation not found")
+}
+
+func (d *Directory) addWriteback(t *transaction, rsp *cache.InvalidateRsp) {
+	write := mem.WriteReqBuilder{}.
+		WithSrc(d.bottomPort).
+		WithDst(d.lowModuleFinder.Find(t.line.addr)).
+		WithPID(t.line.pid).
+		WithAddress(t.line.addr).
+		WithData(rsp.Data).
+		WithDirtyMask(rsp.DirtyMask).
+		Build()
+	t.writebacks = append(t.writebacks, write)
+}
+
+func (d *Directory) parseBottom(now sim.VTimeInSec) bool {
+	msg := d.bottomPort.Retrieve(now)
+	if msg == nil {
+		return false
+	}
+
+	rsp, ok := msg.(mem.AccessRsp)
+	if !ok {
+		log.Panicf("cannot process message of type %s", reflect.TypeOf(msg))
+	}
+
+	for _, t := range d.transactions {
+		if t.memReq != nil && t.memReq.Meta().ID == rsp.GetRspTo() {
+			t.memRsp = rsp
+			tracing.TraceReqFinalize(t.memReq, d)
+
+			return true
+		}
+
+		for i, w := range t.inflightWritebacks {
+			if w.ID == rsp.GetRspTo() {
+				t.inflightWritebacks = append(
+					t.inflightWritebacks[:i], t.inflightWritebacks[i+1:]...)
+				tracing.TraceReqFinalize(w, d)
+
+				return true
+			}
+		}
+	}
+
+	panic("memory request not found")
+}
+
+func (d *Directory) startTransactions() bool {
+	madeProgress := false
+
+	for _, t := range d.transactions {
+		if t.started {
+			continue
+		}
+
+		e := d.getEntry(t.line)
+		if e.activeTrans != nil {
+			continue
+		}
+
+		e.activeTrans = t
+		t.started = true
+		t.invTargets = d.invalidationTargets(t, e)
+		madeProgress = true
+	}
+
+	return madeProgress
+}
+
+func (d *Directory) invalidationTargets(
+	t *transaction,
+	e *entry,
+) []sim.Port {
+	requester := t.requester()
+
+	if t.read != nil && !t.isExclusiveFetch() {
+		if e.owner != nil && e.owner != requester {
+			return []sim.Port{e.owner}
+		}
+
+		return nil
+	}
+
+	var targets []sim.Port
+	for _, s := range e.sharers {
+		if s != requester {
+			targets = append(targets, s)
+		}
+	}
+
+	return targets
+}
+
+func (d *Directory) progressTransactions(now sim.VTimeInSec) bool {
+	madeProgress := false
+
+	for i := 0; i < len(d.transactions); {
+		t := d.transactions[i]
+		if !t.started {
+			i++
+			continue
+		}
+
+		madeProgress = d.progress(now, t) || madeProgress
+
+		if i < len(d.transactions) && d.transactions[i] == t {
+			i++
+		}
+	}
+
+	return madeProgress
+}
+
+func (d *Directory) progress(now sim.VTimeInSec, t *transaction) bool {
+	switch {
+	case len(t.invTargets) > 0:
+		return d.sendInvalidation(now, t)
+	case len(t.pendingInvs) > 0:
+		return false
+	case len(t.writebacks) > 0:
+		return d.sendWriteback(now, t)
+	case len(t.inflightWritebacks) > 0:
+		return false
+	case t.memReq == nil:
+		return d.accessMemory(now, t)
+	case t.memRsp == nil:
+		return false
+	default:
+		return d.respond(now, t)
+	}
+}
+
+func (d *Directory) sendInvalidation(now sim.VTimeInSec, t *transaction) bool {
+	if !d.topSender.CanSend(1) {
+		return false
+	}
+
+	inv := cache.InvalidateReqBuilder{}.
+		WithSendTime(now).
+		WithSrc(d.topPort).
+		WithDst(t.invTargets[0]).
+		WithPID(t.line.pid).
+		WithAddress(t.line.addr).
+		Build()
+	d.topSender.Send(inv)
+
+	t.invTargets = t.invTargets[1:]
+	t.pendingInvs = append(t.pendingInvs, inv)
+
+	tracing.TraceReqInitiate(inv, d, tracing.MsgIDAtReceiver(t.req(), d))
+
+	return true
+}
+
+func (d *Directory) sendWriteback(now sim.VTimeInSec, t *transaction) bool {
+	if !d.bottomSender.CanSend(1) {
+		return false
+	}
+
+	write := t.writebacks[0]
+	write.SendTime = now
+	d.bottomSender.Send(write)
+
+	t.writebacks = t.writebacks[1:]
+	t.inflightWritebacks = append(t.inflightWritebacks, write)
+
+	tracing.TraceReqInitiate(write, d, tracing.MsgIDAtReceiver(t.req(), d))
+
+	return true
+}
+
+func (d *Directory) accessMemory(now sim.VTimeInSec, t *transaction) bool {
+	if !d.bottomSender.CanSend(1) {
+		return false
+	}
+
+	dst := d.lowModuleFinder.Find(t.req().GetAddress())
+
+	if t.read != nil {
+		t.memReq = mem.ReadReqBuilder{}.
+			WithSendTime(now).
+			WithSrc(d.bottomPort).
+			WithDst(dst).
+			WithPID(t.read.PID).
+			WithAddress(t.read.Address).
+			WithByteSize(t.read.AccessByteSize).
+			Build()
+	} else {
+		t.memReq = mem.WriteReqBuilder{}.
+			WithSendTime(now).
+			WithSrc(d.bottomPort).
+			WithDst(dst).
+			WithPID(t.write.PID).
+			WithAddress(t.write.Address).
+			WithData(t.write.Data).
+			WithDirtyMask(t.write.DirtyMask).
+			Build()
+	}
+
+	d.bottomSender.Send(t.memReq)
+
+	tracing.TraceReqInitiate(t.memReq, d, tracing.MsgIDAtReceiver(t.req(), d))
+
+	return true
+}
+
+func (d *Directory) respond(now sim.VTimeInSec, t *transaction) bool {
+	if !d.topSender.CanSend(1) {
+		return false
+	}
+
+	if t.read != nil {
+		rsp := mem.DataReadyRspBuilder{}.
+			WithSendTime(now).
+			WithSrc(d.topPort).
+			WithDst(t.read.Src).
+			WithRspTo(t.read.ID).
+			WithData(t.memRsp.(*mem.DataReadyRsp).Data).
+			Build()
+		d.topSender.Send(rsp)
+	} else {
+		rsp := mem.WriteDoneRspBuilder{}.
+			WithSendTime(now).
+			WithSrc(d.topPort).
+			WithDst(t.write.Src).
+			WithRspTo(t.write.ID).
+			Build()
+		d.topSender.Send(rsp)
+	}
+
+	d.updateEntry(t)
+	d.removeTransaction(t)
+
+	tracing.TraceReqComplete(t.req(), d)
+
+	return true
+}
+
+func (d *Directory) updateEntry(t *transaction) {
+	e := d.entries[t.line]
+	requester := t.requester()
+
+	switch {
+	case t.fromOwner:
+		if e.owner == requester {
+			e.owner = nil
+		}
+		e.removeSharer(requester)
+	case t.write != nil:
+		wasSharer := e.isSharer(requester)
+		e.sharers = nil
+		e.owner = nil
+		if wasSharer {
+			e.addSharer(requester)
+		}
+	case t.isExclusiveFetch():
+		e.sharers = []sim.Port{requester}
+		e.owner = requester
+	case t.isCoherentFetch():
+		if e.owner != nil && e.owner != requester {
+			e.removeSharer(e.owner)
+			e.owner = nil
+		}
+		e.addSharer(requester)
+	default:
+		if e.owner != nil {
+			e.removeSharer(e.owner)
+			e.owner = nil
+		}
+	}
+
+	if e.activeTrans == t {
+		e.activeTrans = nil
+	}
+
+	if e.activeTrans == nil && e.owner == nil && len(e.sharers) == 0 {
+		delete(d.entries, t.line)
+	}
+}
+
+func (d *Directory) removeTransaction(t *transaction) {
+	for i, trans := range d.transactions {
+		if trans == t {
+			d.transactions = append(d.transactions[:i], d.transactions[i+1:]...)
+			return
+		}
+	}
+
+	panic("transaction not found")
+}
+
+func (d *Directory) getEntry(line lineID) *entry {
+	e, found := d.entries[line]
+	if !found {
+		e = &entry{}
+		d.entries[line] = e
+	}
+
+	return e
+}
+
+func (d *Directory) cacheLineAddr(addr uint64) uint64 {
+	return addr >> d.log2BlockSize << d.log2BlockSize
+}
@@ -0,0 +1,309 @@
+package coherence
+
+import (
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/akita/v3/mem/cache"
+	"github.com/sarchlab/akita/v3/mem/mem"
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+var _ = Describe("Directory", func() {
+	var (
+		mockCtrl        *gomock.Controller
+		topPort         *MockPort
+		bottomPort      *MockPort
+		topSender       *MockBufferedSender
+		bottomSender    *MockBufferedSender
+		lowModuleFinder *MockLowModuleFinder
+		dramPort        *MockPort
+		cache1          *MockPort
+		cache2          *MockPort
+		d               *Directory
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		topPort = NewMockPort(mockCtrl)
+		bottomPort = NewMockPort(mockCtrl)
+		topSender = NewMockBufferedSender(mockCtrl)
+		bottomSender = NewMockBufferedSender(mockCtrl)
+		lowModuleFinder = NewMockLowModuleFinder(mockCtrl)
+		dramPort = NewMockPort(mockCtrl)
+		cache1 = NewMockPort(mockCtrl)
+		cache2 = NewMockPort(mockCtrl)
+
+		d = MakeBuilder().
+			WithLowModuleFinder(lowModuleFinder).
+			Build("Directory")
+		d.topPort = topPort
+		d.bottomPort = bottomPort
+		d.topSender = topSender
+		d.bottomSender = bottomSender
+
+		lowModuleFinder.EXPECT().Find(gomock.Any()).Return(dramPort).AnyTimes()
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	fetch := func(src sim.Port, addr uint64, exclusive bool) *mem.ReadReq {
+		return mem.ReadReqBuilder{}.
+			WithSrc(src).
+			WithDst(topPort).
+			WithPID(1).
+			WithAddress(addr).
+			WithByteSize(64).
+			WithInfo(&cache.CoherentFetchInfo{Exclusive: exclusive}).
+			Build()
+	}
+
+	Context("parse top", func() {
+		It("should do nothing if there is no message", func() {
+			topPort.EXPECT().Retrieve(gomock.Any()).Return(nil)
+
+			madeProgress := d.parseTop(10)
+
+			Expect(madeProgress).To(BeFalse())
+		})
+
+		It("should accept read requests", func() {
+			read := fetch(cache1, 0x104, false)
+			topPort.EXPECT().Retrieve(gomock.Any()).Return(read)
+
+			madeProgress := d.parseTop(10)
+
+			Expect(madeProgress).To(BeTrue())
+			Expect(d.transactions).To(HaveLen(1))
+			Expect(d.transactions[0].read).To(BeIdenticalTo(read))
+			Expect(d.transactions[0].line).To(Equal(lineID{1, 0x100}))
+			Expect(d.transactions[0].started).To(BeFalse())
+		})
+
+		It("should start writebacks from the owner immediately", func() {
+			d.entries[lineID{1, 0x100}] = &entry{
+				sharers: []sim.Port{cache1},
+				owner:   cache1,
+			}
+			write := mem.WriteReqBuilder{}.
+				WithSrc(cache1).
+				WithPID(1).
+				WithAddress(0x100).
+				WithData(make([]byte, 64)).
+				Build()
+			topPort.EXPECT().Retrieve(gomock.Any()).Return(write)
+
+			d.parseTop(10)
+
+			Expect(d.transactions[0].fromOwner).To(BeTrue())
+			Expect(d.transactions[0].started).To(BeTrue())
+		})
+
+		It("should turn dirty invalidation responses into writebacks", func() {
+			inv := cache.InvalidateReqBuilder{}.
+				WithSrc(topPort).
+				WithDst(cache2).
+				WithPID(1).
+				WithAddress(0x100).
+				Build()
+			t := &transaction{
+				read:        fetch(cache1, 0x100, true),
+				line:        lineID{1, 0x100},
+				started:     true,
+				pendingInvs: []*cache.InvalidateReq{inv},
+			}
+			d.transactions = append(d.transactions, t)
+			rsp := cache.InvalidateRspBuilder{}.
+				WithSrc(cache2).
+				WithDst(topPort).
+				WithRspTo(inv.ID).
+				WithData(make([]byte, 64)).
+				WithDirtyMask(make([]bool, 64)).
+				Build()
+			topPort.EXPECT().Retrieve(gomock.Any()).Return(rsp)
+
+			d.parseTop(10)
+
+			Expect(t.pendingInvs).To(BeEmpty())
+			Expect(t.writebacks).To(HaveLen(1))
+			Expect(t.writebacks[0].Address).To(Equal(uint64(0x100)))
+			Expect(t.writebacks[0].Dst).To(BeIdenticalTo(dramPort))
+		})
+	})
+
+	Context("start transactions", func() {
+		It("should invalidate the owner for shared fetches", func() {
+			d.entries[lineID{1, 0x100}] = &entry{
+				sharers: []sim.Port{cache2},
+				owner:   cache2,
+			}
+			d.acceptReq(&transaction{read: fetch(cache1, 0x100, false)})
+
+			madeProgress := d.startTransactions()
+
+			Expect(madeProgress).To(BeTrue())
+			Expect(d.transactions[0].invTargets).To(ConsistOf(cache2))
+		})
+
+		It("should invalidate all the other sharers for exclusive fetches",
+			func() {
+				cache3 := NewMockPort(mockCtrl)
+				d.entries[lineID{1, 0x100}] = &entry{
+					sharers: []sim.Port{cache1, cache2, cache3},
+				}
+				d.acceptReq(&transaction{read: fetch(cache1, 0x100, true)})
+
+				d.startTransactions()
+
+				Expect(d.transactions[0].invTargets).
+					To(ConsistOf(cache2, cache3))
+			})
+
+		It("should serialize the transactions to the same line", func() {
+			d.acceptReq(&transaction{read: fetch(cache1, 0x100, true)})
+			d.acceptReq(&transaction{read: fetch(cache2, 0x120, true)})
+			d.acceptReq(&transaction{read: fetch(cache2, 0x200, true)})
+
+			d.startTransactions()
+
+			Expect(d.transactions[0].started).To(BeTrue())
+			Expect(d.transactions[1].started).To(BeFalse())
+			Expect(d.transactions[2].started).To(BeTrue())
+		})
+	})
+
+	Context("progress", func() {
+		var (
+			read *mem.ReadReq
+			t    *transaction
+		)
+
+		BeforeEach(func() {
+			read = fetch(cache1, 0x100, true)
+			t = &transaction{read: read}
+			d.acceptReq(t)
+			d.startTransactions()
+		})
+
+		It("should send invalidations", func() {
+			t.invTargets = []sim.Port{cache2}
+			topSender.EXPECT().CanSend(1).Return(true)
+			topSender.EXPECT().
+				Send(gomock.Any()).
+				Do(func(inv *cache.InvalidateReq) {
+					Expect(inv.Dst).To(BeIdenticalTo(cache2))
+					Expect(inv.Address).To(Equal(uint64(0x100)))
+				})
+
+			madeProgress := d.progressTransactions(10)
+
+			Expect(madeProgress).To(BeTrue())
+			Expect(t.invTargets).To(BeEmpty())
+			Expect(t.pendingInvs).To(HaveLen(1))
+		})
+
+		It("should wait for invalidation responses", func() {
+			t.pendingInvs = []*cache.InvalidateReq{{}}
+
+			madeProgress := d.progressTransactions(10)
+
+			Expect(madeProgress).To(BeFalse())
+		})
+
+		It("should write back dirty data before accessing memory", func() {
+			write := mem.WriteReqBuilder{}.Build()
+			t.writebacks = []*mem.WriteReq{write}
+			bottomSender.EXPECT().CanSend(1).Return(true)
+			bottomSender.EXPECT().Send(write)
+
+			d.progressTransactions(10)
+
+			Expect(t.writebacks).To(BeEmpty())
+			Expect(t.inflightWritebacks).To(ConsistOf(write))
+		})
+
+		It("should fetch from memory", func() {
+			bottomSender.EXPECT().CanSend(1).Return(true)
+			bottomSender.EXPECT().
+				Send(gomock.Any()).
+				Do(func(req *mem.ReadReq) {
+					Expect(req.Dst).To(BeIdenticalTo(dramPort))
+					Expect(req.Address).To(Equal(uint64(0x100)))
+					Expect(req.Info).To(BeNil())
+				})
+
+			d.progressTransactions(10)
+
+			Expect(t.memReq).NotTo(BeNil())
+		})
+
+		It("should respond and make the requester the owner", func() {
+			t.memReq = mem.ReadReqBuilder{}.Build()
+			t.memRsp = mem.DataReadyRspBuilder{}.
+				WithData(make([]byte, 64)).
+				Build()
+			topSender.EXPECT().CanSend(1).Return(true)
+			topSender.EXPECT().
+				Send(gomock.Any()).
+				Do(func(rsp *mem.DataReadyRsp) {
+					Expect(rsp.RespondTo).To(Equal(read.ID))
+					Expect(rsp.Dst).To(BeIdenticalTo(cache1))
+				})
+
+			madeProgress := d.progressTransactions(10)
+
+			Expect(madeProgress).To(BeTrue())
+			Expect(d.transactions).To(BeEmpty())
+			e := d.entries[lineID{1, 0x100}]
+			Expect(e.owner).To(BeIdenticalTo(cache1))
+			Expect(e.sharers).To(ConsistOf(cache1))
+			Expect(e.activeTrans).To(BeNil())
+		})
+	})
+
+	Context("update entry", func() {
+		var line lineID
+
+		BeforeEach(func() {
+			line = lineID{1, 0x100}
+		})
+
+		It("should add sharers on shared fetches", func() {
+			d.entries[line] = &entry{sharers: []sim.Port{cache2}}
+			t := &transaction{read: fetch(cache1, 0x100, false), line: line}
+
+			d.updateEntry(t)
+
+			Expect(d.entries[line].sharers).To(ConsistOf(cache1, cache2))
+			Expect(d.entries[line].owner).To(BeNil())
+		})
+
+		It("should remove the entry after the owner writes back", func() {
+			d.entries[line] = &entry{
+				sharers: []sim.Port{cache1},
+				owner:   cache1,
+			}
+			write := mem.WriteReqBuilder{}.WithSrc(cache1).Build()
+			t := &transaction{write: write, line: line, fromOwner: true}
+
+			d.updateEntry(t)
+
+			Expect(d.entries).NotTo(HaveKey(line))
+		})
+
+		It("should not track non-coherent readers", func() {
+			d.entries[line] = &entry{
+				sharers: []sim.Port{cache2},
+				owner:   cache2,
+			}
+			read := mem.ReadReqBuilder{}.WithSrc(cache1).Build()
+			t := &transaction{read: read, line: line}
+
+			d.updateEntry(t)
+
+			Expect(d.entries).NotTo(HaveKey(line))
+		})
+	})
+})
@@ -0,0 +1,25 @@
+// Package coherence implements the directory of a directory-based MSI cache
+// coherence protocol.
+//
+// A Directory sits between a group of coherent caches (for example, the
+// writeback L2 caches of several GPUs that share a unified memory) and the
+// memory that is the home of the data. The directory records which caches
+// hold a copy of each cache line. A line is either not cached (Invalid), held
+// by one or more caches as a clean copy (Shared), or held by exactly one cache
+// that may modify it (Modified).
+//
+// Coherent caches fetch lines with ReadReqs whose Info field is a
+// *cache.CoherentFetchInfo. A shared fetch recalls the line from the owner, if
+// there is one. An exclusive fetch, which a cache issues before writing to a
+// line, invalidates all the other copies. A WriteReq from the owner of a line
+// is treated as a writeback and releases the ownership. Requests from
+// non-coherent components (for example, DMA engines) are also supported. Reads
+// recall the dirty data from the owner and writes invalidate all the copies.
+//
+// The directory sends cache.InvalidateReqs from the same port that it uses to
+// respond, so that a cache always receives the data of a fetch before any
+// invalidation of the fetched line. Caches reply with cache.InvalidateRsps,
+// which carry the line data if the line was dirty. Requests to the same cache
+// line are processed one at a time in arrival order. Writebacks from the owner
+// bypass the order so that an owner can always drain its evictions.
+package coherence
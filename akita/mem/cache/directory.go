@@ -17,19 +17,34 @@ type Block struct {
 	ReadCount    int
 	IsLocked     bool
 	DirtyMask    []bool
+
+	// SectorValid marks the sectors of the block that hold valid data. It is
+	// only used by sectored caches, in which a valid block may only hold some
+	// of its sectors.
+	SectorValid []bool
+
+	// IsPrefetched is true if the block is filled by a prefetch and has not
+	// been read by any demand request.
+	IsPrefetched bool
 }
 
 // A Set is a list of blocks where a certain piece memory can be stored at
 type Set struct {
 	Blocks   []*Block
 	LRUQueue []*Block
+
+	// PolicyState holds the per-set metadata that the replacement policy
+	// maintains.
+	PolicyState interface{}
 }
 
 // A Directory stores the information about what is stored in the cache.
 type Directory interface {
 	Lookup(pid vm.PID, address uint64) *Block
 	FindVictim(address uint64) *Block
+	ShouldBypass(pid vm.PID, address uint64) bool
 	Visit(block *Block)
+	Insert(block *Block)
 	TotalSize() uint64
 	WayAssociativity() int
 	GetSets() []Set
@@ -107,9 +122,49 @@ func (d *DirectoryImpl) FindVictim(addr uint64) *Block {
 	return block
 }
 
-// Visit moves the block to the end of the LRUQueue
+// ShouldBypass returns true if a line that misses the cache should not be
+// allocated a block. Only a ReplacementPolicy can decide to bypass the cache.
+func (d *DirectoryImpl) ShouldBypass(pid vm.PID, addr uint64) bool {
+	policy, ok := d.victimFinder.(ReplacementPolicy)
+	if !ok {
+		return false
+	}
+
+	set, _ := d.getSet(addr)
+
+	return policy.ShouldBypass(set, pid, addr)
+}
+
+// Visit updates the replacement metadata when a block that already holds the
+// line is accessed.
 func (d *DirectoryImpl) Visit(block *Block) {
-	set := d.Sets[block.SetID]
+	set := &d.Sets[block.SetID]
+
+	policy, ok := d.victimFinder.(ReplacementPolicy)
+	if !ok {
+		moveToLRUQueueEnd(set, block)
+		return
+	}
+
+	policy.OnHit(set, block)
+}
+
+// Insert updates the replacement metadata when a block is allocated to a
+// line. It should be called after the tag and the PID of the block are set,
+// even if the block is refilled with the line that it held before.
+func (d *DirectoryImpl) Insert(block *Block) {
+	set := &d.Sets[block.SetID]
+
+	policy, ok := d.victimFinder.(ReplacementPolicy)
+	if !ok {
+		moveToLRUQueueEnd(set, block)
+		return
+	}
+
+	policy.OnInsert(set, block)
+}
+
+func moveToLRUQueueEnd(set *Set, block *Block) {
 	for i, b := range set.LRUQueue {
 		if b == block {
 			set.LRUQueue = append(set.LRUQueue[:i], set.LRUQueue[i+1:]...)
@@ -137,6 +192,10 @@ func (d *DirectoryImpl) Reset() {
 			d.Sets[i].Blocks = append(d.Sets[i].Blocks, block)
 			d.Sets[i].LRUQueue = append(d.Sets[i].LRUQueue, block)
 		}
+
+		if policy, ok := d.victimFinder.(ReplacementPolicy); ok {
+			policy.InitSet(&d.Sets[i], d.NumSets)
+		}
 	}
 }
 
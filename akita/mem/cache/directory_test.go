@@ -5,6 +5,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/sarchlab/akita/v3/mem/mem"
+	"github.com/sarchlab/akita/v3/mem/vm"
 )
 
 var _ = Describe("Directory", func() {
@@ -83,6 +84,56 @@ var _ = Describe("Directory", func() {
 		Expect(set.LRUQueue[3]).To(BeIdenticalTo(set.Blocks[1]))
 	})
 
+	Context("with a replacement policy", func() {
+		var policy *MockReplacementPolicy
+
+		BeforeEach(func() {
+			policy = NewMockReplacementPolicy(mockCtrl)
+			policy.EXPECT().InitSet(gomock.Any(), 1024).Times(1024)
+			directory = NewDirectory(1024, 4, 64, policy)
+		})
+
+		It("should report insertions", func() {
+			set, _ := directory.getSet(0x100)
+			block := set.Blocks[1]
+			block.Tag = 0x100
+			policy.EXPECT().OnInsert(set, block)
+
+			directory.Insert(block)
+		})
+
+		It("should report hits", func() {
+			set, _ := directory.getSet(0x100)
+			block := set.Blocks[1]
+			block.Tag = 0x100
+			policy.EXPECT().OnHit(set, block)
+
+			directory.Visit(block)
+		})
+
+		It("should report a refill with the same line as an insertion", func() {
+			set, _ := directory.getSet(0x100)
+			block := set.Blocks[1]
+			block.Tag = 0x100
+			policy.EXPECT().OnInsert(set, block).Times(2)
+
+			directory.Insert(block)
+			directory.Insert(block)
+		})
+
+		It("should ask the policy whether to bypass", func() {
+			set, _ := directory.getSet(0x100)
+			policy.EXPECT().ShouldBypass(set, vm.PID(1), uint64(0x100)).
+				Return(true)
+
+			Expect(directory.ShouldBypass(1, 0x100)).To(BeTrue())
+		})
+	})
+
+	It("should not bypass without a replacement policy", func() {
+		Expect(directory.ShouldBypass(1, 0x100)).To(BeFalse())
+	})
+
 	It("should get set considering interleaving", func() {
 		directory.AddrConverter = &mem.InterleavingConverter{
 			InterleavingSize:    128,
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/sarchlab/akita/v3/mem/cache (interfaces: VictimFinder,Directory)
+// Source: github.com/sarchlab/akita/v3/mem/cache (interfaces: VictimFinder,ReplacementPolicy,Directory)
 
 package cache
 
@@ -47,6 +47,93 @@ func (mr *MockVictimFinderMockRecorder) FindVictim(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindVictim", reflect.TypeOf((*MockVictimFinder)(nil).FindVictim), arg0)
 }
 
+// MockReplacementPolicy is a mock of ReplacementPolicy interface.
+type MockReplacementPolicy struct {
+	ctrl     *gomock.Controller
+	recorder *MockReplacementPolicyMockRecorder
+}
+
+// MockReplacementPolicyMockRecorder is the mock recorder for MockReplacementPolicy.
+type MockReplacementPolicyMockRecorder struct {
+	mock *MockReplacementPolicy
+}
+
+// NewMockReplacementPolicy creates a new mock instance.
+func NewMockReplacementPolicy(ctrl *gomock.Controller) *MockReplacementPolicy {
+	mock := &MockReplacementPolicy{ctrl: ctrl}
+	mock.recorder = &MockReplacementPolicyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReplacementPolicy) EXPECT() *MockReplacementPolicyMockRecorder {
+	return m.recorder
+}
+
+// FindVictim mocks base method.
+func (m *MockReplacementPolicy) FindVictim(arg0 *Set) *Block {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindVictim", arg0)
+	ret0, _ := ret[0].(*Block)
+	return ret0
+}
+
+// FindVictim indicates an expected call of FindVictim.
+func (mr *MockReplacementPolicyMockRecorder) FindVictim(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindVictim", reflect.TypeOf((*MockReplacementPolicy)(nil).FindVictim), arg0)
+}
+
+// InitSet mocks base method.
+func (m *MockReplacementPolicy) InitSet(arg0 *Set, arg1 int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "InitSet", arg0, arg1)
+}
+
+// InitSet indicates an expected call of InitSet.
+func (mr *MockReplacementPolicyMockRecorder) InitSet(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InitSet", reflect.TypeOf((*MockReplacementPolicy)(nil).InitSet), arg0, arg1)
+}
+
+// OnHit mocks base method.
+func (m *MockReplacementPolicy) OnHit(arg0 *Set, arg1 *Block) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "OnHit", arg0, arg1)
+}
+
+// OnHit indicates an expected call of OnHit.
+func (mr *MockReplacementPolicyMockRecorder) OnHit(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnHit", reflect.TypeOf((*MockReplacementPolicy)(nil).OnHit), arg0, arg1)
+}
+
+// OnInsert mocks base method.
+func (m *MockReplacementPolicy) OnInsert(arg0 *Set, arg1 *Block) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "OnInsert", arg0, arg1)
+}
+
+// OnInsert indicates an expected call of OnInsert.
+func (mr *MockReplacementPolicyMockRecorder) OnInsert(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnInsert", reflect.TypeOf((*MockReplacementPolicy)(nil).OnInsert), arg0, arg1)
+}
+
+// ShouldBypass mocks base method.
+func (m *MockReplacementPolicy) ShouldBypass(arg0 *Set, arg1 vm.PID, arg2 uint64) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShouldBypass", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// ShouldBypass indicates an expected call of ShouldBypass.
+func (mr *MockReplacementPolicyMockRecorder) ShouldBypass(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShouldBypass", reflect.TypeOf((*MockReplacementPolicy)(nil).ShouldBypass), arg0, arg1, arg2)
+}
+
 // MockDirectory is a mock of Directory interface.
 type MockDirectory struct {
 	ctrl     *gomock.Controller
@@ -98,6 +185,18 @@ func (mr *MockDirectoryMockRecorder) GetSets() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSets", reflect.TypeOf((*MockDirectory)(nil).GetSets))
 }
 
+// Insert mocks base method.
+func (m *MockDirectory) Insert(arg0 *Block) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Insert", arg0)
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockDirectoryMockRecorder) Insert(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockDirectory)(nil).Insert), arg0)
+}
+
 // Lookup mocks base method.
 func (m *MockDirectory) Lookup(arg0 vm.PID, arg1 uint64) *Block {
 	m.ctrl.T.Helper()
@@ -124,6 +223,20 @@ func (mr *MockDirectoryMockRecorder) Reset() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockDirectory)(nil).Reset))
 }
 
+// ShouldBypass mocks base method.
+func (m *MockDirectory) ShouldBypass(arg0 vm.PID, arg1 uint64) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShouldBypass", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// ShouldBypass indicates an expected call of ShouldBypass.
+func (mr *MockDirectoryMockRecorder) ShouldBypass(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShouldBypass", reflect.TypeOf((*MockDirectory)(nil).ShouldBypass), arg0, arg1)
+}
+
 // TotalSize mocks base method.
 func (m *MockDirectory) TotalSize() uint64 {
 	m.ctrl.T.Helper()
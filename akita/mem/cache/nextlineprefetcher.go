@@ -0,0 +1,39 @@
+package cache
+
+// NextLinePrefetcher fetches the cache lines that follow the line of the
+// trigger.
+type NextLinePrefetcher struct {
+	log2BlockSize uint64
+	degree        int
+}
+
+// NewNextLinePrefetcher creates a new NextLinePrefetcher that fetches the
+// next degree lines.
+func NewNextLinePrefetcher(
+	log2BlockSize uint64,
+	degree int,
+) *NextLinePrefetcher {
+	return &NextLinePrefetcher{
+		log2BlockSize: log2BlockSize,
+		degree:        degree,
+	}
+}
+
+// Notify returns the lines that follow the line of the trigger, within the
+// same page.
+func (p *NextLinePrefetcher) Notify(trigger PrefetchTrigger) []uint64 {
+	blockSize := uint64(1) << p.log2BlockSize
+	line := cacheLineAddr(trigger.Address, p.log2BlockSize)
+
+	var addrs []uint64
+	for i := 1; i <= p.degree; i++ {
+		addr := line + uint64(i)*blockSize
+		if !inSamePrefetchPage(addr, line) {
+			break
+		}
+
+		addrs = append(addrs, addr)
+	}
+
+	return addrs
+}
@@ -0,0 +1,28 @@
+package cache
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Next Line Prefetcher", func() {
+	var (
+		prefetcher *NextLinePrefetcher
+	)
+
+	BeforeEach(func() {
+		prefetcher = NewNextLinePrefetcher(6, 2)
+	})
+
+	It("should fetch the next lines", func() {
+		addrs := prefetcher.Notify(PrefetchTrigger{Address: 0x1008})
+
+		Expect(addrs).To(Equal([]uint64{0x1040, 0x1080}))
+	})
+
+	It("should not cross the page boundary", func() {
+		addrs := prefetcher.Notify(PrefetchTrigger{Address: 0x1FC0})
+
+		Expect(addrs).To(BeEmpty())
+	})
+})
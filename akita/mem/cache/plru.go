@@ -0,0 +1,95 @@
+package cache
+
+import (
+	"log"
+
+	"github.com/sarchlab/akita/v3/mem/vm"
+)
+
+// PLRUPolicy is the tree-based pseudo-LRU replacement policy. Each set keeps
+// a binary tree of bits, where each bit points to the less recently used half
+// of the subtree. The number of ways must be a power of 2.
+type PLRUPolicy struct {
+}
+
+// NewPLRUPolicy creates a new PLRUPolicy.
+func NewPLRUPolicy() *PLRUPolicy {
+	return &PLRUPolicy{}
+}
+
+type plruSetState struct {
+	// bits is a binary tree stored in an array. A bit set to true points to
+	// the right child.
+	bits []bool
+}
+
+// InitSet creates the tree of the set.
+func (p *PLRUPolicy) InitSet(set *Set, _ int) {
+	numWays := len(set.Blocks)
+	if numWays&(numWays-1) != 0 {
+		log.Panicf("PLRU requires the number of ways to be a power of 2, "+
+			"but got %d", numWays)
+	}
+
+	set.PolicyState = &plruSetState{
+		bits: make([]bool, numWays-1),
+	}
+}
+
+// FindVictim follows the tree bits to find the pseudo least recently used
+// block. If the block cannot be evicted, the first block that can be evicted
+// is returned.
+func (p *PLRUPolicy) FindVictim(set *Set) *Block {
+	if block := findInvalidBlock(set); block != nil {
+		return block
+	}
+
+	state := set.PolicyState.(*plruSetState)
+	node := 0
+	for node < len(state.bits) {
+		if state.bits[node] {
+			node = 2*node + 2
+		} else {
+			node = 2*node + 1
+		}
+	}
+
+	victim := set.Blocks[node-len(state.bits)]
+	if isEvictable(victim) {
+		return victim
+	}
+
+	for _, block := range set.Blocks {
+		if isEvictable(block) {
+			return block
+		}
+	}
+
+	return victim
+}
+
+// OnHit makes the tree bits point away from the block.
+func (p *PLRUPolicy) OnHit(set *Set, block *Block) {
+	p.touch(set, block)
+}
+
+// OnInsert makes the tree bits point away from the block.
+func (p *PLRUPolicy) OnInsert(set *Set, block *Block) {
+	p.touch(set, block)
+}
+
+func (p *PLRUPolicy) touch(set *Set, block *Block) {
+	state := set.PolicyState.(*plruSetState)
+	node := block.WayID + len(state.bits)
+	for node > 0 {
+		parent := (node - 1) / 2
+		isRightChild := node == 2*parent+2
+		state.bits[parent] = !isRightChild
+		node = parent
+	}
+}
+
+// ShouldBypass returns false, as the policy allocates a block on every miss.
+func (p *PLRUPolicy) ShouldBypass(_ *Set, _ vm.PID, _ uint64) bool {
+	return false
+}
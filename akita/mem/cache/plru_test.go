@@ -0,0 +1,55 @@
+package cache
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PLRU Policy", func() {
+	var (
+		policy    *PLRUPolicy
+		directory *DirectoryImpl
+		set       *Set
+	)
+
+	BeforeEach(func() {
+		policy = NewPLRUPolicy()
+		directory = NewDirectory(1, 4, 64, policy)
+		set = &directory.Sets[0]
+	})
+
+	It("should panic if the number of ways is not a power of 2", func() {
+		Expect(func() { NewDirectory(1, 3, 64, policy) }).To(Panic())
+	})
+
+	It("should evict invalid blocks first", func() {
+		set.Blocks[0].IsValid = true
+
+		Expect(policy.FindVictim(set)).To(BeIdenticalTo(set.Blocks[1]))
+	})
+
+	It("should evict the pseudo least recently used block", func() {
+		for _, b := range set.Blocks {
+			b.IsValid = true
+		}
+
+		directory.Visit(set.Blocks[0])
+		directory.Visit(set.Blocks[2])
+		directory.Visit(set.Blocks[1])
+
+		Expect(policy.FindVictim(set)).To(BeIdenticalTo(set.Blocks[3]))
+
+		directory.Visit(set.Blocks[3])
+
+		Expect(policy.FindVictim(set)).To(BeIdenticalTo(set.Blocks[0]))
+	})
+
+	It("should not evict locked blocks", func() {
+		for _, b := range set.Blocks {
+			b.IsValid = true
+		}
+		set.Blocks[0].IsLocked = true
+
+		Expect(policy.FindVictim(set)).To(BeIdenticalTo(set.Blocks[1]))
+	})
+})
@@ -0,0 +1,105 @@
+package cache
+
+import (
+	"github.com/sarchlab/akita/v3/mem/mem"
+	"github.com/sarchlab/akita/v3/mem/vm"
+)
+
+// log2PrefetchPageSize is the size of the region that a prefetch cannot cross.
+// The caches may be physically addressed, where the next page of a virtual
+// address space is not the next physical page.
+const log2PrefetchPageSize = 12
+
+// A PrefetchTrigger is a demand access that trains the prefetcher.
+type PrefetchTrigger struct {
+	PID     vm.PID
+	Address uint64
+
+	// PC is the program counter of the instruction that issues the access. It
+	// is only meaningful if HasPC is true.
+	PC    uint64
+	HasPC bool
+}
+
+// NewPrefetchTrigger creates the trigger of a read request. The PC is taken
+// from the Info of the request if the requester provides it.
+func NewPrefetchTrigger(read *mem.ReadReq) PrefetchTrigger {
+	pc, hasPC := mem.PCOf(read.Info)
+
+	return PrefetchTrigger{
+		PID:     read.PID,
+		Address: read.Address,
+		PC:      pc,
+		HasPC:   hasPC,
+	}
+}
+
+// A Prefetcher predicts the cache lines that are going to be read.
+//
+// The prefetcher is trained with the miss stream of the cache. The caches
+// notify the prefetcher when a demand read allocates an MSHR entry, and when
+// the first demand read to a prefetched line hits the line or its in-flight
+// MSHR entry, as the read would have allocated an MSHR entry without the
+// prefetcher. The reads that hit other lines or join the MSHR entries of other
+// misses do not train the prefetcher. The prefetcher returns the addresses of the cache lines to
+// fetch. The cache drops the prefetches to the lines that are already cached
+// or being fetched.
+type Prefetcher interface {
+	Notify(trigger PrefetchTrigger) []uint64
+}
+
+// PrefetcherType selects the prefetcher that a cache uses.
+type PrefetcherType int
+
+// A list of all the supported prefetchers.
+const (
+	PrefetchNone PrefetcherType = iota
+	PrefetchNextLine
+	PrefetchStride
+	PrefetchStreamBuffer
+)
+
+func (t PrefetcherType) String() string {
+	switch t {
+	case PrefetchNone:
+		return "None"
+	case PrefetchNextLine:
+		return "NextLine"
+	case PrefetchStride:
+		return "Stride"
+	case PrefetchStreamBuffer:
+		return "StreamBuffer"
+	default:
+		return "Unknown"
+	}
+}
+
+// NewPrefetcher creates a prefetcher of the given type. The degree is the
+// number of cache lines that the prefetcher fetches ahead. It returns nil if
+// the type is PrefetchNone.
+func NewPrefetcher(
+	t PrefetcherType,
+	log2BlockSize uint64,
+	degree int,
+) Prefetcher {
+	switch t {
+	case PrefetchNone:
+		return nil
+	case PrefetchNextLine:
+		return NewNextLinePrefetcher(log2BlockSize, degree)
+	case PrefetchStride:
+		return NewStridePrefetcher(log2BlockSize, degree)
+	case PrefetchStreamBuffer:
+		return NewStreamBufferPrefetcher(log2BlockSize, degree)
+	default:
+		panic("unknown prefetcher type")
+	}
+}
+
+func cacheLineAddr(addr uint64, log2BlockSize uint64) uint64 {
+	return addr >> log2BlockSize << log2BlockSize
+}
+
+func inSamePrefetchPage(a, b uint64) bool {
+	return a>>log2PrefetchPageSize == b>>log2PrefetchPageSize
+}
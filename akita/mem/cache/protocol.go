@@ -70,7 +70,7 @@ func (b FlushReqBuilder) PauseAfterFlushing() FlushReqBuilder {
 // Build creates a new FlushReq
 func (b FlushReqBuilder) Build() *FlushReq {
 	r := &FlushReq{}
-	r.ID = sim.GetIDGenerator().Generate()
+	r.ID = sim.IDGeneratorFor(b.src).Generate()
 	r.Src = b.src
 	r.Dst = b.dst
 	r.SendTime = b.sendTime
@@ -128,7 +128,7 @@ func (b FlushRspBuilder) WithRspTo(id string) FlushRspBuilder {
 // Build creates a new FlushRsp
 func (b FlushRspBuilder) Build() *FlushRsp {
 	r := &FlushRsp{}
-	r.ID = sim.GetIDGenerator().Generate()
+	r.ID = sim.IDGeneratorFor(b.src).Generate()
 	r.Src = b.src
 	r.Dst = b.dst
 	r.SendTime = b.sendTime
@@ -176,7 +176,7 @@ func (b RestartReqBuilder) WithDst(dst sim.Port) RestartReqBuilder {
 // Build creates a new RestartReq
 func (b RestartReqBuilder) Build() *RestartReq {
 	r := &RestartReq{}
-	r.ID = sim.GetIDGenerator().Generate()
+	r.ID = sim.IDGeneratorFor(b.src).Generate()
 	r.Src = b.src
 	r.Dst = b.dst
 	r.SendTime = b.sendTime
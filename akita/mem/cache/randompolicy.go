@@ -0,0 +1,57 @@
+package cache
+
+import (
+	"math/rand"
+
+	"github.com/sarchlab/akita/v3/mem/vm"
+)
+
+// RandomPolicy evicts a random block. The random number generator is seeded
+// by the policy, so that the simulation results are reproducible.
+type RandomPolicy struct {
+	rand *rand.Rand
+}
+
+// NewRandomPolicy creates a new RandomPolicy with the given seed.
+func NewRandomPolicy(seed int64) *RandomPolicy {
+	return &RandomPolicy{
+		rand: rand.New(rand.NewSource(seed)),
+	}
+}
+
+// InitSet does nothing, as the random policy does not need any metadata.
+func (p *RandomPolicy) InitSet(set *Set, _ int) {
+}
+
+// FindVictim returns a random block that can be evicted.
+func (p *RandomPolicy) FindVictim(set *Set) *Block {
+	if block := findInvalidBlock(set); block != nil {
+		return block
+	}
+
+	candidates := make([]*Block, 0, len(set.Blocks))
+	for _, block := range set.Blocks {
+		if isEvictable(block) {
+			candidates = append(candidates, block)
+		}
+	}
+
+	if len(candidates) == 0 {
+		return set.Blocks[0]
+	}
+
+	return candidates[p.rand.Intn(len(candidates))]
+}
+
+// OnHit does nothing.
+func (p *RandomPolicy) OnHit(set *Set, block *Block) {
+}
+
+// OnInsert does nothing.
+func (p *RandomPolicy) OnInsert(set *Set, block *Block) {
+}
+
+// ShouldBypass returns false, as the policy allocates a block on every miss.
+func (p *RandomPolicy) ShouldBypass(_ *Set, _ vm.PID, _ uint64) bool {
+	return false
+}
@@ -0,0 +1,48 @@
+package cache
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Random Policy", func() {
+	var (
+		policy    *RandomPolicy
+		directory *DirectoryImpl
+		set       *Set
+	)
+
+	BeforeEach(func() {
+		policy = NewRandomPolicy(0)
+		directory = NewDirectory(1, 4, 64, policy)
+		set = &directory.Sets[0]
+		for _, b := range set.Blocks {
+			b.IsValid = true
+		}
+	})
+
+	It("should evict invalid blocks first", func() {
+		set.Blocks[2].IsValid = false
+
+		Expect(policy.FindVictim(set)).To(BeIdenticalTo(set.Blocks[2]))
+	})
+
+	It("should only evict the blocks that are not locked", func() {
+		set.Blocks[0].IsLocked = true
+		set.Blocks[1].ReadCount = 1
+		set.Blocks[3].IsLocked = true
+
+		for i := 0; i < 16; i++ {
+			Expect(policy.FindVictim(set)).To(BeIdenticalTo(set.Blocks[2]))
+		}
+	})
+
+	It("should be reproducible", func() {
+		other := NewRandomPolicy(0)
+
+		for i := 0; i < 16; i++ {
+			Expect(policy.FindVictim(set)).
+				To(BeIdenticalTo(other.FindVictim(set)))
+		}
+	})
+})
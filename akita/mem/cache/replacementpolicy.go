@@ -0,0 +1,119 @@
+package cache
+
+import (
+	"log"
+
+	"github.com/sarchlab/akita/v3/mem/vm"
+)
+
+// A ReplacementPolicy is a VictimFinder that maintains its own replacement
+// metadata. The caches report every hit and every allocation to the policy
+// through the directory, so that the policy can decide which block to evict,
+// at which priority a new line is inserted, and whether a new line is
+// allocated at all.
+type ReplacementPolicy interface {
+	VictimFinder
+
+	// InitSet is called when the directory creates or resets a set. The
+	// number of sets in the directory is given so that the policy can spread
+	// its per-set roles over the sets.
+	InitSet(set *Set, numSets int)
+
+	// OnHit is called when a line that is already in the block is accessed.
+	OnHit(set *Set, block *Block)
+
+	// OnInsert is called when a new line is placed in the block.
+	OnInsert(set *Set, block *Block)
+
+	// ShouldBypass is called when an access misses the cache, before a block
+	// is allocated to the line. If it returns true, the cache sends the
+	// access to the low module without allocating a block.
+	ShouldBypass(set *Set, pid vm.PID, addr uint64) bool
+}
+
+// ReplacementPolicyType selects one of the replacement policies that the
+// caches can be configured with.
+type ReplacementPolicyType int
+
+// A list of all the supported replacement policies.
+const (
+	LRU ReplacementPolicyType = iota
+	PLRU
+	Random
+	SRRIP
+	BRRIP
+	DRRIP
+	SHiP
+	BIP
+	Bypass
+)
+
+func (t ReplacementPolicyType) String() string {
+	switch t {
+	case LRU:
+		return "LRU"
+	case PLRU:
+		return "PLRU"
+	case Random:
+		return "Random"
+	case SRRIP:
+		return "SRRIP"
+	case BRRIP:
+		return "BRRIP"
+	case DRRIP:
+		return "DRRIP"
+	case SHiP:
+		return "SHiP"
+	case BIP:
+		return "BIP"
+	case Bypass:
+		return "Bypass"
+	default:
+		return "Unknown"
+	}
+}
+
+// NewReplacementPolicy creates a replacement policy of the given type. As the
+// policies carry state, each directory needs its own policy.
+func NewReplacementPolicy(t ReplacementPolicyType) ReplacementPolicy {
+	switch t {
+	case LRU:
+		return NewLRUVictimFinder()
+	case PLRU:
+		return NewPLRUPolicy()
+	case Random:
+		return NewRandomPolicy(0)
+	case SRRIP:
+		return NewSRRIPPolicy()
+	case BRRIP:
+		return NewBRRIPPolicy()
+	case DRRIP:
+		return NewDRRIPPolicy()
+	case SHiP:
+		return NewSHiPPolicy()
+	case BIP:
+		return NewBIPPolicy()
+	case Bypass:
+		return NewBypassPolicy()
+	default:
+		log.Panicf("unknown replacement policy %d", t)
+	}
+
+	return nil
+}
+
+// findInvalidBlock returns an empty block that is not locked, or nil if there
+// is no such block. All the policies fill empty blocks first.
+func findInvalidBlock(set *Set) *Block {
+	for _, block := range set.Blocks {
+		if !block.IsValid && isEvictable(block) {
+			return block
+		}
+	}
+
+	return nil
+}
+
+func isEvictable(block *Block) bool {
+	return !block.IsLocked && block.ReadCount == 0
+}
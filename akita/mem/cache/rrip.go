@@ -0,0 +1,214 @@
+package cache
+
+import "github.com/sarchlab/akita/v3/mem/vm"
+
+const (
+	rripMaxRRPV = 3
+
+	// brripThrottle is the number of insertions for each insertion that
+	// BRRIP places at the long re-reference interval.
+	brripThrottle = 32
+
+	// drripNumLeaderSets is the number of leader sets of each policy in a
+	// large cache. A small cache has fewer leader sets, as the leader sets are
+	// at least drripMinLeaderSetInterval sets apart.
+	drripNumLeaderSets        = 32
+	drripMinLeaderSetInterval = 4
+	drripPSELMax              = 1023
+)
+
+type rripInsertion int
+
+const (
+	rripInsertionStatic rripInsertion = iota
+	rripInsertionBimodal
+	rripInsertionDynamic
+)
+
+type duelingRole int
+
+const (
+	duelingFollower duelingRole = iota
+	duelingSRRIPLeader
+	duelingBRRIPLeader
+)
+
+type rripSetState struct {
+	// rrpv is the re-reference prediction value of each way.
+	rrpv []int
+	role duelingRole
+}
+
+// RRIPPolicy is the re-reference interval prediction replacement policy
+// [Jaleel et al., ISCA'10]. Each block carries a 2-bit re-reference
+// prediction value (RRPV). A hit predicts a near re-reference, and the block
+// with the most distant prediction is evicted.
+//
+// The RRIP policies differ in how new lines are inserted. SRRIP inserts new
+// lines with a long re-reference interval. BRRIP inserts most of the new lines
+// with a distant re-reference interval, which protects the cache from
+// thrashing. DRRIP uses set dueling to choose between SRRIP and BRRIP.
+type RRIPPolicy struct {
+	insertion    rripInsertion
+	bimodalCount int
+	psel         int
+}
+
+// NewSRRIPPolicy creates a RRIPPolicy that uses static insertion.
+func NewSRRIPPolicy() *RRIPPolicy {
+	return &RRIPPolicy{insertion: rripInsertionStatic}
+}
+
+// NewBRRIPPolicy creates a RRIPPolicy that uses bimodal insertion.
+func NewBRRIPPolicy() *RRIPPolicy {
+	return &RRIPPolicy{insertion: rripInsertionBimodal}
+}
+
+// NewDRRIPPolicy creates a RRIPPolicy that uses set dueling to choose between
+// the static and the bimodal insertion.
+func NewDRRIPPolicy() *RRIPPolicy {
+	return &RRIPPolicy{
+		insertion: rripInsertionDynamic,
+		psel:      drripPSELMax / 2,
+	}
+}
+
+// InitSet sets all the blocks to the distant re-reference interval and
+// assigns the set dueling role of the set.
+func (p *RRIPPolicy) InitSet(set *Set, numSets int) {
+	set.PolicyState = newRRIPSetState(set, numSets)
+}
+
+func newRRIPSetState(set *Set, numSets int) *rripSetState {
+	state := &rripSetState{
+		rrpv: make([]int, len(set.Blocks)),
+	}
+
+	for i := range state.rrpv {
+		state.rrpv[i] = rripMaxRRPV
+	}
+
+	if len(set.Blocks) > 0 {
+		switch set.Blocks[0].SetID % drripLeaderSetInterval(numSets) {
+		case 0:
+			state.role = duelingSRRIPLeader
+		case 1:
+			state.role = duelingBRRIPLeader
+		}
+	}
+
+	return state
+}
+
+// drripLeaderSetInterval returns the distance between two leader sets of the
+// same policy. The first set of each interval is an SRRIP leader and the
+// second set is a BRRIP leader, so that every cache with at least two sets
+// has leaders of both policies, and most of the sets of a cache with more than
+// two sets follow the winner.
+func drripLeaderSetInterval(numSets int) int {
+	interval := numSets / drripNumLeaderSets
+	if interval < drripMinLeaderSetInterval {
+		interval = drripMinLeaderSetInterval
+	}
+
+	return interval
+}
+
+// FindVictim returns the first block with a distant re-reference interval.
+// If there is no such block, all the blocks are aged until one is found.
+func (p *RRIPPolicy) FindVictim(set *Set) *Block {
+	if block := findInvalidBlock(set); block != nil {
+		return block
+	}
+
+	return rripFindVictim(set, set.PolicyState.(*rripSetState))
+}
+
+func rripFindVictim(set *Set, state *rripSetState) *Block {
+	hasEvictable := false
+	for _, block := range set.Blocks {
+		if isEvictable(block) {
+			hasEvictable = true
+			break
+		}
+	}
+
+	if !hasEvictable {
+		return set.Blocks[0]
+	}
+
+	for {
+		for i, block := range set.Blocks {
+			if isEvictable(block) && state.rrpv[i] >= rripMaxRRPV {
+				return block
+			}
+		}
+
+		for i, block := range set.Blocks {
+			if isEvictable(block) {
+				state.rrpv[i]++
+			}
+		}
+	}
+}
+
+// OnHit predicts that the block will be re-referenced in the near future.
+func (p *RRIPPolicy) OnHit(set *Set, block *Block) {
+	state := set.PolicyState.(*rripSetState)
+	state.rrpv[block.WayID] = 0
+}
+
+// OnInsert sets the re-reference prediction value of the new line according
+// to the insertion policy.
+func (p *RRIPPolicy) OnInsert(set *Set, block *Block) {
+	state := set.PolicyState.(*rripSetState)
+	state.rrpv[block.WayID] = p.insertionRRPV(state)
+}
+
+func (p *RRIPPolicy) insertionRRPV(state *rripSetState) int {
+	switch p.insertion {
+	case rripInsertionStatic:
+		return rripMaxRRPV - 1
+	case rripInsertionBimodal:
+		return p.bimodalRRPV()
+	default:
+		return p.dynamicRRPV(state)
+	}
+}
+
+func (p *RRIPPolicy) bimodalRRPV() int {
+	p.bimodalCount++
+	if p.bimodalCount >= brripThrottle {
+		p.bimodalCount = 0
+		return rripMaxRRPV - 1
+	}
+
+	return rripMaxRRPV
+}
+
+// dynamicRRPV updates the policy selector, as each insertion is caused by a
+// miss, and inserts the line with the policy that the set follows.
+func (p *RRIPPolicy) dynamicRRPV(state *rripSetState) int {
+	switch state.role {
+	case duelingSRRIPLeader:
+		if p.psel < drripPSELMax {
+			p.psel++
+		}
+		return rripMaxRRPV - 1
+	case duelingBRRIPLeader:
+		if p.psel > 0 {
+			p.psel--
+		}
+		return p.bimodalRRPV()
+	default:
+		if p.psel > drripPSELMax/2 {
+			return p.bimodalRRPV()
+		}
+		return rripMaxRRPV - 1
+	}
+}
+
+// ShouldBypass returns false, as the policy allocates a block on every miss.
+func (p *RRIPPolicy) ShouldBypass(_ *Set, _ vm.PID, _ uint64) bool {
+	return false
+}
@@ -0,0 +1,153 @@
+package cache
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RRIP Policy", func() {
+	fill := func(directory *DirectoryImpl, set *Set) {
+		for i, b := range set.Blocks {
+			b.IsValid = true
+			b.Tag = uint64(i * 64)
+			directory.Insert(b)
+		}
+	}
+
+	rrpv := func(set *Set) []int {
+		return set.PolicyState.(*rripSetState).rrpv
+	}
+
+	Context("SRRIP", func() {
+		var (
+			policy    *RRIPPolicy
+			directory *DirectoryImpl
+			set       *Set
+		)
+
+		BeforeEach(func() {
+			policy = NewSRRIPPolicy()
+			directory = NewDirectory(64, 4, 64, policy)
+			set = &directory.Sets[2]
+		})
+
+		It("should insert with a long re-reference interval", func() {
+			fill(directory, set)
+
+			Expect(rrpv(set)).To(Equal([]int{2, 2, 2, 2}))
+		})
+
+		It("should predict near re-reference on hit", func() {
+			fill(directory, set)
+
+			directory.Visit(set.Blocks[1])
+
+			Expect(rrpv(set)[1]).To(Equal(0))
+		})
+
+		It("should age the blocks to find a victim", func() {
+			fill(directory, set)
+			directory.Visit(set.Blocks[0])
+
+			victim := policy.FindVictim(set)
+
+			Expect(victim).To(BeIdenticalTo(set.Blocks[1]))
+			Expect(rrpv(set)).To(Equal([]int{1, 3, 3, 3}))
+		})
+
+		It("should not evict blocks that are being read", func() {
+			fill(directory, set)
+			set.Blocks[0].ReadCount = 1
+			rrpv(set)[0] = 3
+
+			Expect(policy.FindVictim(set)).To(BeIdenticalTo(set.Blocks[1]))
+		})
+	})
+
+	Context("BRRIP", func() {
+		It("should insert most lines with a distant re-reference interval",
+			func() {
+				policy := NewBRRIPPolicy()
+				directory := NewDirectory(64, 4, 64, policy)
+				set := &directory.Sets[2]
+
+				numLong := 0
+				for i := 0; i < 64; i++ {
+					set.Blocks[0].Tag = uint64(i * 4096)
+					directory.Insert(set.Blocks[0])
+					if rrpv(set)[0] == 2 {
+						numLong++
+					}
+				}
+
+				Expect(numLong).To(Equal(2))
+			})
+	})
+
+	Context("DRRIP", func() {
+		var (
+			policy    *RRIPPolicy
+			directory *DirectoryImpl
+		)
+
+		BeforeEach(func() {
+			policy = NewDRRIPPolicy()
+			directory = NewDirectory(64, 4, 64, policy)
+		})
+
+		It("should assign leader sets", func() {
+			state := func(i int) *rripSetState {
+				return directory.Sets[i].PolicyState.(*rripSetState)
+			}
+
+			Expect(state(0).role).To(Equal(duelingSRRIPLeader))
+			Expect(state(1).role).To(Equal(duelingBRRIPLeader))
+			Expect(state(2).role).To(Equal(duelingFollower))
+			Expect(state(4).role).To(Equal(duelingSRRIPLeader))
+			Expect(state(5).role).To(Equal(duelingBRRIPLeader))
+		})
+
+		It("should spread the leader sets over a large cache", func() {
+			directory = NewDirectory(2048, 4, 64, policy)
+			state := func(i int) *rripSetState {
+				return directory.Sets[i].PolicyState.(*rripSetState)
+			}
+
+			Expect(state(1).role).To(Equal(duelingBRRIPLeader))
+			Expect(state(4).role).To(Equal(duelingFollower))
+			Expect(state(64).role).To(Equal(duelingSRRIPLeader))
+			Expect(state(65).role).To(Equal(duelingBRRIPLeader))
+		})
+
+		It("should have leaders of both policies in a small cache", func() {
+			directory = NewDirectory(2, 4, 64, policy)
+			state := func(i int) *rripSetState {
+				return directory.Sets[i].PolicyState.(*rripSetState)
+			}
+
+			Expect(state(0).role).To(Equal(duelingSRRIPLeader))
+			Expect(state(1).role).To(Equal(duelingBRRIPLeader))
+		})
+
+		It("should follow SRRIP if SRRIP leaders miss less", func() {
+			set := &directory.Sets[2]
+
+			fill(directory, set)
+
+			Expect(rrpv(set)).To(Equal([]int{2, 2, 2, 2}))
+		})
+
+		It("should follow BRRIP if SRRIP leaders miss more", func() {
+			leader := &directory.Sets[0]
+			for i := 0; i < 8; i++ {
+				leader.Blocks[0].Tag = uint64(i * 4096)
+				directory.Insert(leader.Blocks[0])
+			}
+
+			set := &directory.Sets[2]
+			fill(directory, set)
+
+			Expect(rrpv(set)).To(Equal([]int{3, 3, 3, 3}))
+		})
+	})
+})
@@ -0,0 +1,123 @@
+package cache
+
+import "github.com/sarchlab/akita/v3/mem/vm"
+
+const (
+	shipSignatureBits    = 14
+	shipRegionLog2Size   = 14
+	shipCounterMax       = 7
+	shipCounterInitValue = 1
+)
+
+type shipSetState struct {
+	*rripSetState
+
+	signature []uint64
+	reused    []bool
+	tracked   []bool
+}
+
+// SHiPPolicy is the signature-based hit predictor replacement policy
+// [Wu et al., MICRO'11]. It builds on SRRIP, but predicts whether a new line
+// will be re-referenced from the history of the lines that share the same
+// signature. The signature is the memory region of the line (SHiP-Mem), so
+// that the policy works with any request source.
+//
+// The signature history counter table (SHCT) is trained by the lines that
+// leave the cache. A line that is reused increments the counter of its
+// signature, and a line that is evicted without being reused decrements it.
+// New lines whose signature has a zero counter are inserted with the distant
+// re-reference interval.
+type SHiPPolicy struct {
+	shct []int
+}
+
+// NewSHiPPolicy creates a new SHiPPolicy.
+func NewSHiPPolicy() *SHiPPolicy {
+	p := &SHiPPolicy{
+		shct: make([]int, 1<<shipSignatureBits),
+	}
+
+	for i := range p.shct {
+		p.shct[i] = shipCounterInitValue
+	}
+
+	return p
+}
+
+// InitSet initializes the re-reference predictions and the signatures of the
+// set.
+func (p *SHiPPolicy) InitSet(set *Set, numSets int) {
+	numWays := len(set.Blocks)
+	set.PolicyState = &shipSetState{
+		rripSetState: newRRIPSetState(set, numSets),
+		signature:    make([]uint64, numWays),
+		reused:       make([]bool, numWays),
+		tracked:      make([]bool, numWays),
+	}
+}
+
+// FindVictim returns the first block with a distant re-reference interval.
+func (p *SHiPPolicy) FindVictim(set *Set) *Block {
+	if block := findInvalidBlock(set); block != nil {
+		return block
+	}
+
+	state := set.PolicyState.(*shipSetState)
+
+	return rripFindVictim(set, state.rripSetState)
+}
+
+// OnHit marks the line as reused and trains the SHCT.
+func (p *SHiPPolicy) OnHit(set *Set, block *Block) {
+	state := set.PolicyState.(*shipSetState)
+	way := block.WayID
+
+	state.rrpv[way] = 0
+
+	if !state.reused[way] {
+		state.reused[way] = true
+
+		sig := state.signature[way]
+		if p.shct[sig] < shipCounterMax {
+			p.shct[sig]++
+		}
+	}
+}
+
+// OnInsert trains the SHCT with the line that is replaced and predicts the
+// re-reference interval of the new line.
+func (p *SHiPPolicy) OnInsert(set *Set, block *Block) {
+	state := set.PolicyState.(*shipSetState)
+	way := block.WayID
+
+	if state.tracked[way] && !state.reused[way] {
+		sig := state.signature[way]
+		if p.shct[sig] > 0 {
+			p.shct[sig]--
+		}
+	}
+
+	sig := p.signature(block)
+	state.signature[way] = sig
+	state.reused[way] = false
+	state.tracked[way] = true
+
+	if p.shct[sig] == 0 {
+		state.rrpv[way] = rripMaxRRPV
+	} else {
+		state.rrpv[way] = rripMaxRRPV - 1
+	}
+}
+
+func (p *SHiPPolicy) signature(block *Block) uint64 {
+	region := block.Tag >> shipRegionLog2Size
+	hash := region ^ (region >> shipSignatureBits) ^ uint64(block.PID)
+
+	return hash & (1<<shipSignatureBits - 1)
+}
+
+// ShouldBypass returns false, as the policy allocates a block on every miss.
+func (p *SHiPPolicy) ShouldBypass(_ *Set, _ vm.PID, _ uint64) bool {
+	return false
+}
@@ -0,0 +1,65 @@
+package cache
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SHiP Policy", func() {
+	var (
+		policy    *SHiPPolicy
+		directory *DirectoryImpl
+		set       *Set
+	)
+
+	BeforeEach(func() {
+		policy = NewSHiPPolicy()
+		directory = NewDirectory(1, 4, 64, policy)
+		set = &directory.Sets[0]
+	})
+
+	insert := func(block *Block, addr uint64) {
+		block.IsValid = true
+		block.Tag = addr
+		directory.Insert(block)
+	}
+
+	rrpv := func() []int {
+		return set.PolicyState.(*shipSetState).rrpv
+	}
+
+	It("should insert with a long re-reference interval by default", func() {
+		insert(set.Blocks[0], 0x100)
+
+		Expect(rrpv()[0]).To(Equal(2))
+	})
+
+	It("should learn from lines that are not reused", func() {
+		insert(set.Blocks[0], 0x100)
+		insert(set.Blocks[0], 0x140)
+		insert(set.Blocks[0], 0x180)
+
+		Expect(rrpv()[0]).To(Equal(3))
+	})
+
+	It("should learn from lines that are reused", func() {
+		insert(set.Blocks[0], 0x100)
+		insert(set.Blocks[0], 0x140)
+		insert(set.Blocks[1], 0x180)
+		directory.Visit(set.Blocks[1])
+
+		insert(set.Blocks[2], 0x1c0)
+
+		Expect(rrpv()[1]).To(Equal(0))
+		Expect(rrpv()[2]).To(Equal(2))
+	})
+
+	It("should use separate signatures for different regions", func() {
+		insert(set.Blocks[0], 0x100)
+		insert(set.Blocks[0], 0x140)
+
+		insert(set.Blocks[1], 0x100000)
+
+		Expect(rrpv()[1]).To(Equal(2))
+	})
+})
@@ -0,0 +1,78 @@
+package cache
+
+import (
+	"strings"
+
+	"github.com/sarchlab/akita/v3/mem/mem"
+	"github.com/sarchlab/akita/v3/metrics"
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+// CountedSteps are the steps of the cache transactions that Stats counts.
+var CountedSteps = []string{
+	"read-hit",
+	"read-miss",
+	"read-mshr-hit",
+	"read-sector-miss",
+	"write-hit",
+	"write-miss",
+	"write-mshr-hit",
+	"write-sector-miss",
+	"prefetch-issue",
+	"prefetch-hit",
+	"prefetch-late",
+}
+
+// Stats are the metrics of a cache. The number of each step in CountedSteps
+// is counted with a counter that is named after the step, with the dashes
+// replaced by underscores (e.g., "read_hit"). The latency of the reads and
+// the writes, from the arrival at the cache to the response, is recorded in
+// the "req_latency" histogram.
+//
+// The zero value of Stats does not record anything.
+type Stats struct {
+	steps      map[string]*metrics.Counter
+	reqLatency *metrics.Histogram
+}
+
+// NewStats registers the metrics of the cache with the given name in the
+// metrics registry.
+func NewStats(registry *metrics.Registry, name string) Stats {
+	s := Stats{
+		steps: make(map[string]*metrics.Counter),
+		reqLatency: registry.Histogram(
+			name, "req_latency", metrics.DefaultLatencyBuckets),
+	}
+
+	for _, step := range CountedSteps {
+		s.steps[step] = registry.Counter(name, StepMetricName(step))
+	}
+
+	return s
+}
+
+// StepMetricName returns the name of the counter of a step.
+func StepMetricName(step string) string {
+	return strings.ReplaceAll(step, "-", "_")
+}
+
+// CountStep counts a step. The steps that are not in CountedSteps are
+// ignored.
+func (s Stats) CountStep(step string) {
+	counter, found := s.steps[step]
+	if !found {
+		return
+	}
+
+	counter.Inc()
+}
+
+// ObserveReqLatency records the latency of a request that the cache responds
+// to at the given time.
+func (s Stats) ObserveReqLatency(now sim.VTimeInSec, req mem.AccessReq) {
+	if s.reqLatency == nil {
+		return
+	}
+
+	s.reqLatency.Observe(float64(now - req.Meta().RecvTime))
+}
@@ -0,0 +1,28 @@
+package cache
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/akita/v3/metrics"
+)
+
+var _ = Describe("Stats", func() {
+	It("should register the metrics in the given registry", func() {
+		registry := metrics.NewRegistry()
+		stats := NewStats(registry, "Cache")
+
+		stats.CountStep("read-hit")
+		stats.CountStep("read-hit")
+		stats.CountStep("unknown-step")
+
+		m, found := registry.Lookup("Cache", "read_hit")
+		Expect(found).To(BeTrue())
+		Expect(m.(*metrics.Counter).Value()).To(Equal(2.0))
+
+		_, found = registry.Lookup("Cache", "req_latency")
+		Expect(found).To(BeTrue())
+
+		_, found = metrics.DefaultRegistry().Lookup("Cache", "read_hit")
+		Expect(found).To(BeFalse())
+	})
+})
@@ -0,0 +1,112 @@
+package cache
+
+const streamBufferNumStreams = 8
+
+type stream struct {
+	valid bool
+	pid   uint64
+
+	// lastLine is the last line of the stream that is accessed by demand
+	// requests. tailLine is the last line that is prefetched.
+	lastLine uint64
+	tailLine uint64
+	lastUse  uint64
+}
+
+// StreamBufferPrefetcher follows a number of sequential streams [Jouppi,
+// ISCA'90]. A miss that does not belong to any stream allocates a new stream,
+// replacing the least recently used one, and the next degree lines are
+// fetched. When the stream is accessed again, the prefetcher keeps the stream
+// degree lines ahead of the last access.
+//
+// The original stream buffers hold the prefetched lines outside of the cache.
+// Here, the lines are prefetched into the cache, and the stream buffers only
+// track the addresses.
+type StreamBufferPrefetcher struct {
+	log2BlockSize uint64
+	degree        int
+	streams       []stream
+	useCount      uint64
+}
+
+// NewStreamBufferPrefetcher creates a new StreamBufferPrefetcher.
+func NewStreamBufferPrefetcher(
+	log2BlockSize uint64,
+	degree int,
+) *StreamBufferPrefetcher {
+	return &StreamBufferPrefetcher{
+		log2BlockSize: log2BlockSize,
+		degree:        degree,
+		streams:       make([]stream, streamBufferNumStreams),
+	}
+}
+
+// Notify advances the stream that the trigger belongs to, or allocates a new
+// stream, and returns the lines that the stream has not fetched.
+func (p *StreamBufferPrefetcher) Notify(trigger PrefetchTrigger) []uint64 {
+	line := cacheLineAddr(trigger.Address, p.log2BlockSize)
+	pid := uint64(trigger.PID)
+	p.useCount++
+
+	s := p.findStream(pid, line)
+	if s == nil {
+		s = p.allocateStream()
+		*s = stream{
+			valid:    true,
+			pid:      pid,
+			lastLine: line,
+			tailLine: line,
+		}
+	}
+
+	s.lastLine = line
+	s.lastUse = p.useCount
+
+	blockSize := uint64(1) << p.log2BlockSize
+	var addrs []uint64
+	for i := 1; i <= p.degree; i++ {
+		addr := line + uint64(i)*blockSize
+		if !inSamePrefetchPage(addr, line) {
+			break
+		}
+
+		if addr <= s.tailLine {
+			continue
+		}
+
+		addrs = append(addrs, addr)
+		s.tailLine = addr
+	}
+
+	return addrs
+}
+
+// findStream returns the stream whose window, from the last accessed line
+// to the last prefetched line, covers the line.
+func (p *StreamBufferPrefetcher) findStream(pid, line uint64) *stream {
+	for i := range p.streams {
+		s := &p.streams[i]
+		if s.valid && s.pid == pid &&
+			line >= s.lastLine && line <= s.tailLine {
+			return s
+		}
+	}
+
+	return nil
+}
+
+func (p *StreamBufferPrefetcher) allocateStream() *stream {
+	victim := &p.streams[0]
+	for i := range p.streams {
+		s := &p.streams[i]
+		if !s.valid {
+			return s
+		}
+
+		if s.lastUse < victim.lastUse {
+			victim = s
+		}
+	}
+
+	return victim
+}
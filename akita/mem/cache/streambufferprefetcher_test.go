@@ -0,0 +1,54 @@
+package cache
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Stream Buffer Prefetcher", func() {
+	var (
+		prefetcher *StreamBufferPrefetcher
+	)
+
+	BeforeEach(func() {
+		prefetcher = NewStreamBufferPrefetcher(6, 2)
+	})
+
+	It("should allocate a stream on a miss", func() {
+		addrs := prefetcher.Notify(PrefetchTrigger{Address: 0x1000})
+
+		Expect(addrs).To(Equal([]uint64{0x1040, 0x1080}))
+	})
+
+	It("should only fetch the lines that the stream has not fetched", func() {
+		prefetcher.Notify(PrefetchTrigger{Address: 0x1000})
+
+		addrs := prefetcher.Notify(PrefetchTrigger{Address: 0x1040})
+
+		Expect(addrs).To(Equal([]uint64{0x10C0}))
+	})
+
+	It("should follow multiple streams", func() {
+		prefetcher.Notify(PrefetchTrigger{Address: 0x1000})
+		prefetcher.Notify(PrefetchTrigger{Address: 0x5000})
+
+		Expect(prefetcher.Notify(PrefetchTrigger{Address: 0x1080})).
+			To(Equal([]uint64{0x10C0, 0x1100}))
+		Expect(prefetcher.Notify(PrefetchTrigger{Address: 0x5040})).
+			To(Equal([]uint64{0x50C0}))
+	})
+
+	It("should replace the least recently used stream", func() {
+		for i := 0; i < streamBufferNumStreams; i++ {
+			prefetcher.Notify(PrefetchTrigger{Address: uint64(i+1) * 0x10000})
+		}
+		prefetcher.Notify(PrefetchTrigger{Address: 0x10040})
+
+		prefetcher.Notify(PrefetchTrigger{Address: 0x100000})
+
+		Expect(prefetcher.Notify(PrefetchTrigger{Address: 0x20040})).
+			To(Equal([]uint64{0x20080, 0x200C0}))
+		Expect(prefetcher.Notify(PrefetchTrigger{Address: 0x10080})).
+			To(Equal([]uint64{0x10100}))
+	})
+})
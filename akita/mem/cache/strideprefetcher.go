@@ -0,0 +1,134 @@
+package cache
+
+const (
+	strideTableSize         = 256
+	strideMaxConfidence     = 3
+	strideConfidenceToIssue = 2
+)
+
+type strideEntry struct {
+	valid      bool
+	key        uint64
+	lastAddr   uint64
+	stride     int64
+	confidence int
+}
+
+// StridePrefetcher detects the constant strides between the accesses of the
+// same stream and fetches the lines ahead of the stream [Chen and Baer,
+// IEEE TC'95].
+//
+// A stream is the accesses issued by the same instruction if the requester
+// attaches the PC to the requests. Otherwise, the accesses to the same page
+// are considered as a stream. The streams are tracked by a direct-mapped
+// table. Each entry learns the first stride of its stream and gains one
+// confidence each time the stride repeats. The prefetcher fetches the next
+// degree strides once the confidence reaches strideConfidenceToIssue, which
+// happens when the same stride is observed three times in a row (four
+// accesses). A different stride costs one confidence, and the entry only
+// learns the new stride when the confidence drops to zero.
+type StridePrefetcher struct {
+	log2BlockSize uint64
+	degree        int
+	table         []strideEntry
+}
+
+// NewStridePrefetcher creates a new StridePrefetcher.
+func NewStridePrefetcher(log2BlockSize uint64, degree int) *StridePrefetcher {
+	return &StridePrefetcher{
+		log2BlockSize: log2BlockSize,
+		degree:        degree,
+		table:         make([]strideEntry, strideTableSize),
+	}
+}
+
+// Notify trains the entry of the stream and returns the lines ahead of the
+// stream if the stride is confirmed.
+func (p *StridePrefetcher) Notify(trigger PrefetchTrigger) []uint64 {
+	key := p.streamKey(trigger)
+	entry := &p.table[p.tableIndex(key)]
+
+	if !entry.valid || entry.key != key {
+		*entry = strideEntry{
+			valid:    true,
+			key:      key,
+			lastAddr: trigger.Address,
+		}
+
+		return nil
+	}
+
+	stride := int64(trigger.Address - entry.lastAddr)
+	entry.lastAddr = trigger.Address
+
+	if stride == 0 {
+		return nil
+	}
+
+	if stride == entry.stride {
+		if entry.confidence < strideMaxConfidence {
+			entry.confidence++
+		}
+	} else {
+		if entry.confidence > 0 {
+			entry.confidence--
+		}
+
+		if entry.confidence == 0 {
+			entry.stride = stride
+		}
+	}
+
+	if entry.confidence < strideConfidenceToIssue {
+		return nil
+	}
+
+	return p.linesAhead(trigger.Address, entry.stride)
+}
+
+func (p *StridePrefetcher) streamKey(trigger PrefetchTrigger) uint64 {
+	if trigger.HasPC {
+		return trigger.PC ^ uint64(trigger.PID)<<48
+	}
+
+	page := trigger.Address >> log2PrefetchPageSize
+
+	return page ^ uint64(trigger.PID)<<48
+}
+
+func (p *StridePrefetcher) tableIndex(key uint64) uint64 {
+	// The PCs are aligned to the instruction size, so the lowest bits are
+	// mixed with the higher bits.
+	return (key ^ key>>2 ^ key>>10) % strideTableSize
+}
+
+func (p *StridePrefetcher) linesAhead(addr uint64, stride int64) []uint64 {
+	line := cacheLineAddr(addr, p.log2BlockSize)
+
+	var addrs []uint64
+	for i := 1; i <= p.degree; i++ {
+		target := uint64(int64(addr) + int64(i)*stride)
+		if !inSamePrefetchPage(target, addr) {
+			break
+		}
+
+		targetLine := cacheLineAddr(target, p.log2BlockSize)
+		if targetLine == line || containsAddr(addrs, targetLine) {
+			continue
+		}
+
+		addrs = append(addrs, targetLine)
+	}
+
+	return addrs
+}
+
+func containsAddr(addrs []uint64, addr uint64) bool {
+	for _, a := range addrs {
+		if a == addr {
+			return true
+		}
+	}
+
+	return false
+}
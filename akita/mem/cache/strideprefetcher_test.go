@@ -0,0 +1,79 @@
+package cache
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Stride Prefetcher", func() {
+	var (
+		prefetcher *StridePrefetcher
+	)
+
+	BeforeEach(func() {
+		prefetcher = NewStridePrefetcher(6, 2)
+	})
+
+	trigger := func(pc, addr uint64) PrefetchTrigger {
+		return PrefetchTrigger{Address: addr, PC: pc, HasPC: true}
+	}
+
+	It("should not prefetch before the stride is confirmed", func() {
+		Expect(prefetcher.Notify(trigger(0x100, 0x1000))).To(BeEmpty())
+		Expect(prefetcher.Notify(trigger(0x100, 0x1100))).To(BeEmpty())
+	})
+
+	It("should prefetch along a confirmed stride", func() {
+		prefetcher.Notify(trigger(0x100, 0x1000))
+		prefetcher.Notify(trigger(0x100, 0x1100))
+		prefetcher.Notify(trigger(0x100, 0x1200))
+
+		addrs := prefetcher.Notify(trigger(0x100, 0x1300))
+
+		Expect(addrs).To(Equal([]uint64{0x1400, 0x1500}))
+	})
+
+	It("should prefetch along negative strides", func() {
+		prefetcher.Notify(trigger(0x100, 0x1800))
+		prefetcher.Notify(trigger(0x100, 0x1700))
+		prefetcher.Notify(trigger(0x100, 0x1600))
+
+		addrs := prefetcher.Notify(trigger(0x100, 0x1500))
+
+		Expect(addrs).To(Equal([]uint64{0x1400, 0x1300}))
+	})
+
+	It("should track the instructions separately", func() {
+		prefetcher.Notify(trigger(0x100, 0x1000))
+		prefetcher.Notify(trigger(0x200, 0x3000))
+		prefetcher.Notify(trigger(0x100, 0x1100))
+		prefetcher.Notify(trigger(0x200, 0x3040))
+		prefetcher.Notify(trigger(0x100, 0x1200))
+		prefetcher.Notify(trigger(0x200, 0x3080))
+
+		Expect(prefetcher.Notify(trigger(0x100, 0x1300))).
+			To(Equal([]uint64{0x1400, 0x1500}))
+		Expect(prefetcher.Notify(trigger(0x200, 0x30C0))).
+			To(Equal([]uint64{0x3100, 0x3140}))
+	})
+
+	It("should group the accesses by page if the PC is unknown", func() {
+		prefetcher.Notify(PrefetchTrigger{Address: 0x1000})
+		prefetcher.Notify(PrefetchTrigger{Address: 0x1080})
+		prefetcher.Notify(PrefetchTrigger{Address: 0x1100})
+
+		addrs := prefetcher.Notify(PrefetchTrigger{Address: 0x1180})
+
+		Expect(addrs).To(Equal([]uint64{0x1200, 0x1280}))
+	})
+
+	It("should not cross the page boundary", func() {
+		prefetcher.Notify(trigger(0x100, 0x1D00))
+		prefetcher.Notify(trigger(0x100, 0x1E00))
+		prefetcher.Notify(trigger(0x100, 0x1F00))
+
+		addrs := prefetcher.Notify(trigger(0x100, 0x1F80))
+
+		Expect(addrs).To(BeEmpty())
+	})
+})
@@ -1,5 +1,7 @@
 package cache
 
+import "github.com/sarchlab/akita/v3/mem/vm"
+
 // A VictimFinder decides with block should be evicted
 type VictimFinder interface {
 	FindVictim(set *Set) *Block
@@ -32,3 +34,22 @@ func (e *LRUVictimFinder) FindVictim(set *Set) *Block {
 
 	return set.LRUQueue[0]
 }
+
+// InitSet does nothing, as the directory initializes the LRUQueue.
+func (e *LRUVictimFinder) InitSet(set *Set, _ int) {
+}
+
+// OnHit moves the block to the most recently used position.
+func (e *LRUVictimFinder) OnHit(set *Set, block *Block) {
+	moveToLRUQueueEnd(set, block)
+}
+
+// OnInsert moves the block to the most recently used position.
+func (e *LRUVictimFinder) OnInsert(set *Set, block *Block) {
+	moveToLRUQueueEnd(set, block)
+}
+
+// ShouldBypass returns false, as the policy allocates a block on every miss.
+func (e *LRUVictimFinder) ShouldBypass(_ *Set, _ vm.PID, _ uint64) bool {
+	return false
+}
@@ -0,0 +1,87 @@
+package cache
+
+import (
+	"github.com/sarchlab/akita/v3/mem/mem"
+	"github.com/sarchlab/akita/v3/mem/vm"
+)
+
+// WarmUp functionally brings the line that contains the address into the
+// directory, as if the line is fetched by a read, without moving any data or
+// spending any time. It returns the block that holds the line, or nil if the
+// line cannot be brought in because the victim is in use or because the
+// replacement policy bypasses the line.
+//
+// Warming up only updates the tags and the replacement states. The data of
+// the warmed-up blocks is loaded with Refill. The memory is expected to hold
+// the latest data of all the lines while warming up, so a dirty victim is
+// dropped without being written back.
+func WarmUp(
+	dir Directory,
+	pid vm.PID,
+	addr uint64,
+	log2BlockSize uint64,
+) *Block {
+	lineAddr := addr >> log2BlockSize << log2BlockSize
+
+	block := dir.Lookup(pid, lineAddr)
+	if block != nil {
+		dir.Visit(block)
+		return block
+	}
+
+	if dir.ShouldBypass(pid, lineAddr) {
+		return nil
+	}
+
+	block = dir.FindVictim(lineAddr)
+	if block.IsLocked || block.ReadCount > 0 {
+		return nil
+	}
+
+	block.PID = pid
+	block.Tag = lineAddr
+	block.IsValid = true
+	block.IsDirty = false
+	block.DirtyMask = nil
+	block.SectorValid = nil
+	block.IsPrefetched = false
+	dir.Insert(block)
+
+	return block
+}
+
+// Refill loads the data of all the valid blocks from the memory into the
+// storage of the cache. It is used after the memory is modified without going
+// through the cache, for example, after fast-forwarding with functional
+// emulation, when the memory holds the latest data of all the lines. The
+// blocks that are marked dirty while warming up keep their dirty masks, so
+// that they are written back when evicted. The memory is addressed with the
+// tags of the blocks.
+func Refill(
+	dir Directory,
+	storage *mem.Storage,
+	memory *mem.Storage,
+	log2BlockSize uint64,
+) error {
+	blockSize := uint64(1) << log2BlockSize
+
+	for _, set := range dir.GetSets() {
+		for _, block := range set.Blocks {
+			if !block.IsValid {
+				continue
+			}
+
+			data, err := memory.Read(block.Tag, blockSize)
+			if err != nil {
+				return err
+			}
+
+			err = storage.Write(block.CacheAddress, data)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
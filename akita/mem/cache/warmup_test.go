@@ -0,0 +1,89 @@
+package cache
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/akita/v3/mem/mem"
+)
+
+var _ = Describe("WarmUp", func() {
+	var (
+		directory *DirectoryImpl
+	)
+
+	BeforeEach(func() {
+		directory = NewDirectory(4, 2, 64, NewLRUVictimFinder())
+	})
+
+	It("should bring the line into the directory", func() {
+		block := WarmUp(directory, 1, 0x1048, 6)
+
+		Expect(block).NotTo(BeNil())
+		Expect(block.IsValid).To(BeTrue())
+		Expect(block.IsDirty).To(BeFalse())
+		Expect(block.Tag).To(Equal(uint64(0x1040)))
+		Expect(directory.Lookup(1, 0x1040)).To(BeIdenticalTo(block))
+	})
+
+	It("should visit the line if the line is already in the directory",
+		func() {
+			block := WarmUp(directory, 1, 0x1040, 6)
+			WarmUp(directory, 1, 0x1140, 6)
+
+			Expect(WarmUp(directory, 1, 0x1040, 6)).To(BeIdenticalTo(block))
+
+			set := directory.Sets[block.SetID]
+			Expect(set.LRUQueue[len(set.LRUQueue)-1]).
+				To(BeIdenticalTo(block))
+		})
+
+	It("should drop dirty victims", func() {
+		for _, b := range directory.Sets[1].Blocks {
+			b.IsValid = true
+			b.IsDirty = true
+			b.DirtyMask = make([]bool, 64)
+		}
+
+		block := WarmUp(directory, 1, 0x1040, 6)
+
+		Expect(block).NotTo(BeNil())
+		Expect(block.IsDirty).To(BeFalse())
+		Expect(block.DirtyMask).To(BeNil())
+		Expect(directory.Lookup(1, 0x1040)).To(BeIdenticalTo(block))
+	})
+
+	It("should not evict locked lines", func() {
+		for _, b := range directory.Sets[1].Blocks {
+			b.IsValid = true
+			b.IsLocked = true
+		}
+
+		Expect(WarmUp(directory, 1, 0x1040, 6)).To(BeNil())
+	})
+})
+
+var _ = Describe("Refill", func() {
+	It("should load all the valid lines from the memory", func() {
+		directory := NewDirectory(4, 2, 64, NewLRUVictimFinder())
+		storage := mem.NewStorage(directory.TotalSize())
+		memory := mem.NewStorage(4 * mem.KB)
+		data := make([]byte, 64)
+		for i := range data {
+			data[i] = byte(i)
+		}
+		Expect(memory.Write(0x40, data)).To(Succeed())
+		Expect(memory.Write(0x80, data)).To(Succeed())
+
+		clean := WarmUp(directory, 1, 0x40, 6)
+		dirty := WarmUp(directory, 1, 0x80, 6)
+		dirty.IsDirty = true
+
+		Expect(Refill(directory, storage, memory, 6)).To(Succeed())
+
+		cleanData, _ := storage.Read(clean.CacheAddress, 64)
+		Expect(cleanData).To(Equal(data))
+		dirtyData, _ := storage.Read(dirty.CacheAddress, 64)
+		Expect(dirtyData).To(Equal(data))
+		Expect(dirty.IsDirty).To(BeTrue())
+	})
+})
@@ -5,6 +5,7 @@ import (
 
 	"github.com/sarchlab/akita/v3/mem/cache"
 	"github.com/sarchlab/akita/v3/mem/mem"
+	"github.com/sarchlab/akita/v3/metrics"
 	"github.com/sarchlab/akita/v3/pipelining"
 	"github.com/sarchlab/akita/v3/sim"
 	"github.com/sarchlab/akita/v3/tracing"
@@ -25,6 +26,10 @@ type Builder struct {
 	maxNumConcurrentTrans int
 	lowModuleFinder       mem.LowModuleFinder
 	visTracer             tracing.Tracer
+	replacementPolicy     cache.ReplacementPolicyType
+	prefetcher            cache.PrefetcherType
+	prefetchDegree        int
+	metrics               *metrics.Registry
 }
 
 // NewBuilder creates a builder with default parameter setting
@@ -40,6 +45,8 @@ func NewBuilder() *Builder {
 		maxNumConcurrentTrans: 16,
 		dirLatency:            2,
 		bankLatency:           20,
+		prefetchDegree:        2,
+		metrics:               metrics.DefaultRegistry(),
 	}
 }
 
@@ -128,6 +135,36 @@ func (b *Builder) WithLowModuleFinder(
 	return b
 }
 
+// WithReplacementPolicy sets the policy that the cache uses to select the
+// blocks to evict.
+func (b *Builder) WithReplacementPolicy(
+	policy cache.ReplacementPolicyType,
+) *Builder {
+	b.replacementPolicy = policy
+	return b
+}
+
+// WithPrefetcher sets the prefetcher that the cache uses. By default, the
+// cache does not prefetch.
+func (b *Builder) WithPrefetcher(prefetcher cache.PrefetcherType) *Builder {
+	b.prefetcher = prefetcher
+	return b
+}
+
+// WithPrefetchDegree sets the number of cache lines that the prefetcher
+// fetches ahead.
+func (b *Builder) WithPrefetchDegree(n int) *Builder {
+	b.prefetchDegree = n
+	return b
+}
+
+// WithMetricsRegistry sets the registry that the metrics of the cache are
+// registered in. By default, the default registry is used.
+func (b *Builder) WithMetricsRegistry(registry *metrics.Registry) *Builder {
+	b.metrics = registry
+	return b
+}
+
 // Build returns a new cache unit
 func (b *Builder) Build(name string) *Cache {
 	b.assertAllRequiredInformationIsAvailable()
@@ -138,6 +175,7 @@ func (b *Builder) Build(name string) *Cache {
 	}
 	c.TickingComponent = sim.NewTickingComponent(
 		name, b.engine, b.freq, c)
+	c.stats = cache.NewStats(b.metrics, name)
 
 	c.topPort = sim.NewLimitNumMsgPort(c, b.numReqPerCycle, name+".TopPort")
 	c.AddPort("Top", c.topPort)
@@ -162,12 +200,15 @@ func (b *Builder) Build(name string) *Cache {
 	numSets := int(b.totalByteSize / uint64(b.wayAssociativity*blockSize))
 	c.directory = cache.NewDirectory(
 		numSets, b.wayAssociativity, 1<<b.log2BlockSize,
-		cache.NewLRUVictimFinder())
+		cache.NewReplacementPolicy(b.replacementPolicy))
 	c.storage = mem.NewStorage(b.totalByteSize)
 	c.bankLatency = b.bankLatency
 	c.wayAssociativity = b.wayAssociativity
 	c.lowModuleFinder = b.lowModuleFinder
 	c.maxNumConcurrentTrans = b.maxNumConcurrentTrans
+	c.prefetcher = cache.NewPrefetcher(
+		b.prefetcher, b.log2BlockSize, b.prefetchDegree)
+	c.prefetchQueueSize = b.numMSHREntry
 
 	b.buildStages(c)
 
@@ -184,6 +225,7 @@ func (b *Builder) buildStages(c *Cache) {
 	b.buildBankStages(c)
 	c.parseBottomStage = &bottomParser{cache: c}
 	c.respondStage = &respondStage{cache: c}
+	c.fenceStage = &fenceStage{cache: c}
 
 	c.controlStage = &controlStage{
 		ctrlPort:     c.controlPort,
@@ -3,7 +3,9 @@ package writearound
 import (
 	"github.com/sarchlab/akita/v3/mem/cache"
 	"github.com/sarchlab/akita/v3/mem/mem"
+	"github.com/sarchlab/akita/v3/mem/vm"
 	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/akita/v3/tracing"
 )
 
 // A Cache is a customized L1 cache the for R9nano GPUs.
@@ -32,11 +34,18 @@ type Cache struct {
 	parseBottomStage *bottomParser
 	respondStage     *respondStage
 	controlStage     *controlStage
+	fenceStage       *fenceStage
+
+	prefetcher        cache.Prefetcher
+	prefetchQueue     []*transaction
+	prefetchQueueSize int
 
 	maxNumConcurrentTrans    int
 	transactions             []*transaction
 	postCoalesceTransactions []*transaction
 
+	stats cache.Stats
+
 	isPaused bool
 }
 
@@ -46,6 +55,23 @@ func (c *Cache) SetLowModuleFinder(lmf mem.LowModuleFinder) {
 	c.lowModuleFinder = lmf
 }
 
+// addTaskStep adds a step to the task of a transaction and counts the step.
+func (c *Cache) addTaskStep(taskID, step string) {
+	tracing.AddTaskStep(taskID, c, step)
+	c.stats.CountStep(step)
+}
+
+// WarmUp functionally brings the line that contains the address into the
+// cache without spending any time. The data is loaded later with Refill.
+func (c *Cache) WarmUp(pid vm.PID, addr uint64) {
+	cache.WarmUp(c.directory, pid, addr, c.log2BlockSize)
+}
+
+// Refill reloads the data of all the valid lines from the memory.
+func (c *Cache) Refill(memory *mem.Storage) error {
+	return cache.Refill(c.directory, c.storage, memory, c.log2BlockSize)
+}
+
 // Tick update the state of the cache
 func (c *Cache) Tick(now sim.VTimeInSec) bool {
 	madeProgress := false
@@ -62,6 +88,7 @@ func (c *Cache) Tick(now sim.VTimeInSec) bool {
 func (c *Cache) runPipeline(now sim.VTimeInSec) bool {
 	madeProgress := false
 	madeProgress = c.tickRespondStage(now) || madeProgress
+	madeProgress = c.fenceStage.Tick(now) || madeProgress
 	madeProgress = c.tickParseBottomStage(now) || madeProgress
 	madeProgress = c.tickBankStage(now) || madeProgress
 	madeProgress = c.tickDirectoryStage(now) || madeProgress
@@ -0,0 +1,99 @@
+package writearound
+
+import (
+	"fmt"
+
+	"github.com/sarchlab/akita/v3/mem/cache"
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+type checkpointState struct {
+	IsPaused bool
+}
+
+// CheckCheckpointable returns an error if the cache uses a replacement policy
+// or a prefetcher that cannot be saved in a checkpoint.
+func (c *Cache) CheckCheckpointable() error {
+	return cache.CheckCheckpointable(c.directory, c.prefetcher)
+}
+
+// SaveState saves the content and the replacement metadata of the cache. The
+// cache can only be saved when it has no transaction in flight.
+func (c *Cache) SaveState(cp *sim.Checkpoint) error {
+	if !c.isDrained() {
+		return fmt.Errorf("cache %s has transactions in flight", c.Name())
+	}
+
+	err := c.CheckCheckpointable()
+	if err != nil {
+		return err
+	}
+
+	err = cp.Put(c.Name(), checkpointState{IsPaused: c.isPaused})
+	if err != nil {
+		return err
+	}
+
+	err = cache.SaveDirectoryAs(cp, c.Name()+".Directory", c.directory)
+	if err != nil {
+		return err
+	}
+
+	err = c.storage.SaveStateAs(cp, c.Name()+".Storage")
+	if err != nil {
+		return err
+	}
+
+	return c.TickScheduler.SaveStateAs(cp, c.Name()+".TickScheduler")
+}
+
+// LoadState restores the content and the replacement metadata of the cache.
+func (c *Cache) LoadState(cp *sim.Checkpoint) error {
+	state := checkpointState{}
+	err := cp.Get(c.Name(), &state)
+	if err != nil {
+		return err
+	}
+
+	c.isPaused = state.IsPaused
+
+	err = cache.LoadDirectoryAs(cp, c.Name()+".Directory", c.directory)
+	if err != nil {
+		return err
+	}
+
+	err = c.storage.LoadStateAs(cp, c.Name()+".Storage")
+	if err != nil {
+		return err
+	}
+
+	return c.TickScheduler.LoadStateAs(cp, c.Name()+".TickScheduler")
+}
+
+func (c *Cache) isDrained() bool {
+	if len(c.transactions) > 0 ||
+		len(c.postCoalesceTransactions) > 0 ||
+		len(c.prefetchQueue) > 0 ||
+		len(c.mshr.AllEntries()) > 0 ||
+		len(c.coalesceStage.toCoalesce) > 0 {
+		return false
+	}
+
+	if c.controlStage.currFlushReq != nil ||
+		c.fenceStage.isProcessing() ||
+		len(c.fenceStage.pendingFences) > 0 {
+		return false
+	}
+
+	if c.dirBuf.Size() > 0 {
+		return false
+	}
+
+	for _, buf := range c.bankBufs {
+		if buf.Size() > 0 {
+			return false
+		}
+	}
+
+	return true
+}
@@ -30,6 +30,7 @@ var _ = Describe("Coalescer", func() {
 		}
 		cache.TickingComponent = sim.NewTickingComponent(
 			"Cache", nil, 1, cache)
+		cache.fenceStage = &fenceStage{cache: cache}
 		c = coalescer{cache: cache}
 	})
 
@@ -120,6 +121,50 @@ var _ = Describe("Coalescer", func() {
 			})
 		})
 
+		Context("fence", func() {
+			var fence *mem.FenceReq
+
+			BeforeEach(func() {
+				fence = mem.FenceReqBuilder{}.
+					WithSendTime(12).
+					WithPID(1).
+					WithScope(mem.ScopeAgent).
+					WithAcquire().
+					Build()
+			})
+
+			It("should send pending requests and start the fence", func() {
+				dirBuf.EXPECT().CanPush().Return(true)
+				dirBuf.EXPECT().Push(gomock.Any())
+				topPort.EXPECT().Peek().Return(fence)
+				topPort.EXPECT().Retrieve(gomock.Any())
+
+				madeProgress := c.Tick(13)
+
+				Expect(madeProgress).To(BeTrue())
+				Expect(c.toCoalesce).To(BeEmpty())
+				Expect(cache.fenceStage.fence).To(BeIdenticalTo(fence))
+			})
+
+			It("should stall if cannot send pending requests", func() {
+				dirBuf.EXPECT().CanPush().Return(false)
+				topPort.EXPECT().Peek().Return(fence)
+
+				madeProgress := c.Tick(13)
+
+				Expect(madeProgress).To(BeFalse())
+				Expect(cache.fenceStage.isProcessing()).To(BeFalse())
+			})
+
+			It("should not accept requests while processing a fence", func() {
+				cache.fenceStage.fence = fence
+
+				madeProgress := c.Tick(13)
+
+				Expect(madeProgress).To(BeFalse())
+			})
+		})
+
 		Context("last in wave, coalescable", func() {
 			It("should send to dir stage", func() {
 				read3 := mem.ReadReqBuilder{}.
@@ -68,9 +68,11 @@ func (s *controlStage) hardResetCache(now sim.VTimeInSec) {
 	for _, bankStage := range s.cache.bankStages {
 		bankStage.Reset()
 	}
+	s.cache.fenceStage.Reset()
 
 	s.cache.transactions = nil
 	s.cache.postCoalesceTransactions = nil
+	s.cache.prefetchQueue = nil
 
 	if s.currFlushReq.PauseAfterFlushing {
 		s.cache.isPaused = true
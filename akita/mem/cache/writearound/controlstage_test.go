@@ -45,6 +45,7 @@ var _ = Describe("Control Stage", func() {
 		}
 		cache.TickingComponent = sim.NewTickingComponent(
 			"Cache", nil, 1, cache)
+		cache.fenceStage = &fenceStage{cache: cache}
 
 		s = &controlStage{
 			ctrlPort:     ctrlPort,
@@ -41,6 +41,8 @@ func (d *directory) Tick(now sim.VTimeInSec) (madeProgress bool) {
 		madeProgress = true
 	}
 
+	madeProgress = d.acceptPrefetch(now) || madeProgress
+
 	madeProgress = d.pipeline.Tick(now) || madeProgress
 
 	for i := 0; i < d.cache.numReqPerCycle; i++ {
@@ -51,6 +53,11 @@ func (d *directory) Tick(now sim.VTimeInSec) (madeProgress bool) {
 
 		trans := item.(dirPipelineItem).trans
 
+		if trans.prefetch {
+			madeProgress = d.processPrefetch(now, trans) || madeProgress
+			continue
+		}
+
 		if trans.read != nil {
 			madeProgress = d.processRead(now, trans) || madeProgress
 			continue
@@ -76,6 +83,10 @@ func (d *directory) processRead(now sim.VTimeInSec, trans *transaction) bool {
 
 	block := d.cache.directory.Lookup(pid, cacheLineID)
 	if block != nil && block.IsValid {
+		if read.Scope > mem.ScopeWorkgroup {
+			return d.processCoherentReadHit(now, trans, block)
+		}
+
 		return d.processReadHit(now, trans, block)
 	}
 
@@ -90,9 +101,15 @@ func (d *directory) processMSHRHit(
 	mshrEntry.Requests = append(mshrEntry.Requests, trans)
 
 	if trans.read != nil {
-		tracing.AddTaskStep(trans.id, d.cache, "read-mshr-hit")
+		d.cache.addTaskStep(trans.id, "read-mshr-hit")
+
+		if mshrEntry.Block != nil && mshrEntry.Block.IsPrefetched {
+			mshrEntry.Block.IsPrefetched = false
+			d.cache.addTaskStep(trans.id, "prefetch-late")
+			d.notifyPrefetcher(trans.read)
+		}
 	} else {
-		tracing.AddTaskStep(trans.id, d.cache, "write-mshr-hit")
+		d.cache.addTaskStep(trans.id, "write-mshr-hit")
 	}
 
 	d.buf.Pop()
@@ -121,7 +138,39 @@ func (d *directory) processReadHit(
 	bankBuf.Push(trans)
 
 	d.buf.Pop()
-	tracing.AddTaskStep(trans.id, d.cache, "read-hit")
+	d.cache.addTaskStep(trans.id, "read-hit")
+
+	if block.IsPrefetched {
+		block.IsPrefetched = false
+		d.cache.addTaskStep(trans.id, "prefetch-hit")
+		d.notifyPrefetcher(trans.read)
+	}
+
+	return true
+}
+
+// processCoherentReadHit handles the reads that must be coherent beyond the
+// workgroup. The cached copy may be stale, so the block is fetched again from
+// the low module.
+func (d *directory) processCoherentReadHit(
+	now sim.VTimeInSec,
+	trans *transaction,
+	block *cache.Block,
+) bool {
+	if block.IsLocked || block.ReadCount > 0 {
+		return false
+	}
+
+	if d.cache.mshr.IsFull() {
+		return false
+	}
+
+	if !d.fetchFromBottom(now, trans, block) {
+		return false
+	}
+
+	d.buf.Pop()
+	d.cache.addTaskStep(trans.id, "read-miss")
 
 	return true
 }
@@ -135,21 +184,41 @@ func (d *directory) processReadMiss(
 	blockSize := uint64(1 << d.cache.log2BlockSize)
 	cacheLineID := addr / blockSize * blockSize
 
+	if d.cache.mshr.IsFull() {
+		return false
+	}
+
+	if d.cache.directory.ShouldBypass(read.PID, cacheLineID) {
+		return d.processReadBypass(now, trans)
+	}
+
 	victim := d.cache.directory.FindVictim(cacheLineID)
 	if victim.IsLocked || victim.ReadCount > 0 {
 		return false
 	}
 
-	if d.cache.mshr.IsFull() {
+	if !d.fetchFromBottom(now, trans, victim) {
 		return false
 	}
 
-	if !d.fetchFromBottom(now, trans, victim) {
+	d.buf.Pop()
+	d.cache.addTaskStep(trans.id, "read-miss")
+
+	return true
+}
+
+// processReadBypass fetches the line without allocating a block. The fetched
+// data is only sent to the requesters.
+func (d *directory) processReadBypass(
+	now sim.VTimeInSec,
+	trans *transaction,
+) bool {
+	if !d.fetchFromBottom(now, trans, nil) {
 		return false
 	}
 
 	d.buf.Pop()
-	tracing.AddTaskStep(trans.id, d.cache, "read-miss")
+	d.cache.addTaskStep(trans.id, "read-bypass")
 
 	return true
 }
@@ -186,7 +255,7 @@ func (d *directory) writeMiss(
 	trans *transaction,
 ) bool {
 	if ok := d.writeBottom(now, trans); ok {
-		tracing.AddTaskStep(trans.id, d.cache, "write-miss")
+		d.cache.addTaskStep(trans.id, "write-miss")
 		d.buf.Pop()
 		return true
 	}
@@ -204,6 +273,7 @@ func (d *directory) writeBottom(now sim.VTimeInSec, trans *transaction) bool {
 		WithDst(d.cache.lowModuleFinder.Find(addr)).
 		WithAddress(addr).
 		WithPID(write.PID).
+		WithScope(write.Scope).
 		WithData(write.Data).
 		WithDirtyMask(write.DirtyMask).
 		Build()
@@ -254,12 +324,15 @@ func (d *directory) processWriteHit(
 	trans.block = block
 	bankBuf.Push(trans)
 
-	tracing.AddTaskStep(trans.id, d.cache, "write-hit")
+	d.cache.addTaskStep(trans.id, "write-hit")
 	d.buf.Pop()
 
 	return true
 }
 
+// fetchFromBottom reads the line from the low module and allocates an MSHR
+// entry for it. The line is placed in the victim block, or only sent to the
+// requesters if the victim is nil.
 func (d *directory) fetchFromBottom(
 	now sim.VTimeInSec,
 	trans *transaction,
@@ -277,6 +350,7 @@ func (d *directory) fetchFromBottom(
 		WithDst(bottomModule).
 		WithAddress(cacheLineID).
 		WithPID(pid).
+		WithScope(trans.Scope()).
 		WithByteSize(blockSize).
 		Build()
 	err := d.cache.bottomPort.Send(readToBottom)
@@ -293,15 +367,127 @@ func (d *directory) fetchFromBottom(
 	mshrEntry.ReadReq = readToBottom
 	mshrEntry.Block = victim
 
-	victim.Tag = cacheLineID
-	victim.PID = pid
-	victim.IsValid = true
-	victim.IsLocked = true
-	d.cache.directory.Visit(victim)
+	if victim != nil {
+		victim.Tag = cacheLineID
+		victim.PID = pid
+		victim.IsValid = true
+		victim.IsLocked = true
+		victim.IsPrefetched = trans.prefetch
+		d.cache.directory.Insert(victim)
+	}
+
+	d.notifyPrefetcherOfMiss(trans)
 
 	return true
 }
 
+// acceptPrefetch lets a prefetch enter the directory pipeline. The demand
+// requests have a higher priority, so the prefetches only use the cycles in
+// which there is no demand request waiting.
+func (d *directory) acceptPrefetch(now sim.VTimeInSec) bool {
+	if len(d.cache.prefetchQueue) == 0 {
+		return false
+	}
+
+	if !d.pipeline.CanAccept() || d.cache.dirBuf.Peek() != nil {
+		return false
+	}
+
+	trans := d.cache.prefetchQueue[0]
+	d.pipeline.Accept(now, dirPipelineItem{trans})
+	d.cache.prefetchQueue = d.cache.prefetchQueue[1:]
+
+	return true
+}
+
+// processPrefetch fetches the line of a prefetch. A prefetch never waits, so
+// that it does not delay the demand requests. It is dropped if the line is
+// already cached or being fetched, if the line cannot be fetched right away,
+// or if the replacement policy would not allocate a block for the line.
+func (d *directory) processPrefetch(
+	now sim.VTimeInSec,
+	trans *transaction,
+) bool {
+	read := trans.read
+	d.buf.Pop()
+
+	if d.cache.mshr.Query(read.PID, read.Address) != nil {
+		return true
+	}
+
+	block := d.cache.directory.Lookup(read.PID, read.Address)
+	if block != nil && block.IsValid {
+		return true
+	}
+
+	if d.cache.mshr.IsFull() || !d.cache.bottomPort.CanSend() {
+		return true
+	}
+
+	if d.cache.directory.ShouldBypass(read.PID, read.Address) {
+		return true
+	}
+
+	victim := d.cache.directory.FindVictim(read.Address)
+	if victim.IsLocked || victim.ReadCount > 0 {
+		return true
+	}
+
+	tracing.StartTaskWithSpecificLocation(trans.id, "",
+		d.cache, "cache_transaction", "prefetch",
+		d.cache.Name()+".Local",
+		nil)
+
+	if !d.fetchFromBottom(now, trans, victim) {
+		tracing.EndTask(trans.id, d.cache)
+		return true
+	}
+
+	d.cache.postCoalesceTransactions =
+		append(d.cache.postCoalesceTransactions, trans)
+	d.cache.addTaskStep(trans.id, "prefetch-issue")
+
+	return true
+}
+
+// notifyPrefetcherOfMiss trains the prefetcher when a demand read allocates an
+// MSHR entry. The prefetches and the writes do not train the prefetcher.
+func (d *directory) notifyPrefetcherOfMiss(trans *transaction) {
+	if trans.read == nil || trans.prefetch {
+		return
+	}
+
+	d.notifyPrefetcher(trans.read)
+}
+
+// notifyPrefetcher trains the prefetcher with a demand read and queues the
+// lines that the prefetcher asks for. The lines that do not fit in the queue
+// are dropped.
+func (d *directory) notifyPrefetcher(read *mem.ReadReq) {
+	if d.cache.prefetcher == nil {
+		return
+	}
+
+	blockSize := uint64(1 << d.cache.log2BlockSize)
+	addrs := d.cache.prefetcher.Notify(cache.NewPrefetchTrigger(read))
+	for _, addr := range addrs {
+		if len(d.cache.prefetchQueue) >= d.cache.prefetchQueueSize {
+			return
+		}
+
+		prefetch := mem.ReadReqBuilder{}.
+			WithAddress(addr).
+			WithByteSize(blockSize).
+			WithPID(read.PID).
+			Build()
+		d.cache.prefetchQueue = append(d.cache.prefetchQueue, &transaction{
+			id:       sim.IDGeneratorFor(d.cache).Generate(),
+			read:     prefetch,
+			prefetch: true,
+		})
+	}
+}
+
 func (d *directory) getBankBuf(block *cache.Block) sim.Buffer {
 	numWaysPerSet := d.cache.directory.WayAssociativity()
 	blockID := block.SetID*numWaysPerSet + block.WayID
@@ -105,6 +105,19 @@ var _ = Describe("Directory", func() {
 			Expect(madeProgress).To(BeTrue())
 			Expect(mshrEntry.Requests).To(ContainElement(trans))
 		})
+
+		It("should not train the prefetcher", func() {
+			c.prefetcher = cache.NewNextLinePrefetcher(6, 1)
+			c.prefetchQueueSize = 4
+			mshrEntry := &cache.MSHREntry{}
+			mshr.EXPECT().Query(vm.PID(1), uint64(0x100)).Return(mshrEntry)
+			buf.EXPECT().Pop()
+
+			madeProgress := d.Tick(10)
+
+			Expect(madeProgress).To(BeTrue())
+			Expect(c.prefetchQueue).To(BeEmpty())
+		})
 	})
 
 	Context("read hit", func() {
@@ -151,6 +164,26 @@ var _ = Describe("Directory", func() {
 			Expect(block.ReadCount).To(Equal(1))
 		})
 
+		It("should train the prefetcher with a hit to a prefetched line",
+			func() {
+				c.prefetcher = cache.NewNextLinePrefetcher(6, 1)
+				c.prefetchQueueSize = 4
+				block.IsPrefetched = true
+				dir.EXPECT().Lookup(vm.PID(1), uint64(0x100)).Return(block)
+				dir.EXPECT().Visit(block)
+				bankBuf.EXPECT().CanPush().Return(true)
+				bankBuf.EXPECT().Push(gomock.Any())
+				buf.EXPECT().Pop()
+
+				madeProgress := d.Tick(10)
+
+				Expect(madeProgress).To(BeTrue())
+				Expect(block.IsPrefetched).To(BeFalse())
+				Expect(c.prefetchQueue).To(HaveLen(1))
+				Expect(c.prefetchQueue[0].read.Address).
+					To(Equal(uint64(0x140)))
+			})
+
 		It("should stall if cannot send to bank", func() {
 			dir.EXPECT().Lookup(vm.PID(1), uint64(0x100)).Return(block)
 			bankBuf.EXPECT().CanPush().Return(false)
@@ -166,6 +199,27 @@ var _ = Describe("Directory", func() {
 			madeProgress := d.Tick(10)
 			Expect(madeProgress).To(BeFalse())
 		})
+
+		It("should refetch if the read is coherent beyond workgroup", func() {
+			read.Scope = mem.ScopeAgent
+			mshrEntry := &cache.MSHREntry{}
+			dir.EXPECT().Lookup(vm.PID(1), uint64(0x100)).Return(block)
+			dir.EXPECT().Insert(block)
+			lowModuleFinder.EXPECT().Find(uint64(0x100)).Return(nil)
+			bottomPort.EXPECT().Send(gomock.Any()).Do(func(read *mem.ReadReq) {
+				Expect(read.Address).To(Equal(uint64(0x100)))
+				Expect(read.Scope).To(Equal(mem.ScopeAgent))
+			})
+			mshr.EXPECT().IsFull().Return(false)
+			mshr.EXPECT().Add(vm.PID(1), uint64(0x100)).Return(mshrEntry)
+			buf.EXPECT().Pop()
+
+			madeProgress := d.Tick(10)
+
+			Expect(madeProgress).To(BeTrue())
+			Expect(mshrEntry.Block).To(BeIdenticalTo(block))
+			Expect(block.IsLocked).To(BeTrue())
+		})
 	})
 
 	Context("read miss", func() {
@@ -200,8 +254,10 @@ var _ = Describe("Directory", func() {
 		It("should send request to bottom", func() {
 			var readToBottom *mem.ReadReq
 			dir.EXPECT().Lookup(vm.PID(1), uint64(0x100)).Return(nil)
+			dir.EXPECT().ShouldBypass(vm.PID(1), uint64(0x100)).
+				Return(false)
 			dir.EXPECT().FindVictim(uint64(0x100)).Return(block)
-			dir.EXPECT().Visit(block)
+			dir.EXPECT().Insert(block)
 			lowModuleFinder.EXPECT().Find(uint64(0x100)).Return(nil)
 			bottomPort.EXPECT().Send(gomock.Any()).Do(func(read *mem.ReadReq) {
 				readToBottom = read
@@ -226,9 +282,53 @@ var _ = Describe("Directory", func() {
 			Expect(trans.block).To(BeIdenticalTo(block))
 		})
 
+		It("should queue the prefetches", func() {
+			c.prefetcher = cache.NewNextLinePrefetcher(6, 2)
+			c.prefetchQueueSize = 1
+			dir.EXPECT().Lookup(vm.PID(1), uint64(0x100)).Return(nil)
+			dir.EXPECT().ShouldBypass(vm.PID(1), uint64(0x100)).
+				Return(false)
+			dir.EXPECT().FindVictim(uint64(0x100)).Return(block)
+			dir.EXPECT().Insert(block)
+			lowModuleFinder.EXPECT().Find(uint64(0x100)).Return(nil)
+			bottomPort.EXPECT().Send(gomock.Any())
+			mshr.EXPECT().IsFull().Return(false)
+			mshr.EXPECT().Add(vm.PID(1), uint64(0x100)).Return(mshrEntry)
+			buf.EXPECT().Pop()
+
+			madeProgress := d.Tick(10)
+
+			Expect(madeProgress).To(BeTrue())
+			Expect(c.prefetchQueue).To(HaveLen(1))
+			Expect(c.prefetchQueue[0].prefetch).To(BeTrue())
+			Expect(c.prefetchQueue[0].read.Address).To(Equal(uint64(0x140)))
+			Expect(c.prefetchQueue[0].read.PID).To(Equal(vm.PID(1)))
+		})
+
+		It("should fetch without allocating if the policy bypasses", func() {
+			dir.EXPECT().Lookup(vm.PID(1), uint64(0x100)).Return(nil)
+			mshr.EXPECT().IsFull().Return(false)
+			dir.EXPECT().ShouldBypass(vm.PID(1), uint64(0x100)).Return(true)
+			lowModuleFinder.EXPECT().Find(uint64(0x100)).Return(nil)
+			bottomPort.EXPECT().Send(gomock.Any())
+			mshr.EXPECT().Add(vm.PID(1), uint64(0x100)).Return(mshrEntry)
+			buf.EXPECT().Pop()
+
+			madeProgress := d.Tick(10)
+
+			Expect(madeProgress).To(BeTrue())
+			Expect(mshrEntry.Requests).To(ContainElement(trans))
+			Expect(mshrEntry.Block).To(BeNil())
+			Expect(trans.block).To(BeNil())
+			Expect(trans.readToBottom).NotTo(BeNil())
+		})
+
 		It("should stall is victim block is locked", func() {
 			block.IsLocked = true
 			dir.EXPECT().Lookup(vm.PID(1), uint64(0x100)).Return(nil)
+			mshr.EXPECT().IsFull().Return(false)
+			dir.EXPECT().ShouldBypass(vm.PID(1), uint64(0x100)).
+				Return(false)
 			dir.EXPECT().FindVictim(uint64(0x100)).Return(block)
 
 			madeProgress := d.Tick(10)
@@ -239,6 +339,9 @@ var _ = Describe("Directory", func() {
 		It("should stall is victim block is being read", func() {
 			block.ReadCount = 1
 			dir.EXPECT().Lookup(vm.PID(1), uint64(0x100)).Return(nil)
+			mshr.EXPECT().IsFull().Return(false)
+			dir.EXPECT().ShouldBypass(vm.PID(1), uint64(0x100)).
+				Return(false)
 			dir.EXPECT().FindVictim(uint64(0x100)).Return(block)
 
 			madeProgress := d.Tick(10)
@@ -248,7 +351,6 @@ var _ = Describe("Directory", func() {
 
 		It("should stall is mshr is full", func() {
 			dir.EXPECT().Lookup(vm.PID(1), uint64(0x100)).Return(nil)
-			dir.EXPECT().FindVictim(uint64(0x100)).Return(block)
 			mshr.EXPECT().IsFull().Return(true)
 
 			madeProgress := d.Tick(10)
@@ -258,6 +360,8 @@ var _ = Describe("Directory", func() {
 
 		It("should stall if send to bottom failed", func() {
 			dir.EXPECT().Lookup(vm.PID(1), uint64(0x100)).Return(nil)
+			dir.EXPECT().ShouldBypass(vm.PID(1), uint64(0x100)).
+				Return(false)
 			dir.EXPECT().FindVictim(uint64(0x100)).Return(block)
 			lowModuleFinder.EXPECT().Find(uint64(0x100)).Return(nil)
 			mshr.EXPECT().IsFull().Return(false)
@@ -269,6 +373,147 @@ var _ = Describe("Directory", func() {
 		})
 	})
 
+	Context("prefetch", func() {
+		var (
+			block    *cache.Block
+			prefetch *transaction
+		)
+
+		BeforeEach(func() {
+			block = &cache.Block{}
+			prefetch = &transaction{
+				read: mem.ReadReqBuilder{}.
+					WithAddress(0x140).
+					WithPID(1).
+					WithByteSize(64).
+					Build(),
+				prefetch: true,
+			}
+		})
+
+		It("should accept prefetches when there is no demand request", func() {
+			c.prefetchQueue = []*transaction{prefetch}
+			pipeline.EXPECT().CanAccept().Return(true).Times(2)
+			inBuf.EXPECT().Peek().Return(nil).Times(2)
+			pipeline.EXPECT().Accept(gomock.Any(), dirPipelineItem{prefetch})
+			buf.EXPECT().Peek().Return(nil)
+
+			madeProgress := d.Tick(10)
+
+			Expect(madeProgress).To(BeTrue())
+			Expect(c.prefetchQueue).To(BeEmpty())
+		})
+
+		It("should fetch the line", func() {
+			mshrEntry := &cache.MSHREntry{}
+			pipeline.EXPECT().CanAccept().Return(false)
+			buf.EXPECT().Peek().Return(dirPipelineItem{trans: prefetch})
+			buf.EXPECT().Peek().Return(nil)
+			buf.EXPECT().Pop()
+			mshr.EXPECT().Query(vm.PID(1), uint64(0x140)).Return(nil)
+			dir.EXPECT().Lookup(vm.PID(1), uint64(0x140)).Return(nil)
+			mshr.EXPECT().IsFull().Return(false)
+			bottomPort.EXPECT().CanSend().Return(true)
+			dir.EXPECT().ShouldBypass(vm.PID(1), uint64(0x140)).
+				Return(false)
+			dir.EXPECT().FindVictim(uint64(0x140)).Return(block)
+			dir.EXPECT().Insert(block)
+			lowModuleFinder.EXPECT().Find(uint64(0x140)).Return(nil)
+			bottomPort.EXPECT().Send(gomock.Any())
+			mshr.EXPECT().Add(vm.PID(1), uint64(0x140)).Return(mshrEntry)
+
+			madeProgress := d.Tick(10)
+
+			Expect(madeProgress).To(BeTrue())
+			Expect(block.IsPrefetched).To(BeTrue())
+			Expect(block.IsLocked).To(BeTrue())
+			Expect(mshrEntry.Requests).To(ContainElement(prefetch))
+			Expect(c.postCoalesceTransactions).To(ContainElement(prefetch))
+		})
+
+		It("should not train the prefetcher with a prefetch", func() {
+			c.prefetcher = cache.NewNextLinePrefetcher(6, 1)
+			c.prefetchQueueSize = 4
+			mshrEntry := &cache.MSHREntry{}
+			pipeline.EXPECT().CanAccept().Return(false)
+			buf.EXPECT().Peek().Return(dirPipelineItem{trans: prefetch})
+			buf.EXPECT().Peek().Return(nil)
+			buf.EXPECT().Pop()
+			mshr.EXPECT().Query(vm.PID(1), uint64(0x140)).Return(nil)
+			dir.EXPECT().Lookup(vm.PID(1), uint64(0x140)).Return(nil)
+			mshr.EXPECT().IsFull().Return(false)
+			bottomPort.EXPECT().CanSend().Return(true)
+			dir.EXPECT().ShouldBypass(vm.PID(1), uint64(0x140)).
+				Return(false)
+			dir.EXPECT().FindVictim(uint64(0x140)).Return(block)
+			dir.EXPECT().Insert(block)
+			lowModuleFinder.EXPECT().Find(uint64(0x140)).Return(nil)
+			bottomPort.EXPECT().Send(gomock.Any())
+			mshr.EXPECT().Add(vm.PID(1), uint64(0x140)).Return(mshrEntry)
+
+			madeProgress := d.Tick(10)
+
+			Expect(madeProgress).To(BeTrue())
+			Expect(c.prefetchQueue).To(BeEmpty())
+		})
+
+		It("should drop the prefetch if the policy bypasses", func() {
+			pipeline.EXPECT().CanAccept().Return(false)
+			buf.EXPECT().Peek().Return(dirPipelineItem{trans: prefetch})
+			buf.EXPECT().Peek().Return(nil)
+			buf.EXPECT().Pop()
+			mshr.EXPECT().Query(vm.PID(1), uint64(0x140)).Return(nil)
+			dir.EXPECT().Lookup(vm.PID(1), uint64(0x140)).Return(nil)
+			mshr.EXPECT().IsFull().Return(false)
+			bottomPort.EXPECT().CanSend().Return(true)
+			dir.EXPECT().ShouldBypass(vm.PID(1), uint64(0x140)).Return(true)
+
+			madeProgress := d.Tick(10)
+
+			Expect(madeProgress).To(BeTrue())
+			Expect(c.postCoalesceTransactions).To(BeEmpty())
+		})
+
+		It("should drop the prefetch if the line is cached", func() {
+			block.IsValid = true
+			pipeline.EXPECT().CanAccept().Return(false)
+			buf.EXPECT().Peek().Return(dirPipelineItem{trans: prefetch})
+			buf.EXPECT().Peek().Return(nil)
+			buf.EXPECT().Pop()
+			mshr.EXPECT().Query(vm.PID(1), uint64(0x140)).Return(nil)
+			dir.EXPECT().Lookup(vm.PID(1), uint64(0x140)).Return(block)
+
+			madeProgress := d.Tick(10)
+
+			Expect(madeProgress).To(BeTrue())
+			Expect(c.postCoalesceTransactions).To(BeEmpty())
+		})
+
+		It("should count a demand read to a fetching prefetch as late",
+			func() {
+				read := mem.ReadReqBuilder{}.
+					WithAddress(0x144).
+					WithPID(1).
+					WithByteSize(4).
+					Build()
+				trans := &transaction{read: read}
+				block.IsPrefetched = true
+				mshrEntry := &cache.MSHREntry{Block: block}
+				pipeline.EXPECT().CanAccept().Return(false)
+				buf.EXPECT().Peek().Return(dirPipelineItem{trans: trans})
+				buf.EXPECT().Peek().Return(nil)
+				buf.EXPECT().Pop()
+				mshr.EXPECT().Query(vm.PID(1), uint64(0x140)).
+					Return(mshrEntry)
+
+				madeProgress := d.Tick(10)
+
+				Expect(madeProgress).To(BeTrue())
+				Expect(block.IsPrefetched).To(BeFalse())
+				Expect(mshrEntry.Requests).To(ContainElement(trans))
+			})
+	})
+
 	Context("write mshr hit", func() {
 		var (
 			write     *mem.WriteReq
@@ -0,0 +1,150 @@
+package writearound
+
+import (
+	"github.com/sarchlab/akita/v3/mem/mem"
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/akita/v3/tracing"
+)
+
+// A fenceStage enforces the fences that arrive at the top port. When a fence
+// arrives, the cache stops accepting new requests until all the earlier
+// transactions complete. An acquire fence with a scope wider than the
+// workgroup invalidates the whole cache. A system-scope fence is also
+// forwarded to all the low modules.
+type fenceStage struct {
+	cache *Cache
+
+	fence         *mem.FenceReq
+	drained       bool
+	toForward     []sim.Port
+	pendingFences []*mem.FenceReq
+}
+
+func (s *fenceStage) isProcessing() bool {
+	return s.fence != nil
+}
+
+func (s *fenceStage) accept(fence *mem.FenceReq) {
+	s.fence = fence
+
+	tracing.TraceReqReceive(fence, s.cache)
+}
+
+func (s *fenceStage) Tick(now sim.VTimeInSec) bool {
+	if s.fence == nil {
+		return false
+	}
+
+	if !s.drained {
+		return s.drain()
+	}
+
+	if len(s.toForward) > 0 {
+		return s.forward(now)
+	}
+
+	if len(s.pendingFences) > 0 {
+		return false
+	}
+
+	return s.respond(now)
+}
+
+func (s *fenceStage) drain() bool {
+	if len(s.cache.transactions) > 0 ||
+		len(s.cache.postCoalesceTransactions) > 0 {
+		return false
+	}
+
+	if s.fence.Acquire && s.fence.Scope > mem.ScopeWorkgroup {
+		s.cache.directory.Reset()
+	}
+
+	if s.fence.Scope == mem.ScopeSystem {
+		s.toForward = s.lowModules()
+	}
+
+	s.drained = true
+
+	return true
+}
+
+func (s *fenceStage) lowModules() []sim.Port {
+	lister, ok := s.cache.lowModuleFinder.(mem.LowModuleLister)
+	if !ok {
+		return []sim.Port{s.cache.lowModuleFinder.Find(0)}
+	}
+
+	modules := make([]sim.Port, len(lister.ListLowModules()))
+	copy(modules, lister.ListLowModules())
+
+	return modules
+}
+
+func (s *fenceStage) forward(now sim.VTimeInSec) bool {
+	builder := mem.FenceReqBuilder{}.
+		WithSendTime(now).
+		WithSrc(s.cache.bottomPort).
+		WithDst(s.toForward[0]).
+		WithPID(s.fence.PID).
+		WithScope(s.fence.Scope)
+	if s.fence.Acquire {
+		builder = builder.WithAcquire()
+	}
+	if s.fence.Release {
+		builder = builder.WithRelease()
+	}
+	fence := builder.Build()
+
+	err := s.cache.bottomPort.Send(fence)
+	if err != nil {
+		return false
+	}
+
+	s.toForward = s.toForward[1:]
+	s.pendingFences = append(s.pendingFences, fence)
+
+	tracing.TraceReqInitiate(fence, s.cache,
+		tracing.MsgIDAtReceiver(s.fence, s.cache))
+
+	return true
+}
+
+func (s *fenceStage) finalizeForwardedFence(rsp *mem.FenceRsp) {
+	for i, fence := range s.pendingFences {
+		if fence.ID == rsp.RespondTo {
+			s.pendingFences = append(
+				s.pendingFences[:i], s.pendingFences[i+1:]...)
+
+			tracing.TraceReqFinalize(fence, s.cache)
+
+			return
+		}
+	}
+}
+
+func (s *fenceStage) respond(now sim.VTimeInSec) bool {
+	rsp := mem.FenceRspBuilder{}.
+		WithSendTime(now).
+		WithSrc(s.cache.topPort).
+		WithDst(s.fence.Src).
+		WithRspTo(s.fence.ID).
+		Build()
+
+	err := s.cache.topPort.Send(rsp)
+	if err != nil {
+		return false
+	}
+
+	tracing.TraceReqComplete(s.fence, s.cache)
+	s.Reset()
+
+	return true
+}
+
+func (s *fenceStage) Reset() {
+	s.fence = nil
+	s.drained = false
+	s.toForward = nil
+	s.pendingFences = nil
+}
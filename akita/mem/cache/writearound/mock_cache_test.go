@@ -62,6 +62,18 @@ func (mr *MockDirectoryMockRecorder) GetSets() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSets", reflect.TypeOf((*MockDirectory)(nil).GetSets))
 }
 
+// Insert mocks base method.
+func (m *MockDirectory) Insert(arg0 *cache.Block) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Insert", arg0)
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockDirectoryMockRecorder) Insert(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockDirectory)(nil).Insert), arg0)
+}
+
 // Lookup mocks base method.
 func (m *MockDirectory) Lookup(arg0 vm.PID, arg1 uint64) *cache.Block {
 	m.ctrl.T.Helper()
@@ -88,6 +100,20 @@ func (mr *MockDirectoryMockRecorder) Reset() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockDirectory)(nil).Reset))
 }
 
+// ShouldBypass mocks base method.
+func (m *MockDirectory) ShouldBypass(arg0 vm.PID, arg1 uint64) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShouldBypass", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// ShouldBypass indicates an expected call of ShouldBypass.
+func (mr *MockDirectoryMockRecorder) ShouldBypass(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShouldBypass", reflect.TypeOf((*MockDirectory)(nil).ShouldBypass), arg0, arg1)
+}
+
 // TotalSize mocks base method.
 func (m *MockDirectory) TotalSize() uint64 {
 	m.ctrl.T.Helper()
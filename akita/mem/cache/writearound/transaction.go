@@ -32,6 +32,7 @@ type transaction struct {
 	writeFetchedDirtyMask []bool
 
 	fetchAndWrite bool
+	prefetch      bool
 	done          bool
 }
 
@@ -48,3 +49,10 @@ func (t *transaction) PID() vm.PID {
 	}
 	return t.write.PID
 }
+
+func (t *transaction) Scope() mem.Scope {
+	if t.read != nil {
+		return t.read.Scope
+	}
+	return t.write.Scope
+}
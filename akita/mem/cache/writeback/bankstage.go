@@ -252,6 +252,7 @@ func (s *bankStage) finalizeReadHit(
 		Build()
 	s.cache.topSender.Send(dataReady)
 
+	s.cache.stats.ObserveReqLatency(now, read)
 	tracing.TraceReqComplete(read, s.cache)
 
 	// log.Printf("%.10f, %s, bank read hit finalize， %s, %04X, %04X, (%d, %d), %v\n",
@@ -297,6 +298,7 @@ func (s *bankStage) finalizeWriteHit(
 		Build()
 	s.cache.topSender.Send(done)
 
+	s.cache.stats.ObserveReqLatency(now, write)
 	tracing.TraceReqComplete(write, s.cache)
 
 	// log.Printf("%.10f, %s, bank write hit finalize， %s, %04X, %04X, (%d, %d), %v\n",
@@ -350,6 +352,10 @@ func (s *bankStage) finalizeBankWriteFetched(
 		return false
 	}
 
+	if trans.fetchSectors != nil {
+		s.fillSectors(trans)
+	}
+
 	mshrEntry := trans.mshrEntry
 	block := mshrEntry.Block
 	s.cache.mshrStageBuffer.Push(mshrEntry)
@@ -377,6 +383,33 @@ func (s *bankStage) finalizeBankWriteFetched(
 	return true
 }
 
+// fillSectors merges the fetched sectors with the data that the block already
+// holds, as the other sectors may be dirty. The writes that wait for the fetch
+// are then applied.
+func (s *bankStage) fillSectors(trans *transaction) {
+	mshrEntry := trans.mshrEntry
+	data, err := s.cache.storage.Read(
+		mshrEntry.Block.CacheAddress, 1<<s.cache.log2BlockSize)
+	if err != nil {
+		panic(err)
+	}
+
+	sectorSize := uint64(1) << s.cache.log2Sector()
+	fetchOffset := trans.fetchReadReq.Address - trans.fetchAddress
+	for i, fetched := range trans.fetchSectors {
+		if !fetched {
+			continue
+		}
+
+		start := uint64(i) * sectorSize
+		copy(data[start:start+sectorSize],
+			trans.fetchedData[start-fetchOffset:start-fetchOffset+sectorSize])
+	}
+
+	mshrEntry.Data = data
+	combineData(mshrEntry, s.cache.log2BlockSize)
+}
+
 func (s *bankStage) removeTransaction(now sim.VTimeInSec, trans *transaction) {
 	for i, t := range s.cache.inFlightTransactions {
 		if trans == t {
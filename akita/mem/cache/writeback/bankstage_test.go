@@ -335,6 +335,33 @@ var _ = Describe("Bank Stage", func() {
 			Expect(bs.inflightTransCount).To(Equal(0))
 			Expect(postPipelineBuf.Size()).To(Equal(0))
 		})
+
+		It("should only write the fetched sectors", func() {
+			cacheModule.log2SectorSize = 4
+			oldData := make([]byte, 64)
+			for i := range oldData {
+				oldData[i] = 9
+			}
+			_ = storage.Write(0x40, oldData)
+			trans.fetchAddress = 0x1000
+			trans.fetchSectors = []bool{false, true, false, false}
+			trans.fetchReadReq = mem.ReadReqBuilder{}.
+				WithAddress(0x1010).
+				WithByteSize(16).
+				Build()
+			trans.fetchedData = mshrEntry.Data[:16]
+			mshrStageBuffer.EXPECT().CanPush().Return(true)
+			mshrStageBuffer.EXPECT().Push(mshrEntry)
+
+			ret := bs.Tick(10)
+
+			Expect(ret).To(BeTrue())
+			writtenData, _ := storage.Read(0x40, 64)
+			Expect(writtenData[0:16]).To(Equal(oldData[0:16]))
+			Expect(writtenData[16:32]).To(Equal(trans.fetchedData))
+			Expect(writtenData[32:64]).To(Equal(oldData[32:64]))
+			Expect(mshrEntry.Data).To(Equal(writtenData))
+		})
 	})
 
 	Context("finalizing a read for eviction action", func() {
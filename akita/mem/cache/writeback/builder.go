@@ -5,6 +5,7 @@ import (
 
 	"github.com/sarchlab/akita/v3/mem/cache"
 	"github.com/sarchlab/akita/v3/mem/mem"
+	"github.com/sarchlab/akita/v3/metrics"
 	"github.com/sarchlab/akita/v3/pipelining"
 	"github.com/sarchlab/akita/v3/sim"
 )
@@ -16,6 +17,7 @@ type Builder struct {
 	lowModuleFinder  mem.LowModuleFinder
 	wayAssociativity int
 	log2BlockSize    uint64
+	log2SectorSize   uint64
 
 	interleaving          bool
 	numInterleavingBlock  int
@@ -31,6 +33,14 @@ type Builder struct {
 
 	dirLatency  int
 	bankLatency int
+
+	coherent bool
+
+	replacementPolicy cache.ReplacementPolicyType
+	prefetcher        cache.PrefetcherType
+	prefetchDegree    int
+
+	metrics *metrics.Registry
 }
 
 // MakeBuilder creates a new builder with default configurations.
@@ -46,6 +56,8 @@ func MakeBuilder() Builder {
 		maxInflightFetch:    128,
 		maxInflightEviction: 128,
 		bankLatency:         10,
+		prefetchDegree:      2,
+		metrics:             metrics.DefaultRegistry(),
 	}
 }
 
@@ -73,6 +85,16 @@ func (b Builder) WithLog2BlockSize(n uint64) Builder {
 	return b
 }
 
+// WithLog2SectorSize sets the sector size as the power of 2. The blocks of a
+// sectored cache are divided into sectors. The sectors are fetched separately,
+// so that a miss only fetches the sectors that the request touches. By
+// default, the sector size equals the block size and the cache is not
+// sectored.
+func (b Builder) WithLog2SectorSize(n uint64) Builder {
+	b.log2SectorSize = n
+	return b
+}
+
 // WithNumMSHREntry sets the number of MSHR entries.
 func (b Builder) WithNumMSHREntry(n int) Builder {
 	b.numMSHREntry = n
@@ -144,6 +166,45 @@ func (b Builder) WithBankLatency(n int) Builder {
 	return b
 }
 
+// WithCoherence lets the cache participate in a directory-based coherence
+// protocol. The low modules of a coherent cache must be coherence directories.
+// A coherent cache fetches a line with exclusive permission before writing to
+// it and responds to the invalidation requests from the directories.
+func (b Builder) WithCoherence() Builder {
+	b.coherent = true
+	return b
+}
+
+// WithReplacementPolicy sets the policy that the cache uses to select the
+// blocks to evict.
+func (b Builder) WithReplacementPolicy(
+	policy cache.ReplacementPolicyType,
+) Builder {
+	b.replacementPolicy = policy
+	return b
+}
+
+// WithPrefetcher sets the prefetcher that the cache uses. By default, the
+// cache does not prefetch.
+func (b Builder) WithPrefetcher(prefetcher cache.PrefetcherType) Builder {
+	b.prefetcher = prefetcher
+	return b
+}
+
+// WithPrefetchDegree sets the number of cache lines that the prefetcher
+// fetches ahead.
+func (b Builder) WithPrefetchDegree(n int) Builder {
+	b.prefetchDegree = n
+	return b
+}
+
+// WithMetricsRegistry sets the registry that the metrics of the cache are
+// registered in. By default, the default registry is used.
+func (b Builder) WithMetricsRegistry(registry *metrics.Registry) Builder {
+	b.metrics = registry
+	return b
+}
+
 // Build creates a usable writeback cache.
 func (b Builder) Build(name string) *Cache {
 	cache := new(Cache)
@@ -160,24 +221,48 @@ func (b Builder) Build(name string) *Cache {
 }
 
 func (b *Builder) configureCache(cacheModule *Cache) {
+	cacheModule.stats = cache.NewStats(b.metrics, cacheModule.Name())
+
 	blockSize := 1 << b.log2BlockSize
-	vimctimFinder := cache.NewLRUVictimFinder()
+	vimctimFinder := cache.NewReplacementPolicy(b.replacementPolicy)
 	numSet := int(b.byteSize / uint64(b.wayAssociativity*blockSize))
 	directory := cache.NewDirectory(
 		numSet, b.wayAssociativity, blockSize, vimctimFinder)
 
 	if b.interleaving {
-		directory.AddrConverter = &mem.InterleavingConverter{
+		converter := &mem.InterleavingConverter{
 			InterleavingSize:    uint64(b.numInterleavingBlock) * (1 << b.log2BlockSize),
 			TotalNumOfElements:  b.interleavingUnitCount,
 			CurrentElementIndex: b.interleavingUnitIndex,
 		}
+		directory.AddrConverter = converter
+		cacheModule.interleaving = converter
+	}
+
+	log2SectorSize := b.log2SectorSize
+	if log2SectorSize == 0 {
+		log2SectorSize = b.log2BlockSize
+	}
+
+	if log2SectorSize > b.log2BlockSize {
+		panic("sector size cannot be larger than the block size")
+	}
+
+	if b.coherent && log2SectorSize < b.log2BlockSize {
+		panic("coherent caches cannot be sectored")
+	}
+
+	// The coherence directory tracks the lines by the caches that hold them,
+	// so a coherent cache has to allocate every line that it accesses.
+	if b.coherent && b.replacementPolicy == cache.Bypass {
+		panic("coherent caches cannot bypass")
 	}
 
 	mshr := cache.NewMSHR(b.numMSHREntry)
 	storage := mem.NewStorage(b.byteSize)
 
 	cacheModule.log2BlockSize = b.log2BlockSize
+	cacheModule.log2SectorSize = log2SectorSize
 	cacheModule.numReqPerCycle = b.numReqPerCycle
 	cacheModule.directory = directory
 	cacheModule.mshr = mshr
@@ -185,6 +270,10 @@ func (b *Builder) configureCache(cacheModule *Cache) {
 	cacheModule.lowModuleFinder = b.lowModuleFinder
 	cacheModule.state = cacheStateRunning
 	cacheModule.evictingList = make(map[uint64]bool)
+	cacheModule.coherent = b.coherent
+	cacheModule.prefetcher = cache.NewPrefetcher(
+		b.prefetcher, b.log2BlockSize, b.prefetchDegree)
+	cacheModule.prefetchQueueSize = b.numMSHREntry
 }
 
 func (b *Builder) createPorts(cache *Cache) {
@@ -229,6 +318,7 @@ func (b *Builder) createInternalStages(cache *Cache) {
 	b.buildBankStages(cache)
 	cache.mshrStage = &mshrStage{cache: cache}
 	cache.flusher = &flusher{cache: cache}
+	cache.invalidator = &invalidator{cache: cache}
 	cache.writeBuffer = &writeBufferStage{
 		cache:               cache,
 		writeBufferCapacity: b.writeBufferCapacity,
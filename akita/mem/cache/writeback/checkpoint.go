@@ -0,0 +1,106 @@
+package writeback
+
+import (
+	"fmt"
+
+	"github.com/sarchlab/akita/v3/mem/cache"
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+type checkpointState struct {
+	State cacheState
+}
+
+// CheckCheckpointable returns an error if the cache uses a replacement policy
+// or a prefetcher that cannot be saved in a checkpoint. Coherent caches cannot
+// be saved either, as the coherence directories are not saved.
+func (c *Cache) CheckCheckpointable() error {
+	if c.coherent {
+		return fmt.Errorf("coherent cache %s cannot be checkpointed", c.Name())
+	}
+
+	return cache.CheckCheckpointable(c.directory, c.prefetcher)
+}
+
+// SaveState saves the content and the replacement metadata of the cache. The
+// cache can only be saved when it has no transaction in flight.
+func (c *Cache) SaveState(cp *sim.Checkpoint) error {
+	if !c.isDrained() {
+		return fmt.Errorf("cache %s has transactions in flight", c.Name())
+	}
+
+	err := c.CheckCheckpointable()
+	if err != nil {
+		return err
+	}
+
+	err = cp.Put(c.Name(), checkpointState{State: c.state})
+	if err != nil {
+		return err
+	}
+
+	err = cache.SaveDirectoryAs(cp, c.Name()+".Directory", c.directory)
+	if err != nil {
+		return err
+	}
+
+	err = c.storage.SaveStateAs(cp, c.Name()+".Storage")
+	if err != nil {
+		return err
+	}
+
+	return c.TickScheduler.SaveStateAs(cp, c.Name()+".TickScheduler")
+}
+
+// LoadState restores the content and the replacement metadata of the cache.
+func (c *Cache) LoadState(cp *sim.Checkpoint) error {
+	state := checkpointState{}
+	err := cp.Get(c.Name(), &state)
+	if err != nil {
+		return err
+	}
+
+	c.state = state.State
+
+	err = cache.LoadDirectoryAs(cp, c.Name()+".Directory", c.directory)
+	if err != nil {
+		return err
+	}
+
+	err = c.storage.LoadStateAs(cp, c.Name()+".Storage")
+	if err != nil {
+		return err
+	}
+
+	return c.TickScheduler.LoadStateAs(cp, c.Name()+".TickScheduler")
+}
+
+func (c *Cache) isDrained() bool {
+	if len(c.inFlightTransactions) > 0 ||
+		len(c.evictingList) > 0 ||
+		len(c.prefetchQueue) > 0 ||
+		len(c.mshr.AllEntries()) > 0 {
+		return false
+	}
+
+	if c.flusher.isProcessing() ||
+		c.mshrStage.processingMSHREntry != nil ||
+		len(c.invalidator.pendingInvalidations) > 0 {
+		return false
+	}
+
+	wb := c.writeBuffer
+	if len(wb.pendingEvictions) > 0 ||
+		len(wb.inflightFetch) > 0 ||
+		len(wb.inflightEviction) > 0 {
+		return false
+	}
+
+	for _, bs := range c.bankStages {
+		if bs.inflightTransCount > 0 || bs.downwardInflightTransCount > 0 {
+			return false
+		}
+	}
+
+	return true
+}
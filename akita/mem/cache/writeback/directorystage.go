@@ -54,6 +54,11 @@ func (ds *directoryStage) processTransaction(
 			break
 		}
 
+		if trans.prefetch {
+			madeProgress = ds.doPrefetch(now, trans) || madeProgress
+			continue
+		}
+
 		if trans.read != nil {
 			madeProgress = ds.doRead(now, trans) || madeProgress
 			continue
@@ -85,9 +90,31 @@ func (ds *directoryStage) acceptNewTransaction(now sim.VTimeInSec) bool {
 		madeProgress = true
 	}
 
+	madeProgress = ds.acceptPrefetch(now) || madeProgress
+
 	return madeProgress
 }
 
+// acceptPrefetch lets a prefetch enter the directory pipeline. The demand
+// requests have a higher priority, so the prefetches only use the cycles in
+// which there is no demand request waiting.
+func (ds *directoryStage) acceptPrefetch(now sim.VTimeInSec) bool {
+	if len(ds.cache.prefetchQueue) == 0 ||
+		ds.cache.state != cacheStateRunning {
+		return false
+	}
+
+	if !ds.pipeline.CanAccept() || ds.cache.dirStageBuffer.Peek() != nil {
+		return false
+	}
+
+	trans := ds.cache.prefetchQueue[0]
+	ds.pipeline.Accept(now, dirPipelineItem{trans})
+	ds.cache.prefetchQueue = ds.cache.prefetchQueue[1:]
+
+	return true
+}
+
 func (ds *directoryStage) Reset(now sim.VTimeInSec) {
 	ds.pipeline.Clear()
 	ds.buf.Clear()
@@ -109,6 +136,10 @@ func (ds *directoryStage) doRead(
 	block := ds.cache.directory.Lookup(
 		trans.read.PID, cachelineID)
 	if block != nil {
+		if !ds.hasSectors(block, trans.read) {
+			return ds.handleReadSectorMiss(now, trans, block)
+		}
+
 		return ds.handleReadHit(now, trans, block)
 	}
 
@@ -120,16 +151,34 @@ func (ds *directoryStage) handleReadMSHRHit(
 	trans *transaction,
 	mshrEntry *cache.MSHREntry,
 ) bool {
+	// The read waits for the fetch to complete if the fetch does not bring
+	// all the sectors that the read needs.
+	if !ds.hasSectors(mshrEntry.Block, trans.read) {
+		return false
+	}
+
+	if ds.isBypassingWrite(mshrEntry) {
+		return false
+	}
+
 	trans.mshrEntry = mshrEntry
 	mshrEntry.Requests = append(mshrEntry.Requests, trans)
 	ds.buf.Pop()
 
-	tracing.AddTaskStep(
+	ds.cache.addTaskStep(
 		tracing.MsgIDAtReceiver(trans.read, ds.cache),
-		ds.cache,
 		"read-mshr-hit",
 	)
 
+	if mshrEntry.Block != nil && mshrEntry.Block.IsPrefetched {
+		mshrEntry.Block.IsPrefetched = false
+		ds.cache.addTaskStep(
+			tracing.MsgIDAtReceiver(trans.read, ds.cache),
+			"prefetch-late",
+		)
+		ds.notifyPrefetcher(trans.read)
+	}
+
 	return true
 }
 
@@ -142,9 +191,8 @@ func (ds *directoryStage) handleReadHit(
 		return false
 	}
 
-	tracing.AddTaskStep(
+	ds.cache.addTaskStep(
 		tracing.MsgIDAtReceiver(trans.read, ds.cache),
-		ds.cache,
 		"read-hit",
 	)
 
@@ -157,7 +205,42 @@ func (ds *directoryStage) handleReadHit(
 	// 	nil,
 	// )
 
-	return ds.readFromBank(trans, block)
+	if !ds.readFromBank(trans, block) {
+		return false
+	}
+
+	if block.IsPrefetched {
+		block.IsPrefetched = false
+		ds.cache.addTaskStep(
+			tracing.MsgIDAtReceiver(trans.read, ds.cache),
+			"prefetch-hit",
+		)
+		ds.notifyPrefetcher(trans.read)
+	}
+
+	return true
+}
+
+// handleReadSectorMiss fetches the sectors that a read needs but the block
+// does not hold.
+func (ds *directoryStage) handleReadSectorMiss(
+	now sim.VTimeInSec,
+	trans *transaction,
+	block *cache.Block,
+) bool {
+	if block.IsLocked || block.ReadCount > 0 || ds.cache.mshr.IsFull() {
+		return false
+	}
+
+	ok := ds.fetch(now, trans, block)
+	if ok {
+		ds.cache.addTaskStep(
+			tracing.MsgIDAtReceiver(trans.read, ds.cache),
+			"read-sector-miss",
+		)
+	}
+
+	return ok
 }
 
 func (ds *directoryStage) handleReadMiss(
@@ -171,6 +254,18 @@ func (ds *directoryStage) handleReadMiss(
 		return false
 	}
 
+	if ds.cache.directory.ShouldBypass(req.PID, cacheLineID) {
+		ok := ds.bypass(trans)
+		if ok {
+			ds.cache.addTaskStep(
+				tracing.MsgIDAtReceiver(trans.read, ds.cache),
+				"read-bypass",
+			)
+		}
+
+		return ok
+	}
+
 	victim := ds.cache.directory.FindVictim(cacheLineID)
 	if victim.IsLocked || victim.ReadCount > 0 {
 		return false
@@ -188,9 +283,8 @@ func (ds *directoryStage) handleReadMiss(
 	if ds.needEviction(victim) {
 		ok := ds.evict(now, trans, victim)
 		if ok {
-			tracing.AddTaskStep(
+			ds.cache.addTaskStep(
 				tracing.MsgIDAtReceiver(trans.read, ds.cache),
-				ds.cache,
 				"read-miss",
 			)
 		}
@@ -200,9 +294,8 @@ func (ds *directoryStage) handleReadMiss(
 
 	ok := ds.fetch(now, trans, victim)
 	if ok {
-		tracing.AddTaskStep(
+		ds.cache.addTaskStep(
 			tracing.MsgIDAtReceiver(trans.read, ds.cache),
-			ds.cache,
 			"read-miss",
 		)
 	}
@@ -220,9 +313,8 @@ func (ds *directoryStage) doWrite(
 	mshrEntry := ds.cache.mshr.Query(write.PID, cachelineID)
 	if mshrEntry != nil {
 		ok := ds.doWriteMSHRHit(now, trans, mshrEntry)
-		tracing.AddTaskStep(
+		ds.cache.addTaskStep(
 			tracing.MsgIDAtReceiver(trans.write, ds.cache),
-			ds.cache,
 			"write-mshr-hit",
 		)
 
@@ -230,12 +322,23 @@ func (ds *directoryStage) doWrite(
 	}
 
 	block := ds.cache.directory.Lookup(trans.write.PID, cachelineID)
+	if block != nil && ds.needSectorFetch(write, block) {
+		ok := ds.doWriteSectorMiss(now, trans, block)
+		if ok {
+			ds.cache.addTaskStep(
+				tracing.MsgIDAtReceiver(trans.write, ds.cache),
+				"write-sector-miss",
+			)
+		}
+
+		return ok
+	}
+
 	if block != nil {
-		ok := ds.doWriteHit(trans, block)
+		ok := ds.doWriteHit(now, trans, block)
 		if ok {
-			tracing.AddTaskStep(
+			ds.cache.addTaskStep(
 				tracing.MsgIDAtReceiver(trans.write, ds.cache),
-				ds.cache,
 				"write-hit",
 			)
 		}
@@ -245,9 +348,8 @@ func (ds *directoryStage) doWrite(
 
 	ok := ds.doWriteMiss(now, trans)
 	if ok {
-		tracing.AddTaskStep(
+		ds.cache.addTaskStep(
 			tracing.MsgIDAtReceiver(trans.write, ds.cache),
-			ds.cache,
 			"write-miss",
 		)
 	}
@@ -260,6 +362,22 @@ func (ds *directoryStage) doWriteMSHRHit(
 	trans *transaction,
 	mshrEntry *cache.MSHREntry,
 ) bool {
+	if ds.cache.coherent && !ds.isExclusiveFetch(mshrEntry) {
+		// A shared copy cannot be written. Wait for the fetch to complete and
+		// retry as a write to a shared line.
+		return false
+	}
+
+	// There is no block to merge the write into if the line bypasses the
+	// cache. Wait for the bypassing access to complete.
+	if mshrEntry.Block == nil {
+		return false
+	}
+
+	if !ds.hasSectors(mshrEntry.Block, trans.write) {
+		return false
+	}
+
 	trans.mshrEntry = mshrEntry
 	mshrEntry.Requests = append(mshrEntry.Requests, trans)
 	ds.buf.Pop()
@@ -267,7 +385,33 @@ func (ds *directoryStage) doWriteMSHRHit(
 	return true
 }
 
+func (ds *directoryStage) isExclusiveFetch(mshrEntry *cache.MSHREntry) bool {
+	return mshrEntry.Requests[0].(*transaction).write != nil
+}
+
+// isBypassingWrite returns true if the MSHR entry is allocated by a write that
+// bypasses the cache. The accesses to the line wait for the write to complete.
+func (ds *directoryStage) isBypassingWrite(mshrEntry *cache.MSHREntry) bool {
+	return mshrEntry.Block == nil &&
+		mshrEntry.Requests[0].(*transaction).write != nil
+}
+
+// doWriteSectorMiss fetches the sectors that a write partially overwrites but
+// the block does not hold.
+func (ds *directoryStage) doWriteSectorMiss(
+	now sim.VTimeInSec,
+	trans *transaction,
+	block *cache.Block,
+) bool {
+	if block.IsLocked || block.ReadCount > 0 || ds.cache.mshr.IsFull() {
+		return false
+	}
+
+	return ds.fetch(now, trans, block)
+}
+
 func (ds *directoryStage) doWriteHit(
+	now sim.VTimeInSec,
 	trans *transaction,
 	block *cache.Block,
 ) bool {
@@ -275,16 +419,45 @@ func (ds *directoryStage) doWriteHit(
 		return false
 	}
 
+	if ds.cache.coherent && !block.IsDirty {
+		return ds.upgrade(now, trans, block)
+	}
+
 	return ds.writeToBank(trans, block)
 }
 
+// upgrade handles a write to a clean line in a coherent cache. Only the owner
+// of a line, which is the cache that holds it dirty, can write to it. The
+// shared copy is dropped and the line is fetched again with the exclusive
+// permission.
+func (ds *directoryStage) upgrade(
+	now sim.VTimeInSec,
+	trans *transaction,
+	block *cache.Block,
+) bool {
+	block.IsValid = false
+
+	return ds.writePartialLineMiss(now, trans)
+}
+
 func (ds *directoryStage) doWriteMiss(
 	now sim.VTimeInSec,
 	trans *transaction,
 ) bool {
 	write := trans.write
+	cachelineID, _ := getCacheLineID(write.Address, ds.cache.log2BlockSize)
 
-	if ds.isWritingFullLine(write) {
+	if ds.cache.directory.ShouldBypass(write.PID, cachelineID) {
+		if ds.cache.mshr.IsFull() {
+			return false
+		}
+
+		return ds.bypass(trans)
+	}
+
+	// A coherent cache needs to fetch the exclusive permission even if the
+	// whole line is overwritten.
+	if ds.isWritingFullSectors(write) && !ds.cache.coherent {
 		return ds.writeFullLineMiss(now, trans)
 	}
 	return ds.writePartialLineMiss(now, trans)
@@ -373,11 +546,24 @@ func (ds *directoryStage) writeToBank(
 	addr := trans.write.Address
 	cachelineID, _ := getCacheLineID(addr, ds.cache.log2BlockSize)
 
-	ds.cache.directory.Visit(block)
+	holding := ds.isHolding(block, trans.write.PID, cachelineID)
+	if !holding {
+		block.DirtyMask = nil
+		block.SectorValid = nil
+	}
+	ds.updateSectors(trans, block, false)
+
 	block.IsLocked = true
 	block.Tag = cachelineID
 	block.IsValid = true
+	block.IsPrefetched = false
 	block.PID = trans.write.PID
+
+	if holding {
+		ds.cache.directory.Visit(block)
+	} else {
+		ds.cache.directory.Insert(block)
+	}
 	trans.block = block
 	trans.action = bankWriteHit
 	ds.buf.Pop()
@@ -413,6 +599,8 @@ func (ds *directoryStage) evict(
 
 	ds.updateTransForEviction(trans, victim, pid, cacheLineID)
 	ds.updateVictimBlockMetaData(victim, cacheLineID, pid)
+	ds.updateSectors(trans, victim, trans.action == bankEvictAndFetch)
+	victim.IsPrefetched = trans.prefetch
 
 	ds.buf.Pop()
 	bankBuf.Push(trans)
@@ -434,7 +622,9 @@ func (ds *directoryStage) updateVictimBlockMetaData(victim *cache.Block, cacheLi
 	victim.PID = pid
 	victim.IsLocked = true
 	victim.IsDirty = false
-	ds.cache.directory.Visit(victim)
+	victim.DirtyMask = nil
+	victim.SectorValid = nil
+	ds.cache.directory.Insert(victim)
 }
 
 func (ds *directoryStage) updateTransForEviction(
@@ -463,17 +653,19 @@ func (ds *directoryStage) updateTransForEviction(
 		trans.fetchPID = pid
 		trans.fetchAddress = cacheLineID
 		trans.action = bankEvictAndFetch
+
+		ds.notifyPrefetcherOfMiss(trans)
 	} else {
 		trans.action = bankEvictAndWrite
 	}
 }
 
 func (ds *directoryStage) evictionNeedFetch(t *transaction) bool {
-	if t.write == nil {
+	if t.write == nil || ds.cache.coherent {
 		return true
 	}
 
-	if ds.isWritingFullLine(t.write) {
+	if ds.isWritingFullSectors(t.write) {
 		return false
 	}
 
@@ -507,6 +699,13 @@ func (ds *directoryStage) fetch(
 		return false
 	}
 
+	holding := ds.isHolding(block, pid, cacheLineID)
+	if !holding {
+		block.DirtyMask = nil
+		block.SectorValid = nil
+	}
+	ds.updateSectors(trans, block, true)
+
 	mshrEntry := ds.cache.mshr.Add(pid, cacheLineID)
 	trans.mshrEntry = mshrEntry
 	trans.block = block
@@ -514,11 +713,16 @@ func (ds *directoryStage) fetch(
 	block.Tag = cacheLineID
 	block.PID = pid
 	block.IsValid = true
-	ds.cache.directory.Visit(block)
+	block.IsPrefetched = trans.prefetch
+
+	if holding {
+		ds.cache.directory.Visit(block)
+	} else {
+		ds.cache.directory.Insert(block)
+	}
 
-	tracing.AddTaskStep(
+	ds.cache.addTaskStep(
 		tracing.MsgIDAtReceiver(req, ds.cache),
-		ds.cache,
 		fmt.Sprintf("add-mshr-entry-0x%x-0x%x", mshrEntry.Address, block.Tag),
 	)
 
@@ -532,25 +736,245 @@ func (ds *directoryStage) fetch(
 	mshrEntry.Block = block
 	mshrEntry.Requests = append(mshrEntry.Requests, trans)
 
+	ds.notifyPrefetcherOfMiss(trans)
+
 	return true
 }
 
-func (ds *directoryStage) isWritingFullLine(write *mem.WriteReq) bool {
-	if len(write.Data) != (1 << ds.cache.log2BlockSize) {
+// bypass sends an access to the write buffer without allocating a block. A
+// read fetches the whole line and a write is forwarded to the low module. The
+// access holds an MSHR entry without a block until it completes, so that the
+// later accesses to the line do not overtake it.
+func (ds *directoryStage) bypass(trans *transaction) bool {
+	if !ds.cache.writeBufferBuffer.CanPush() {
 		return false
 	}
 
-	if write.DirtyMask != nil {
-		for _, dirty := range write.DirtyMask {
-			if !dirty {
-				return false
-			}
+	req := trans.accessReq()
+	pid := req.GetPID()
+	cacheLineID, _ := getCacheLineID(req.GetAddress(), ds.cache.log2BlockSize)
+
+	mshrEntry := ds.cache.mshr.Add(pid, cacheLineID)
+	mshrEntry.Requests = append(mshrEntry.Requests, trans)
+	trans.mshrEntry = mshrEntry
+	trans.bypass = true
+
+	if trans.read != nil {
+		trans.action = writeBufferFetch
+		trans.fetchPID = pid
+		trans.fetchAddress = cacheLineID
+	} else {
+		trans.action = writeBufferBypassWrite
+		trans.evictingPID = pid
+		trans.evictingAddr = trans.write.Address
+		trans.evictingData = trans.write.Data
+		trans.evictingDirtyMask = trans.write.DirtyMask
+	}
+
+	ds.buf.Pop()
+	ds.cache.writeBufferBuffer.Push(trans)
+
+	ds.notifyPrefetcherOfMiss(trans)
+
+	return true
+}
+
+// doPrefetch fetches the line of a prefetch. A prefetch never waits, so that
+// it does not delay the demand requests. It is dropped if the line is already
+// cached or being fetched, if the line cannot be fetched right away, or if the
+// replacement policy would not allocate a block for the line.
+func (ds *directoryStage) doPrefetch(
+	now sim.VTimeInSec,
+	trans *transaction,
+) bool {
+	read := trans.read
+
+	if ds.cache.mshr.Query(read.PID, read.Address) != nil ||
+		ds.cache.directory.Lookup(read.PID, read.Address) != nil ||
+		ds.cache.mshr.IsFull() ||
+		ds.cache.directory.ShouldBypass(read.PID, read.Address) {
+		ds.buf.Pop()
+		return true
+	}
+
+	victim := ds.cache.directory.FindVictim(read.Address)
+	if victim.IsLocked || victim.ReadCount > 0 {
+		ds.buf.Pop()
+		return true
+	}
+
+	taskID := tracing.MsgIDAtReceiver(read, ds.cache)
+	tracing.StartTask(taskID, "", ds.cache, "prefetch", "prefetch", read)
+
+	var ok bool
+	if ds.needEviction(victim) {
+		ok = ds.evict(now, trans, victim)
+	} else {
+		ok = ds.fetch(now, trans, victim)
+	}
+
+	if !ok {
+		tracing.EndTask(taskID, ds.cache)
+		ds.buf.Pop()
+		return true
+	}
+
+	ds.cache.inFlightTransactions = append(ds.cache.inFlightTransactions, trans)
+	ds.cache.addTaskStep(taskID, "prefetch-issue")
+
+	return true
+}
+
+// notifyPrefetcherOfMiss trains the prefetcher when a demand read allocates an
+// MSHR entry. The prefetches and the writes do not train the prefetcher.
+func (ds *directoryStage) notifyPrefetcherOfMiss(trans *transaction) {
+	if trans.read == nil || trans.prefetch {
+		return
+	}
+
+	ds.notifyPrefetcher(trans.read)
+}
+
+// notifyPrefetcher trains the prefetcher with a demand read and queues the
+// lines that the prefetcher asks for. The lines that do not fit in the queue
+// are dropped. An interleaved cache also drops the lines that are mapped to
+// the other caches.
+func (ds *directoryStage) notifyPrefetcher(read *mem.ReadReq) {
+	if ds.cache.prefetcher == nil {
+		return
+	}
+
+	blockSize := uint64(1) << ds.cache.log2BlockSize
+	addrs := ds.cache.prefetcher.Notify(cache.NewPrefetchTrigger(read))
+	for _, addr := range addrs {
+		if len(ds.cache.prefetchQueue) >= ds.cache.prefetchQueueSize {
+			return
+		}
+
+		if ds.cache.interleaving != nil &&
+			!ds.cache.interleaving.Contains(addr) {
+			continue
+		}
+
+		prefetch := mem.ReadReqBuilder{}.
+			WithAddress(addr).
+			WithByteSize(blockSize).
+			WithPID(read.PID).
+			Build()
+		ds.cache.prefetchQueue = append(ds.cache.prefetchQueue, &transaction{
+			id:       sim.IDGeneratorFor(ds.cache).Generate(),
+			read:     prefetch,
+			prefetch: true,
+		})
+	}
+}
+
+// isWritingFullSectors returns true if a write overwrites all the bytes of the
+// sectors that it touches, so that the sectors do not need to be fetched. The
+// block of a cache that is not sectored has a single sector.
+func (ds *directoryStage) isWritingFullSectors(write *mem.WriteReq) bool {
+	touched := ds.cache.touchedSectors(write.Address, uint64(len(write.Data)))
+	written := ds.cache.fullyWrittenSectors(write)
+	for i := range touched {
+		if touched[i] && !written[i] {
+			return false
 		}
 	}
 
 	return true
 }
 
+// isHolding returns true if the block already holds the given line, in which
+// case the sectors that the block holds are kept.
+func (ds *directoryStage) isHolding(
+	block *cache.Block,
+	pid vm.PID,
+	cacheLineID uint64,
+) bool {
+	return block.IsValid && block.PID == pid && block.Tag == cacheLineID
+}
+
+// hasSectors returns true if the block holds all the sectors that an access
+// touches.
+func (ds *directoryStage) hasSectors(
+	block *cache.Block,
+	req mem.AccessReq,
+) bool {
+	if !ds.cache.isSectored() || block == nil {
+		return true
+	}
+
+	touched := ds.cache.touchedSectors(req.GetAddress(), req.GetByteSize())
+	for i := range touched {
+		if touched[i] && !isSectorValid(block, i) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// needSectorFetch returns true if a write partially overwrites a sector that
+// the block does not hold. The rest of the sector needs to be fetched.
+func (ds *directoryStage) needSectorFetch(
+	write *mem.WriteReq,
+	block *cache.Block,
+) bool {
+	if !ds.cache.isSectored() {
+		return false
+	}
+
+	touched := ds.cache.touchedSectors(write.Address, uint64(len(write.Data)))
+	written := ds.cache.fullyWrittenSectors(write)
+	for i := range touched {
+		if touched[i] && !written[i] && !isSectorValid(block, i) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// updateSectors selects the sectors that a transaction fetches and marks the
+// sectors that the transaction touches as valid. The sectors are marked ahead
+// of the data, as the block stays locked until the data arrives.
+func (ds *directoryStage) updateSectors(
+	trans *transaction,
+	block *cache.Block,
+	needFetch bool,
+) {
+	if !ds.cache.isSectored() {
+		return
+	}
+
+	req := trans.accessReq()
+	touched := ds.cache.touchedSectors(req.GetAddress(), req.GetByteSize())
+
+	var written []bool
+	if trans.write != nil {
+		written = ds.cache.fullyWrittenSectors(trans.write)
+	}
+
+	if needFetch {
+		trans.fetchSectors = make([]bool, len(touched))
+		for i := range touched {
+			trans.fetchSectors[i] = touched[i] &&
+				!isSectorValid(block, i) &&
+				(written == nil || !written[i])
+		}
+	}
+
+	if block.SectorValid == nil {
+		block.SectorValid = make([]bool, len(touched))
+	}
+
+	for i := range touched {
+		if touched[i] {
+			block.SectorValid[i] = true
+		}
+	}
+}
+
 func (ds *directoryStage) needEviction(victim *cache.Block) bool {
 	return victim.IsValid && victim.IsDirty
 }
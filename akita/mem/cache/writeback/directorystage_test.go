@@ -99,7 +99,7 @@ var _ = Describe("DirectoryStage", func() {
 			)
 
 			BeforeEach(func() {
-				mshrEntry = &cache.MSHREntry{}
+				mshrEntry = &cache.MSHREntry{Block: &cache.Block{}}
 				mshr.EXPECT().
 					Query(vm.PID(1), uint64(0x100)).
 					Return(mshrEntry)
@@ -165,6 +165,184 @@ var _ = Describe("DirectoryStage", func() {
 				Expect(block.ReadCount).To(Equal(1))
 				Expect(trans.action).To(Equal(bankReadHit))
 			})
+
+			It("should not train the prefetcher", func() {
+				cacheModule.prefetcher = cache.NewNextLinePrefetcher(6, 1)
+				bankBuf.EXPECT().CanPush().Return(true)
+				bankBuf.EXPECT().Push(gomock.Any())
+				buf.EXPECT().Pop()
+				directory.EXPECT().Visit(block)
+
+				ret := ds.Tick(10)
+
+				Expect(ret).To(BeTrue())
+				Expect(cacheModule.prefetchQueue).To(BeEmpty())
+			})
+		})
+
+		Context("hit on a prefetched line", func() {
+			var (
+				block *cache.Block
+			)
+
+			BeforeEach(func() {
+				mshr.EXPECT().
+					Query(vm.PID(1), uint64(0x100)).
+					Return(nil)
+
+				block = &cache.Block{
+					Tag:          0x100,
+					IsPrefetched: true,
+				}
+				directory.EXPECT().
+					Lookup(vm.PID(1), uint64(0x100)).
+					Return(block)
+			})
+
+			It("should train the prefetcher", func() {
+				cacheModule.prefetcher = cache.NewNextLinePrefetcher(6, 1)
+				bankBuf.EXPECT().CanPush().Return(true)
+				bankBuf.EXPECT().Push(gomock.Any())
+				buf.EXPECT().Pop()
+				directory.EXPECT().Visit(block)
+
+				ret := ds.Tick(10)
+
+				Expect(ret).To(BeTrue())
+				Expect(block.IsPrefetched).To(BeFalse())
+				Expect(cacheModule.prefetchQueue).To(HaveLen(1))
+				Expect(cacheModule.prefetchQueue[0].read.Address).
+					To(Equal(uint64(0x140)))
+			})
+		})
+
+		Context("sector miss", func() {
+			var (
+				block *cache.Block
+			)
+
+			BeforeEach(func() {
+				cacheModule.log2SectorSize = 4
+				read.Address = 0x110
+				read.AccessByteSize = 16
+
+				mshr.EXPECT().
+					Query(vm.PID(1), uint64(0x100)).
+					Return(nil)
+
+				block = &cache.Block{
+					PID:         1,
+					Tag:         0x100,
+					IsValid:     true,
+					SectorValid: []bool{true, false, false, false},
+				}
+				directory.EXPECT().
+					Lookup(vm.PID(1), uint64(0x100)).
+					Return(block)
+			})
+
+			It("should stall if the block is being read", func() {
+				block.ReadCount = 1
+
+				ret := ds.Tick(10)
+
+				Expect(ret).To(BeFalse())
+			})
+
+			It("should only fetch the missing sector", func() {
+				mshrEntry := &cache.MSHREntry{}
+				mshr.EXPECT().IsFull().Return(false)
+				mshr.EXPECT().Add(vm.PID(1), uint64(0x100)).Return(mshrEntry)
+				bankBuf.EXPECT().CanPush().Return(true)
+				bankBuf.EXPECT().Push(trans)
+				buf.EXPECT().Pop()
+				directory.EXPECT().Visit(block)
+
+				ret := ds.Tick(10)
+
+				Expect(ret).To(BeTrue())
+				Expect(trans.action).To(Equal(writeBufferFetch))
+				Expect(trans.fetchSectors).
+					To(Equal([]bool{false, true, false, false}))
+				Expect(block.SectorValid).
+					To(Equal([]bool{true, true, false, false}))
+				Expect(mshrEntry.Block).To(BeIdenticalTo(block))
+			})
+
+			It("should train the prefetcher with the MSHR allocation", func() {
+				cacheModule.prefetcher = cache.NewNextLinePrefetcher(6, 1)
+				mshr.EXPECT().IsFull().Return(false)
+				mshr.EXPECT().Add(vm.PID(1), uint64(0x100)).
+					Return(&cache.MSHREntry{})
+				bankBuf.EXPECT().CanPush().Return(true)
+				bankBuf.EXPECT().Push(trans)
+				buf.EXPECT().Pop()
+				directory.EXPECT().Visit(block)
+
+				ret := ds.Tick(10)
+
+				Expect(ret).To(BeTrue())
+				Expect(cacheModule.prefetchQueue).To(HaveLen(1))
+				Expect(cacheModule.prefetchQueue[0].read.Address).
+					To(Equal(uint64(0x140)))
+			})
+		})
+
+		Context("mshr hit on a bypassing write", func() {
+			It("should stall", func() {
+				mshrEntry := &cache.MSHREntry{}
+				mshrEntry.Requests = append(mshrEntry.Requests,
+					&transaction{write: mem.WriteReqBuilder{}.Build()})
+				mshr.EXPECT().
+					Query(vm.PID(1), uint64(0x100)).
+					Return(mshrEntry)
+
+				ret := ds.Tick(10)
+
+				Expect(ret).To(BeFalse())
+				Expect(mshrEntry.Requests).To(HaveLen(1))
+			})
+		})
+
+		Context("miss, bypass", func() {
+			BeforeEach(func() {
+				directory.EXPECT().
+					Lookup(vm.PID(1), uint64(0x100)).
+					Return(nil)
+				directory.EXPECT().
+					ShouldBypass(vm.PID(1), uint64(0x100)).
+					Return(true)
+				mshr.EXPECT().Query(vm.PID(1), uint64(0x100)).Return(nil)
+				mshr.EXPECT().IsFull().Return(false)
+			})
+
+			It("should stall if the write buffer buffer is full", func() {
+				writeBufferBuffer.EXPECT().CanPush().Return(false)
+
+				ret := ds.Tick(10)
+
+				Expect(ret).To(BeFalse())
+			})
+
+			It("should fetch without allocating a block", func() {
+				mshrEntry := &cache.MSHREntry{}
+				writeBufferBuffer.EXPECT().CanPush().Return(true)
+				writeBufferBuffer.EXPECT().Push(trans)
+				mshr.EXPECT().Add(vm.PID(1), uint64(0x100)).Return(mshrEntry)
+				buf.EXPECT().Pop()
+
+				ret := ds.Tick(10)
+
+				Expect(ret).To(BeTrue())
+				Expect(trans.bypass).To(BeTrue())
+				Expect(trans.block).To(BeNil())
+				Expect(trans.action).To(Equal(writeBufferFetch))
+				Expect(trans.fetchPID).To(Equal(vm.PID(1)))
+				Expect(trans.fetchAddress).To(Equal(uint64(0x100)))
+				Expect(trans.mshrEntry).To(BeIdenticalTo(mshrEntry))
+				Expect(mshrEntry.Block).To(BeNil())
+				Expect(mshrEntry.Requests).To(ContainElement(trans))
+			})
 		})
 
 		Context("miss, mshr miss, mshr full", func() {
@@ -197,6 +375,10 @@ var _ = Describe("DirectoryStage", func() {
 				directory.EXPECT().
 					Lookup(vm.PID(1), uint64(0x100)).
 					Return(nil)
+				directory.EXPECT().
+					ShouldBypass(vm.PID(1), uint64(0x100)).
+					Return(false).
+					AnyTimes()
 				directory.EXPECT().FindVictim(uint64(0x100)).Return(block)
 				mshr.EXPECT().Query(vm.PID(1), uint64(0x100)).Return(nil)
 				mshr.EXPECT().IsFull().Return(false)
@@ -222,7 +404,7 @@ var _ = Describe("DirectoryStage", func() {
 					})
 				mshr.EXPECT().Add(vm.PID(1), uint64(0x100)).Return(mshrEntry)
 				buf.EXPECT().Pop()
-				directory.EXPECT().Visit(block)
+				directory.EXPECT().Insert(block)
 
 				ret := ds.Tick(10)
 
@@ -264,6 +446,10 @@ var _ = Describe("DirectoryStage", func() {
 				directory.EXPECT().
 					Lookup(vm.PID(1), uint64(0x100)).
 					Return(nil)
+				directory.EXPECT().
+					ShouldBypass(vm.PID(1), uint64(0x100)).
+					Return(false).
+					AnyTimes()
 				directory.EXPECT().FindVictim(uint64(0x100)).Return(block)
 				mshr.EXPECT().Query(vm.PID(1), uint64(0x100)).Return(nil)
 				mshr.EXPECT().IsFull().Return(false)
@@ -286,7 +472,7 @@ var _ = Describe("DirectoryStage", func() {
 			})
 
 			It("should do evict", func() {
-				directory.EXPECT().Visit(block)
+				directory.EXPECT().Insert(block)
 				bankBuf.EXPECT().CanPush().Return(true)
 				bankBuf.EXPECT().
 					Push(gomock.Any()).
@@ -340,6 +526,85 @@ var _ = Describe("DirectoryStage", func() {
 		})
 	})
 
+	Context("prefetch", func() {
+		var (
+			prefetch *transaction
+			block    *cache.Block
+		)
+
+		BeforeEach(func() {
+			prefetch = &transaction{
+				read: mem.ReadReqBuilder{}.
+					WithAddress(0x140).
+					WithPID(1).
+					WithByteSize(64).
+					Build(),
+				prefetch: true,
+			}
+			block = &cache.Block{}
+		})
+
+		It("should accept prefetches when there is no demand request", func() {
+			cacheModule.prefetchQueue = []*transaction{prefetch}
+			pipeline.EXPECT().CanAccept().Return(true).Times(2)
+			dirBuf.EXPECT().Peek().Return(nil).Times(2)
+			pipeline.EXPECT().Accept(gomock.Any(), dirPipelineItem{prefetch})
+			buf.EXPECT().Peek().Return(nil)
+
+			ret := ds.Tick(10)
+
+			Expect(ret).To(BeTrue())
+			Expect(cacheModule.prefetchQueue).To(BeEmpty())
+		})
+
+		Context("in the pipeline", func() {
+			BeforeEach(func() {
+				pipeline.EXPECT().CanAccept().Return(false)
+				buf.EXPECT().Peek().Return(dirPipelineItem{trans: prefetch})
+				buf.EXPECT().Peek().Return(nil)
+				mshr.EXPECT().Query(vm.PID(1), uint64(0x140)).Return(nil)
+			})
+
+			It("should drop the prefetch if the line is cached", func() {
+				directory.EXPECT().
+					Lookup(vm.PID(1), uint64(0x140)).
+					Return(block)
+				buf.EXPECT().Pop()
+
+				ret := ds.Tick(10)
+
+				Expect(ret).To(BeTrue())
+				Expect(cacheModule.inFlightTransactions).To(BeEmpty())
+			})
+
+			It("should fetch the line", func() {
+				mshrEntry := &cache.MSHREntry{}
+				directory.EXPECT().
+					Lookup(vm.PID(1), uint64(0x140)).
+					Return(nil)
+				mshr.EXPECT().IsFull().Return(false)
+				directory.EXPECT().
+					ShouldBypass(vm.PID(1), uint64(0x140)).
+					Return(false)
+				directory.EXPECT().FindVictim(uint64(0x140)).Return(block)
+				directory.EXPECT().Insert(block)
+				mshr.EXPECT().Add(vm.PID(1), uint64(0x140)).Return(mshrEntry)
+				bankBuf.EXPECT().CanPush().Return(true)
+				bankBuf.EXPECT().Push(prefetch)
+				buf.EXPECT().Pop()
+
+				ret := ds.Tick(10)
+
+				Expect(ret).To(BeTrue())
+				Expect(block.IsPrefetched).To(BeTrue())
+				Expect(prefetch.action).To(Equal(writeBufferFetch))
+				Expect(mshrEntry.Requests).To(ContainElement(prefetch))
+				Expect(cacheModule.inFlightTransactions).
+					To(ContainElement(prefetch))
+			})
+		})
+	})
+
 	Context("write", func() {
 		var (
 			write *mem.WriteReq
@@ -368,7 +633,7 @@ var _ = Describe("DirectoryStage", func() {
 			)
 
 			BeforeEach(func() {
-				mshrEntry = &cache.MSHREntry{}
+				mshrEntry = &cache.MSHREntry{Block: &cache.Block{}}
 				mshr.EXPECT().
 					Query(vm.PID(1), uint64(0x100)).
 					Return(mshrEntry)
@@ -384,6 +649,67 @@ var _ = Describe("DirectoryStage", func() {
 			})
 		})
 
+		Context("mshr hit on a bypassing access", func() {
+			It("should stall", func() {
+				mshrEntry := &cache.MSHREntry{}
+				mshrEntry.Requests = append(mshrEntry.Requests,
+					&transaction{read: mem.ReadReqBuilder{}.Build()})
+				mshr.EXPECT().
+					Query(vm.PID(1), uint64(0x100)).
+					Return(mshrEntry)
+
+				ret := ds.Tick(10)
+
+				Expect(ret).To(BeFalse())
+				Expect(mshrEntry.Requests).To(HaveLen(1))
+			})
+		})
+
+		Context("miss, bypass", func() {
+			BeforeEach(func() {
+				write.Data = []byte{1, 2, 3, 4}
+				write.DirtyMask = []bool{true, true, false, true}
+				directory.EXPECT().
+					Lookup(vm.PID(1), uint64(0x100)).
+					Return(nil)
+				directory.EXPECT().
+					ShouldBypass(vm.PID(1), uint64(0x100)).
+					Return(true)
+				mshr.EXPECT().Query(vm.PID(1), uint64(0x100)).Return(nil)
+			})
+
+			It("should stall if mshr is full", func() {
+				mshr.EXPECT().IsFull().Return(true)
+
+				ret := ds.Tick(10)
+
+				Expect(ret).To(BeFalse())
+			})
+
+			It("should send the write to the write buffer", func() {
+				mshrEntry := &cache.MSHREntry{}
+				mshr.EXPECT().IsFull().Return(false)
+				writeBufferBuffer.EXPECT().CanPush().Return(true)
+				writeBufferBuffer.EXPECT().Push(trans)
+				mshr.EXPECT().Add(vm.PID(1), uint64(0x100)).Return(mshrEntry)
+				buf.EXPECT().Pop()
+
+				ret := ds.Tick(10)
+
+				Expect(ret).To(BeTrue())
+				Expect(trans.bypass).To(BeTrue())
+				Expect(trans.block).To(BeNil())
+				Expect(trans.action).To(Equal(writeBufferBypassWrite))
+				Expect(trans.evictingPID).To(Equal(vm.PID(1)))
+				Expect(trans.evictingAddr).To(Equal(uint64(0x100)))
+				Expect(trans.evictingData).To(Equal([]byte{1, 2, 3, 4}))
+				Expect(trans.evictingDirtyMask).
+					To(Equal([]bool{true, true, false, true}))
+				Expect(mshrEntry.Block).To(BeNil())
+				Expect(mshrEntry.Requests).To(ContainElement(trans))
+			})
+		})
+
 		Context("hit", func() {
 			var (
 				block *cache.Block
@@ -391,6 +717,7 @@ var _ = Describe("DirectoryStage", func() {
 
 			BeforeEach(func() {
 				block = &cache.Block{
+					PID:     1,
 					Tag:     0x100,
 					IsValid: true,
 				}
@@ -445,6 +772,65 @@ var _ = Describe("DirectoryStage", func() {
 			})
 		})
 
+		Context("sectored hit", func() {
+			var (
+				block *cache.Block
+			)
+
+			BeforeEach(func() {
+				cacheModule.log2SectorSize = 4
+				write.Address = 0x110
+
+				block = &cache.Block{
+					PID:         1,
+					Tag:         0x100,
+					IsValid:     true,
+					SectorValid: []bool{true, false, false, false},
+				}
+
+				mshr.EXPECT().
+					Query(vm.PID(1), uint64(0x100)).
+					Return(nil)
+
+				directory.EXPECT().
+					Lookup(vm.PID(1), uint64(0x100)).
+					Return(block)
+			})
+
+			It("should write a full sector without fetching", func() {
+				write.Data = make([]byte, 16)
+				bankBuf.EXPECT().CanPush().Return(true)
+				bankBuf.EXPECT().Push(trans)
+				buf.EXPECT().Pop()
+				directory.EXPECT().Visit(block)
+
+				ret := ds.Tick(10)
+
+				Expect(ret).To(BeTrue())
+				Expect(trans.action).To(Equal(bankWriteHit))
+				Expect(block.SectorValid).
+					To(Equal([]bool{true, true, false, false}))
+			})
+
+			It("should fetch a partially written sector", func() {
+				write.Data = make([]byte, 4)
+				mshrEntry := &cache.MSHREntry{}
+				mshr.EXPECT().IsFull().Return(false)
+				mshr.EXPECT().Add(vm.PID(1), uint64(0x100)).Return(mshrEntry)
+				bankBuf.EXPECT().CanPush().Return(true)
+				bankBuf.EXPECT().Push(trans)
+				buf.EXPECT().Pop()
+				directory.EXPECT().Visit(block)
+
+				ret := ds.Tick(10)
+
+				Expect(ret).To(BeTrue())
+				Expect(trans.action).To(Equal(writeBufferFetch))
+				Expect(trans.fetchSectors).
+					To(Equal([]bool{false, true, false, false}))
+			})
+		})
+
 		Context("miss, write full line, no eviction", func() {
 			var (
 				block *cache.Block
@@ -470,6 +856,10 @@ var _ = Describe("DirectoryStage", func() {
 				directory.EXPECT().
 					Lookup(vm.PID(1), uint64(0x100)).
 					Return(nil)
+				directory.EXPECT().
+					ShouldBypass(vm.PID(1), uint64(0x100)).
+					Return(false).
+					AnyTimes()
 				directory.EXPECT().FindVictim(uint64(0x100)).Return(block)
 				mshr.EXPECT().Query(vm.PID(1), uint64(0x100)).Return(nil)
 			})
@@ -501,7 +891,7 @@ var _ = Describe("DirectoryStage", func() {
 						Expect(trans.block).To(BeIdenticalTo(block))
 					})
 				buf.EXPECT().Pop()
-				directory.EXPECT().Visit(block)
+				directory.EXPECT().Insert(block)
 
 				ret := ds.Tick(10)
 
@@ -530,6 +920,10 @@ var _ = Describe("DirectoryStage", func() {
 				directory.EXPECT().
 					Lookup(vm.PID(1), uint64(0x100)).
 					Return(nil)
+				directory.EXPECT().
+					ShouldBypass(vm.PID(1), uint64(0x100)).
+					Return(false).
+					AnyTimes()
 				mshr.EXPECT().Query(vm.PID(1), uint64(0x100)).Return(nil)
 				directory.EXPECT().FindVictim(uint64(0x100)).Return(block)
 				write.Data = make([]byte, 64)
@@ -542,7 +936,7 @@ var _ = Describe("DirectoryStage", func() {
 			})
 
 			It("should send to evictor", func() {
-				directory.EXPECT().Visit(block)
+				directory.EXPECT().Insert(block)
 				bankBuf.EXPECT().CanPush().Return(true)
 				bankBuf.EXPECT().
 					Push(gomock.Any()).
@@ -580,6 +974,10 @@ var _ = Describe("DirectoryStage", func() {
 				directory.EXPECT().
 					Lookup(vm.PID(1), uint64(0x100)).
 					Return(nil)
+				directory.EXPECT().
+					ShouldBypass(vm.PID(1), uint64(0x100)).
+					Return(false).
+					AnyTimes()
 				mshr.EXPECT().Query(vm.PID(1), uint64(0x100)).Return(nil)
 			})
 
@@ -609,7 +1007,7 @@ var _ = Describe("DirectoryStage", func() {
 				mshrEntry := &cache.MSHREntry{}
 				mshr.EXPECT().IsFull().Return(false)
 				directory.EXPECT().FindVictim(uint64(0x100)).Return(block)
-				directory.EXPECT().Visit(block)
+				directory.EXPECT().Insert(block)
 				bankBuf.EXPECT().CanPush().Return(true)
 				bankBuf.EXPECT().
 					Push(gomock.Any()).
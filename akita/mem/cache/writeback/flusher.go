@@ -5,6 +5,7 @@ import (
 	"reflect"
 
 	"github.com/sarchlab/akita/v3/mem/cache"
+	"github.com/sarchlab/akita/v3/mem/mem"
 	"github.com/sarchlab/akita/v3/sim"
 	"github.com/sarchlab/akita/v3/tracing"
 )
@@ -14,15 +15,16 @@ type flusher struct {
 
 	blockToEvict    []*cache.Block
 	processingFlush *cache.FlushReq
+	processingFence *mem.FenceReq
 }
 
 func (f *flusher) Tick(now sim.VTimeInSec) bool {
-	if f.processingFlush != nil && f.cache.state == cacheStatePreFlushing {
+	if f.isProcessing() && f.cache.state == cacheStatePreFlushing {
 		return f.processPreFlushing(now)
 	}
 
 	madeProgress := false
-	if f.processingFlush != nil && f.cache.state == cacheStateFlushing {
+	if f.isProcessing() && f.cache.state == cacheStateFlushing {
 		madeProgress = f.finalizeFlushing(now) || madeProgress
 		madeProgress = f.processFlush(now) || madeProgress
 		return madeProgress
@@ -31,6 +33,10 @@ func (f *flusher) Tick(now sim.VTimeInSec) bool {
 	return f.extractFromPort(now)
 }
 
+func (f *flusher) isProcessing() bool {
+	return f.processingFlush != nil || f.processingFence != nil
+}
+
 func (f *flusher) processPreFlushing(now sim.VTimeInSec) bool {
 	if f.existInflightTransaction() {
 		return false
@@ -79,12 +85,21 @@ func (f *flusher) processFlush(now sim.VTimeInSec) bool {
 
 	trans := &transaction{
 		flush:             f.processingFlush,
+		fence:             f.processingFence,
 		victim:            block,
 		action:            bankEvict,
 		evictingAddr:      block.Tag,
 		evictingDirtyMask: block.DirtyMask,
 	}
 	bankBuf.Push(trans)
+	f.cache.evictingList[block.Tag] = true
+
+	if f.processingFence != nil {
+		// The block stays in the cache after being written back, so that the
+		// following accesses can still hit.
+		block.IsDirty = false
+		block.DirtyMask = nil
+	}
 
 	f.blockToEvict = f.blockToEvict[1:]
 
@@ -126,6 +141,21 @@ func (f *flusher) startProcessingFlush(
 	return true
 }
 
+// startProcessingFence starts to write back all the dirty blocks so that the
+// writes before the fence become visible to the other agents in the system.
+func (f *flusher) startProcessingFence(
+	now sim.VTimeInSec,
+	fence *mem.FenceReq,
+) bool {
+	f.processingFence = fence
+	f.cache.state = cacheStatePreFlushing
+	f.cache.topPort.Retrieve(now)
+
+	tracing.TraceReqReceive(fence, f.cache)
+
+	return true
+}
+
 func (f *flusher) handleCacheRestart(
 	now sim.VTimeInSec,
 	req *cache.RestartReq,
@@ -135,7 +165,7 @@ func (f *flusher) handleCacheRestart(
 	}
 
 	clearPort(f.cache.topPort, now)
-	clearPort(f.cache.bottomPort, now)
+	f.clearBottomPort(now)
 
 	f.cache.state = cacheStateRunning
 
@@ -152,6 +182,22 @@ func (f *flusher) handleCacheRestart(
 	return true
 }
 
+// clearBottomPort discards the responses to the discarded transactions. The
+// invalidation requests are kept, as the coherence directory waits for the
+// responses.
+func (f *flusher) clearBottomPort(now sim.VTimeInSec) {
+	for {
+		item := f.cache.bottomPort.Retrieve(now)
+		if item == nil {
+			return
+		}
+
+		if req, ok := item.(*cache.InvalidateReq); ok {
+			f.cache.invalidator.accept(req)
+		}
+	}
+}
+
 func (f *flusher) finalizeFlushing(now sim.VTimeInSec) bool {
 	if len(f.blockToEvict) > 0 {
 		return false
@@ -161,6 +207,10 @@ func (f *flusher) finalizeFlushing(now sim.VTimeInSec) bool {
 		return false
 	}
 
+	if f.processingFence != nil {
+		return f.finalizeFence(now)
+	}
+
 	if !f.cache.controlPortSender.CanSend(1) {
 		return false
 	}
@@ -188,6 +238,33 @@ func (f *flusher) finalizeFlushing(now sim.VTimeInSec) bool {
 	return true
 }
 
+func (f *flusher) finalizeFence(now sim.VTimeInSec) bool {
+	if !f.cache.topSender.CanSend(1) {
+		return false
+	}
+
+	fence := f.processingFence
+	rsp := mem.FenceRspBuilder{}.
+		WithSendTime(now).
+		WithSrc(f.cache.topPort).
+		WithDst(fence.Src).
+		WithRspTo(fence.ID).
+		Build()
+	f.cache.topSender.Send(rsp)
+
+	if fence.Acquire {
+		f.cache.mshr.Reset()
+		f.cache.directory.Reset()
+	}
+
+	f.cache.state = cacheStateRunning
+
+	tracing.TraceReqComplete(fence, f.cache)
+	f.processingFence = nil
+
+	return true
+}
+
 func (f *flusher) flushCompleted() bool {
 	for _, b := range f.cache.dirToBankBuffers {
 		if b.Size() > 0 {
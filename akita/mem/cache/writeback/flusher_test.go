@@ -5,6 +5,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/sarchlab/akita/v3/mem/cache"
+	"github.com/sarchlab/akita/v3/mem/mem"
 	"github.com/sarchlab/akita/v3/sim"
 )
 
@@ -274,6 +275,74 @@ var _ = Describe("Flusher", func() {
 		})
 	})
 
+	Context("fence", func() {
+		var fence *mem.FenceReq
+
+		BeforeEach(func() {
+			fence = mem.FenceReqBuilder{}.
+				WithSendTime(8).
+				WithScope(mem.ScopeSystem).
+				WithRelease().
+				Build()
+			f.processingFence = fence
+		})
+
+		It("should keep the blocks after writing them back", func() {
+			cacheModule.state = cacheStateFlushing
+			block := &cache.Block{
+				Tag:       0x80,
+				IsValid:   true,
+				IsDirty:   true,
+				DirtyMask: make([]bool, 64),
+			}
+			f.blockToEvict = []*cache.Block{block}
+
+			bankBuf.EXPECT().CanPush().Return(true)
+			bankBuf.EXPECT().Push(gomock.Any()).Do(func(trans *transaction) {
+				Expect(trans.req()).To(BeIdenticalTo(fence))
+			})
+
+			ret := f.Tick(10)
+
+			Expect(ret).To(BeTrue())
+			Expect(block.IsValid).To(BeTrue())
+			Expect(block.IsDirty).To(BeFalse())
+		})
+
+		It("should respond to the top after all the blocks are evicted",
+			func() {
+				cacheModule.state = cacheStateFlushing
+				bankBuf.EXPECT().Size().Return(0)
+				writeBufferBuf.EXPECT().Size().Return(0)
+				topPortSender.EXPECT().CanSend(1).Return(true)
+				topPortSender.EXPECT().Send(gomock.Any()).
+					Do(func(rsp *mem.FenceRsp) {
+						Expect(rsp.RespondTo).To(Equal(fence.ID))
+					})
+
+				ret := f.Tick(10)
+
+				Expect(ret).To(BeTrue())
+				Expect(f.processingFence).To(BeNil())
+				Expect(cacheModule.state).To(Equal(cacheStateRunning))
+			})
+
+		It("should invalidate the cache for acquire fences", func() {
+			fence.Acquire = true
+			cacheModule.state = cacheStateFlushing
+			bankBuf.EXPECT().Size().Return(0)
+			writeBufferBuf.EXPECT().Size().Return(0)
+			topPortSender.EXPECT().CanSend(1).Return(true)
+			topPortSender.EXPECT().Send(gomock.Any())
+			mshr.EXPECT().Reset()
+			directory.EXPECT().Reset()
+
+			ret := f.Tick(10)
+
+			Expect(ret).To(BeTrue())
+		})
+	})
+
 	Context("flush with reset", func() {
 		It("should remove inflight state", func() {
 			req := cache.FlushReqBuilder{}.
@@ -0,0 +1,128 @@
+package writeback
+
+import (
+	"github.com/sarchlab/akita/v3/mem/cache"
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/akita/v3/tracing"
+)
+
+// An invalidator removes cache lines upon the requests from a coherence
+// directory.
+type invalidator struct {
+	cache *Cache
+
+	pendingInvalidations []*cache.InvalidateReq
+}
+
+func (inv *invalidator) Tick(now sim.VTimeInSec) bool {
+	madeProgress := false
+
+	madeProgress = inv.extractFromPort(now) || madeProgress
+	madeProgress = inv.processInvalidation(now) || madeProgress
+
+	return madeProgress
+}
+
+func (inv *invalidator) extractFromPort(now sim.VTimeInSec) bool {
+	req, ok := inv.cache.bottomPort.Peek().(*cache.InvalidateReq)
+	if !ok {
+		return false
+	}
+
+	inv.accept(req)
+	inv.cache.bottomPort.Retrieve(now)
+
+	return true
+}
+
+func (inv *invalidator) accept(req *cache.InvalidateReq) {
+	inv.pendingInvalidations = append(inv.pendingInvalidations, req)
+
+	tracing.TraceReqReceive(req, inv.cache)
+}
+
+func (inv *invalidator) processInvalidation(now sim.VTimeInSec) bool {
+	for i, req := range inv.pendingInvalidations {
+		if inv.invalidate(now, req) {
+			inv.pendingInvalidations = append(
+				inv.pendingInvalidations[:i],
+				inv.pendingInvalidations[i+1:]...)
+
+			return true
+		}
+	}
+
+	return false
+}
+
+func (inv *invalidator) invalidate(
+	now sim.VTimeInSec,
+	req *cache.InvalidateReq,
+) bool {
+	if !inv.cache.bottomSender.CanSend(1) {
+		return false
+	}
+
+	// The directory must receive the evicted data before the response.
+	if inv.isEvicting(req) {
+		return false
+	}
+
+	rspBuilder := cache.InvalidateRspBuilder{}.
+		WithSendTime(now).
+		WithSrc(inv.cache.bottomPort).
+		WithDst(req.Src).
+		WithRspTo(req.ID)
+
+	// An MSHR entry means that the line is being fetched. As the directory
+	// always responds to the fetch before invalidating the line, the
+	// invalidation targets an earlier copy that has already been dropped.
+	if inv.cache.mshr.Query(req.PID, req.Address) == nil {
+		block := inv.cache.directory.Lookup(req.PID, req.Address)
+		if block != nil {
+			if block.IsLocked || block.ReadCount > 0 {
+				return false
+			}
+
+			rspBuilder = inv.invalidateBlock(block, rspBuilder)
+		}
+	}
+
+	rsp := rspBuilder.Build()
+	inv.cache.bottomSender.Send(rsp)
+
+	tracing.TraceReqComplete(req, inv.cache)
+
+	return true
+}
+
+func (inv *invalidator) isEvicting(req *cache.InvalidateReq) bool {
+	if inv.cache.evictingList[req.Address] {
+		return true
+	}
+
+	return inv.cache.writeBuffer.isEvicting(req.PID, req.Address)
+}
+
+func (inv *invalidator) invalidateBlock(
+	block *cache.Block,
+	rspBuilder cache.InvalidateRspBuilder,
+) cache.InvalidateRspBuilder {
+	if block.IsDirty {
+		data, err := inv.cache.storage.Read(
+			block.CacheAddress, 1<<inv.cache.log2BlockSize)
+		if err != nil {
+			panic(err)
+		}
+
+		rspBuilder = rspBuilder.
+			WithData(data).
+			WithDirtyMask(block.DirtyMask)
+	}
+
+	block.IsValid = false
+	block.IsDirty = false
+	block.DirtyMask = nil
+
+	return rspBuilder
+}
@@ -0,0 +1,189 @@
+package writeback
+
+import (
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/akita/v3/mem/cache"
+	"github.com/sarchlab/akita/v3/mem/mem"
+)
+
+var _ = Describe("Invalidator", func() {
+	var (
+		mockCtrl         *gomock.Controller
+		bottomPort       *MockPort
+		directoryPort    *MockPort
+		directory        *MockDirectory
+		mshr             *MockMSHR
+		bottomPortSender *MockBufferedSender
+		cacheModule      *Cache
+		inv              *invalidator
+		req              *cache.InvalidateReq
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		bottomPort = NewMockPort(mockCtrl)
+		directoryPort = NewMockPort(mockCtrl)
+		directory = NewMockDirectory(mockCtrl)
+		mshr = NewMockMSHR(mockCtrl)
+		bottomPortSender = NewMockBufferedSender(mockCtrl)
+
+		cacheModule = MakeBuilder().WithCoherence().Build("Cache")
+		cacheModule.bottomPort = bottomPort
+		cacheModule.directory = directory
+		cacheModule.mshr = mshr
+		cacheModule.bottomSender = bottomPortSender
+
+		inv = &invalidator{cache: cacheModule}
+
+		req = cache.InvalidateReqBuilder{}.
+			WithSrc(directoryPort).
+			WithDst(bottomPort).
+			WithPID(1).
+			WithAddress(0x100).
+			Build()
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	It("should do nothing if no request", func() {
+		bottomPort.EXPECT().Peek().Return(nil)
+
+		madeProgress := inv.Tick(10)
+
+		Expect(madeProgress).To(BeFalse())
+	})
+
+	It("should not extract responses", func() {
+		bottomPort.EXPECT().Peek().Return(&mem.DataReadyRsp{})
+
+		madeProgress := inv.Tick(10)
+
+		Expect(madeProgress).To(BeFalse())
+	})
+
+	It("should extract invalidation requests", func() {
+		bottomPort.EXPECT().Peek().Return(req)
+		bottomPort.EXPECT().Retrieve(gomock.Any()).Return(req)
+		bottomPortSender.EXPECT().CanSend(1).Return(false)
+
+		madeProgress := inv.Tick(10)
+
+		Expect(madeProgress).To(BeTrue())
+		Expect(inv.pendingInvalidations).To(ContainElement(req))
+	})
+
+	Context("processing invalidations", func() {
+		BeforeEach(func() {
+			inv.pendingInvalidations = append(inv.pendingInvalidations, req)
+			bottomPortSender.EXPECT().CanSend(1).Return(true).AnyTimes()
+		})
+
+		It("should respond if the line is not in the cache", func() {
+			mshr.EXPECT().Query(req.PID, req.Address).Return(nil)
+			directory.EXPECT().Lookup(req.PID, req.Address).Return(nil)
+			bottomPortSender.EXPECT().
+				Send(gomock.Any()).
+				Do(func(rsp *cache.InvalidateRsp) {
+					Expect(rsp.RspTo).To(Equal(req.ID))
+					Expect(rsp.Dst).To(BeIdenticalTo(directoryPort))
+					Expect(rsp.HasData()).To(BeFalse())
+				})
+
+			madeProgress := inv.processInvalidation(10)
+
+			Expect(madeProgress).To(BeTrue())
+			Expect(inv.pendingInvalidations).To(BeEmpty())
+		})
+
+		It("should not touch the line that is being fetched", func() {
+			block := &cache.Block{IsValid: true, IsLocked: true}
+			mshr.EXPECT().Query(req.PID, req.Address).
+				Return(&cache.MSHREntry{Block: block})
+			bottomPortSender.EXPECT().Send(gomock.Any())
+
+			madeProgress := inv.processInvalidation(10)
+
+			Expect(madeProgress).To(BeTrue())
+			Expect(block.IsValid).To(BeTrue())
+		})
+
+		It("should wait if the line is locked", func() {
+			block := &cache.Block{IsValid: true, IsLocked: true}
+			mshr.EXPECT().Query(req.PID, req.Address).Return(nil)
+			directory.EXPECT().Lookup(req.PID, req.Address).Return(block)
+
+			madeProgress := inv.processInvalidation(10)
+
+			Expect(madeProgress).To(BeFalse())
+			Expect(inv.pendingInvalidations).To(ContainElement(req))
+		})
+
+		It("should wait if the line is being evicted", func() {
+			cacheModule.evictingList[req.Address] = true
+
+			madeProgress := inv.processInvalidation(10)
+
+			Expect(madeProgress).To(BeFalse())
+		})
+
+		It("should wait if the line is in the write buffer", func() {
+			cacheModule.writeBuffer.pendingEvictions = append(
+				cacheModule.writeBuffer.pendingEvictions,
+				&transaction{evictingPID: 1, evictingAddr: 0x100})
+
+			madeProgress := inv.processInvalidation(10)
+
+			Expect(madeProgress).To(BeFalse())
+		})
+
+		It("should invalidate a clean line", func() {
+			block := &cache.Block{IsValid: true}
+			mshr.EXPECT().Query(req.PID, req.Address).Return(nil)
+			directory.EXPECT().Lookup(req.PID, req.Address).Return(block)
+			bottomPortSender.EXPECT().
+				Send(gomock.Any()).
+				Do(func(rsp *cache.InvalidateRsp) {
+					Expect(rsp.HasData()).To(BeFalse())
+				})
+
+			madeProgress := inv.processInvalidation(10)
+
+			Expect(madeProgress).To(BeTrue())
+			Expect(block.IsValid).To(BeFalse())
+		})
+
+		It("should send the data back when invalidating a dirty line", func() {
+			data := make([]byte, 64)
+			data[4] = 42
+			dirtyMask := make([]bool, 64)
+			dirtyMask[4] = true
+			block := &cache.Block{
+				IsValid:      true,
+				IsDirty:      true,
+				CacheAddress: 0x40,
+				DirtyMask:    dirtyMask,
+			}
+			err := cacheModule.storage.Write(0x40, data)
+			Expect(err).To(BeNil())
+
+			mshr.EXPECT().Query(req.PID, req.Address).Return(nil)
+			directory.EXPECT().Lookup(req.PID, req.Address).Return(block)
+			bottomPortSender.EXPECT().
+				Send(gomock.Any()).
+				Do(func(rsp *cache.InvalidateRsp) {
+					Expect(rsp.Data).To(Equal(data))
+					Expect(rsp.DirtyMask).To(Equal(dirtyMask))
+				})
+
+			madeProgress := inv.processInvalidation(10)
+
+			Expect(madeProgress).To(BeTrue())
+			Expect(block.IsValid).To(BeFalse())
+			Expect(block.IsDirty).To(BeFalse())
+		})
+	})
+})
@@ -45,9 +45,13 @@ func (s *mshrStage) processOneReq(now sim.VTimeInSec) bool {
 	if transactionPresent {
 		s.removeTransaction(now, trans)
 
-		if trans.read != nil {
+		switch {
+		case trans.prefetch:
+			tracing.EndTask(
+				tracing.MsgIDAtReceiver(trans.read, s.cache), s.cache)
+		case trans.read != nil:
 			s.respondRead(now, trans.read, mshrEntry.Data)
-		} else {
+		default:
 			s.respondWrite(now, trans.write)
 		}
 
@@ -82,6 +86,7 @@ func (s *mshrStage) respondRead(
 		Build()
 	s.cache.topSender.Send(dataReady)
 
+	s.cache.stats.ObserveReqLatency(now, read)
 	tracing.TraceReqComplete(read, s.cache)
 }
 
@@ -97,6 +102,7 @@ func (s *mshrStage) respondWrite(
 		Build()
 	s.cache.topSender.Send(writeDoneRsp)
 
+	s.cache.stats.ObserveReqLatency(now, write)
 	tracing.TraceReqComplete(write, s.cache)
 }
 
@@ -182,4 +182,26 @@ var _ = Describe("MSHR Stage", func() {
 		Expect(ret).To(BeTrue())
 		Expect(ms.processingMSHREntry).To(BeNil())
 	})
+	It("should not respond to prefetches", func() {
+		read := mem.ReadReqBuilder{}.
+			WithAddress(0x100).
+			WithByteSize(64).
+			Build()
+		trans := &transaction{read: read, prefetch: true}
+		cacheModule.inFlightTransactions = append(
+			cacheModule.inFlightTransactions, trans)
+		mshrEntry := &cache.MSHREntry{
+			Requests: []interface{}{trans},
+			Block:    &cache.Block{Tag: 0x100},
+			Data:     make([]byte, 64),
+		}
+		inBuf.EXPECT().Pop().Return(mshrEntry)
+		topSender.EXPECT().CanSend(1).Return(true)
+
+		ret := ms.Tick(10)
+
+		Expect(ret).To(BeTrue())
+		Expect(ms.processingMSHREntry).To(BeNil())
+		Expect(cacheModule.inFlightTransactions).NotTo(ContainElement(trans))
+	})
 })
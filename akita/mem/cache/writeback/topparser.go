@@ -20,12 +20,16 @@ func (p *topParser) Tick(now sim.VTimeInSec) bool {
 		return false
 	}
 
+	if fence, ok := req.(*mem.FenceReq); ok {
+		return p.processFence(now, fence)
+	}
+
 	if !p.cache.dirStageBuffer.CanPush() {
 		return false
 	}
 
 	trans := &transaction{
-		id: sim.GetIDGenerator().Generate(),
+		id: sim.IDGeneratorFor(p.cache).Generate(),
 	}
 	switch req := req.(type) {
 	case *mem.ReadReq:
@@ -43,3 +47,36 @@ func (p *topParser) Tick(now sim.VTimeInSec) bool {
 
 	return true
 }
+
+// processFence handles the fences from the top. As the cache is shared by all
+// the L1 caches of a GPU, it is already coherent at the agent scope. Coherent
+// caches are also kept coherent at the system scope by the directory. Other
+// system-scope fences are handled by the flusher.
+func (p *topParser) processFence(
+	now sim.VTimeInSec,
+	fence *mem.FenceReq,
+) bool {
+	if fence.Scope == mem.ScopeSystem && !p.cache.coherent {
+		return p.cache.flusher.startProcessingFence(now, fence)
+	}
+
+	if !p.cache.topSender.CanSend(1) {
+		return false
+	}
+
+	tracing.TraceReqReceive(fence, p.cache)
+
+	rsp := mem.FenceRspBuilder{}.
+		WithSendTime(now).
+		WithSrc(p.cache.topPort).
+		WithDst(fence.Src).
+		WithRspTo(fence.ID).
+		Build()
+	p.cache.topSender.Send(rsp)
+
+	tracing.TraceReqComplete(fence, p.cache)
+
+	p.cache.topPort.Retrieve(now)
+
+	return true
+}
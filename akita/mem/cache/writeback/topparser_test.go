@@ -101,4 +101,42 @@ var _ = Describe("TopParser", func() {
 		Expect(cache.inFlightTransactions).To(HaveLen(1))
 	})
 
+	It("should respond to agent-scope fences immediately", func() {
+		topSender := NewMockBufferedSender(mockCtrl)
+		cache.topSender = topSender
+		fence := mem.FenceReqBuilder{}.
+			WithSendTime(10).
+			WithScope(mem.ScopeAgent).
+			WithAcquire().
+			Build()
+
+		port.EXPECT().Peek().Return(fence)
+		topSender.EXPECT().CanSend(1).Return(true)
+		topSender.EXPECT().Send(gomock.Any()).Do(func(rsp *mem.FenceRsp) {
+			Expect(rsp.RespondTo).To(Equal(fence.ID))
+		})
+		port.EXPECT().Retrieve(sim.VTimeInSec(10)).Return(fence)
+
+		ret := parser.Tick(10)
+
+		Expect(ret).To(BeTrue())
+		Expect(cache.inFlightTransactions).To(BeEmpty())
+	})
+
+	It("should flush the cache for system-scope fences", func() {
+		fence := mem.FenceReqBuilder{}.
+			WithSendTime(10).
+			WithScope(mem.ScopeSystem).
+			WithRelease().
+			Build()
+
+		port.EXPECT().Peek().Return(fence)
+		port.EXPECT().Retrieve(sim.VTimeInSec(10)).Return(fence)
+
+		ret := parser.Tick(10)
+
+		Expect(ret).To(BeTrue())
+		Expect(cache.flusher.processingFence).To(BeIdenticalTo(fence))
+		Expect(cache.state).To(Equal(cacheStatePreFlushing))
+	})
 })
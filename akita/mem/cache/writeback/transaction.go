@@ -21,6 +21,7 @@ const (
 	writeBufferEvictAndFetch
 	writeBufferEvictAndWrite
 	writeBufferFlush
+	writeBufferBypassWrite
 )
 
 type transaction struct {
@@ -29,10 +30,12 @@ type transaction struct {
 	read              *mem.ReadReq
 	write             *mem.WriteReq
 	flush             *cache.FlushReq
+	fence             *mem.FenceReq
 	block             *cache.Block
 	victim            *cache.Block
 	fetchPID          vm.PID
 	fetchAddress      uint64
+	fetchSectors      []bool
 	fetchedData       []byte
 	fetchReadReq      *mem.ReadReq
 	evictingPID       vm.PID
@@ -41,6 +44,8 @@ type transaction struct {
 	evictingDirtyMask []bool
 	evictionWriteReq  *mem.WriteReq
 	mshrEntry         *cache.MSHREntry
+	prefetch          bool
+	bypass            bool
 }
 
 func (t transaction) accessReq() mem.AccessReq {
@@ -60,5 +65,8 @@ func (t transaction) req() sim.Msg {
 	if t.flush != nil {
 		return t.flush
 	}
+	if t.fence != nil {
+		return t.fence
+	}
 	return nil
 }
@@ -2,6 +2,7 @@ package writeback
 
 import (
 	"github.com/sarchlab/akita/v3/mem/cache"
+	"github.com/sarchlab/akita/v3/mem/mem"
 	"github.com/sarchlab/akita/v3/sim"
 )
 
@@ -27,3 +28,120 @@ func clearPort(p sim.Port, now sim.VTimeInSec) {
 		}
 	}
 }
+
+// isSectored returns true if the blocks of the cache are divided into sectors
+// that are fetched separately.
+func (c *Cache) isSectored() bool {
+	return c.log2SectorSize != 0 && c.log2SectorSize < c.log2BlockSize
+}
+
+// log2Sector returns the sector size as a power of 2. A block of a cache that
+// is not sectored has a single sector.
+func (c *Cache) log2Sector() uint64 {
+	if !c.isSectored() {
+		return c.log2BlockSize
+	}
+
+	return c.log2SectorSize
+}
+
+func (c *Cache) numSectors() int {
+	return 1 << (c.log2BlockSize - c.log2Sector())
+}
+
+// touchedSectors returns the mask of the sectors that an access touches.
+func (c *Cache) touchedSectors(addr, byteSize uint64) []bool {
+	mask := make([]bool, c.numSectors())
+	_, offset := getCacheLineID(addr, c.log2BlockSize)
+
+	first := offset >> c.log2Sector()
+	last := (offset + byteSize - 1) >> c.log2Sector()
+	for i := first; i <= last && i < uint64(len(mask)); i++ {
+		mask[i] = true
+	}
+
+	return mask
+}
+
+// fullyWrittenSectors returns the mask of the sectors whose bytes are all
+// overwritten by a write.
+func (c *Cache) fullyWrittenSectors(write *mem.WriteReq) []bool {
+	_, offset := getCacheLineID(write.Address, c.log2BlockSize)
+	written := make([]bool, 1<<c.log2BlockSize)
+	for i := range write.Data {
+		if write.DirtyMask == nil || write.DirtyMask[i] {
+			written[offset+uint64(i)] = true
+		}
+	}
+
+	sectorSize := 1 << c.log2Sector()
+	mask := make([]bool, c.numSectors())
+	for i := range mask {
+		mask[i] = true
+		for j := i * sectorSize; j < (i+1)*sectorSize; j++ {
+			if !written[j] {
+				mask[i] = false
+				break
+			}
+		}
+	}
+
+	return mask
+}
+
+func isSectorValid(block *cache.Block, sector int) bool {
+	return block.IsValid &&
+		block.SectorValid != nil &&
+		block.SectorValid[sector]
+}
+
+// fetchRange returns the address and the size of the read that fetches the
+// sectors of a transaction. The read covers all the sectors from the first to
+// the last sector to fetch.
+func (c *Cache) fetchRange(trans *transaction) (addr, byteSize uint64) {
+	if trans.fetchSectors == nil {
+		return trans.fetchAddress, 1 << c.log2BlockSize
+	}
+
+	first, last := -1, -1
+	for i, fetch := range trans.fetchSectors {
+		if !fetch {
+			continue
+		}
+
+		if first < 0 {
+			first = i
+		}
+		last = i
+	}
+
+	addr = trans.fetchAddress + uint64(first)<<c.log2Sector()
+	byteSize = uint64(last-first+1) << c.log2Sector()
+
+	return addr, byteSize
+}
+
+// combineData applies the writes that wait for a fetch to the fetched data.
+func combineData(mshrEntry *cache.MSHREntry, log2BlockSize uint64) {
+	if mshrEntry.Block.DirtyMask == nil {
+		mshrEntry.Block.DirtyMask = make([]bool, 1<<log2BlockSize)
+	}
+
+	for _, t := range mshrEntry.Requests {
+		trans := t.(*transaction)
+		if trans.read != nil {
+			continue
+		}
+
+		mshrEntry.Block.IsDirty = true
+		write := trans.write
+		_, offset := getCacheLineID(write.Address, log2BlockSize)
+		for i := 0; i < len(write.Data); i++ {
+			if write.DirtyMask == nil || write.DirtyMask[i] {
+				index := offset + uint64(i)
+				mshrEntry.Data[index] = write.Data[i]
+				mshrEntry.Block.DirtyMask[index] = true
+			}
+		}
+	}
+}
@@ -3,7 +3,9 @@ package writeback
 import (
 	"github.com/sarchlab/akita/v3/mem/cache"
 	"github.com/sarchlab/akita/v3/mem/mem"
+	"github.com/sarchlab/akita/v3/mem/vm"
 	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/akita/v3/tracing"
 )
 
 type cacheState int
@@ -40,17 +42,27 @@ type Cache struct {
 	bankStages  []*bankStage
 	mshrStage   *mshrStage
 	flusher     *flusher
+	invalidator *invalidator
 
 	storage         *mem.Storage
 	lowModuleFinder mem.LowModuleFinder
 	directory       cache.Directory
 	mshr            cache.MSHR
 	log2BlockSize   uint64
+	log2SectorSize  uint64
 	numReqPerCycle  int
+	coherent        bool
+
+	interleaving      *mem.InterleavingConverter
+	prefetcher        cache.Prefetcher
+	prefetchQueue     []*transaction
+	prefetchQueueSize int
 
 	state                cacheState
 	inFlightTransactions []*transaction
 	evictingList         map[uint64]bool
+
+	stats cache.Stats
 }
 
 // SetLowModuleFinder sets the LowModuleFinder used by the cache.
@@ -58,6 +70,79 @@ func (c *Cache) SetLowModuleFinder(lmf mem.LowModuleFinder) {
 	c.lowModuleFinder = lmf
 }
 
+// addTaskStep adds a step to the task of a transaction and counts the step.
+func (c *Cache) addTaskStep(taskID, step string) {
+	tracing.AddTaskStep(taskID, c, step)
+	c.stats.CountStep(step)
+}
+
+// WarmUp functionally brings the line that contains the address into the
+// cache without spending any time. Addresses that are mapped to other
+// interleaved banks are ignored. The data is loaded later with Refill.
+func (c *Cache) WarmUp(pid vm.PID, addr uint64) {
+	if c.interleaving != nil && !c.interleaving.Contains(addr) {
+		return
+	}
+
+	block := cache.WarmUp(c.directory, pid, addr, c.log2BlockSize)
+	if block == nil {
+		return
+	}
+
+	c.warmUpSectors(block)
+}
+
+// WarmUpWrite functionally brings the line that contains the address into the
+// cache and marks the written bytes dirty, so that the line is written back
+// when it is evicted. The bytes beyond the line are ignored.
+func (c *Cache) WarmUpWrite(pid vm.PID, addr, byteSize uint64) {
+	if c.interleaving != nil && !c.interleaving.Contains(addr) {
+		return
+	}
+
+	block := cache.WarmUp(c.directory, pid, addr, c.log2BlockSize)
+	if block == nil {
+		return
+	}
+
+	c.warmUpSectors(block)
+
+	blockSize := uint64(1) << c.log2BlockSize
+	_, offset := getCacheLineID(addr, c.log2BlockSize)
+	end := offset + byteSize
+	if end > blockSize {
+		end = blockSize
+	}
+
+	if block.DirtyMask == nil {
+		block.DirtyMask = make([]bool, blockSize)
+	}
+
+	for i := offset; i < end; i++ {
+		block.DirtyMask[i] = true
+	}
+
+	block.IsDirty = true
+}
+
+// warmUpSectors marks all the sectors of a warmed-up block valid, as the whole
+// line is loaded by Refill.
+func (c *Cache) warmUpSectors(block *cache.Block) {
+	if !c.isSectored() || block.SectorValid != nil {
+		return
+	}
+
+	block.SectorValid = make([]bool, c.numSectors())
+	for i := range block.SectorValid {
+		block.SectorValid[i] = true
+	}
+}
+
+// Refill reloads the data of all the valid lines from the memory.
+func (c *Cache) Refill(memory *mem.Storage) error {
+	return cache.Refill(c.directory, c.storage, memory, c.log2BlockSize)
+}
+
 // Tick updates the internal states of the Cache.
 func (c *Cache) Tick(now sim.VTimeInSec) bool {
 	madeProgress := false
@@ -66,6 +151,8 @@ func (c *Cache) Tick(now sim.VTimeInSec) bool {
 
 	if c.state != cacheStatePaused {
 		madeProgress = c.runPipeline(now) || madeProgress
+	} else {
+		madeProgress = c.runCoherence(now) || madeProgress
 	}
 
 	madeProgress = c.flusher.Tick(now) || madeProgress
@@ -79,6 +166,7 @@ func (c *Cache) runPipeline(now sim.VTimeInSec) bool {
 	madeProgress = c.runStage(now, c.topSender) || madeProgress
 	madeProgress = c.runStage(now, c.bottomSender) || madeProgress
 	madeProgress = c.runStage(now, c.mshrStage) || madeProgress
+	madeProgress = c.runStage(now, c.invalidator) || madeProgress
 
 	for _, bs := range c.bankStages {
 		madeProgress = bs.Tick(now) || madeProgress
@@ -91,6 +179,18 @@ func (c *Cache) runPipeline(now sim.VTimeInSec) bool {
 	return madeProgress
 }
 
+// runCoherence keeps responding to invalidation requests while the cache is
+// paused, as the coherence directory cannot make progress without the
+// responses.
+func (c *Cache) runCoherence(now sim.VTimeInSec) bool {
+	madeProgress := false
+
+	madeProgress = c.runStage(now, c.bottomSender) || madeProgress
+	madeProgress = c.runStage(now, c.invalidator) || madeProgress
+
+	return madeProgress
+}
+
 func (c *Cache) runStage(now sim.VTimeInSec, stage sim.Ticker) bool {
 	madeProgress := false
 	for i := 0; i < c.numReqPerCycle; i++ {
@@ -118,6 +218,8 @@ func (c *Cache) discardInflightTransactions(now sim.VTimeInSec) {
 	clearPort(c.topPort, now)
 
 	c.topSender.Clear()
+	c.evictingList = make(map[uint64]bool)
+	c.prefetchQueue = nil
 
 	// for _, t := range c.inFlightTransactions {
 	// 	fmt.Printf("%.10f, %s, transaction %s discarded due to flushing\n",
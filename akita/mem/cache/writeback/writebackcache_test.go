@@ -120,6 +120,48 @@ var _ = Describe("Write-Back Cache Integration", func() {
 		Expect(directory.Sets[0].LRUQueue[3]).To(BeIdenticalTo(block))
 	})
 
+	It("should hit on warmed-up lines after refilling", func() {
+		directory = cache.NewDirectory(1024, 4, 64, cache.NewLRUVictimFinder())
+		cacheModule.directory = directory
+		dram.Storage.Write(0x10000, []byte{1, 2, 3, 4, 5, 6, 7, 8})
+
+		cacheModule.WarmUp(0, 0x10004)
+		Expect(cacheModule.Refill(dram.Storage)).To(Succeed())
+		dram.Storage.Write(0x10000, []byte{0, 0, 0, 0, 0, 0, 0, 0})
+
+		read := mem.ReadReqBuilder{}.
+			WithSendTime(10).
+			WithSrc(agentPort).
+			WithDst(cacheModule.topPort).
+			WithAddress(0x10004).
+			WithByteSize(4).
+			Build()
+		read.RecvTime = 10
+		cacheModule.topPort.Recv(read)
+
+		agentPort.EXPECT().Recv(gomock.Any()).
+			Do(func(dr *mem.DataReadyRsp) {
+				Expect(dr.Data).To(Equal([]byte{5, 6, 7, 8}))
+			})
+
+		engine.Run()
+	})
+
+	It("should mark the bytes written while warming up dirty", func() {
+		directory = cache.NewDirectory(1024, 4, 64, cache.NewLRUVictimFinder())
+		cacheModule.directory = directory
+
+		cacheModule.WarmUpWrite(0, 0x1003c, 8)
+
+		block := directory.Lookup(0, 0x10000)
+		Expect(block).NotTo(BeNil())
+		Expect(block.IsDirty).To(BeTrue())
+		Expect(block.DirtyMask).To(HaveLen(64))
+		for i, dirty := range block.DirtyMask {
+			Expect(dirty).To(Equal(i >= 0x3c))
+		}
+	})
+
 	It("should write hit", func() {
 		block := directory.Sets[0].Blocks[0]
 		block.Tag = 0x10000
@@ -3,6 +3,7 @@ package writeback
 import (
 	"github.com/sarchlab/akita/v3/mem/cache"
 	"github.com/sarchlab/akita/v3/mem/mem"
+	"github.com/sarchlab/akita/v3/mem/vm"
 	"github.com/sarchlab/akita/v3/sim"
 	"github.com/sarchlab/akita/v3/tracing"
 )
@@ -43,7 +44,7 @@ func (wb *writeBufferStage) processNewTransaction(now sim.VTimeInSec) bool {
 		return wb.processWriteBufferEvictAndWrite(now, trans)
 	case writeBufferEvictAndFetch:
 		return wb.processWriteBufferFetchAndEvict(now, trans)
-	case writeBufferFlush:
+	case writeBufferFlush, writeBufferBypassWrite:
 		return wb.processWriteBufferFlush(now, trans, true)
 	default:
 		panic("unknown transaction action")
@@ -54,6 +55,10 @@ func (wb *writeBufferStage) processWriteBufferFetch(
 	now sim.VTimeInSec,
 	trans *transaction,
 ) bool {
+	if wb.cache.coherent || wb.cache.isSectored() || trans.bypass {
+		return wb.fetchAfterEviction(now, trans)
+	}
+
 	if wb.findDataLocally(trans) {
 		return wb.sendFetchedDataToBank(now, trans)
 	}
@@ -61,16 +66,48 @@ func (wb *writeBufferStage) processWriteBufferFetch(
 	return wb.fetchFromBottom(now, trans)
 }
 
+// fetchAfterEviction fetches the line from the bottom without looking for the
+// data in the write buffer. A coherent cache may no longer have the permission
+// to access the data in the write buffer, and the evicted line of a sectored
+// cache may not hold all the sectors. The fetch waits until the eviction of
+// the line completes, so that the bottom receives the writeback first.
+func (wb *writeBufferStage) fetchAfterEviction(
+	now sim.VTimeInSec,
+	trans *transaction,
+) bool {
+	if wb.isEvicting(trans.fetchPID, trans.fetchAddress) {
+		return false
+	}
+
+	return wb.fetchFromBottom(now, trans)
+}
+
+func (wb *writeBufferStage) isEvicting(pid vm.PID, addr uint64) bool {
+	for _, e := range wb.inflightEviction {
+		if !e.bypass && e.evictingPID == pid && e.evictingAddr == addr {
+			return true
+		}
+	}
+
+	for _, e := range wb.pendingEvictions {
+		if !e.bypass && e.evictingPID == pid && e.evictingAddr == addr {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (wb *writeBufferStage) findDataLocally(trans *transaction) bool {
 	for _, e := range wb.inflightEviction {
-		if e.evictingAddr == trans.fetchAddress {
+		if !e.bypass && e.evictingAddr == trans.fetchAddress {
 			trans.fetchedData = e.evictingData
 			return true
 		}
 	}
 
 	for _, e := range wb.pendingEvictions {
-		if e.evictingAddr == trans.fetchAddress {
+		if !e.bypass && e.evictingAddr == trans.fetchAddress {
 			trans.fetchedData = e.evictingData
 			return true
 		}
@@ -94,7 +131,7 @@ func (wb *writeBufferStage) sendFetchedDataToBank(
 
 	trans.mshrEntry.Data = trans.fetchedData
 	trans.action = bankWriteFetched
-	wb.combineData(trans.mshrEntry)
+	combineData(trans.mshrEntry, wb.cache.log2BlockSize)
 
 	wb.cache.mshr.Remove(trans.mshrEntry.PID, trans.mshrEntry.Address)
 
@@ -125,14 +162,20 @@ func (wb *writeBufferStage) fetchFromBottom(
 		return false
 	}
 
-	lowModulePort := wb.cache.lowModuleFinder.Find(trans.fetchAddress)
-	read := mem.ReadReqBuilder{}.
+	addr, byteSize := wb.cache.fetchRange(trans)
+	lowModulePort := wb.cache.lowModuleFinder.Find(addr)
+	readBuilder := mem.ReadReqBuilder{}.
 		WithSrc(wb.cache.bottomPort).
 		WithDst(lowModulePort).
 		WithPID(trans.fetchPID).
-		WithAddress(trans.fetchAddress).
-		WithByteSize(1 << wb.cache.log2BlockSize).
-		Build()
+		WithAddress(addr).
+		WithByteSize(byteSize)
+	if wb.cache.coherent {
+		readBuilder = readBuilder.WithInfo(&cache.CoherentFetchInfo{
+			Exclusive: trans.write != nil,
+		})
+	}
+	read := readBuilder.Build()
 	wb.cache.bottomSender.Send(read)
 
 	trans.fetchReadReq = read
@@ -275,6 +318,9 @@ func (wb *writeBufferStage) processReturnRsp(now sim.VTimeInSec) bool {
 		return wb.processDataReadyRsp(now, msg)
 	case *mem.WriteDoneRsp:
 		return wb.processWriteDoneRsp(now, msg)
+	case *cache.InvalidateReq:
+		// Handled by the invalidator.
+		return false
 	default:
 		panic("unknown msg type")
 	}
@@ -285,6 +331,10 @@ func (wb *writeBufferStage) processDataReadyRsp(
 	dataReady *mem.DataReadyRsp,
 ) bool {
 	trans := wb.findInflightFetchByFetchReadReqID(dataReady.RespondTo)
+	if trans.bypass {
+		return wb.processBypassDataReadyRsp(now, trans, dataReady)
+	}
+
 	bankIndex := bankID(
 		trans.block,
 		wb.cache.directory.WayAssociativity(),
@@ -298,8 +348,12 @@ func (wb *writeBufferStage) processDataReadyRsp(
 
 	trans.fetchedData = dataReady.Data
 	trans.action = bankWriteFetched
-	trans.mshrEntry.Data = dataReady.Data
-	wb.combineData(trans.mshrEntry)
+
+	// The fetched sectors are merged with the rest of the block in the bank.
+	if trans.fetchSectors == nil {
+		trans.mshrEntry.Data = dataReady.Data
+		combineData(trans.mshrEntry, wb.cache.log2BlockSize)
+	}
 
 	wb.cache.mshr.Remove(trans.mshrEntry.PID, trans.mshrEntry.Address)
 
@@ -321,25 +375,39 @@ func (wb *writeBufferStage) processDataReadyRsp(
 	return true
 }
 
-func (wb *writeBufferStage) combineData(mshrEntry *cache.MSHREntry) {
-	mshrEntry.Block.DirtyMask = make([]bool, 1<<wb.cache.log2BlockSize)
-	for _, t := range mshrEntry.Requests {
-		trans := t.(*transaction)
-		if trans.read != nil {
-			continue
-		}
+// processBypassDataReadyRsp responds to the reads that wait for a line that
+// bypasses the cache. The data is not written to any block.
+func (wb *writeBufferStage) processBypassDataReadyRsp(
+	now sim.VTimeInSec,
+	trans *transaction,
+	dataReady *mem.DataReadyRsp,
+) bool {
+	trans.fetchedData = dataReady.Data
+	trans.mshrEntry.Data = dataReady.Data
 
-		mshrEntry.Block.IsDirty = true
-		write := trans.write
-		_, offset := getCacheLineID(write.Address, wb.cache.log2BlockSize)
-		for i := 0; i < len(write.Data); i++ {
-			if write.DirtyMask == nil || write.DirtyMask[i] {
-				index := offset + uint64(i)
-				mshrEntry.Data[index] = write.Data[i]
-				mshrEntry.Block.DirtyMask[index] = true
-			}
-		}
+	if !wb.completeBypass(trans) {
+		return false
 	}
+
+	wb.removeInflightFetch(trans)
+	wb.cache.bottomPort.Retrieve(now)
+
+	tracing.TraceReqFinalize(trans.fetchReadReq, wb.cache)
+
+	return true
+}
+
+// completeBypass removes the MSHR entry of an access that bypasses the cache
+// and lets the MSHR stage respond to the requests that wait for the entry.
+func (wb *writeBufferStage) completeBypass(trans *transaction) bool {
+	if !wb.cache.mshrStageBuffer.CanPush() {
+		return false
+	}
+
+	wb.cache.mshr.Remove(trans.mshrEntry.PID, trans.mshrEntry.Address)
+	wb.cache.mshrStageBuffer.Push(trans.mshrEntry)
+
+	return true
 }
 
 func (wb *writeBufferStage) findInflightFetchByFetchReadReqID(
@@ -375,6 +443,10 @@ func (wb *writeBufferStage) processWriteDoneRsp(
 	for i := len(wb.inflightEviction) - 1; i >= 0; i-- {
 		e := wb.inflightEviction[i]
 		if e.evictionWriteReq.ID == writeDone.RespondTo {
+			if e.bypass && !wb.completeBypass(e) {
+				return false
+			}
+
 			// log.Printf("%.10f, %s, wb write to bottom， %s, %04X, %04X, (%d, %d), %v\n",
 			// 	now, wb.cache.Name(),
 			// 	e.accessReq().Meta().ID,
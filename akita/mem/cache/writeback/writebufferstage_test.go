@@ -6,6 +6,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/sarchlab/akita/v3/mem/cache"
 	"github.com/sarchlab/akita/v3/mem/mem"
+	"github.com/sarchlab/akita/v3/mem/vm"
 	"github.com/sarchlab/akita/v3/sim"
 )
 
@@ -15,6 +16,7 @@ var _ = Describe("Write Buffer Stage", func() {
 		cacheModule       *Cache
 		writeBufferBuffer *MockBuffer
 		bankBuffer        *MockBuffer
+		mshrStageBuffer   *MockBuffer
 		directory         *MockDirectory
 		lowModuleFinder   *MockLowModuleFinder
 		bottomPort        *MockPort
@@ -29,6 +31,7 @@ var _ = Describe("Write Buffer Stage", func() {
 
 		writeBufferBuffer = NewMockBuffer(mockCtrl)
 		bankBuffer = NewMockBuffer(mockCtrl)
+		mshrStageBuffer = NewMockBuffer(mockCtrl)
 		directory = NewMockDirectory(mockCtrl)
 		directory.EXPECT().WayAssociativity().Return(4).AnyTimes()
 		mshr = NewMockMSHR(mockCtrl)
@@ -45,6 +48,7 @@ var _ = Describe("Write Buffer Stage", func() {
 		cacheModule.lowModuleFinder = lowModuleFinder
 		cacheModule.writeBufferBuffer = writeBufferBuffer
 		cacheModule.writeBufferToBankBuffers = []sim.Buffer{bankBuffer}
+		cacheModule.mshrStageBuffer = mshrStageBuffer
 
 		wbStage = &writeBufferStage{
 			cache:               cacheModule,
@@ -279,6 +283,26 @@ var _ = Describe("Write Buffer Stage", func() {
 			Expect(trans.fetchReadReq).To(BeIdenticalTo(fetchReq))
 			Expect(wbStage.inflightFetch).To(ContainElement(trans))
 		})
+
+		It("should only fetch the missing sectors", func() {
+			cacheModule.log2SectorSize = 4
+			trans.fetchSectors = []bool{false, true, true, false}
+			dramPort := NewMockPort(mockCtrl)
+
+			lowModuleFinder.EXPECT().Find(uint64(0x1010)).Return(dramPort)
+			bottomSender.EXPECT().CanSend(1).Return(true)
+			bottomSender.EXPECT().
+				Send(gomock.Any()).
+				Do(func(req *mem.ReadReq) {
+					Expect(req.Address).To(Equal(uint64(0x1010)))
+					Expect(req.AccessByteSize).To(Equal(uint64(32)))
+				})
+			writeBufferBuffer.EXPECT().Pop()
+
+			madeProgress := wbStage.processNewTransaction(10)
+
+			Expect(madeProgress).To(BeTrue())
+		})
 	})
 
 	Context("evict and write", func() {
@@ -591,6 +615,104 @@ var _ = Describe("Write Buffer Stage", func() {
 		})
 	})
 
+	Context("when received write-done rsp of a bypassing write", func() {
+		var (
+			mshrEntry *cache.MSHREntry
+			bypass    *transaction
+			writeDone *mem.WriteDoneRsp
+		)
+
+		BeforeEach(func() {
+			mshrEntry = cache.NewMSHREntry()
+			mshrEntry.PID = 1
+			mshrEntry.Address = 0x100
+			write := mem.WriteReqBuilder{}.Build()
+			bypass = &transaction{
+				bypass:           true,
+				mshrEntry:        mshrEntry,
+				evictionWriteReq: write,
+			}
+			writeDone = mem.WriteDoneRspBuilder{}.
+				WithRspTo(write.ID).
+				Build()
+
+			wbStage.inflightEviction = append(wbStage.inflightEviction, bypass)
+			bottomPort.EXPECT().Peek().Return(writeDone)
+		})
+
+		It("should stall if the mshr stage buffer is full", func() {
+			mshrStageBuffer.EXPECT().CanPush().Return(false)
+
+			madeProgress := wbStage.processReturnRsp(10)
+
+			Expect(madeProgress).To(BeFalse())
+			Expect(wbStage.inflightEviction).To(ContainElement(bypass))
+		})
+
+		It("should let the mshr stage respond", func() {
+			mshrStageBuffer.EXPECT().CanPush().Return(true)
+			mshrStageBuffer.EXPECT().Push(mshrEntry)
+			mshr.EXPECT().Remove(vm.PID(1), uint64(0x100))
+			bottomPort.EXPECT().Retrieve(sim.VTimeInSec(10))
+
+			madeProgress := wbStage.processReturnRsp(10)
+
+			Expect(madeProgress).To(BeTrue())
+			Expect(wbStage.inflightEviction).NotTo(ContainElement(bypass))
+		})
+	})
+
+	Context("when received data-ready rsp of a bypassing read", func() {
+		var (
+			mshrEntry *cache.MSHREntry
+			bypass    *transaction
+			dataReady *mem.DataReadyRsp
+		)
+
+		BeforeEach(func() {
+			mshrEntry = cache.NewMSHREntry()
+			mshrEntry.PID = 1
+			mshrEntry.Address = 0x100
+			read := mem.ReadReqBuilder{}.
+				WithAddress(0x100).
+				Build()
+			bypass = &transaction{
+				bypass:       true,
+				mshrEntry:    mshrEntry,
+				fetchReadReq: read,
+			}
+			dataReady = mem.DataReadyRspBuilder{}.
+				WithRspTo(read.ID).
+				WithData([]byte{1, 2, 3, 4}).
+				Build()
+
+			wbStage.inflightFetch = append(wbStage.inflightFetch, bypass)
+			bottomPort.EXPECT().Peek().Return(dataReady)
+		})
+
+		It("should stall if the mshr stage buffer is full", func() {
+			mshrStageBuffer.EXPECT().CanPush().Return(false)
+
+			madeProgress := wbStage.processReturnRsp(10)
+
+			Expect(madeProgress).To(BeFalse())
+			Expect(wbStage.inflightFetch).To(ContainElement(bypass))
+		})
+
+		It("should respond without writing to a bank", func() {
+			mshrStageBuffer.EXPECT().CanPush().Return(true)
+			mshrStageBuffer.EXPECT().Push(mshrEntry)
+			mshr.EXPECT().Remove(vm.PID(1), uint64(0x100))
+			bottomPort.EXPECT().Retrieve(sim.VTimeInSec(10))
+
+			madeProgress := wbStage.processReturnRsp(10)
+
+			Expect(madeProgress).To(BeTrue())
+			Expect(mshrEntry.Data).To(Equal([]byte{1, 2, 3, 4}))
+			Expect(wbStage.inflightFetch).NotTo(ContainElement(bypass))
+		})
+	})
+
 	Context("when received data-ready rsp", func() {
 		var (
 			read      *mem.ReadReq
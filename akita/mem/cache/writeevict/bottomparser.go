@@ -60,9 +60,15 @@ func (p *bottomParser) processDataReady(
 		return true
 	}
 	pid := trans.readToBottom.PID
-	bankBuf := p.getBankBuf(trans.block)
-	if !bankBuf.CanPush() {
-		return false
+
+	// A transaction that bypasses the cache has no block to write the
+	// fetched data to.
+	var bankBuf sim.Buffer
+	if trans.block != nil {
+		bankBuf = p.getBankBuf(trans.block)
+		if !bankBuf.CanPush() {
+			return false
+		}
 	}
 
 	addr := trans.Address()
@@ -74,10 +80,12 @@ func (p *bottomParser) processDataReady(
 	p.finalizeMSHRTrans(mshrEntry, data, now)
 	p.cache.mshr.Remove(pid, cachelineID)
 
-	trans.bankAction = bankActionWriteFetched
-	trans.data = data
-	trans.writeFetchedDirtyMask = dirtyMask
-	bankBuf.Push(trans)
+	if bankBuf != nil {
+		trans.bankAction = bankActionWriteFetched
+		trans.data = data
+		trans.writeFetchedDirtyMask = dirtyMask
+		bankBuf.Push(trans)
+	}
 	p.removeTransaction(trans)
 	p.cache.bottomPort.Retrieve(now)
 
@@ -99,7 +107,7 @@ func (p *bottomParser) mergeMSHRData(
 		}
 
 		write := trans.write
-		offset := write.Address - mshrEntry.Block.Tag
+		offset := write.Address - mshrEntry.Address
 		for i := 0; i < len(write.Data); i++ {
 			if write.DirtyMask[i] {
 				data[offset+uint64(i)] = write.Data[i]
@@ -119,7 +127,7 @@ func (p *bottomParser) finalizeMSHRTrans(
 		if trans.read != nil {
 			for _, preCTrans := range trans.preCoalesceTransactions {
 				read := preCTrans.read
-				offset := read.Address - mshrEntry.Block.Tag
+				offset := read.Address - mshrEntry.Address
 				preCTrans.data = data[offset : offset+read.AccessByteSize]
 				preCTrans.done = true
 			}
@@ -202,7 +202,8 @@ var _ = Describe("Bottom Parser", func() {
 			}
 
 			mshrEntry = &cache.MSHREntry{
-				Block: block,
+				Address: 0x100,
+				Block:   block,
 			}
 			mshrEntry.Requests = append(mshrEntry.Requests, postCTrans1)
 		})
@@ -237,6 +238,23 @@ var _ = Describe("Bottom Parser", func() {
 			Expect(c.postCoalesceTransactions).NotTo(ContainElement(postCTrans1))
 		})
 
+		It("should respond without a block if bypassing", func() {
+			postCTrans1.block = nil
+			mshrEntry.Block = nil
+			bottomPort.EXPECT().Peek().Return(dataReady)
+			bottomPort.EXPECT().Retrieve(gomock.Any())
+			mshr.EXPECT().Query(vm.PID(1), uint64(0x100)).Return(mshrEntry)
+			mshr.EXPECT().Remove(vm.PID(1), uint64(0x100))
+
+			madeProgress := p.Tick(12)
+
+			Expect(madeProgress).To(BeTrue())
+			Expect(preCTrans1.done).To(BeTrue())
+			Expect(preCTrans1.data).To(Equal([]byte{1, 2, 3, 4}))
+			Expect(preCTrans2.done).To(BeTrue())
+			Expect(c.postCoalesceTransactions).NotTo(ContainElement(postCTrans1))
+		})
+
 		It("should combine write", func() {
 			mshrEntry.Requests = append(mshrEntry.Requests, postCTrans2)
 			c.postCoalesceTransactions = append(c.postCoalesceTransactions, postCTrans2)
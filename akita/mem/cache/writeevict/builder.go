@@ -5,6 +5,7 @@ import (
 
 	"github.com/sarchlab/akita/v3/mem/cache"
 	"github.com/sarchlab/akita/v3/mem/mem"
+	"github.com/sarchlab/akita/v3/metrics"
 	"github.com/sarchlab/akita/v3/pipelining"
 	"github.com/sarchlab/akita/v3/sim"
 	"github.com/sarchlab/akita/v3/tracing"
@@ -25,6 +26,8 @@ type Builder struct {
 	maxNumConcurrentTrans int
 	lowModuleFinder       mem.LowModuleFinder
 	visTracer             tracing.Tracer
+	replacementPolicy     cache.ReplacementPolicyType
+	metrics               *metrics.Registry
 }
 
 // NewBuilder creates a builder with default parameter setting
@@ -40,6 +43,7 @@ func NewBuilder() *Builder {
 		numReqPerCycle:        4,
 		dirLatency:            2,
 		bankLatency:           20,
+		metrics:               metrics.DefaultRegistry(),
 	}
 }
 
@@ -128,6 +132,22 @@ func (b *Builder) WithLowModuleFinder(
 	return b
 }
 
+// WithReplacementPolicy sets the policy that the cache uses to select the
+// blocks to evict.
+func (b *Builder) WithReplacementPolicy(
+	policy cache.ReplacementPolicyType,
+) *Builder {
+	b.replacementPolicy = policy
+	return b
+}
+
+// WithMetricsRegistry sets the registry that the metrics of the cache are
+// registered in. By default, the default registry is used.
+func (b *Builder) WithMetricsRegistry(registry *metrics.Registry) *Builder {
+	b.metrics = registry
+	return b
+}
+
 // Build returns a new cache unit
 func (b *Builder) Build(name string) *Cache {
 	b.assertAllRequiredInformationIsAvailable()
@@ -138,6 +158,7 @@ func (b *Builder) Build(name string) *Cache {
 	}
 	c.TickingComponent = sim.NewTickingComponent(
 		name, b.engine, b.freq, c)
+	c.stats = cache.NewStats(b.metrics, name)
 
 	b.createPorts(c)
 
@@ -158,7 +179,7 @@ func (b *Builder) Build(name string) *Cache {
 	numSets := int(b.totalByteSize / uint64(b.wayAssociativity*blockSize))
 	c.directory = cache.NewDirectory(
 		numSets, b.wayAssociativity, 1<<b.log2BlockSize,
-		cache.NewLRUVictimFinder())
+		cache.NewReplacementPolicy(b.replacementPolicy))
 	c.storage = mem.NewStorage(b.totalByteSize)
 	c.bankLatency = b.bankLatency
 	c.wayAssociativity = b.wayAssociativity
@@ -4,6 +4,7 @@ import (
 	"github.com/sarchlab/akita/v3/mem/cache"
 	"github.com/sarchlab/akita/v3/mem/mem"
 	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/akita/v3/tracing"
 )
 
 // A Cache is a customized L1 cache the for R9nano GPUs.
@@ -37,6 +38,8 @@ type Cache struct {
 	transactions             []*transaction
 	postCoalesceTransactions []*transaction
 
+	stats cache.Stats
+
 	isPaused bool
 }
 
@@ -46,6 +49,12 @@ func (c *Cache) SetLowModuleFinder(lmf mem.LowModuleFinder) {
 	c.lowModuleFinder = lmf
 }
 
+// addTaskStep adds a step to the task of a transaction and counts the step.
+func (c *Cache) addTaskStep(taskID, step string) {
+	tracing.AddTaskStep(taskID, c, step)
+	c.stats.CountStep(step)
+}
+
 // Tick update the state of the cache
 func (c *Cache) Tick(now sim.VTimeInSec) bool {
 	madeProgress := false
@@ -189,7 +189,7 @@ func (c *coalescer) coalesceRead() *transaction {
 		WithPID(c.toCoalesce[0].PID()).
 		Build()
 	return &transaction{
-		id:                      sim.GetIDGenerator().Generate(),
+		id:                      sim.IDGeneratorFor(c.cache).Generate(),
 		read:                    coalescedRead,
 		preCoalesceTransactions: c.toCoalesce,
 	}
@@ -216,7 +216,7 @@ func (c *coalescer) coalesceWrite() *transaction {
 		}
 	}
 	return &transaction{
-		id:                      sim.GetIDGenerator().Generate(),
+		id:                      sim.IDGeneratorFor(c.cache).Generate(),
 		write:                   write,
 		preCoalesceTransactions: c.toCoalesce,
 	}
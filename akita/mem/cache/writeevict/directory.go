@@ -90,9 +90,9 @@ func (d *directory) processMSHRHit(
 	mshrEntry.Requests = append(mshrEntry.Requests, trans)
 
 	if trans.read != nil {
-		tracing.AddTaskStep(trans.id, d.cache, "read-mshr-hit")
+		d.cache.addTaskStep(trans.id, "read-mshr-hit")
 	} else {
-		tracing.AddTaskStep(trans.id, d.cache, "write-mshr-hit")
+		d.cache.addTaskStep(trans.id, "write-mshr-hit")
 	}
 
 	d.buf.Pop()
@@ -121,7 +121,7 @@ func (d *directory) processReadHit(
 	bankBuf.Push(trans)
 
 	d.buf.Pop()
-	tracing.AddTaskStep(trans.id, d.cache, "read-hit")
+	d.cache.addTaskStep(trans.id, "read-hit")
 
 	return true
 }
@@ -135,21 +135,41 @@ func (d *directory) processReadMiss(
 	blockSize := uint64(1 << d.cache.log2BlockSize)
 	cacheLineID := addr / blockSize * blockSize
 
+	if d.cache.mshr.IsFull() {
+		return false
+	}
+
+	if d.cache.directory.ShouldBypass(read.PID, cacheLineID) {
+		return d.processReadBypass(now, trans)
+	}
+
 	victim := d.cache.directory.FindVictim(cacheLineID)
 	if victim.IsLocked || victim.ReadCount > 0 {
 		return false
 	}
 
-	if d.cache.mshr.IsFull() {
+	if !d.fetchFromBottom(now, trans, victim) {
 		return false
 	}
 
-	if !d.fetchFromBottom(now, trans, victim) {
+	d.buf.Pop()
+	d.cache.addTaskStep(trans.id, "read-miss")
+
+	return true
+}
+
+// processReadBypass fetches the line without allocating a block. The fetched
+// data is only sent to the requesters.
+func (d *directory) processReadBypass(
+	now sim.VTimeInSec,
+	trans *transaction,
+) bool {
+	if !d.fetchFromBottom(now, trans, nil) {
 		return false
 	}
 
 	d.buf.Pop()
-	tracing.AddTaskStep(trans.id, d.cache, "read-miss")
+	d.cache.addTaskStep(trans.id, "read-bypass")
 
 	return true
 }
@@ -186,7 +206,7 @@ func (d *directory) writeMiss(
 	trans *transaction,
 ) bool {
 	if ok := d.writeBottom(now, trans); ok {
-		tracing.AddTaskStep(trans.id, d.cache, "write-miss")
+		d.cache.addTaskStep(trans.id, "write-miss")
 		d.buf.Pop()
 		return true
 	}
@@ -243,12 +263,15 @@ func (d *directory) processWriteHit(
 
 	block.IsValid = false
 
-	tracing.AddTaskStep(trans.id, d.cache, "write-hit")
+	d.cache.addTaskStep(trans.id, "write-hit")
 	d.buf.Pop()
 
 	return true
 }
 
+// fetchFromBottom reads the line from the low module and allocates an MSHR
+// entry for it. The line is placed in the victim block, or only sent to the
+// requesters if the victim is nil.
 func (d *directory) fetchFromBottom(
 	now sim.VTimeInSec,
 	trans *transaction,
@@ -282,11 +305,13 @@ func (d *directory) fetchFromBottom(
 	mshrEntry.ReadReq = readToBottom
 	mshrEntry.Block = victim
 
-	victim.Tag = cacheLineID
-	victim.PID = pid
-	victim.IsValid = true
-	victim.IsLocked = true
-	d.cache.directory.Visit(victim)
+	if victim != nil {
+		victim.Tag = cacheLineID
+		victim.PID = pid
+		victim.IsValid = true
+		victim.IsLocked = true
+		d.cache.directory.Insert(victim)
+	}
 
 	return true
 }
@@ -199,8 +199,10 @@ var _ = Describe("Directory", func() {
 		It("should send request to bottom", func() {
 			var readToBottom *mem.ReadReq
 			dir.EXPECT().Lookup(vm.PID(1), uint64(0x100)).Return(nil)
+			dir.EXPECT().ShouldBypass(vm.PID(1), uint64(0x100)).
+				Return(false)
 			dir.EXPECT().FindVictim(uint64(0x100)).Return(block)
-			dir.EXPECT().Visit(block)
+			dir.EXPECT().Insert(block)
 			lowModuleFinder.EXPECT().Find(uint64(0x100)).Return(nil)
 			bottomPort.EXPECT().Send(gomock.Any()).Do(func(read *mem.ReadReq) {
 				readToBottom = read
@@ -225,9 +227,30 @@ var _ = Describe("Directory", func() {
 			Expect(trans.block).To(BeIdenticalTo(block))
 		})
 
+		It("should fetch without allocating if the policy bypasses", func() {
+			dir.EXPECT().Lookup(vm.PID(1), uint64(0x100)).Return(nil)
+			mshr.EXPECT().IsFull().Return(false)
+			dir.EXPECT().ShouldBypass(vm.PID(1), uint64(0x100)).Return(true)
+			lowModuleFinder.EXPECT().Find(uint64(0x100)).Return(nil)
+			bottomPort.EXPECT().Send(gomock.Any())
+			mshr.EXPECT().Add(vm.PID(1), uint64(0x100)).Return(mshrEntry)
+			buf.EXPECT().Pop()
+
+			madeProgress := d.Tick(10)
+
+			Expect(madeProgress).To(BeTrue())
+			Expect(mshrEntry.Requests).To(ContainElement(trans))
+			Expect(mshrEntry.Block).To(BeNil())
+			Expect(trans.block).To(BeNil())
+			Expect(trans.readToBottom).NotTo(BeNil())
+		})
+
 		It("should stall is victim block is locked", func() {
 			block.IsLocked = true
 			dir.EXPECT().Lookup(vm.PID(1), uint64(0x100)).Return(nil)
+			mshr.EXPECT().IsFull().Return(false)
+			dir.EXPECT().ShouldBypass(vm.PID(1), uint64(0x100)).
+				Return(false)
 			dir.EXPECT().FindVictim(uint64(0x100)).Return(block)
 
 			madeProgress := d.Tick(10)
@@ -238,6 +261,9 @@ var _ = Describe("Directory", func() {
 		It("should stall is victim block is being read", func() {
 			block.ReadCount = 1
 			dir.EXPECT().Lookup(vm.PID(1), uint64(0x100)).Return(nil)
+			mshr.EXPECT().IsFull().Return(false)
+			dir.EXPECT().ShouldBypass(vm.PID(1), uint64(0x100)).
+				Return(false)
 			dir.EXPECT().FindVictim(uint64(0x100)).Return(block)
 
 			madeProgress := d.Tick(10)
@@ -247,7 +273,6 @@ var _ = Describe("Directory", func() {
 
 		It("should stall is mshr is full", func() {
 			dir.EXPECT().Lookup(vm.PID(1), uint64(0x100)).Return(nil)
-			dir.EXPECT().FindVictim(uint64(0x100)).Return(block)
 			mshr.EXPECT().IsFull().Return(true)
 
 			madeProgress := d.Tick(10)
@@ -257,6 +282,8 @@ var _ = Describe("Directory", func() {
 
 		It("should stall if send to bottom failed", func() {
 			dir.EXPECT().Lookup(vm.PID(1), uint64(0x100)).Return(nil)
+			dir.EXPECT().ShouldBypass(vm.PID(1), uint64(0x100)).
+				Return(false)
 			dir.EXPECT().FindVictim(uint64(0x100)).Return(block)
 			lowModuleFinder.EXPECT().Find(uint64(0x100)).Return(nil)
 			mshr.EXPECT().IsFull().Return(false)
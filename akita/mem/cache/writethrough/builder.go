@@ -5,6 +5,7 @@ import (
 
 	"github.com/sarchlab/akita/v3/mem/cache"
 	"github.com/sarchlab/akita/v3/mem/mem"
+	"github.com/sarchlab/akita/v3/metrics"
 	"github.com/sarchlab/akita/v3/pipelining"
 	"github.com/sarchlab/akita/v3/sim"
 	"github.com/sarchlab/akita/v3/tracing"
@@ -25,6 +26,8 @@ type Builder struct {
 	numReqPerCycle        int
 	lowModuleFinder       mem.LowModuleFinder
 	visTracer             tracing.Tracer
+	replacementPolicy     cache.ReplacementPolicyType
+	metrics               *metrics.Registry
 }
 
 // NewBuilder creates a builder with default parameter setting
@@ -39,6 +42,7 @@ func NewBuilder() *Builder {
 		numReqPerCycle:        4,
 		maxNumConcurrentTrans: 16,
 		bankLatency:           20,
+		metrics:               metrics.DefaultRegistry(),
 	}
 }
 
@@ -127,6 +131,22 @@ func (b *Builder) WithLowModuleFinder(
 	return b
 }
 
+// WithReplacementPolicy sets the policy that the cache uses to select the
+// blocks to evict.
+func (b *Builder) WithReplacementPolicy(
+	policy cache.ReplacementPolicyType,
+) *Builder {
+	b.replacementPolicy = policy
+	return b
+}
+
+// WithMetricsRegistry sets the registry that the metrics of the cache are
+// registered in. By default, the default registry is used.
+func (b *Builder) WithMetricsRegistry(registry *metrics.Registry) *Builder {
+	b.metrics = registry
+	return b
+}
+
 // Build returns a new cache unit
 func (b *Builder) Build(name string) *Cache {
 	b.assertAllRequiredInformationIsAvailable()
@@ -137,6 +157,7 @@ func (b *Builder) Build(name string) *Cache {
 	}
 	c.TickingComponent = sim.NewTickingComponent(
 		name, b.engine, b.freq, c)
+	c.stats = cache.NewStats(b.metrics, name)
 
 	c.topPort = sim.NewLimitNumMsgPort(c, b.numReqPerCycle, name+".TopPort")
 	c.AddPort("Top", c.topPort)
@@ -163,7 +184,7 @@ func (b *Builder) Build(name string) *Cache {
 	numSets := int(b.totalByteSize / uint64(b.wayAssociativity*blockSize))
 	c.directory = cache.NewDirectory(
 		numSets, b.wayAssociativity, 1<<b.log2BlockSize,
-		cache.NewLRUVictimFinder())
+		cache.NewReplacementPolicy(b.replacementPolicy))
 	c.storage = mem.NewStorage(b.totalByteSize)
 	c.bankLatency = b.bankLatency
 	c.wayAssociativity = b.wayAssociativity
@@ -185,6 +206,7 @@ func (b *Builder) buildStages(c *Cache) {
 	b.buildBankStages(c)
 	c.parseBottomStage = &bottomParser{cache: c}
 	c.respondStage = &respondStage{cache: c}
+	c.fenceStage = &fenceStage{cache: c}
 
 	c.controlStage = &controlStage{
 		ctrlPort:     c.controlPort,
@@ -19,6 +19,10 @@ func (c *coalescer) Reset() {
 }
 
 func (c *coalescer) Tick(now sim.VTimeInSec) bool {
+	if c.cache.fenceStage.isProcessing() {
+		return false
+	}
+
 	req := c.cache.topPort.Peek()
 	if req == nil {
 		return false
@@ -38,6 +42,8 @@ func (c *coalescer) processReq(
 	switch item := req.(type) {
 	case *mem.GL0InvalidateReq:
 		return c.processGL0InvalidateReq(item, now)
+	case *mem.FenceReq:
+		return c.processFenceReq(now, item)
 	}
 
 	if c.isReqLastInWave(req) {
@@ -74,6 +80,24 @@ func (c *coalescer) processGL0InvalidateReq(
 	return false
 }
 
+func (c *coalescer) processFenceReq(
+	now sim.VTimeInSec,
+	req *mem.FenceReq,
+) bool {
+	if len(c.toCoalesce) > 0 {
+		if !c.cache.dirBuf.CanPush() {
+			return false
+		}
+
+		c.coalesceAndSend(now)
+	}
+
+	c.cache.fenceStage.accept(req)
+	c.cache.topPort.Retrieve(now)
+
+	return true
+}
+
 func (c *coalescer) processReqCoalescable(
 	now sim.VTimeInSec,
 	req mem.AccessReq,
@@ -213,9 +237,10 @@ func (c *coalescer) coalesceRead() *transaction {
 		WithAddress(cachelineID).
 		WithByteSize(blockSize).
 		WithPID(c.toCoalesce[0].PID()).
+		WithScope(c.coalescedScope()).
 		Build()
 	return &transaction{
-		id:                      sim.GetIDGenerator().Generate(),
+		id:                      sim.IDGeneratorFor(c.cache).Generate(),
 		read:                    coalescedRead,
 		preCoalesceTransactions: c.toCoalesce,
 	}
@@ -227,6 +252,7 @@ func (c *coalescer) coalesceWrite() *transaction {
 	write := mem.WriteReqBuilder{}.
 		WithAddress(cachelineID).
 		WithPID(c.toCoalesce[0].PID()).
+		WithScope(c.coalescedScope()).
 		WithData(make([]byte, blockSize)).
 		WithDirtyMask(make([]bool, blockSize)).
 		Build()
@@ -242,8 +268,20 @@ func (c *coalescer) coalesceWrite() *transaction {
 		}
 	}
 	return &transaction{
-		id:                      sim.GetIDGenerator().Generate(),
+		id:                      sim.IDGeneratorFor(c.cache).Generate(),
 		write:                   write,
 		preCoalesceTransactions: c.toCoalesce,
 	}
 }
+
+// coalescedScope returns the widest scope of the requests to coalesce.
+func (c *coalescer) coalescedScope() mem.Scope {
+	scope := mem.ScopeWorkgroup
+	for _, t := range c.toCoalesce {
+		if t.Scope() > scope {
+			scope = t.Scope()
+		}
+	}
+
+	return scope
+}
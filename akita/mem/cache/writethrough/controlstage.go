@@ -68,6 +68,7 @@ func (s *controlStage) hardResetCache(now sim.VTimeInSec) {
 	for _, bankStage := range s.cache.bankStages {
 		bankStage.Reset()
 	}
+	s.cache.fenceStage.Reset()
 
 	s.cache.transactions = nil
 	s.cache.postCoalesceTransactions = nil
@@ -75,6 +75,10 @@ func (d *directory) processRead(now sim.VTimeInSec, trans *transaction) bool {
 
 	block := d.cache.directory.Lookup(pid, cacheLineID)
 	if block != nil && block.IsValid {
+		if read.Scope > mem.ScopeWorkgroup {
+			return d.processCoherentReadHit(now, trans, block)
+		}
+
 		return d.processReadHit(now, trans, block)
 	}
 
@@ -91,9 +95,9 @@ func (d *directory) processMSHRHit(
 	d.buf.Pop()
 
 	if trans.read != nil {
-		tracing.AddTaskStep(trans.id, d.cache, "read-mshr-hit")
+		d.cache.addTaskStep(trans.id, "read-mshr-hit")
 	} else {
-		tracing.AddTaskStep(trans.id, d.cache, "write-mshr-hit")
+		d.cache.addTaskStep(trans.id, "write-mshr-hit")
 	}
 
 	return true
@@ -120,7 +124,33 @@ func (d *directory) processReadHit(
 	bankBuf.Push(trans)
 
 	d.buf.Pop()
-	tracing.AddTaskStep(trans.id, d.cache, "read-hit")
+	d.cache.addTaskStep(trans.id, "read-hit")
+
+	return true
+}
+
+// processCoherentReadHit handles the reads that must be coherent beyond the
+// workgroup. The cached copy may be stale, so the block is fetched again from
+// the low module.
+func (d *directory) processCoherentReadHit(
+	now sim.VTimeInSec,
+	trans *transaction,
+	block *cache.Block,
+) bool {
+	if block.IsLocked || block.ReadCount > 0 {
+		return false
+	}
+
+	if d.cache.mshr.IsFull() {
+		return false
+	}
+
+	if !d.fetchFromBottom(now, trans, block) {
+		return false
+	}
+
+	d.buf.Pop()
+	d.cache.addTaskStep(trans.id, "read-miss")
 
 	return true
 }
@@ -134,21 +164,41 @@ func (d *directory) processReadMiss(
 	blockSize := uint64(1 << d.cache.log2BlockSize)
 	cacheLineID := addr / blockSize * blockSize
 
+	if d.cache.mshr.IsFull() {
+		return false
+	}
+
+	if d.cache.directory.ShouldBypass(read.PID, cacheLineID) {
+		return d.processReadBypass(now, trans)
+	}
+
 	victim := d.cache.directory.FindVictim(cacheLineID)
 	if victim.IsLocked || victim.ReadCount > 0 {
 		return false
 	}
 
-	if d.cache.mshr.IsFull() {
+	if !d.fetchFromBottom(now, trans, victim) {
 		return false
 	}
 
-	if !d.fetchFromBottom(now, trans, victim) {
+	d.buf.Pop()
+	d.cache.addTaskStep(trans.id, "read-miss")
+
+	return true
+}
+
+// processReadBypass fetches the line without allocating a block. The fetched
+// data is only sent to the requesters.
+func (d *directory) processReadBypass(
+	now sim.VTimeInSec,
+	trans *transaction,
+) bool {
+	if !d.fetchFromBottom(now, trans, nil) {
 		return false
 	}
 
 	d.buf.Pop()
-	tracing.AddTaskStep(trans.id, d.cache, "read-miss")
+	d.cache.addTaskStep(trans.id, "read-bypass")
 
 	return true
 }
@@ -176,24 +226,47 @@ func (d *directory) processWrite(
 	if block != nil && block.IsValid {
 		ok := d.processWriteHit(now, trans, block)
 		if ok {
-			tracing.AddTaskStep(trans.id, d.cache, "write-hit")
+			d.cache.addTaskStep(trans.id, "write-hit")
 		}
 
 		return ok
 	}
 
+	if d.cache.directory.ShouldBypass(pid, cacheLineID) {
+		return d.processWriteBypass(now, trans)
+	}
+
 	if d.isPartialWrite(write) {
 		return d.partialWriteMiss(now, trans)
 	}
 
 	ok := d.fullLineWriteMiss(now, trans)
 	if ok {
-		tracing.AddTaskStep(trans.id, d.cache, "write-miss")
+		d.cache.addTaskStep(trans.id, "write-miss")
 	}
 
 	return ok
 }
 
+// processWriteBypass writes to the low module without allocating a block.
+func (d *directory) processWriteBypass(
+	now sim.VTimeInSec,
+	trans *transaction,
+) bool {
+	if trans.writeToBottom == nil {
+		ok := d.writeBottom(now, trans)
+		if !ok {
+			return false
+		}
+	}
+
+	trans.fetchAndWrite = false
+	d.buf.Pop()
+	d.cache.addTaskStep(trans.id, "write-bypass")
+
+	return true
+}
+
 func (d *directory) isPartialWrite(write *mem.WriteReq) bool {
 	if len(write.Data) < (1 << d.cache.log2BlockSize) {
 		return true
@@ -244,7 +317,7 @@ func (d *directory) partialWriteMiss(
 	}
 
 	d.buf.Pop()
-	tracing.AddTaskStep(trans.id, d.cache, "write-miss")
+	d.cache.addTaskStep(trans.id, "write-miss")
 
 	return true
 }
@@ -257,8 +330,9 @@ func (d *directory) fullLineWriteMiss(
 	addr := write.Address
 	blockSize := uint64(1 << d.cache.log2BlockSize)
 	cacheLineID := addr / blockSize * blockSize
-	block := d.cache.directory.FindVictim(cacheLineID)
-	return d.processWriteHit(now, trans, block)
+	victim := d.cache.directory.FindVictim(cacheLineID)
+
+	return d.writeToBlock(now, trans, victim, true)
 }
 
 func (d *directory) writeBottom(now sim.VTimeInSec, trans *transaction) bool {
@@ -271,6 +345,7 @@ func (d *directory) writeBottom(now sim.VTimeInSec, trans *transaction) bool {
 		WithDst(d.cache.lowModuleFinder.Find(addr)).
 		WithAddress(addr).
 		WithPID(write.PID).
+		WithScope(write.Scope).
 		WithData(write.Data).
 		WithDirtyMask(write.DirtyMask).
 		Build()
@@ -291,6 +366,18 @@ func (d *directory) processWriteHit(
 	now sim.VTimeInSec,
 	trans *transaction,
 	block *cache.Block,
+) bool {
+	return d.writeToBlock(now, trans, block, false)
+}
+
+// writeToBlock writes the data to the block and to the low module. If insert
+// is true, the block is allocated to the line, replacing the line that the
+// block held.
+func (d *directory) writeToBlock(
+	now sim.VTimeInSec,
+	trans *transaction,
+	block *cache.Block,
+	insert bool,
 ) bool {
 	if block.IsLocked || block.ReadCount > 0 {
 		return false
@@ -315,7 +402,13 @@ func (d *directory) processWriteHit(
 	block.IsLocked = true
 	block.IsValid = true
 	block.Tag = cacheLineID
-	d.cache.directory.Visit(block)
+
+	if insert {
+		block.PID = write.PID
+		d.cache.directory.Insert(block)
+	} else {
+		d.cache.directory.Visit(block)
+	}
 
 	trans.bankAction = bankActionWrite
 	trans.block = block
@@ -326,6 +419,9 @@ func (d *directory) processWriteHit(
 	return true
 }
 
+// fetchFromBottom reads the line from the low module and allocates an MSHR
+// entry for it. The line is placed in the victim block, or only sent to the
+// requesters if the victim is nil.
 func (d *directory) fetchFromBottom(
 	now sim.VTimeInSec,
 	trans *transaction,
@@ -343,6 +439,7 @@ func (d *directory) fetchFromBottom(
 		WithDst(bottomModule).
 		WithAddress(cacheLineID).
 		WithPID(pid).
+		WithScope(trans.Scope()).
 		WithByteSize(blockSize).
 		Build()
 	err := d.cache.bottomPort.Send(readToBottom)
@@ -359,11 +456,13 @@ func (d *directory) fetchFromBottom(
 	mshrEntry.ReadReq = readToBottom
 	mshrEntry.Block = victim
 
-	victim.Tag = cacheLineID
-	victim.PID = pid
-	victim.IsValid = true
-	victim.IsLocked = true
-	d.cache.directory.Visit(victim)
+	if victim != nil {
+		victim.Tag = cacheLineID
+		victim.PID = pid
+		victim.IsValid = true
+		victim.IsLocked = true
+		d.cache.directory.Insert(victim)
+	}
 
 	return true
 }
@@ -166,6 +166,27 @@ var _ = Describe("Directory", func() {
 			madeProgress := d.Tick(10)
 			Expect(madeProgress).To(BeFalse())
 		})
+
+		It("should refetch if the read is coherent beyond workgroup", func() {
+			read.Scope = mem.ScopeAgent
+			mshrEntry := &cache.MSHREntry{}
+			dir.EXPECT().Lookup(vm.PID(1), uint64(0x100)).Return(block)
+			dir.EXPECT().Insert(block)
+			lowModuleFinder.EXPECT().Find(uint64(0x100)).Return(nil)
+			bottomPort.EXPECT().Send(gomock.Any()).Do(func(read *mem.ReadReq) {
+				Expect(read.Address).To(Equal(uint64(0x100)))
+				Expect(read.Scope).To(Equal(mem.ScopeAgent))
+			})
+			mshr.EXPECT().IsFull().Return(false)
+			mshr.EXPECT().Add(vm.PID(1), uint64(0x100)).Return(mshrEntry)
+			buf.EXPECT().Pop()
+
+			madeProgress := d.Tick(10)
+
+			Expect(madeProgress).To(BeTrue())
+			Expect(mshrEntry.Block).To(BeIdenticalTo(block))
+			Expect(block.IsLocked).To(BeTrue())
+		})
 	})
 
 	Context("read miss", func() {
@@ -200,8 +221,10 @@ var _ = Describe("Directory", func() {
 		It("should send request to bottom", func() {
 			var readToBottom *mem.ReadReq
 			dir.EXPECT().Lookup(vm.PID(1), uint64(0x100)).Return(nil)
+			dir.EXPECT().ShouldBypass(vm.PID(1), uint64(0x100)).
+				Return(false)
 			dir.EXPECT().FindVictim(uint64(0x100)).Return(block)
-			dir.EXPECT().Visit(block)
+			dir.EXPECT().Insert(block)
 			lowModuleFinder.EXPECT().Find(uint64(0x100)).Return(nil)
 			bottomPort.EXPECT().Send(gomock.Any()).Do(func(read *mem.ReadReq) {
 				readToBottom = read
@@ -226,9 +249,30 @@ var _ = Describe("Directory", func() {
 			Expect(trans.block).To(BeIdenticalTo(block))
 		})
 
+		It("should fetch without allocating if the policy bypasses", func() {
+			dir.EXPECT().Lookup(vm.PID(1), uint64(0x100)).Return(nil)
+			mshr.EXPECT().IsFull().Return(false)
+			dir.EXPECT().ShouldBypass(vm.PID(1), uint64(0x100)).Return(true)
+			lowModuleFinder.EXPECT().Find(uint64(0x100)).Return(nil)
+			bottomPort.EXPECT().Send(gomock.Any())
+			mshr.EXPECT().Add(vm.PID(1), uint64(0x100)).Return(mshrEntry)
+			buf.EXPECT().Pop()
+
+			madeProgress := d.Tick(10)
+
+			Expect(madeProgress).To(BeTrue())
+			Expect(mshrEntry.Requests).To(ContainElement(trans))
+			Expect(mshrEntry.Block).To(BeNil())
+			Expect(trans.block).To(BeNil())
+			Expect(trans.readToBottom).NotTo(BeNil())
+		})
+
 		It("should stall is victim block is locked", func() {
 			block.IsLocked = true
 			dir.EXPECT().Lookup(vm.PID(1), uint64(0x100)).Return(nil)
+			mshr.EXPECT().IsFull().Return(false)
+			dir.EXPECT().ShouldBypass(vm.PID(1), uint64(0x100)).
+				Return(false)
 			dir.EXPECT().FindVictim(uint64(0x100)).Return(block)
 
 			madeProgress := d.Tick(10)
@@ -239,6 +283,9 @@ var _ = Describe("Directory", func() {
 		It("should stall is victim block is being read", func() {
 			block.ReadCount = 1
 			dir.EXPECT().Lookup(vm.PID(1), uint64(0x100)).Return(nil)
+			mshr.EXPECT().IsFull().Return(false)
+			dir.EXPECT().ShouldBypass(vm.PID(1), uint64(0x100)).
+				Return(false)
 			dir.EXPECT().FindVictim(uint64(0x100)).Return(block)
 
 			madeProgress := d.Tick(10)
@@ -248,7 +295,6 @@ var _ = Describe("Directory", func() {
 
 		It("should stall is mshr is full", func() {
 			dir.EXPECT().Lookup(vm.PID(1), uint64(0x100)).Return(nil)
-			dir.EXPECT().FindVictim(uint64(0x100)).Return(block)
 			mshr.EXPECT().IsFull().Return(true)
 
 			madeProgress := d.Tick(10)
@@ -258,6 +304,8 @@ var _ = Describe("Directory", func() {
 
 		It("should stall if send to bottom failed", func() {
 			dir.EXPECT().Lookup(vm.PID(1), uint64(0x100)).Return(nil)
+			dir.EXPECT().ShouldBypass(vm.PID(1), uint64(0x100)).
+				Return(false)
 			dir.EXPECT().FindVictim(uint64(0x100)).Return(block)
 			lowModuleFinder.EXPECT().Find(uint64(0x100)).Return(nil)
 			mshr.EXPECT().IsFull().Return(false)
@@ -450,6 +498,8 @@ var _ = Describe("Directory", func() {
 			mshr.EXPECT().Query(vm.PID(1), uint64(0x100)).Return(nil)
 			mshr.EXPECT().IsFull().Return(true)
 			dir.EXPECT().Lookup(vm.PID(1), uint64(0x100)).Return(nil)
+			dir.EXPECT().ShouldBypass(vm.PID(1), uint64(0x100)).
+				Return(false)
 
 			madeProgress := d.Tick(10)
 
@@ -474,8 +524,10 @@ var _ = Describe("Directory", func() {
 			mshr.EXPECT().IsFull().Return(false)
 			mshr.EXPECT().Add(vm.PID(1), uint64(0x100)).Return(mshrEntry)
 			dir.EXPECT().Lookup(vm.PID(1), uint64(0x100)).Return(nil)
+			dir.EXPECT().ShouldBypass(vm.PID(1), uint64(0x100)).
+				Return(false)
 			dir.EXPECT().FindVictim(uint64(0x100)).Return(block)
-			dir.EXPECT().Visit(block)
+			dir.EXPECT().Insert(block)
 			lowModuleFinder.EXPECT().Find(uint64(0x100))
 			bottomPort.EXPECT().Send(gomock.Any()).
 				Do(func(read *mem.ReadReq) {
@@ -498,6 +550,29 @@ var _ = Describe("Directory", func() {
 			Expect(block.IsValid).To(BeTrue())
 		})
 
+		It("should only write to bottom if the policy bypasses", func() {
+			pipeline.EXPECT().CanAccept().Return(false)
+			buf.EXPECT().Peek().Return(dirPipelineItem{trans: trans})
+			buf.EXPECT().Peek().Return(nil)
+			buf.EXPECT().Pop()
+			mshr.EXPECT().Query(vm.PID(1), uint64(0x100)).Return(nil)
+			dir.EXPECT().Lookup(vm.PID(1), uint64(0x100)).Return(nil)
+			dir.EXPECT().ShouldBypass(vm.PID(1), uint64(0x100)).Return(true)
+			lowModuleFinder.EXPECT().Find(uint64(0x104))
+			bottomPort.EXPECT().Send(gomock.Any()).
+				Do(func(write *mem.WriteReq) {
+					Expect(write.Address).To(Equal(uint64(0x104)))
+				})
+
+			madeProgress := d.Tick(10)
+
+			Expect(madeProgress).To(BeTrue())
+			Expect(trans.writeToBottom).NotTo(BeNil())
+			Expect(trans.readToBottom).To(BeNil())
+			Expect(trans.fetchAndWrite).To(BeFalse())
+			Expect(trans.block).To(BeNil())
+		})
+
 		It("should write partial block", func() {
 			pipeline.EXPECT().CanAccept().Return(false)
 			buf.EXPECT().Peek().Return(dirPipelineItem{trans: trans})
@@ -507,8 +582,10 @@ var _ = Describe("Directory", func() {
 			mshr.EXPECT().IsFull().Return(false)
 			mshr.EXPECT().Add(vm.PID(1), uint64(0x100)).Return(mshrEntry)
 			dir.EXPECT().Lookup(vm.PID(1), uint64(0x100)).Return(nil)
+			dir.EXPECT().ShouldBypass(vm.PID(1), uint64(0x100)).
+				Return(false)
 			dir.EXPECT().FindVictim(uint64(0x100)).Return(block)
-			dir.EXPECT().Visit(block)
+			dir.EXPECT().Insert(block)
 			lowModuleFinder.EXPECT().Find(uint64(0x104))
 			lowModuleFinder.EXPECT().Find(uint64(0x100))
 			bottomPort.EXPECT().Send(gomock.Any()).
@@ -572,8 +649,10 @@ var _ = Describe("Directory", func() {
 			buf.EXPECT().Pop()
 			mshr.EXPECT().Query(vm.PID(1), uint64(0x100)).Return(nil)
 			dir.EXPECT().Lookup(vm.PID(1), uint64(0x100)).Return(nil)
+			dir.EXPECT().ShouldBypass(vm.PID(1), uint64(0x100)).
+				Return(false)
 			dir.EXPECT().FindVictim(uint64(0x100)).Return(block)
-			dir.EXPECT().Visit(block)
+			dir.EXPECT().Insert(block)
 			bankBuf.EXPECT().CanPush().Return(true)
 			bankBuf.EXPECT().Push(gomock.Any()).
 				Do(func(trans *transaction) {
@@ -593,9 +672,29 @@ var _ = Describe("Directory", func() {
 			Expect(madeProgress).To(BeTrue())
 			Expect(block.IsLocked).To(BeTrue())
 			Expect(block.Tag).To(Equal(uint64(0x100)))
+			Expect(block.PID).To(Equal(vm.PID(1)))
 			Expect(block.IsValid).To(BeTrue())
 			Expect(trans.writeToBottom).NotTo(BeNil())
 		})
+
+		It("should only write to bottom if the policy bypasses", func() {
+			pipeline.EXPECT().CanAccept().Return(false)
+			buf.EXPECT().Peek().Return(dirPipelineItem{trans: trans})
+			buf.EXPECT().Peek().Return(nil)
+			buf.EXPECT().Pop()
+			mshr.EXPECT().Query(vm.PID(1), uint64(0x100)).Return(nil)
+			dir.EXPECT().Lookup(vm.PID(1), uint64(0x100)).Return(nil)
+			dir.EXPECT().ShouldBypass(vm.PID(1), uint64(0x100)).Return(true)
+			lowModuleFinder.EXPECT().Find(uint64(0x100))
+			bottomPort.EXPECT().Send(gomock.Any())
+
+			madeProgress := d.Tick(10)
+
+			Expect(madeProgress).To(BeTrue())
+			Expect(block.IsLocked).To(BeFalse())
+			Expect(trans.writeToBottom).NotTo(BeNil())
+			Expect(trans.block).To(BeNil())
+		})
 	})
 
 })
@@ -0,0 +1,148 @@
+package writethrough
+
+import (
+	gomock "github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/akita/v3/mem/mem"
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+var _ = Describe("Fence Stage", func() {
+	var (
+		mockCtrl        *gomock.Controller
+		topPort         *MockPort
+		bottomPort      *MockPort
+		lowModulePort   *MockPort
+		agentPort       *MockPort
+		dir             *MockDirectory
+		lowModuleFinder *MockLowModuleFinder
+		c               *Cache
+		s               *fenceStage
+		fence           *mem.FenceReq
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		topPort = NewMockPort(mockCtrl)
+		bottomPort = NewMockPort(mockCtrl)
+		lowModulePort = NewMockPort(mockCtrl)
+		agentPort = NewMockPort(mockCtrl)
+		dir = NewMockDirectory(mockCtrl)
+		lowModuleFinder = NewMockLowModuleFinder(mockCtrl)
+		c = &Cache{
+			topPort:         topPort,
+			bottomPort:      bottomPort,
+			directory:       dir,
+			lowModuleFinder: lowModuleFinder,
+		}
+		c.TickingComponent = sim.NewTickingComponent(
+			"Cache", nil, 1, c)
+		s = &fenceStage{cache: c}
+		c.fenceStage = s
+
+		fence = mem.FenceReqBuilder{}.
+			WithSrc(agentPort).
+			WithDst(topPort).
+			WithPID(1).
+			WithScope(mem.ScopeAgent).
+			WithAcquire().
+			WithRelease().
+			Build()
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	It("should do nothing if there is no fence", func() {
+		madeProgress := s.Tick(10)
+
+		Expect(madeProgress).To(BeFalse())
+	})
+
+	It("should wait for the earlier transactions", func() {
+		s.accept(fence)
+		c.transactions = append(c.transactions, &transaction{})
+
+		madeProgress := s.Tick(10)
+
+		Expect(madeProgress).To(BeFalse())
+		Expect(s.drained).To(BeFalse())
+	})
+
+	It("should invalidate the cache on acquire", func() {
+		s.accept(fence)
+		dir.EXPECT().Reset()
+
+		madeProgress := s.Tick(10)
+
+		Expect(madeProgress).To(BeTrue())
+		Expect(s.drained).To(BeTrue())
+		Expect(s.toForward).To(BeEmpty())
+	})
+
+	It("should not invalidate the cache on workgroup fences", func() {
+		fence.Scope = mem.ScopeWorkgroup
+		s.accept(fence)
+
+		madeProgress := s.Tick(10)
+
+		Expect(madeProgress).To(BeTrue())
+		Expect(s.drained).To(BeTrue())
+	})
+
+	It("should respond when the fence takes effect", func() {
+		s.accept(fence)
+		s.drained = true
+		topPort.EXPECT().Send(gomock.Any()).Do(func(rsp *mem.FenceRsp) {
+			Expect(rsp.RespondTo).To(Equal(fence.ID))
+			Expect(rsp.Dst).To(BeIdenticalTo(agentPort))
+		})
+
+		madeProgress := s.Tick(10)
+
+		Expect(madeProgress).To(BeTrue())
+		Expect(s.isProcessing()).To(BeFalse())
+	})
+
+	Context("system scope", func() {
+		BeforeEach(func() {
+			fence.Scope = mem.ScopeSystem
+			s.accept(fence)
+		})
+
+		It("should forward the fence to the low modules", func() {
+			lowModuleFinder.EXPECT().Find(uint64(0)).Return(lowModulePort)
+			dir.EXPECT().Reset()
+			s.Tick(10)
+
+			bottomPort.EXPECT().Send(gomock.Any()).Do(func(f *mem.FenceReq) {
+				Expect(f.Dst).To(BeIdenticalTo(lowModulePort))
+				Expect(f.Scope).To(Equal(mem.ScopeSystem))
+				Expect(f.Acquire).To(BeTrue())
+				Expect(f.Release).To(BeTrue())
+			})
+
+			madeProgress := s.Tick(11)
+
+			Expect(madeProgress).To(BeTrue())
+			Expect(s.toForward).To(BeEmpty())
+			Expect(s.pendingFences).To(HaveLen(1))
+		})
+
+		It("should wait for the forwarded fences", func() {
+			forwarded := mem.FenceReqBuilder{}.Build()
+			s.drained = true
+			s.pendingFences = []*mem.FenceReq{forwarded}
+
+			madeProgress := s.Tick(10)
+			Expect(madeProgress).To(BeFalse())
+
+			s.finalizeForwardedFence(mem.FenceRspBuilder{}.
+				WithRspTo(forwarded.ID).
+				Build())
+			Expect(s.pendingFences).To(BeEmpty())
+		})
+	})
+})
@@ -52,6 +52,7 @@ func (s *respondStage) respondReadTrans(
 
 	s.removeTransaction(trans)
 
+	s.cache.stats.ObserveReqLatency(now, read)
 	tracing.TraceReqComplete(read, s.cache)
 
 	return true
@@ -79,6 +80,7 @@ func (s *respondStage) respondWriteTrans(
 
 	s.removeTransaction(trans)
 
+	s.cache.stats.ObserveReqLatency(now, write)
 	tracing.TraceReqComplete(write, s.cache)
 
 	return true
@@ -48,3 +48,10 @@ func (t *transaction) PID() vm.PID {
 	}
 	return t.write.PID
 }
+
+func (t *transaction) Scope() mem.Scope {
+	if t.read != nil {
+		return t.read.Scope
+	}
+	return t.write.Scope
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/sarchlab/akita/v3/mem/mem"
+	"github.com/sarchlab/akita/v3/metrics"
 	"github.com/sarchlab/akita/v3/sim"
 
 	"github.com/sarchlab/akita/v3/mem/dram/internal/signal"
@@ -67,6 +68,7 @@ type Builder struct {
 	tXS        int
 
 	tracers []tracing.Tracer
+	metrics *metrics.Registry
 }
 
 // MakeBuilder creates a builder with default configuration.
@@ -109,6 +111,7 @@ func MakeBuilder() Builder {
 		tRFCb:                1950,
 		tCKESR:               5,
 		tXS:                  216,
+		metrics:              metrics.DefaultRegistry(),
 	}
 
 	return b
@@ -391,6 +394,13 @@ func (b Builder) WithRFCb(cycle int) Builder {
 	return b
 }
 
+// WithMetricsRegistry sets the registry that the metrics of the memory
+// controller are registered in. By default, the default registry is used.
+func (b Builder) WithMetricsRegistry(registry *metrics.Registry) Builder {
+	b.metrics = registry
+	return b
+}
+
 // Build builds a new MemController.
 func (b Builder) Build(name string) *MemController {
 	m := &MemController{
@@ -400,6 +410,7 @@ func (b Builder) Build(name string) *MemController {
 	m.TickingComponent = sim.NewTickingComponent(name, b.engine, b.freq, m)
 
 	b.attachTracers(m)
+	b.registerMetrics(name, m)
 	b.buildChannel(name, m)
 
 	m.addrConverter = b.addrConverter
@@ -415,7 +426,8 @@ func (b Builder) Build(name string) *MemController {
 		Build()
 
 	numAccessUnitBit, _ := log2(uint64(b.busWidth / 8 * b.burstLength))
-	m.subTransSplitter = trans.NewSubTransSplitter(numAccessUnitBit)
+	m.subTransSplitter = trans.NewSubTransSplitter(
+		numAccessUnitBit, sim.IDGeneratorFor(m))
 	m.cmdQueue = &cmdq.CommandQueueImpl{
 		Queues:           make([]cmdq.Queue, b.numChannel*b.numRank),
 		CapacityPerQueue: b.commandQueueSize,
@@ -425,10 +437,12 @@ func (b Builder) Build(name string) *MemController {
 		Capacity: b.transactionQueueSize,
 		CmdQueue: m.cmdQueue,
 		CmdCreator: &trans.ClosePageCommandCreator{
-			AddrMapper: m.addrMapper,
+			AddrMapper:  m.addrMapper,
+			IDGenerator: sim.IDGeneratorFor(m),
 		},
 	}
 
+	m.useGlobalStorage = b.useGlobalStorage
 	if b.useGlobalStorage {
 		m.storage = b.storage
 	} else {
@@ -451,6 +465,17 @@ func (b Builder) attachTracers(hookable tracing.NamedHookable) {
 	}
 }
 
+func (b Builder) registerMetrics(name string, m *MemController) {
+	m.readTransCount = b.metrics.Counter(name, "read_trans_count")
+	m.writeTransCount = b.metrics.Counter(name, "write_trans_count")
+	m.readSize = b.metrics.Counter(name, "read_size")
+	m.writeSize = b.metrics.Counter(name, "write_size")
+	m.readLatency = b.metrics.Histogram(
+		name, "read_latency", metrics.DefaultLatencyBuckets)
+	m.writeLatency = b.metrics.Histogram(
+		name, "write_latency", metrics.DefaultLatencyBuckets)
+}
+
 func (b Builder) buildChannel(name string, m *MemController) {
 	timing := b.generateTiming()
 	channel := &org.ChannelImpl{
@@ -468,6 +493,7 @@ func (b Builder) buildChannel(name string, m *MemController) {
 				bankName := fmt.Sprintf("%s.Bank[%d][%d][%d]",
 					name, i, j, k)
 				bank := org.NewBankImpl(bankName)
+				bank.IDGenerator = sim.IDGeneratorFor(m)
 				bank.CmdCycles = map[signal.CommandKind]int{
 					signal.CmdKindRead:           b.readDelay,
 					signal.CmdKindReadPrecharge:  b.tRP,
@@ -0,0 +1,131 @@
+package dram
+
+import (
+	"fmt"
+
+	"github.com/sarchlab/akita/v3/mem/dram/internal/cmdq"
+	"github.com/sarchlab/akita/v3/mem/dram/internal/org"
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+type checkpointState struct {
+	NextQueueIndex int
+	Banks          []org.BankSnapshot
+}
+
+// SaveState saves the states of the banks and the data of the memory
+// controller. The memory controller can only be saved when it has no
+// transaction in flight. A global storage is not saved with the memory
+// controller, as it is shared with other components and is saved by its
+// owner.
+func (c *MemController) SaveState(cp *sim.Checkpoint) error {
+	if len(c.inflightTransactions) > 0 {
+		return fmt.Errorf("memory controller %s has transactions in flight",
+			c.Name())
+	}
+
+	cmdQueue, banks, err := c.checkpointableParts()
+	if err != nil {
+		return err
+	}
+
+	for _, q := range cmdQueue.Queues {
+		if len(q) > 0 {
+			return fmt.Errorf("memory controller %s has commands in flight",
+				c.Name())
+		}
+	}
+
+	state := checkpointState{NextQueueIndex: cmdQueue.NextQueueIndex()}
+	for _, bank := range banks {
+		snapshot, err := bank.TakeSnapshot()
+		if err != nil {
+			return err
+		}
+
+		state.Banks = append(state.Banks, snapshot)
+	}
+
+	err = cp.Put(c.Name(), state)
+	if err != nil {
+		return err
+	}
+
+	if !c.useGlobalStorage {
+		err = c.storage.SaveStateAs(cp, c.Name()+".Storage")
+		if err != nil {
+			return err
+		}
+	}
+
+	return c.TickScheduler.SaveStateAs(cp, c.Name()+".TickScheduler")
+}
+
+// LoadState restores the states of the banks and the data of the memory
+// controller.
+func (c *MemController) LoadState(cp *sim.Checkpoint) error {
+	cmdQueue, banks, err := c.checkpointableParts()
+	if err != nil {
+		return err
+	}
+
+	state := checkpointState{}
+	err = cp.Get(c.Name(), &state)
+	if err != nil {
+		return err
+	}
+
+	if len(state.Banks) != len(banks) {
+		return fmt.Errorf("memory controller %s does not match the checkpoint",
+			c.Name())
+	}
+
+	cmdQueue.SetNextQueueIndex(state.NextQueueIndex)
+	for i, bank := range banks {
+		bank.RestoreSnapshot(state.Banks[i])
+	}
+
+	if !c.useGlobalStorage {
+		err = c.storage.LoadStateAs(cp, c.Name()+".Storage")
+		if err != nil {
+			return err
+		}
+	}
+
+	return c.TickScheduler.LoadStateAs(cp, c.Name()+".TickScheduler")
+}
+
+func (c *MemController) checkpointableParts() (
+	*cmdq.CommandQueueImpl,
+	[]*org.BankImpl,
+	error,
+) {
+	cmdQueue, ok := c.cmdQueue.(*cmdq.CommandQueueImpl)
+	if !ok {
+		return nil, nil, fmt.Errorf("command queue %T cannot be checkpointed",
+			c.cmdQueue)
+	}
+
+	channel, ok := c.channel.(*org.ChannelImpl)
+	if !ok {
+		return nil, nil, fmt.Errorf("channel %T cannot be checkpointed",
+			c.channel)
+	}
+
+	banks := []*org.BankImpl{}
+	for _, rank := range channel.Banks {
+		for _, bankGroup := range rank {
+			for _, b := range bankGroup {
+				bank, ok := b.(*org.BankImpl)
+				if !ok {
+					return nil, nil, fmt.Errorf(
+						"bank %T cannot be checkpointed", b)
+				}
+
+				banks = append(banks, bank)
+			}
+		}
+	}
+
+	return cmdQueue, banks, nil
+}
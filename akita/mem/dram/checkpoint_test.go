@@ -0,0 +1,47 @@
+package dram
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/akita/v3/mem/dram/internal/signal"
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+var _ = Describe("MemController Checkpoint", func() {
+	var (
+		memCtrl      *MemController
+		checkpointer *sim.Checkpointer
+	)
+
+	BeforeEach(func() {
+		engine := sim.NewSerialEngine()
+		memCtrl = MakeBuilder().
+			WithEngine(engine).
+			Build("CheckpointDRAM")
+		checkpointer = sim.NewCheckpointer(engine)
+		checkpointer.Register(memCtrl)
+	})
+
+	It("should restore the data", func() {
+		Expect(memCtrl.storage.Write(0x40, []byte{1, 2, 3, 4})).To(Succeed())
+
+		buf := bytes.NewBuffer(nil)
+		Expect(checkpointer.Save(buf)).To(Succeed())
+
+		Expect(memCtrl.storage.Write(0x40, []byte{0, 0, 0, 0})).To(Succeed())
+		Expect(checkpointer.Restore(buf)).To(Succeed())
+
+		data, err := memCtrl.storage.Read(0x40, 4)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(Equal([]byte{1, 2, 3, 4}))
+	})
+
+	It("should not save a transaction in flight", func() {
+		memCtrl.inflightTransactions = append(memCtrl.inflightTransactions,
+			&signal.Transaction{})
+
+		Expect(checkpointer.Save(bytes.NewBuffer(nil))).NotTo(Succeed())
+	})
+})
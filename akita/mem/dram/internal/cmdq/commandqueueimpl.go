@@ -61,6 +61,18 @@ func (q *CommandQueueImpl) getFirstReadyInQueue(
 	return nil
 }
 
+// NextQueueIndex returns the index of the queue that is searched first for a
+// command to issue.
+func (q *CommandQueueImpl) NextQueueIndex() int {
+	return q.nextQueueIndex
+}
+
+// SetNextQueueIndex sets the index of the queue that is searched first for a
+// command to issue.
+func (q *CommandQueueImpl) SetNextQueueIndex(i int) {
+	q.nextQueueIndex = i
+}
+
 // CanAccept returns true is there is empty space in the command queue.
 func (q *CommandQueueImpl) CanAccept(cmd *signal.Command) bool {
 	queueIndex := q.getQueueIndex(cmd)
@@ -1,6 +1,8 @@
 package org
 
 import (
+	"fmt"
+
 	"github.com/sarchlab/akita/v3/mem/dram/internal/signal"
 	"github.com/sarchlab/akita/v3/sim"
 	"github.com/sarchlab/akita/v3/tracing"
@@ -26,6 +28,7 @@ type BankImpl struct {
 	currentCmd           *signal.Command
 	openRow              uint64
 	CmdCycles            map[signal.CommandKind]int
+	IDGenerator          sim.IDGenerator
 	cyclesToCmdAvailable map[signal.CommandKind]int
 }
 
@@ -36,6 +39,7 @@ func NewBankImpl(name string) *BankImpl {
 		state:                BankStateClosed,
 		cyclesToCmdAvailable: make(map[signal.CommandKind]int),
 		CmdCycles:            make(map[signal.CommandKind]int),
+		IDGenerator:          sim.GetIDGenerator(),
 	}
 
 	return b
@@ -94,6 +98,46 @@ func (b *BankImpl) completeCurrentCmd(now sim.VTimeInSec) {
 	b.currentCmd = nil
 }
 
+// A BankSnapshot is the state of a bank that is saved in a checkpoint.
+type BankSnapshot struct {
+	State                BankState
+	OpenRow              uint64
+	CyclesToCmdAvailable map[signal.CommandKind]int
+}
+
+// TakeSnapshot returns the state of the bank. A bank that is running a
+// command cannot be saved.
+func (b *BankImpl) TakeSnapshot() (BankSnapshot, error) {
+	if b.currentCmd != nil {
+		return BankSnapshot{}, fmt.Errorf("bank %s is running a command",
+			b.BankName)
+	}
+
+	s := BankSnapshot{
+		State:                b.state,
+		OpenRow:              b.openRow,
+		CyclesToCmdAvailable: make(map[signal.CommandKind]int),
+	}
+
+	for kind, cycles := range b.cyclesToCmdAvailable {
+		s.CyclesToCmdAvailable[kind] = cycles
+	}
+
+	return s, nil
+}
+
+// RestoreSnapshot sets the state of the bank to the snapshot.
+func (b *BankImpl) RestoreSnapshot(s BankSnapshot) {
+	b.state = s.State
+	b.openRow = s.OpenRow
+	b.currentCmd = nil
+	b.cyclesToCmdAvailable = make(map[signal.CommandKind]int)
+
+	for kind, cycles := range s.CyclesToCmdAvailable {
+		b.cyclesToCmdAvailable[kind] = cycles
+	}
+}
+
 // GetReadyCommand returns the next command is ready to be issued.
 func (b *BankImpl) GetReadyCommand(
 	now sim.VTimeInSec,
@@ -105,7 +149,7 @@ func (b *BankImpl) GetReadyCommand(
 	}
 
 	if b.cyclesToCmdAvailable[requiredKind] == 0 {
-		readyCmd := cmd.Clone()
+		readyCmd := cmd.Clone(b.IDGenerator)
 		readyCmd.Kind = requiredKind
 		return readyCmd
 	}
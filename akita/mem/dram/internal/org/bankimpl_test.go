@@ -4,6 +4,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/sarchlab/akita/v3/mem/dram/internal/signal"
+	"github.com/sarchlab/akita/v3/sim"
 )
 
 var _ = Describe("Bank", func() {
@@ -14,6 +15,7 @@ var _ = Describe("Bank", func() {
 	BeforeEach(func() {
 		b = BankImpl{
 			cyclesToCmdAvailable: make(map[signal.CommandKind]int),
+			IDGenerator:          sim.GetIDGenerator(),
 			CmdCycles: map[signal.CommandKind]int{
 				signal.CmdKindRead:           1,
 				signal.CmdKindReadPrecharge:  1,
@@ -58,10 +58,11 @@ type Command struct {
 	SubTrans  *SubTransaction
 }
 
-// Clone will create another command with the same content, but different ID.
-func (c *Command) Clone() *Command {
+// Clone will create another command with the same content, but a different ID
+// generated by the given ID generator.
+func (c *Command) Clone(idGenerator sim.IDGenerator) *Command {
 	newCmd := &Command{
-		ID:        sim.GetIDGenerator().Generate(),
+		ID:        idGenerator.Generate(),
 		Location:  c.Location,
 		Kind:      c.Kind,
 		Address:   c.Address,
@@ -1,6 +1,9 @@
 package signal
 
-import "github.com/sarchlab/akita/v3/mem/mem"
+import (
+	"github.com/sarchlab/akita/v3/mem/mem"
+	"github.com/sarchlab/akita/v3/sim"
+)
 
 // Transaction is the state associated with the processing of a read or write
 // request.
@@ -10,6 +13,8 @@ type Transaction struct {
 
 	InternalAddress uint64
 	SubTransactions []*SubTransaction
+
+	StartTime sim.VTimeInSec
 }
 
 // GlobalAddress returns the address that the transaction is accessing.
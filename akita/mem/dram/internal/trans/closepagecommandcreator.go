@@ -9,7 +9,8 @@ import (
 // ClosePageCommandCreator always creates precharge commands as precharge
 // commands will be the last command in a row.
 type ClosePageCommandCreator struct {
-	AddrMapper addressmapping.Mapper
+	AddrMapper  addressmapping.Mapper
+	IDGenerator sim.IDGenerator
 }
 
 // Create creates new commands that can accomplish the subTrans.
@@ -17,7 +18,7 @@ func (c *ClosePageCommandCreator) Create(
 	subTrans *signal.SubTransaction,
 ) *signal.Command {
 	cmd := &signal.Command{
-		ID: sim.GetIDGenerator().Generate(),
+		ID: c.IDGenerator.Generate(),
 	}
 
 	if subTrans.IsRead() {
@@ -7,6 +7,7 @@ import (
 	"github.com/sarchlab/akita/v3/mem/dram/internal/addressmapping"
 	"github.com/sarchlab/akita/v3/mem/dram/internal/signal"
 	"github.com/sarchlab/akita/v3/mem/mem"
+	"github.com/sarchlab/akita/v3/sim"
 )
 
 var _ = Describe("ClosePageCommandCreator", func() {
@@ -20,7 +21,8 @@ var _ = Describe("ClosePageCommandCreator", func() {
 		mockCtrl = gomock.NewController(GinkgoT())
 		mapper = NewMockMapper(mockCtrl)
 		cmdCreator = &ClosePageCommandCreator{
-			AddrMapper: mapper,
+			AddrMapper:  mapper,
+			IDGenerator: sim.GetIDGenerator(),
 		}
 	})
 
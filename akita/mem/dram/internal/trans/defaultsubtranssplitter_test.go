@@ -5,6 +5,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/sarchlab/akita/v3/mem/dram/internal/signal"
 	"github.com/sarchlab/akita/v3/mem/mem"
+	"github.com/sarchlab/akita/v3/sim"
 )
 
 var _ = Describe("Default SubTransSplitter", func() {
@@ -18,7 +19,7 @@ var _ = Describe("Default SubTransSplitter", func() {
 			Read: read,
 		}
 
-		splitter := NewSubTransSplitter(6)
+		splitter := NewSubTransSplitter(6, sim.GetIDGenerator())
 
 		splitter.Split(transaction)
 
@@ -10,10 +10,15 @@ type SubTransSplitter interface {
 	Split(t *signal.Transaction)
 }
 
-// NewSubTransSplitter creates a default SubTransSplitter
-func NewSubTransSplitter(log2BankSize uint64) SubTransSplitter {
+// NewSubTransSplitter creates a default SubTransSplitter. The IDs of the
+// sub-transactions are generated by the given ID generator.
+func NewSubTransSplitter(
+	log2BankSize uint64,
+	idGenerator sim.IDGenerator,
+) SubTransSplitter {
 	s := &defaultSubTransSplitter{
 		log2AccessUnitSize: log2BankSize,
+		idGenerator:        idGenerator,
 	}
 
 	return s
@@ -21,6 +26,7 @@ func NewSubTransSplitter(log2BankSize uint64) SubTransSplitter {
 
 type defaultSubTransSplitter struct {
 	log2AccessUnitSize uint64
+	idGenerator        sim.IDGenerator
 }
 
 func (s *defaultSubTransSplitter) Split(t *signal.Transaction) {
@@ -30,7 +36,7 @@ func (s *defaultSubTransSplitter) Split(t *signal.Transaction) {
 	unitSize := uint64(1 << s.log2AccessUnitSize)
 	for addr < endAddr {
 		st := &signal.SubTransaction{
-			ID:          sim.GetIDGenerator().Generate(),
+			ID:          s.idGenerator.Generate(),
 			Transaction: t,
 			Address:     addr,
 		}
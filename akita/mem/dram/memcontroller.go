@@ -7,6 +7,7 @@ import (
 	"github.com/sarchlab/akita/v3/mem/dram/internal/signal"
 	"github.com/sarchlab/akita/v3/mem/dram/internal/trans"
 	"github.com/sarchlab/akita/v3/mem/mem"
+	"github.com/sarchlab/akita/v3/metrics"
 	"github.com/sarchlab/akita/v3/sim"
 	"github.com/sarchlab/akita/v3/tracing"
 )
@@ -44,6 +45,7 @@ type MemController struct {
 	topPort sim.Port
 
 	storage             *mem.Storage
+	useGlobalStorage    bool
 	addrConverter       mem.AddressConverter
 	subTransSplitter    trans.SubTransSplitter
 	addrMapper          addressmapping.Mapper
@@ -52,6 +54,13 @@ type MemController struct {
 	channel             org.Channel
 
 	inflightTransactions []*signal.Transaction
+
+	readTransCount  *metrics.Counter
+	writeTransCount *metrics.Counter
+	readSize        *metrics.Counter
+	writeSize       *metrics.Counter
+	readLatency     *metrics.Histogram
+	writeLatency    *metrics.Histogram
 }
 
 // Tick updates memory controller's internal state.
@@ -71,7 +80,7 @@ func (c *MemController) parseTop(now sim.VTimeInSec) (madeProgress bool) {
 		return false
 	}
 
-	trans := &signal.Transaction{}
+	trans := &signal.Transaction{StartTime: now}
 	switch msg := msg.(type) {
 	case *mem.ReadReq:
 		trans.Read = msg
@@ -153,11 +162,17 @@ func (c *MemController) finalizeTransaction(
 		done = c.finalizeWriteTrans(now, t, i)
 		if done {
 			tracing.TraceReqComplete(t.Write, c)
+			c.writeTransCount.Inc()
+			c.writeSize.Add(float64(len(t.Write.Data)))
+			c.writeLatency.Observe(float64(now - t.StartTime))
 		}
 	} else {
 		done = c.finalizeReadTrans(now, t, i)
 		if done {
 			tracing.TraceReqComplete(t.Read, c)
+			c.readTransCount.Inc()
+			c.readSize.Add(float64(t.Read.AccessByteSize))
+			c.readLatency.Observe(float64(now - t.StartTime))
 		}
 	}
 
@@ -41,6 +41,20 @@ func (c InterleavingConverter) ConvertExternalToInternal(external uint64) uint64
 	return internal
 }
 
+// Contains returns true if the external address belongs to the current
+// element.
+func (c InterleavingConverter) Contains(external uint64) bool {
+	if external < c.Offset {
+		return false
+	}
+
+	addr := external - c.Offset
+	roundSize := c.InterleavingSize * uint64(c.TotalNumOfElements)
+	belongsTo := int(addr % roundSize / c.InterleavingSize)
+
+	return belongsTo == c.CurrentElementIndex
+}
+
 // ConvertInternalToExternal converts from internal address to external address
 func (c InterleavingConverter) ConvertInternalToExternal(internal uint64) uint64 {
 	panic("this function should never be called")
@@ -35,4 +35,11 @@ var _ = Describe("Interleaving Address Converter", func() {
 			Should(Panic())
 	})
 
+	It("should tell if an address belongs to current element", func() {
+		Expect(converter.Contains(4096 + 100)).To(BeTrue())
+		Expect(converter.Contains(4096*8 + 4096)).To(BeTrue())
+		Expect(converter.Contains(0)).To(BeFalse())
+		Expect(converter.Contains(4096 * 2)).To(BeFalse())
+	})
+
 })
@@ -0,0 +1,202 @@
+package mem
+
+import (
+	"github.com/sarchlab/akita/v3/mem/vm"
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+// Scope defines the set of agents that a memory operation must be coherent
+// with.
+type Scope int
+
+const (
+	// ScopeWorkgroup requires the memory operation to be coherent with the
+	// work-items in the same workgroup. It is the default scope, which allows
+	// the accesses to be served by the L1 caches.
+	ScopeWorkgroup Scope = iota
+
+	// ScopeAgent requires the memory operation to be coherent with all the
+	// work-items that run on the same GPU.
+	ScopeAgent
+
+	// ScopeSystem requires the memory operation to be coherent with all the
+	// agents in the system, including other GPUs and the CPU.
+	ScopeSystem
+)
+
+func (s Scope) String() string {
+	switch s {
+	case ScopeWorkgroup:
+		return "workgroup"
+	case ScopeAgent:
+		return "agent"
+	case ScopeSystem:
+		return "system"
+	default:
+		return "unknown"
+	}
+}
+
+// A FenceReq requests the memory system to order the memory operations before
+// and after the fence. A release fence guarantees that all the writes issued
+// before the fence are visible in the scope of the fence. An acquire fence
+// guarantees that the reads issued after the fence do not return stale data
+// cached outside the scope of the fence.
+//
+// A FenceReq travels along with the read and write requests, so that it is
+// ordered with the requests that are issued before it.
+type FenceReq struct {
+	sim.MsgMeta
+
+	PID     vm.PID
+	Scope   Scope
+	Acquire bool
+	Release bool
+}
+
+// Meta returns the meta data associated with the message.
+func (r *FenceReq) Meta() *sim.MsgMeta {
+	return &r.MsgMeta
+}
+
+// GetByteSize returns 0, as a fence does not access any data.
+func (r *FenceReq) GetByteSize() uint64 {
+	return 0
+}
+
+// GetAddress returns 0, as a fence applies to all addresses.
+func (r *FenceReq) GetAddress() uint64 {
+	return 0
+}
+
+// GetPID returns the process ID that the request is working on.
+func (r *FenceReq) GetPID() vm.PID {
+	return r.PID
+}
+
+// FenceReqBuilder can build fence requests.
+type FenceReqBuilder struct {
+	sendTime         sim.VTimeInSec
+	src, dst         sim.Port
+	pid              vm.PID
+	scope            Scope
+	acquire, release bool
+}
+
+// WithSendTime sets the send time of the request to build.
+func (b FenceReqBuilder) WithSendTime(t sim.VTimeInSec) FenceReqBuilder {
+	b.sendTime = t
+	return b
+}
+
+// WithSrc sets the source of the request to build.
+func (b FenceReqBuilder) WithSrc(src sim.Port) FenceReqBuilder {
+	b.src = src
+	return b
+}
+
+// WithDst sets the destination of the request to build.
+func (b FenceReqBuilder) WithDst(dst sim.Port) FenceReqBuilder {
+	b.dst = dst
+	return b
+}
+
+// WithPID sets the PID of the request to build.
+func (b FenceReqBuilder) WithPID(pid vm.PID) FenceReqBuilder {
+	b.pid = pid
+	return b
+}
+
+// WithScope sets the scope of the fence to build.
+func (b FenceReqBuilder) WithScope(scope Scope) FenceReqBuilder {
+	b.scope = scope
+	return b
+}
+
+// WithAcquire makes the fence to build an acquire fence.
+func (b FenceReqBuilder) WithAcquire() FenceReqBuilder {
+	b.acquire = true
+	return b
+}
+
+// WithRelease makes the fence to build a release fence.
+func (b FenceReqBuilder) WithRelease() FenceReqBuilder {
+	b.release = true
+	return b
+}
+
+// Build creates a new FenceReq.
+func (b FenceReqBuilder) Build() *FenceReq {
+	r := &FenceReq{}
+	r.ID = sim.IDGeneratorFor(b.src).Generate()
+	r.Src = b.src
+	r.Dst = b.dst
+	r.SendTime = b.sendTime
+	r.TrafficBytes = controlMsgByteOverhead
+	r.PID = b.pid
+	r.Scope = b.scope
+	r.Acquire = b.acquire
+	r.Release = b.release
+	return r
+}
+
+// A FenceRsp is the response to a FenceReq. It is sent when the fence takes
+// effect.
+type FenceRsp struct {
+	sim.MsgMeta
+
+	RespondTo string
+}
+
+// Meta returns the meta data associated with the message.
+func (r *FenceRsp) Meta() *sim.MsgMeta {
+	return &r.MsgMeta
+}
+
+// GetRspTo returns the ID of the request that this response is responding to.
+func (r *FenceRsp) GetRspTo() string {
+	return r.RespondTo
+}
+
+// FenceRspBuilder can build fence responses.
+type FenceRspBuilder struct {
+	sendTime sim.VTimeInSec
+	src, dst sim.Port
+	rspTo    string
+}
+
+// WithSendTime sets the send time of the respond to build.
+func (b FenceRspBuilder) WithSendTime(t sim.VTimeInSec) FenceRspBuilder {
+	b.sendTime = t
+	return b
+}
+
+// WithSrc sets the source of the respond to build.
+func (b FenceRspBuilder) WithSrc(src sim.Port) FenceRspBuilder {
+	b.src = src
+	return b
+}
+
+// WithDst sets the destination of the respond to build.
+func (b FenceRspBuilder) WithDst(dst sim.Port) FenceRspBuilder {
+	b.dst = dst
+	return b
+}
+
+// WithRspTo sets ID of the request that the respond to build is replying to.
+func (b FenceRspBuilder) WithRspTo(id string) FenceRspBuilder {
+	b.rspTo = id
+	return b
+}
+
+// Build creates a new FenceRsp.
+func (b FenceRspBuilder) Build() *FenceRsp {
+	r := &FenceRsp{}
+	r.ID = sim.IDGeneratorFor(b.src).Generate()
+	r.Src = b.src
+	r.Dst = b.dst
+	r.SendTime = b.sendTime
+	r.TrafficBytes = controlMsgByteOverhead
+	r.RespondTo = b.rspTo
+	return r
+}
@@ -8,6 +8,14 @@ type LowModuleFinder interface {
 	Find(address uint64) sim.Port
 }
 
+// A LowModuleLister is a LowModuleFinder that can list all the low modules
+// that it may find. Requests that apply to all the addresses, such as fences,
+// are sent to all the listed low modules.
+type LowModuleLister interface {
+	LowModuleFinder
+	ListLowModules() []sim.Port
+}
+
 // SingleLowModuleFinder is used when a unit is connected with only one
 // low module
 type SingleLowModuleFinder struct {
@@ -19,6 +27,11 @@ func (f *SingleLowModuleFinder) Find(address uint64) sim.Port {
 	return f.LowModule
 }
 
+// ListLowModules returns the solo unit that it connects to.
+func (f *SingleLowModuleFinder) ListLowModules() []sim.Port {
+	return []sim.Port{f.LowModule}
+}
+
 // InterleavedLowModuleFinder helps find the low module when the low modules
 // maintains interleaved address space
 type InterleavedLowModuleFinder struct {
@@ -40,6 +53,12 @@ func (f *InterleavedLowModuleFinder) Find(address uint64) sim.Port {
 	return f.LowModules[number]
 }
 
+// ListLowModules returns the low modules that serve the interleaved address
+// space. The module for other addresses is not included.
+func (f *InterleavedLowModuleFinder) ListLowModules() []sim.Port {
+	return f.LowModules
+}
+
 // NewInterleavedLowModuleFinder creates a new finder for interleaved lower
 // modules
 func NewInterleavedLowModuleFinder(interleavingSize uint64) *InterleavedLowModuleFinder {
@@ -61,6 +80,11 @@ func (f *BankedLowModuleFinder) Find(address uint64) sim.Port {
 	return f.LowModules[i]
 }
 
+// ListLowModules returns all the low modules.
+func (f *BankedLowModuleFinder) ListLowModules() []sim.Port {
+	return f.LowModules
+}
+
 // NewBankedLowModuleFinder returns a new BankedLowModuleFinder.
 func NewBankedLowModuleFinder(bankSize uint64) *BankedLowModuleFinder {
 	f := new(BankedLowModuleFinder)
@@ -44,4 +44,9 @@ var _ = Describe("InterleavedLowModuleFinder", func() {
 		Expect(lowModuleFinder.Find(4 * GB)).To(
 			BeIdenticalTo(lowModuleFinder.ModuleForOtherAddresses))
 	})
+
+	It("should list the interleaved low modules", func() {
+		Expect(lowModuleFinder.ListLowModules()).To(
+			Equal(lowModuleFinder.LowModules))
+	})
 })
@@ -0,0 +1,30 @@
+package mem
+
+// A PCProvider is the Info of a request that tells the program counter of the
+// instruction that issues the request. Requesters that know the PC can attach
+// it to their requests, so that the memory system can tell the access streams
+// of different instructions apart.
+type PCProvider interface {
+	PC() uint64
+}
+
+// PCInfo is the request Info that only carries the program counter.
+type PCInfo struct {
+	ProgramCounter uint64
+}
+
+// PC returns the program counter of the instruction that issues the request.
+func (i PCInfo) PC() uint64 {
+	return i.ProgramCounter
+}
+
+// PCOf returns the program counter attached to a request. The second return
+// value is false if the request does not carry a program counter.
+func PCOf(info interface{}) (uint64, bool) {
+	provider, ok := info.(PCProvider)
+	if !ok {
+		return 0, false
+	}
+
+	return provider.PC(), true
+}
@@ -31,6 +31,7 @@ type ReadReq struct {
 	Address            uint64
 	AccessByteSize     uint64
 	PID                vm.PID
+	Scope              Scope
 	CanWaitForCoalesce bool
 	Info               interface{}
 }
@@ -61,6 +62,7 @@ type ReadReqBuilder struct {
 	src, dst           sim.Port
 	pid                vm.PID
 	address, byteSize  uint64
+	scope              Scope
 	canWaitForCoalesce bool
 	info               interface{}
 }
@@ -107,6 +109,12 @@ func (b ReadReqBuilder) WithByteSize(byteSize uint64) ReadReqBuilder {
 	return b
 }
 
+// WithScope sets the scope in which the request to build must be coherent.
+func (b ReadReqBuilder) WithScope(scope Scope) ReadReqBuilder {
+	b.scope = scope
+	return b
+}
+
 // CanWaitForCoalesce allow the request to build to wait for coalesce.
 func (b ReadReqBuilder) CanWaitForCoalesce() ReadReqBuilder {
 	b.canWaitForCoalesce = true
@@ -116,7 +124,7 @@ func (b ReadReqBuilder) CanWaitForCoalesce() ReadReqBuilder {
 // Build creates a new ReadReq
 func (b ReadReqBuilder) Build() *ReadReq {
 	r := &ReadReq{}
-	r.ID = sim.GetIDGenerator().Generate()
+	r.ID = sim.IDGeneratorFor(b.src).Generate()
 	r.Src = b.src
 	r.Dst = b.dst
 	r.SendTime = b.sendTime
@@ -125,6 +133,7 @@ func (b ReadReqBuilder) Build() *ReadReq {
 	r.PID = b.pid
 	r.Info = b.info
 	r.AccessByteSize = b.byteSize
+	r.Scope = b.scope
 	r.CanWaitForCoalesce = b.canWaitForCoalesce
 	return r
 }
@@ -137,6 +146,7 @@ type WriteReq struct {
 	Data               []byte
 	DirtyMask          []bool
 	PID                vm.PID
+	Scope              Scope
 	CanWaitForCoalesce bool
 	Info               interface{}
 }
@@ -170,6 +180,7 @@ type WriteReqBuilder struct {
 	address            uint64
 	data               []byte
 	dirtyMask          []bool
+	scope              Scope
 	canWaitForCoalesce bool
 }
 
@@ -221,6 +232,12 @@ func (b WriteReqBuilder) WithDirtyMask(mask []bool) WriteReqBuilder {
 	return b
 }
 
+// WithScope sets the scope in which the request to build must be coherent.
+func (b WriteReqBuilder) WithScope(scope Scope) WriteReqBuilder {
+	b.scope = scope
+	return b
+}
+
 // CanWaitForCoalesce allow the request to build to wait for coalesce.
 func (b WriteReqBuilder) CanWaitForCoalesce() WriteReqBuilder {
 	b.canWaitForCoalesce = true
@@ -230,7 +247,7 @@ func (b WriteReqBuilder) CanWaitForCoalesce() WriteReqBuilder {
 // Build creates a new WriteReq
 func (b WriteReqBuilder) Build() *WriteReq {
 	r := &WriteReq{}
-	r.ID = sim.GetIDGenerator().Generate()
+	r.ID = sim.IDGeneratorFor(b.src).Generate()
 	r.Src = b.src
 	r.Dst = b.dst
 	r.SendTime = b.sendTime
@@ -240,6 +257,7 @@ func (b WriteReqBuilder) Build() *WriteReq {
 	r.Data = b.data
 	r.TrafficBytes = len(r.Data) + accessReqByteOverhead
 	r.DirtyMask = b.dirtyMask
+	r.Scope = b.scope
 	r.CanWaitForCoalesce = b.canWaitForCoalesce
 	return r
 }
@@ -306,7 +324,7 @@ func (b DataReadyRspBuilder) WithData(data []byte) DataReadyRspBuilder {
 // Build creates a new DataReadyRsp
 func (b DataReadyRspBuilder) Build() *DataReadyRsp {
 	r := &DataReadyRsp{}
-	r.ID = sim.GetIDGenerator().Generate()
+	r.ID = sim.IDGeneratorFor(b.src).Generate()
 	r.Src = b.src
 	r.Dst = b.dst
 	r.SendTime = b.sendTime
@@ -370,7 +388,7 @@ func (b WriteDoneRspBuilder) WithRspTo(id string) WriteDoneRspBuilder {
 // Build creates a new WriteDoneRsp
 func (b WriteDoneRspBuilder) Build() *WriteDoneRsp {
 	r := &WriteDoneRsp{}
-	r.ID = sim.GetIDGenerator().Generate()
+	r.ID = sim.IDGeneratorFor(b.src).Generate()
 	r.Src = b.src
 	r.Dst = b.dst
 	r.TrafficBytes = accessRspByteOverhead
@@ -446,7 +464,7 @@ func (b ControlMsgBuilder) ToNotifyDone() ControlMsgBuilder {
 // Build creates a new ControlMsg.
 func (b ControlMsgBuilder) Build() *ControlMsg {
 	m := &ControlMsg{}
-	m.ID = sim.GetIDGenerator().Generate()
+	m.ID = sim.IDGeneratorFor(b.src).Generate()
 	m.Src = b.src
 	m.Dst = b.dst
 	m.TrafficBytes = controlMsgByteOverhead
@@ -521,7 +539,7 @@ func (b GL0InvalidateReqBuilder) WithPID(pid vm.PID) GL0InvalidateReqBuilder {
 // Build creates a new GL0InvalidateReq
 func (b GL0InvalidateReqBuilder) Build() *GL0InvalidateReq {
 	r := &GL0InvalidateReq{}
-	r.ID = sim.GetIDGenerator().Generate()
+	r.ID = sim.IDGeneratorFor(b.src).Generate()
 	r.Src = b.src
 	r.Dst = b.dst
 	r.SendTime = b.sendTime
@@ -608,7 +626,7 @@ func (r *GL0InvalidateRsp) GetRespondTo() string {
 // Build creates a new CUPipelineRestartReq
 func (b GL0InvalidateRspBuilder) Build() *GL0InvalidateRsp {
 	r := &GL0InvalidateRsp{}
-	r.ID = sim.GetIDGenerator().Generate()
+	r.ID = sim.IDGeneratorFor(b.src).Generate()
 	r.Src = b.src
 	r.Dst = b.dst
 	r.SendTime = b.sendTime
@@ -2,7 +2,10 @@ package mem
 
 import (
 	"errors"
+	"fmt"
 	"sync"
+
+	"github.com/sarchlab/akita/v3/sim"
 )
 
 // For capacity
@@ -22,7 +25,6 @@ const (
 // The storage implementation manages the storage in units. The unit can is
 // similar to the concept of page in memory management. For the units that
 // it not touched by Read and Write function, no memory will be allocated.
-//
 type Storage struct {
 	sync.Mutex
 	Capacity uint64
@@ -151,3 +153,55 @@ func (s *Storage) Write(address uint64, data []byte) error {
 
 	return nil
 }
+
+type storageState struct {
+	Capacity uint64
+	UnitSize uint64
+	Units    map[uint64][]byte
+}
+
+// SaveStateAs saves the data of the storage in the checkpoint under the key.
+// The owner of the storage calls this function in its SaveState method.
+func (s *Storage) SaveStateAs(cp *sim.Checkpoint, key string) error {
+	s.Lock()
+	defer s.Unlock()
+
+	state := storageState{
+		Capacity: s.Capacity,
+		UnitSize: s.unitSize,
+		Units:    make(map[uint64][]byte, len(s.data)),
+	}
+
+	for addr, unit := range s.data {
+		unit.RLock()
+		state.Units[addr] = append([]byte(nil), unit.data...)
+		unit.RUnlock()
+	}
+
+	return cp.Put(key, state)
+}
+
+// LoadStateAs restores the data of the storage from the checkpoint.
+func (s *Storage) LoadStateAs(cp *sim.Checkpoint, key string) error {
+	state := storageState{}
+	err := cp.Get(key, &state)
+	if err != nil {
+		return err
+	}
+
+	if state.Capacity != s.Capacity || state.UnitSize != s.unitSize {
+		return fmt.Errorf("storage %s does not match the checkpoint", key)
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	s.data = make(map[uint64]*storageUnit, len(state.Units))
+	for addr, data := range state.Units {
+		unit := newStorageUnit(s.unitSize)
+		copy(unit.data, data)
+		s.data[addr] = unit
+	}
+
+	return nil
+}
@@ -1,10 +1,29 @@
 package mem
 
 import (
+	"bytes"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/sarchlab/akita/v3/sim"
 )
 
+type storageOwner struct {
+	storage *Storage
+}
+
+func (o *storageOwner) Name() string {
+	return "StorageOwner"
+}
+
+func (o *storageOwner) SaveState(cp *sim.Checkpoint) error {
+	return o.storage.SaveStateAs(cp, "StorageOwner.Storage")
+}
+
+func (o *storageOwner) LoadState(cp *sim.Checkpoint) error {
+	return o.storage.LoadStateAs(cp, "StorageOwner.Storage")
+}
+
 var _ = Describe("Storage", func() {
 	It("should read and write in single unit", func() {
 		storage := NewStorage(4096)
@@ -34,4 +53,21 @@ var _ = Describe("Storage", func() {
 		Expect(err).To(MatchError("accessing physical address beyond the storage capacity"))
 	})
 
+	It("should save and restore the data", func() {
+		storage := NewStorage(8192)
+		storage.Write(4094, []byte{1, 2, 3, 4})
+		checkpointer := sim.NewCheckpointer(sim.NewSerialEngine())
+		checkpointer.Register(&storageOwner{storage: storage})
+
+		checkpoint := bytes.NewBuffer(nil)
+		Expect(checkpointer.Save(checkpoint)).To(Succeed())
+		storage.Write(4094, []byte{5, 6, 7, 8})
+		storage.Write(0, []byte{9})
+		Expect(checkpointer.Restore(checkpoint)).To(Succeed())
+
+		res, _ := storage.Read(4094, 4)
+		Expect(res).To(Equal([]byte{1, 2, 3, 4}))
+		res, _ = storage.Read(0, 1)
+		Expect(res).To(Equal([]byte{0}))
+	})
 })
@@ -3,6 +3,7 @@ package trace
 
 import (
 	"log"
+	"sync"
 
 	"github.com/sarchlab/akita/v3/mem/mem"
 	"github.com/sarchlab/akita/v3/sim"
@@ -10,14 +11,18 @@ import (
 )
 
 // A tracer is a hook that can record the actions of a memory model into
-// traces.
+// traces. The tracer can be shared by components that run in parallel.
 type tracer struct {
+	lock       sync.Mutex
 	timeTeller sim.TimeTeller
 	logger     *log.Logger
 }
 
 // StartTask marks the start of a memory transaction
 func (t *tracer) StartTask(task tracing.Task) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
 	task.StartTime = t.timeTeller.CurrentTime()
 
 	req, ok := task.Detail.(mem.AccessReq)
@@ -31,6 +36,9 @@ func (t *tracer) StartTask(task tracing.Task) {
 
 // StepTask marks the memory transaction has completed a milestone
 func (t *tracer) StepTask(task tracing.Task) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
 	task.Steps[0].Time = t.timeTeller.CurrentTime()
 
 	t.logger.Printf("step, %.12f, %s, %s\n",
@@ -41,6 +49,9 @@ func (t *tracer) StepTask(task tracing.Task) {
 
 // EndTask marks the end of a memory transaction
 func (t *tracer) EndTask(task tracing.Task) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
 	task.EndTime = t.timeTeller.CurrentTime()
 
 	t.logger.Printf("end, %.12f, %s\n", task.EndTime, task.ID)
@@ -132,6 +132,8 @@ func (t *AddressTranslator) translate(now sim.VTimeInSec) bool {
 	switch req := item.(type) {
 	case *mem.GL0InvalidateReq:
 		return t.handleGL0InvalidateReq(now, req)
+	case *mem.FenceReq:
+		return t.forwardFence(now, req)
 	}
 
 	req := item.(mem.AccessReq)
@@ -181,6 +183,48 @@ func (t *AddressTranslator) handleGL0InvalidateReq(
 	return true
 }
 
+// forwardFence sends the fence to the low module after all the earlier
+// requests are sent, so that the fence is ordered after them.
+func (t *AddressTranslator) forwardFence(
+	now sim.VTimeInSec,
+	req *mem.FenceReq,
+) bool {
+	if len(t.transactions) > 0 {
+		return false
+	}
+
+	builder := mem.FenceReqBuilder{}.
+		WithSendTime(now).
+		WithSrc(t.bottomPort).
+		WithDst(t.lowModuleFinder.Find(0)).
+		WithPID(req.PID).
+		WithScope(req.Scope)
+	if req.Acquire {
+		builder = builder.WithAcquire()
+	}
+	if req.Release {
+		builder = builder.WithRelease()
+	}
+	fence := builder.Build()
+
+	err := t.bottomPort.Send(fence)
+	if err != nil {
+		return false
+	}
+
+	t.inflightReqToBottom = append(t.inflightReqToBottom,
+		reqToBottom{
+			reqFromTop:  req,
+			reqToBottom: fence,
+		})
+	t.topPort.Retrieve(now)
+
+	tracing.TraceReqReceive(req, t)
+	tracing.TraceReqInitiate(fence, t, tracing.MsgIDAtReceiver(req, t))
+
+	return true
+}
+
 func (t *AddressTranslator) parseTranslation(now sim.VTimeInSec) bool {
 	rsp := t.translationPort.Peek()
 	if rsp == nil {
@@ -265,6 +309,18 @@ func (t *AddressTranslator) respond(now sim.VTimeInSec) bool {
 				WithRspTo(reqFromTop.Meta().ID).
 				Build()
 		}
+	case *mem.FenceRsp:
+		reqInBottom = t.isReqInBottomByID(rsp.RespondTo)
+		if reqInBottom {
+			reqToBottomCombo = t.findReqToBottomByID(rsp.RespondTo)
+			reqFromTop = reqToBottomCombo.reqFromTop
+			rspToTop = mem.FenceRspBuilder{}.
+				WithSendTime(now).
+				WithSrc(t.topPort).
+				WithDst(reqFromTop.Meta().Src).
+				WithRspTo(reqFromTop.Meta().ID).
+				Build()
+		}
 	case *mem.GL0InvalidateRsp:
 		gl0InvalidateReq := t.currentGL0InvReq
 		if gl0InvalidateReq == nil {
@@ -344,6 +400,7 @@ func (t *AddressTranslator) createTranslatedReadReq(
 		WithAddress(addr).
 		WithByteSize(req.AccessByteSize).
 		WithPID(0).
+		WithScope(req.Scope).
 		WithInfo(req.Info).
 		Build()
 	clone.CanWaitForCoalesce = req.CanWaitForCoalesce
@@ -363,6 +420,7 @@ func (t *AddressTranslator) createTranslatedWriteReq(
 		WithDirtyMask(req.DirtyMask).
 		WithAddress(addr).
 		WithPID(0).
+		WithScope(req.Scope).
 		WithInfo(req.Info).
 		Build()
 	clone.CanWaitForCoalesce = req.CanWaitForCoalesce
@@ -108,6 +108,48 @@ var _ = Describe("Address Translator", func() {
 			Expect(needTick).To(BeFalse())
 			Expect(t.transactions).To(HaveLen(0))
 		})
+
+		Context("when the request is a fence", func() {
+			var fence *mem.FenceReq
+
+			BeforeEach(func() {
+				fence = mem.FenceReqBuilder{}.
+					WithPID(1).
+					WithScope(mem.ScopeAgent).
+					WithAcquire().
+					Build()
+			})
+
+			It("should wait for the earlier requests to be translated",
+				func() {
+					t.transactions = append(t.transactions, &transaction{})
+					topPort.EXPECT().Peek().Return(fence)
+
+					madeProgress := t.translate(10)
+
+					Expect(madeProgress).To(BeFalse())
+				})
+
+			It("should forward the fence", func() {
+				lowModule := NewMockPort(mockCtrl)
+				lowModuleFinder.EXPECT().Find(uint64(0)).Return(lowModule)
+				topPort.EXPECT().Peek().Return(fence)
+				topPort.EXPECT().Retrieve(gomock.Any())
+				bottomPort.EXPECT().Send(gomock.Any()).
+					Do(func(f *mem.FenceReq) {
+						Expect(f.Dst).To(BeIdenticalTo(lowModule))
+						Expect(f.Scope).To(Equal(mem.ScopeAgent))
+						Expect(f.Acquire).To(BeTrue())
+						Expect(f.Release).To(BeFalse())
+					}).
+					Return(nil)
+
+				madeProgress := t.translate(10)
+
+				Expect(madeProgress).To(BeTrue())
+				Expect(t.inflightReqToBottom).To(HaveLen(1))
+			})
+		})
 	})
 
 	Context("parse translation", func() {
@@ -339,6 +381,28 @@ var _ = Describe("Address Translator", func() {
 			Expect(t.inflightReqToBottom).To(HaveLen(1))
 		})
 
+		It("should respond fence", func() {
+			fenceFromTop := mem.FenceReqBuilder{}.Build()
+			fenceToBottom := mem.FenceReqBuilder{}.Build()
+			t.inflightReqToBottom = append(t.inflightReqToBottom,
+				reqToBottom{reqFromTop: fenceFromTop, reqToBottom: fenceToBottom})
+			rsp := mem.FenceRspBuilder{}.
+				WithRspTo(fenceToBottom.ID).
+				Build()
+			bottomPort.EXPECT().Peek().Return(rsp)
+			topPort.EXPECT().Send(gomock.Any()).
+				Do(func(rsp *mem.FenceRsp) {
+					Expect(rsp.RespondTo).To(Equal(fenceFromTop.ID))
+				}).
+				Return(nil)
+			bottomPort.EXPECT().Retrieve(gomock.Any())
+
+			madeProgress := t.respond(10)
+
+			Expect(madeProgress).To(BeTrue())
+			Expect(t.inflightReqToBottom).To(HaveLen(2))
+		})
+
 		It("should stall if TopPort is busy", func() {
 			dataReady := mem.DataReadyRspBuilder{}.
 				WithSendTime(10).
@@ -68,7 +68,7 @@ func (b TranslationReqBuilder) WithDeviceID(deviceID uint64) TranslationReqBuild
 // Build creates a new TranslationReq
 func (b TranslationReqBuilder) Build() *TranslationReq {
 	r := &TranslationReq{}
-	r.ID = sim.GetIDGenerator().Generate()
+	r.ID = sim.IDGeneratorFor(b.src).Generate()
 	r.Src = b.src
 	r.Dst = b.dst
 	r.SendTime = b.sendTime
@@ -139,7 +139,7 @@ func (b TranslationRspBuilder) WithPage(page Page) TranslationRspBuilder {
 // Build creates a new TranslationRsp
 func (b TranslationRspBuilder) Build() *TranslationRsp {
 	r := &TranslationRsp{}
-	r.ID = sim.GetIDGenerator().Generate()
+	r.ID = sim.IDGeneratorFor(b.src).Generate()
 	r.Src = b.src
 	r.Dst = b.dst
 	r.SendTime = b.sendTime
@@ -1,6 +1,9 @@
 package tlb
 
-import "github.com/sarchlab/akita/v3/sim"
+import (
+	"github.com/sarchlab/akita/v3/metrics"
+	"github.com/sarchlab/akita/v3/sim"
+)
 
 // A Builder can build TLBs
 type Builder struct {
@@ -12,6 +15,7 @@ type Builder struct {
 	pageSize       uint64
 	lowModule      sim.Port
 	numMSHREntry   int
+	metrics        *metrics.Registry
 }
 
 // MakeBuilder returns a Builder
@@ -23,6 +27,7 @@ func MakeBuilder() Builder {
 		numWays:        32,
 		pageSize:       4096,
 		numMSHREntry:   4,
+		metrics:        metrics.DefaultRegistry(),
 	}
 }
 
@@ -78,6 +83,13 @@ func (b Builder) WithNumMSHREntry(num int) Builder {
 	return b
 }
 
+// WithMetricsRegistry sets the registry that the metrics of the TLB are
+// registered in. By default, the default registry is used.
+func (b Builder) WithMetricsRegistry(registry *metrics.Registry) Builder {
+	b.metrics = registry
+	return b
+}
+
 // Build creates a new TLB
 func (b Builder) Build(name string) *TLB {
 	tlb := &TLB{}
@@ -92,12 +104,19 @@ func (b Builder) Build(name string) *TLB {
 	tlb.mshr = newMSHR(b.numMSHREntry)
 
 	b.createPorts(name, tlb)
+	b.registerMetrics(name, tlb)
 
 	tlb.reset()
 
 	return tlb
 }
 
+func (b Builder) registerMetrics(name string, tlb *TLB) {
+	tlb.hitCount = b.metrics.Counter(name, "hit")
+	tlb.missCount = b.metrics.Counter(name, "miss")
+	tlb.mshrHitCount = b.metrics.Counter(name, "mshr_hit")
+}
+
 func (b Builder) createPorts(name string, tlb *TLB) {
 	tlb.topPort = sim.NewLimitNumMsgPort(tlb, b.numReqPerCycle,
 		name+".TopPort")
@@ -0,0 +1,63 @@
+package tlb
+
+import (
+	"fmt"
+
+	"github.com/sarchlab/akita/v3/mem/vm/tlb/internal"
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+type checkpointState struct {
+	IsPaused bool
+	Sets     []internal.SetSnapshot
+}
+
+// SaveState saves the pages in the TLB. The TLB can only be saved when it has
+// no translation in flight.
+func (tlb *TLB) SaveState(cp *sim.Checkpoint) error {
+	if len(tlb.mshr.AllEntries()) > 0 || tlb.respondingMSHREntry != nil {
+		return fmt.Errorf("TLB %s has translations in flight", tlb.Name())
+	}
+
+	state := checkpointState{IsPaused: tlb.isPaused}
+	for _, set := range tlb.Sets {
+		snapshot, err := internal.TakeSnapshot(set)
+		if err != nil {
+			return err
+		}
+
+		state.Sets = append(state.Sets, snapshot)
+	}
+
+	err := cp.Put(tlb.Name(), state)
+	if err != nil {
+		return err
+	}
+
+	return tlb.TickScheduler.SaveStateAs(cp, tlb.Name()+".TickScheduler")
+}
+
+// LoadState restores the pages in the TLB.
+func (tlb *TLB) LoadState(cp *sim.Checkpoint) error {
+	state := checkpointState{}
+	err := cp.Get(tlb.Name(), &state)
+	if err != nil {
+		return err
+	}
+
+	if len(state.Sets) != tlb.numSets {
+		return fmt.Errorf("TLB %s does not match the checkpoint", tlb.Name())
+	}
+
+	tlb.isPaused = state.IsPaused
+	tlb.reset()
+
+	for i, snapshot := range state.Sets {
+		err = internal.RestoreSnapshot(tlb.Sets[i], snapshot)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tlb.TickScheduler.LoadStateAs(cp, tlb.Name()+".TickScheduler")
+}
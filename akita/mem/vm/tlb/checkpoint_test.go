@@ -0,0 +1,56 @@
+package tlb
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/akita/v3/mem/vm"
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+var _ = Describe("TLB Checkpoint", func() {
+	var (
+		tlb          *TLB
+		checkpointer *sim.Checkpointer
+	)
+
+	BeforeEach(func() {
+		engine := sim.NewSerialEngine()
+		tlb = MakeBuilder().
+			WithEngine(engine).
+			WithNumSets(1).
+			WithNumWays(2).
+			Build("CheckpointTLB")
+		checkpointer = sim.NewCheckpointer(engine)
+		checkpointer.Register(tlb)
+	})
+
+	It("should restore the pages and the LRU order", func() {
+		page1 := vm.Page{PID: 1, VAddr: 0x1000, PAddr: 0x8000, Valid: true}
+		page2 := vm.Page{PID: 1, VAddr: 0x2000, PAddr: 0x9000, Valid: true}
+		tlb.WarmUp(page1)
+		tlb.WarmUp(page2)
+
+		buf := bytes.NewBuffer(nil)
+		Expect(checkpointer.Save(buf)).To(Succeed())
+
+		tlb.reset()
+		Expect(checkpointer.Restore(buf)).To(Succeed())
+
+		_, page, found := tlb.Sets[0].Lookup(1, 0x2000)
+		Expect(found).To(BeTrue())
+		Expect(page).To(Equal(page2))
+
+		wayID, _, _ := tlb.Sets[0].Lookup(1, 0x1000)
+		victim, ok := tlb.Sets[0].Evict()
+		Expect(ok).To(BeTrue())
+		Expect(victim).To(Equal(wayID))
+	})
+
+	It("should not save a translation in flight", func() {
+		tlb.mshr.Add(1, 0x1000)
+
+		Expect(checkpointer.Save(bytes.NewBuffer(nil))).NotTo(Succeed())
+	})
+})
@@ -114,3 +114,64 @@ func (s *setImpl) Visit(wayID int) {
 func (s *setImpl) hasNothingToEvict() bool {
 	return len(s.visitList) == 0
 }
+
+// A SetSnapshot is the content of a set that is saved in a checkpoint.
+type SetSnapshot struct {
+	Pages []vm.Page
+
+	// VisitList lists the ways that can be evicted, from the least recently
+	// visited to the most recently visited.
+	VisitList []int
+}
+
+// TakeSnapshot returns the content of the set.
+func TakeSnapshot(s Set) (SetSnapshot, error) {
+	impl, ok := s.(*setImpl)
+	if !ok {
+		return SetSnapshot{}, fmt.Errorf("set %T cannot be checkpointed", s)
+	}
+
+	snapshot := SetSnapshot{}
+	for _, b := range impl.blocks {
+		snapshot.Pages = append(snapshot.Pages, b.page)
+	}
+
+	for _, b := range impl.visitList {
+		snapshot.VisitList = append(snapshot.VisitList, b.wayID)
+	}
+
+	return snapshot, nil
+}
+
+// RestoreSnapshot sets the content of the set to the snapshot.
+func RestoreSnapshot(s Set, snapshot SetSnapshot) error {
+	impl, ok := s.(*setImpl)
+	if !ok {
+		return fmt.Errorf("set %T cannot be checkpointed", s)
+	}
+
+	if len(snapshot.Pages) != len(impl.blocks) {
+		return fmt.Errorf("the set has %d ways, but the snapshot has %d",
+			len(impl.blocks), len(snapshot.Pages))
+	}
+
+	impl.vAddrWayIDMap = make(map[string]int)
+	for i, page := range snapshot.Pages {
+		impl.blocks[i].page = page
+		impl.blocks[i].lastVisit = 0
+
+		if page != (vm.Page{}) {
+			impl.vAddrWayIDMap[impl.keyString(page.PID, page.VAddr)] = i
+		}
+	}
+
+	impl.visitCount = 0
+	impl.visitList = impl.visitList[:0]
+	for _, wayID := range snapshot.VisitList {
+		impl.visitCount++
+		impl.blocks[wayID].lastVisit = impl.visitCount
+		impl.visitList = append(impl.visitList, impl.blocks[wayID])
+	}
+
+	return nil
+}
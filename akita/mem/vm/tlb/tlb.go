@@ -6,6 +6,7 @@ import (
 
 	"github.com/sarchlab/akita/v3/mem/vm"
 	"github.com/sarchlab/akita/v3/mem/vm/tlb/internal"
+	"github.com/sarchlab/akita/v3/metrics"
 	"github.com/sarchlab/akita/v3/sim"
 	"github.com/sarchlab/akita/v3/tracing"
 )
@@ -32,6 +33,10 @@ type TLB struct {
 	respondingMSHREntry *mshrEntry
 
 	isPaused bool
+
+	hitCount     *metrics.Counter
+	missCount    *metrics.Counter
+	mshrHitCount *metrics.Counter
 }
 
 // Reset sets all the entries int he TLB to be invalid
@@ -43,6 +48,25 @@ func (tlb *TLB) reset() {
 	}
 }
 
+// WarmUp functionally inserts a page into the TLB, as if the page has been
+// translated, without sending any message or spending any time.
+func (tlb *TLB) WarmUp(page vm.Page) {
+	set := tlb.Sets[tlb.vAddrToSetID(page.VAddr)]
+
+	wayID, _, found := set.Lookup(page.PID, page.VAddr)
+	if !found {
+		var ok bool
+		wayID, ok = set.Evict()
+		if !ok {
+			return
+		}
+
+		set.Update(wayID, page)
+	}
+
+	set.Visit(wayID)
+}
+
 // Tick defines how TLB update states at each cycle
 func (tlb *TLB) Tick(now sim.VTimeInSec) bool {
 	madeProgress := false
@@ -135,6 +159,7 @@ func (tlb *TLB) handleTranslationHit(
 	tracing.TraceReqReceive(req, tlb)
 	tracing.AddTaskStep(tracing.MsgIDAtReceiver(req, tlb), tlb, "hit")
 	tracing.TraceReqComplete(req, tlb)
+	tlb.hitCount.Inc()
 
 	return true
 }
@@ -152,6 +177,7 @@ func (tlb *TLB) handleTranslationMiss(
 		tlb.topPort.Retrieve(now)
 		tracing.TraceReqReceive(req, tlb)
 		tracing.AddTaskStep(tracing.MsgIDAtReceiver(req, tlb), tlb, "miss")
+		tlb.missCount.Inc()
 		return true
 	}
 
@@ -190,6 +216,7 @@ func (tlb *TLB) processTLBMSHRHit(
 	tlb.topPort.Retrieve(now)
 	tracing.TraceReqReceive(req, tlb)
 	tracing.AddTaskStep(tracing.MsgIDAtReceiver(req, tlb), tlb, "mshr-hit")
+	tlb.mshrHitCount.Inc()
 
 	return true
 }
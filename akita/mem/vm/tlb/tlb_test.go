@@ -40,6 +40,37 @@ var _ = Describe("TLB", func() {
 		mockCtrl.Finish()
 	})
 
+	Context("warm up", func() {
+		var page vm.Page
+
+		BeforeEach(func() {
+			page = vm.Page{
+				PID:   1,
+				VAddr: 0x100,
+				PAddr: 0x200,
+				Valid: true,
+			}
+		})
+
+		It("should visit the page if the page is in the TLB", func() {
+			set.EXPECT().Lookup(vm.PID(1), uint64(0x100)).
+				Return(1, page, true)
+			set.EXPECT().Visit(1)
+
+			tlb.WarmUp(page)
+		})
+
+		It("should insert the page if the page is not in the TLB", func() {
+			set.EXPECT().Lookup(vm.PID(1), uint64(0x100)).
+				Return(0, vm.Page{}, false)
+			set.EXPECT().Evict().Return(2, true)
+			set.EXPECT().Update(2, page)
+			set.EXPECT().Visit(2)
+
+			tlb.WarmUp(page)
+		})
+	})
+
 	It("should do nothing if there is no req in TopPort", func() {
 		topPort.EXPECT().Peek().Return(nil)
 
@@ -60,7 +60,7 @@ func (b FlushReqBuilder) WithPID(pid vm.PID) FlushReqBuilder {
 // Build creates a new TLBFlushReq
 func (b FlushReqBuilder) Build() *FlushReq {
 	r := &FlushReq{}
-	r.ID = sim.GetIDGenerator().Generate()
+	r.ID = sim.IDGeneratorFor(b.src).Generate()
 	r.Src = b.src
 	r.Dst = b.dst
 	r.SendTime = b.sendTime
@@ -108,7 +108,7 @@ func (b FlushRspBuilder) WithDst(dst sim.Port) FlushRspBuilder {
 // Build creates a new TLBFlushRsps.
 func (b FlushRspBuilder) Build() *FlushRsp {
 	r := &FlushRsp{}
-	r.ID = sim.GetIDGenerator().Generate()
+	r.ID = sim.IDGeneratorFor(b.src).Generate()
 	r.Src = b.src
 	r.Dst = b.dst
 	r.SendTime = b.sendTime
@@ -155,7 +155,7 @@ func (b RestartReqBuilder) WithDst(dst sim.Port) RestartReqBuilder {
 // Build creates a new TLBRestartReq.
 func (b RestartReqBuilder) Build() *RestartReq {
 	r := &RestartReq{}
-	r.ID = sim.GetIDGenerator().Generate()
+	r.ID = sim.IDGeneratorFor(b.src).Generate()
 	r.Src = b.src
 	r.Dst = b.dst
 	r.SendTime = b.sendTime
@@ -202,7 +202,7 @@ func (b RestartRspBuilder) WithDst(dst sim.Port) RestartRspBuilder {
 // Build creates a new TLBRestartRsp
 func (b RestartRspBuilder) Build() *RestartRsp {
 	r := &RestartRsp{}
-	r.ID = sim.GetIDGenerator().Generate()
+	r.ID = sim.IDGeneratorFor(b.src).Generate()
 	r.Src = b.src
 	r.Dst = b.dst
 	r.SendTime = b.sendTime
@@ -0,0 +1,110 @@
+package metrics
+
+import (
+	"fmt"
+
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+type metricState struct {
+	Where       string
+	What        string
+	Kind        Kind
+	Value       float64
+	UpperBounds []float64
+	Counts      []uint64
+	Count       uint64
+	Sum         float64
+}
+
+// SaveStateAs saves the values of all the metrics in the registry in the
+// checkpoint under the key.
+func (r *Registry) SaveStateAs(cp *sim.Checkpoint, key string) error {
+	state := []metricState{}
+
+	for _, m := range r.Metrics() {
+		s := metricState{Where: m.Where(), What: m.What(), Kind: m.Kind()}
+
+		switch m := m.(type) {
+		case *Counter:
+			s.Value = m.Value()
+		case *Gauge:
+			s.Value = m.Value()
+		case *Histogram:
+			m.lock.Lock()
+			s.UpperBounds = m.upperBounds
+			s.Counts = append([]uint64(nil), m.counts...)
+			s.Count = m.count
+			s.Sum = m.sum
+			m.lock.Unlock()
+		}
+
+		state = append(state, s)
+	}
+
+	return cp.Put(key, state)
+}
+
+// LoadStateAs restores the values of the metrics from the checkpoint. The
+// metrics that are not in the checkpoint are reset, and the metrics that are
+// only in the checkpoint are created.
+func (r *Registry) LoadStateAs(cp *sim.Checkpoint, key string) error {
+	state := []metricState{}
+	err := cp.Get(key, &state)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range r.Metrics() {
+		resetMetric(m)
+	}
+
+	for _, s := range state {
+		err = r.loadMetric(s)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Registry) loadMetric(s metricState) error {
+	switch s.Kind {
+	case KindCounter:
+		r.Counter(s.Where, s.What).value.set(s.Value)
+	case KindGauge:
+		r.Gauge(s.Where, s.What).Set(s.Value)
+	case KindHistogram:
+		h := r.Histogram(s.Where, s.What, s.UpperBounds)
+		if len(h.counts) != len(s.Counts) {
+			return fmt.Errorf("histogram %s of %s does not match the checkpoint",
+				s.What, s.Where)
+		}
+
+		h.lock.Lock()
+		copy(h.counts, s.Counts)
+		h.count = s.Count
+		h.sum = s.Sum
+		h.lock.Unlock()
+	}
+
+	return nil
+}
+
+func resetMetric(m Metric) {
+	switch m := m.(type) {
+	case *Counter:
+		m.value.set(0)
+	case *Gauge:
+		m.value.set(0)
+	case *Histogram:
+		m.lock.Lock()
+		for i := range m.counts {
+			m.counts[i] = 0
+		}
+		m.count = 0
+		m.sum = 0
+		m.lock.Unlock()
+	}
+}
@@ -0,0 +1,86 @@
+package metrics
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+type registryHolder struct {
+	registry *Registry
+}
+
+func (h *registryHolder) Name() string {
+	return "Holder"
+}
+
+func (h *registryHolder) SaveState(cp *sim.Checkpoint) error {
+	return h.registry.SaveStateAs(cp, "Holder.Metrics")
+}
+
+func (h *registryHolder) LoadState(cp *sim.Checkpoint) error {
+	return h.registry.LoadStateAs(cp, "Holder.Metrics")
+}
+
+var _ = Describe("Registry Checkpoint", func() {
+	var (
+		r            *Registry
+		checkpointer *sim.Checkpointer
+	)
+
+	BeforeEach(func() {
+		r = NewRegistry()
+		checkpointer = sim.NewCheckpointer(sim.NewSerialEngine())
+		checkpointer.Register(&registryHolder{registry: r})
+	})
+
+	It("should restore the values of the metrics", func() {
+		c := r.Counter("Cache", "hit_count")
+		g := r.Gauge("Cache", "occupancy")
+		h := r.Histogram("Cache", "latency", []float64{1, 2})
+		c.Add(3)
+		g.Set(0.5)
+		h.Observe(1.5)
+
+		buf := bytes.NewBuffer(nil)
+		Expect(checkpointer.Save(buf)).To(Succeed())
+
+		c.Inc()
+		g.Set(1)
+		h.Observe(3)
+		Expect(checkpointer.Restore(buf)).To(Succeed())
+
+		Expect(c.Value()).To(Equal(3.0))
+		Expect(g.Value()).To(Equal(0.5))
+		Expect(h.Count()).To(Equal(uint64(1)))
+		Expect(h.Sum()).To(Equal(1.5))
+		Expect(h.CumulativeCounts()).To(Equal([]uint64{0, 1, 1}))
+	})
+
+	It("should reset the metrics that are not in the checkpoint", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(checkpointer.Save(buf)).To(Succeed())
+
+		c := r.Counter("Cache", "hit_count")
+		c.Add(3)
+		Expect(checkpointer.Restore(buf)).To(Succeed())
+
+		Expect(c.Value()).To(Equal(0.0))
+	})
+
+	It("should create the metrics that are only in the checkpoint", func() {
+		r.Counter("Cache", "hit_count").Add(3)
+
+		buf := bytes.NewBuffer(nil)
+		Expect(checkpointer.Save(buf)).To(Succeed())
+
+		restored := NewRegistry()
+		checkpointer = sim.NewCheckpointer(sim.NewSerialEngine())
+		checkpointer.Register(&registryHolder{registry: restored})
+		Expect(checkpointer.Restore(buf)).To(Succeed())
+
+		Expect(restored.Counter("Cache", "hit_count").Value()).To(Equal(3.0))
+	})
+})
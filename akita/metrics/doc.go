@@ -0,0 +1,12 @@
+// Package metrics provides counters, gauges, and histograms that components
+// can register to report statistics.
+//
+// A component creates a metric with one line, for example,
+//
+//	c.hitCount = metrics.NewCounter(c.Name(), "hit_count")
+//
+// and updates it with c.hitCount.Inc(). The metrics are kept in a Registry.
+// A Sampler records the values of all the metrics periodically in virtual
+// time and sends them to Exporters, such as CSV files and SQLite databases.
+// The current values can also be written in the Prometheus text format.
+package metrics
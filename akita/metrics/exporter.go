@@ -0,0 +1,159 @@
+package metrics
+
+import (
+	"bufio"
+	"database/sql"
+	"fmt"
+	"os"
+
+	// Need to use SQLite connections.
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+// CSVExporter writes the samples to a CSV file.
+type CSVExporter struct {
+	file   *os.File
+	writer *bufio.Writer
+}
+
+// NewCSVExporter creates a CSV file with the given name. The extension name
+// is not required. If the file already exists, it is overwritten.
+func NewCSVExporter(filename string) *CSVExporter {
+	file, err := os.Create(filename + ".csv")
+	if err != nil {
+		panic(err)
+	}
+
+	e := &CSVExporter{
+		file:   file,
+		writer: bufio.NewWriter(file),
+	}
+
+	fmt.Fprintf(e.writer, "time, where, what, value\n")
+
+	return e
+}
+
+// Export writes the samples to the CSV file.
+func (e *CSVExporter) Export(time sim.VTimeInSec, samples []Sample) {
+	for _, s := range samples {
+		fmt.Fprintf(e.writer, "%.12f, %s, %s, %.12f\n",
+			time, s.Where, s.What, s.Value)
+	}
+}
+
+// Flush writes the buffered samples to the CSV file.
+func (e *CSVExporter) Flush() {
+	err := e.writer.Flush()
+	if err != nil {
+		panic(err)
+	}
+}
+
+// SQLiteExporter writes the samples to a SQLite database.
+type SQLiteExporter struct {
+	*sql.DB
+
+	batchSize int
+	samples   []timedSample
+}
+
+type timedSample struct {
+	time sim.VTimeInSec
+	Sample
+}
+
+// NewSQLiteExporter creates a SQLite database with the given name. The
+// extension name is not required. If the file already exists, it is
+// overwritten.
+func NewSQLiteExporter(filename string) *SQLiteExporter {
+	filename += ".sqlite3"
+
+	_, err := os.Stat(filename)
+	if err == nil {
+		err = os.Remove(filename)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	db, err := sql.Open("sqlite3", filename)
+	if err != nil {
+		panic(err)
+	}
+
+	e := &SQLiteExporter{
+		DB:        db,
+		batchSize: 100000,
+	}
+
+	e.mustExecute(`
+		CREATE TABLE metrics
+		(
+			time     REAL,
+			location TEXT,
+			what     TEXT,
+			value    REAL
+		);
+	`)
+
+	e.mustExecute(`
+		CREATE INDEX metrics_location_what_index
+			ON metrics (location, what, time);
+	`)
+
+	return e
+}
+
+// Export buffers the samples and writes them when the buffer is full.
+func (e *SQLiteExporter) Export(time sim.VTimeInSec, samples []Sample) {
+	for _, s := range samples {
+		e.samples = append(e.samples, timedSample{time: time, Sample: s})
+	}
+
+	if len(e.samples) >= e.batchSize {
+		e.Flush()
+	}
+}
+
+// Flush writes the buffered samples to the database.
+func (e *SQLiteExporter) Flush() {
+	if len(e.samples) == 0 {
+		return
+	}
+
+	tx, err := e.Begin()
+	if err != nil {
+		panic(err)
+	}
+
+	stmt, err := tx.Prepare(
+		"INSERT INTO metrics (time, location, what, value) VALUES (?, ?, ?, ?)")
+	if err != nil {
+		panic(err)
+	}
+
+	for _, s := range e.samples {
+		_, err = stmt.Exec(float64(s.time), s.Where, s.What, s.Value)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		panic(err)
+	}
+
+	e.samples = e.samples[:0]
+}
+
+func (e *SQLiteExporter) mustExecute(query string) {
+	_, err := e.Exec(query)
+	if err != nil {
+		fmt.Printf("Failed to execute: %s\n", query)
+		panic(err)
+	}
+}
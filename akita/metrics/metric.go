@@ -0,0 +1,307 @@
+package metrics
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// Kind is the type of a metric.
+type Kind int
+
+// A list of all the kinds of metrics.
+const (
+	KindCounter Kind = iota
+	KindGauge
+	KindHistogram
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindCounter:
+		return "counter"
+	case KindGauge:
+		return "gauge"
+	case KindHistogram:
+		return "histogram"
+	default:
+		panic("unknown metric kind")
+	}
+}
+
+// A Metric is a statistic of a component.
+type Metric interface {
+	// Where returns the name of the component that owns the metric.
+	Where() string
+
+	// What returns the name of the metric.
+	What() string
+
+	// Kind returns the type of the metric.
+	Kind() Kind
+
+	// Samples returns the current values of the metric. Counters and gauges
+	// have one sample, while histograms have one sample per bucket, plus the
+	// count and the sum of the observed values.
+	Samples() []Sample
+}
+
+// A Sample is a value of a metric.
+type Sample struct {
+	Where string  `json:"where"`
+	What  string  `json:"what"`
+	Value float64 `json:"value"`
+}
+
+type metricBase struct {
+	where, what string
+}
+
+// Where returns the name of the component that owns the metric.
+func (m metricBase) Where() string {
+	return m.where
+}
+
+// What returns the name of the metric.
+func (m metricBase) What() string {
+	return m.what
+}
+
+// atomicFloat is a float64 that can be updated from multiple goroutines.
+type atomicFloat struct {
+	bits uint64
+}
+
+func (f *atomicFloat) add(v float64) {
+	for {
+		oldBits := atomic.LoadUint64(&f.bits)
+		newBits := math.Float64bits(math.Float64frombits(oldBits) + v)
+
+		if atomic.CompareAndSwapUint64(&f.bits, oldBits, newBits) {
+			return
+		}
+	}
+}
+
+func (f *atomicFloat) set(v float64) {
+	atomic.StoreUint64(&f.bits, math.Float64bits(v))
+}
+
+func (f *atomicFloat) load() float64 {
+	return math.Float64frombits(atomic.LoadUint64(&f.bits))
+}
+
+// A Counter is a metric that only increases, such as the number of requests
+// that are handled.
+type Counter struct {
+	metricBase
+
+	value atomicFloat
+}
+
+// Kind returns KindCounter.
+func (c *Counter) Kind() Kind {
+	return KindCounter
+}
+
+// Inc increases the counter by 1.
+func (c *Counter) Inc() {
+	c.value.add(1)
+}
+
+// Add increases the counter by the given value, which must not be negative.
+func (c *Counter) Add(v float64) {
+	if v < 0 {
+		panic("counter cannot decrease")
+	}
+
+	c.value.add(v)
+}
+
+// Value returns the current value of the counter.
+func (c *Counter) Value() float64 {
+	return c.value.load()
+}
+
+// Samples returns the current value of the counter.
+func (c *Counter) Samples() []Sample {
+	return []Sample{{Where: c.where, What: c.what, Value: c.Value()}}
+}
+
+// A Gauge is a metric that can go up and down, such as the number of
+// in-flight requests.
+type Gauge struct {
+	metricBase
+
+	value atomicFloat
+}
+
+// Kind returns KindGauge.
+func (g *Gauge) Kind() Kind {
+	return KindGauge
+}
+
+// Set sets the value of the gauge.
+func (g *Gauge) Set(v float64) {
+	g.value.set(v)
+}
+
+// Add adds the given value, which can be negative, to the gauge.
+func (g *Gauge) Add(v float64) {
+	g.value.add(v)
+}
+
+// Value returns the current value of the gauge.
+func (g *Gauge) Value() float64 {
+	return g.value.load()
+}
+
+// Samples returns the current value of the gauge.
+func (g *Gauge) Samples() []Sample {
+	return []Sample{{Where: g.where, What: g.what, Value: g.Value()}}
+}
+
+// A Histogram counts the observed values in buckets, such as the latency of
+// requests.
+type Histogram struct {
+	metricBase
+
+	lock sync.Mutex
+
+	// upperBounds are the inclusive upper bounds of the buckets. There is an
+	// extra bucket for the values that are larger than all the bounds.
+	upperBounds []float64
+	counts      []uint64
+	count       uint64
+	sum         float64
+}
+
+// Kind returns KindHistogram.
+func (h *Histogram) Kind() Kind {
+	return KindHistogram
+}
+
+// Observe adds a value to the histogram.
+func (h *Histogram) Observe(v float64) {
+	i := sort.SearchFloat64s(h.upperBounds, v)
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.counts[i]++
+	h.count++
+	h.sum += v
+}
+
+// Count returns the number of observed values.
+func (h *Histogram) Count() uint64 {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	return h.count
+}
+
+// Sum returns the sum of the observed values.
+func (h *Histogram) Sum() float64 {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	return h.sum
+}
+
+// Mean returns the average of the observed values. It returns 0 if no value
+// is observed.
+func (h *Histogram) Mean() float64 {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if h.count == 0 {
+		return 0
+	}
+
+	return h.sum / float64(h.count)
+}
+
+// UpperBounds returns the upper bounds of the buckets.
+func (h *Histogram) UpperBounds() []float64 {
+	return h.upperBounds
+}
+
+// CumulativeCounts returns the number of observed values that are less than
+// or equal to each upper bound. The last element is the count of all values.
+func (h *Histogram) CumulativeCounts() []uint64 {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	counts := make([]uint64, len(h.counts))
+
+	var total uint64
+	for i, c := range h.counts {
+		total += c
+		counts[i] = total
+	}
+
+	return counts
+}
+
+// Samples returns the count, the sum, and the cumulative counts of the
+// buckets. The bucket samples are named as "<what>_bucket_le_<bound>".
+func (h *Histogram) Samples() []Sample {
+	counts := h.CumulativeCounts()
+
+	h.lock.Lock()
+	samples := []Sample{
+		{Where: h.where, What: h.what + "_count", Value: float64(h.count)},
+		{Where: h.where, What: h.what + "_sum", Value: h.sum},
+	}
+	h.lock.Unlock()
+
+	for i, bound := range h.upperBounds {
+		samples = append(samples, Sample{
+			Where: h.where,
+			What:  h.what + "_bucket_le_" + formatBound(bound),
+			Value: float64(counts[i]),
+		})
+	}
+
+	samples = append(samples, Sample{
+		Where: h.where,
+		What:  h.what + "_bucket_le_" + formatBound(math.Inf(1)),
+		Value: float64(counts[len(counts)-1]),
+	})
+
+	return samples
+}
+
+// ExponentialBuckets returns n upper bounds that start from start and are
+// multiplied by factor each time.
+func ExponentialBuckets(start, factor float64, n int) []float64 {
+	if start <= 0 || factor <= 1 || n < 1 {
+		panic("invalid exponential buckets")
+	}
+
+	bounds := make([]float64, n)
+	bound := start
+	for i := range bounds {
+		bounds[i] = bound
+		bound *= factor
+	}
+
+	return bounds
+}
+
+// LinearBuckets returns n upper bounds that start from start and increase by
+// width each time.
+func LinearBuckets(start, width float64, n int) []float64 {
+	if width <= 0 || n < 1 {
+		panic("invalid linear buckets")
+	}
+
+	bounds := make([]float64, n)
+	for i := range bounds {
+		bounds[i] = start + float64(i)*width
+	}
+
+	return bounds
+}
@@ -0,0 +1,96 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// WritePrometheus writes the current values of all the metrics in the
+// registry in the Prometheus text format. Each metric is named as
+// "akita_<what>" and the component is given in the "where" label.
+func WritePrometheus(w io.Writer, r *Registry) {
+	metrics := r.Metrics()
+
+	byName := make(map[string][]Metric)
+	var names []string
+	for _, m := range metrics {
+		name := prometheusName(m.What())
+		if _, found := byName[name]; !found {
+			names = append(names, name)
+		}
+
+		byName[name] = append(byName[name], m)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		group := byName[name]
+		fmt.Fprintf(w, "# TYPE %s %s\n", name, group[0].Kind())
+
+		for _, m := range group {
+			writePrometheusMetric(w, name, m)
+		}
+	}
+}
+
+func writePrometheusMetric(w io.Writer, name string, m Metric) {
+	where := prometheusLabelValue(m.Where())
+
+	switch m := m.(type) {
+	case *Counter:
+		fmt.Fprintf(w, "%s{where=\"%s\"} %g\n", name, where, m.Value())
+	case *Gauge:
+		fmt.Fprintf(w, "%s{where=\"%s\"} %g\n", name, where, m.Value())
+	case *Histogram:
+		counts := m.CumulativeCounts()
+		for i, bound := range m.UpperBounds() {
+			fmt.Fprintf(w, "%s_bucket{where=\"%s\",le=\"%s\"} %d\n",
+				name, where, formatBound(bound), counts[i])
+		}
+
+		fmt.Fprintf(w, "%s_bucket{where=\"%s\",le=\"+Inf\"} %d\n",
+			name, where, counts[len(counts)-1])
+		fmt.Fprintf(w, "%s_sum{where=\"%s\"} %g\n", name, where, m.Sum())
+		fmt.Fprintf(w, "%s_count{where=\"%s\"} %d\n", name, where, m.Count())
+	}
+}
+
+// prometheusName converts the name of a metric to a valid Prometheus metric
+// name by replacing invalid characters with underscores.
+func prometheusName(what string) string {
+	var b strings.Builder
+	b.WriteString("akita_")
+
+	for _, c := range what {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9',
+			c == '_', c == ':':
+			b.WriteRune(c)
+		default:
+			b.WriteRune('_')
+		}
+	}
+
+	return b.String()
+}
+
+func prometheusLabelValue(v string) string {
+	v = strings.ReplaceAll(v, `\`, `\\`)
+	v = strings.ReplaceAll(v, `"`, `\"`)
+	v = strings.ReplaceAll(v, "\n", `\n`)
+
+	return v
+}
+
+// PrometheusHandler returns an HTTP handler that serves the metrics in the
+// registry in the Prometheus text format.
+func PrometheusHandler(r *Registry) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		WritePrometheus(w, r)
+	})
+}
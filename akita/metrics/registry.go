@@ -0,0 +1,167 @@
+package metrics
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// DefaultLatencyBuckets are the histogram buckets that cover latencies from
+// 1 ns to about 1 ms in seconds.
+var DefaultLatencyBuckets = ExponentialBuckets(1e-9, 2, 21)
+
+var defaultRegistry = NewRegistry()
+
+// DefaultRegistry returns the registry that is used by NewCounter, NewGauge,
+// and NewHistogram.
+func DefaultRegistry() *Registry {
+	return defaultRegistry
+}
+
+// NewCounter returns the counter of the given component and name in the
+// default registry.
+func NewCounter(where, what string) *Counter {
+	return defaultRegistry.Counter(where, what)
+}
+
+// NewGauge returns the gauge of the given component and name in the default
+// registry.
+func NewGauge(where, what string) *Gauge {
+	return defaultRegistry.Gauge(where, what)
+}
+
+// NewHistogram returns the histogram of the given component and name in the
+// default registry.
+func NewHistogram(where, what string, upperBounds []float64) *Histogram {
+	return defaultRegistry.Histogram(where, what, upperBounds)
+}
+
+type metricKey struct {
+	where, what string
+}
+
+// A Registry keeps the metrics of a simulation. A metric is identified by
+// the name of the component (where) and the name of the metric (what).
+type Registry struct {
+	lock    sync.Mutex
+	metrics map[metricKey]Metric
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		metrics: make(map[metricKey]Metric),
+	}
+}
+
+// Counter returns the counter with the given component and name. The
+// counter is created if it does not exist.
+func (r *Registry) Counter(where, what string) *Counter {
+	m := r.getOrCreate(where, what, KindCounter, func() Metric {
+		return &Counter{metricBase: metricBase{where: where, what: what}}
+	})
+
+	return m.(*Counter)
+}
+
+// Gauge returns the gauge with the given component and name. The gauge is
+// created if it does not exist.
+func (r *Registry) Gauge(where, what string) *Gauge {
+	m := r.getOrCreate(where, what, KindGauge, func() Metric {
+		return &Gauge{metricBase: metricBase{where: where, what: what}}
+	})
+
+	return m.(*Gauge)
+}
+
+// Histogram returns the histogram with the given component and name. The
+// histogram is created with the upper bounds of the buckets if it does not
+// exist.
+func (r *Registry) Histogram(
+	where, what string,
+	upperBounds []float64,
+) *Histogram {
+	if !sort.Float64sAreSorted(upperBounds) {
+		panic("histogram bucket bounds must be sorted")
+	}
+
+	m := r.getOrCreate(where, what, KindHistogram, func() Metric {
+		return &Histogram{
+			metricBase:  metricBase{where: where, what: what},
+			upperBounds: upperBounds,
+			counts:      make([]uint64, len(upperBounds)+1),
+		}
+	})
+
+	return m.(*Histogram)
+}
+
+func (r *Registry) getOrCreate(
+	where, what string,
+	kind Kind,
+	create func() Metric,
+) Metric {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	key := metricKey{where: where, what: what}
+
+	m, found := r.metrics[key]
+	if !found {
+		m = create()
+		r.metrics[key] = m
+	}
+
+	if m.Kind() != kind {
+		panic(fmt.Sprintf("metric %s of %s is a %s, not a %s",
+			what, where, m.Kind(), kind))
+	}
+
+	return m
+}
+
+// Lookup returns the metric with the given component and name, if it
+// exists.
+func (r *Registry) Lookup(where, what string) (Metric, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	m, found := r.metrics[metricKey{where: where, what: what}]
+
+	return m, found
+}
+
+// Metrics returns all the metrics, sorted by the component and the name.
+func (r *Registry) Metrics() []Metric {
+	r.lock.Lock()
+	metrics := make([]Metric, 0, len(r.metrics))
+	for _, m := range r.metrics {
+		metrics = append(metrics, m)
+	}
+	r.lock.Unlock()
+
+	sort.Slice(metrics, func(i, j int) bool {
+		if metrics[i].Where() != metrics[j].Where() {
+			return metrics[i].Where() < metrics[j].Where()
+		}
+
+		return metrics[i].What() < metrics[j].What()
+	})
+
+	return metrics
+}
+
+// Samples returns the current values of all the metrics.
+func (r *Registry) Samples() []Sample {
+	var samples []Sample
+	for _, m := range r.Metrics() {
+		samples = append(samples, m.Samples()...)
+	}
+
+	return samples
+}
+
+func formatBound(bound float64) string {
+	return strconv.FormatFloat(bound, 'g', -1, 64)
+}
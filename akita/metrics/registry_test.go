@@ -0,0 +1,101 @@
+package metrics
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Registry", func() {
+	var r *Registry
+
+	BeforeEach(func() {
+		r = NewRegistry()
+	})
+
+	It("should return the same counter for the same name", func() {
+		c := r.Counter("Cache", "hit_count")
+		c.Inc()
+		c.Add(2)
+
+		Expect(r.Counter("Cache", "hit_count")).To(BeIdenticalTo(c))
+		Expect(c.Value()).To(Equal(3.0))
+	})
+
+	It("should not allow counters to decrease", func() {
+		c := r.Counter("Cache", "hit_count")
+
+		Expect(func() { c.Add(-1) }).To(Panic())
+	})
+
+	It("should panic if a name is used by another kind of metric", func() {
+		r.Counter("Cache", "hit_count")
+
+		Expect(func() { r.Gauge("Cache", "hit_count") }).To(Panic())
+	})
+
+	It("should look up metrics", func() {
+		c := r.Counter("Cache", "hit_count")
+
+		m, found := r.Lookup("Cache", "hit_count")
+		Expect(found).To(BeTrue())
+		Expect(m).To(BeIdenticalTo(c))
+
+		_, found = r.Lookup("Cache", "miss_count")
+		Expect(found).To(BeFalse())
+	})
+
+	It("should update gauges", func() {
+		g := r.Gauge("Cache", "mshr_entries")
+		g.Set(3)
+		g.Add(-1)
+
+		Expect(g.Value()).To(Equal(2.0))
+	})
+
+	It("should observe values in histograms", func() {
+		h := r.Histogram("DRAM", "latency", []float64{1, 2, 4})
+		h.Observe(0.5)
+		h.Observe(2)
+		h.Observe(3)
+		h.Observe(10)
+
+		Expect(h.Count()).To(Equal(uint64(4)))
+		Expect(h.Sum()).To(Equal(15.5))
+		Expect(h.Mean()).To(Equal(3.875))
+		Expect(h.CumulativeCounts()).To(Equal([]uint64{1, 2, 3, 4}))
+	})
+
+	It("should list samples sorted by component and name", func() {
+		r.Counter("B", "x").Inc()
+		r.Gauge("A", "y").Set(2)
+		r.Histogram("A", "z", []float64{1}).Observe(0.5)
+
+		Expect(r.Samples()).To(Equal([]Sample{
+			{Where: "A", What: "y", Value: 2},
+			{Where: "A", What: "z_count", Value: 1},
+			{Where: "A", What: "z_sum", Value: 0.5},
+			{Where: "A", What: "z_bucket_le_1", Value: 1},
+			{Where: "A", What: "z_bucket_le_+Inf", Value: 1},
+			{Where: "B", What: "x", Value: 1},
+		}))
+	})
+
+	It("should write the Prometheus text format", func() {
+		r.Counter("GPU[1].L2", "read-hit").Add(5)
+		r.Histogram("DRAM", "latency", []float64{1}).Observe(2)
+
+		buf := bytes.NewBuffer(nil)
+		WritePrometheus(buf, r)
+
+		Expect(buf.String()).To(Equal(
+			"# TYPE akita_latency histogram\n" +
+				"akita_latency_bucket{where=\"DRAM\",le=\"1\"} 0\n" +
+				"akita_latency_bucket{where=\"DRAM\",le=\"+Inf\"} 1\n" +
+				"akita_latency_sum{where=\"DRAM\"} 2\n" +
+				"akita_latency_count{where=\"DRAM\"} 1\n" +
+				"# TYPE akita_read_hit counter\n" +
+				"akita_read_hit{where=\"GPU[1].L2\"} 5\n"))
+	})
+})
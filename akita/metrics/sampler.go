@@ -0,0 +1,145 @@
+package metrics
+
+import (
+	"sync"
+
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/tebeka/atexit"
+)
+
+// An Exporter stores the samples of the metrics.
+type Exporter interface {
+	// Export stores the samples that are taken at the given time.
+	Export(time sim.VTimeInSec, samples []Sample)
+
+	// Flush writes the buffered samples to the storage.
+	Flush()
+}
+
+// A Sampler takes samples of all the metrics in a registry periodically in
+// virtual time. It is an engine hook. Before the engine handles an event, the
+// sampler takes the samples of all the sampling points that are not later
+// than the event. So, the samples at a time do not include the changes that
+// are made by the events at that time.
+type Sampler struct {
+	lock sync.Mutex
+
+	timeTeller  sim.TimeTeller
+	registry    *Registry
+	period      sim.VTimeInSec
+	nextTime    sim.VTimeInSec
+	lastTime    sim.VTimeInSec
+	hasLastTime bool
+	exporters   []Exporter
+}
+
+// SamplerBuilder can build samplers.
+type SamplerBuilder struct {
+	timeTeller sim.TimeTeller
+	registry   *Registry
+	period     sim.VTimeInSec
+	exporters  []Exporter
+}
+
+// MakeSamplerBuilder creates a SamplerBuilder with the default registry.
+func MakeSamplerBuilder() SamplerBuilder {
+	return SamplerBuilder{
+		registry: defaultRegistry,
+	}
+}
+
+// WithTimeTeller sets the time teller that tells the time of the final
+// sample.
+func (b SamplerBuilder) WithTimeTeller(t sim.TimeTeller) SamplerBuilder {
+	b.timeTeller = t
+	return b
+}
+
+// WithRegistry sets the registry that contains the metrics to sample.
+func (b SamplerBuilder) WithRegistry(r *Registry) SamplerBuilder {
+	b.registry = r
+	return b
+}
+
+// WithPeriod sets the period of sampling in virtual time.
+func (b SamplerBuilder) WithPeriod(period sim.VTimeInSec) SamplerBuilder {
+	b.period = period
+	return b
+}
+
+// WithExporter adds an exporter that receives the samples.
+func (b SamplerBuilder) WithExporter(e Exporter) SamplerBuilder {
+	b.exporters = append(b.exporters, e)
+	return b
+}
+
+// Build creates a sampler. The sampler takes a final sample and flushes the
+// exporters when the simulation exits.
+func (b SamplerBuilder) Build() *Sampler {
+	if b.period <= 0 {
+		panic("sampling period must be positive")
+	}
+
+	s := &Sampler{
+		timeTeller: b.timeTeller,
+		registry:   b.registry,
+		period:     b.period,
+		nextTime:   b.period,
+		exporters:  b.exporters,
+	}
+
+	atexit.Register(func() { s.Terminate() })
+
+	return s
+}
+
+// Func takes samples before an event is handled.
+func (s *Sampler) Func(ctx sim.HookCtx) {
+	if ctx.Pos != sim.HookPosBeforeEvent {
+		return
+	}
+
+	evt := ctx.Item.(sim.Event)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.nextTime > evt.Time() {
+		return
+	}
+
+	// The values do not change between the sampling points, so all the
+	// points before the event share the same samples.
+	samples := s.registry.Samples()
+	for s.nextTime <= evt.Time() {
+		s.export(s.nextTime, samples)
+		s.nextTime += s.period
+	}
+}
+
+// Terminate takes a final sample at the current time and flushes the
+// exporters.
+func (s *Sampler) Terminate() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.timeTeller != nil {
+		now := s.timeTeller.CurrentTime()
+		if !s.hasLastTime || now > s.lastTime {
+			s.export(now, s.registry.Samples())
+		}
+	}
+
+	for _, e := range s.exporters {
+		e.Flush()
+	}
+}
+
+func (s *Sampler) export(time sim.VTimeInSec, samples []Sample) {
+	for _, e := range s.exporters {
+		e.Export(time, samples)
+	}
+
+	s.lastTime = time
+	s.hasLastTime = true
+}
@@ -0,0 +1,90 @@
+package metrics
+
+import (
+	"github.com/sarchlab/akita/v3/sim"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type timedSamples struct {
+	time    sim.VTimeInSec
+	samples []Sample
+}
+
+type recordingExporter struct {
+	exported []timedSamples
+	numFlush int
+}
+
+func (e *recordingExporter) Export(time sim.VTimeInSec, samples []Sample) {
+	e.exported = append(e.exported, timedSamples{time, samples})
+}
+
+func (e *recordingExporter) Flush() {
+	e.numFlush++
+}
+
+type fixedTimeTeller struct {
+	now sim.VTimeInSec
+}
+
+func (t *fixedTimeTeller) CurrentTime() sim.VTimeInSec {
+	return t.now
+}
+
+var _ = Describe("Sampler", func() {
+	var (
+		r          *Registry
+		exporter   *recordingExporter
+		timeTeller *fixedTimeTeller
+		sampler    *Sampler
+	)
+
+	beforeEvent := func(t sim.VTimeInSec) {
+		sampler.Func(sim.HookCtx{
+			Pos:  sim.HookPosBeforeEvent,
+			Item: sim.NewEventBase(t, nil),
+		})
+	}
+
+	BeforeEach(func() {
+		r = NewRegistry()
+		exporter = &recordingExporter{}
+		timeTeller = &fixedTimeTeller{}
+		sampler = MakeSamplerBuilder().
+			WithRegistry(r).
+			WithTimeTeller(timeTeller).
+			WithPeriod(1).
+			WithExporter(exporter).
+			Build()
+	})
+
+	It("should sample at every period before the event", func() {
+		c := r.Counter("Comp", "count")
+
+		beforeEvent(0.5)
+		c.Inc()
+		beforeEvent(1)
+		c.Inc()
+		beforeEvent(3.5)
+
+		Expect(exporter.exported).To(HaveLen(3))
+		Expect(exporter.exported[0].time).To(Equal(sim.VTimeInSec(1)))
+		Expect(exporter.exported[0].samples[0].Value).To(Equal(1.0))
+		Expect(exporter.exported[1].time).To(Equal(sim.VTimeInSec(2)))
+		Expect(exporter.exported[1].samples[0].Value).To(Equal(2.0))
+		Expect(exporter.exported[2].time).To(Equal(sim.VTimeInSec(3)))
+	})
+
+	It("should take a final sample when terminated", func() {
+		r.Counter("Comp", "count").Inc()
+		timeTeller.now = 0.5
+
+		sampler.Terminate()
+
+		Expect(exporter.exported).To(HaveLen(1))
+		Expect(exporter.exported[0].time).To(Equal(sim.VTimeInSec(0.5)))
+		Expect(exporter.numFlush).To(Equal(1))
+	})
+})
@@ -0,0 +1,402 @@
+package monitoring
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+// BreakpointKind tells what condition a breakpoint checks.
+type BreakpointKind string
+
+// A list of all the kinds of breakpoints.
+const (
+	// BreakpointTime pauses the simulation before handling the first event
+	// at or after the given time.
+	BreakpointTime BreakpointKind = "time"
+
+	// BreakpointMsg pauses the simulation when a component receives a
+	// message that matches the given type and ID.
+	BreakpointMsg BreakpointKind = "msg"
+
+	// BreakpointBuffer pauses the simulation when the level of a buffer
+	// exceeds the given level.
+	BreakpointBuffer BreakpointKind = "buffer"
+)
+
+// A Breakpoint is a condition that pauses the simulation.
+type Breakpoint struct {
+	ID        int            `json:"id"`
+	Kind      BreakpointKind `json:"kind"`
+	Time      sim.VTimeInSec `json:"time,omitempty"`
+	Component string         `json:"component,omitempty"`
+	MsgType   string         `json:"msg_type,omitempty"`
+	MsgID     string         `json:"msg_id,omitempty"`
+	Buffer    string         `json:"buffer,omitempty"`
+	Level     int            `json:"level,omitempty"`
+	NumHits   int            `json:"num_hits"`
+
+	// isAboveLevel is true if the buffer level is above the breakpoint
+	// level, so that the breakpoint is only hit when the level rises above.
+	isAboveLevel bool
+}
+
+// DebuggerStatus tells if the simulation is paused by the debugger and why.
+type DebuggerStatus struct {
+	Paused       bool           `json:"paused"`
+	Reason       string         `json:"reason,omitempty"`
+	Time         sim.VTimeInSec `json:"time"`
+	StepsLeft    uint64         `json:"steps_left"`
+	BreakpointID int            `json:"breakpoint_id,omitempty"`
+}
+
+// A Debugger pauses the simulation at breakpoints or after a given number of
+// events. It is an engine hook and a port hook.
+//
+// The debugger pauses the simulation by blocking the engine in the hook until
+// Continue or Step is called. A time breakpoint pauses before the event is
+// handled. The other breakpoints pause after the event that hits the
+// breakpoint is handled. With parallel engines, the events that are being
+// handled by other threads are completed before their threads are blocked.
+type Debugger struct {
+	lock sync.Mutex
+	cond *sync.Cond
+
+	// armed is non-zero if there is a breakpoint, a pending pause, or steps
+	// to count, so that the hooks can return quickly in other cases.
+	armed int32
+
+	breakpoints []*Breakpoint
+	nextID      int
+	stepsLeft   uint64
+	pending     *DebuggerStatus
+	status      DebuggerStatus
+
+	components map[string]sim.Component
+	portOwners map[sim.Port]string
+	buffers    map[string]sim.Buffer
+}
+
+// NewDebugger creates a new Debugger.
+func NewDebugger() *Debugger {
+	d := &Debugger{
+		nextID:     1,
+		components: make(map[string]sim.Component),
+		portOwners: make(map[sim.Port]string),
+		buffers:    make(map[string]sim.Buffer),
+	}
+	d.cond = sync.NewCond(&d.lock)
+
+	return d
+}
+
+// RegisterComponent allows breakpoints to be set on the messages that the
+// component receives and on the buffers of the component. It must be called
+// before the simulation starts.
+func (d *Debugger) RegisterComponent(c sim.Component) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if _, found := d.components[c.Name()]; found {
+		return
+	}
+
+	d.components[c.Name()] = c
+
+	for _, p := range c.Ports() {
+		if _, found := d.portOwners[p]; found {
+			continue
+		}
+
+		d.portOwners[p] = c.Name()
+		p.AcceptHook(d)
+	}
+
+	for _, b := range componentBuffers(c) {
+		d.buffers[b.Name()] = b
+	}
+}
+
+// AddTimeBreakpoint adds a breakpoint that pauses the simulation before the
+// first event at or after the given time is handled.
+func (d *Debugger) AddTimeBreakpoint(t sim.VTimeInSec) Breakpoint {
+	return d.addBreakpoint(&Breakpoint{Kind: BreakpointTime, Time: t})
+}
+
+// AddMsgBreakpoint adds a breakpoint that pauses the simulation when the
+// component receives a message. The message type is the Go type name, such
+// as *mem.ReadReq, and the leading star can be omitted. An empty type or ID
+// matches all the messages.
+func (d *Debugger) AddMsgBreakpoint(
+	component, msgType, msgID string,
+) (Breakpoint, error) {
+	d.lock.Lock()
+	_, found := d.components[component]
+	d.lock.Unlock()
+
+	if !found {
+		return Breakpoint{}, fmt.Errorf("component %s not found", component)
+	}
+
+	return d.addBreakpoint(&Breakpoint{
+		Kind:      BreakpointMsg,
+		Component: component,
+		MsgType:   strings.TrimPrefix(msgType, "*"),
+		MsgID:     msgID,
+	}), nil
+}
+
+// AddBufferBreakpoint adds a breakpoint that pauses the simulation when the
+// number of elements in the buffer rises above the given level.
+func (d *Debugger) AddBufferBreakpoint(
+	buffer string,
+	level int,
+) (Breakpoint, error) {
+	d.lock.Lock()
+	b, found := d.buffers[buffer]
+	d.lock.Unlock()
+
+	if !found {
+		return Breakpoint{}, fmt.Errorf("buffer %s not found", buffer)
+	}
+
+	return d.addBreakpoint(&Breakpoint{
+		Kind:         BreakpointBuffer,
+		Buffer:       buffer,
+		Level:        level,
+		isAboveLevel: b.Size() > level,
+	}), nil
+}
+
+func (d *Debugger) addBreakpoint(bp *Breakpoint) Breakpoint {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	bp.ID = d.nextID
+	d.nextID++
+	d.breakpoints = append(d.breakpoints, bp)
+	d.updateArmed()
+
+	return *bp
+}
+
+// RemoveBreakpoint removes the breakpoint with the given ID. It returns false
+// if the breakpoint does not exist.
+func (d *Debugger) RemoveBreakpoint(id int) bool {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	for i, bp := range d.breakpoints {
+		if bp.ID == id {
+			d.breakpoints = append(d.breakpoints[:i], d.breakpoints[i+1:]...)
+			d.updateArmed()
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// Breakpoints returns all the breakpoints.
+func (d *Debugger) Breakpoints() []Breakpoint {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	bps := make([]Breakpoint, 0, len(d.breakpoints))
+	for _, bp := range d.breakpoints {
+		bps = append(bps, *bp)
+	}
+
+	return bps
+}
+
+// Status returns whether the simulation is paused by the debugger.
+func (d *Debugger) Status() DebuggerStatus {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	status := d.status
+	status.StepsLeft = d.stepsLeft
+
+	return status
+}
+
+// Continue resumes the simulation if it is paused by the debugger.
+func (d *Debugger) Continue() {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.resume()
+}
+
+// Step lets the simulation handle the given number of events and pause
+// again. If the simulation is running, it pauses after the given number of
+// events.
+func (d *Debugger) Step(numEvents uint64) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.stepsLeft = numEvents
+	d.resume()
+}
+
+func (d *Debugger) resume() {
+	d.status = DebuggerStatus{}
+	d.pending = nil
+	d.updateArmed()
+	d.cond.Broadcast()
+}
+
+func (d *Debugger) updateArmed() {
+	armed := len(d.breakpoints) > 0 ||
+		d.stepsLeft > 0 ||
+		d.pending != nil ||
+		d.status.Paused
+
+	if armed {
+		atomic.StoreInt32(&d.armed, 1)
+	} else {
+		atomic.StoreInt32(&d.armed, 0)
+	}
+}
+
+// Func checks the breakpoints when an event is handled or a message is
+// received.
+func (d *Debugger) Func(ctx sim.HookCtx) {
+	if atomic.LoadInt32(&d.armed) == 0 {
+		return
+	}
+
+	switch ctx.Pos {
+	case sim.HookPosBeforeEvent:
+		d.beforeEvent(ctx.Item.(sim.Event))
+	case sim.HookPosAfterEvent:
+		d.afterEvent(ctx.Item.(sim.Event))
+	case sim.HookPosPortMsgRecvd:
+		d.msgReceived(ctx.Domain.(sim.Port), ctx.Item.(sim.Msg))
+	}
+}
+
+func (d *Debugger) beforeEvent(evt sim.Event) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.waitWhilePaused()
+
+	for _, bp := range d.breakpoints {
+		if bp.Kind != BreakpointTime || evt.Time() < bp.Time || bp.NumHits > 0 {
+			continue
+		}
+
+		bp.NumHits++
+		d.pause(DebuggerStatus{
+			Reason:       fmt.Sprintf("time breakpoint at %.10f", bp.Time),
+			Time:         evt.Time(),
+			BreakpointID: bp.ID,
+		})
+
+		return
+	}
+}
+
+func (d *Debugger) afterEvent(evt sim.Event) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.waitWhilePaused()
+
+	d.checkBufferBreakpoints(evt.Time())
+
+	if d.pending != nil {
+		d.pause(*d.pending)
+		return
+	}
+
+	if d.stepsLeft > 0 {
+		d.stepsLeft--
+		if d.stepsLeft == 0 {
+			d.pause(DebuggerStatus{Reason: "step", Time: evt.Time()})
+		}
+	}
+}
+
+func (d *Debugger) checkBufferBreakpoints(now sim.VTimeInSec) {
+	for _, bp := range d.breakpoints {
+		if bp.Kind != BreakpointBuffer {
+			continue
+		}
+
+		size := d.buffers[bp.Buffer].Size()
+		isAboveLevel := size > bp.Level
+		if isAboveLevel && !bp.isAboveLevel {
+			bp.NumHits++
+			d.setPending(DebuggerStatus{
+				Reason: fmt.Sprintf("buffer %s level %d exceeds %d",
+					bp.Buffer, size, bp.Level),
+				Time:         now,
+				BreakpointID: bp.ID,
+			})
+		}
+
+		bp.isAboveLevel = isAboveLevel
+	}
+}
+
+func (d *Debugger) msgReceived(port sim.Port, msg sim.Msg) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	component := d.portOwners[port]
+	msgType := strings.TrimPrefix(reflect.TypeOf(msg).String(), "*")
+
+	for _, bp := range d.breakpoints {
+		if bp.Kind != BreakpointMsg ||
+			bp.Component != component ||
+			(bp.MsgType != "" && bp.MsgType != msgType) ||
+			(bp.MsgID != "" && bp.MsgID != msg.Meta().ID) {
+			continue
+		}
+
+		bp.NumHits++
+		d.setPending(DebuggerStatus{
+			Reason: fmt.Sprintf("%s received %s (id %s)",
+				component, msgType, msg.Meta().ID),
+			Time:         msg.Meta().RecvTime,
+			BreakpointID: bp.ID,
+		})
+	}
+}
+
+// setPending records a breakpoint hit, so that the simulation pauses after
+// the current event is handled. Only the first hit of an event is reported.
+func (d *Debugger) setPending(status DebuggerStatus) {
+	if d.pending != nil {
+		return
+	}
+
+	d.pending = &status
+	d.updateArmed()
+}
+
+// pause blocks the calling thread until the simulation is resumed. The lock
+// must be held.
+func (d *Debugger) pause(status DebuggerStatus) {
+	status.Paused = true
+	d.status = status
+	d.pending = nil
+	d.stepsLeft = 0
+	d.updateArmed()
+
+	d.waitWhilePaused()
+}
+
+func (d *Debugger) waitWhilePaused() {
+	for d.status.Paused {
+		d.cond.Wait()
+	}
+}
@@ -0,0 +1,143 @@
+package monitoring
+
+import (
+	"github.com/sarchlab/akita/v3/sim"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type funcHandler func(evt sim.Event)
+
+func (f funcHandler) Handle(evt sim.Event) error {
+	f(evt)
+	return nil
+}
+
+var _ = Describe("Debugger", func() {
+	var (
+		engine *sim.SerialEngine
+		comp   *sampleComponent
+		d      *Debugger
+		done   chan error
+	)
+
+	BeforeEach(func() {
+		engine = sim.NewSerialEngine()
+		comp = newSampleComponent()
+		d = NewDebugger()
+		d.RegisterComponent(comp)
+		engine.AcceptHook(d)
+		done = make(chan error, 1)
+	})
+
+	run := func() {
+		go func() { done <- engine.Run() }()
+	}
+
+	scheduleEvents := func(handler sim.Handler, times ...sim.VTimeInSec) {
+		for _, t := range times {
+			engine.Schedule(sim.NewEventBase(t, handler))
+		}
+	}
+
+	It("should pause at a time breakpoint", func() {
+		scheduleEvents(comp, 1, 2, 3)
+		d.AddTimeBreakpoint(1.5)
+
+		run()
+
+		Eventually(d.Status).Should(HaveField("Paused", true))
+		Expect(d.Status().Time).To(Equal(sim.VTimeInSec(2)))
+		Expect(engine.CurrentTime()).To(Equal(sim.VTimeInSec(2)))
+		Consistently(done).ShouldNot(Receive())
+
+		d.Continue()
+
+		Eventually(done).Should(Receive(BeNil()))
+		Expect(d.Breakpoints()[0].NumHits).To(Equal(1))
+	})
+
+	It("should pause when a component receives a matching message", func() {
+		port := comp.GetPortByName("Port1")
+		msg := sim.GeneralRspBuilder{}.WithDst(port).Build()
+		send := funcHandler(func(evt sim.Event) {
+			if evt.Time() == 2 {
+				port.Recv(msg)
+			}
+		})
+		scheduleEvents(send, 1, 2, 3)
+
+		_, err := d.AddMsgBreakpoint("Comp", "*sim.GeneralReq", "")
+		Expect(err).To(BeNil())
+		bp, err := d.AddMsgBreakpoint("Comp", "sim.GeneralRsp", msg.ID)
+		Expect(err).To(BeNil())
+
+		run()
+
+		Eventually(d.Status).Should(HaveField("Paused", true))
+		Expect(d.Status().BreakpointID).To(Equal(bp.ID))
+		Expect(engine.CurrentTime()).To(Equal(sim.VTimeInSec(2)))
+
+		d.Continue()
+
+		Eventually(done).Should(Receive(BeNil()))
+	})
+
+	It("should pause when a buffer level rises above a level", func() {
+		push := funcHandler(func(evt sim.Event) { comp.buffer.Push(1) })
+		scheduleEvents(push, 1, 2, 3, 4)
+
+		_, err := d.AddBufferBreakpoint("Comp.Buf", 1)
+		Expect(err).To(BeNil())
+
+		run()
+
+		Eventually(d.Status).Should(HaveField("Paused", true))
+		Expect(d.Status().Time).To(Equal(sim.VTimeInSec(2)))
+
+		d.Continue()
+
+		Eventually(done).Should(Receive(BeNil()))
+		Expect(d.Breakpoints()[0].NumHits).To(Equal(1))
+	})
+
+	It("should step a given number of events", func() {
+		scheduleEvents(comp, 1, 2, 3, 4, 5)
+		d.AddTimeBreakpoint(1)
+
+		run()
+
+		Eventually(d.Status).Should(HaveField("Paused", true))
+		Expect(engine.CurrentTime()).To(Equal(sim.VTimeInSec(1)))
+
+		d.Step(2)
+
+		Eventually(d.Status).Should(HaveField("Reason", "step"))
+		Expect(d.Status().Time).To(Equal(sim.VTimeInSec(2)))
+
+		d.Continue()
+
+		Eventually(done).Should(Receive(BeNil()))
+	})
+
+	It("should not pause after a breakpoint is removed", func() {
+		scheduleEvents(comp, 1, 2, 3)
+		bp := d.AddTimeBreakpoint(1.5)
+
+		Expect(d.RemoveBreakpoint(bp.ID)).To(BeTrue())
+		Expect(d.RemoveBreakpoint(bp.ID)).To(BeFalse())
+
+		run()
+
+		Eventually(done).Should(Receive(BeNil()))
+	})
+
+	It("should reject breakpoints on unknown components and buffers", func() {
+		_, err := d.AddMsgBreakpoint("Unknown", "", "")
+		Expect(err).NotTo(BeNil())
+
+		_, err = d.AddBufferBreakpoint("Unknown.Buf", 1)
+		Expect(err).NotTo(BeNil())
+	})
+})
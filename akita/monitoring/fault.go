@@ -0,0 +1,285 @@
+package monitoring
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sync"
+
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+// FaultKind tells what a fault does to a message.
+type FaultKind string
+
+// A list of all the kinds of faults.
+const (
+	// FaultDrop discards the message.
+	FaultDrop FaultKind = "drop"
+
+	// FaultDelay delivers the message after a delay.
+	FaultDelay FaultKind = "delay"
+
+	// FaultCorrupt flips a random bit in each of the byte slices of the
+	// message, such as the data of a write request.
+	FaultCorrupt FaultKind = "corrupt"
+)
+
+// faultRetryInterval is the time to wait before delivering a delayed message
+// again if the port is full.
+const faultRetryInterval = sim.VTimeInSec(1e-9)
+
+// A Fault is applied to the messages that arrive at a port or the ports of a
+// connection.
+type Fault struct {
+	ID          int            `json:"id"`
+	Kind        FaultKind      `json:"kind"`
+	Target      string         `json:"target"`
+	Probability float64        `json:"probability"`
+	Delay       sim.VTimeInSec `json:"delay,omitempty"`
+	NumApplied  int            `json:"num_applied"`
+}
+
+// An interceptablePort allows the messages that it receives to be taken over.
+type interceptablePort interface {
+	sim.Port
+	SetRecvInterceptor(i sim.RecvInterceptor)
+}
+
+// A FaultInjector drops, delays, or corrupts the messages that arrive at
+// ports.
+type FaultInjector struct {
+	lock         sync.Mutex
+	engine       sim.Engine
+	rand         *rand.Rand
+	faults       []*Fault
+	portFaults   map[sim.Port][]*Fault
+	nextID       int
+	redelivering map[string]bool
+}
+
+type delayedDeliveryEvent struct {
+	*sim.EventBase
+	port sim.Port
+	msg  sim.Msg
+}
+
+// NewFaultInjector creates a new FaultInjector. The random numbers that
+// decide if a fault is applied are generated from a fixed seed, so that the
+// faults are reproducible.
+func NewFaultInjector(engine sim.Engine) *FaultInjector {
+	return &FaultInjector{
+		engine:       engine,
+		rand:         rand.New(rand.NewSource(1)),
+		portFaults:   make(map[sim.Port][]*Fault),
+		nextID:       1,
+		redelivering: make(map[string]bool),
+	}
+}
+
+// AddFault applies a fault to the messages that arrive at the given ports.
+// It should only be called when the simulation is paused.
+func (f *FaultInjector) AddFault(fault Fault, ports []sim.Port) (Fault, error) {
+	err := f.faultMustBeValid(fault, ports)
+	if err != nil {
+		return Fault{}, err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	fault.ID = f.nextID
+	fault.NumApplied = 0
+	f.nextID++
+
+	newFault := &fault
+	f.faults = append(f.faults, newFault)
+
+	for _, p := range ports {
+		if _, found := f.portFaults[p]; !found {
+			p.(interceptablePort).SetRecvInterceptor(f)
+		}
+
+		f.portFaults[p] = append(f.portFaults[p], newFault)
+	}
+
+	return fault, nil
+}
+
+func (f *FaultInjector) faultMustBeValid(fault Fault, ports []sim.Port) error {
+	switch fault.Kind {
+	case FaultDrop, FaultCorrupt:
+	case FaultDelay:
+		if fault.Delay <= 0 {
+			return fmt.Errorf("the delay must be positive")
+		}
+	default:
+		return fmt.Errorf("unknown fault kind %q", fault.Kind)
+	}
+
+	if fault.Probability <= 0 || fault.Probability > 1 {
+		return fmt.Errorf("the probability must be in (0, 1]")
+	}
+
+	if len(ports) == 0 {
+		return fmt.Errorf("no port to apply the fault to")
+	}
+
+	for _, p := range ports {
+		if _, ok := p.(interceptablePort); !ok {
+			return fmt.Errorf("port %s does not support faults", p.Name())
+		}
+	}
+
+	return nil
+}
+
+// RemoveFault removes the fault with the given ID. It returns false if the
+// fault does not exist. It should only be called when the simulation is
+// paused.
+func (f *FaultInjector) RemoveFault(id int) bool {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	found := false
+	for i, fault := range f.faults {
+		if fault.ID == id {
+			f.faults = append(f.faults[:i], f.faults[i+1:]...)
+			found = true
+
+			break
+		}
+	}
+
+	for p, faults := range f.portFaults {
+		for i, fault := range faults {
+			if fault.ID == id {
+				f.portFaults[p] = append(faults[:i], faults[i+1:]...)
+				break
+			}
+		}
+	}
+
+	return found
+}
+
+// Faults returns all the faults.
+func (f *FaultInjector) Faults() []Fault {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	faults := make([]Fault, 0, len(f.faults))
+	for _, fault := range f.faults {
+		faults = append(faults, *fault)
+	}
+
+	return faults
+}
+
+// InterceptRecv applies the faults of the port to a message.
+func (f *FaultInjector) InterceptRecv(port sim.Port, msg sim.Msg) bool {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.redelivering[msg.Meta().ID] {
+		delete(f.redelivering, msg.Meta().ID)
+		return true
+	}
+
+	for _, fault := range f.portFaults[port] {
+		if f.rand.Float64() >= fault.Probability {
+			continue
+		}
+
+		switch fault.Kind {
+		case FaultDrop:
+			fault.NumApplied++
+			return false
+		case FaultDelay:
+			fault.NumApplied++
+			f.scheduleDelivery(port, msg, fault.Delay)
+
+			return false
+		case FaultCorrupt:
+			if f.corrupt(msg) {
+				fault.NumApplied++
+			}
+		}
+	}
+
+	return true
+}
+
+func (f *FaultInjector) scheduleDelivery(
+	port sim.Port,
+	msg sim.Msg,
+	delay sim.VTimeInSec,
+) {
+	evt := delayedDeliveryEvent{
+		EventBase: sim.NewEventBase(f.engine.CurrentTime()+delay, f),
+		port:      port,
+		msg:       msg,
+	}
+	f.engine.Schedule(evt)
+}
+
+// corrupt flips a random bit in each of the non-empty byte slice fields of
+// the message. The slices are copied, as they may be shared with the sender.
+func (f *FaultInjector) corrupt(msg sim.Msg) bool {
+	v := reflect.ValueOf(msg)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return false
+	}
+
+	corrupted := false
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if !field.CanSet() ||
+			field.Kind() != reflect.Slice ||
+			field.Type().Elem().Kind() != reflect.Uint8 ||
+			field.Len() == 0 {
+			continue
+		}
+
+		data := make([]byte, field.Len())
+		reflect.Copy(reflect.ValueOf(data), field)
+		data[f.rand.Intn(len(data))] ^= 1 << f.rand.Intn(8)
+		field.Set(reflect.ValueOf(data))
+
+		corrupted = true
+	}
+
+	return corrupted
+}
+
+// Handle delivers a delayed message.
+func (f *FaultInjector) Handle(e sim.Event) error {
+	evt := e.(delayedDeliveryEvent)
+
+	f.lock.Lock()
+	f.redelivering[evt.msg.Meta().ID] = true
+	f.lock.Unlock()
+
+	evt.msg.Meta().RecvTime = evt.Time()
+	err := evt.port.Recv(evt.msg)
+	if err == nil {
+		return nil
+	}
+
+	f.lock.Lock()
+	delete(f.redelivering, evt.msg.Meta().ID)
+	f.lock.Unlock()
+
+	retry := delayedDeliveryEvent{
+		EventBase: sim.NewEventBase(evt.Time()+faultRetryInterval, f),
+		port:      evt.port,
+		msg:       evt.msg,
+	}
+	f.engine.Schedule(retry)
+
+	return nil
+}
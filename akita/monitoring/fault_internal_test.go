@@ -0,0 +1,100 @@
+package monitoring
+
+import (
+	"github.com/sarchlab/akita/v3/sim"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type dataMsg struct {
+	sim.MsgMeta
+
+	Data []byte
+}
+
+func (m *dataMsg) Meta() *sim.MsgMeta {
+	return &m.MsgMeta
+}
+
+var _ = Describe("FaultInjector", func() {
+	var (
+		engine *sim.SerialEngine
+		comp   *sampleComponent
+		port   sim.Port
+		f      *FaultInjector
+	)
+
+	BeforeEach(func() {
+		engine = sim.NewSerialEngine()
+		comp = newSampleComponent()
+		port = comp.GetPortByName("Port1")
+		f = NewFaultInjector(engine)
+	})
+
+	newMsg := func() *dataMsg {
+		msg := &dataMsg{Data: []byte{1, 2, 3, 4}}
+		msg.ID = sim.GetIDGenerator().Generate()
+		msg.Dst = port
+
+		return msg
+	}
+
+	It("should drop messages", func() {
+		fault, err := f.AddFault(
+			Fault{Kind: FaultDrop, Probability: 1}, []sim.Port{port})
+		Expect(err).To(BeNil())
+
+		Expect(port.Recv(newMsg())).To(BeNil())
+
+		Expect(port.Peek()).To(BeNil())
+		Expect(f.Faults()[0].NumApplied).To(Equal(1))
+
+		Expect(f.RemoveFault(fault.ID)).To(BeTrue())
+		Expect(port.Recv(newMsg())).To(BeNil())
+		Expect(port.Peek()).NotTo(BeNil())
+	})
+
+	It("should delay messages", func() {
+		_, err := f.AddFault(
+			Fault{Kind: FaultDelay, Probability: 1, Delay: 2},
+			[]sim.Port{port})
+		Expect(err).To(BeNil())
+
+		msg := newMsg()
+		Expect(port.Recv(msg)).To(BeNil())
+		Expect(port.Peek()).To(BeNil())
+
+		Expect(engine.Run()).To(Succeed())
+
+		Expect(port.Peek()).To(BeIdenticalTo(msg))
+		Expect(msg.RecvTime).To(Equal(sim.VTimeInSec(2)))
+	})
+
+	It("should corrupt a copy of the data", func() {
+		_, err := f.AddFault(
+			Fault{Kind: FaultCorrupt, Probability: 1}, []sim.Port{port})
+		Expect(err).To(BeNil())
+
+		msg := newMsg()
+		original := msg.Data
+		Expect(port.Recv(msg)).To(BeNil())
+
+		Expect(port.Peek()).To(BeIdenticalTo(msg))
+		Expect(original).To(Equal([]byte{1, 2, 3, 4}))
+		Expect(msg.Data).NotTo(Equal(original))
+	})
+
+	It("should reject invalid faults", func() {
+		_, err := f.AddFault(Fault{Kind: "flip", Probability: 1},
+			[]sim.Port{port})
+		Expect(err).NotTo(BeNil())
+
+		_, err = f.AddFault(Fault{Kind: FaultDelay, Probability: 1},
+			[]sim.Port{port})
+		Expect(err).NotTo(BeNil())
+
+		_, err = f.AddFault(Fault{Kind: FaultDrop, Probability: 1}, nil)
+		Expect(err).NotTo(BeNil())
+	})
+})
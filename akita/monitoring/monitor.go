@@ -24,6 +24,7 @@ import (
 	"github.com/google/pprof/profile"
 	"github.com/gorilla/mux"
 	"github.com/sarchlab/akita/v3/analysis"
+	"github.com/sarchlab/akita/v3/metrics"
 	"github.com/sarchlab/akita/v3/monitoring/web"
 	"github.com/sarchlab/akita/v3/sim"
 	"github.com/shirou/gopsutil/process"
@@ -38,6 +39,12 @@ type Monitor struct {
 	buffers      []sim.Buffer
 	portNumber   int
 	perfAnalyzer *analysis.PerfAnalyzer
+	metrics      *metrics.Registry
+	debugger     *Debugger
+	faults       *FaultInjector
+
+	enginePausedLock sync.Mutex
+	enginePaused     bool
 
 	progressBarsLock sync.Mutex
 	progressBars     []*ProgressBar
@@ -45,7 +52,10 @@ type Monitor struct {
 
 // NewMonitor creates a new Monitor
 func NewMonitor() *Monitor {
-	return &Monitor{}
+	return &Monitor{
+		metrics:  metrics.DefaultRegistry(),
+		debugger: NewDebugger(),
+	}
 }
 
 // WithPortNumber sets the port number of the monitor.
@@ -65,6 +75,8 @@ func (m *Monitor) WithPortNumber(portNumber int) *Monitor {
 // RegisterEngine registers the engine that is used in the simulation.
 func (m *Monitor) RegisterEngine(e sim.Engine) {
 	m.engine = e
+	m.faults = NewFaultInjector(e)
+	e.AcceptHook(m.debugger)
 }
 
 // RegisterPerfAnalyzer sets the performance analyzer to be used in the monitor.
@@ -72,22 +84,40 @@ func (m *Monitor) RegisterPerfAnalyzer(pa *analysis.PerfAnalyzer) {
 	m.perfAnalyzer = pa
 }
 
+// RegisterMetrics sets the registry of the metrics that the monitor serves.
+// By default, the default registry is used.
+func (m *Monitor) RegisterMetrics(r *metrics.Registry) {
+	m.metrics = r
+}
+
 // RegisterComponent register a component to be monitored.
 func (m *Monitor) RegisterComponent(c sim.Component) {
 	m.components = append(m.components, c)
+	m.debugger.RegisterComponent(c)
+
+	m.buffers = append(m.buffers, componentBuffers(c)...)
+}
 
-	m.registerBuffers(c)
+// Components returns the components registered to the monitor.
+func (m *Monitor) Components() []sim.Component {
+	return m.components
 }
 
-func (m *Monitor) registerBuffers(c sim.Component) {
-	m.registerComponentOrPortBuffers(c)
+// componentBuffers returns the buffers that are fields of the component or
+// the ports of the component.
+func componentBuffers(c sim.Component) []sim.Buffer {
+	buffers := componentOrPortBuffers(c)
 
 	for _, p := range c.Ports() {
-		m.registerComponentOrPortBuffers(p)
+		buffers = append(buffers, componentOrPortBuffers(p)...)
 	}
+
+	return buffers
 }
 
-func (m *Monitor) registerComponentOrPortBuffers(c any) {
+func componentOrPortBuffers(c any) []sim.Buffer {
+	var buffers []sim.Buffer
+
 	v := reflect.ValueOf(c).Elem()
 	for i := 0; i < v.NumField(); i++ {
 		field := v.Field(i)
@@ -100,9 +130,11 @@ func (m *Monitor) registerComponentOrPortBuffers(c any) {
 				field.Type(),
 				unsafe.Pointer(field.UnsafeAddr()),
 			).Elem().Interface().(sim.Buffer)
-			m.buffers = append(m.buffers, fieledRef)
+			buffers = append(buffers, fieledRef)
 		}
 	}
+
+	return buffers
 }
 
 // CreateProgressBar creates a new progress bar.
@@ -147,14 +179,26 @@ func (m *Monitor) StartServer() {
 	r.HandleFunc("/api/now", m.now)
 	r.HandleFunc("/api/run", m.run)
 	r.HandleFunc("/api/tick/{name}", m.tick)
+	r.HandleFunc("/api/step/{n}", m.step)
+	r.HandleFunc("/api/debugger", m.debuggerStatus)
+	r.HandleFunc("/api/breakpoint/list", m.listBreakpoints)
+	r.HandleFunc("/api/breakpoint/add", m.addBreakpoint)
+	r.HandleFunc("/api/breakpoint/remove/{id}", m.removeBreakpoint)
 	r.HandleFunc("/api/list_components", m.listComponents)
 	r.HandleFunc("/api/component/{name}", m.listComponentDetails)
 	r.HandleFunc("/api/field/{json}", m.listFieldValue)
+	r.HandleFunc("/api/set_field/{json}", m.setFieldValue)
+	r.HandleFunc("/api/set_buffer_capacity", m.setBufferCapacity)
+	r.HandleFunc("/api/fault/list", m.listFaults)
+	r.HandleFunc("/api/fault/add", m.addFault)
+	r.HandleFunc("/api/fault/remove/{id}", m.removeFault)
 	r.HandleFunc("/api/hangdetector/buffers", m.hangDetectorBuffers)
 	r.HandleFunc("/api/progress", m.listProgressBars)
 	r.HandleFunc("/api/resource", m.listResources)
 	r.HandleFunc("/api/profile", m.collectProfile)
 	r.HandleFunc("/api/traffic/{name}", m.reportTraffic)
+	r.HandleFunc("/api/metrics", m.listMetrics)
+	r.Handle("/metrics", metrics.PrometheusHandler(m.metrics))
 	r.PathPrefix("/").Handler(fServer)
 	http.Handle("/", r)
 
@@ -178,17 +222,116 @@ func (m *Monitor) StartServer() {
 }
 
 func (m *Monitor) pauseEngine(w http.ResponseWriter, _ *http.Request) {
-	m.engine.Pause()
+	// The engine cannot be paused while the debugger blocks it, as the engine
+	// only pauses between events.
+	m.enginePausedLock.Lock()
+	if !m.enginePaused && !m.debugger.Status().Paused {
+		m.engine.Pause()
+		m.enginePaused = true
+	}
+	m.enginePausedLock.Unlock()
+
 	_, err := w.Write(nil)
 	dieOnErr(err)
 }
 
 func (m *Monitor) continueEngine(w http.ResponseWriter, _ *http.Request) {
-	m.engine.Continue()
+	m.continueIfEnginePaused()
+	m.debugger.Continue()
+
 	_, err := w.Write(nil)
 	dieOnErr(err)
 }
 
+func (m *Monitor) continueIfEnginePaused() {
+	m.enginePausedLock.Lock()
+	defer m.enginePausedLock.Unlock()
+
+	if m.enginePaused {
+		m.engine.Continue()
+		m.enginePaused = false
+	}
+}
+
+func (m *Monitor) step(w http.ResponseWriter, r *http.Request) {
+	n, err := strconv.ParseUint(mux.Vars(r)["n"], 10, 64)
+	if err != nil || n == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Error: the number of events must be positive")
+		return
+	}
+
+	m.debugger.Step(n)
+	m.continueIfEnginePaused()
+
+	m.writeJSON(w, m.debugger.Status())
+}
+
+func (m *Monitor) debuggerStatus(w http.ResponseWriter, _ *http.Request) {
+	m.writeJSON(w, m.debugger.Status())
+}
+
+func (m *Monitor) listBreakpoints(w http.ResponseWriter, _ *http.Request) {
+	m.writeJSON(w, m.debugger.Breakpoints())
+}
+
+func (m *Monitor) addBreakpoint(w http.ResponseWriter, r *http.Request) {
+	bp, err := m.parseAndAddBreakpoint(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Error: %s", err)
+		return
+	}
+
+	m.writeJSON(w, bp)
+}
+
+func (m *Monitor) parseAndAddBreakpoint(r *http.Request) (Breakpoint, error) {
+	query := r.URL.Query()
+
+	switch BreakpointKind(query.Get("kind")) {
+	case BreakpointTime:
+		t, err := strconv.ParseFloat(query.Get("time"), 64)
+		if err != nil {
+			return Breakpoint{}, err
+		}
+
+		return m.debugger.AddTimeBreakpoint(sim.VTimeInSec(t)), nil
+	case BreakpointMsg:
+		return m.debugger.AddMsgBreakpoint(
+			query.Get("component"), query.Get("msg_type"), query.Get("msg_id"))
+	case BreakpointBuffer:
+		level, err := strconv.Atoi(query.Get("level"))
+		if err != nil {
+			return Breakpoint{}, err
+		}
+
+		return m.debugger.AddBufferBreakpoint(query.Get("buffer"), level)
+	default:
+		return Breakpoint{}, fmt.Errorf(
+			"unknown breakpoint kind %q", query.Get("kind"))
+	}
+}
+
+func (m *Monitor) removeBreakpoint(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || !m.debugger.RemoveBreakpoint(id) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, "Breakpoint not found")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (m *Monitor) writeJSON(w http.ResponseWriter, v any) {
+	bytes, err := json.Marshal(v)
+	dieOnErr(err)
+
+	_, err = w.Write(bytes)
+	dieOnErr(err)
+}
+
 func (m *Monitor) now(w http.ResponseWriter, _ *http.Request) {
 	now := m.engine.CurrentTime()
 	fmt.Fprintf(w, "{\"now\":%.10f}", now)
@@ -255,6 +398,7 @@ func (m *Monitor) listComponentDetails(w http.ResponseWriter, r *http.Request) {
 type fieldReq struct {
 	CompName  string `json:"comp_name,omitempty"`
 	FieldName string `json:"field_name,omitempty"`
+	Value     string `json:"value,omitempty"`
 }
 
 func (m *Monitor) listFieldValue(w http.ResponseWriter, r *http.Request) {
@@ -285,6 +429,230 @@ func (m *Monitor) listFieldValue(w http.ResponseWriter, r *http.Request) {
 	dieOnErr(err)
 }
 
+// isPaused returns true if the engine is paused from the API or by the
+// debugger, so that the state of the simulation can be modified safely.
+func (m *Monitor) isPaused() bool {
+	m.enginePausedLock.Lock()
+	defer m.enginePausedLock.Unlock()
+
+	return m.enginePaused || m.debugger.Status().Paused
+}
+
+func (m *Monitor) mustBePaused(w http.ResponseWriter) bool {
+	if m.isPaused() {
+		return true
+	}
+
+	w.WriteHeader(http.StatusConflict)
+	fmt.Fprint(w, "Error: the simulation must be paused")
+
+	return false
+}
+
+func (m *Monitor) setFieldValue(w http.ResponseWriter, r *http.Request) {
+	if !m.mustBePaused(w) {
+		return
+	}
+
+	req := fieldReq{}
+	err := json.Unmarshal([]byte(mux.Vars(r)["json"]), &req)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Error: %s", err)
+		return
+	}
+
+	component := m.findComponentOr404(w, req.CompName)
+	if component == nil {
+		return
+	}
+
+	elem, err := m.walkFields(component, req.FieldName)
+	if err == nil {
+		err = setScalar(elem, req.Value)
+	}
+
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Error: %s", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// setScalar sets the value of a field from a string. Only the exported
+// boolean, number, and string fields can be set.
+func setScalar(elem reflect.Value, value string) error {
+	if !elem.CanSet() {
+		return errors.New("only exported fields can be set")
+	}
+
+	var err error
+	switch elem.Kind() {
+	case reflect.Bool:
+		var v bool
+		v, err = strconv.ParseBool(value)
+		elem.SetBool(v)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64:
+		var v int64
+		v, err = strconv.ParseInt(value, 10, elem.Type().Bits())
+		elem.SetInt(v)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64:
+		var v uint64
+		v, err = strconv.ParseUint(value, 10, elem.Type().Bits())
+		elem.SetUint(v)
+	case reflect.Float32, reflect.Float64:
+		var v float64
+		v, err = strconv.ParseFloat(value, elem.Type().Bits())
+		elem.SetFloat(v)
+	case reflect.String:
+		elem.SetString(value)
+	default:
+		return fmt.Errorf("field of kind %s cannot be set", elem.Kind())
+	}
+
+	return err
+}
+
+// A capacitySetter is a buffer whose capacity can be changed.
+type capacitySetter interface {
+	SetCapacity(capacity int)
+}
+
+func (m *Monitor) setBufferCapacity(w http.ResponseWriter, r *http.Request) {
+	if !m.mustBePaused(w) {
+		return
+	}
+
+	name := r.URL.Query().Get("buffer")
+	capacity, err := strconv.Atoi(r.URL.Query().Get("capacity"))
+	if err != nil || capacity < 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, "Error: the capacity must be a non-negative integer")
+		return
+	}
+
+	for _, b := range m.buffers {
+		if b.Name() != name {
+			continue
+		}
+
+		setter, ok := b.(capacitySetter)
+		if !ok {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			fmt.Fprint(w, "Error: the capacity of the buffer cannot be set")
+			return
+		}
+
+		setter.SetCapacity(capacity)
+		w.WriteHeader(http.StatusOK)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNotFound)
+	fmt.Fprint(w, "Buffer not found")
+}
+
+func (m *Monitor) listFaults(w http.ResponseWriter, _ *http.Request) {
+	m.writeJSON(w, m.faults.Faults())
+}
+
+func (m *Monitor) addFault(w http.ResponseWriter, r *http.Request) {
+	if !m.mustBePaused(w) {
+		return
+	}
+
+	fault, err := m.parseAndAddFault(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Error: %s", err)
+		return
+	}
+
+	m.writeJSON(w, fault)
+}
+
+func (m *Monitor) parseAndAddFault(r *http.Request) (Fault, error) {
+	query := r.URL.Query()
+
+	fault := Fault{
+		Kind:        FaultKind(query.Get("kind")),
+		Target:      query.Get("target"),
+		Probability: 1,
+	}
+
+	if p := query.Get("probability"); p != "" {
+		probability, err := strconv.ParseFloat(p, 64)
+		if err != nil {
+			return Fault{}, err
+		}
+
+		fault.Probability = probability
+	}
+
+	if d := query.Get("delay"); d != "" {
+		delay, err := strconv.ParseFloat(d, 64)
+		if err != nil {
+			return Fault{}, err
+		}
+
+		fault.Delay = sim.VTimeInSec(delay)
+	}
+
+	return m.faults.AddFault(fault, m.findFaultTargetPorts(fault.Target))
+}
+
+// A connectedPort can tell the connection that it is plugged into.
+type connectedPort interface {
+	Connection() sim.Connection
+}
+
+// findFaultTargetPorts returns the port with the given name. If no port has
+// the name, it returns the ports that are plugged into the connection with
+// the name.
+func (m *Monitor) findFaultTargetPorts(name string) []sim.Port {
+	var connPorts []sim.Port
+
+	for _, c := range m.components {
+		for _, p := range c.Ports() {
+			if p.Name() == name {
+				return []sim.Port{p}
+			}
+
+			cp, ok := p.(connectedPort)
+			if !ok {
+				continue
+			}
+
+			conn, ok := cp.Connection().(sim.Named)
+			if ok && conn.Name() == name {
+				connPorts = append(connPorts, p)
+			}
+		}
+	}
+
+	return connPorts
+}
+
+func (m *Monitor) removeFault(w http.ResponseWriter, r *http.Request) {
+	if !m.mustBePaused(w) {
+		return
+	}
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || !m.faults.RemoveFault(id) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, "Fault not found")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
 func (m *Monitor) hangDetectorBuffers(w http.ResponseWriter, r *http.Request) {
 	sortMethod, limit, offset, err := m.buffersParseParams(r, w)
 	if err != nil {
@@ -415,6 +783,10 @@ func (m *Monitor) walkFields(
 			elem = elem.Elem()
 		case reflect.Struct:
 			elem = elem.FieldByName(fieldNames[0])
+			if !elem.IsValid() {
+				return elem, fieldFormatError{}
+			}
+
 			fieldNames = fieldNames[1:]
 		case reflect.Slice:
 			index, err := strconv.Atoi(fieldNames[0])
@@ -422,6 +794,10 @@ func (m *Monitor) walkFields(
 				return elem, fieldFormatError{}
 			}
 
+			if index < 0 || index >= elem.Len() {
+				return elem, fieldFormatError{}
+			}
+
 			elem = elem.Index(index)
 			fieldNames = fieldNames[1:]
 		default:
@@ -456,6 +832,14 @@ func (m *Monitor) findComponentOr404(
 	return component
 }
 
+func (m *Monitor) listMetrics(w http.ResponseWriter, _ *http.Request) {
+	bytes, err := json.Marshal(m.metrics.Samples())
+	dieOnErr(err)
+
+	_, err = w.Write(bytes)
+	dieOnErr(err)
+}
+
 func (m *Monitor) listProgressBars(w http.ResponseWriter, _ *http.Request) {
 	bytes, err := json.Marshal(m.progressBars)
 	dieOnErr(err)
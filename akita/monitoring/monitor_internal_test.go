@@ -1,8 +1,11 @@
 package monitoring
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 
+	"github.com/gorilla/mux"
 	"github.com/sarchlab/akita/v3/sim"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -20,6 +23,8 @@ type sampleComponent struct {
 	*sim.ComponentBase
 
 	buffer sim.Buffer
+
+	NumReqs int
 }
 
 func (c *sampleComponent) Handle(_ sim.Event) error {
@@ -51,7 +56,7 @@ var _ = Describe("Monitor", func() {
 	)
 
 	BeforeEach(func() {
-		m = &Monitor{}
+		m = NewMonitor()
 	})
 
 	It("should register components and internal buffers", func() {
@@ -143,4 +148,61 @@ var _ = Describe("Monitor", func() {
 		Expect(elem.Type().Name()).To(Equal("int"))
 		Expect(elem.Int()).To(Equal(int64(1)))
 	})
+
+	Context("when modifying the state", func() {
+		var comp *sampleComponent
+
+		BeforeEach(func() {
+			comp = newSampleComponent()
+			m.RegisterComponent(comp)
+		})
+
+		setField := func(json string) *httptest.ResponseRecorder {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "/api/set_field", nil)
+			r = mux.SetURLVars(r, map[string]string{"json": json})
+			m.setFieldValue(w, r)
+
+			return w
+		}
+
+		It("should not set fields while the simulation is running", func() {
+			w := setField(
+				`{"comp_name":"Comp","field_name":"NumReqs","value":"3"}`)
+
+			Expect(w.Code).To(Equal(http.StatusConflict))
+			Expect(comp.NumReqs).To(Equal(0))
+		})
+
+		It("should set exported fields while paused", func() {
+			m.enginePaused = true
+
+			w := setField(
+				`{"comp_name":"Comp","field_name":"NumReqs","value":"3"}`)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(comp.NumReqs).To(Equal(3))
+		})
+
+		It("should not set unexported fields", func() {
+			m.enginePaused = true
+
+			w := setField(
+				`{"comp_name":"Comp","field_name":"buffer","value":"3"}`)
+
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should set buffer capacities while paused", func() {
+			m.enginePaused = true
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET",
+				"/api/set_buffer_capacity?buffer=Comp.Buf&capacity=4", nil)
+			m.setBufferCapacity(w, r)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(comp.buffer.Capacity()).To(Equal(4))
+		})
+	})
 })
@@ -0,0 +1,336 @@
+package monitoring
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/akita/v3/tracing"
+	"github.com/tebeka/atexit"
+)
+
+// A Watchdog terminates a simulation that no longer makes progress.
+//
+// The watchdog triggers in two cases. First, no event is handled for a
+// wall-clock interval (the stall timeout) while some requests are still
+// outstanding, which is usually a deadlock. Second, the simulation reaches
+// a simulated-time limit. In both cases, the watchdog writes a report of
+// the full buffers, the ports with pending messages, and the oldest
+// in-flight tasks of each component. It then exits the program with a
+// non-zero code.
+type Watchdog struct {
+	engine         sim.Engine
+	components     []sim.Component
+	buffers        []sim.Buffer
+	timeLimit      sim.VTimeInSec
+	stallTimeout   time.Duration
+	numOldestTasks int
+	out            io.Writer
+	exit           func(code int)
+
+	numEvents uint64
+
+	tasksLock sync.Mutex
+	tasks     map[string]map[string]inFlightTask
+
+	triggerOnce sync.Once
+	stopChan    chan struct{}
+}
+
+type inFlightTask struct {
+	task      tracing.Task
+	startTime sim.VTimeInSec
+}
+
+// NewWatchdog creates a new watchdog that watches the given engine.
+func NewWatchdog(engine sim.Engine) *Watchdog {
+	return &Watchdog{
+		engine:         engine,
+		numOldestTasks: 5,
+		out:            os.Stderr,
+		exit:           atexit.Exit,
+		tasks:          make(map[string]map[string]inFlightTask),
+	}
+}
+
+// WithTimeLimit sets the simulated time limit. The watchdog triggers when
+// the engine is about to handle an event after the limit. A limit of 0
+// means no limit.
+func (w *Watchdog) WithTimeLimit(limit sim.VTimeInSec) *Watchdog {
+	w.timeLimit = limit
+	return w
+}
+
+// WithStallTimeout sets how long in wall-clock time the engine can go
+// without handling an event while requests are outstanding. A timeout of 0
+// disables the stall detection.
+func (w *Watchdog) WithStallTimeout(timeout time.Duration) *Watchdog {
+	w.stallTimeout = timeout
+	return w
+}
+
+// WithNumOldestTasks sets how many in-flight tasks are reported for each
+// component.
+func (w *Watchdog) WithNumOldestTasks(n int) *Watchdog {
+	w.numOldestTasks = n
+	return w
+}
+
+// WithReportWriter sets where the report is written to. By default, the
+// report is written to the standard error.
+func (w *Watchdog) WithReportWriter(out io.Writer) *Watchdog {
+	w.out = out
+	return w
+}
+
+// RegisterComponent registers a component to be watched. The watchdog keeps
+// track of the tasks of the component and includes the buffers and the
+// ports of the component in the report.
+func (w *Watchdog) RegisterComponent(c sim.Component) {
+	w.components = append(w.components, c)
+	w.buffers = append(w.buffers, componentBuffers(c)...)
+
+	c.AcceptHook(w)
+}
+
+// Start starts watching the engine.
+func (w *Watchdog) Start() {
+	w.engine.AcceptHook(w)
+
+	if w.stallTimeout == 0 {
+		return
+	}
+
+	w.stopChan = make(chan struct{})
+	go w.watchStall(w.stopChan)
+}
+
+// Stop stops the stall detection. The time limit is still enforced.
+func (w *Watchdog) Stop() {
+	if w.stopChan != nil {
+		close(w.stopChan)
+		w.stopChan = nil
+	}
+}
+
+// Func is called when an event is handled or a task starts or ends.
+func (w *Watchdog) Func(ctx sim.HookCtx) {
+	switch ctx.Pos {
+	case sim.HookPosBeforeEvent:
+		w.beforeEvent(ctx.Item.(sim.Event))
+	case sim.HookPosAfterEvent:
+		atomic.AddUint64(&w.numEvents, 1)
+	case tracing.HookPosTaskStart:
+		w.startTask(ctx)
+	case tracing.HookPosTaskEnd:
+		w.endTask(ctx)
+	}
+}
+
+func (w *Watchdog) beforeEvent(evt sim.Event) {
+	if w.timeLimit == 0 || evt.Time() <= w.timeLimit {
+		return
+	}
+
+	w.trigger(fmt.Sprintf(
+		"simulated time %.10f s exceeds the limit of %.10f s",
+		evt.Time(), w.timeLimit))
+}
+
+func (w *Watchdog) startTask(ctx sim.HookCtx) {
+	task := ctx.Item.(tracing.Task)
+	domain := ctx.Domain.(sim.Named).Name()
+
+	w.tasksLock.Lock()
+	defer w.tasksLock.Unlock()
+
+	tasks, found := w.tasks[domain]
+	if !found {
+		tasks = make(map[string]inFlightTask)
+		w.tasks[domain] = tasks
+	}
+
+	tasks[task.ID] = inFlightTask{
+		task:      task,
+		startTime: w.engine.CurrentTime(),
+	}
+}
+
+func (w *Watchdog) endTask(ctx sim.HookCtx) {
+	task := ctx.Item.(tracing.Task)
+	domain := ctx.Domain.(sim.Named).Name()
+
+	w.tasksLock.Lock()
+	defer w.tasksLock.Unlock()
+
+	delete(w.tasks[domain], task.ID)
+}
+
+func (w *Watchdog) watchStall(stopChan chan struct{}) {
+	ticker := time.NewTicker(w.stallTimeout)
+	defer ticker.Stop()
+
+	lastNumEvents := atomic.LoadUint64(&w.numEvents)
+
+	for {
+		select {
+		case <-stopChan:
+			return
+		case <-ticker.C:
+			numEvents := atomic.LoadUint64(&w.numEvents)
+			if numEvents == lastNumEvents && w.hasOutstandingWork() {
+				w.trigger(fmt.Sprintf(
+					"no event handled in %s while requests are outstanding",
+					w.stallTimeout))
+			}
+
+			lastNumEvents = numEvents
+		}
+	}
+}
+
+func (w *Watchdog) hasOutstandingWork() bool {
+	if w.hasInFlightSubTasks() {
+		return true
+	}
+
+	for _, b := range w.buffers {
+		if b.Size() > 0 {
+			return true
+		}
+	}
+
+	for _, c := range w.components {
+		for _, p := range c.Ports() {
+			if p.Peek() != nil {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// hasInFlightSubTasks checks if there are in-flight tasks other than the
+// root tasks. A root task, such as the one that covers the whole simulation,
+// stays in flight while the simulator waits for the host program.
+func (w *Watchdog) hasInFlightSubTasks() bool {
+	w.tasksLock.Lock()
+	defer w.tasksLock.Unlock()
+
+	for _, tasks := range w.tasks {
+		for _, t := range tasks {
+			if t.task.ParentID != "" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (w *Watchdog) trigger(reason string) {
+	w.triggerOnce.Do(func() {
+		fmt.Fprintf(w.out, "Watchdog: %s at %.10f s.\n",
+			reason, w.engine.CurrentTime())
+		w.Report(w.out)
+		w.exit(1)
+	})
+}
+
+// Report writes the full buffers, the ports with pending messages, and the
+// oldest in-flight tasks of each component to the given writer.
+func (w *Watchdog) Report(out io.Writer) {
+	w.reportFullBuffers(out)
+	w.reportPendingPorts(out)
+	w.reportInFlightTasks(out)
+}
+
+func (w *Watchdog) reportFullBuffers(out io.Writer) {
+	fmt.Fprintln(out, "Full buffers:")
+
+	for _, b := range w.buffers {
+		if b.Size() >= b.Capacity() {
+			fmt.Fprintf(out, "\t%s: %d/%d\n", b.Name(), b.Size(), b.Capacity())
+		}
+	}
+}
+
+func (w *Watchdog) reportPendingPorts(out io.Writer) {
+	fmt.Fprintln(out, "Ports with pending messages:")
+
+	for _, c := range w.components {
+		for _, p := range c.Ports() {
+			msg := p.Peek()
+			if msg == nil {
+				continue
+			}
+
+			fmt.Fprintf(out, "\t%s: %T %s\n", p.Name(), msg, msgSummary(msg))
+		}
+	}
+}
+
+func msgSummary(msg sim.Msg) string {
+	meta := msg.Meta()
+
+	src, dst := "", ""
+	if meta.Src != nil {
+		src = meta.Src.Name()
+	}
+
+	if meta.Dst != nil {
+		dst = meta.Dst.Name()
+	}
+
+	return fmt.Sprintf("(id %s, %s -> %s, sent at %.10f s)",
+		meta.ID, src, dst, meta.SendTime)
+}
+
+func (w *Watchdog) reportInFlightTasks(out io.Writer) {
+	fmt.Fprintln(out, "Oldest in-flight tasks:")
+
+	w.tasksLock.Lock()
+	defer w.tasksLock.Unlock()
+
+	for _, c := range w.components {
+		tasks := w.oldestTasks(c.Name())
+		if len(tasks) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(out, "\t%s (%d in flight):\n",
+			c.Name(), len(w.tasks[c.Name()]))
+
+		for _, t := range tasks {
+			fmt.Fprintf(out, "\t\t%s %s %s (started at %.10f s)\n",
+				t.task.ID, t.task.Kind, t.task.What, t.startTime)
+		}
+	}
+}
+
+func (w *Watchdog) oldestTasks(domain string) []inFlightTask {
+	tasks := make([]inFlightTask, 0, len(w.tasks[domain]))
+	for _, t := range w.tasks[domain] {
+		tasks = append(tasks, t)
+	}
+
+	sort.Slice(tasks, func(i, j int) bool {
+		if tasks[i].startTime != tasks[j].startTime {
+			return tasks[i].startTime < tasks[j].startTime
+		}
+
+		return tasks[i].task.ID < tasks[j].task.ID
+	})
+
+	if len(tasks) > w.numOldestTasks {
+		tasks = tasks[:w.numOldestTasks]
+	}
+
+	return tasks
+}
@@ -0,0 +1,94 @@
+package monitoring
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/akita/v3/tracing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Watchdog", func() {
+	var (
+		engine   *sim.SerialEngine
+		comp     *sampleComponent
+		out      *bytes.Buffer
+		exitCode chan int
+		w        *Watchdog
+	)
+
+	BeforeEach(func() {
+		engine = sim.NewSerialEngine()
+		comp = newSampleComponent()
+		out = bytes.NewBuffer(nil)
+		exitCode = make(chan int, 1)
+
+		w = NewWatchdog(engine).WithReportWriter(out)
+		w.exit = func(code int) { exitCode <- code }
+		w.RegisterComponent(comp)
+	})
+
+	AfterEach(func() {
+		w.Stop()
+	})
+
+	It("should exit when the time limit is exceeded", func() {
+		w.WithTimeLimit(1.0).Start()
+		engine.Schedule(sim.NewEventBase(2.0, comp))
+
+		err := engine.Run()
+
+		Expect(err).To(BeNil())
+		Expect(exitCode).To(Receive(Equal(1)))
+		Expect(out.String()).To(ContainSubstring("exceeds the limit"))
+	})
+
+	It("should not exit before the time limit", func() {
+		w.WithTimeLimit(1.0).Start()
+		engine.Schedule(sim.NewEventBase(0.5, comp))
+
+		err := engine.Run()
+
+		Expect(err).To(BeNil())
+		Expect(exitCode).NotTo(Receive())
+	})
+
+	It("should exit when no progress is made with tasks in flight", func() {
+		tracing.StartTask("task1", "root", comp, "req_in", "read", nil)
+
+		w.WithStallTimeout(10 * time.Millisecond).Start()
+
+		Eventually(exitCode).Should(Receive(Equal(1)))
+		Expect(out.String()).To(ContainSubstring("no event handled"))
+		Expect(out.String()).To(ContainSubstring("task1 req_in read"))
+	})
+
+	It("should not exit when no request is outstanding", func() {
+		tracing.StartTask("root", "", comp, "Simulation", "Simulation", nil)
+		tracing.StartTask("task1", "root", comp, "req_in", "read", nil)
+		tracing.EndTask("task1", comp)
+
+		w.WithStallTimeout(10 * time.Millisecond).Start()
+
+		Consistently(exitCode, 50*time.Millisecond).ShouldNot(Receive())
+	})
+
+	It("should report full buffers and pending messages", func() {
+		for i := 0; i < comp.buffer.Capacity(); i++ {
+			comp.buffer.Push(i)
+		}
+
+		port := comp.GetPortByName("Port1")
+		msg := sim.GeneralRspBuilder{}.WithDst(port).Build()
+		port.Recv(msg)
+
+		w.Report(out)
+
+		Expect(out.String()).To(ContainSubstring("Comp.Buf: 10/10"))
+		Expect(out.String()).To(ContainSubstring(
+			"Comp.Port1: *sim.GeneralRsp (id " + msg.ID))
+	})
+})
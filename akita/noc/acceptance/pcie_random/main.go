@@ -7,6 +7,7 @@ import (
 
 	"github.com/sarchlab/akita/v3/monitoring"
 	"github.com/sarchlab/akita/v3/noc/acceptance"
+	"github.com/sarchlab/akita/v3/noc/networking/arbitration"
 	"github.com/sarchlab/akita/v3/noc/networking/pcie"
 	"github.com/sarchlab/akita/v3/sim"
 	"github.com/tebeka/atexit"
@@ -15,6 +16,10 @@ import (
 var numDevicePerSwitch = 8
 var numPortPerDevice = 9
 
+var arbiterFlag = flag.String("arbiter", "xbar",
+	"The arbitration policy of the switches, one of xbar, priority, age, "+
+		"wfq, and islip.")
+
 func main() {
 	flag.Parse()
 	rand.Seed(1)
@@ -55,6 +60,7 @@ func createNetwork(engine sim.Engine, test *acceptance.Test) {
 
 	pcieConnector := pcie.NewConnector()
 	pcieConnector = pcieConnector.
+		WithArbiterCreator(newArbiter).
 		WithEngine(engine).
 		WithFrequency(freq).
 		WithMonitor(monitor).
@@ -75,3 +81,20 @@ func createNetwork(engine sim.Engine, test *acceptance.Test) {
 
 	pcieConnector.EstablishRoute()
 }
+
+func newArbiter() arbitration.Arbiter {
+	switch *arbiterFlag {
+	case "xbar":
+		return arbitration.NewXBarArbiter()
+	case "priority":
+		return arbitration.NewPriorityRoundRobinArbiter(nil)
+	case "age":
+		return arbitration.NewAgeBasedArbiter()
+	case "wfq":
+		return arbitration.NewWeightedFairArbiter(nil)
+	case "islip":
+		return arbitration.NewISLIPArbiter(1)
+	default:
+		panic(fmt.Sprintf("unknown arbiter %s", *arbiterFlag))
+	}
+}
@@ -15,6 +15,10 @@ type channelEnd struct {
 	postPipelineBuf sim.Buffer
 	pipeline        pipelining.Pipeline
 	busy            bool
+
+	// readyTimes are the times that the messages in the postPipelineBuf can
+	// be delivered. It is only used with a LinkModel.
+	readyTimes []sim.VTimeInSec
 }
 
 // Channel connects two ports and can deliver messages with configurable
@@ -28,6 +32,8 @@ type Channel struct {
 	pipelineNumStages      int
 	pipelineCyclesPerStage int
 	pipelineWidth          int
+
+	link *linkState
 }
 
 // PlugIn marks the port to be connected with the connection.
@@ -116,6 +122,10 @@ func (c *Channel) Send(msg sim.Msg) *sim.SendError {
 
 	srcEnd.srcSideBuf.Push(msg)
 
+	if c.link != nil {
+		c.link.startTransfer(msg.Meta().SendTime, c.Freq.Period())
+	}
+
 	// fmt.Printf("%.10f, %s, send, %s\n",
 	// 	c.Engine.CurrentTime(), c.Name(), msg.Meta().ID)
 
@@ -160,8 +170,15 @@ func (c *Channel) moveMsgOneEnd(now sim.VTimeInSec, end *channelEnd) bool {
 	madeProgress := false
 
 	madeProgress = end.pipeline.Tick(now) || madeProgress
+	c.recordReadyTimes(now, end)
 
 	for end.srcSideBuf.Size() > 0 {
+		if c.link != nil && now < c.link.awakeAt {
+			// Keep ticking until the link wakes up.
+			madeProgress = true
+			break
+		}
+
 		if !end.pipeline.CanAccept() {
 			break
 		}
@@ -201,7 +218,10 @@ func (c *Channel) traceTransferTaskStart(
 		panic("channel internal error")
 	}
 
-	flitKind := fmt.Sprintf("flit.%s", reflect.TypeOf(msg.(*Flit).Msg))
+	flitKind := fmt.Sprintf("msg.%s", reflect.TypeOf(msg))
+	if flit, ok := msg.(*Flit); ok {
+		flitKind = fmt.Sprintf("flit.%s", reflect.TypeOf(flit.Msg))
+	}
 
 	tracing.StartTaskWithSpecificLocation(
 		c.channelMsgTaskID(msg),
@@ -210,6 +230,32 @@ func (c *Channel) traceTransferTaskStart(
 		c, flitKind, "flit_through_channel", direction, msg)
 }
 
+// recordReadyTimes decides when the messages that just left the pipeline can
+// be delivered.
+func (c *Channel) recordReadyTimes(now sim.VTimeInSec, end *channelEnd) {
+	if c.link == nil {
+		return
+	}
+
+	for len(end.readyTimes) < end.postPipelineBuf.Size() {
+		end.readyTimes = append(end.readyTimes,
+			c.link.readyTime(now, c.Freq.Period()))
+	}
+}
+
+// LinkStats returns the activities and the energy of the channel so far. It
+// returns empty stats if the channel does not have a LinkModel.
+func (c *Channel) LinkStats() LinkStats {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.link == nil {
+		return LinkStats{}
+	}
+
+	return c.link.stats(c.Engine.CurrentTime(), c.Freq.Period())
+}
+
 func (c *Channel) deliver(now sim.VTimeInSec) bool {
 	madeProgress := false
 
@@ -226,6 +272,12 @@ func (c *Channel) deliverOneEnd(
 	madeProgress := false
 
 	for srcEnd.postPipelineBuf.Size() > 0 {
+		if c.link != nil && now < srcEnd.readyTimes[0] {
+			// Keep ticking until the message can be delivered.
+			madeProgress = true
+			break
+		}
+
 		msgTask := srcEnd.postPipelineBuf.Peek().(msgPipeTask)
 		msg := msgTask.msg
 		msg.Meta().RecvTime = now
@@ -241,6 +293,11 @@ func (c *Channel) deliverOneEnd(
 		srcEnd.postPipelineBuf.Pop()
 		madeProgress = true
 
+		if c.link != nil {
+			srcEnd.readyTimes = srcEnd.readyTimes[1:]
+			c.link.finishTransfer(now)
+		}
+
 		tracing.EndTask(c.channelMsgTaskID(msg), c)
 	}
 
@@ -280,6 +337,7 @@ type ChannelBuilder struct {
 	pipelineNumStages      int
 	pipelineCyclesPerStage int
 	pipelineWidth          int
+	linkModel              *LinkModel
 }
 
 // MakeChannelBuilder creates a ChannelBuilder.
@@ -313,6 +371,13 @@ func (b ChannelBuilder) WithPipelineParameters(
 	return b
 }
 
+// WithLinkModel sets the serialization, the error, the power-state, and the
+// energy behaviors of the channel to be built.
+func (b ChannelBuilder) WithLinkModel(model LinkModel) ChannelBuilder {
+	b.linkModel = &model
+	return b
+}
+
 // Build creates a new channel with the given name.
 func (b ChannelBuilder) Build(name string) *Channel {
 	c := &Channel{
@@ -330,6 +395,10 @@ func (b ChannelBuilder) Build(name string) *Channel {
 
 	c.ends = make(map[sim.Port]*channelEnd)
 
+	if b.linkModel != nil {
+		c.link = newLinkState(name, *b.linkModel)
+	}
+
 	return c
 }
 
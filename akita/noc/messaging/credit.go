@@ -0,0 +1,105 @@
+package messaging
+
+import (
+	"hash/fnv"
+
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+// CreditMsg returns the buffer slots of a virtual channel to the sender on
+// the other side of a link.
+type CreditMsg struct {
+	sim.MsgMeta
+	VC         int
+	NumCredits int
+}
+
+// Meta returns the meta data associated with the CreditMsg.
+func (m *CreditMsg) Meta() *sim.MsgMeta {
+	return &m.MsgMeta
+}
+
+// CreditMsgBuilder can build credit messages.
+type CreditMsgBuilder struct {
+	sendTime   sim.VTimeInSec
+	src, dst   sim.Port
+	vc         int
+	numCredits int
+}
+
+// WithSendTime sets the send time of the message to build.
+func (b CreditMsgBuilder) WithSendTime(t sim.VTimeInSec) CreditMsgBuilder {
+	b.sendTime = t
+	return b
+}
+
+// WithSrc sets the src of the message to build.
+func (b CreditMsgBuilder) WithSrc(src sim.Port) CreditMsgBuilder {
+	b.src = src
+	return b
+}
+
+// WithDst sets the dst of the message to build.
+func (b CreditMsgBuilder) WithDst(dst sim.Port) CreditMsgBuilder {
+	b.dst = dst
+	return b
+}
+
+// WithVC sets the virtual channel that the credits belong to.
+func (b CreditMsgBuilder) WithVC(vc int) CreditMsgBuilder {
+	b.vc = vc
+	return b
+}
+
+// WithNumCredits sets the number of buffer slots to return.
+func (b CreditMsgBuilder) WithNumCredits(n int) CreditMsgBuilder {
+	b.numCredits = n
+	return b
+}
+
+// Build creates a new credit message.
+func (b CreditMsgBuilder) Build() *CreditMsg {
+	m := &CreditMsg{}
+	m.ID = sim.IDGeneratorFor(b.src).Generate()
+	m.SendTime = b.sendTime
+	m.Src = b.src
+	m.Dst = b.dst
+	m.VC = b.vc
+	m.NumCredits = b.numCredits
+	return m
+}
+
+// VCForMsg returns the virtual channel that carries the message on a link with
+// numVCs virtual channels. Requests use the first half of the virtual
+// channels and responses use the second half, so that responses are never
+// blocked behind requests. All the flits of a message use the same virtual
+// channel.
+func VCForMsg(msg sim.Msg, numVCs int) int {
+	if numVCs <= 1 {
+		return 0
+	}
+
+	first, count := VCRangeForMsg(msg, numVCs)
+	if count == 1 {
+		return first
+	}
+
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(msg.Meta().ID))
+
+	return first + int(h.Sum32()%uint32(count))
+}
+
+// VCRangeForMsg returns the range of the virtual channels that the class of
+// the message can use on a link with numVCs virtual channels.
+func VCRangeForMsg(msg sim.Msg, numVCs int) (first, count int) {
+	if numVCs <= 1 {
+		return 0, 1
+	}
+
+	if _, isRsp := msg.(sim.Rsp); isRsp {
+		return numVCs / 2, numVCs - numVCs/2
+	}
+
+	return 0, numVCs / 2
+}
@@ -0,0 +1,32 @@
+package messaging
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+var _ = Describe("VCForMsg", func() {
+	It("should use a single VC if there is only one", func() {
+		req := &testMsg{}
+		req.ID = sim.GetIDGenerator().Generate()
+		rsp := sim.GeneralRspBuilder{}.Build()
+
+		Expect(VCForMsg(req, 1)).To(Equal(0))
+		Expect(VCForMsg(rsp, 1)).To(Equal(0))
+	})
+
+	It("should separate requests and responses", func() {
+		for i := 0; i < 16; i++ {
+			req := &testMsg{}
+			req.ID = sim.GetIDGenerator().Generate()
+			rsp := sim.GeneralRspBuilder{}.Build()
+
+			Expect(VCForMsg(req, 2)).To(Equal(0))
+			Expect(VCForMsg(rsp, 2)).To(Equal(1))
+			Expect(VCForMsg(req, 4)).To(BeNumerically("<", 2))
+			Expect(VCForMsg(rsp, 4)).To(BeNumerically(">=", 2))
+			Expect(VCForMsg(rsp, 4)).To(BeNumerically("<", 4))
+		}
+	})
+})
@@ -13,6 +13,7 @@ type Flit struct {
 	NumFlitInMsg int
 	Msg          sim.Msg
 	OutputBuf    sim.Buffer // The buffer to route to within a switch
+	VC           int        // The virtual channel used on the current link
 }
 
 // Meta returns the meta data associated with the Flit.
@@ -69,7 +70,7 @@ func (b FlitBuilder) Build() *Flit {
 	f := &Flit{}
 	f.ID = fmt.Sprintf("flit-%d-msg-%s-%s",
 		b.seqID, b.msg.Meta().ID,
-		sim.GetIDGenerator().Generate())
+		sim.IDGeneratorFor(b.src).Generate())
 	f.SendTime = b.sendTime
 	f.Src = b.src
 	f.Dst = b.dst
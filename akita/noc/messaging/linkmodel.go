@@ -0,0 +1,231 @@
+package messaging
+
+import (
+	"hash/fnv"
+	"math"
+	"math/rand"
+
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+// LinkModel defines the physical-layer and link-layer behaviors of a Channel
+// beyond its bandwidth and pipeline latency. The zero value disables all the
+// behaviors. The latencies are in the cycles of the channel.
+type LinkModel struct {
+	// SerDesLatency is the number of cycles that the serializer and the
+	// deserializer add to each transfer. It is pipelined, so it does not
+	// reduce the bandwidth.
+	SerDesLatency int
+
+	// BitErrorRate is the probability that a bit is corrupted. A corrupted
+	// transfer is detected by the receiver and retransmitted by the link
+	// layer, which takes RetryLatency cycles. The link delivers messages in
+	// order, so the messages behind the corrupted one also wait.
+	BitErrorRate float64
+	RetryLatency int
+
+	// TransferSizeInBits is the number of bits of each transfer, which is
+	// usually the flit size. It decides how likely a transfer is corrupted.
+	TransferSizeInBits int
+
+	// The link enters L0s after being idle for L0sEntryLatency cycles and L1
+	// after being idle for L1EntryLatency cycles. A latency of 0 disables the
+	// state. The link cannot transfer until it wakes up, which takes the exit
+	// latency of the state.
+	L0sEntryLatency int
+	L0sExitLatency  int
+	L1EntryLatency  int
+	L1ExitLatency   int
+
+	// EnergyPerTransfer is the energy, in joules, of moving one transfer over
+	// the link, including the retransmissions.
+	EnergyPerTransfer float64
+
+	// ActivePower, L0sPower, and L1Power are the static power, in watts, of
+	// the link in the active and the low-power states.
+	ActivePower float64
+	L0sPower    float64
+	L1Power     float64
+}
+
+// The power states of a link.
+const (
+	linkStateL0 = iota
+	linkStateL0s
+	linkStateL1
+	numLinkStates
+)
+
+// LinkStats summarizes the activities and the energy of a Channel.
+type LinkStats struct {
+	NumTransfers  uint64
+	NumRetries    uint64
+	NumWakeups    uint64
+	TimeInL0      sim.VTimeInSec
+	TimeInL0s     sim.VTimeInSec
+	TimeInL1      sim.VTimeInSec
+	DynamicEnergy float64
+	StaticEnergy  float64
+}
+
+// TotalEnergy returns the dynamic and the static energy in joules.
+func (s LinkStats) TotalEnergy() float64 {
+	return s.DynamicEnergy + s.StaticEnergy
+}
+
+// linkState keeps the states of the LinkModel of a channel.
+type linkState struct {
+	model LinkModel
+	rng   *rand.Rand
+
+	// transferErrorRate is the probability that a transfer is corrupted.
+	transferErrorRate float64
+
+	numInFlight    int
+	lastActiveTime sim.VTimeInSec
+	accountedUntil sim.VTimeInSec
+	awakeAt        sim.VTimeInSec
+	residency      [numLinkStates]sim.VTimeInSec
+
+	numTransfers uint64
+	numRetries   uint64
+	numWakeups   uint64
+}
+
+func newLinkState(name string, model LinkModel) *linkState {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(name))
+
+	s := &linkState{
+		model: model,
+		rng:   rand.New(rand.NewSource(int64(h.Sum64()))),
+	}
+
+	if model.BitErrorRate >= 1 {
+		panic("bit error rate must be less than 1")
+	}
+
+	if model.BitErrorRate > 0 {
+		s.transferErrorRate = 1 - math.Pow(1-model.BitErrorRate,
+			float64(model.TransferSizeInBits))
+	}
+
+	return s
+}
+
+// idleResidency splits an idle period into the time spent in each state.
+func (s *linkState) idleResidency(
+	idle sim.VTimeInSec,
+	period sim.VTimeInSec,
+) (residency [numLinkStates]sim.VTimeInSec, deepest int) {
+	l0sStart := idle
+	if s.model.L0sEntryLatency > 0 {
+		l0sStart = sim.VTimeInSec(math.Min(float64(idle),
+			float64(period)*float64(s.model.L0sEntryLatency)))
+	}
+
+	l1Start := idle
+	if s.model.L1EntryLatency > 0 {
+		l1Start = sim.VTimeInSec(math.Min(float64(idle),
+			float64(period)*float64(s.model.L1EntryLatency)))
+	}
+
+	if l1Start < l0sStart {
+		l0sStart = l1Start
+	}
+
+	residency[linkStateL0] = l0sStart
+	residency[linkStateL0s] = l1Start - l0sStart
+	residency[linkStateL1] = idle - l1Start
+
+	deepest = linkStateL0
+	if residency[linkStateL0s] > 0 {
+		deepest = linkStateL0s
+	}
+
+	if residency[linkStateL1] > 0 {
+		deepest = linkStateL1
+	}
+
+	return residency, deepest
+}
+
+// startTransfer records that a message enters the link. If the link is in a
+// low-power state, the link starts to wake up.
+func (s *linkState) startTransfer(now, period sim.VTimeInSec) {
+	if s.numInFlight == 0 {
+		s.residency[linkStateL0] += s.lastActiveTime - s.accountedUntil
+
+		idle, deepest := s.idleResidency(now-s.lastActiveTime, period)
+		for state := range idle {
+			s.residency[state] += idle[state]
+		}
+
+		s.accountedUntil = now
+
+		switch deepest {
+		case linkStateL0s:
+			s.awakeAt = now + period*sim.VTimeInSec(s.model.L0sExitLatency)
+			s.numWakeups++
+		case linkStateL1:
+			s.awakeAt = now + period*sim.VTimeInSec(s.model.L1ExitLatency)
+			s.numWakeups++
+		}
+	}
+
+	s.numInFlight++
+}
+
+// finishTransfer records that a message leaves the link.
+func (s *linkState) finishTransfer(now sim.VTimeInSec) {
+	s.numInFlight--
+	if s.numInFlight == 0 {
+		s.lastActiveTime = now
+	}
+}
+
+// readyTime returns the time that a transfer that leaves the pipeline can be
+// delivered, considering the SerDes latency and the retries.
+func (s *linkState) readyTime(now, period sim.VTimeInSec) sim.VTimeInSec {
+	s.numTransfers++
+	ready := now + period*sim.VTimeInSec(s.model.SerDesLatency)
+
+	for s.transferErrorRate > 0 && s.rng.Float64() < s.transferErrorRate {
+		s.numTransfers++
+		s.numRetries++
+		ready += period * sim.VTimeInSec(s.model.RetryLatency)
+	}
+
+	return ready
+}
+
+func (s *linkState) stats(now, period sim.VTimeInSec) LinkStats {
+	residency := s.residency
+
+	if s.numInFlight == 0 && now > s.lastActiveTime {
+		residency[linkStateL0] += s.lastActiveTime - s.accountedUntil
+
+		idle, _ := s.idleResidency(now-s.lastActiveTime, period)
+		for state := range idle {
+			residency[state] += idle[state]
+		}
+	} else if now > s.accountedUntil {
+		residency[linkStateL0] += now - s.accountedUntil
+	}
+
+	stats := LinkStats{
+		NumTransfers: s.numTransfers,
+		NumRetries:   s.numRetries,
+		NumWakeups:   s.numWakeups,
+		TimeInL0:     residency[linkStateL0],
+		TimeInL0s:    residency[linkStateL0s],
+		TimeInL1:     residency[linkStateL1],
+	}
+
+	stats.DynamicEnergy = float64(s.numTransfers) * s.model.EnergyPerTransfer
+	stats.StaticEnergy = float64(stats.TimeInL0)*s.model.ActivePower +
+		float64(stats.TimeInL0s)*s.model.L0sPower +
+		float64(stats.TimeInL1)*s.model.L1Power
+
+	return stats
+}
@@ -0,0 +1,77 @@
+package messaging
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+var _ = Describe("LinkModel", func() {
+	const period = sim.VTimeInSec(1e-9)
+
+	It("should add the SerDes latency", func() {
+		s := newLinkState("Link", LinkModel{SerDesLatency: 5})
+
+		Expect(s.readyTime(10e-9, period)).
+			To(BeNumerically("~", 15e-9, 1e-15))
+	})
+
+	It("should retry the corrupted transfers", func() {
+		// Each transfer is corrupted with a probability of about 0.5.
+		s := newLinkState("Link", LinkModel{
+			BitErrorRate:       0.0027,
+			TransferSizeInBits: 256,
+			RetryLatency:       10,
+		})
+
+		for i := 0; i < 10000; i++ {
+			s.readyTime(0, period)
+		}
+
+		Expect(s.numRetries).To(BeNumerically("~", 10000, 500))
+		Expect(s.numTransfers).To(Equal(10000 + s.numRetries))
+	})
+
+	It("should wake up from the low-power states", func() {
+		s := newLinkState("Link", LinkModel{
+			L0sEntryLatency: 10,
+			L0sExitLatency:  2,
+			L1EntryLatency:  100,
+			L1ExitLatency:   50,
+		})
+
+		s.startTransfer(5e-9, period)
+		Expect(s.awakeAt).To(Equal(sim.VTimeInSec(0)))
+		s.finishTransfer(10e-9)
+
+		s.startTransfer(30e-9, period)
+		Expect(s.awakeAt).To(BeNumerically("~", 32e-9, 1e-15))
+		s.finishTransfer(40e-9)
+
+		s.startTransfer(1040e-9, period)
+		Expect(s.awakeAt).To(BeNumerically("~", 1090e-9, 1e-15))
+		Expect(s.numWakeups).To(Equal(uint64(2)))
+	})
+
+	It("should account the energy", func() {
+		s := newLinkState("Link", LinkModel{
+			L1EntryLatency:    100,
+			EnergyPerTransfer: 1e-12,
+			ActivePower:       1,
+			L1Power:           0.1,
+		})
+
+		s.startTransfer(0, period)
+		s.readyTime(0, period)
+		s.finishTransfer(100e-9)
+
+		stats := s.stats(1100e-9, period)
+
+		Expect(stats.NumTransfers).To(Equal(uint64(1)))
+		Expect(float64(stats.TimeInL0)).To(BeNumerically("~", 200e-9, 1e-15))
+		Expect(float64(stats.TimeInL1)).To(BeNumerically("~", 900e-9, 1e-15))
+		Expect(stats.DynamicEnergy).To(BeNumerically("~", 1e-12))
+		Expect(stats.StaticEnergy).To(BeNumerically("~", 290e-9, 1e-15))
+		Expect(stats.TotalEnergy()).To(BeNumerically("~", 290.001e-9, 1e-15))
+	})
+})
@@ -0,0 +1,41 @@
+package arbitration
+
+import (
+	"sort"
+
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+// NewAgeBasedArbiter creates an arbiter that grants the oldest flits first.
+// The age of a flit is determined by the time that its message is sent by the
+// source. Ties are broken in a round-robin manner.
+func NewAgeBasedArbiter() Arbiter {
+	return &ageBasedArbiter{}
+}
+
+type ageBasedArbiter struct {
+	arbiterBase
+
+	nextInput int
+}
+
+func (a *ageBasedArbiter) Arbitrate(now sim.VTimeInSec) []sim.Buffer {
+	reqs := a.requests()
+	if len(reqs) == 0 {
+		return nil
+	}
+
+	ordered := rotate(reqs, a.nextInput, len(a.buffers))
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].flit.Msg.Meta().SendTime <
+			ordered[j].flit.Msg.Meta().SendTime
+	})
+
+	granted := grantDistinctOutputs(ordered)
+
+	a.nextInput = (a.nextInput + 1) % len(a.buffers)
+
+	a.report(reqs, granted)
+
+	return granted
+}
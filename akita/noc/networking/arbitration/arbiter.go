@@ -1,14 +1,110 @@
 package arbitration
 
 import (
+	"github.com/sarchlab/akita/v3/noc/messaging"
 	"github.com/sarchlab/akita/v3/sim"
 )
 
 // Arbiter can determine which buffer can send a message out
 type Arbiter interface {
+	sim.Hookable
+
 	// Add a buffer for arbitration
 	AddBuffer(buf sim.Buffer)
 
 	// Arbitrate returns a set of ports that can send request in the next cycle.
 	Arbitrate(now sim.VTimeInSec) []sim.Buffer
+
+	// SetBlockedFunc sets the function that tells if the flit at the head of
+	// a buffer cannot be forwarded, for example, because its virtual channel
+	// has no credit. Blocked buffers do not request, so that the grants go to
+	// the buffers that can make progress.
+	SetBlockedFunc(isBlocked func(buf sim.Buffer) bool)
+}
+
+// HookPosArbiterRequest marks when an input buffer has a flit that waits to be
+// forwarded. The item is the buffer and the detail is the flit.
+var HookPosArbiterRequest = &sim.HookPos{Name: "Arbiter Request"}
+
+// HookPosArbiterGrant marks when an input buffer is granted to forward its
+// flit. The item is the buffer and the detail is the flit.
+var HookPosArbiterGrant = &sim.HookPos{Name: "Arbiter Grant"}
+
+// A request is an input buffer that has a flit at its head.
+type request struct {
+	input int
+	buf   sim.Buffer
+	flit  *messaging.Flit
+}
+
+// arbiterBase keeps the input buffers and reports the requests and the grants
+// to the hooks.
+type arbiterBase struct {
+	sim.HookableBase
+
+	buffers   []sim.Buffer
+	isBlocked func(buf sim.Buffer) bool
+}
+
+func (a *arbiterBase) AddBuffer(buf sim.Buffer) {
+	a.buffers = append(a.buffers, buf)
+}
+
+func (a *arbiterBase) SetBlockedFunc(isBlocked func(buf sim.Buffer) bool) {
+	a.isBlocked = isBlocked
+}
+
+// requests returns the buffers that have flits at their heads and are not
+// blocked, in the order of the buffers.
+func (a *arbiterBase) requests() []request {
+	reqs := make([]request, 0, len(a.buffers))
+
+	for i, buf := range a.buffers {
+		item := buf.Peek()
+		if item == nil {
+			continue
+		}
+
+		if a.isBlocked != nil && a.isBlocked(buf) {
+			continue
+		}
+
+		reqs = append(reqs, request{
+			input: i,
+			buf:   buf,
+			flit:  item.(*messaging.Flit),
+		})
+	}
+
+	return reqs
+}
+
+// report invokes the hooks for the requests and the grants.
+func (a *arbiterBase) report(reqs []request, granted []sim.Buffer) {
+	if a.NumHooks() == 0 {
+		return
+	}
+
+	isGranted := make(map[sim.Buffer]bool, len(granted))
+	for _, buf := range granted {
+		isGranted[buf] = true
+	}
+
+	for _, req := range reqs {
+		a.InvokeHook(sim.HookCtx{
+			Domain: a,
+			Pos:    HookPosArbiterRequest,
+			Item:   req.buf,
+			Detail: req.flit,
+		})
+
+		if isGranted[req.buf] {
+			a.InvokeHook(sim.HookCtx{
+				Domain: a,
+				Pos:    HookPosArbiterGrant,
+				Item:   req.buf,
+				Detail: req.flit,
+			})
+		}
+	}
 }
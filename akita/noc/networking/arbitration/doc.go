@@ -1,3 +1,9 @@
 // Package arbitration provides implementations for different arbitation
 // algorithms.
+//
+// The arbiters decide which input buffers of a switch can forward their flits
+// in a cycle. The package provides the crossbar arbiter, the priority
+// round-robin arbiter, the age-based arbiter, the weighted fair arbiter, and
+// the iSLIP arbiter. All the arbiters report the requests and the grants of
+// each input buffer to their hooks, which can be counted with a GrantCounter.
 package arbitration
@@ -0,0 +1,81 @@
+package arbitration
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+// InputGrantStat is the number of requests and grants of an input buffer.
+type InputGrantStat struct {
+	Buffer      string
+	NumRequests uint64
+	NumGrants   uint64
+}
+
+// GrantRatio returns the fraction of the requests that are granted.
+func (s InputGrantStat) GrantRatio() float64 {
+	if s.NumRequests == 0 {
+		return 0
+	}
+
+	return float64(s.NumGrants) / float64(s.NumRequests)
+}
+
+// A GrantCounter is a hook that counts the requests and the grants of the
+// input buffers of the arbiters that it is attached to. A request is counted
+// in each cycle that an input buffer has a flit to forward.
+type GrantCounter struct {
+	lock  sync.Mutex
+	stats map[string]*InputGrantStat
+}
+
+// NewGrantCounter creates a new GrantCounter.
+func NewGrantCounter() *GrantCounter {
+	return &GrantCounter{
+		stats: make(map[string]*InputGrantStat),
+	}
+}
+
+// Func counts the requests and the grants.
+func (c *GrantCounter) Func(ctx sim.HookCtx) {
+	if ctx.Pos != HookPosArbiterRequest && ctx.Pos != HookPosArbiterGrant {
+		return
+	}
+
+	name := ctx.Item.(sim.Buffer).Name()
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	stat, found := c.stats[name]
+	if !found {
+		stat = &InputGrantStat{Buffer: name}
+		c.stats[name] = stat
+	}
+
+	if ctx.Pos == HookPosArbiterRequest {
+		stat.NumRequests++
+	} else {
+		stat.NumGrants++
+	}
+}
+
+// Stats returns the statistics of all the input buffers, sorted by the buffer
+// names.
+func (c *GrantCounter) Stats() []InputGrantStat {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	stats := make([]InputGrantStat, 0, len(c.stats))
+	for _, s := range c.stats {
+		stats = append(stats, *s)
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Buffer < stats[j].Buffer
+	})
+
+	return stats
+}
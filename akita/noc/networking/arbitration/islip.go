@@ -0,0 +1,145 @@
+package arbitration
+
+import (
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+// NewISLIPArbiter creates an arbiter that uses the iSLIP algorithm with the
+// given number of iterations. Each input buffer requests the output of the
+// flit at its head. Each output keeps a grant pointer and each input keeps an
+// accept pointer. The pointers only move when a grant is accepted in the
+// first iteration, which desynchronizes the outputs.
+func NewISLIPArbiter(numIterations int) Arbiter {
+	if numIterations <= 0 {
+		panic("iSLIP requires at least one iteration")
+	}
+
+	return &iSLIPArbiter{
+		numIterations: numIterations,
+		outputIDs:     make(map[sim.Buffer]int),
+	}
+}
+
+type iSLIPArbiter struct {
+	arbiterBase
+
+	numIterations  int
+	outputIDs      map[sim.Buffer]int
+	grantPointers  []int
+	acceptPointers []int
+}
+
+func (a *iSLIPArbiter) AddBuffer(buf sim.Buffer) {
+	a.arbiterBase.AddBuffer(buf)
+	a.acceptPointers = append(a.acceptPointers, 0)
+}
+
+func (a *iSLIPArbiter) outputID(out sim.Buffer) int {
+	id, found := a.outputIDs[out]
+	if !found {
+		id = len(a.outputIDs)
+		a.outputIDs[out] = id
+		a.grantPointers = append(a.grantPointers, 0)
+	}
+
+	return id
+}
+
+func (a *iSLIPArbiter) Arbitrate(now sim.VTimeInSec) []sim.Buffer {
+	reqs := a.requests()
+	if len(reqs) == 0 {
+		return nil
+	}
+
+	matchedInputs := make(map[int]bool)
+	matchedOutputs := make(map[int]bool)
+	granted := make([]sim.Buffer, 0)
+
+	for iter := 0; iter < a.numIterations; iter++ {
+		grants := a.grantPhase(reqs, matchedInputs, matchedOutputs)
+		if len(grants) == 0 {
+			break
+		}
+
+		for _, req := range a.acceptPhase(grants) {
+			out := a.outputID(req.flit.OutputBuf)
+			matchedInputs[req.input] = true
+			matchedOutputs[out] = true
+			granted = append(granted, req.buf)
+
+			if iter == 0 {
+				a.grantPointers[out] = (req.input + 1) % len(a.buffers)
+				a.acceptPointers[req.input] =
+					(out + 1) % len(a.outputIDs)
+			}
+		}
+	}
+
+	a.report(reqs, granted)
+
+	return granted
+}
+
+// grantPhase lets each unmatched output grant the unmatched requesting input
+// that is next to its grant pointer. It returns the grants received by each
+// input.
+func (a *iSLIPArbiter) grantPhase(
+	reqs []request,
+	matchedInputs, matchedOutputs map[int]bool,
+) map[int][]request {
+	best := make(map[int]request)
+
+	for _, req := range reqs {
+		out := a.outputID(req.flit.OutputBuf)
+		if matchedInputs[req.input] || matchedOutputs[out] {
+			continue
+		}
+
+		curr, found := best[out]
+		if !found || a.distance(a.grantPointers[out], req.input, len(a.buffers)) <
+			a.distance(a.grantPointers[out], curr.input, len(a.buffers)) {
+			best[out] = req
+		}
+	}
+
+	grants := make(map[int][]request)
+	for _, req := range best {
+		grants[req.input] = append(grants[req.input], req)
+	}
+
+	return grants
+}
+
+// acceptPhase lets each input accept the granting output that is next to its
+// accept pointer.
+func (a *iSLIPArbiter) acceptPhase(grants map[int][]request) []request {
+	accepted := make([]request, 0, len(grants))
+
+	for input := 0; input < len(a.buffers); input++ {
+		reqs, found := grants[input]
+		if !found {
+			continue
+		}
+
+		selected := reqs[0]
+		for _, req := range reqs[1:] {
+			out := a.outputID(req.flit.OutputBuf)
+			selectedOut := a.outputID(selected.flit.OutputBuf)
+
+			if a.distance(a.acceptPointers[input], out, len(a.outputIDs)) <
+				a.distance(a.acceptPointers[input], selectedOut, len(a.outputIDs)) {
+				selected = req
+			}
+		}
+
+		accepted = append(accepted, selected)
+	}
+
+	return accepted
+}
+
+// distance returns how far an index is after the pointer in a round-robin
+// order.
+func (a *iSLIPArbiter) distance(pointer, index, n int) int {
+	return (index - pointer + n) % n
+}
@@ -0,0 +1,167 @@
+package arbitration
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/akita/v3/noc/messaging"
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+var _ = Describe("Arbitration Policies", func() {
+	var (
+		inputs  []sim.Buffer
+		outputs []sim.Buffer
+	)
+
+	BeforeEach(func() {
+		inputs = nil
+		outputs = nil
+
+		for i := 0; i < 3; i++ {
+			inputs = append(inputs,
+				sim.NewBuffer(fmt.Sprintf("In%d", i), 16))
+			outputs = append(outputs,
+				sim.NewBuffer(fmt.Sprintf("Out%d", i), 16))
+		}
+	})
+
+	pushFlit := func(in, out int, sendTime sim.VTimeInSec) *messaging.Flit {
+		msg := &SampleMsg{}
+		msg.SendTime = sendTime
+		flit := messaging.FlitBuilder{}.WithMsg(msg).Build()
+		flit.OutputBuf = outputs[out]
+		inputs[in].Push(flit)
+
+		return flit
+	}
+
+	addInputs := func(a Arbiter) Arbiter {
+		for _, buf := range inputs {
+			a.AddBuffer(buf)
+		}
+
+		return a
+	}
+
+	// drain arbitrates for a number of cycles. Each input always has a flit
+	// for output 0. It returns the number of grants of each input.
+	drain := func(a Arbiter, numCycles int) []int {
+		grants := make([]int, len(inputs))
+
+		for i := range inputs {
+			pushFlit(i, 0, 0)
+		}
+
+		for c := 0; c < numCycles; c++ {
+			for _, buf := range a.Arbitrate(sim.VTimeInSec(c)) {
+				for i, in := range inputs {
+					if in == buf {
+						grants[i]++
+						in.Pop()
+						pushFlit(i, 0, 0)
+					}
+				}
+			}
+		}
+
+		return grants
+	}
+
+	It("should grant the higher priority first", func() {
+		a := addInputs(NewPriorityRoundRobinArbiter(
+			func(buf sim.Buffer, _ *messaging.Flit) int {
+				if buf == inputs[2] {
+					return 1
+				}
+
+				return 0
+			}))
+
+		pushFlit(0, 0, 0)
+		pushFlit(1, 1, 0)
+		pushFlit(2, 0, 0)
+
+		granted := a.Arbitrate(0)
+
+		Expect(granted).To(ConsistOf(inputs[2], inputs[1]))
+	})
+
+	It("should rotate among the inputs with the same priority", func() {
+		a := addInputs(NewPriorityRoundRobinArbiter(nil))
+
+		Expect(drain(a, 30)).To(Equal([]int{10, 10, 10}))
+	})
+
+	It("should grant the oldest flit first", func() {
+		a := addInputs(NewAgeBasedArbiter())
+
+		pushFlit(0, 0, 3)
+		pushFlit(1, 0, 1)
+		pushFlit(2, 0, 2)
+
+		Expect(a.Arbitrate(10)).To(Equal([]sim.Buffer{inputs[1]}))
+	})
+
+	It("should share an output in proportion to the weights", func() {
+		a := addInputs(NewWeightedFairArbiter(func(buf sim.Buffer) int {
+			if buf == inputs[0] {
+				return 2
+			}
+
+			return 1
+		}))
+
+		Expect(drain(a, 40)).To(Equal([]int{20, 10, 10}))
+	})
+
+	It("should match inputs and outputs with iSLIP", func() {
+		a := addInputs(NewISLIPArbiter(2))
+
+		pushFlit(0, 0, 0)
+		pushFlit(1, 0, 0)
+		pushFlit(2, 1, 0)
+
+		Expect(a.Arbitrate(0)).To(ConsistOf(inputs[0], inputs[2]))
+
+		inputs[0].Pop()
+		inputs[2].Pop()
+		pushFlit(0, 0, 0)
+
+		Expect(a.Arbitrate(1)).To(Equal([]sim.Buffer{inputs[1]}))
+	})
+
+	It("should be fair with iSLIP", func() {
+		a := addInputs(NewISLIPArbiter(1))
+
+		Expect(drain(a, 30)).To(Equal([]int{10, 10, 10}))
+	})
+
+	It("should skip the blocked inputs", func() {
+		a := addInputs(NewPriorityRoundRobinArbiter(nil))
+		a.SetBlockedFunc(func(buf sim.Buffer) bool {
+			return buf == inputs[0]
+		})
+
+		pushFlit(0, 0, 0)
+		pushFlit(1, 0, 0)
+
+		Expect(a.Arbitrate(0)).To(Equal([]sim.Buffer{inputs[1]}))
+	})
+
+	It("should count the requests and the grants", func() {
+		counter := NewGrantCounter()
+		a := addInputs(NewXBarArbiter())
+		a.AcceptHook(counter)
+
+		drain(a, 30)
+
+		stats := counter.Stats()
+		Expect(stats).To(HaveLen(3))
+		Expect(stats[0].Buffer).To(Equal("In0"))
+		Expect(stats[0].NumRequests).To(Equal(uint64(30)))
+		Expect(stats[0].NumGrants).To(Equal(uint64(10)))
+		Expect(stats[0].GrantRatio()).To(BeNumerically("~", 1.0/3))
+	})
+})
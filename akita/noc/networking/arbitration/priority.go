@@ -0,0 +1,58 @@
+package arbitration
+
+import (
+	"sort"
+
+	"github.com/sarchlab/akita/v3/noc/messaging"
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+// NewPriorityRoundRobinArbiter creates an arbiter that grants the flits with
+// higher priorities first. The inputs with the same priority are served in a
+// round-robin manner. The priority function gives the priority of the flit at
+// the head of a buffer, for example, to prioritize the responses or the flits
+// from certain ports. A nil function gives all the flits the same priority.
+func NewPriorityRoundRobinArbiter(
+	priority func(buf sim.Buffer, flit *messaging.Flit) int,
+) Arbiter {
+	return &priorityRoundRobinArbiter{
+		priority: priority,
+	}
+}
+
+type priorityRoundRobinArbiter struct {
+	arbiterBase
+
+	priority  func(buf sim.Buffer, flit *messaging.Flit) int
+	nextInput int
+}
+
+func (a *priorityRoundRobinArbiter) Arbitrate(
+	now sim.VTimeInSec,
+) []sim.Buffer {
+	reqs := a.requests()
+	if len(reqs) == 0 {
+		return nil
+	}
+
+	ordered := rotate(reqs, a.nextInput, len(a.buffers))
+
+	if a.priority != nil {
+		priorities := make(map[int]int, len(ordered))
+		for _, req := range ordered {
+			priorities[req.input] = a.priority(req.buf, req.flit)
+		}
+
+		sort.SliceStable(ordered, func(i, j int) bool {
+			return priorities[ordered[i].input] > priorities[ordered[j].input]
+		})
+	}
+
+	granted := grantDistinctOutputs(ordered)
+
+	a.nextInput = (ordered[0].input + 1) % len(a.buffers)
+
+	a.report(reqs, granted)
+
+	return granted
+}
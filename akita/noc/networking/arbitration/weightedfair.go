@@ -0,0 +1,86 @@
+package arbitration
+
+import (
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+// NewWeightedFairArbiter creates an arbiter that shares each output among the
+// inputs in proportion to their weights, using the smooth weighted round-robin
+// algorithm. The weight function gives the weight of each input buffer when
+// the buffer is added. A nil function gives all the inputs the weight of 1.
+func NewWeightedFairArbiter(weight func(buf sim.Buffer) int) Arbiter {
+	return &weightedFairArbiter{
+		weight: weight,
+	}
+}
+
+type weightedFairArbiter struct {
+	arbiterBase
+
+	weight  func(buf sim.Buffer) int
+	weights []int
+	current []int
+}
+
+func (a *weightedFairArbiter) AddBuffer(buf sim.Buffer) {
+	w := 1
+	if a.weight != nil {
+		w = a.weight(buf)
+	}
+
+	if w <= 0 {
+		panic("the weight of an input must be positive")
+	}
+
+	a.arbiterBase.AddBuffer(buf)
+	a.weights = append(a.weights, w)
+	a.current = append(a.current, 0)
+}
+
+func (a *weightedFairArbiter) Arbitrate(now sim.VTimeInSec) []sim.Buffer {
+	reqs := a.requests()
+	if len(reqs) == 0 {
+		return nil
+	}
+
+	contenders := make(map[sim.Buffer][]request)
+	outputs := make([]sim.Buffer, 0)
+
+	for _, req := range reqs {
+		out := req.flit.OutputBuf
+		if _, found := contenders[out]; !found {
+			outputs = append(outputs, out)
+		}
+
+		contenders[out] = append(contenders[out], req)
+	}
+
+	granted := make([]sim.Buffer, 0, len(outputs))
+	for _, out := range outputs {
+		granted = append(granted, a.selectInput(contenders[out]).buf)
+	}
+
+	a.report(reqs, granted)
+
+	return granted
+}
+
+// selectInput selects the input with the highest current weight. All the
+// contenders gain their weights and the selected one pays the total weight.
+func (a *weightedFairArbiter) selectInput(reqs []request) request {
+	totalWeight := 0
+	selected := reqs[0]
+
+	for _, req := range reqs {
+		a.current[req.input] += a.weights[req.input]
+		totalWeight += a.weights[req.input]
+
+		if a.current[req.input] > a.current[selected.input] {
+			selected = req
+		}
+	}
+
+	a.current[selected.input] -= totalWeight
+
+	return selected
+}
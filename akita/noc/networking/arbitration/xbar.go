@@ -1,7 +1,6 @@
 package arbitration
 
 import (
-	"github.com/sarchlab/akita/v3/noc/messaging"
 	"github.com/sarchlab/akita/v3/sim"
 )
 
@@ -11,37 +10,61 @@ func NewXBarArbiter() Arbiter {
 }
 
 type xbarArbiter struct {
-	buffers    []sim.Buffer
+	arbiterBase
+
 	nextPortID int
 }
 
-func (a *xbarArbiter) AddBuffer(buf sim.Buffer) {
-	a.buffers = append(a.buffers, buf)
+func (a *xbarArbiter) Arbitrate(now sim.VTimeInSec) []sim.Buffer {
+	if len(a.buffers) == 0 {
+		return nil
+	}
+
+	reqs := a.requests()
+	selectedPort := grantDistinctOutputs(
+		rotate(reqs, a.nextPortID, len(a.buffers)))
+
+	a.nextPortID = (a.nextPortID + 1) % len(a.buffers)
+
+	a.report(reqs, selectedPort)
+
+	return selectedPort
 }
 
-func (a *xbarArbiter) Arbitrate(now sim.VTimeInSec) []sim.Buffer {
-	startingPortID := a.nextPortID
-	selectedPort := make([]sim.Buffer, 0)
-	occupiedOutputPort := make(map[sim.Buffer]bool)
+// rotate returns the requests ordered by the input, starting from the given
+// input.
+func rotate(reqs []request, start, numInputs int) []request {
+	rotated := make([]request, 0, len(reqs))
 
-	for i := 0; i < len(a.buffers); i++ {
-		currPortID := (startingPortID + i) % len(a.buffers)
-		buf := a.buffers[currPortID]
-		item := buf.Peek()
-		if item == nil {
-			continue
+	for _, req := range reqs {
+		if req.input >= start {
+			rotated = append(rotated, req)
 		}
+	}
+
+	for _, req := range reqs {
+		if req.input < start {
+			rotated = append(rotated, req)
+		}
+	}
+
+	return rotated
+}
 
-		flit := item.(*messaging.Flit)
-		if _, ok := occupiedOutputPort[flit.OutputBuf]; ok {
+// grantDistinctOutputs grants the requests in order, as long as the output of
+// the request is not granted to another input.
+func grantDistinctOutputs(ordered []request) []sim.Buffer {
+	selected := make([]sim.Buffer, 0)
+	occupiedOutputPort := make(map[sim.Buffer]bool)
+
+	for _, req := range ordered {
+		if occupiedOutputPort[req.flit.OutputBuf] {
 			continue
 		}
 
-		selectedPort = append(selectedPort, buf)
-		occupiedOutputPort[flit.OutputBuf] = true
+		selected = append(selected, req.buf)
+		occupiedOutputPort[req.flit.OutputBuf] = true
 	}
 
-	a.nextPortID = (a.nextPortID + 1) % len(a.buffers)
-
-	return selectedPort
+	return selected
 }
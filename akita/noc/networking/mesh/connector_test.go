@@ -1,8 +1,12 @@
 package mesh_test
 
 import (
+	"fmt"
+
 	. "github.com/onsi/ginkgo/v2"
-	// . "github.com/onsi/gomega"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/akita/v3/noc/acceptance"
+	"github.com/sarchlab/akita/v3/noc/messaging"
 	"github.com/sarchlab/akita/v3/noc/networking/mesh"
 	"github.com/sarchlab/akita/v3/sim"
 )
@@ -27,4 +31,73 @@ var _ = Describe("Connector", func() {
 
 		connector.EstablishNetwork()
 	})
+
+	deliverAllMsgs := func() {
+		test := acceptance.NewTest()
+
+		for x := 0; x < 4; x++ {
+			for y := 0; y < 4; y++ {
+				name := fmt.Sprintf("Agent[%d][%d]", x, y)
+				agent := acceptance.NewAgent(engine, 1*sim.GHz, name, 2, test)
+				agent.TickLater(0)
+
+				connector.AddTile([3]int{x, y, 0}, agent.AgentPorts)
+				test.RegisterAgent(agent)
+			}
+		}
+
+		connector.EstablishNetwork()
+		test.GenerateMsgs(1000)
+
+		Expect(engine.Run()).To(Succeed())
+		test.MustHaveReceivedAllMsgs()
+	}
+
+	DescribeTable("should deliver all the messages",
+		func(algorithm mesh.RoutingAlgorithm) {
+			connector.WithRoutingAlgorithm(algorithm)
+			deliverAllMsgs()
+		},
+		Entry("with dimension-order routing", mesh.DimensionOrderRouting),
+		Entry("with west-first routing", mesh.WestFirstRouting),
+		Entry("with odd-even routing", mesh.OddEvenRouting),
+	)
+
+	It("should deliver all the messages in a torus", func() {
+		connector.WithTorus()
+		deliverAllMsgs()
+	})
+
+	It("should account the energy of the links", func() {
+		connector.WithLinkModel(messaging.LinkModel{
+			SerDesLatency:     2,
+			BitErrorRate:      1e-4,
+			RetryLatency:      10,
+			L0sEntryLatency:   10,
+			L0sExitLatency:    5,
+			EnergyPerTransfer: 1e-12,
+			ActivePower:       0.1,
+			L0sPower:          0.01,
+		})
+		deliverAllMsgs()
+
+		stats := messaging.LinkStats{}
+		for _, c := range connector.Channels() {
+			s := c.LinkStats()
+			stats.NumTransfers += s.NumTransfers
+			stats.NumRetries += s.NumRetries
+			stats.DynamicEnergy += s.DynamicEnergy
+		}
+
+		Expect(connector.Channels()).To(HaveLen(24))
+		Expect(stats.NumRetries).To(BeNumerically(">", 0))
+		Expect(stats.DynamicEnergy).To(BeNumerically("~",
+			float64(stats.NumTransfers)*1e-12, 1e-15))
+	})
+
+	It("should not support adaptive routing in a torus", func() {
+		connector.WithTorus().WithRoutingAlgorithm(mesh.OddEvenRouting)
+
+		Expect(connector.EstablishNetwork).To(Panic())
+	})
 })
@@ -7,7 +7,9 @@ import (
 
 	"github.com/sarchlab/akita/v3/analysis"
 	"github.com/sarchlab/akita/v3/monitoring"
+	"github.com/sarchlab/akita/v3/noc/messaging"
 	"github.com/sarchlab/akita/v3/noc/networking/networkconnector"
+	"github.com/sarchlab/akita/v3/noc/networking/noctracing"
 	"github.com/sarchlab/akita/v3/sim"
 	"github.com/sarchlab/akita/v3/tracing"
 )
@@ -31,6 +33,11 @@ type Connector struct {
 	gridCap  [3]int
 	grid     [][][]tile
 	dstTable map[string]*tile
+
+	algorithm     RoutingAlgorithm
+	isTorus       bool
+	vcBufferDepth int
+	linkModel     *messaging.LinkModel
 }
 
 // NewConnector creates a new mesh Connector.
@@ -40,6 +47,7 @@ func NewConnector() *Connector {
 		flitSize:             16,
 		linkTransferPerCycle: 1,
 		dstTable:             make(map[string]*tile),
+		vcBufferDepth:        16,
 	}
 
 	c.connector = networkconnector.
@@ -75,6 +83,45 @@ func (c *Connector) WithBandwidth(transferPerCycle float64) *Connector {
 	return c
 }
 
+// WithRoutingAlgorithm sets the algorithm that the switches use to find the
+// next hop. The default is dimension-order routing. The adaptive algorithms
+// are only supported on meshes.
+func (c *Connector) WithRoutingAlgorithm(algorithm RoutingAlgorithm) *Connector {
+	c.algorithm = algorithm
+	return c
+}
+
+// WithTorus makes the network a torus. Wraparound links connect the switches
+// on the opposite edges of each dimension that has more than two switches.
+// The flits take the shorter way around each ring. To avoid deadlocks, the
+// links between switches use 4 virtual channels, two for each message class,
+// and the flits move to the second virtual channel of their class when they
+// cross a wraparound link.
+func (c *Connector) WithTorus() *Connector {
+	c.isTorus = true
+	return c
+}
+
+// WithVCBufferDepth sets the number of flits that each virtual channel of the
+// links between switches can hold. Virtual channels are only used in tori.
+func (c *Connector) WithVCBufferDepth(depth int) *Connector {
+	c.vcBufferDepth = depth
+	return c
+}
+
+// WithLinkModel sets the SerDes latency, the bit errors, the power states, and
+// the energy of the links between the switches.
+func (c *Connector) WithLinkModel(model messaging.LinkModel) *Connector {
+	c.linkModel = &model
+	return c
+}
+
+// Channels returns the links between the switches, whose LinkStats tell the
+// activities and the energy of the links.
+func (c *Connector) Channels() []*messaging.Channel {
+	return c.connector.Channels()
+}
+
 // WithVisTracer sets the tracer used to trace tasks in the network.
 func (c *Connector) WithVisTracer(t tracing.Tracer) *Connector {
 	c.connector = c.connector.WithVisTracer(t)
@@ -111,6 +158,16 @@ func (c *Connector) WithPerfAnalyzer(
 	return c
 }
 
+// WithStatsCollector sets the collector that collects the link utilization,
+// the hop counts, the queueing delay of the switches, and the latency between
+// the devices of the mesh.
+func (c *Connector) WithStatsCollector(
+	sc *noctracing.StatsCollector,
+) *Connector {
+	c.connector = c.connector.WithStatsCollector(sc)
+	return c
+}
+
 // CreateNetwork starts the process of creating a network. It also resets the
 // connector if the connector has been used to create another network.
 func (c *Connector) CreateNetwork(name string) {
@@ -225,6 +282,10 @@ func (c *Connector) initializeGrid(cap [3]int) [][][]tile {
 // EstablishNetwork creates the switches, links, and the routing tables for the
 // network to built.
 func (c *Connector) EstablishNetwork() {
+	if c.isTorus && c.algorithm != DimensionOrderRouting {
+		panic("adaptive routing is not supported on tori")
+	}
+
 	c.createSwitches()
 	c.createLinks()
 
@@ -243,6 +304,48 @@ func (c *Connector) createLinks() {
 			}
 		}
 	}
+
+	if c.isTorus {
+		c.createWraparoundLinks()
+	}
+}
+
+func (c *Connector) createWraparoundLinks() {
+	for x := 0; x < c.gridSize[0]; x++ {
+		for y := 0; y < c.gridSize[1]; y++ {
+			for z := 0; z < c.gridSize[2]; z++ {
+				loc := [3]int{x, y, z}
+				for d := range loc {
+					if loc[d] == 0 && c.gridSize[d] > 2 {
+						c.connectWraparound(loc, d)
+					}
+				}
+			}
+		}
+	}
+}
+
+// connectWraparound connects the switch at the start of a ring with the switch
+// at the end of the ring.
+func (c *Connector) connectWraparound(first [3]int, d int) {
+	last := first
+	last[d] = c.gridSize[d] - 1
+
+	firstTile := c.grid[first[0]][first[1]][first[2]]
+	lastTile := c.grid[last[0]][last[1]][last[2]]
+
+	names := [3][2]string{
+		{"Left", "Right"},
+		{"Top", "Bottom"},
+		{"Front", "Back"},
+	}
+
+	portA, portB := c.createLink(lastTile.sw, firstTile.sw,
+		names[d][positive], names[d][negative])
+	lastTile.rt.neighbors[d][positive] = portA
+	lastTile.rt.wraparound[d][positive] = true
+	firstTile.rt.neighbors[d][negative] = portB
+	firstTile.rt.wraparound[d][negative] = true
 }
 
 func (c *Connector) createSwitches() {
@@ -251,10 +354,13 @@ func (c *Connector) createSwitches() {
 			for z := 0; z < c.gridSize[2]; z++ {
 				swName := fmt.Sprintf("SW[%d][%d][%d]", x, y, z)
 				rt := &meshRoutingTable{
-					x:        x,
-					y:        y,
-					z:        z,
-					dstTable: c.dstTable,
+					x:         x,
+					y:         y,
+					z:         z,
+					dstTable:  c.dstTable,
+					algorithm: c.algorithm,
+					isTorus:   c.isTorus,
+					size:      c.gridSize,
 				}
 				sw := c.connector.AddSwitchWithNameAndRoutingTable(swName, rt)
 
@@ -306,8 +412,8 @@ func (c *Connector) connectWithLeftSwitch(x, y, z int) {
 	left := c.grid[x1][y][z]
 
 	portA, portB := c.createLink(left.sw, curr.sw, "Right", "Left")
-	left.rt.right = portA
-	curr.rt.left = portB
+	left.rt.neighbors[dimX][positive] = portA
+	curr.rt.neighbors[dimX][negative] = portB
 }
 
 func (c *Connector) connectWithTopSwitch(x, y, z int) {
@@ -321,8 +427,8 @@ func (c *Connector) connectWithTopSwitch(x, y, z int) {
 	top := c.grid[x][y1][z]
 
 	portA, portB := c.createLink(top.sw, curr.sw, "Bottom", "Top")
-	top.rt.bottom = portA
-	curr.rt.top = portB
+	top.rt.neighbors[dimY][positive] = portA
+	curr.rt.neighbors[dimY][negative] = portB
 }
 
 func (c *Connector) connectWithFrontSwitch(x, y, z int) {
@@ -336,8 +442,8 @@ func (c *Connector) connectWithFrontSwitch(x, y, z int) {
 	front := c.grid[x][y][z1]
 
 	portA, portB := c.createLink(front.sw, curr.sw, "Back", "Front")
-	front.rt.back = portA
-	curr.rt.front = portB
+	front.rt.neighbors[dimZ][positive] = portA
+	curr.rt.neighbors[dimZ][negative] = portB
 }
 
 func (c *Connector) createLink(
@@ -345,6 +451,14 @@ func (c *Connector) createLink(
 	DirectionA, DirectionB string,
 ) (portA, portB sim.Port) {
 	transferPerCycle := int(math.Ceil(c.linkTransferPerCycle))
+
+	var vcBufferDepths []int
+	if c.isTorus {
+		vcBufferDepths = []int{
+			c.vcBufferDepth, c.vcBufferDepth, c.vcBufferDepth, c.vcBufferDepth,
+		}
+	}
+
 	return c.connector.ConnectSwitches(a, b,
 		networkconnector.SwitchToSwitchLinkParameter{
 			LeftEndParam: networkconnector.LinkEndSwitchParameter{
@@ -354,6 +468,7 @@ func (c *Connector) createLink(
 				NumInputChannel:  transferPerCycle,
 				NumOutputChannel: transferPerCycle,
 				PortName:         DirectionA,
+				VCBufferDepths:   vcBufferDepths,
 			},
 			RightEndParam: networkconnector.LinkEndSwitchParameter{
 				IncomingBufSize:  transferPerCycle,
@@ -362,6 +477,7 @@ func (c *Connector) createLink(
 				NumInputChannel:  transferPerCycle,
 				NumOutputChannel: transferPerCycle,
 				PortName:         DirectionB,
+				VCBufferDepths:   vcBufferDepths,
 			},
 			LinkParam: networkconnector.LinkParameter{
 				IsIdeal:       false, // Use channel model for NoC tracing
@@ -369,6 +485,7 @@ func (c *Connector) createLink(
 				NumStage:      1,
 				CyclePerStage: 1,
 				PipelineWidth: 1,
+				Model:         c.linkModel,
 			},
 		})
 }
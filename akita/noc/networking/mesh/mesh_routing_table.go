@@ -1,48 +1,304 @@
 package mesh
 
 import (
+	"github.com/sarchlab/akita/v3/noc/messaging"
+	"github.com/sarchlab/akita/v3/noc/networking/switching"
 	"github.com/sarchlab/akita/v3/sim"
 )
 
+// RoutingAlgorithm decides how the switches in a mesh find the next hop.
+type RoutingAlgorithm int
+
+// A list of all the supported routing algorithms.
+const (
+	// DimensionOrderRouting routes along the Z, the Y, and the X dimension in
+	// order. It is deterministic and deadlock-free.
+	DimensionOrderRouting RoutingAlgorithm = iota
+
+	// WestFirstRouting uses the west-first turn model. Flits that need to
+	// travel in the negative X direction (west) do so first. Otherwise, the
+	// least occupied of the productive directions is selected.
+	WestFirstRouting
+
+	// OddEvenRouting uses the odd-even turn model, which forbids the
+	// east-to-north and east-to-south turns in the even columns and the
+	// north-to-west and south-to-west turns in the odd columns. The least
+	// occupied of the allowed directions is selected.
+	OddEvenRouting
+
+	// MinimalAdaptiveRouting selects the least occupied of all the productive
+	// directions. It does not restrict the turns, so it can deadlock under
+	// heavy load.
+	MinimalAdaptiveRouting
+)
+
+// The directions along a dimension.
+const (
+	negative = 0
+	positive = 1
+)
+
+// The dimensions of the mesh.
+const (
+	dimX = 0
+	dimY = 1
+	dimZ = 2
+)
+
 // meshRoutingTable is a routing table that can find the next-hop port according
 // to the coordinate of the final destination.
+//
+// The turn models only restrict the turns in the X-Y plane. The adaptive
+// algorithms route along the Z dimension first, so that the 3D mesh remains
+// deadlock-free. Minimal adaptive routing is the exception, as it considers
+// all the productive directions.
 type meshRoutingTable struct {
-	x, y, z                               int
-	top, left, bottom, right, front, back sim.Port
-	local                                 sim.Port
-	dstTable                              map[string]*tile
+	x, y, z int
+
+	// neighbors are the ports that connect to the neighbor switches, indexed
+	// by the dimension and the direction. The top, the left, and the front
+	// switch are in the negative direction.
+	neighbors [3][2]sim.Port
+
+	// wraparound tells if a neighbor port is the wraparound link of a torus.
+	wraparound [3][2]bool
+
+	local     sim.Port
+	dstTable  map[string]*tile
+	algorithm RoutingAlgorithm
+	isTorus   bool
+	size      [3]int
+	occupancy func(port sim.Port) int
 }
 
 // FindPort finds the next-hop port according to the coordinate of the final
 // destination.
 func (t *meshRoutingTable) FindPort(dst sim.Port) sim.Port {
-	// fmt.Printf("dst name: %s\n", dst.Name())
-
 	dstTile := t.dstTable[dst.Name()]
-	dstX, dstY, dstZ := dstTile.rt.x, dstTile.rt.y, dstTile.rt.z
+	curr := [3]int{t.x, t.y, t.z}
+	target := [3]int{dstTile.rt.x, dstTile.rt.y, dstTile.rt.z}
+
+	if curr == target {
+		return t.local
+	}
+
+	if t.isTorus {
+		return t.torusPort(curr, target)
+	}
+
+	switch t.algorithm {
+	case DimensionOrderRouting:
+		return t.dimensionOrderPort(curr, target)
+	case WestFirstRouting:
+		return t.leastOccupied(t.westFirstCandidates(curr, target))
+	case OddEvenRouting:
+		return t.leastOccupied(t.oddEvenCandidates(curr, target))
+	case MinimalAdaptiveRouting:
+		return t.leastOccupied(t.productivePorts(curr, target, dimZ, dimY, dimX))
+	default:
+		panic("unknown routing algorithm")
+	}
+}
+
+// direction returns the direction to travel from a to b along a dimension.
+func direction(a, b int) int {
+	if b < a {
+		return negative
+	}
 
-	// fmt.Printf("dst coord is: %d, %d, %d\n", dstX, dstY, dstZ)
+	return positive
+}
+
+func (t *meshRoutingTable) dimensionOrderPort(curr, target [3]int) sim.Port {
+	for _, d := range []int{dimZ, dimY, dimX} {
+		if curr[d] != target[d] {
+			return t.neighbors[d][direction(curr[d], target[d])]
+		}
+	}
+
+	panic("unreachable")
+}
+
+// productivePorts returns the ports that bring the flit closer to the
+// destination along the given dimensions.
+func (t *meshRoutingTable) productivePorts(
+	curr, target [3]int,
+	dims ...int,
+) []sim.Port {
+	ports := make([]sim.Port, 0, len(dims))
+
+	for _, d := range dims {
+		if curr[d] != target[d] {
+			ports = append(ports, t.neighbors[d][direction(curr[d], target[d])])
+		}
+	}
+
+	return ports
+}
+
+func (t *meshRoutingTable) westFirstCandidates(
+	curr, target [3]int,
+) []sim.Port {
+	if curr[dimZ] != target[dimZ] {
+		return t.productivePorts(curr, target, dimZ)
+	}
+
+	if target[dimX] < curr[dimX] {
+		return []sim.Port{t.neighbors[dimX][negative]}
+	}
+
+	return t.productivePorts(curr, target, dimY, dimX)
+}
+
+// oddEvenCandidates implements the minimal odd-even routing algorithm by Chiu.
+// As the source of the flit is not known, the flits are not allowed to turn
+// at the even source columns.
+func (t *meshRoutingTable) oddEvenCandidates(
+	curr, target [3]int,
+) []sim.Port {
+	if curr[dimZ] != target[dimZ] {
+		return t.productivePorts(curr, target, dimZ)
+	}
+
+	dx := target[dimX] - curr[dimX]
+	dy := target[dimY] - curr[dimY]
+	yPort := t.neighbors[dimY][direction(curr[dimY], target[dimY])]
+	isOddColumn := curr[dimX]%2 == 1
 
 	switch {
-	case dstZ < t.z:
-		return t.front
-	case dstZ > t.z:
-		return t.back
-	case dstY < t.y:
-		return t.top
-	case dstY > t.y:
-		return t.bottom
-	case dstX < t.x:
-		return t.left
-	case dstX > t.x:
-		return t.right
-	case dstX == t.x && dstY == t.y && dstZ == t.z:
-		return t.local
+	case dx == 0:
+		return []sim.Port{yPort}
+	case dx > 0:
+		if dy == 0 {
+			return []sim.Port{t.neighbors[dimX][positive]}
+		}
+
+		candidates := make([]sim.Port, 0, 2)
+		if isOddColumn {
+			candidates = append(candidates, yPort)
+		}
+
+		if target[dimX]%2 == 1 || dx != 1 {
+			candidates = append(candidates, t.neighbors[dimX][positive])
+		}
+
+		return candidates
 	default:
-		panic("unreachable")
+		candidates := []sim.Port{t.neighbors[dimX][negative]}
+		if dy != 0 && !isOddColumn {
+			candidates = append(candidates, yPort)
+		}
+
+		return candidates
 	}
 }
 
+// leastOccupied returns the port with the fewest flits queued for the next
+// hop. Ties are broken by the order of the candidates.
+func (t *meshRoutingTable) leastOccupied(candidates []sim.Port) sim.Port {
+	if len(candidates) == 0 {
+		panic("no port to route to")
+	}
+
+	best := candidates[0]
+	bestOccupancy := t.occupancyOf(best)
+
+	for _, p := range candidates[1:] {
+		occupancy := t.occupancyOf(p)
+		if occupancy < bestOccupancy {
+			best = p
+			bestOccupancy = occupancy
+		}
+	}
+
+	return best
+}
+
+func (t *meshRoutingTable) occupancyOf(port sim.Port) int {
+	if t.occupancy != nil {
+		return t.occupancy(port)
+	}
+
+	sw, ok := port.Component().(*switching.Switch)
+	if !ok {
+		return 0
+	}
+
+	return sw.OutputOccupancy(port)
+}
+
+// torusPort routes along the Z, the Y, and the X dimension in order. In each
+// dimension, the flit takes the shorter way around the ring.
+func (t *meshRoutingTable) torusPort(curr, target [3]int) sim.Port {
+	for _, d := range []int{dimZ, dimY, dimX} {
+		if curr[d] == target[d] {
+			continue
+		}
+
+		dir := direction(curr[d], target[d])
+
+		if t.size[d] > 2 {
+			forward := (target[d] - curr[d] + t.size[d]) % t.size[d]
+			dir = positive
+			if forward > t.size[d]-forward {
+				dir = negative
+			}
+		}
+
+		return t.neighbors[d][dir]
+	}
+
+	panic("unreachable")
+}
+
+// SelectVC selects the virtual channels of the flits on a torus with the
+// dateline method. Each message class uses two virtual channels. A flit uses
+// the first one until it crosses the wraparound link of a ring and uses the
+// second one for the rest of the ring. A flit that turns into a new
+// dimension uses the first one again.
+func (t *meshRoutingTable) SelectVC(
+	msg sim.Msg,
+	inPort sim.Port,
+	inVC int,
+	outPort sim.Port,
+	numVCs int,
+) int {
+	first, count := messaging.VCRangeForMsg(msg, numVCs)
+	if !t.isTorus || count < 2 {
+		return messaging.VCForMsg(msg, numVCs)
+	}
+
+	d, dir, found := t.neighborDirection(outPort)
+	if !found {
+		return first
+	}
+
+	if t.wraparound[d][dir] {
+		return first + 1
+	}
+
+	isSameRing := inPort == t.neighbors[d][1-dir]
+	if isSameRing && inVC == first+1 {
+		return first + 1
+	}
+
+	return first
+}
+
+func (t *meshRoutingTable) neighborDirection(
+	port sim.Port,
+) (d, dir int, found bool) {
+	for d := range t.neighbors {
+		for dir, p := range t.neighbors[d] {
+			if p != nil && p == port {
+				return d, dir, true
+			}
+		}
+	}
+
+	return 0, 0, false
+}
+
 // DefineRoute does noting
 func (t *meshRoutingTable) DefineRoute(finalDst, outputPort sim.Port) {
 	// Do nothing.
@@ -0,0 +1,135 @@
+package mesh
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+var _ = Describe("Mesh Routing Table", func() {
+	var (
+		dstTable  map[string]*tile
+		occupancy map[sim.Port]int
+		t         *meshRoutingTable
+	)
+
+	newPort := func(name string) sim.Port {
+		return sim.NewLimitNumMsgPort(nil, 1, name)
+	}
+
+	addDst := func(name string, x, y int) sim.Port {
+		port := newPort(name)
+		dstTable[name] = &tile{rt: &meshRoutingTable{x: x, y: y}}
+
+		return port
+	}
+
+	port := func(d, dir int) sim.Port {
+		return t.neighbors[d][dir]
+	}
+
+	BeforeEach(func() {
+		dstTable = make(map[string]*tile)
+		occupancy = make(map[sim.Port]int)
+		t = &meshRoutingTable{
+			x:        2,
+			y:        2,
+			local:    newPort("Local"),
+			dstTable: dstTable,
+			size:     [3]int{5, 5, 1},
+			occupancy: func(p sim.Port) int {
+				return occupancy[p]
+			},
+		}
+
+		for d := 0; d < 2; d++ {
+			t.neighbors[d][negative] = newPort("Negative")
+			t.neighbors[d][positive] = newPort("Positive")
+		}
+	})
+
+	It("should route to the local port", func() {
+		dst := addDst("Dst", 2, 2)
+
+		Expect(t.FindPort(dst)).To(BeIdenticalTo(t.local))
+	})
+
+	It("should route along Y before X with dimension-order routing", func() {
+		dst := addDst("Dst", 3, 0)
+
+		Expect(t.FindPort(dst)).To(BeIdenticalTo(port(dimY, negative)))
+	})
+
+	It("should select the least occupied productive port", func() {
+		t.algorithm = MinimalAdaptiveRouting
+		dst := addDst("Dst", 3, 0)
+
+		occupancy[port(dimY, negative)] = 2
+		Expect(t.FindPort(dst)).To(BeIdenticalTo(port(dimX, positive)))
+
+		occupancy[port(dimX, positive)] = 3
+		Expect(t.FindPort(dst)).To(BeIdenticalTo(port(dimY, negative)))
+	})
+
+	It("should go west first with west-first routing", func() {
+		t.algorithm = WestFirstRouting
+		dst := addDst("Dst", 0, 0)
+		occupancy[port(dimX, negative)] = 10
+
+		Expect(t.FindPort(dst)).To(BeIdenticalTo(port(dimX, negative)))
+	})
+
+	It("should not turn from east in even columns with odd-even routing",
+		func() {
+			t.algorithm = OddEvenRouting
+			dst := addDst("Dst", 4, 0)
+			occupancy[port(dimX, positive)] = 10
+
+			Expect(t.FindPort(dst)).To(BeIdenticalTo(port(dimX, positive)))
+
+			t.x = 3
+			Expect(t.FindPort(dst)).To(BeIdenticalTo(port(dimY, negative)))
+		})
+
+	It("should not turn west in odd columns with odd-even routing", func() {
+		t.algorithm = OddEvenRouting
+		t.x = 3
+		dst := addDst("Dst", 0, 0)
+		occupancy[port(dimX, negative)] = 10
+
+		Expect(t.FindPort(dst)).To(BeIdenticalTo(port(dimX, negative)))
+
+		t.x = 2
+		Expect(t.FindPort(dst)).To(BeIdenticalTo(port(dimY, negative)))
+	})
+
+	Context("in a torus", func() {
+		BeforeEach(func() {
+			t.isTorus = true
+		})
+
+		It("should take the shorter way around the ring", func() {
+			t.x = 0
+			dst := addDst("Dst", 4, 0)
+
+			Expect(t.FindPort(dst)).To(BeIdenticalTo(port(dimY, negative)))
+
+			t.y = 0
+			Expect(t.FindPort(dst)).To(BeIdenticalTo(port(dimX, negative)))
+		})
+
+		It("should switch the virtual channel at the dateline", func() {
+			rsp := &sim.GeneralRsp{}
+			inPort := port(dimX, negative)
+			outPort := port(dimX, positive)
+
+			Expect(t.SelectVC(rsp, t.local, 0, outPort, 4)).To(Equal(2))
+			Expect(t.SelectVC(rsp, inPort, 3, outPort, 4)).To(Equal(3))
+			Expect(t.SelectVC(rsp, port(dimY, negative), 3, outPort, 4)).
+				To(Equal(2))
+
+			t.wraparound[dimX][positive] = true
+			Expect(t.SelectVC(rsp, t.local, 0, outPort, 4)).To(Equal(3))
+		})
+	})
+})
@@ -7,6 +7,7 @@ import (
 	"github.com/sarchlab/akita/v3/monitoring"
 	"github.com/sarchlab/akita/v3/noc/messaging"
 	"github.com/sarchlab/akita/v3/noc/networking/arbitration"
+	"github.com/sarchlab/akita/v3/noc/networking/noctracing"
 	"github.com/sarchlab/akita/v3/noc/networking/routing"
 	"github.com/sarchlab/akita/v3/noc/networking/switching"
 	"github.com/sarchlab/akita/v3/sim"
@@ -22,6 +23,10 @@ type LinkEndSwitchParameter struct {
 	NumOutputChannel int
 	Latency          int
 	PortName         string
+
+	// VCBufferDepths enables virtual channels on the switch port if not
+	// empty. See switching.SwitchPortAdder.WithVCBufferDepths.
+	VCBufferDepths []int
 }
 
 // LinkEndDeviceParameter defines the parameter that associated with an end of a
@@ -40,6 +45,11 @@ type LinkParameter struct {
 	NumStage      int
 	CyclePerStage int
 	PipelineWidth int
+
+	// Model adds the SerDes latency, the bit errors, the power states, and
+	// the energy to the link. It is only used if the link is not ideal. If the
+	// TransferSizeInBits of the model is not set, the flit size is used.
+	Model *messaging.LinkModel
 }
 
 // DeviceToSwitchLinkParameter contains the parameters that define a link
@@ -60,18 +70,21 @@ type SwitchToSwitchLinkParameter struct {
 
 // Connector can build complex network topologies.
 type Connector struct {
-	name         string
-	engine       sim.Engine
-	monitor      *monitoring.Monitor
-	defaultFreq  sim.Freq
-	flitSize     int
-	router       Router
-	visTracer    tracing.Tracer
-	nocTracer    tracing.Tracer
-	perfAnalyzer *analysis.PerfAnalyzer
+	name           string
+	engine         sim.Engine
+	monitor        *monitoring.Monitor
+	defaultFreq    sim.Freq
+	flitSize       int
+	router         Router
+	visTracer      tracing.Tracer
+	nocTracer      tracing.Tracer
+	perfAnalyzer   *analysis.PerfAnalyzer
+	statsCollector *noctracing.StatsCollector
+	newArbiter     func() arbitration.Arbiter
 
 	switches        []*switchNode
 	devices         []*deviceNode
+	channels        []*messaging.Channel
 	connectionCount int
 }
 
@@ -81,6 +94,7 @@ func MakeConnector() Connector {
 		defaultFreq: 1 * sim.GHz,
 		flitSize:    64,
 		router:      new(FloydWarshallRouter),
+		newArbiter:  arbitration.NewXBarArbiter,
 	}
 }
 
@@ -118,6 +132,15 @@ func (c Connector) WithRouter(r Router) Connector {
 	return c
 }
 
+// WithArbiterCreator sets the function that creates the arbiter of each
+// switch. By default, the switches use crossbar arbiters.
+func (c Connector) WithArbiterCreator(
+	newArbiter func() arbitration.Arbiter,
+) Connector {
+	c.newArbiter = newArbiter
+	return c
+}
+
 // WithVisTracer sets the tracer used to trace tasks in the network.
 func (c Connector) WithVisTracer(t tracing.Tracer) Connector {
 	c.visTracer = t
@@ -139,6 +162,16 @@ func (c Connector) WithPerfAnalyzer(
 	return c
 }
 
+// WithStatsCollector sets the collector that collects the link utilization,
+// the hop counts, the queueing delay of the switches, and the latency between
+// the devices of the network.
+func (c Connector) WithStatsCollector(
+	sc *noctracing.StatsCollector,
+) Connector {
+	c.statsCollector = sc
+	return c
+}
+
 // GetFlitSize returns the flit size used by the network.
 func (c *Connector) GetFlitSize() int {
 	return c.flitSize
@@ -149,6 +182,13 @@ func (c *Connector) GetFlitSize() int {
 func (c *Connector) NewNetwork(name string) {
 	c.name = name
 	c.switches = nil
+	c.channels = nil
+}
+
+// Channels returns the links of the network that are not ideal. The
+// LinkStats of the channels tell the activities and the energy of the links.
+func (c *Connector) Channels() []*messaging.Channel {
+	return c.channels
 }
 
 // AddSwitch adds a new switch to the network.
@@ -168,7 +208,7 @@ func (c *Connector) AddSwitchWithNameAndRoutingTable(
 	rt routing.Table,
 ) (switchID int) {
 	switchID = len(c.switches)
-	arbiter := arbitration.NewXBarArbiter()
+	arbiter := c.newArbiter()
 
 	name := fmt.Sprintf("%s.%s", c.name, swName)
 	sw := switching.SwitchBuilder{}.
@@ -222,11 +262,34 @@ func (c *Connector) ConnectDevice(
 ) {
 	swNode := c.switches[switchID]
 
-	epNode := c.createEndPoint(ports, param, swNode)
+	epNode := c.createEndPoint(ports, param, swNode, c.engine)
 	swPort, conn := c.connectEndPointWithSwitch(swNode, epNode.endPoint, param)
 	c.createRemoteInfoFoEP(epNode, swNode, epNode.endPoint.NetworkPort, swPort, conn)
 }
 
+// ConnectDeviceInPartition connects a few ports that belongs to a device to a
+// switch that is identified by switchID. The device and its end point are
+// simulated with the given engine, which is usually a partition of a
+// ConservativeEngine, while the network is simulated in the partition that
+// the connector uses as the engine. The link between the end point and the
+// switch is a PartitionLink, whose latency is the latency of the pipeline of
+// the link, or half a cycle if the link is ideal. The engine can also be the
+// engine of the network, so that a simulation with the same links can run
+// serially.
+func (c *Connector) ConnectDeviceInPartition(
+	switchID int,
+	ports []sim.Port,
+	param DeviceToSwitchLinkParameter,
+	engine sim.Engine,
+) {
+	swNode := c.switches[switchID]
+
+	epNode := c.createEndPoint(ports, param, swNode, engine)
+	swPort, conn := c.connectEndPointWithSwitchAcrossPartitions(
+		swNode, epNode.endPoint, param, engine)
+	c.createRemoteInfoFoEP(epNode, swNode, epNode.endPoint.NetworkPort, swPort, conn)
+}
+
 // ConnectDeviceWithEPName connects a few ports that belongs to the device to a
 // switch that is identified by switchID.
 func (c *Connector) ConnectDeviceWithEPName(
@@ -237,7 +300,7 @@ func (c *Connector) ConnectDeviceWithEPName(
 ) (epPort, swPort sim.Port) {
 	swNode := c.switches[switchID]
 
-	epNode := c.createEndPointWithName(ports, param, swNode, epName)
+	epNode := c.createEndPointWithName(ports, param, swNode, epName, c.engine)
 	swPort, conn := c.connectEndPointWithSwitch(swNode, epNode.endPoint, param)
 	c.createRemoteInfoFoEP(
 		epNode, swNode, epNode.endPoint.NetworkPort, swPort,
@@ -251,10 +314,11 @@ func (c *Connector) createEndPointWithName(
 	param DeviceToSwitchLinkParameter,
 	swNode *switchNode,
 	name string,
+	engine sim.Engine,
 ) *deviceNode {
 	fullName := fmt.Sprintf("%s.%s", c.name, name)
 	endPoint := switching.MakeEndPointBuilder().
-		WithEngine(c.engine).
+		WithEngine(engine).
 		WithFreq(c.defaultFreq).
 		WithFlitByteSize(c.flitSize).
 		WithDevicePorts(ports).
@@ -275,6 +339,10 @@ func (c *Connector) createEndPointWithName(
 		endPoint.Name()+".NetworkPort")
 	endPoint.NetworkPort = epPort
 
+	if c.statsCollector != nil {
+		c.statsCollector.RegisterDevice(endPoint.Name(), ports)
+	}
+
 	epNode := &deviceNode{
 		ports:    ports,
 		endPoint: endPoint,
@@ -289,15 +357,69 @@ func (c *Connector) createEndPoint(
 	ports []sim.Port,
 	param DeviceToSwitchLinkParameter,
 	swNode *switchNode,
+	engine sim.Engine,
 ) *deviceNode {
 	name := fmt.Sprintf("EndPoint[%d]", len(c.devices))
-	return c.createEndPointWithName(ports, param, swNode, name)
+	return c.createEndPointWithName(ports, param, swNode, name, engine)
 }
 
 func (c *Connector) connectEndPointWithSwitch(
 	swNode *switchNode, endPoint *switching.EndPoint,
 	param DeviceToSwitchLinkParameter,
 ) (*sim.LimitNumMsgPort, namedHookableConnection) {
+	swPort := c.addSwitchPortForEndPoint(swNode, endPoint, param)
+
+	conn := c.connectPorts(endPoint.NetworkPort, swPort,
+		param.DeviceEndParam.OutgoingBufSize,
+		param.SwitchEndParam.OutgoingBufSize,
+		param.LinkParam,
+	)
+
+	return swPort, conn
+}
+
+func (c *Connector) connectEndPointWithSwitchAcrossPartitions(
+	swNode *switchNode, endPoint *switching.EndPoint,
+	param DeviceToSwitchLinkParameter,
+	engine sim.Engine,
+) (*sim.LimitNumMsgPort, namedHookableConnection) {
+	swPort := c.addSwitchPortForEndPoint(swNode, endPoint, param)
+
+	connName := fmt.Sprintf("%s.Conn[%d]", c.name, c.connectionCount)
+	c.connectionCount++
+
+	freq := param.LinkParam.Frequency
+	if freq == 0 {
+		freq = c.defaultFreq
+	}
+
+	latency := 0
+	if !param.LinkParam.IsIdeal {
+		latency = param.LinkParam.NumStage * param.LinkParam.CyclePerStage
+	}
+
+	conn := sim.NewPartitionLink(connName,
+		engine, c.switchEngine(), freq, latency)
+	conn.PlugIn(endPoint.NetworkPort, param.DeviceEndParam.OutgoingBufSize)
+	conn.PlugIn(swPort, param.SwitchEndParam.OutgoingBufSize)
+
+	return swPort, conn
+}
+
+// switchEngine returns the engine that simulates the switches, which is the
+// first partition if the connector uses a ConservativeEngine.
+func (c *Connector) switchEngine() sim.Engine {
+	if e, ok := c.engine.(*sim.ConservativeEngine); ok {
+		return e.Partition(0)
+	}
+
+	return c.engine
+}
+
+func (c *Connector) addSwitchPortForEndPoint(
+	swNode *switchNode, endPoint *switching.EndPoint,
+	param DeviceToSwitchLinkParameter,
+) *sim.LimitNumMsgPort {
 	sw := swNode.sw
 	epPort := endPoint.NetworkPort
 
@@ -310,15 +432,14 @@ func (c *Connector) connectEndPointWithSwitch(
 		WithLatency(param.SwitchEndParam.Latency).
 		WithNumInputChannel(param.SwitchEndParam.NumInputChannel).
 		WithNumOutputChannel(param.SwitchEndParam.NumOutputChannel).
+		WithVCBufferDepths(param.SwitchEndParam.VCBufferDepths...).
 		AddPort()
 
-	conn := c.connectPorts(epPort, swPort,
-		param.DeviceEndParam.OutgoingBufSize,
-		param.SwitchEndParam.OutgoingBufSize,
-		param.LinkParam,
-	)
+	if c.statsCollector != nil {
+		c.statsCollector.RegisterSwitchPort(sw.Name(), swPort)
+	}
 
-	return swPort, conn
+	return swPort
 }
 
 func (c *Connector) createRemoteInfoFoEP(
@@ -353,14 +474,7 @@ func (c *Connector) connectPorts(
 	if linkParam.IsIdeal {
 		conn = sim.NewDirectConnection(connName, c.engine, c.defaultFreq)
 	} else {
-		conn = messaging.MakeChannelBuilder().
-			WithEngine(c.engine).
-			WithPipelineParameters(
-				linkParam.NumStage,
-				linkParam.CyclePerStage,
-				linkParam.PipelineWidth).
-			WithFreq(linkParam.Frequency).
-			Build(connName)
+		conn = c.createChannel(connName, linkParam)
 	}
 	conn.PlugIn(left, leftBufSize)
 	conn.PlugIn(right, rightBufSize)
@@ -382,9 +496,57 @@ func (c *Connector) connectPorts(
 		c.perfAnalyzer.RegisterPort(right)
 	}
 
+	if c.statsCollector != nil {
+		c.statsCollector.RegisterLink(conn.Name(), left, right,
+			c.linkCapacity(linkParam))
+	}
+
 	return conn
 }
 
+// linkCapacity returns the number of flits that a link can carry in each
+// direction per second. An ideal link is considered to carry one flit per
+// cycle of the network.
+func (c *Connector) linkCapacity(linkParam LinkParameter) float64 {
+	if linkParam.IsIdeal {
+		return float64(c.defaultFreq)
+	}
+
+	width := linkParam.PipelineWidth
+	if width == 0 {
+		width = 1
+	}
+
+	return float64(linkParam.Frequency) * float64(width)
+}
+
+func (c *Connector) createChannel(
+	name string,
+	linkParam LinkParameter,
+) *messaging.Channel {
+	builder := messaging.MakeChannelBuilder().
+		WithEngine(c.engine).
+		WithPipelineParameters(
+			linkParam.NumStage,
+			linkParam.CyclePerStage,
+			linkParam.PipelineWidth).
+		WithFreq(linkParam.Frequency)
+
+	if linkParam.Model != nil {
+		model := *linkParam.Model
+		if model.TransferSizeInBits == 0 {
+			model.TransferSizeInBits = c.flitSize * 8
+		}
+
+		builder = builder.WithLinkModel(model)
+	}
+
+	channel := builder.Build(name)
+	c.channels = append(c.channels, channel)
+
+	return channel
+}
+
 // ConnectSwitches create a connection between two switches. The connection
 // created is bi-directional.
 func (c *Connector) ConnectSwitches(
@@ -417,6 +579,7 @@ func (c *Connector) ConnectSwitches(
 		WithLatency(param.LeftEndParam.Latency).
 		WithNumInputChannel(param.LeftEndParam.NumInputChannel).
 		WithNumOutputChannel(param.LeftEndParam.NumOutputChannel).
+		WithVCBufferDepths(param.LeftEndParam.VCBufferDepths...).
 		AddPort()
 
 	switching.MakeSwitchPortAdder(rightSwitch).
@@ -424,8 +587,14 @@ func (c *Connector) ConnectSwitches(
 		WithLatency(param.RightEndParam.Latency).
 		WithNumInputChannel(param.RightEndParam.NumInputChannel).
 		WithNumOutputChannel(param.RightEndParam.NumOutputChannel).
+		WithVCBufferDepths(param.RightEndParam.VCBufferDepths...).
 		AddPort()
 
+	if c.statsCollector != nil {
+		c.statsCollector.RegisterSwitchPort(leftSwitch.Name(), leftPort)
+		c.statsCollector.RegisterSwitchPort(rightSwitch.Name(), rightPort)
+	}
+
 	conn := c.connectPorts(leftPort, rightPort,
 		param.LeftEndParam.OutgoingBufSize,
 		param.RightEndParam.OutgoingBufSize,
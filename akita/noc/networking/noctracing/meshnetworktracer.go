@@ -1,4 +1,7 @@
-// Package noctracing provides a speficied tracer implementation for the mesh.
+// Package noctracing provides a speficied tracer implementation for the mesh,
+// and a StatsCollector that collects the link utilization, the hop counts, the
+// queueing delay of the switches, and the latency between the devices of any
+// network.
 package noctracing
 
 import (
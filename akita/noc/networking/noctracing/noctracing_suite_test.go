@@ -0,0 +1,13 @@
+package noctracing_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestNoCTracing(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "NoC Tracing Suite")
+}
@@ -0,0 +1,264 @@
+package noctracing
+
+import (
+	"math"
+	"sync"
+
+	"github.com/sarchlab/akita/v3/noc/messaging"
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+type linkDirection struct {
+	link     string
+	src, dst sim.Port
+	capacity float64
+	numFlits uint64
+	buckets  []uint64
+}
+
+type switchDelay struct {
+	numFlits   uint64
+	totalDelay sim.VTimeInSec
+	maxDelay   sim.VTimeInSec
+}
+
+type flowKey struct {
+	src, dst string
+}
+
+type flowRecord struct {
+	numMsgs      uint64
+	totalLatency sim.VTimeInSec
+	maxLatency   sim.VTimeInSec
+	totalHops    uint64
+}
+
+type arrivalKey struct {
+	sw   string
+	flit *messaging.Flit
+}
+
+// StatsCollector collects the statistics of a network, including the
+// utilization of the links over time, the number of hops that the messages
+// travel, the queueing delay in the switches, and the latency between each
+// pair of the devices. It does not rely on the names of the components, so it
+// works with any topology that a networkconnector.Connector builds.
+//
+// The collector is a hook that is attached to the ports of the network. Links,
+// switch ports, and the ports of the devices need to be registered before the
+// simulation starts.
+type StatsCollector struct {
+	sync.Mutex
+
+	bucketDuration sim.VTimeInSec
+
+	hooked      map[sim.Port]bool
+	directions  []*linkDirection
+	portToLink  map[sim.Port]*linkDirection
+	portToSw    map[sim.Port]string
+	switchNames []string
+	switches    map[string]*switchDelay
+	portToDev   map[sim.Port]string
+	deviceNames []string
+
+	arrivals map[arrivalKey]sim.VTimeInSec
+	hops     map[string]uint64
+	flows    map[flowKey]*flowRecord
+	hopHist  map[uint64]uint64
+}
+
+// NewStatsCollector creates a StatsCollector that samples the utilization of
+// the links with the given time granularity.
+func NewStatsCollector(bucketDuration sim.VTimeInSec) *StatsCollector {
+	if bucketDuration <= 0 {
+		panic("bucket duration must be positive")
+	}
+
+	return &StatsCollector{
+		bucketDuration: bucketDuration,
+		hooked:         make(map[sim.Port]bool),
+		portToLink:     make(map[sim.Port]*linkDirection),
+		portToSw:       make(map[sim.Port]string),
+		switches:       make(map[string]*switchDelay),
+		portToDev:      make(map[sim.Port]string),
+		arrivals:       make(map[arrivalKey]sim.VTimeInSec),
+		hops:           make(map[string]uint64),
+		flows:          make(map[flowKey]*flowRecord),
+		hopHist:        make(map[uint64]uint64),
+	}
+}
+
+// RegisterLink registers a bi-directional link that connects two ports. The
+// capacity is the number of flits that the link can carry in each direction
+// per second. The utilization is not reported if the capacity is 0.
+func (c *StatsCollector) RegisterLink(
+	name string,
+	left, right sim.Port,
+	capacity float64,
+) {
+	c.Lock()
+	defer c.Unlock()
+
+	for _, ends := range [][2]sim.Port{{left, right}, {right, left}} {
+		d := &linkDirection{
+			link:     name,
+			src:      ends[0],
+			dst:      ends[1],
+			capacity: capacity,
+		}
+		c.directions = append(c.directions, d)
+		c.portToLink[ends[0]] = d
+		c.hook(ends[0])
+	}
+}
+
+// RegisterSwitchPort registers a port that belongs to a switch.
+func (c *StatsCollector) RegisterSwitchPort(switchName string, port sim.Port) {
+	c.Lock()
+	defer c.Unlock()
+
+	if _, found := c.switches[switchName]; !found {
+		c.switches[switchName] = &switchDelay{}
+		c.switchNames = append(c.switchNames, switchName)
+	}
+
+	c.portToSw[port] = switchName
+	c.hook(port)
+}
+
+// RegisterDevice registers the ports of a device that is connected to the
+// network. The messages that the ports send and receive are counted as the
+// traffic of the device.
+func (c *StatsCollector) RegisterDevice(name string, ports []sim.Port) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.deviceNames = append(c.deviceNames, name)
+
+	for _, p := range ports {
+		c.portToDev[p] = name
+		c.hook(p)
+	}
+}
+
+func (c *StatsCollector) hook(port sim.Port) {
+	if c.hooked[port] {
+		return
+	}
+
+	c.hooked[port] = true
+	port.AcceptHook(c)
+}
+
+// Func records the flits and the messages that pass the ports.
+func (c *StatsCollector) Func(ctx sim.HookCtx) {
+	port, ok := ctx.Domain.(sim.Port)
+	if !ok {
+		return
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	switch ctx.Pos {
+	case sim.HookPosPortMsgSend:
+		if flit, ok := ctx.Item.(*messaging.Flit); ok {
+			c.flitSent(port, flit)
+		}
+	case sim.HookPosPortMsgRecvd:
+		switch msg := ctx.Item.(type) {
+		case *messaging.Flit:
+			c.flitReceived(port, msg)
+		case *messaging.CreditMsg:
+			// Credits are not part of the traffic.
+		case sim.Msg:
+			c.msgDelivered(port, msg)
+		}
+	}
+}
+
+func (c *StatsCollector) flitSent(port sim.Port, flit *messaging.Flit) {
+	now := flit.SendTime
+
+	if d, found := c.portToLink[port]; found {
+		bucket := int(now / c.bucketDuration)
+		for len(d.buckets) <= bucket {
+			d.buckets = append(d.buckets, 0)
+		}
+
+		d.buckets[bucket]++
+		d.numFlits++
+	}
+
+	sw, found := c.portToSw[port]
+	if !found {
+		return
+	}
+
+	key := arrivalKey{sw: sw, flit: flit}
+	arrival, found := c.arrivals[key]
+	if !found {
+		return
+	}
+
+	delete(c.arrivals, key)
+
+	delay := now - arrival
+	s := c.switches[sw]
+	s.numFlits++
+	s.totalDelay += delay
+	if delay > s.maxDelay {
+		s.maxDelay = delay
+	}
+}
+
+func (c *StatsCollector) flitReceived(port sim.Port, flit *messaging.Flit) {
+	sw, found := c.portToSw[port]
+	if !found {
+		return
+	}
+
+	c.arrivals[arrivalKey{sw: sw, flit: flit}] = flit.RecvTime
+
+	if flit.SeqID == 0 && flit.Msg != nil {
+		c.hops[flit.Msg.Meta().ID]++
+	}
+}
+
+func (c *StatsCollector) msgDelivered(port sim.Port, msg sim.Msg) {
+	dst, found := c.portToDev[port]
+	if !found {
+		return
+	}
+
+	meta := msg.Meta()
+
+	src, found := c.portToDev[meta.Src]
+	if !found && meta.Src != nil {
+		src = meta.Src.Name()
+	}
+
+	key := flowKey{src: src, dst: dst}
+	f, found := c.flows[key]
+	if !found {
+		f = &flowRecord{}
+		c.flows[key] = f
+	}
+
+	latency := meta.RecvTime - meta.SendTime
+	hops := c.hops[meta.ID]
+	delete(c.hops, meta.ID)
+
+	f.numMsgs++
+	f.totalLatency += latency
+	f.totalHops += hops
+	if latency > f.maxLatency {
+		f.maxLatency = latency
+	}
+
+	c.hopHist[hops]++
+}
+
+func (c *StatsCollector) numBuckets(endTime sim.VTimeInSec) int {
+	return int(math.Ceil(float64(endTime / c.bucketDuration)))
+}
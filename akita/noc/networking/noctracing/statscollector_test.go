@@ -0,0 +1,120 @@
+package noctracing_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/akita/v3/noc/acceptance"
+	"github.com/sarchlab/akita/v3/noc/networking/mesh"
+	"github.com/sarchlab/akita/v3/noc/networking/noctracing"
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+var _ = Describe("StatsCollector", func() {
+	var (
+		engine    sim.Engine
+		collector *noctracing.StatsCollector
+	)
+
+	BeforeEach(func() {
+		engine = sim.NewSerialEngine()
+		collector = noctracing.NewStatsCollector(100 * 1e-9)
+
+		connector := mesh.NewConnector().
+			WithEngine(engine).
+			WithStatsCollector(collector)
+		connector.CreateNetwork("Mesh")
+
+		test := acceptance.NewTest()
+		for x := 0; x < 3; x++ {
+			name := fmt.Sprintf("Agent[%d]", x)
+			agent := acceptance.NewAgent(engine, 1*sim.GHz, name, 1, test)
+			agent.TickLater(0)
+			connector.AddTile([3]int{x, 0, 0}, agent.AgentPorts)
+
+			if x != 1 {
+				test.RegisterAgent(agent)
+			}
+		}
+
+		connector.EstablishNetwork()
+		test.GenerateMsgs(100)
+
+		Expect(engine.Run()).To(Succeed())
+		test.MustHaveReceivedAllMsgs()
+	})
+
+	It("should collect the statistics of the flows", func() {
+		stats := collector.Stats(engine.CurrentTime())
+
+		Expect(stats.Devices).To(HaveLen(3))
+		Expect(stats.Flows).To(HaveLen(2))
+		Expect(stats.HopHistogram).To(HaveKeyWithValue(uint64(3), uint64(100)))
+
+		for _, f := range stats.Flows {
+			Expect(f.Src).NotTo(Equal(f.Dst))
+			Expect(f.AvgHops).To(Equal(3.0))
+			Expect(f.AvgLatency).To(BeNumerically(">", 0))
+			Expect(f.MaxLatency).To(BeNumerically(">=", f.AvgLatency))
+		}
+	})
+
+	It("should collect the utilization of the links", func() {
+		stats := collector.Stats(engine.CurrentTime())
+
+		Expect(stats.Links).To(HaveLen(10))
+
+		numBusyLinks := 0
+		for _, l := range stats.Links {
+			Expect(l.AvgUtilization).To(BeNumerically("<=", 1))
+			for _, u := range l.Utilization {
+				Expect(u).To(BeNumerically("<=", 1))
+			}
+
+			if l.NumFlits > 0 {
+				numBusyLinks++
+			}
+		}
+
+		Expect(numBusyLinks).To(Equal(8))
+	})
+
+	It("should collect the queueing delay of the switches", func() {
+		stats := collector.Stats(engine.CurrentTime())
+
+		Expect(stats.Switches).To(HaveLen(3))
+		for _, s := range stats.Switches {
+			Expect(s.NumFlits).To(BeNumerically(">", 0))
+			Expect(s.AvgQueueingDelay).To(BeNumerically(">", 0))
+		}
+	})
+
+	It("should export the statistics", func() {
+		buf := new(bytes.Buffer)
+		Expect(collector.WriteJSON(buf, engine.CurrentTime())).To(Succeed())
+
+		stats := noctracing.NetworkStats{}
+		Expect(json.Unmarshal(buf.Bytes(), &stats)).To(Succeed())
+		Expect(stats.Flows).To(HaveLen(2))
+
+		buf.Reset()
+		Expect(collector.WriteLatencyMatrixCSV(buf)).To(Succeed())
+		rows, err := csv.NewReader(buf).ReadAll()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rows).To(HaveLen(4))
+		Expect(rows[1][1]).To(BeEmpty())
+		Expect(rows[1][3]).NotTo(BeEmpty())
+
+		buf.Reset()
+		Expect(collector.WriteLinkUtilizationCSV(
+			buf, engine.CurrentTime())).To(Succeed())
+		rows, err = csv.NewReader(buf).ReadAll()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rows).To(HaveLen(11))
+		Expect(len(rows[0])).To(Equal(len(rows[1])))
+	})
+})
@@ -0,0 +1,245 @@
+package noctracing
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+// LinkTraffic is the traffic on one direction of a link.
+type LinkTraffic struct {
+	Link           string    `json:"link"`
+	Src            string    `json:"src"`
+	Dst            string    `json:"dst"`
+	NumFlits       uint64    `json:"num_flits"`
+	AvgUtilization float64   `json:"avg_utilization"`
+	Utilization    []float64 `json:"utilization"`
+}
+
+// SwitchStats is the time that the flits spend in a switch, from the arrival
+// at an input port to the departure from an output port.
+type SwitchStats struct {
+	Switch           string         `json:"switch"`
+	NumFlits         uint64         `json:"num_flits"`
+	AvgQueueingDelay sim.VTimeInSec `json:"avg_queueing_delay"`
+	MaxQueueingDelay sim.VTimeInSec `json:"max_queueing_delay"`
+}
+
+// FlowStats is the end-to-end statistics of the messages from a source device
+// to a destination device.
+type FlowStats struct {
+	Src        string         `json:"src"`
+	Dst        string         `json:"dst"`
+	NumMsgs    uint64         `json:"num_msgs"`
+	AvgLatency sim.VTimeInSec `json:"avg_latency"`
+	MaxLatency sim.VTimeInSec `json:"max_latency"`
+	AvgHops    float64        `json:"avg_hops"`
+}
+
+// NetworkStats is a summary of the statistics that a StatsCollector collects.
+type NetworkStats struct {
+	BucketDuration sim.VTimeInSec    `json:"bucket_duration"`
+	Links          []LinkTraffic     `json:"links"`
+	Switches       []SwitchStats     `json:"switches"`
+	Devices        []string          `json:"devices"`
+	Flows          []FlowStats       `json:"flows"`
+	HopHistogram   map[uint64]uint64 `json:"hop_histogram"`
+}
+
+// Stats summarizes the statistics collected from time 0 to the end time.
+func (c *StatsCollector) Stats(endTime sim.VTimeInSec) NetworkStats {
+	c.Lock()
+	defer c.Unlock()
+
+	stats := NetworkStats{
+		BucketDuration: c.bucketDuration,
+		Devices:        append([]string(nil), c.deviceNames...),
+		HopHistogram:   make(map[uint64]uint64),
+	}
+
+	for _, d := range c.directions {
+		stats.Links = append(stats.Links, c.linkTraffic(d, endTime))
+	}
+
+	for _, name := range c.switchNames {
+		s := c.switches[name]
+		st := SwitchStats{
+			Switch:           name,
+			NumFlits:         s.numFlits,
+			MaxQueueingDelay: s.maxDelay,
+		}
+
+		if s.numFlits > 0 {
+			st.AvgQueueingDelay = s.totalDelay / sim.VTimeInSec(s.numFlits)
+		}
+
+		stats.Switches = append(stats.Switches, st)
+	}
+
+	for key, f := range c.flows {
+		stats.Flows = append(stats.Flows, FlowStats{
+			Src:        key.src,
+			Dst:        key.dst,
+			NumMsgs:    f.numMsgs,
+			AvgLatency: f.totalLatency / sim.VTimeInSec(f.numMsgs),
+			MaxLatency: f.maxLatency,
+			AvgHops:    float64(f.totalHops) / float64(f.numMsgs),
+		})
+	}
+
+	sort.Slice(stats.Flows, func(i, j int) bool {
+		if stats.Flows[i].Src != stats.Flows[j].Src {
+			return stats.Flows[i].Src < stats.Flows[j].Src
+		}
+
+		return stats.Flows[i].Dst < stats.Flows[j].Dst
+	})
+
+	for hops, count := range c.hopHist {
+		stats.HopHistogram[hops] = count
+	}
+
+	return stats
+}
+
+func (c *StatsCollector) linkTraffic(
+	d *linkDirection,
+	endTime sim.VTimeInSec,
+) LinkTraffic {
+	st := LinkTraffic{
+		Link:     d.link,
+		Src:      d.src.Name(),
+		Dst:      d.dst.Name(),
+		NumFlits: d.numFlits,
+	}
+
+	numBuckets := c.numBuckets(endTime)
+	if d.capacity == 0 || numBuckets == 0 {
+		return st
+	}
+
+	st.Utilization = make([]float64, numBuckets)
+	for i := 0; i < numBuckets && i < len(d.buckets); i++ {
+		duration := c.bucketDuration
+		if end := sim.VTimeInSec(i+1) * c.bucketDuration; end > endTime {
+			duration = endTime - sim.VTimeInSec(i)*c.bucketDuration
+		}
+
+		st.Utilization[i] = float64(d.buckets[i]) /
+			(d.capacity * float64(duration))
+	}
+
+	st.AvgUtilization = float64(d.numFlits) /
+		(d.capacity * float64(endTime))
+
+	return st
+}
+
+// WriteJSON writes all the statistics as a JSON document.
+func (c *StatsCollector) WriteJSON(w io.Writer, endTime sim.VTimeInSec) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(c.Stats(endTime))
+}
+
+// WriteLinkUtilizationCSV writes the utilization of the links over time. Each
+// row is a direction of a link and each column after the link information is
+// a time bucket, which forms a heatmap of the links over time.
+func (c *StatsCollector) WriteLinkUtilizationCSV(
+	w io.Writer,
+	endTime sim.VTimeInSec,
+) error {
+	stats := c.Stats(endTime)
+	writer := csv.NewWriter(w)
+
+	header := []string{"link", "src", "dst", "num_flits", "avg_utilization"}
+	for i := 0; i < c.numBuckets(endTime); i++ {
+		header = append(header,
+			fmt.Sprintf("%.10f", float64(sim.VTimeInSec(i)*c.bucketDuration)))
+	}
+
+	rows := [][]string{header}
+	for _, l := range stats.Links {
+		row := []string{
+			l.Link, l.Src, l.Dst,
+			fmt.Sprint(l.NumFlits),
+			fmt.Sprint(l.AvgUtilization),
+		}
+
+		for _, u := range l.Utilization {
+			row = append(row, fmt.Sprint(u))
+		}
+
+		rows = append(rows, row)
+	}
+
+	return writer.WriteAll(rows)
+}
+
+// WriteSwitchCSV writes the queueing delay of each switch.
+func (c *StatsCollector) WriteSwitchCSV(w io.Writer) error {
+	stats := c.Stats(0)
+	writer := csv.NewWriter(w)
+
+	rows := [][]string{
+		{"switch", "num_flits", "avg_queueing_delay", "max_queueing_delay"},
+	}
+	for _, s := range stats.Switches {
+		rows = append(rows, []string{
+			s.Switch,
+			fmt.Sprint(s.NumFlits),
+			fmt.Sprint(float64(s.AvgQueueingDelay)),
+			fmt.Sprint(float64(s.MaxQueueingDelay)),
+		})
+	}
+
+	return writer.WriteAll(rows)
+}
+
+// WriteLatencyMatrixCSV writes the average latency from each source device
+// (rows) to each destination device (columns). The cells of the pairs that
+// have no traffic are left empty.
+func (c *StatsCollector) WriteLatencyMatrixCSV(w io.Writer) error {
+	return c.writeMatrixCSV(w, func(f FlowStats) float64 {
+		return float64(f.AvgLatency)
+	})
+}
+
+// WriteHopCountMatrixCSV writes the average number of switches that the
+// messages from each source device (rows) to each destination device
+// (columns) traverse.
+func (c *StatsCollector) WriteHopCountMatrixCSV(w io.Writer) error {
+	return c.writeMatrixCSV(w, func(f FlowStats) float64 {
+		return f.AvgHops
+	})
+}
+
+func (c *StatsCollector) writeMatrixCSV(
+	w io.Writer,
+	value func(f FlowStats) float64,
+) error {
+	stats := c.Stats(0)
+	writer := csv.NewWriter(w)
+
+	cells := make(map[flowKey]string)
+	for _, f := range stats.Flows {
+		cells[flowKey{src: f.Src, dst: f.Dst}] = fmt.Sprint(value(f))
+	}
+
+	rows := [][]string{append([]string{"src\\dst"}, stats.Devices...)}
+	for _, src := range stats.Devices {
+		row := []string{src}
+		for _, dst := range stats.Devices {
+			row = append(row, cells[flowKey{src: src, dst: dst}])
+		}
+
+		rows = append(rows, row)
+	}
+
+	return writer.WriteAll(rows)
+}
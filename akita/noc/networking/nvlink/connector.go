@@ -7,7 +7,9 @@ import (
 	"math"
 
 	"github.com/sarchlab/akita/v3/monitoring"
+	"github.com/sarchlab/akita/v3/noc/messaging"
 	"github.com/sarchlab/akita/v3/noc/networking/networkconnector"
+	"github.com/sarchlab/akita/v3/noc/networking/noctracing"
 	"github.com/sarchlab/akita/v3/sim"
 	"github.com/sarchlab/akita/v3/tracing"
 )
@@ -34,6 +36,9 @@ type Connector struct {
 	ethernetSwitchLatency int
 	ethernetBandwidth     uint64
 
+	pcieLinkModel   *messaging.LinkModel
+	nvlinkLinkModel *messaging.LinkModel
+
 	connector networkconnector.Connector
 
 	devices          []*deviceNode
@@ -65,6 +70,16 @@ func NewConnector() *Connector {
 	return c
 }
 
+// WithStatsCollector sets the collector that collects the link utilization,
+// the hop counts, the queueing delay of the switches, and the latency between
+// the devices of the network.
+func (c *Connector) WithStatsCollector(
+	sc *noctracing.StatsCollector,
+) *Connector {
+	c.connector = c.connector.WithStatsCollector(sc)
+	return c
+}
+
 // WithMonitor sets the monitor that monitors the components in the connection.
 func (c *Connector) WithMonitor(m *monitoring.Monitor) *Connector {
 	c.connector = c.connector.WithMonitor(m)
@@ -174,6 +189,27 @@ func (c Connector) WithVisTracer(t tracing.Tracer) Connector {
 	return c
 }
 
+// WithPCIeLinkModel sets the SerDes latency, the bit errors, the power states,
+// and the energy of the PCIe links between switches.
+func (c *Connector) WithPCIeLinkModel(model messaging.LinkModel) *Connector {
+	c.pcieLinkModel = &model
+	return c
+}
+
+// WithNVLinkLinkModel sets the SerDes latency, the bit errors, the power
+// states, and the energy of the NVLinks.
+func (c *Connector) WithNVLinkLinkModel(model messaging.LinkModel) *Connector {
+	c.nvlinkLinkModel = &model
+	return c
+}
+
+// Channels returns the PCIe links between switches, the NVLinks, and the
+// ethernet links, whose LinkStats tell the activities and the energy of the
+// links.
+func (c *Connector) Channels() []*messaging.Channel {
+	return c.connector.Channels()
+}
+
 // CreateNetwork creates a network. This function should be called before
 // creating root complexes.
 func (c *Connector) CreateNetwork(name string) {
@@ -223,6 +259,7 @@ func (c *Connector) ConnectSwitchesWithPCIeLink(switchAID, switchBID int) {
 				NumStage:      20,
 				CyclePerStage: 1,
 				PipelineWidth: 1,
+				Model:         c.pcieLinkModel,
 			},
 		})
 }
@@ -308,6 +345,7 @@ func (c *Connector) connectDeviceSwitchWithPCIeSwitch(
 				NumStage:      20,
 				CyclePerStage: 1,
 				PipelineWidth: 1,
+				Model:         c.pcieLinkModel,
 			},
 		})
 }
@@ -372,6 +410,7 @@ func (c *Connector) ConnectDevicesWithNVLink(
 				NumStage:      20,
 				CyclePerStage: 1,
 				PipelineWidth: numLink,
+				Model:         c.nvlinkLinkModel,
 			},
 		})
 }
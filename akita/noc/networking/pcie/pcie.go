@@ -5,7 +5,10 @@ import (
 	"math"
 
 	"github.com/sarchlab/akita/v3/monitoring"
+	"github.com/sarchlab/akita/v3/noc/messaging"
+	"github.com/sarchlab/akita/v3/noc/networking/arbitration"
 	"github.com/sarchlab/akita/v3/noc/networking/networkconnector"
+	"github.com/sarchlab/akita/v3/noc/networking/noctracing"
 	"github.com/sarchlab/akita/v3/sim"
 	"github.com/sarchlab/akita/v3/tracing"
 )
@@ -16,6 +19,7 @@ type Connector struct {
 	bandwidth     uint64
 	flitByteSize  int
 	switchLatency int
+	linkModel     *messaging.LinkModel
 	connector     networkconnector.Connector
 }
 
@@ -92,12 +96,44 @@ func (c *Connector) WithSwitchLatency(numCycles int) *Connector {
 	return c
 }
 
+// WithLinkModel sets the SerDes latency, the bit errors, the power states, and
+// the energy of the PCIe links. Without a link model, the links are ideal.
+func (c *Connector) WithLinkModel(model messaging.LinkModel) *Connector {
+	c.linkModel = &model
+	return c
+}
+
+// Channels returns the links of the PCIe network, whose LinkStats tell the
+// activities and the energy of the links.
+func (c *Connector) Channels() []*messaging.Channel {
+	return c.connector.Channels()
+}
+
+// WithArbiterCreator sets the function that creates the arbiter of each
+// switch, which decides how the devices share the links of the switch.
+func (c *Connector) WithArbiterCreator(
+	newArbiter func() arbitration.Arbiter,
+) *Connector {
+	c.connector = c.connector.WithArbiterCreator(newArbiter)
+	return c
+}
+
 // WithVisTracer sets the vis tracer that can be used to visualize the network.
 func (c *Connector) WithVisTracer(tracer tracing.Tracer) *Connector {
 	c.connector = c.connector.WithVisTracer(tracer)
 	return c
 }
 
+// WithStatsCollector sets the collector that collects the link utilization,
+// the hop counts, the queueing delay of the switches, and the latency between
+// the devices of the PCIe network.
+func (c *Connector) WithStatsCollector(
+	sc *noctracing.StatsCollector,
+) *Connector {
+	c.connector = c.connector.WithStatsCollector(sc)
+	return c
+}
+
 // CreateNetwork creates a network. This function should be called before
 // creating root complexes.
 func (c *Connector) CreateNetwork(name string) {
@@ -133,10 +169,7 @@ func (c *Connector) AddSwitch(baseSwitchID int) (switchID int) {
 				NumInputChannel:  1,
 				NumOutputChannel: 1,
 			},
-			LinkParam: networkconnector.LinkParameter{
-				IsIdeal:   true,
-				Frequency: c.freq,
-			},
+			LinkParam: c.linkParam(),
 		})
 
 	return switchID
@@ -145,25 +178,67 @@ func (c *Connector) AddSwitch(baseSwitchID int) (switchID int) {
 // PlugInDevice connects a series of ports to a switch.
 func (c *Connector) PlugInDevice(baseSwitchID int, devicePorts []sim.Port) {
 	c.connector.ConnectDevice(baseSwitchID, devicePorts,
-		networkconnector.DeviceToSwitchLinkParameter{
-			DeviceEndParam: networkconnector.LinkEndDeviceParameter{
-				IncomingBufSize:  16,
-				OutgoingBufSize:  16,
-				NumInputChannel:  1,
-				NumOutputChannel: 1,
-			},
-			SwitchEndParam: networkconnector.LinkEndSwitchParameter{
-				IncomingBufSize:  16,
-				OutgoingBufSize:  16,
-				Latency:          c.switchLatency,
-				NumInputChannel:  1,
-				NumOutputChannel: 1,
-			},
-			LinkParam: networkconnector.LinkParameter{
-				IsIdeal:   true,
-				Frequency: c.freq,
-			},
-		})
+		c.deviceLinkParam(c.linkParam()))
+}
+
+// PlugInDeviceInPartition connects a series of ports to a switch. The device
+// is simulated with the given engine, which is usually a partition of the
+// ConservativeEngine that also simulates the PCIe network. The device connects
+// to the switch with a PartitionLink, whose latency is the latency of the
+// link. The link model only sets the latency of the link, as a PartitionLink
+// does not account for the energy of the link.
+func (c *Connector) PlugInDeviceInPartition(
+	baseSwitchID int,
+	devicePorts []sim.Port,
+	engine sim.Engine,
+) {
+	c.connector.ConnectDeviceInPartition(baseSwitchID, devicePorts,
+		c.deviceLinkParam(c.linkParam()), engine)
+}
+
+func (c *Connector) deviceLinkParam(
+	linkParam networkconnector.LinkParameter,
+) (
+	param networkconnector.DeviceToSwitchLinkParameter,
+) {
+	return networkconnector.DeviceToSwitchLinkParameter{
+		DeviceEndParam: networkconnector.LinkEndDeviceParameter{
+			IncomingBufSize:  16,
+			OutgoingBufSize:  16,
+			NumInputChannel:  1,
+			NumOutputChannel: 1,
+		},
+		SwitchEndParam: networkconnector.LinkEndSwitchParameter{
+			IncomingBufSize:  16,
+			OutgoingBufSize:  16,
+			Latency:          c.switchLatency,
+			NumInputChannel:  1,
+			NumOutputChannel: 1,
+		},
+		LinkParam: linkParam,
+	}
+}
+
+func (c *Connector) idealLinkParam() networkconnector.LinkParameter {
+	return networkconnector.LinkParameter{
+		IsIdeal:   true,
+		Frequency: c.freq,
+	}
+}
+
+func (c *Connector) linkParam() networkconnector.LinkParameter {
+	if c.linkModel == nil {
+		return c.idealLinkParam()
+	}
+
+	return networkconnector.LinkParameter{
+		IsIdeal:       false,
+		Frequency:     c.freq,
+		NumStage:      1,
+		CyclePerStage: 1,
+		PipelineWidth: 1,
+		Model:         c.linkModel,
+	}
 }
 
 // EstablishRoute populates the routing tables in the network.
@@ -0,0 +1,13 @@
+package pcie_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestPCIe(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "PCIe Suite")
+}
@@ -0,0 +1,103 @@
+package pcie_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/akita/v3/noc/acceptance"
+	"github.com/sarchlab/akita/v3/noc/messaging"
+	"github.com/sarchlab/akita/v3/noc/networking/noctracing"
+	"github.com/sarchlab/akita/v3/noc/networking/pcie"
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+var _ = Describe("Connector", func() {
+	var (
+		engine    sim.Engine
+		connector *pcie.Connector
+	)
+
+	BeforeEach(func() {
+		engine = sim.NewSerialEngine()
+		connector = pcie.NewConnector().WithEngine(engine)
+	})
+
+	deliverAllMsgs := func() {
+		test := acceptance.NewTest()
+
+		var agents []*acceptance.Agent
+		for i := 0; i < 5; i++ {
+			agent := acceptance.NewAgent(
+				engine, 1*sim.GHz, fmt.Sprintf("Agent%d", i), 2, test)
+			agent.TickLater(0)
+			agents = append(agents, agent)
+			test.RegisterAgent(agent)
+		}
+
+		connector.CreateNetwork("PCIe")
+		rootComplexID := connector.AddRootComplex(agents[0].AgentPorts)
+
+		switch1ID := connector.AddSwitch(rootComplexID)
+		connector.PlugInDevice(switch1ID, agents[1].AgentPorts)
+		connector.PlugInDevice(switch1ID, agents[2].AgentPorts)
+
+		switch2ID := connector.AddSwitch(rootComplexID)
+		connector.PlugInDevice(switch2ID, agents[3].AgentPorts)
+		connector.PlugInDevice(switch2ID, agents[4].AgentPorts)
+
+		connector.EstablishRoute()
+		test.GenerateMsgs(500)
+
+		Expect(engine.Run()).To(Succeed())
+		test.MustHaveReceivedAllMsgs()
+	}
+
+	It("should deliver all the messages over ideal links", func() {
+		deliverAllMsgs()
+
+		Expect(connector.Channels()).To(BeEmpty())
+	})
+
+	It("should account the energy of the links", func() {
+		connector.WithLinkModel(messaging.LinkModel{
+			SerDesLatency:     2,
+			BitErrorRate:      1e-4,
+			RetryLatency:      10,
+			EnergyPerTransfer: 1e-12,
+			ActivePower:       0.1,
+		})
+		deliverAllMsgs()
+
+		stats := messaging.LinkStats{}
+		for _, c := range connector.Channels() {
+			s := c.LinkStats()
+			stats.NumTransfers += s.NumTransfers
+			stats.NumRetries += s.NumRetries
+			stats.DynamicEnergy += s.DynamicEnergy
+		}
+
+		Expect(connector.Channels()).To(HaveLen(7))
+		Expect(stats.NumRetries).To(BeNumerically(">", 0))
+		Expect(stats.DynamicEnergy).To(BeNumerically("~",
+			float64(stats.NumTransfers)*1e-12, 1e-15))
+	})
+	It("should collect the statistics of the network", func() {
+		collector := noctracing.NewStatsCollector(100 * 1e-9)
+		connector.WithStatsCollector(collector)
+		deliverAllMsgs()
+
+		stats := collector.Stats(engine.CurrentTime())
+
+		Expect(stats.Devices).To(HaveLen(5))
+		Expect(stats.Switches).To(HaveLen(3))
+		Expect(stats.Flows).NotTo(BeEmpty())
+		Expect(stats.HopHistogram).To(HaveKey(uint64(1)))
+		Expect(stats.HopHistogram).To(HaveKey(uint64(3)))
+
+		for _, f := range stats.Flows {
+			Expect(f.AvgHops).To(BeNumerically(">=", 1))
+			Expect(f.AvgLatency).To(BeNumerically(">", 0))
+		}
+	})
+})
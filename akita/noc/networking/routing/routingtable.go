@@ -10,6 +10,20 @@ type Table interface {
 	DefineDefaultRoute(outputPort sim.Port)
 }
 
+// A VCSelector is a routing table that also selects the virtual channel that a
+// flit uses on the next link, for example, to break the cyclic dependencies
+// on the rings of a torus.
+type VCSelector interface {
+	// SelectVC returns the virtual channel, in [0, numVCs), that the message
+	// uses on the output port. The input port and the input virtual channel
+	// tell where the flit comes from.
+	SelectVC(
+		msg sim.Msg,
+		inPort sim.Port, inVC int,
+		outPort sim.Port, numVCs int,
+	) int
+}
+
 // NewTable creates a new Table.
 func NewTable() Table {
 	t := &table{}
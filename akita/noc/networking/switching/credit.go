@@ -0,0 +1,96 @@
+package switching
+
+import (
+	"github.com/sarchlab/akita/v3/noc/messaging"
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+// outputCredits tracks the free buffer slots of each virtual channel of the
+// port on the other side of a link.
+//
+// The virtual channels of the remote port are discovered the first time the
+// credits are used, as the remote port may be added to its switch after the
+// local port. If the remote port is not a switch port with virtual channels,
+// the credits are unlimited and the flits are only throttled by the port.
+type outputCredits struct {
+	remote   sim.Port
+	resolved bool
+	depths   []int
+	credits  []int
+}
+
+func (c *outputCredits) resolve() {
+	if c.resolved {
+		return
+	}
+
+	c.resolved = true
+
+	if c.remote == nil {
+		return
+	}
+
+	sw, ok := c.remote.Component().(*Switch)
+	if !ok {
+		return
+	}
+
+	pc, found := sw.portToComplexMapping[c.remote]
+	if !found || len(pc.vcBufferDepths) == 0 {
+		return
+	}
+
+	c.depths = pc.vcBufferDepths
+	c.credits = append([]int(nil), pc.vcBufferDepths...)
+}
+
+// used returns the number of the slots of the remote port that are taken.
+func (c *outputCredits) used() int {
+	c.resolve()
+
+	used := 0
+	for vc, depth := range c.depths {
+		used += depth - c.credits[vc]
+	}
+
+	return used
+}
+
+// numVCs returns the number of virtual channels of the remote port. It is 0
+// if the credits are unlimited.
+func (c *outputCredits) numVCs() int {
+	c.resolve()
+
+	return len(c.credits)
+}
+
+// available returns whether the virtual channel has a free slot.
+func (c *outputCredits) available(vc int) bool {
+	c.resolve()
+
+	if c.credits == nil {
+		return true
+	}
+
+	return c.credits[vc] > 0
+}
+
+// consume takes a slot of a virtual channel.
+func (c *outputCredits) consume(vc int) {
+	if c.credits == nil {
+		return
+	}
+
+	c.credits[vc]--
+}
+
+// release returns the slots carried by a credit message.
+func (c *outputCredits) release(msg *messaging.CreditMsg) {
+	c.resolve()
+
+	if c.credits == nil {
+		return
+	}
+
+	c.credits[msg.VC] += msg.NumCredits
+}
@@ -0,0 +1,190 @@
+package switching
+
+import (
+	gomock "github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/akita/v3/noc/messaging"
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+var _ = Describe("Virtual Channels", func() {
+	var (
+		mockCtrl       *gomock.Controller
+		engine         *MockEngine
+		routingTable   *MockTable
+		arbiter        *MockArbiter
+		inPC, outPC    portComplex
+		inVC0, inVC1   *MockBuffer
+		dstPort        *MockPort
+		sw             *Switch
+		outputCredits1 *outputCredits
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		engine = NewMockEngine(mockCtrl)
+		routingTable = NewMockTable(mockCtrl)
+		arbiter = NewMockArbiter(mockCtrl)
+		arbiter.EXPECT().AddBuffer(gomock.Any()).AnyTimes()
+		arbiter.EXPECT().SetBlockedFunc(gomock.Any()).AnyTimes()
+		dstPort = NewMockPort(mockCtrl)
+		dstPort.EXPECT().Name().AnyTimes()
+
+		inPC = createMockPortComplex(mockCtrl)
+		inVC0 = inPC.forwardBuffers[0].(*MockBuffer)
+		inVC1 = NewMockBuffer(mockCtrl)
+		inPC.forwardBuffers = []sim.Buffer{inVC0, inVC1}
+		inPC.vcBufferDepths = []int{2, 2}
+		inPC.pendingCredits = make([]int, 2)
+
+		outPC = createMockPortComplex(mockCtrl)
+		outputCredits1 = &outputCredits{
+			remote:   outPC.remotePort,
+			resolved: true,
+			credits:  []int{1, 0},
+		}
+		outPC.outputCredits = outputCredits1
+
+		sw = SwitchBuilder{}.
+			WithEngine(engine).
+			WithFreq(1).
+			WithRoutingTable(routingTable).
+			WithArbiter(arbiter).
+			Build("Switch")
+		sw.addPort(inPC)
+		sw.addPort(outPC)
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	newFlit := func(msg sim.Msg) *messaging.Flit {
+		flit := messaging.FlitBuilder{}.WithMsg(msg).Build()
+		flit.OutputBuf = outPC.sendOutBuffer
+
+		return flit
+	}
+
+	It("should route flits to the buffer of their virtual channel", func() {
+		routeBuf := inPC.routeBuffer.(*MockBuffer)
+		msg := &sampleMsg{}
+		msg.Dst = dstPort
+		flit := newFlit(msg)
+		flit.VC = 1
+
+		routeBuf.EXPECT().Peek().Return(flitPipelineItem{flit: flit})
+		routeBuf.EXPECT().Pop()
+		inVC1.EXPECT().CanPush().Return(true)
+		inVC1.EXPECT().Push(flit)
+		routingTable.EXPECT().FindPort(dstPort).Return(outPC.localPort)
+		outPC.routeBuffer.(*MockBuffer).EXPECT().Peek().Return(nil)
+
+		madeProgress := sw.route(10)
+
+		Expect(madeProgress).To(BeTrue())
+	})
+
+	It("should forward and return a credit", func() {
+		sendOutBuf := outPC.sendOutBuffer.(*MockBuffer)
+		flit := newFlit(&sampleMsg{})
+
+		arbiter.EXPECT().Arbitrate(sim.VTimeInSec(10)).
+			Return([]sim.Buffer{inVC0})
+		inVC0.EXPECT().Peek().Return(flit)
+		inVC0.EXPECT().Peek().Return(nil)
+		inVC0.EXPECT().Pop()
+		sendOutBuf.EXPECT().CanPush().Return(true)
+		sendOutBuf.EXPECT().Push(flit)
+
+		madeProgress := sw.forward(10)
+
+		Expect(madeProgress).To(BeTrue())
+		Expect(flit.VC).To(Equal(0))
+		Expect(outputCredits1.credits).To(Equal([]int{0, 0}))
+		Expect(inPC.pendingCredits).To(Equal([]int{1, 0}))
+	})
+
+	It("should not forward if the virtual channel has no credit", func() {
+		sendOutBuf := outPC.sendOutBuffer.(*MockBuffer)
+		rsp := sim.GeneralRspBuilder{}.Build()
+		flit := newFlit(rsp)
+
+		arbiter.EXPECT().Arbitrate(sim.VTimeInSec(10)).
+			Return([]sim.Buffer{inVC1})
+		inVC1.EXPECT().Peek().Return(flit)
+		sendOutBuf.EXPECT().CanPush().Return(true)
+
+		madeProgress := sw.forward(10)
+
+		Expect(madeProgress).To(BeFalse())
+		Expect(inPC.pendingCredits).To(Equal([]int{0, 0}))
+	})
+
+	It("should tell the arbiter the virtual channels that have no credit",
+		func() {
+			inVC0.EXPECT().Peek().Return(newFlit(&sampleMsg{}))
+			inVC1.EXPECT().Peek().Return(newFlit(sim.GeneralRspBuilder{}.Build()))
+
+			Expect(sw.isBlockedByCredits(inVC0)).To(BeFalse())
+			Expect(sw.isBlockedByCredits(inVC1)).To(BeTrue())
+		})
+
+	It("should send credits back", func() {
+		localPort := inPC.localPort.(*MockPort)
+		inPC.pendingCredits[1] = 2
+
+		localPort.EXPECT().Send(gomock.Any()).
+			Do(func(msg *messaging.CreditMsg) {
+				Expect(msg.Dst).To(BeIdenticalTo(inPC.remotePort))
+				Expect(msg.VC).To(Equal(1))
+				Expect(msg.NumCredits).To(Equal(2))
+			})
+		inPC.sendOutBuffer.(*MockBuffer).EXPECT().Peek().Return(nil)
+		outPC.sendOutBuffer.(*MockBuffer).EXPECT().Peek().Return(nil)
+
+		madeProgress := sw.sendOut(10)
+
+		Expect(madeProgress).To(BeTrue())
+		Expect(inPC.pendingCredits).To(Equal([]int{0, 0}))
+	})
+
+	It("should receive credits", func() {
+		localPort := outPC.localPort.(*MockPort)
+		credit := messaging.CreditMsgBuilder{}.
+			WithVC(1).
+			WithNumCredits(3).
+			Build()
+
+		inPC.localPort.(*MockPort).EXPECT().Peek().Return(nil)
+		localPort.EXPECT().Peek().Return(credit)
+		localPort.EXPECT().Retrieve(sim.VTimeInSec(10))
+		localPort.EXPECT().Peek().Return(nil)
+
+		madeProgress := sw.startProcessing(10)
+
+		Expect(madeProgress).To(BeTrue())
+		Expect(outputCredits1.credits).To(Equal([]int{1, 3}))
+	})
+
+	It("should discover the virtual channels of a remote switch", func() {
+		remoteSw := SwitchBuilder{}.
+			WithEngine(engine).
+			WithFreq(1).
+			WithRoutingTable(routingTable).
+			WithArbiter(arbiter).
+			Build("RemoteSwitch")
+		remotePort := sim.NewLimitNumMsgPort(remoteSw, 4, "RemoteSwitch.Port")
+		MakeSwitchPortAdder(remoteSw).
+			WithPorts(remotePort, outPC.localPort).
+			WithVCBufferDepths(2, 3).
+			AddPort()
+
+		credits := &outputCredits{remote: remotePort}
+
+		Expect(credits.numVCs()).To(Equal(2))
+		Expect(credits.available(1)).To(BeTrue())
+		Expect(credits.credits).To(Equal([]int{2, 3}))
+	})
+})
@@ -1,2 +1,11 @@
 // Package switching provides implementations of EndPoints and Switches.
+//
+// By default, a switch port has a single channel and the flits are only
+// throttled by the buffer of the port. A port can enable virtual channels with
+// SwitchPortAdder.WithVCBufferDepths. Requests and responses then use separate
+// virtual channels, and the switch or the endpoint on the other side of the
+// link only sends a flit when the virtual channel has a free slot. The switch
+// returns the freed slots to the sender with messaging.CreditMsg. A virtual
+// channel should be deep enough to cover the round trip of the credits, or
+// the link cannot reach its full bandwidth.
 package switching
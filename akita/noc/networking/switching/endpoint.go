@@ -32,6 +32,7 @@ type EndPoint struct {
 	msgOutBuf         []sim.Msg
 	msgOutBufSize     int
 	flitsToSend       []*messaging.Flit
+	credits           *outputCredits
 
 	assemblingMsgTable map[string]*list.Element
 	assemblingMsgs     *list.List
@@ -65,11 +66,17 @@ func (ep *EndPoint) Send(msg sim.Msg) *sim.SendError {
 	return nil
 }
 
-// PlugIn connects a port to the endpoint.
+// PlugIn connects a port to the endpoint. As the endpoint delivers messages
+// to the port directly when it ticks, the endpoint is coupled with the owner
+// of the port if the engine needs to know.
 func (ep *EndPoint) PlugIn(port sim.Port, srcBufCap int) {
 	port.SetConnection(ep)
 	ep.DevicePorts = append(ep.DevicePorts, port)
 	ep.msgOutBufSize = srcBufCap
+
+	if coupler, ok := ep.Engine.(sim.Coupler); ok {
+		coupler.Couple(ep, port.Component())
+	}
 }
 
 // NotifyAvailable triggers the endpoint to continue to tick.
@@ -114,12 +121,19 @@ func (ep *EndPoint) sendFlitOut(now sim.VTimeInSec) bool {
 			return madeProgress
 		}
 
-		flit := ep.flitsToSend[0]
+		index, vc := ep.nextFlitToSend()
+		if index < 0 {
+			return madeProgress
+		}
+
+		flit := ep.flitsToSend[index]
 		flit.SendTime = now
+		flit.VC = vc
 		err := ep.NetworkPort.Send(flit)
 
 		if err == nil {
-			ep.flitsToSend = ep.flitsToSend[1:]
+			ep.linkCredits().consume(vc)
+			ep.removeFlitToSend(index)
 
 			// fmt.Printf("%.10f, %s, ep send, %s, %d\n",
 			// 	ep.Engine.CurrentTime(), ep.Name(),
@@ -138,6 +152,42 @@ func (ep *EndPoint) sendFlitOut(now sim.VTimeInSec) bool {
 	return madeProgress
 }
 
+// nextFlitToSend returns the index of the first flit that has a free slot in
+// its virtual channel, together with the virtual channel. The index is -1 if
+// no flit can be sent.
+func (ep *EndPoint) nextFlitToSend() (index, vc int) {
+	credits := ep.linkCredits()
+	numVCs := credits.numVCs()
+
+	for i, flit := range ep.flitsToSend {
+		vc := messaging.VCForMsg(flit.Msg, numVCs)
+		if credits.available(vc) {
+			return i, vc
+		}
+	}
+
+	return -1, 0
+}
+
+func (ep *EndPoint) removeFlitToSend(index int) {
+	if index == 0 {
+		ep.flitsToSend = ep.flitsToSend[1:]
+		return
+	}
+
+	ep.flitsToSend = append(ep.flitsToSend[:index], ep.flitsToSend[index+1:]...)
+}
+
+// linkCredits returns the credits of the virtual channels of the switch port
+// that the endpoint sends flits to.
+func (ep *EndPoint) linkCredits() *outputCredits {
+	if ep.credits == nil || ep.credits.remote != ep.DefaultSwitchDst {
+		ep.credits = &outputCredits{remote: ep.DefaultSwitchDst}
+	}
+
+	return ep.credits
+}
+
 func (ep *EndPoint) prepareFlits(_ sim.VTimeInSec) bool {
 	madeProgress := false
 
@@ -169,12 +219,20 @@ func (ep *EndPoint) prepareFlits(_ sim.VTimeInSec) bool {
 func (ep *EndPoint) recv(now sim.VTimeInSec) bool {
 	madeProgress := false
 
-	for i := 0; i < ep.numInputChannels; i++ {
+	for i := 0; i < ep.numInputChannels; {
 		received := ep.NetworkPort.Peek()
 		if received == nil {
 			return madeProgress
 		}
 
+		if credit, ok := received.(*messaging.CreditMsg); ok {
+			ep.NetworkPort.Retrieve(now)
+			ep.linkCredits().release(credit)
+			madeProgress = true
+
+			continue
+		}
+
 		flit := received.(*messaging.Flit)
 		msg := flit.Msg
 
@@ -199,6 +257,8 @@ func (ep *EndPoint) recv(now sim.VTimeInSec) bool {
 
 		// fmt.Printf("%.10f, %s, ep received flit %s\n",
 		// 	now, ep.Name(), flit.ID)
+
+		i++
 	}
 
 	return madeProgress
@@ -24,6 +24,7 @@ var _ = Describe("End Point", func() {
 		devicePort = NewMockPort(mockCtrl)
 		networkPort = NewMockPort(mockCtrl)
 		defaultSwitchPort = NewMockPort(mockCtrl)
+		defaultSwitchPort.EXPECT().Component().Return(nil).AnyTimes()
 
 		devicePort.EXPECT().SetConnection(gomock.Any())
 
@@ -120,4 +121,41 @@ var _ = Describe("End Point", func() {
 		madeProgress = endPoint.Tick(14)
 		Expect(madeProgress).To(BeFalse())
 	})
+
+	It("should wait for credits before sending flits", func() {
+		msg := &sampleMsg{}
+		endPoint.credits = &outputCredits{
+			remote:   defaultSwitchPort,
+			resolved: true,
+			credits:  []int{0, 0},
+		}
+		credit := messaging.CreditMsgBuilder{}.
+			WithVC(0).
+			WithNumCredits(1).
+			Build()
+
+		engine.EXPECT().Schedule(gomock.Any())
+		endPoint.Send(msg)
+
+		networkPort.EXPECT().Peek().Return(nil)
+		madeProgress := endPoint.Tick(10)
+		Expect(madeProgress).To(BeTrue())
+
+		networkPort.EXPECT().Peek().Return(credit)
+		networkPort.EXPECT().Retrieve(sim.VTimeInSec(11))
+		networkPort.EXPECT().Peek().Return(nil)
+		madeProgress = endPoint.Tick(11)
+		Expect(madeProgress).To(BeTrue())
+		Expect(endPoint.credits.credits).To(Equal([]int{1, 0}))
+
+		networkPort.EXPECT().Peek().Return(nil)
+		networkPort.EXPECT().Send(gomock.Any()).Do(func(flit *messaging.Flit) {
+			Expect(flit.VC).To(Equal(0))
+			Expect(flit.Msg).To(BeIdenticalTo(msg))
+		})
+		devicePort.EXPECT().NotifyAvailable(gomock.Any())
+		madeProgress = endPoint.Tick(12)
+		Expect(madeProgress).To(BeTrue())
+		Expect(endPoint.credits.credits).To(Equal([]int{0, 0}))
+	})
 })
@@ -33,6 +33,18 @@ func (m *MockArbiter) EXPECT() *MockArbiterMockRecorder {
 	return m.recorder
 }
 
+// AcceptHook mocks base method.
+func (m *MockArbiter) AcceptHook(arg0 sim.Hook) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AcceptHook", arg0)
+}
+
+// AcceptHook indicates an expected call of AcceptHook.
+func (mr *MockArbiterMockRecorder) AcceptHook(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptHook", reflect.TypeOf((*MockArbiter)(nil).AcceptHook), arg0)
+}
+
 // AddBuffer mocks base method.
 func (m *MockArbiter) AddBuffer(arg0 sim.Buffer) {
 	m.ctrl.T.Helper()
@@ -58,3 +70,43 @@ func (mr *MockArbiterMockRecorder) Arbitrate(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Arbitrate", reflect.TypeOf((*MockArbiter)(nil).Arbitrate), arg0)
 }
+
+// Hooks mocks base method.
+func (m *MockArbiter) Hooks() []sim.Hook {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hooks")
+	ret0, _ := ret[0].([]sim.Hook)
+	return ret0
+}
+
+// Hooks indicates an expected call of Hooks.
+func (mr *MockArbiterMockRecorder) Hooks() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hooks", reflect.TypeOf((*MockArbiter)(nil).Hooks))
+}
+
+// NumHooks mocks base method.
+func (m *MockArbiter) NumHooks() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NumHooks")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// NumHooks indicates an expected call of NumHooks.
+func (mr *MockArbiterMockRecorder) NumHooks() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NumHooks", reflect.TypeOf((*MockArbiter)(nil).NumHooks))
+}
+
+// SetBlockedFunc mocks base method.
+func (m *MockArbiter) SetBlockedFunc(arg0 func(sim.Buffer) bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetBlockedFunc", arg0)
+}
+
+// SetBlockedFunc indicates an expected call of SetBlockedFunc.
+func (mr *MockArbiterMockRecorder) SetBlockedFunc(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBlockedFunc", reflect.TypeOf((*MockArbiter)(nil).SetBlockedFunc), arg0)
+}
@@ -37,7 +37,8 @@ type portComplex struct {
 	routeBuffer sim.Buffer
 
 	// The flits here are buffered to wait to be forwarded to the output buffer.
-	forwardBuffer sim.Buffer
+	// There is a forward buffer for each virtual channel.
+	forwardBuffers []sim.Buffer
 
 	// The flits here are waiting to be sent to the next hop.
 	sendOutBuffer sim.Buffer
@@ -51,6 +52,25 @@ type portComplex struct {
 	// switch to the port. The SendOutBuffer should have the capacity of this
 	// number.
 	numOutputChannel int
+
+	// vcBufferDepths is the number of flits that each virtual channel of the
+	// port can hold. It is empty if the port does not use virtual channels.
+	vcBufferDepths []int
+
+	// pendingCredits is the number of slots of each virtual channel that are
+	// freed but are not yet returned to the remote port.
+	pendingCredits []int
+
+	// outputCredits tracks the free slots of the remote port.
+	outputCredits *outputCredits
+}
+
+// inputVC identifies the port and the virtual channel that a forward buffer
+// belongs to.
+type inputVC struct {
+	port           sim.Port
+	pendingCredits []int
+	vc             int
 }
 
 // Switch is an Akita component that can forward request to destination.
@@ -61,13 +81,28 @@ type Switch struct {
 	portToComplexMapping map[sim.Port]portComplex
 	routingTable         routing.Table
 	arbiter              arbitration.Arbiter
+	sendOutBufToComplex  map[sim.Buffer]portComplex
+	forwardBufToInput    map[sim.Buffer]inputVC
 }
 
 // addPort adds a new port on the switch.
 func (s *Switch) addPort(complex portComplex) {
+	if complex.outputCredits == nil {
+		complex.outputCredits = &outputCredits{remote: complex.remotePort}
+	}
+
 	s.ports = append(s.ports, complex.localPort)
 	s.portToComplexMapping[complex.localPort] = complex
-	s.arbiter.AddBuffer(complex.forwardBuffer)
+	s.sendOutBufToComplex[complex.sendOutBuffer] = complex
+
+	for vc, buf := range complex.forwardBuffers {
+		s.arbiter.AddBuffer(buf)
+		s.forwardBufToInput[buf] = inputVC{
+			port:           complex.localPort,
+			pendingCredits: complex.pendingCredits,
+			vc:             vc,
+		}
+	}
 }
 
 // GetRoutingTable returns the routine table used by the switch.
@@ -75,6 +110,29 @@ func (s *Switch) GetRoutingTable() routing.Table {
 	return s.routingTable
 }
 
+// OutputOccupancy returns the number of flits that are queued to leave the
+// switch through the given port. It includes the flits that wait in the
+// switch, and the flits that the remote port holds. If the remote port uses
+// virtual channels, the flits that hold its credits are counted instead.
+func (s *Switch) OutputOccupancy(port sim.Port) int {
+	pc, found := s.portToComplexMapping[port]
+	if !found {
+		return 0
+	}
+
+	occupancy := pc.sendOutBuffer.Size()
+
+	if pc.outputCredits.numVCs() > 0 {
+		return occupancy + pc.outputCredits.used()
+	}
+
+	if remote, ok := pc.remotePort.(interface{ NumIncoming() int }); ok {
+		occupancy += remote.NumIncoming()
+	}
+
+	return occupancy
+}
+
 // Tick update the Switch's state.
 func (s *Switch) Tick(now sim.VTimeInSec) bool {
 	madeProgress := false
@@ -100,12 +158,20 @@ func (s *Switch) startProcessing(now sim.VTimeInSec) (madeProgress bool) {
 	for _, port := range s.ports {
 		pc := s.portToComplexMapping[port]
 
-		for i := 0; i < pc.numInputChannel; i++ {
+		for i := 0; i < pc.numInputChannel; {
 			item := port.Peek()
 			if item == nil {
 				break
 			}
 
+			if credit, ok := item.(*messaging.CreditMsg); ok {
+				port.Retrieve(now)
+				pc.outputCredits.release(credit)
+				madeProgress = true
+
+				continue
+			}
+
 			if !pc.pipeline.CanAccept() {
 				break
 			}
@@ -128,6 +194,8 @@ func (s *Switch) startProcessing(now sim.VTimeInSec) (madeProgress bool) {
 
 			// fmt.Printf("%.10f, %s, switch recv flit, %s\n",
 			// 	now, s.Name(), flit.ID)
+
+			i++
 		}
 	}
 
@@ -147,7 +215,6 @@ func (s *Switch) route(_ sim.VTimeInSec) (madeProgress bool) {
 	for _, port := range s.ports {
 		pc := s.portToComplexMapping[port]
 		routeBuf := pc.routeBuffer
-		forwardBuf := pc.forwardBuffer
 
 		for i := 0; i < pc.numInputChannel; i++ {
 			item := routeBuf.Peek()
@@ -155,12 +222,14 @@ func (s *Switch) route(_ sim.VTimeInSec) (madeProgress bool) {
 				break
 			}
 
+			pipelineItem := item.(flitPipelineItem)
+			flit := pipelineItem.flit
+
+			forwardBuf := pc.forwardBuffers[flit.VC%len(pc.forwardBuffers)]
 			if !forwardBuf.CanPush() {
 				break
 			}
 
-			pipelineItem := item.(flitPipelineItem)
-			flit := pipelineItem.flit
 			s.assignFlitOutputBuf(flit)
 			routeBuf.Pop()
 			forwardBuf.Push(flit)
@@ -178,34 +247,101 @@ func (s *Switch) forward(now sim.VTimeInSec) (madeProgress bool) {
 	inputBuffers := s.arbiter.Arbitrate(now)
 
 	for _, buf := range inputBuffers {
-		for {
-			item := buf.Peek()
-			if item == nil {
-				break
-			}
-
-			flit := item.(*messaging.Flit)
-			if !flit.OutputBuf.CanPush() {
-				break
-			}
-
-			flit.OutputBuf.Push(flit)
-			buf.Pop()
+		for s.forwardFlit(buf) {
 			madeProgress = true
-
-			// fmt.Printf("%.10f, %s, switch forward flit, %s\n",
-			// now, s.Name(), item.(*messaging.Flit).ID)
 		}
 	}
 
 	return madeProgress
 }
 
+// forwardFlit moves the flit at the head of an input buffer to its output
+// buffer.
+func (s *Switch) forwardFlit(buf sim.Buffer) bool {
+	item := buf.Peek()
+	if item == nil {
+		return false
+	}
+
+	flit := item.(*messaging.Flit)
+	if !flit.OutputBuf.CanPush() {
+		return false
+	}
+
+	out := s.sendOutBufToComplex[flit.OutputBuf]
+	vc := s.selectVC(flit, s.forwardBufToInput[buf], out)
+	if !out.outputCredits.available(vc) {
+		return false
+	}
+
+	out.outputCredits.consume(vc)
+	s.freeInputSlot(buf)
+
+	flit.VC = vc
+	flit.OutputBuf.Push(flit)
+	buf.Pop()
+
+	return true
+}
+
+// isBlockedByCredits tells the arbiter that the flit at the head of an input
+// buffer waits for the credits of its virtual channel. The arbiter does not
+// grant such a buffer, so that the output can be used by another virtual
+// channel under the arbiter's own policy. Otherwise, the arbiter may keep
+// granting a virtual channel that waits for credits, while another virtual
+// channel that can make progress, and that eventually returns the credits,
+// starves.
+func (s *Switch) isBlockedByCredits(buf sim.Buffer) bool {
+	item := buf.Peek()
+	if item == nil {
+		return false
+	}
+
+	flit := item.(*messaging.Flit)
+	out := s.sendOutBufToComplex[flit.OutputBuf]
+	vc := s.selectVC(flit, s.forwardBufToInput[buf], out)
+
+	return !out.outputCredits.available(vc)
+}
+
+// selectVC returns the virtual channel that the flit uses on the link of the
+// output port.
+func (s *Switch) selectVC(
+	flit *messaging.Flit,
+	in inputVC,
+	out portComplex,
+) int {
+	numVCs := out.outputCredits.numVCs()
+	if numVCs <= 1 {
+		return 0
+	}
+
+	if selector, ok := s.routingTable.(routing.VCSelector); ok {
+		return selector.SelectVC(
+			flit.Msg, in.port, flit.VC, out.localPort, numVCs)
+	}
+
+	return messaging.VCForMsg(flit.Msg, numVCs)
+}
+
+// freeInputSlot records that a slot of a virtual channel is freed, so that
+// the credit can be returned to the sender.
+func (s *Switch) freeInputSlot(forwardBuf sim.Buffer) {
+	in := s.forwardBufToInput[forwardBuf]
+	if in.pendingCredits == nil {
+		return
+	}
+
+	in.pendingCredits[in.vc]++
+}
+
 func (s *Switch) sendOut(now sim.VTimeInSec) (madeProgress bool) {
 	for _, port := range s.ports {
 		pc := s.portToComplexMapping[port]
 		sendOutBuf := pc.sendOutBuffer
 
+		madeProgress = s.sendCredits(now, pc) || madeProgress
+
 		for i := 0; i < pc.numOutputChannel; i++ {
 			item := sendOutBuf.Peek()
 			if item == nil {
@@ -233,6 +369,37 @@ func (s *Switch) sendOut(now sim.VTimeInSec) (madeProgress bool) {
 	return madeProgress
 }
 
+// sendCredits returns the freed slots of the virtual channels of a port to the
+// remote port.
+func (s *Switch) sendCredits(
+	now sim.VTimeInSec,
+	pc portComplex,
+) (madeProgress bool) {
+	for vc, numCredits := range pc.pendingCredits {
+		if numCredits == 0 {
+			continue
+		}
+
+		msg := messaging.CreditMsgBuilder{}.
+			WithSendTime(now).
+			WithSrc(pc.localPort).
+			WithDst(pc.remotePort).
+			WithVC(vc).
+			WithNumCredits(numCredits).
+			Build()
+
+		err := pc.localPort.Send(msg)
+		if err != nil {
+			return madeProgress
+		}
+
+		pc.pendingCredits[vc] = 0
+		madeProgress = true
+	}
+
+	return madeProgress
+}
+
 func (s *Switch) assignFlitOutputBuf(f *messaging.Flit) {
 	outPort := s.routingTable.FindPort(f.Msg.Meta().Dst)
 	if outPort == nil {
@@ -292,7 +459,10 @@ func (b SwitchBuilder) Build(name string) *Switch {
 	s.TickingComponent = sim.NewTickingComponent(name, b.engine, b.freq, s)
 	s.routingTable = b.routingTable
 	s.arbiter = b.arbiter
+	s.arbiter.SetBlockedFunc(s.isBlockedByCredits)
 	s.portToComplexMapping = make(map[sim.Port]portComplex)
+	s.sendOutBufToComplex = make(map[sim.Buffer]portComplex)
+	s.forwardBufToInput = make(map[sim.Buffer]inputVC)
 	return s
 }
 
@@ -328,6 +498,7 @@ type SwitchPortAdder struct {
 	latency          int
 	numInputChannel  int
 	numOutputChannel int
+	vcBufferDepths   []int
 }
 
 // MakeSwitchPortAdder creates a SwitchPortAdder that can add ports for the
@@ -371,13 +542,27 @@ func (a SwitchPortAdder) WithNumOutputChannel(num int) SwitchPortAdder {
 	return a
 }
 
+// WithVCBufferDepths enables virtual channels on the port. Each depth is the
+// number of flits that a virtual channel can hold, and the number of depths is
+// the number of virtual channels. Requests and responses travel on separate
+// virtual channels. The sender on the other side of the link only sends a
+// flit when the virtual channel has a free slot, and the freed slots are
+// returned with credit messages.
+//
+// Virtual channels should be enabled on both ends of a link between two
+// switches, as the credits share the link with the flits.
+func (a SwitchPortAdder) WithVCBufferDepths(depths ...int) SwitchPortAdder {
+	a.vcBufferDepths = depths
+	return a
+}
+
 // AddPort adds the port to the switch.
 func (a SwitchPortAdder) AddPort() {
 	complexID := len(a.sw.ports)
 	complexName := fmt.Sprintf("%s.PortComplex%d", a.sw.Name(), complexID)
 
 	sendOutBuf := sim.NewBuffer(complexName+"SendOutBuf", a.numOutputChannel)
-	forwardBuf := sim.NewBuffer(complexName+"ForwardBuf", a.numInputChannel)
+	forwardBufs := a.createForwardBuffers(complexName)
 	routeBuf := sim.NewBuffer(complexName+"RouteBuf", a.numInputChannel)
 	pipeline := pipelining.MakeBuilder().
 		WithNumStage(a.latency).
@@ -391,11 +576,36 @@ func (a SwitchPortAdder) AddPort() {
 		remotePort:       a.remotePort,
 		pipeline:         pipeline,
 		routeBuffer:      routeBuf,
-		forwardBuffer:    forwardBuf,
+		forwardBuffers:   forwardBufs,
 		sendOutBuffer:    sendOutBuf,
 		numInputChannel:  a.numInputChannel,
 		numOutputChannel: a.numOutputChannel,
 	}
 
+	if len(a.vcBufferDepths) > 0 {
+		pc.vcBufferDepths = append([]int(nil), a.vcBufferDepths...)
+		pc.pendingCredits = make([]int, len(a.vcBufferDepths))
+	}
+
 	a.sw.addPort(pc)
 }
+
+func (a SwitchPortAdder) createForwardBuffers(complexName string) []sim.Buffer {
+	if len(a.vcBufferDepths) == 0 {
+		return []sim.Buffer{
+			sim.NewBuffer(complexName+"ForwardBuf", a.numInputChannel),
+		}
+	}
+
+	bufs := make([]sim.Buffer, len(a.vcBufferDepths))
+	for vc, depth := range a.vcBufferDepths {
+		if depth <= 0 {
+			panic("the buffer depth of a virtual channel must be positive")
+		}
+
+		bufs[vc] = sim.NewBuffer(
+			fmt.Sprintf("%sForwardBuf.VC%d", complexName, vc), depth)
+	}
+
+	return bufs
+}
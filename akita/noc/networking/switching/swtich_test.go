@@ -11,6 +11,7 @@ import (
 func createMockPortComplex(ctrl *gomock.Controller) portComplex {
 	local := NewMockPort(ctrl)
 	remote := NewMockPort(ctrl)
+	remote.EXPECT().Component().Return(nil).AnyTimes()
 	routeBuf := NewMockBuffer(ctrl)
 	forwardBuf := NewMockBuffer(ctrl)
 	sendOutBuf := NewMockBuffer(ctrl)
@@ -21,7 +22,7 @@ func createMockPortComplex(ctrl *gomock.Controller) portComplex {
 		remotePort:       remote,
 		pipeline:         pipeline,
 		routeBuffer:      routeBuf,
-		forwardBuffer:    forwardBuf,
+		forwardBuffers:   []sim.Buffer{forwardBuf},
 		sendOutBuffer:    sendOutBuf,
 		numInputChannel:  1,
 		numOutputChannel: 1,
@@ -51,6 +52,7 @@ var _ = Describe("Switch", func() {
 		routingTable = NewMockTable(mockCtrl)
 		arbiter = NewMockArbiter(mockCtrl)
 		arbiter.EXPECT().AddBuffer(gomock.Any()).AnyTimes()
+		arbiter.EXPECT().SetBlockedFunc(gomock.Any()).AnyTimes()
 		sw = SwitchBuilder{}.
 			WithEngine(engine).
 			WithFreq(1).
@@ -131,7 +133,7 @@ var _ = Describe("Switch", func() {
 	It("should route", func() {
 		routeBuffer1 := portComplex1.routeBuffer.(*MockBuffer)
 		routeBuffer2 := portComplex2.routeBuffer.(*MockBuffer)
-		forwardBuffer1 := portComplex1.forwardBuffer.(*MockBuffer)
+		forwardBuffer1 := portComplex1.forwardBuffers[0].(*MockBuffer)
 
 		msg := &sampleMsg{}
 		msg.Src = dstPort
@@ -157,7 +159,7 @@ var _ = Describe("Switch", func() {
 	It("should not route if forward buffer is full", func() {
 		routeBuffer1 := portComplex1.routeBuffer.(*MockBuffer)
 		routeBuffer2 := portComplex2.routeBuffer.(*MockBuffer)
-		forwardBuffer1 := portComplex1.forwardBuffer.(*MockBuffer)
+		forwardBuffer1 := portComplex1.forwardBuffers[0].(*MockBuffer)
 
 		msg := &sampleMsg{}
 		msg.Src = dstPort
@@ -177,8 +179,8 @@ var _ = Describe("Switch", func() {
 	})
 
 	It("should forward", func() {
-		forwardBuffer1 := portComplex1.forwardBuffer.(*MockBuffer)
-		forwardBuffer2 := portComplex2.forwardBuffer.(*MockBuffer)
+		forwardBuffer1 := portComplex1.forwardBuffers[0].(*MockBuffer)
+		forwardBuffer2 := portComplex2.forwardBuffers[0].(*MockBuffer)
 		sendOutBuffer2 := portComplex2.sendOutBuffer.(*MockBuffer)
 
 		msg := &sampleMsg{}
@@ -205,8 +207,8 @@ var _ = Describe("Switch", func() {
 	})
 
 	It("should not forward if the output buffer is busy", func() {
-		forwardBuffer1 := portComplex1.forwardBuffer.(*MockBuffer)
-		forwardBuffer2 := portComplex2.forwardBuffer.(*MockBuffer)
+		forwardBuffer1 := portComplex1.forwardBuffers[0].(*MockBuffer)
+		forwardBuffer2 := portComplex2.forwardBuffers[0].(*MockBuffer)
 		sendOutBuffer2 := portComplex2.sendOutBuffer.(*MockBuffer)
 
 		msg := &sampleMsg{}
@@ -0,0 +1,293 @@
+package topology
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/sarchlab/akita/v3/monitoring"
+	"github.com/sarchlab/akita/v3/noc/messaging"
+	"github.com/sarchlab/akita/v3/noc/networking/networkconnector"
+	"github.com/sarchlab/akita/v3/noc/networking/noctracing"
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/akita/v3/tracing"
+)
+
+// Builder can build networks of different topologies.
+type Builder struct {
+	engine               sim.Engine
+	freq                 sim.Freq
+	monitor              *monitoring.Monitor
+	visTracer            tracing.Tracer
+	flitSize             int
+	switchLatency        int
+	linkLatency          int
+	linkTransferPerCycle float64
+	vcBufferDepth        int
+	linkModel            *messaging.LinkModel
+	statsCollector       *noctracing.StatsCollector
+}
+
+// MakeBuilder creates a Builder with default parameters.
+func MakeBuilder() Builder {
+	return Builder{
+		freq:                 1 * sim.GHz,
+		flitSize:             16,
+		switchLatency:        1,
+		linkLatency:          1,
+		linkTransferPerCycle: 1,
+		vcBufferDepth:        16,
+	}
+}
+
+// WithEngine sets the engine that the network uses.
+func (b Builder) WithEngine(e sim.Engine) Builder {
+	b.engine = e
+	return b
+}
+
+// WithFreq sets the frequency of the switches.
+func (b Builder) WithFreq(freq sim.Freq) Builder {
+	b.freq = freq
+	return b
+}
+
+// WithMonitor sets the monitor that inspects the switches and the links.
+func (b Builder) WithMonitor(m *monitoring.Monitor) Builder {
+	b.monitor = m
+	return b
+}
+
+// WithVisTracer sets the tracer that can visualize the network.
+func (b Builder) WithVisTracer(t tracing.Tracer) Builder {
+	b.visTracer = t
+	return b
+}
+
+// WithStatsCollector sets the collector that collects the link utilization,
+// the hop counts, the queueing delay of the switches, and the latency between
+// the devices of the network.
+func (b Builder) WithStatsCollector(sc *noctracing.StatsCollector) Builder {
+	b.statsCollector = sc
+	return b
+}
+
+// WithFlitSize sets the number of bytes that a flit carries.
+func (b Builder) WithFlitSize(size int) Builder {
+	b.flitSize = size
+	return b
+}
+
+// WithSwitchLatency sets the number of cycles that a flit spends in a switch.
+func (b Builder) WithSwitchLatency(numCycles int) Builder {
+	b.switchLatency = numCycles
+	return b
+}
+
+// WithLinkLatency sets the number of cycles that a flit spends on a link
+// between two switches.
+func (b Builder) WithLinkLatency(numCycles int) Builder {
+	b.linkLatency = numCycles
+	return b
+}
+
+// WithLinkBandwidth sets the number of flits that a link between two switches
+// can transfer in each cycle.
+func (b Builder) WithLinkBandwidth(transferPerCycle float64) Builder {
+	b.linkTransferPerCycle = transferPerCycle
+	return b
+}
+
+// WithLinkModel sets the SerDes latency, the bit errors, the power states, and
+// the energy of the links between the switches.
+func (b Builder) WithLinkModel(model messaging.LinkModel) Builder {
+	b.linkModel = &model
+	return b
+}
+
+// WithVCBufferDepth sets the number of flits that each virtual channel can
+// buffer in the topologies that use virtual channels.
+func (b Builder) WithVCBufferDepth(depth int) Builder {
+	b.vcBufferDepth = depth
+	return b
+}
+
+// LinkParameter defines the bandwidth and the latency of a link.
+type LinkParameter struct {
+	TransferPerCycle float64
+	Latency          int
+
+	// Model adds the SerDes latency, the bit errors, the power states, and
+	// the energy to the link if set.
+	Model *messaging.LinkModel
+}
+
+// Network is a network built by the Builder.
+type Network struct {
+	channels []*messaging.Channel
+}
+
+// Channels returns the links between the switches, whose LinkStats tell the
+// activities and the energy of the links.
+func (n Network) Channels() []*messaging.Channel {
+	return n.channels
+}
+
+// network keeps the switches and the devices while a network is being built.
+type network struct {
+	b         Builder
+	connector networkconnector.Connector
+	tables    []*datelineTable
+
+	deviceSwitch []int
+	devicePorts  [][]sim.Port
+}
+
+func (b Builder) newNetwork(name string) *network {
+	if b.engine == nil {
+		panic("engine is not set")
+	}
+
+	connector := networkconnector.MakeConnector().
+		WithEngine(b.engine).
+		WithDefaultFreq(b.freq).
+		WithFlitSize(b.flitSize)
+
+	if b.monitor != nil {
+		connector = connector.WithMonitor(b.monitor)
+	}
+
+	if b.visTracer != nil {
+		connector = connector.WithVisTracer(b.visTracer)
+	}
+
+	if b.statsCollector != nil {
+		connector = connector.WithStatsCollector(b.statsCollector)
+	}
+
+	connector.NewNetwork(name)
+
+	return &network{b: b, connector: connector}
+}
+
+func (b Builder) defaultLink() LinkParameter {
+	return LinkParameter{
+		TransferPerCycle: b.linkTransferPerCycle,
+		Latency:          b.linkLatency,
+		Model:            b.linkModel,
+	}
+}
+
+func (n *network) built() Network {
+	return Network{channels: n.connector.Channels()}
+}
+
+func (n *network) addSwitch(name string) int {
+	rt := newDatelineTable()
+	n.tables = append(n.tables, rt)
+
+	return n.connector.AddSwitchWithNameAndRoutingTable(name, rt)
+}
+
+// plugInDevice connects a device to a switch and routes the messages to the
+// device from the switch.
+func (n *network) plugInDevice(switchID int, ports []sim.Port) {
+	epName := fmt.Sprintf("EP[%d]", len(n.devicePorts))
+	_, swPort := n.connector.ConnectDeviceWithEPName(epName, switchID, ports,
+		networkconnector.DeviceToSwitchLinkParameter{
+			DeviceEndParam: networkconnector.LinkEndDeviceParameter{
+				IncomingBufSize:  4,
+				OutgoingBufSize:  4,
+				NumInputChannel:  1,
+				NumOutputChannel: 1,
+			},
+			SwitchEndParam: networkconnector.LinkEndSwitchParameter{
+				IncomingBufSize:  4,
+				OutgoingBufSize:  4,
+				Latency:          n.b.switchLatency,
+				NumInputChannel:  1,
+				NumOutputChannel: 1,
+			},
+			LinkParam: networkconnector.LinkParameter{
+				IsIdeal:   true,
+				Frequency: n.b.freq,
+			},
+		})
+
+	for _, p := range ports {
+		n.tables[switchID].DefineRoute(p, swPort)
+	}
+
+	n.deviceSwitch = append(n.deviceSwitch, switchID)
+	n.devicePorts = append(n.devicePorts, ports)
+}
+
+// connect creates a link between two switches. If useVCs is set, the ports
+// on both sides have two virtual channels per message class.
+func (n *network) connect(
+	a, b int,
+	link LinkParameter,
+	useVCs bool,
+) (portA, portB sim.Port) {
+	transferPerCycle := int(math.Ceil(link.TransferPerCycle))
+
+	var vcBufferDepths []int
+	if useVCs {
+		vcBufferDepths = []int{
+			n.b.vcBufferDepth, n.b.vcBufferDepth,
+			n.b.vcBufferDepth, n.b.vcBufferDepth,
+		}
+	}
+
+	end := networkconnector.LinkEndSwitchParameter{
+		IncomingBufSize:  transferPerCycle,
+		OutgoingBufSize:  transferPerCycle,
+		Latency:          n.b.switchLatency,
+		NumInputChannel:  transferPerCycle,
+		NumOutputChannel: transferPerCycle,
+		VCBufferDepths:   vcBufferDepths,
+	}
+
+	// The pipeline of the channel runs at the frequency of the link, so the
+	// latency is converted to the cycles of the link.
+	numStage := int(math.Round(float64(link.Latency) * link.TransferPerCycle))
+	if numStage < 1 {
+		numStage = 1
+	}
+
+	portA, portB = n.connector.ConnectSwitches(a, b,
+		networkconnector.SwitchToSwitchLinkParameter{
+			LeftEndParam:  end,
+			RightEndParam: end,
+			LinkParam: networkconnector.LinkParameter{
+				IsIdeal:       false,
+				Frequency:     n.b.freq * sim.Freq(link.TransferPerCycle),
+				NumStage:      numStage,
+				CyclePerStage: 1,
+				PipelineWidth: 1,
+				Model:         link.Model,
+			},
+		})
+
+	n.tables[a].links[portA] = true
+	n.tables[b].links[portB] = true
+
+	return portA, portB
+}
+
+// defineRoutes defines the routes to all the devices on all the switches.
+// The nextHop function returns the output port of a switch to reach the
+// switch of a device.
+func (n *network) defineRoutes(nextHop func(sw, dstSw int) sim.Port) {
+	for sw, rt := range n.tables {
+		for d, dstSw := range n.deviceSwitch {
+			if dstSw == sw {
+				continue
+			}
+
+			port := nextHop(sw, dstSw)
+			for _, p := range n.devicePorts[d] {
+				rt.DefineRoute(p, port)
+			}
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package topology
+
+import (
+	"fmt"
+
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+// ChipletParameter defines the shape of a multi-chiplet mesh.
+type ChipletParameter struct {
+	// NumChiplets is the number of chiplets in the X and the Y dimension.
+	NumChiplets [2]int
+
+	// ChipletSize is the number of switches of each chiplet in the X and the
+	// Y dimension.
+	ChipletSize [2]int
+
+	// InterChipletLink defines the links that cross the boundaries of the
+	// chiplets.
+	InterChipletLink LinkParameter
+}
+
+// BuildChiplet builds a mesh of chiplets, each of which has a mesh of
+// switches. The switches on the edges of neighboring chiplets are connected
+// by inter-chiplet links, which usually have a lower bandwidth and a higher
+// latency than the links in a chiplet. The switch at (x, y) of the whole mesh
+// connects the device with index y*width+x. The flits use dimension-order
+// routing, first along X and then along Y.
+func (b Builder) BuildChiplet(
+	name string,
+	param ChipletParameter,
+	devices [][]sim.Port,
+) Network {
+	c := chipletMesh{
+		width:  param.NumChiplets[0] * param.ChipletSize[0],
+		height: param.NumChiplets[1] * param.ChipletSize[1],
+		param:  param,
+	}
+
+	if len(devices) > c.width*c.height {
+		panic("too many devices for the chiplet mesh")
+	}
+
+	n := b.newNetwork(name)
+
+	for y := 0; y < c.height; y++ {
+		for x := 0; x < c.width; x++ {
+			n.addSwitch(fmt.Sprintf("Chiplet[%d][%d].Switch[%d][%d]",
+				x/param.ChipletSize[0], y/param.ChipletSize[1],
+				x%param.ChipletSize[0], y%param.ChipletSize[1]))
+		}
+	}
+
+	for d, ports := range devices {
+		n.plugInDevice(d, ports)
+	}
+
+	c.connect(n, b.defaultLink(), param.InterChipletLink)
+
+	n.defineRoutes(c.nextHop)
+
+	return n.built()
+}
+
+type chipletMesh struct {
+	width, height int
+	param         ChipletParameter
+
+	// ports[sw][d][dir] is the port of the switch sw to the neighbor in the
+	// dimension d and the direction dir.
+	ports [][2][2]sim.Port
+}
+
+func (c *chipletMesh) connect(n *network, intra, inter LinkParameter) {
+	c.ports = make([][2][2]sim.Port, c.width*c.height)
+
+	for y := 0; y < c.height; y++ {
+		for x := 0; x < c.width; x++ {
+			sw := y*c.width + x
+
+			if x+1 < c.width {
+				link := intra
+				if (x+1)%c.param.ChipletSize[0] == 0 {
+					link = inter
+				}
+
+				c.ports[sw][0][1], c.ports[sw+1][0][0] =
+					n.connect(sw, sw+1, link, false)
+			}
+
+			if y+1 < c.height {
+				link := intra
+				if (y+1)%c.param.ChipletSize[1] == 0 {
+					link = inter
+				}
+
+				c.ports[sw][1][1], c.ports[sw+c.width][1][0] =
+					n.connect(sw, sw+c.width, link, false)
+			}
+		}
+	}
+}
+
+func (c *chipletMesh) nextHop(sw, dstSw int) sim.Port {
+	x, y := sw%c.width, sw/c.width
+	dstX, dstY := dstSw%c.width, dstSw/c.width
+
+	switch {
+	case dstX > x:
+		return c.ports[sw][0][1]
+	case dstX < x:
+		return c.ports[sw][0][0]
+	case dstY > y:
+		return c.ports[sw][1][1]
+	default:
+		return c.ports[sw][1][0]
+	}
+}
@@ -0,0 +1,57 @@
+package topology
+
+import (
+	"github.com/sarchlab/akita/v3/noc/messaging"
+	"github.com/sarchlab/akita/v3/noc/networking/routing"
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+// datelineTable is a routing table that also selects the virtual channels with
+// the dateline method. Each message class uses two virtual channels. A flit
+// uses the first one until it crosses a dateline link, and uses the second one
+// for the rest of the route.
+type datelineTable struct {
+	routing.Table
+
+	// links are the ports that connect to other switches.
+	links map[sim.Port]bool
+
+	// datelines are the output ports that move flits to the second virtual
+	// channel.
+	datelines map[sim.Port]bool
+}
+
+func newDatelineTable() *datelineTable {
+	return &datelineTable{
+		Table:     routing.NewTable(),
+		links:     make(map[sim.Port]bool),
+		datelines: make(map[sim.Port]bool),
+	}
+}
+
+// SelectVC selects the virtual channel of a flit on the output port.
+func (t *datelineTable) SelectVC(
+	msg sim.Msg,
+	inPort sim.Port,
+	inVC int,
+	outPort sim.Port,
+	numVCs int,
+) int {
+	first, count := messaging.VCRangeForMsg(msg, numVCs)
+	if count < 2 {
+		return messaging.VCForMsg(msg, numVCs)
+	}
+
+	if t.datelines[outPort] {
+		return first + 1
+	}
+
+	// Flits from the devices may use any virtual channel of their class on
+	// the first link, so only the flits from other switches keep the second
+	// virtual channel.
+	if t.links[inPort] && inVC == first+1 {
+		return first + 1
+	}
+
+	return first
+}
@@ -0,0 +1,16 @@
+// Package topology provides builders for the common network topologies,
+// including rings, fat-trees, dragonflies, and multi-chiplet meshes.
+//
+// The builders create the switches and the links with a
+// networkconnector.Connector and generate the routing tables of all the
+// switches, so that the users only need to provide the ports of the devices.
+// The devices are attached to the switches in the order that they are given.
+//
+// The routes are minimal and deterministic. The topologies that have cycles
+// in the routes, the rings and the dragonflies, use two virtual channels per
+// message class on the links between the switches. A flit moves to the second
+// virtual channel after it crosses a dateline link and stays there, which
+// breaks the cyclic dependencies. The fat-trees and the chiplet meshes route
+// with up*/down* and dimension-order routing, which are deadlock-free without
+// virtual channels.
+package topology
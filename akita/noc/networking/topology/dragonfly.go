@@ -0,0 +1,132 @@
+package topology
+
+import (
+	"fmt"
+
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+// DragonflyParameter defines the shape of a dragonfly.
+type DragonflyParameter struct {
+	NumGroups           int
+	NumRoutersPerGroup  int
+	NumDevicesPerRouter int
+
+	// GlobalLink defines the links between the groups. The links in a group
+	// use the default link of the builder if GlobalLink is not set.
+	GlobalLink *LinkParameter
+}
+
+// BuildDragonfly builds a dragonfly. The routers in each group are fully
+// connected, and each pair of groups is connected by one global link. The
+// global links of a group are spread over the routers of the group.
+//
+// The flits use minimal routing, which takes at most one local link in the
+// source group, one global link, and one local link in the destination group.
+// The global links are the datelines.
+func (b Builder) BuildDragonfly(
+	name string,
+	param DragonflyParameter,
+	devices [][]sim.Port,
+) Network {
+	numRouters := param.NumGroups * param.NumRoutersPerGroup
+	if len(devices) > numRouters*param.NumDevicesPerRouter {
+		panic("too many devices for the dragonfly")
+	}
+
+	n := b.newNetwork(name)
+	for g := 0; g < param.NumGroups; g++ {
+		for r := 0; r < param.NumRoutersPerGroup; r++ {
+			n.addSwitch(fmt.Sprintf("Switch[%d][%d]", g, r))
+		}
+	}
+
+	for d, ports := range devices {
+		n.plugInDevice(d/param.NumDevicesPerRouter, ports)
+	}
+
+	df := dragonfly{param: param}
+	df.connectLocal(n, b.defaultLink())
+
+	globalLink := b.defaultLink()
+	if param.GlobalLink != nil {
+		globalLink = *param.GlobalLink
+	}
+
+	df.connectGlobal(n, globalLink)
+
+	n.defineRoutes(df.nextHop)
+
+	return n.built()
+}
+
+type dragonfly struct {
+	param DragonflyParameter
+
+	// local maps a pair of routers in a group to the port of the first
+	// router. global maps a router and a group to the port of the router.
+	local  map[[2]int]sim.Port
+	global map[[2]int]sim.Port
+}
+
+// gateway returns the router in group g that has the global link to group h.
+func (df *dragonfly) gateway(g, h int) int {
+	m := h
+	if h > g {
+		m--
+	}
+
+	return g*df.param.NumRoutersPerGroup + m%df.param.NumRoutersPerGroup
+}
+
+func (df *dragonfly) group(router int) int {
+	return router / df.param.NumRoutersPerGroup
+}
+
+func (df *dragonfly) connectLocal(n *network, link LinkParameter) {
+	df.local = make(map[[2]int]sim.Port)
+	a := df.param.NumRoutersPerGroup
+
+	for g := 0; g < df.param.NumGroups; g++ {
+		for i := g * a; i < (g+1)*a; i++ {
+			for j := i + 1; j < (g+1)*a; j++ {
+				portI, portJ := n.connect(i, j, link, true)
+				df.local[[2]int{i, j}] = portI
+				df.local[[2]int{j, i}] = portJ
+			}
+		}
+	}
+}
+
+func (df *dragonfly) connectGlobal(n *network, link LinkParameter) {
+	df.global = make(map[[2]int]sim.Port)
+
+	for g := 0; g < df.param.NumGroups; g++ {
+		for h := g + 1; h < df.param.NumGroups; h++ {
+			i := df.gateway(g, h)
+			j := df.gateway(h, g)
+
+			portI, portJ := n.connect(i, j, link, true)
+			df.global[[2]int{i, h}] = portI
+			df.global[[2]int{j, g}] = portJ
+			n.tables[i].datelines[portI] = true
+			n.tables[j].datelines[portJ] = true
+		}
+	}
+}
+
+func (df *dragonfly) nextHop(sw, dstSw int) sim.Port {
+	g := df.group(sw)
+	h := df.group(dstSw)
+
+	if g == h {
+		return df.local[[2]int{sw, dstSw}]
+	}
+
+	gateway := df.gateway(g, h)
+	if gateway == sw {
+		return df.global[[2]int{sw, h}]
+	}
+
+	return df.local[[2]int{sw, gateway}]
+}
@@ -0,0 +1,141 @@
+package topology
+
+import (
+	"fmt"
+
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+// BuildFatTree builds a k-ary n-tree, where k is the arity and n is the number
+// of levels. Each level has k^(n-1) switches. Each leaf switch connects k
+// devices, so that the tree can connect up to k^n devices. Each switch that is
+// not a root connects k parent switches.
+//
+// The flits go up until they reach a switch that is an ancestor of the
+// destination and then go down. The parent is selected by the destination
+// (d-mod-k routing), which spreads the traffic to different destinations over
+// the roots.
+func (b Builder) BuildFatTree(
+	name string,
+	arity, numLevels int,
+	devices [][]sim.Port,
+) Network {
+	t := fatTree{k: arity, n: numLevels}
+	t.numSwitchesPerLevel = pow(arity, numLevels-1)
+
+	if len(devices) > t.numSwitchesPerLevel*arity {
+		panic("too many devices for the fat-tree")
+	}
+
+	n := b.newNetwork(name)
+
+	for l := 0; l < numLevels; l++ {
+		for w := 0; w < t.numSwitchesPerLevel; w++ {
+			n.addSwitch(fmt.Sprintf("Switch[%d][%d]", l, w))
+		}
+	}
+
+	t.connect(n, b.defaultLink())
+
+	for d, ports := range devices {
+		n.plugInDevice(t.switchID(0, d/arity), ports)
+	}
+
+	for d, ports := range devices {
+		for l := 0; l < numLevels; l++ {
+			for w := 0; w < t.numSwitchesPerLevel; w++ {
+				if l == 0 && w == d/arity {
+					continue
+				}
+
+				port := t.nextHop(l, w, d)
+				for _, p := range ports {
+					n.tables[t.switchID(l, w)].DefineRoute(p, port)
+				}
+			}
+		}
+	}
+
+	return n.built()
+}
+
+type fatTree struct {
+	k, n                int
+	numSwitchesPerLevel int
+
+	// up[l][w][j] and down[l][w][j] are the j-th parent port and the j-th
+	// child port of the switch w at level l.
+	up, down [][][]sim.Port
+}
+
+func pow(base, exp int) int {
+	r := 1
+	for i := 0; i < exp; i++ {
+		r *= base
+	}
+
+	return r
+}
+
+// digit returns the i-th base-k digit of x.
+func (t *fatTree) digit(x, i int) int {
+	return x / pow(t.k, i) % t.k
+}
+
+// withDigit returns x with the i-th base-k digit replaced by v.
+func (t *fatTree) withDigit(x, i, v int) int {
+	return x + (v-t.digit(x, i))*pow(t.k, i)
+}
+
+func (t *fatTree) switchID(level, w int) int {
+	return level*t.numSwitchesPerLevel + w
+}
+
+// connect links the switch w at level l with the switches at level l+1 that
+// only differ from w in the l-th digit.
+func (t *fatTree) connect(n *network, link LinkParameter) {
+	t.up = make([][][]sim.Port, t.n)
+	t.down = make([][][]sim.Port, t.n)
+
+	for l := 0; l < t.n; l++ {
+		t.up[l] = make([][]sim.Port, t.numSwitchesPerLevel)
+		t.down[l] = make([][]sim.Port, t.numSwitchesPerLevel)
+
+		for w := range t.up[l] {
+			t.up[l][w] = make([]sim.Port, t.k)
+			t.down[l][w] = make([]sim.Port, t.k)
+		}
+	}
+
+	for l := 0; l < t.n-1; l++ {
+		for w := 0; w < t.numSwitchesPerLevel; w++ {
+			for j := 0; j < t.k; j++ {
+				parent := t.withDigit(w, l, j)
+				upPort, downPort := n.connect(
+					t.switchID(l, w), t.switchID(l+1, parent), link, false)
+				t.up[l][w][j] = upPort
+				t.down[l+1][parent][t.digit(w, l)] = downPort
+			}
+		}
+	}
+}
+
+// isAncestor returns true if the switch w at level l can reach the device d by
+// going down.
+func (t *fatTree) isAncestor(l, w, d int) bool {
+	for i := l; i < t.n-1; i++ {
+		if t.digit(w, i) != t.digit(d, i+1) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (t *fatTree) nextHop(l, w, d int) sim.Port {
+	if t.isAncestor(l, w, d) {
+		return t.down[l][w][t.digit(d, l)]
+	}
+
+	return t.up[l][w][t.digit(d, l)]
+}
@@ -0,0 +1,67 @@
+package topology
+
+import (
+	"fmt"
+
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+// BuildRing connects each device to a switch and connects the switches in a
+// unidirectional ring. The flits only travel from switch i to switch i+1.
+func (b Builder) BuildRing(name string, devices [][]sim.Port) Network {
+	return b.buildRing(name, devices, false)
+}
+
+// BuildBidirectionalRing connects each device to a switch and connects the
+// switches in a bidirectional ring. The flits take the shorter way around the
+// ring.
+func (b Builder) BuildBidirectionalRing(
+	name string,
+	devices [][]sim.Port,
+) Network {
+	return b.buildRing(name, devices, true)
+}
+
+func (b Builder) buildRing(
+	name string,
+	devices [][]sim.Port,
+	isBidirectional bool,
+) Network {
+	n := b.newNetwork(name)
+	numNodes := len(devices)
+
+	for i, ports := range devices {
+		sw := n.addSwitch(fmt.Sprintf("Switch[%d]", i))
+		n.plugInDevice(sw, ports)
+	}
+
+	// cw[i] is the port of switch i to switch i+1, and ccw[i] is the port of
+	// switch i to switch i-1.
+	cw := make([]sim.Port, numNodes)
+	ccw := make([]sim.Port, numNodes)
+
+	switch {
+	case numNodes == 2:
+		cw[0], cw[1] = n.connect(0, 1, b.defaultLink(), false)
+		ccw[0], ccw[1] = cw[0], cw[1]
+	case numNodes > 2:
+		for i := 0; i < numNodes; i++ {
+			next := (i + 1) % numNodes
+			cw[i], ccw[next] = n.connect(i, next, b.defaultLink(), true)
+		}
+
+		n.tables[numNodes-1].datelines[cw[numNodes-1]] = true
+		n.tables[0].datelines[ccw[0]] = true
+	}
+
+	n.defineRoutes(func(sw, dstSw int) sim.Port {
+		forward := (dstSw - sw + numNodes) % numNodes
+		if isBidirectional && forward > numNodes-forward {
+			return ccw[sw]
+		}
+
+		return cw[sw]
+	})
+
+	return n.built()
+}
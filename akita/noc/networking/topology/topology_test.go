@@ -0,0 +1,99 @@
+package topology_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/akita/v3/noc/acceptance"
+	"github.com/sarchlab/akita/v3/noc/networking/topology"
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+var _ = Describe("Builder", func() {
+	var (
+		engine  sim.Engine
+		builder topology.Builder
+		test    *acceptance.Test
+	)
+
+	BeforeEach(func() {
+		engine = sim.NewSerialEngine()
+		builder = topology.MakeBuilder().WithEngine(engine)
+		test = acceptance.NewTest()
+	})
+
+	createDevices := func(n int) [][]sim.Port {
+		var devices [][]sim.Port
+
+		for i := 0; i < n; i++ {
+			name := fmt.Sprintf("Agent[%d]", i)
+			agent := acceptance.NewAgent(engine, 1*sim.GHz, name, 2, test)
+			agent.TickLater(0)
+			test.RegisterAgent(agent)
+
+			devices = append(devices, agent.AgentPorts)
+		}
+
+		return devices
+	}
+
+	deliverAllMsgs := func() {
+		test.GenerateMsgs(1000)
+
+		Expect(engine.Run()).To(Succeed())
+		test.MustHaveReceivedAllMsgs()
+	}
+
+	It("should deliver all the messages in a ring", func() {
+		builder.BuildRing("Ring", createDevices(6))
+		deliverAllMsgs()
+	})
+
+	It("should deliver all the messages in a bidirectional ring", func() {
+		builder.BuildBidirectionalRing("Ring", createDevices(7))
+		deliverAllMsgs()
+	})
+
+	It("should deliver all the messages in a fat-tree", func() {
+		builder.BuildFatTree("FatTree", 2, 3, createDevices(8))
+		deliverAllMsgs()
+	})
+
+	It("should deliver all the messages in a dragonfly", func() {
+		builder.BuildDragonfly("Dragonfly",
+			topology.DragonflyParameter{
+				NumGroups:           5,
+				NumRoutersPerGroup:  2,
+				NumDevicesPerRouter: 1,
+				GlobalLink: &topology.LinkParameter{
+					TransferPerCycle: 0.5,
+					Latency:          10,
+				},
+			},
+			createDevices(10))
+		deliverAllMsgs()
+	})
+
+	It("should deliver all the messages in a chiplet mesh", func() {
+		builder.BuildChiplet("Chiplet",
+			topology.ChipletParameter{
+				NumChiplets: [2]int{2, 2},
+				ChipletSize: [2]int{2, 2},
+				InterChipletLink: topology.LinkParameter{
+					TransferPerCycle: 0.5,
+					Latency:          20,
+				},
+			},
+			createDevices(16))
+		deliverAllMsgs()
+	})
+
+	It("should not accept more devices than the fat-tree can connect", func() {
+		devices := createDevices(5)
+
+		Expect(func() {
+			builder.BuildFatTree("FatTree", 2, 2, devices)
+		}).To(Panic())
+	})
+})
@@ -11,6 +11,11 @@ import (
 // It has a byte size, but we do not care about the information it carries.
 type TrafficMsg struct {
 	sim.MsgMeta
+
+	// GenerateTime is the time that the message is created by the traffic
+	// injector. It is earlier than the send time if the message waits in the
+	// buffer of the agent.
+	GenerateTime sim.VTimeInSec
 }
 
 // Meta returns the meta data of the message.
@@ -43,6 +48,7 @@ func NewStartSendEvent(
 	e := new(StartSendEvent)
 	e.EventBase = sim.NewEventBase(time, src)
 	e.Msg = NewTrafficMsg(src.ToOut, dst.ToOut, byteSize)
+	e.Msg.GenerateTime = time
 	e.Msg.Meta().TrafficClass = trafficClass
 	return e
 }
@@ -1,3 +1,8 @@
 // Package standalone provides infrastructures to write network simulations
 // without simulating compute cores and memory systems.
+//
+// The SyntheticTrafficInjector generates open-loop traffic with the classic
+// synthetic traffic patterns and with Bernoulli or bursty injection. The
+// LatencyRecorder measures the latency and the accepted throughput, and the
+// sweep command uses both to plot the load-latency curve of a network.
 package standalone
@@ -0,0 +1,73 @@
+package standalone
+
+import "math/rand"
+
+// An InjectionProcess decides in which cycles a node generates packets.
+type InjectionProcess interface {
+	// Inject returns true if a packet is generated in the next cycle.
+	Inject(rng *rand.Rand) bool
+}
+
+type bernoulliProcess struct {
+	rate float64
+}
+
+// NewBernoulliProcess creates an injection process that generates a packet in
+// each cycle with the probability of the rate.
+func NewBernoulliProcess(rate float64) InjectionProcess {
+	return &bernoulliProcess{rate: rate}
+}
+
+func (p *bernoulliProcess) Inject(rng *rand.Rand) bool {
+	return rng.Float64() < p.rate
+}
+
+type burstyProcess struct {
+	isOn      bool
+	onToOff   float64
+	offToOn   float64
+	isStarted bool
+}
+
+// NewBurstyProcess creates an on-off injection process. The node generates a
+// packet in every cycle when it is on. The average length of the on period is
+// burstLength cycles, and the off periods are long enough to keep the average
+// injection rate at the given rate.
+func NewBurstyProcess(rate, burstLength float64) InjectionProcess {
+	if burstLength < 1 {
+		panic("burst length must be at least 1")
+	}
+
+	p := &burstyProcess{onToOff: 1 / burstLength}
+
+	switch {
+	case rate >= 1:
+		p.onToOff = 0
+		p.offToOn = 1
+	case rate > 0:
+		p.offToOn = rate * p.onToOff / (1 - rate)
+	}
+
+	return p
+}
+
+func (p *burstyProcess) Inject(rng *rand.Rand) bool {
+	if !p.isStarted {
+		p.isStarted = true
+		p.isOn = rng.Float64() < p.onRatio()
+	} else if p.isOn {
+		p.isOn = rng.Float64() >= p.onToOff
+	} else {
+		p.isOn = rng.Float64() < p.offToOn
+	}
+
+	return p.isOn
+}
+
+func (p *burstyProcess) onRatio() float64 {
+	if p.offToOn == 0 {
+		return 0
+	}
+
+	return p.offToOn / (p.offToOn + p.onToOff)
+}
@@ -0,0 +1,155 @@
+package standalone
+
+import "math/rand"
+
+// A TrafficPattern decides the destination of each packet in a synthetic
+// traffic.
+//
+// The nodes are indexed from 0 to numNodes-1. The patterns that are defined
+// on a 2D grid place node i at column i%width and row i/width.
+type TrafficPattern interface {
+	// Dst returns the index of the destination node of a packet generated by
+	// the src node. It returns -1 if the node does not send under the pattern.
+	Dst(src, numNodes int, rng *rand.Rand) int
+}
+
+// gridSize returns the width and the height of the grid. A width of 0 places
+// all the nodes in a single row.
+func gridSize(width, numNodes int) (w, h int) {
+	if width <= 0 {
+		return numNodes, 1
+	}
+
+	if numNodes%width != 0 {
+		panic("the number of nodes is not a multiple of the grid width")
+	}
+
+	return width, numNodes / width
+}
+
+func notSelf(src, dst int) int {
+	if src == dst {
+		return -1
+	}
+
+	return dst
+}
+
+type uniformRandomPattern struct{}
+
+// NewUniformRandomPattern creates a pattern that sends each packet to a
+// node other than the source, selected uniformly at random.
+func NewUniformRandomPattern() TrafficPattern {
+	return uniformRandomPattern{}
+}
+
+func (p uniformRandomPattern) Dst(src, numNodes int, rng *rand.Rand) int {
+	if numNodes < 2 {
+		return -1
+	}
+
+	dst := rng.Intn(numNodes - 1)
+	if dst >= src {
+		dst++
+	}
+
+	return dst
+}
+
+type transposePattern struct {
+	width int
+}
+
+// NewTransposePattern creates a pattern that sends the packets of the node
+// at (x, y) to the node at (y, x). The grid must be square. The nodes on the
+// diagonal do not send.
+func NewTransposePattern(width int) TrafficPattern {
+	return transposePattern{width: width}
+}
+
+func (p transposePattern) Dst(src, numNodes int, _ *rand.Rand) int {
+	w, h := gridSize(p.width, numNodes)
+	if w != h {
+		panic("transpose traffic requires a square grid")
+	}
+
+	x, y := src%w, src/w
+
+	return notSelf(src, x*w+y)
+}
+
+type bitComplementPattern struct{}
+
+// NewBitComplementPattern creates a pattern that sends the packets of node i
+// to node numNodes-1-i. If the number of nodes is a power of 2, the
+// destination index is the bitwise complement of the source index.
+func NewBitComplementPattern() TrafficPattern {
+	return bitComplementPattern{}
+}
+
+func (p bitComplementPattern) Dst(src, numNodes int, _ *rand.Rand) int {
+	return notSelf(src, numNodes-1-src)
+}
+
+type hotspotPattern struct {
+	hotspots []int
+	fraction float64
+}
+
+// NewHotspotPattern creates a pattern that sends a fraction of the packets to
+// the hotspot nodes. The rest of the packets are sent uniformly at random.
+func NewHotspotPattern(hotspots []int, fraction float64) TrafficPattern {
+	if len(hotspots) == 0 {
+		panic("no hotspot is given")
+	}
+
+	return hotspotPattern{hotspots: hotspots, fraction: fraction}
+}
+
+func (p hotspotPattern) Dst(src, numNodes int, rng *rand.Rand) int {
+	if rng.Float64() < p.fraction {
+		dst := p.hotspots[rng.Intn(len(p.hotspots))]
+		if dst != src {
+			return dst
+		}
+	}
+
+	return uniformRandomPattern{}.Dst(src, numNodes, rng)
+}
+
+type tornadoPattern struct {
+	width int
+}
+
+// NewTornadoPattern creates a pattern that sends the packets almost half-way
+// around each dimension. The coordinate of the destination in a dimension of
+// k nodes is (s + ceil(k/2) - 1) mod k.
+func NewTornadoPattern(width int) TrafficPattern {
+	return tornadoPattern{width: width}
+}
+
+func (p tornadoPattern) Dst(src, numNodes int, _ *rand.Rand) int {
+	w, h := gridSize(p.width, numNodes)
+	x := (src%w + (w+1)/2 - 1) % w
+	y := (src/w + (h+1)/2 - 1) % h
+
+	return notSelf(src, y*w+x)
+}
+
+type nearestNeighborPattern struct {
+	width int
+}
+
+// NewNearestNeighborPattern creates a pattern that sends the packets to the
+// next node in each dimension, wrapping around at the edge of the grid.
+func NewNearestNeighborPattern(width int) TrafficPattern {
+	return nearestNeighborPattern{width: width}
+}
+
+func (p nearestNeighborPattern) Dst(src, numNodes int, _ *rand.Rand) int {
+	w, h := gridSize(p.width, numNodes)
+	x := (src%w + 1) % w
+	y := (src/w + 1) % h
+
+	return notSelf(src, y*w+x)
+}
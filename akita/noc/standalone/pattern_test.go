@@ -0,0 +1,110 @@
+package standalone
+
+import (
+	"math/rand"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Traffic Patterns", func() {
+	var rng *rand.Rand
+
+	BeforeEach(func() {
+		rng = rand.New(rand.NewSource(1))
+	})
+
+	It("should not send uniform random traffic to the source", func() {
+		p := NewUniformRandomPattern()
+		counts := make([]int, 4)
+
+		for i := 0; i < 3000; i++ {
+			counts[p.Dst(2, 4, rng)]++
+		}
+
+		Expect(counts[2]).To(Equal(0))
+		Expect(counts[0]).To(BeNumerically("~", 1000, 100))
+	})
+
+	It("should transpose", func() {
+		p := NewTransposePattern(4)
+
+		Expect(p.Dst(1, 16, rng)).To(Equal(4))
+		Expect(p.Dst(14, 16, rng)).To(Equal(11))
+		Expect(p.Dst(5, 16, rng)).To(Equal(-1))
+	})
+
+	It("should send to the bit complement", func() {
+		p := NewBitComplementPattern()
+
+		Expect(p.Dst(0b0101, 16, rng)).To(Equal(0b1010))
+	})
+
+	It("should send a fraction of the traffic to the hotspots", func() {
+		p := NewHotspotPattern([]int{0}, 0.5)
+		count := 0
+
+		for i := 0; i < 1000; i++ {
+			if p.Dst(3, 11, rng) == 0 {
+				count++
+			}
+		}
+
+		Expect(count).To(BeNumerically("~", 550, 50))
+	})
+
+	It("should send tornado traffic", func() {
+		p := NewTornadoPattern(8)
+
+		Expect(p.Dst(0, 64, rng)).To(Equal(3*8 + 3))
+		Expect(p.Dst(7*8+6, 64, rng)).To(Equal(2*8 + 1))
+	})
+
+	It("should send to the nearest neighbor", func() {
+		p := NewNearestNeighborPattern(0)
+
+		Expect(p.Dst(2, 4, rng)).To(Equal(3))
+		Expect(p.Dst(3, 4, rng)).To(Equal(0))
+	})
+})
+
+var _ = Describe("Injection Processes", func() {
+	var rng *rand.Rand
+
+	BeforeEach(func() {
+		rng = rand.New(rand.NewSource(1))
+	})
+
+	count := func(p InjectionProcess, numCycles int) (packets, bursts int) {
+		wasOn := false
+
+		for i := 0; i < numCycles; i++ {
+			on := p.Inject(rng)
+			if on {
+				packets++
+			}
+
+			if on && !wasOn {
+				bursts++
+			}
+
+			wasOn = on
+		}
+
+		return packets, bursts
+	}
+
+	It("should inject with the Bernoulli process", func() {
+		packets, _ := count(NewBernoulliProcess(0.2), 100000)
+
+		Expect(packets).To(BeNumerically("~", 20000, 500))
+	})
+
+	It("should inject in bursts", func() {
+		packets, bursts := count(NewBurstyProcess(0.2, 10), 100000)
+
+		Expect(packets).To(BeNumerically("~", 20000, 2000))
+		Expect(float64(packets) / float64(bursts)).
+			To(BeNumerically("~", 10, 1))
+	})
+})
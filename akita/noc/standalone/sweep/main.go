@@ -0,0 +1,346 @@
+// Package main provides a tool that measures the load-latency curve of a
+// network with synthetic traffic. For each injection rate, the tool builds a
+// new network, injects open-loop traffic, and reports the average latency and
+// the accepted throughput in CSV format.
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/sarchlab/akita/v3/noc/networking/mesh"
+	"github.com/sarchlab/akita/v3/noc/networking/nvlink"
+	"github.com/sarchlab/akita/v3/noc/networking/pcie"
+	"github.com/sarchlab/akita/v3/noc/networking/topology"
+	"github.com/sarchlab/akita/v3/noc/standalone"
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+var networkFlag = flag.String("network", "mesh",
+	"The network to measure, one of mesh, torus, pcie, nvlink, ring, "+
+		"biring, fattree, dragonfly, and chiplet.")
+var widthFlag = flag.Int("width", 4,
+	"The width and the height of the mesh, the torus, or the chiplet "+
+		"mesh. It is also the number of nodes of the rings.")
+var patternFlag = flag.String("pattern", "uniform",
+	"The traffic pattern, one of uniform, transpose, bitcomplement, "+
+		"hotspot, tornado, and neighbor.")
+var hotspotFractionFlag = flag.Float64("hotspot-fraction", 0.2,
+	"The fraction of the packets sent to node 0 with the hotspot pattern.")
+var injectionFlag = flag.String("injection", "bernoulli",
+	"The injection process, either bernoulli or bursty.")
+var burstLengthFlag = flag.Float64("burst-length", 8,
+	"The average number of cycles of a burst with bursty injection.")
+var ratesFlag = flag.String("rates", "0.01,0.02,0.05,0.1,0.2,0.3,0.4,0.5",
+	"The comma-separated injection rates, in packets per node per cycle.")
+var packetSizeFlag = flag.Int("packet-size", 64,
+	"The size of each packet in bytes.")
+var cyclesFlag = flag.Int("cycles", 10000,
+	"The number of cycles to inject traffic.")
+var warmupFlag = flag.Int("warmup", 2000,
+	"The number of cycles excluded from the measurement at the beginning.")
+var seedFlag = flag.Int64("seed", 1, "The seed of the random numbers.")
+var outputFlag = flag.String("output", "",
+	"The CSV file to write. The results are printed if not set.")
+
+const freq = 1 * sim.GHz
+
+func main() {
+	flag.Parse()
+
+	var out io.Writer = os.Stdout
+	if *outputFlag != "" {
+		f, err := os.Create(*outputFlag)
+		if err != nil {
+			log.Panic(err)
+		}
+		defer f.Close()
+
+		out = f
+	}
+
+	w := csv.NewWriter(out)
+	mustWrite(w, []string{
+		"injection_rate", "accepted_throughput",
+		"avg_latency_cycles", "max_latency_cycles",
+		"num_packets", "bandwidth_bytes_per_second",
+	})
+
+	for _, rate := range parseRates(*ratesFlag) {
+		mustWrite(w, measure(rate))
+		w.Flush()
+	}
+}
+
+func mustWrite(w *csv.Writer, record []string) {
+	err := w.Write(record)
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func parseRates(s string) []float64 {
+	var rates []float64
+
+	for _, field := range strings.Split(s, ",") {
+		rate, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+		if err != nil {
+			log.Panic(err)
+		}
+
+		rates = append(rates, rate)
+	}
+
+	return rates
+}
+
+func measure(rate float64) []string {
+	engine := sim.NewSerialEngine()
+	recorder := standalone.NewLatencyRecorder()
+
+	agents, gridWidth := createNetwork(engine)
+	injector := standalone.NewSyntheticTrafficInjector(engine, rate)
+	injector.Freq = freq
+	injector.PacketSize = *packetSizeFlag
+	injector.NumCycles = *cyclesFlag
+	injector.Seed = *seedFlag
+	injector.Pattern = createPattern(gridWidth)
+	injector.NewProcess = func() standalone.InjectionProcess {
+		return createProcess(rate)
+	}
+
+	for _, a := range agents {
+		a.ToOut.AcceptHook(recorder)
+		injector.RegisterAgent(a)
+	}
+
+	injector.InjectTraffic()
+
+	err := engine.Run()
+	if err != nil {
+		log.Panic(err)
+	}
+
+	start := freq.NCyclesLater(*warmupFlag, 0)
+	end := freq.NCyclesLater(*cyclesFlag, 0)
+	stats := recorder.Stats(start, end)
+
+	numCycles := float64(*cyclesFlag - *warmupFlag)
+	throughput := float64(stats.NumPacketsRecvd) /
+		float64(len(agents)) / numCycles
+	bandwidth := float64(stats.NumBytesRecvd) / float64(end-start)
+
+	return []string{
+		fmt.Sprintf("%g", rate),
+		fmt.Sprintf("%.4f", throughput),
+		fmt.Sprintf("%.2f", float64(stats.AvgLatency)*float64(freq)),
+		fmt.Sprintf("%.0f", float64(stats.MaxLatency)*float64(freq)),
+		fmt.Sprintf("%d", stats.NumPackets),
+		fmt.Sprintf("%.4g", bandwidth),
+	}
+}
+
+func createPattern(gridWidth int) standalone.TrafficPattern {
+	switch *patternFlag {
+	case "uniform":
+		return standalone.NewUniformRandomPattern()
+	case "transpose":
+		return standalone.NewTransposePattern(gridWidth)
+	case "bitcomplement":
+		return standalone.NewBitComplementPattern()
+	case "hotspot":
+		return standalone.NewHotspotPattern([]int{0}, *hotspotFractionFlag)
+	case "tornado":
+		return standalone.NewTornadoPattern(gridWidth)
+	case "neighbor":
+		return standalone.NewNearestNeighborPattern(gridWidth)
+	default:
+		panic(fmt.Sprintf("unknown pattern %s", *patternFlag))
+	}
+}
+
+func createProcess(rate float64) standalone.InjectionProcess {
+	switch *injectionFlag {
+	case "bernoulli":
+		return standalone.NewBernoulliProcess(rate)
+	case "bursty":
+		return standalone.NewBurstyProcess(rate, *burstLengthFlag)
+	default:
+		panic(fmt.Sprintf("unknown injection process %s", *injectionFlag))
+	}
+}
+
+func createAgents(engine sim.Engine, n int) []*standalone.Agent {
+	agents := make([]*standalone.Agent, 0, n)
+	for i := 0; i < n; i++ {
+		agents = append(agents,
+			standalone.NewAgent(fmt.Sprintf("Agent%d", i), engine))
+	}
+
+	return agents
+}
+
+// createNetwork creates the agents and connects them with the selected
+// network. It also returns the width of the grid that the agents are placed
+// in, which is 0 if the agents are not on a grid.
+func createNetwork(engine sim.Engine) ([]*standalone.Agent, int) {
+	switch *networkFlag {
+	case "mesh", "torus":
+		return createMesh(engine), *widthFlag
+	case "pcie":
+		return createPCIe(engine), 0
+	case "nvlink":
+		return createNVLink(engine), 0
+	case "ring", "biring", "fattree", "dragonfly", "chiplet":
+		return createTopology(engine)
+	default:
+		panic(fmt.Sprintf("unknown network %s", *networkFlag))
+	}
+}
+
+func createMesh(engine sim.Engine) []*standalone.Agent {
+	width := *widthFlag
+	agents := createAgents(engine, width*width)
+
+	connector := mesh.NewConnector().
+		WithEngine(engine).
+		WithFreq(freq)
+	if *networkFlag == "torus" {
+		connector = connector.WithTorus()
+	}
+
+	connector.CreateNetwork("Mesh")
+
+	for i, a := range agents {
+		connector.AddTile([3]int{i % width, i / width, 0},
+			[]sim.Port{a.ToOut})
+	}
+
+	connector.EstablishNetwork()
+
+	return agents
+}
+
+func createPCIe(engine sim.Engine) []*standalone.Agent {
+	numDevicePerSwitch := 8
+	agents := createAgents(engine, 2*numDevicePerSwitch+1)
+
+	connector := pcie.NewConnector().
+		WithEngine(engine).
+		WithFrequency(freq).
+		WithVersion(4, 16)
+	connector.CreateNetwork("PCIe")
+
+	rootComplexID := connector.AddRootComplex([]sim.Port{agents[0].ToOut})
+	for s := 0; s < 2; s++ {
+		switchID := connector.AddSwitch(rootComplexID)
+		for i := 0; i < numDevicePerSwitch; i++ {
+			a := agents[1+s*numDevicePerSwitch+i]
+			connector.PlugInDevice(switchID, []sim.Port{a.ToOut})
+		}
+	}
+
+	connector.EstablishRoute()
+
+	return agents
+}
+
+// createNVLink creates a DGX-1-like network, with a CPU and 8 GPUs connected
+// by PCIe and a hybrid cube-mesh of NVLinks.
+func createNVLink(engine sim.Engine) []*standalone.Agent {
+	agents := createAgents(engine, 9)
+
+	connector := nvlink.NewConnector().
+		WithEngine(engine).
+		WithFrequency(freq).
+		WithPCIeVersion(3, 16)
+	connector.CreateNetwork("NVLink")
+
+	rootComplexID := connector.AddRootComplex([]sim.Port{agents[0].ToOut})
+	deviceIDs := []int{0}
+
+	for s := 0; s < 2; s++ {
+		switchID := connector.AddPCIeSwitch()
+		connector.ConnectSwitchesWithPCIeLink(rootComplexID, switchID)
+
+		for i := 0; i < 4; i++ {
+			a := agents[1+s*4+i]
+			deviceIDs = append(deviceIDs,
+				connector.PlugInDevice(switchID, []sim.Port{a.ToOut}))
+		}
+	}
+
+	links := [][3]int{
+		{1, 2, 2}, {2, 3, 1}, {3, 4, 2}, {1, 4, 2}, {1, 3, 1}, {2, 4, 1},
+		{5, 6, 2}, {6, 7, 2}, {7, 8, 2}, {6, 8, 1}, {5, 7, 1},
+		{1, 6, 1}, {2, 5, 2}, {4, 8, 1}, {3, 7, 2},
+	}
+	for _, l := range links {
+		connector.ConnectDevicesWithNVLink(deviceIDs[l[0]], deviceIDs[l[1]], l[2])
+	}
+
+	connector.EstablishRoute()
+
+	return agents
+}
+
+func createTopology(engine sim.Engine) ([]*standalone.Agent, int) {
+	builder := topology.MakeBuilder().
+		WithEngine(engine).
+		WithFreq(freq)
+
+	switch *networkFlag {
+	case "ring":
+		agents := createAgents(engine, *widthFlag)
+		builder.BuildRing("Ring", devicePorts(agents))
+
+		return agents, 0
+	case "biring":
+		agents := createAgents(engine, *widthFlag)
+		builder.BuildBidirectionalRing("Ring", devicePorts(agents))
+
+		return agents, 0
+	case "fattree":
+		agents := createAgents(engine, 16)
+		builder.BuildFatTree("FatTree", 2, 4, devicePorts(agents))
+
+		return agents, 0
+	case "dragonfly":
+		agents := createAgents(engine, 18)
+		builder.BuildDragonfly("Dragonfly", topology.DragonflyParameter{
+			NumGroups:           9,
+			NumRoutersPerGroup:  2,
+			NumDevicesPerRouter: 1,
+		}, devicePorts(agents))
+
+		return agents, 0
+	default:
+		width := *widthFlag
+		agents := createAgents(engine, width*width)
+		builder.BuildChiplet("Chiplet", topology.ChipletParameter{
+			NumChiplets: [2]int{2, 2},
+			ChipletSize: [2]int{width / 2, width / 2},
+			InterChipletLink: topology.LinkParameter{
+				TransferPerCycle: 0.5,
+				Latency:          20,
+			},
+		}, devicePorts(agents))
+
+		return agents, width
+	}
+}
+
+func devicePorts(agents []*standalone.Agent) [][]sim.Port {
+	ports := make([][]sim.Port, 0, len(agents))
+	for _, a := range agents {
+		ports = append(ports, []sim.Port{a.ToOut})
+	}
+
+	return ports
+}
@@ -0,0 +1,167 @@
+package standalone
+
+import (
+	"math/rand"
+
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+// SyntheticTrafficInjector generates open-loop traffic. In each cycle, the
+// injection process of each agent decides if the agent generates a packet,
+// and the traffic pattern decides where the packet goes. The packets are
+// generated regardless of whether the network can accept them, so the agents
+// queue the packets that cannot be sent.
+type SyntheticTrafficInjector struct {
+	agents []*Agent
+
+	engine sim.Engine
+
+	Freq       sim.Freq
+	PacketSize int
+	NumCycles  int
+	Pattern    TrafficPattern
+
+	// NewProcess creates the injection process of each agent.
+	NewProcess func() InjectionProcess
+
+	Seed int64
+}
+
+// NewSyntheticTrafficInjector creates a new SyntheticTrafficInjector that
+// injects uniform random traffic with the given injection rate, in packets
+// per agent per cycle.
+func NewSyntheticTrafficInjector(
+	engine sim.Engine,
+	rate float64,
+) *SyntheticTrafficInjector {
+	ti := new(SyntheticTrafficInjector)
+	ti.engine = engine
+	ti.Freq = 1 * sim.GHz
+	ti.PacketSize = 64
+	ti.NumCycles = 10000
+	ti.Pattern = NewUniformRandomPattern()
+	ti.NewProcess = func() InjectionProcess {
+		return NewBernoulliProcess(rate)
+	}
+	ti.Seed = 1
+
+	return ti
+}
+
+// RegisterAgent allows the SyntheticTrafficInjector to inject traffic from the
+// agent.
+func (ti *SyntheticTrafficInjector) RegisterAgent(a *Agent) {
+	ti.agents = append(ti.agents, a)
+}
+
+// InjectTraffic schedules the packets of all the cycles.
+func (ti *SyntheticTrafficInjector) InjectTraffic() {
+	rng := rand.New(rand.NewSource(ti.Seed))
+
+	processes := make([]InjectionProcess, len(ti.agents))
+	for i := range processes {
+		processes[i] = ti.NewProcess()
+	}
+
+	for cycle := 0; cycle < ti.NumCycles; cycle++ {
+		now := ti.Freq.NCyclesLater(cycle, 0)
+
+		for i, a := range ti.agents {
+			if !processes[i].Inject(rng) {
+				continue
+			}
+
+			dstID := ti.Pattern.Dst(i, len(ti.agents), rng)
+			if dstID < 0 {
+				continue
+			}
+
+			evt := NewStartSendEvent(now, a, ti.agents[dstID], ti.PacketSize, 0)
+			ti.engine.Schedule(evt)
+		}
+	}
+}
+
+type latencySample struct {
+	generateTime sim.VTimeInSec
+	recvTime     sim.VTimeInSec
+	byteSize     int
+}
+
+// LatencyRecorder is a hook that records the latency of the traffic messages
+// that arrive at the ports that it is attached to. The latency of a message
+// counts from the time that the message is generated, so that it includes
+// the time waiting at the source.
+type LatencyRecorder struct {
+	samples []latencySample
+}
+
+// NewLatencyRecorder creates a new LatencyRecorder.
+func NewLatencyRecorder() *LatencyRecorder {
+	return &LatencyRecorder{}
+}
+
+// Func records the messages received.
+func (r *LatencyRecorder) Func(ctx sim.HookCtx) {
+	if ctx.Pos != sim.HookPosPortMsgRecvd {
+		return
+	}
+
+	msg, ok := ctx.Item.(*TrafficMsg)
+	if !ok {
+		return
+	}
+
+	r.samples = append(r.samples, latencySample{
+		generateTime: msg.GenerateTime,
+		recvTime:     msg.RecvTime,
+		byteSize:     msg.TrafficBytes,
+	})
+}
+
+// TrafficStats summarizes the traffic in a measurement window.
+type TrafficStats struct {
+	// NumPackets is the number of the packets generated in the window.
+	NumPackets int
+
+	// AvgLatency and MaxLatency are the latencies of the packets generated
+	// in the window.
+	AvgLatency sim.VTimeInSec
+	MaxLatency sim.VTimeInSec
+
+	// NumPacketsRecvd and NumBytesRecvd count the packets that arrive in the
+	// window.
+	NumPacketsRecvd int
+	NumBytesRecvd   int
+}
+
+// Stats returns the statistics of the window from start to end. Packets
+// generated before the window warm up the network and are excluded from the
+// latency.
+func (r *LatencyRecorder) Stats(start, end sim.VTimeInSec) TrafficStats {
+	stats := TrafficStats{}
+	totalLatency := sim.VTimeInSec(0)
+
+	for _, s := range r.samples {
+		if s.generateTime >= start && s.generateTime < end {
+			latency := s.recvTime - s.generateTime
+			stats.NumPackets++
+			totalLatency += latency
+
+			if latency > stats.MaxLatency {
+				stats.MaxLatency = latency
+			}
+		}
+
+		if s.recvTime >= start && s.recvTime < end {
+			stats.NumPacketsRecvd++
+			stats.NumBytesRecvd += s.byteSize
+		}
+	}
+
+	if stats.NumPackets > 0 {
+		stats.AvgLatency = totalLatency / sim.VTimeInSec(stats.NumPackets)
+	}
+
+	return stats
+}
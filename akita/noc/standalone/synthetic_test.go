@@ -0,0 +1,34 @@
+package standalone
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+var _ = Describe("SyntheticTrafficInjector", func() {
+	It("should measure the latency of the traffic", func() {
+		engine := sim.NewSerialEngine()
+		conn := sim.NewDirectConnection("Conn", engine, 1*sim.GHz)
+		recorder := NewLatencyRecorder()
+		injector := NewSyntheticTrafficInjector(engine, 0.1)
+		injector.NumCycles = 1000
+
+		for _, name := range []string{"Agent0", "Agent1"} {
+			a := NewAgent(name, engine)
+			a.ToOut.AcceptHook(recorder)
+			conn.PlugIn(a.ToOut, 4)
+			injector.RegisterAgent(a)
+		}
+
+		injector.InjectTraffic()
+		Expect(engine.Run()).To(Succeed())
+
+		stats := recorder.Stats(0, 2e-6)
+		Expect(stats.NumPackets).To(BeNumerically("~", 200, 40))
+		Expect(stats.NumPacketsRecvd).To(Equal(stats.NumPackets))
+		Expect(stats.NumBytesRecvd).To(Equal(stats.NumPackets * 64))
+		Expect(stats.AvgLatency).To(BeNumerically(">", 0))
+		Expect(stats.MaxLatency).To(BeNumerically("<", 1e-8))
+	})
+})
@@ -1,6 +1,9 @@
 package sim
 
-import "log"
+import (
+	"fmt"
+	"log"
+)
 
 // HookPosBufPush marks when an element is pushed into the buffer.
 var HookPosBufPush = &HookPos{Name: "Buffer Push"}
@@ -100,6 +103,13 @@ func (b *bufferImpl) Capacity() int {
 	return b.capacity
 }
 
+// SetCapacity changes the capacity of the buffer. If the new capacity is
+// smaller than the number of elements in the buffer, no element can be pushed
+// until the buffer is drained below the capacity.
+func (b *bufferImpl) SetCapacity(capacity int) {
+	b.capacity = capacity
+}
+
 func (b *bufferImpl) Size() int {
 	return len(b.elements)
 }
@@ -107,3 +117,36 @@ func (b *bufferImpl) Size() int {
 func (b *bufferImpl) Clear() {
 	b.elements = nil
 }
+
+// SaveState saves the messages in the buffer. Buffers that hold elements
+// other than messages cannot be checkpointed.
+func (b *bufferImpl) SaveState(cp *Checkpoint) error {
+	refs := make([]string, 0, len(b.elements))
+	for _, e := range b.elements {
+		msg, ok := e.(Msg)
+		if !ok {
+			return fmt.Errorf("buffer %s holds a %T, which is not a message",
+				b.name, e)
+		}
+
+		refs = append(refs, cp.MsgRef(msg))
+	}
+
+	return cp.Put(b.name, refs)
+}
+
+// LoadState restores the messages in the buffer.
+func (b *bufferImpl) LoadState(cp *Checkpoint) error {
+	refs := []string{}
+	err := cp.Get(b.name, &refs)
+	if err != nil {
+		return err
+	}
+
+	b.elements = nil
+	for _, ref := range refs {
+		b.elements = append(b.elements, cp.Msg(ref))
+	}
+
+	return nil
+}
@@ -0,0 +1,639 @@
+package sim
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"sync/atomic"
+)
+
+// Checkpointable is implemented by the elements of a simulation that can save
+// their states to a checkpoint and restore their states from a checkpoint.
+// Components, buffers, ports, and connections opt in to checkpointing by
+// implementing this interface.
+type Checkpointable interface {
+	Named
+
+	// SaveState writes the state of the element into the checkpoint.
+	SaveState(cp *Checkpoint) error
+
+	// LoadState restores the state of the element from the checkpoint.
+	LoadState(cp *Checkpoint) error
+}
+
+// A Checkpoint is the state of a simulation at a point of time.
+//
+// The elements store their states in the checkpoint with Put and read their
+// states back with Get. The states are encoded with encoding/gob. Messages
+// and ports cannot be encoded directly, as they are shared by multiple
+// elements. Instead, the elements store the references returned by MsgRef
+// and the port names. A message is saved only once, so that all the elements
+// that refer to the same message get the same message after restoring.
+type Checkpoint struct {
+	data checkpointData
+
+	msgs     map[string]Msg
+	ports    map[string]Port
+	handlers map[string]Handler
+	states   map[string][]byte
+}
+
+type checkpointData struct {
+	Time   VTimeInSec
+	IDs    idGeneratorState
+	Events []eventRecord
+	Msgs   []msgRecord
+	States []stateRecord
+}
+
+type idGeneratorState struct {
+	Deterministic bool
+	NextID        uint64
+	Streams       []idStreamState
+}
+
+type idStreamState struct {
+	Name   string
+	NextID uint64
+}
+
+type stateRecord struct {
+	Key  string
+	Data []byte
+}
+
+type msgRecord struct {
+	ID   string
+	Src  string
+	Dst  string
+	Body []byte
+}
+
+type msgBody struct {
+	Msg Msg
+}
+
+type eventRecord struct {
+	ID        string
+	Time      VTimeInSec
+	Handler   string
+	Secondary bool
+	Queue     int
+	IsTick    bool
+	Body      []byte
+}
+
+type eventBody struct {
+	Event Event
+}
+
+// eventBaseOwner is implemented by the events that embed an EventBase, so
+// that the unexported fields of the EventBase can be restored.
+type eventBaseOwner interface {
+	eventBase() *EventBase
+}
+
+func (e *EventBase) eventBase() *EventBase {
+	return e
+}
+
+func newCheckpoint() *Checkpoint {
+	return &Checkpoint{
+		msgs:     make(map[string]Msg),
+		ports:    make(map[string]Port),
+		handlers: make(map[string]Handler),
+		states:   make(map[string][]byte),
+	}
+}
+
+// Time returns the time at which the checkpoint is taken.
+func (cp *Checkpoint) Time() VTimeInSec {
+	return cp.data.Time
+}
+
+// Put stores a state under the given key. The key is usually the name of the
+// element.
+func (cp *Checkpoint) Put(key string, state interface{}) error {
+	if _, found := cp.states[key]; found {
+		return fmt.Errorf("state %s is saved twice", key)
+	}
+
+	buf := bytes.NewBuffer(nil)
+	err := gob.NewEncoder(buf).Encode(state)
+	if err != nil {
+		return fmt.Errorf("cannot encode state %s: %w", key, err)
+	}
+
+	cp.states[key] = buf.Bytes()
+	cp.data.States = append(cp.data.States, stateRecord{
+		Key:  key,
+		Data: buf.Bytes(),
+	})
+
+	return nil
+}
+
+// Get reads the state stored under the given key.
+func (cp *Checkpoint) Get(key string, state interface{}) error {
+	data, found := cp.states[key]
+	if !found {
+		return fmt.Errorf("state %s is not in the checkpoint", key)
+	}
+
+	err := gob.NewDecoder(bytes.NewReader(data)).Decode(state)
+	if err != nil {
+		return fmt.Errorf("cannot decode state %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// MsgRef adds a message to the checkpoint and returns the reference to the
+// message. Messages are identified by their IDs, so the messages that are
+// saved must have unique IDs. The reference of a nil message is an empty
+// string.
+func (cp *Checkpoint) MsgRef(msg Msg) string {
+	if msg == nil {
+		return ""
+	}
+
+	id := msg.Meta().ID
+	cp.msgs[id] = msg
+
+	return id
+}
+
+// Msg returns the message of a reference returned by MsgRef.
+func (cp *Checkpoint) Msg(ref string) Msg {
+	if ref == "" {
+		return nil
+	}
+
+	msg, found := cp.msgs[ref]
+	if !found {
+		panic(fmt.Sprintf("message %s is not in the checkpoint", ref))
+	}
+
+	return msg
+}
+
+// Port returns the port with the given name. It returns nil if the name is
+// empty.
+func (cp *Checkpoint) Port(name string) Port {
+	if name == "" {
+		return nil
+	}
+
+	port, found := cp.ports[name]
+	if !found {
+		panic(fmt.Sprintf("port %s is not registered", name))
+	}
+
+	return port
+}
+
+func portName(port Port) string {
+	if port == nil {
+		return ""
+	}
+
+	return port.Name()
+}
+
+func (cp *Checkpoint) encodeMsgs() error {
+	for id, msg := range cp.msgs {
+		rec, err := cp.encodeMsg(id, msg)
+		if err != nil {
+			return err
+		}
+
+		cp.data.Msgs = append(cp.data.Msgs, rec)
+	}
+
+	return nil
+}
+
+func (cp *Checkpoint) encodeMsg(id string, msg Msg) (msgRecord, error) {
+	rec := msgRecord{
+		ID:  id,
+		Src: portName(msg.Meta().Src),
+		Dst: portName(msg.Meta().Dst),
+	}
+
+	// The ports are saved as names. The message is encoded as a shallow copy
+	// without the ports.
+	msgCopy := reflect.New(reflect.TypeOf(msg).Elem())
+	msgCopy.Elem().Set(reflect.ValueOf(msg).Elem())
+	copied := msgCopy.Interface().(Msg)
+	copied.Meta().Src = nil
+	copied.Meta().Dst = nil
+
+	buf := bytes.NewBuffer(nil)
+	err := gob.NewEncoder(buf).Encode(msgBody{Msg: copied})
+	if err != nil {
+		return rec, fmt.Errorf("cannot encode message %s of type %T: %w",
+			id, msg, err)
+	}
+
+	rec.Body = buf.Bytes()
+
+	return rec, nil
+}
+
+func (cp *Checkpoint) decodeMsgs() error {
+	for _, rec := range cp.data.Msgs {
+		body := msgBody{}
+		err := gob.NewDecoder(bytes.NewReader(rec.Body)).Decode(&body)
+		if err != nil {
+			return fmt.Errorf("cannot decode message %s: %w", rec.ID, err)
+		}
+
+		body.Msg.Meta().Src = cp.Port(rec.Src)
+		body.Msg.Meta().Dst = cp.Port(rec.Dst)
+		cp.msgs[rec.ID] = body.Msg
+	}
+
+	return nil
+}
+
+func (cp *Checkpoint) encodeEvent(evt Event, queue int) (eventRecord, error) {
+	rec := eventRecord{
+		Time:      evt.Time(),
+		Secondary: evt.IsSecondary(),
+		Queue:     queue,
+	}
+
+	named, ok := evt.Handler().(Named)
+	if !ok {
+		return rec, fmt.Errorf("the handler of event %T is not named", evt)
+	}
+
+	rec.Handler = named.Name()
+	if _, registered := cp.handlers[rec.Handler]; !registered {
+		return rec, fmt.Errorf("handler %s is not registered", rec.Handler)
+	}
+
+	if tick, isTick := evt.(TickEvent); isTick {
+		rec.ID = tick.ID
+		rec.IsTick = true
+		return rec, nil
+	}
+
+	owner, ok := evt.(eventBaseOwner)
+	if !ok {
+		return rec, fmt.Errorf("event %T does not embed an EventBase", evt)
+	}
+	rec.ID = owner.eventBase().ID
+
+	buf := bytes.NewBuffer(nil)
+	err := gob.NewEncoder(buf).Encode(eventBody{Event: evt})
+	if err != nil {
+		return rec, fmt.Errorf("cannot encode event %T: %w", evt, err)
+	}
+	rec.Body = buf.Bytes()
+
+	return rec, nil
+}
+
+func (cp *Checkpoint) decodeEvent(rec eventRecord) (Event, error) {
+	handler, found := cp.handlers[rec.Handler]
+	if !found {
+		return nil, fmt.Errorf("handler %s is not registered", rec.Handler)
+	}
+
+	if rec.IsTick {
+		tick := TickEvent{}
+		tick.ID = rec.ID
+		tick.time = rec.Time
+		tick.handler = handler
+		tick.secondary = rec.Secondary
+
+		return tick, nil
+	}
+
+	body := eventBody{}
+	err := gob.NewDecoder(bytes.NewReader(rec.Body)).Decode(&body)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode event %s: %w", rec.ID, err)
+	}
+
+	base := body.Event.(eventBaseOwner).eventBase()
+	if base == nil {
+		return nil, fmt.Errorf("event %s has no EventBase", rec.ID)
+	}
+
+	base.ID = rec.ID
+	base.time = rec.Time
+	base.handler = handler
+	base.secondary = rec.Secondary
+
+	return body.Event, nil
+}
+
+// A checkpointableEngine is an engine whose event queues can be saved into a
+// checkpoint.
+type checkpointableEngine interface {
+	saveEvents(cp *Checkpoint) error
+	loadEvents(cp *Checkpoint) error
+}
+
+// A Checkpointer saves the state of a simulation to a checkpoint and restores
+// the simulation from a checkpoint.
+//
+// A checkpoint includes the event queues of the engine, the states of the
+// registered elements, and the state of the ID generator. It can only be
+// saved when the engine is paused or from an event handler, when no other
+// event is being handled. All the elements that hold state, as well as the
+// handlers of all the events in the queue, must be registered.
+//
+// A checkpoint is restored into a simulation that is built in the same way as
+// the simulation that saves the checkpoint. The restored simulation continues
+// exactly the same way as the original simulation would.
+type Checkpointer struct {
+	engine   Engine
+	elements []Checkpointable
+	ports    map[string]Port
+	handlers map[string]Handler
+}
+
+// NewCheckpointer creates a Checkpointer for the simulation that runs on the
+// engine.
+func NewCheckpointer(engine Engine) *Checkpointer {
+	return &Checkpointer{
+		engine:   engine,
+		ports:    make(map[string]Port),
+		handlers: make(map[string]Handler),
+	}
+}
+
+// Register adds elements whose states are saved in the checkpoints. The ports
+// of a registered component are also registered. The elements are saved and
+// restored in the order of registration.
+func (c *Checkpointer) Register(elements ...Checkpointable) {
+	for _, e := range elements {
+		c.elements = append(c.elements, e)
+
+		if handler, ok := e.(Handler); ok {
+			c.handlers[e.Name()] = handler
+		}
+
+		if owner, ok := e.(PortOwner); ok {
+			for _, port := range owner.Ports() {
+				c.RegisterPort(port)
+			}
+		}
+	}
+}
+
+// RegisterPort lets the messages refer to a port. If the port is
+// Checkpointable, its state is also saved.
+func (c *Checkpointer) RegisterPort(port Port) {
+	if _, found := c.ports[port.Name()]; found {
+		return
+	}
+
+	c.ports[port.Name()] = port
+
+	if checkpointable, ok := port.(Checkpointable); ok {
+		c.elements = append(c.elements, checkpointable)
+	}
+}
+
+// RegisterHandler lets the events in the queue be handled by a handler that
+// is not a registered element.
+func (c *Checkpointer) RegisterHandler(name string, handler Handler) {
+	c.handlers[name] = handler
+}
+
+// Save writes a checkpoint of the current state of the simulation.
+func (c *Checkpointer) Save(w io.Writer) error {
+	engine, ok := c.engine.(checkpointableEngine)
+	if !ok {
+		return fmt.Errorf("engine %T cannot be checkpointed", c.engine)
+	}
+
+	cp := c.newCheckpoint()
+	cp.data.Time = c.engine.CurrentTime()
+
+	for _, e := range c.elements {
+		err := e.SaveState(cp)
+		if err != nil {
+			return fmt.Errorf("cannot save %s: %w", e.Name(), err)
+		}
+	}
+
+	err := engine.saveEvents(cp)
+	if err != nil {
+		return err
+	}
+
+	err = cp.encodeMsgs()
+	if err != nil {
+		return err
+	}
+
+	cp.data.IDs, err = saveIDGenerator(GetIDGenerator())
+	if err != nil {
+		return err
+	}
+
+	return gob.NewEncoder(w).Encode(cp.data)
+}
+
+// Restore reads a checkpoint and sets the simulation to the state in the
+// checkpoint. The events that are already scheduled are discarded.
+func (c *Checkpointer) Restore(r io.Reader) error {
+	engine, ok := c.engine.(checkpointableEngine)
+	if !ok {
+		return fmt.Errorf("engine %T cannot be checkpointed", c.engine)
+	}
+
+	cp := c.newCheckpoint()
+	err := gob.NewDecoder(r).Decode(&cp.data)
+	if err != nil {
+		return fmt.Errorf("cannot decode checkpoint: %w", err)
+	}
+
+	for _, rec := range cp.data.States {
+		cp.states[rec.Key] = rec.Data
+	}
+
+	err = cp.decodeMsgs()
+	if err != nil {
+		return err
+	}
+
+	for _, e := range c.elements {
+		err = e.LoadState(cp)
+		if err != nil {
+			return fmt.Errorf("cannot restore %s: %w", e.Name(), err)
+		}
+	}
+
+	err = engine.loadEvents(cp)
+	if err != nil {
+		return err
+	}
+
+	return restoreIDGenerator(GetIDGenerator(), cp.data.IDs)
+}
+
+func (c *Checkpointer) newCheckpoint() *Checkpoint {
+	cp := newCheckpoint()
+
+	for name, port := range c.ports {
+		cp.ports[name] = port
+	}
+
+	for name, handler := range c.handlers {
+		cp.handlers[name] = handler
+	}
+
+	return cp
+}
+
+// saveIDGenerator saves the next IDs of the sequential ID generator or of all
+// the streams of the deterministic ID generator. The IDs of the parallel ID
+// generator are random and cannot be saved.
+func saveIDGenerator(g IDGenerator) (idGeneratorState, error) {
+	state := idGeneratorState{}
+
+	switch g := g.(type) {
+	case *sequentialIDGenerator:
+		state.NextID = atomic.LoadUint64(&g.nextID)
+	case *deterministicIDGenerator:
+		state.Deterministic = true
+		state.NextID = atomic.LoadUint64(&g.shared.nextID)
+		g.streams.Range(func(name, s interface{}) bool {
+			state.Streams = append(state.Streams, idStreamState{
+				Name:   name.(string),
+				NextID: atomic.LoadUint64(&s.(*idStream).nextID),
+			})
+			return true
+		})
+		sort.Slice(state.Streams, func(i, j int) bool {
+			return state.Streams[i].Name < state.Streams[j].Name
+		})
+	default:
+		return state, fmt.Errorf("ID generator %T cannot be checkpointed", g)
+	}
+
+	return state, nil
+}
+
+// restoreIDGenerator restores the next IDs into an ID generator of the same
+// type as the one that is saved. The streams of the deterministic ID generator
+// that are not in the checkpoint restart from the beginning.
+func restoreIDGenerator(g IDGenerator, state idGeneratorState) error {
+	switch g := g.(type) {
+	case *sequentialIDGenerator:
+		if state.Deterministic {
+			return errors.New("the checkpoint uses deterministic IDs")
+		}
+
+		atomic.StoreUint64(&g.nextID, state.NextID)
+	case *deterministicIDGenerator:
+		if !state.Deterministic {
+			return errors.New("the checkpoint uses sequential IDs")
+		}
+
+		atomic.StoreUint64(&g.shared.nextID, state.NextID)
+		g.streams.Range(func(_, s interface{}) bool {
+			atomic.StoreUint64(&s.(*idStream).nextID, 0)
+			return true
+		})
+
+		for _, s := range state.Streams {
+			atomic.StoreUint64(&g.stream(s.Name).nextID, s.NextID)
+		}
+	default:
+		return fmt.Errorf("ID generator %T cannot be checkpointed", g)
+	}
+
+	return nil
+}
+
+// saveQueues saves the events of the queues in the order of the heaps, so that
+// the events with the same time are popped in the same order after restoring.
+// The primary queue i is saved as queue 2i, and the secondary queue i is saved
+// as queue 2i+1.
+func saveQueues(cp *Checkpoint, primary, secondary []EventQueue) error {
+	for i := range primary {
+		err := saveQueue(cp, primary[i], 2*i)
+		if err != nil {
+			return err
+		}
+
+		err = saveQueue(cp, secondary[i], 2*i+1)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func saveQueue(cp *Checkpoint, q EventQueue, queueIndex int) error {
+	impl, ok := q.(*EventQueueImpl)
+	if !ok {
+		return fmt.Errorf("event queue %T cannot be checkpointed", q)
+	}
+
+	impl.Lock()
+	defer impl.Unlock()
+
+	for _, evt := range impl.all() {
+		rec, err := cp.encodeEvent(evt, queueIndex)
+		if err != nil {
+			return err
+		}
+
+		cp.data.Events = append(cp.data.Events, rec)
+	}
+
+	return nil
+}
+
+// loadQueues restores the events of the queues. The events are pushed to the
+// queues in the saved order, which is the order that they are handled.
+func loadQueues(cp *Checkpoint, primary, secondary []EventQueue) error {
+	queues := make([]*EventQueueImpl, 0, 2*len(primary))
+	for i := range primary {
+		for _, q := range []EventQueue{primary[i], secondary[i]} {
+			impl, ok := q.(*EventQueueImpl)
+			if !ok {
+				return fmt.Errorf("event queue %T cannot be checkpointed", q)
+			}
+
+			queues = append(queues, impl)
+		}
+	}
+
+	for _, rec := range cp.data.Events {
+		if rec.Queue >= len(queues) {
+			return fmt.Errorf("event queue %d does not exist", rec.Queue)
+		}
+	}
+
+	events := make([][]Event, len(queues))
+	for _, rec := range cp.data.Events {
+		evt, err := cp.decodeEvent(rec)
+		if err != nil {
+			return err
+		}
+
+		events[rec.Queue] = append(events[rec.Queue], evt)
+	}
+
+	for i, q := range queues {
+		q.Lock()
+		q.reset(events[i])
+		q.Unlock()
+	}
+
+	return nil
+}
@@ -0,0 +1,298 @@
+package sim_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+func init() {
+	gob.Register(&PingMsg{})
+	gob.Register(&PingRsp{})
+}
+
+// checkpointAgent pings its peer and responds the pings from its peer. The
+// number of cycles to respond depends on the sequence ID, so that the two
+// agents interleave their messages.
+type checkpointAgent struct {
+	*sim.TickingComponent
+
+	port sim.Port
+	peer sim.Port
+
+	numPingToSend int
+	nextSeqID     int
+	transactions  []*pingTransaction
+	log           []string
+}
+
+func newCheckpointAgent(
+	name string,
+	engine sim.Engine,
+) *checkpointAgent {
+	a := &checkpointAgent{}
+	a.TickingComponent = sim.NewTickingComponent(name, engine, 1*sim.GHz, a)
+	a.port = sim.NewLimitNumMsgPort(a, 2, name+".Port")
+	a.AddPort("Port", a.port)
+
+	return a
+}
+
+func (a *checkpointAgent) Tick(now sim.VTimeInSec) bool {
+	madeProgress := false
+
+	madeProgress = a.respond(now) || madeProgress
+	madeProgress = a.ping(now) || madeProgress
+	madeProgress = a.receive(now) || madeProgress
+
+	return madeProgress
+}
+
+func (a *checkpointAgent) respond(now sim.VTimeInSec) bool {
+	madeProgress := false
+	for _, trans := range a.transactions {
+		if trans.cycleLeft > 0 {
+			trans.cycleLeft--
+			madeProgress = true
+		}
+	}
+
+	if len(a.transactions) == 0 || a.transactions[0].cycleLeft > 0 {
+		return madeProgress
+	}
+
+	rsp := &PingRsp{SeqID: a.transactions[0].req.SeqID}
+	rsp.ID = sim.GetIDGenerator().Generate()
+	rsp.Src = a.port
+	rsp.Dst = a.peer
+	rsp.SendTime = now
+
+	if a.port.Send(rsp) != nil {
+		return madeProgress
+	}
+
+	a.transactions = a.transactions[1:]
+
+	return true
+}
+
+func (a *checkpointAgent) ping(now sim.VTimeInSec) bool {
+	if a.numPingToSend == 0 {
+		return false
+	}
+
+	ping := &PingMsg{SeqID: a.nextSeqID}
+	ping.ID = sim.GetIDGenerator().Generate()
+	ping.Src = a.port
+	ping.Dst = a.peer
+	ping.SendTime = now
+
+	if a.port.Send(ping) != nil {
+		return false
+	}
+
+	a.numPingToSend--
+	a.nextSeqID++
+
+	return true
+}
+
+func (a *checkpointAgent) receive(now sim.VTimeInSec) bool {
+	msg := a.port.Retrieve(now)
+	if msg == nil {
+		return false
+	}
+
+	switch msg := msg.(type) {
+	case *PingMsg:
+		a.transactions = append(a.transactions, &pingTransaction{
+			req:       msg,
+			cycleLeft: msg.SeqID%3 + 1,
+		})
+	case *PingRsp:
+		a.log = append(a.log,
+			fmt.Sprintf("%.10f %s rsp %d", now, a.Name(), msg.SeqID))
+	}
+
+	return true
+}
+
+type checkpointTransactionState struct {
+	Req       string
+	CycleLeft int
+}
+
+type checkpointAgentState struct {
+	NumPingToSend int
+	NextSeqID     int
+	Transactions  []checkpointTransactionState
+	Log           []string
+}
+
+func (a *checkpointAgent) SaveState(cp *sim.Checkpoint) error {
+	state := checkpointAgentState{
+		NumPingToSend: a.numPingToSend,
+		NextSeqID:     a.nextSeqID,
+		Log:           a.log,
+	}
+
+	for _, trans := range a.transactions {
+		state.Transactions = append(state.Transactions,
+			checkpointTransactionState{
+				Req:       cp.MsgRef(trans.req),
+				CycleLeft: trans.cycleLeft,
+			})
+	}
+
+	err := cp.Put(a.Name(), state)
+	if err != nil {
+		return err
+	}
+
+	return a.TickScheduler.SaveStateAs(cp, a.Name()+".TickScheduler")
+}
+
+func (a *checkpointAgent) LoadState(cp *sim.Checkpoint) error {
+	state := checkpointAgentState{}
+	err := cp.Get(a.Name(), &state)
+	if err != nil {
+		return err
+	}
+
+	a.numPingToSend = state.NumPingToSend
+	a.nextSeqID = state.NextSeqID
+	a.log = state.Log
+	a.transactions = nil
+
+	for _, trans := range state.Transactions {
+		a.transactions = append(a.transactions, &pingTransaction{
+			req:       cp.Msg(trans.Req).(*PingMsg),
+			cycleLeft: trans.CycleLeft,
+		})
+	}
+
+	return a.TickScheduler.LoadStateAs(cp, a.Name()+".TickScheduler")
+}
+
+type eventRecorder struct {
+	events []string
+}
+
+func (r *eventRecorder) Func(ctx sim.HookCtx) {
+	if ctx.Pos != sim.HookPosBeforeEvent {
+		return
+	}
+
+	evt := ctx.Item.(sim.Event)
+	r.events = append(r.events, fmt.Sprintf("%.10f %s %t",
+		evt.Time(), evt.Handler().(sim.Named).Name(), evt.IsSecondary()))
+}
+
+type checkpointEvent struct {
+	*sim.EventBase
+}
+
+type checkpointTrigger struct {
+	handle func() error
+}
+
+func (t *checkpointTrigger) Handle(_ sim.Event) error {
+	return t.handle()
+}
+
+type checkpointSystem struct {
+	engine       *sim.SerialEngine
+	agentA       *checkpointAgent
+	agentB       *checkpointAgent
+	conn         *sim.DirectConnection
+	checkpointer *sim.Checkpointer
+}
+
+func buildCheckpointSystem() *checkpointSystem {
+	s := &checkpointSystem{}
+	s.engine = sim.NewSerialEngine()
+	s.agentA = newCheckpointAgent("AgentA", s.engine)
+	s.agentB = newCheckpointAgent("AgentB", s.engine)
+	s.conn = sim.NewDirectConnection("Conn", s.engine, 1*sim.GHz)
+
+	s.conn.PlugIn(s.agentA.port, 1)
+	s.conn.PlugIn(s.agentB.port, 1)
+	s.agentA.peer = s.agentB.port
+	s.agentB.peer = s.agentA.port
+
+	s.checkpointer = sim.NewCheckpointer(s.engine)
+	s.checkpointer.Register(s.agentA, s.agentB, s.conn)
+
+	return s
+}
+
+var _ = Describe("Checkpointer", func() {
+	It("should restore the simulation bit-exactly", func() {
+		original := buildCheckpointSystem()
+		original.agentA.numPingToSend = 20
+		original.agentB.numPingToSend = 15
+		original.agentA.TickLater(0)
+		original.agentB.TickLater(0)
+
+		checkpoint := bytes.NewBuffer(nil)
+		originalEvents := &eventRecorder{}
+		original.engine.Schedule(&checkpointEvent{
+			EventBase: sim.NewEventBase(10.5e-9,
+				&checkpointTrigger{handle: func() error {
+					original.engine.AcceptHook(originalEvents)
+					return original.checkpointer.Save(checkpoint)
+				}}),
+		})
+
+		Expect(original.engine.Run()).To(Succeed())
+		Expect(checkpoint.Len()).NotTo(BeZero())
+		originalNextID := sim.GetIDGenerator().Generate()
+
+		restored := buildCheckpointSystem()
+		restoredEvents := &eventRecorder{}
+		restored.engine.AcceptHook(restoredEvents)
+		Expect(restored.checkpointer.Restore(checkpoint)).To(Succeed())
+		Expect(restored.engine.CurrentTime()).
+			To(Equal(sim.VTimeInSec(10.5e-9)))
+
+		Expect(restored.engine.Run()).To(Succeed())
+
+		Expect(restoredEvents.events).NotTo(BeEmpty())
+		Expect(restoredEvents.events).To(Equal(originalEvents.events))
+		Expect(restored.engine.CurrentTime()).
+			To(Equal(original.engine.CurrentTime()))
+		Expect(restored.agentA.log).To(Equal(original.agentA.log))
+		Expect(restored.agentB.log).To(Equal(original.agentB.log))
+		Expect(restored.agentA.log).To(HaveLen(20))
+		Expect(restored.agentB.log).To(HaveLen(15))
+		Expect(sim.GetIDGenerator().Generate()).To(Equal(originalNextID))
+	})
+
+	It("should fail if the handler of an event is not registered", func() {
+		s := buildCheckpointSystem()
+		s.engine.Schedule(&checkpointEvent{
+			EventBase: sim.NewEventBase(1,
+				&checkpointTrigger{handle: func() error { return nil }}),
+		})
+
+		err := s.checkpointer.Save(bytes.NewBuffer(nil))
+
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should fail if a buffer holds elements other than messages", func() {
+		engine := sim.NewSerialEngine()
+		buf := sim.NewBuffer("Buf", 2)
+		buf.Push(1)
+		checkpointer := sim.NewCheckpointer(engine)
+		checkpointer.Register(buf.(sim.Checkpointable))
+
+		err := checkpointer.Save(bytes.NewBuffer(nil))
+
+		Expect(err).To(HaveOccurred())
+	})
+})
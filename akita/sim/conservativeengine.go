@@ -0,0 +1,532 @@
+package sim
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"reflect"
+	"sync"
+)
+
+// A ConservativeEngine is an Engine that runs the simulation in parallel with
+// conservative parallel discrete event simulation.
+//
+// The components are divided into partitions, or logical processes. Each
+// partition has its own event queues and runs its events one after another,
+// in the same order as a SerialEngine created with NewOrderedSerialEngine
+// does. Components in different partitions can only interact through
+// PartitionLinks.
+//
+// A message takes at least the latency of a PartitionLink to go through the
+// link, so a partition cannot affect the partition on the other side of the
+// link earlier than the latency, which is the lookahead between the two
+// partitions. The engine runs in rounds. In each round, it finds the earliest
+// time at which each partition may receive an event from the other
+// partitions, considering the earliest events of all the partitions and the
+// lookahead of the links, including the paths through other partitions. Each
+// partition then runs alone, in parallel with the other partitions, until
+// that time. The events that the partitions schedule for each other are
+// exchanged at the end of the round in the order of the partitions, so the
+// results do not depend on how the partitions are scheduled. The results are
+// the same as the results of an ordered SerialEngine that simulates the same
+// components and PartitionLinks.
+//
+// Components should be built with the Partition that they belong to as the
+// engine. The events that are scheduled directly to the ConservativeEngine
+// belong to the first partition.
+type ConservativeEngine struct {
+	HookableBase
+
+	pauseLock sync.Mutex
+	nowLock   sync.RWMutex
+	now       VTimeInSec
+	hookLock  sync.Mutex
+	inRound   bool
+
+	partitions []*Partition
+
+	simulationEndHandlers []SimulationEndHandler
+}
+
+// NewConservativeEngine creates a ConservativeEngine.
+func NewConservativeEngine() *ConservativeEngine {
+	e := new(ConservativeEngine)
+	return e
+}
+
+// Partition returns the i-th partition of the engine. The partition is
+// created if it does not exist.
+func (e *ConservativeEngine) Partition(i int) *Partition {
+	for len(e.partitions) <= i {
+		p := &Partition{
+			engine:         e,
+			id:             len(e.partitions),
+			queue:          NewOrderedEventQueue(),
+			secondaryQueue: NewOrderedEventQueue(),
+			lookaheads:     make(map[*Partition]VTimeInSec),
+		}
+		e.partitions = append(e.partitions, p)
+	}
+
+	return e.partitions[i]
+}
+
+// NumPartitions returns the number of partitions of the engine.
+func (e *ConservativeEngine) NumPartitions() int {
+	return len(e.partitions)
+}
+
+// addLink records the latency of a link between two partitions as the
+// lookahead between them, if it is shorter than the lookahead of the other
+// links between the partitions.
+func (e *ConservativeEngine) addLink(a, b *Partition, latency VTimeInSec) {
+	if a == b {
+		return
+	}
+
+	if latency <= 0 {
+		log.Panic("the latency of a link between partitions must be positive")
+	}
+
+	for _, pair := range [][2]*Partition{{a, b}, {b, a}} {
+		src, dst := pair[0], pair[1]
+		if l, found := dst.lookaheads[src]; !found || latency < l {
+			dst.lookaheads[src] = latency
+		}
+	}
+}
+
+// Schedule registers an event in the first partition.
+func (e *ConservativeEngine) Schedule(evt Event) {
+	e.Partition(0).Schedule(evt)
+}
+
+func (e *ConservativeEngine) readNow() VTimeInSec {
+	e.nowLock.RLock()
+	t := e.now
+	e.nowLock.RUnlock()
+	return t
+}
+
+func (e *ConservativeEngine) writeNow(t VTimeInSec) {
+	e.nowLock.Lock()
+	e.now = t
+	e.nowLock.Unlock()
+}
+
+// invokeHook invokes the hooks of the engine. The hooks are shared by all the
+// partitions, so they are invoked one at a time.
+func (e *ConservativeEngine) invokeHook(ctx HookCtx) {
+	if e.NumHooks() == 0 {
+		return
+	}
+
+	e.hookLock.Lock()
+	e.InvokeHook(ctx)
+	e.hookLock.Unlock()
+}
+
+// Run processes all the events scheduled in the partitions.
+func (e *ConservativeEngine) Run() error {
+	for {
+		e.pauseLock.Lock()
+
+		start, ends, found := e.nextRound()
+		if !found {
+			e.synchronize()
+			e.pauseLock.Unlock()
+			return nil
+		}
+
+		e.writeNow(start)
+		e.runRound(ends)
+		e.exchangeEvents()
+
+		e.pauseLock.Unlock()
+	}
+}
+
+// nextRound returns the time of the earliest event of all the partitions and
+// the time until which each partition can run in the next round.
+//
+// A partition can run until the earliest time at which an event of another
+// partition may reach it. The earliest time at which a partition may handle
+// an event is the shortest path to the partition, where the earliest events
+// of the partitions are the sources and the lookaheads are the lengths of the
+// edges. The events that a partition receives in the round are only handled
+// in later rounds, which the shortest paths account for.
+func (e *ConservativeEngine) nextRound() (
+	start VTimeInSec,
+	ends []VTimeInSec,
+	found bool,
+) {
+	inf := VTimeInSec(math.Inf(1))
+	earliest := make(map[*Partition]VTimeInSec, len(e.partitions))
+
+	for _, p := range e.partitions {
+		earliest[p] = inf
+
+		t, ok := p.earliestEventTime()
+		if !ok {
+			continue
+		}
+
+		earliest[p] = t
+		if !found || t < start {
+			start = t
+			found = true
+		}
+	}
+
+	if !found {
+		return 0, nil, false
+	}
+
+	for range e.partitions {
+		for _, p := range e.partitions {
+			for src, lookahead := range p.lookaheads {
+				if t := earliest[src] + lookahead; t < earliest[p] {
+					earliest[p] = t
+				}
+			}
+		}
+	}
+
+	ends = make([]VTimeInSec, len(e.partitions))
+	for i, p := range e.partitions {
+		ends[i] = inf
+		for src, lookahead := range p.lookaheads {
+			if t := earliest[src] + lookahead; t < ends[i] {
+				ends[i] = t
+			}
+		}
+	}
+
+	return start, ends, true
+}
+
+// runRound lets all the partitions handle the events before the end of the
+// partitions in parallel.
+func (e *ConservativeEngine) runRound(ends []VTimeInSec) {
+	active := make([]*Partition, 0, len(e.partitions))
+	for i, p := range e.partitions {
+		p.roundEnd = ends[i]
+		if t, ok := p.earliestEventTime(); ok && t < p.roundEnd {
+			active = append(active, p)
+		}
+	}
+
+	e.inRound = true
+	defer func() { e.inRound = false }()
+
+	if len(active) == 1 {
+		active[0].runUntil(active[0].roundEnd)
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, p := range active {
+		wg.Add(1)
+		go func(p *Partition) {
+			defer wg.Done()
+			p.runUntil(p.roundEnd)
+		}(p)
+	}
+	wg.Wait()
+}
+
+// exchangeEvents moves the events that the partitions schedule for each other
+// to the destination partitions. The events are moved in the order of the
+// source partitions so that the simulation is deterministic.
+func (e *ConservativeEngine) exchangeEvents() {
+	for _, p := range e.partitions {
+		for _, r := range p.outbox {
+			if r.evt.Time() < r.dst.roundEnd {
+				log.Panicf("event %s @ %.10f is scheduled within the "+
+					"lookahead of partition %d",
+					reflect.TypeOf(r.evt), r.evt.Time(), r.dst.id)
+			}
+
+			r.dst.push(r.evt)
+		}
+
+		p.outbox = p.outbox[:0]
+	}
+}
+
+// synchronize moves the time of the engine and all the partitions to the time
+// of the last event handled, which is the time at which the components
+// continue when more events are scheduled, as with the SerialEngine.
+func (e *ConservativeEngine) synchronize() {
+	now := e.readNow()
+	for _, p := range e.partitions {
+		if t := p.readNow(); t > now {
+			now = t
+		}
+	}
+
+	e.writeNow(now)
+	for _, p := range e.partitions {
+		p.writeNow(now)
+	}
+}
+
+// Pause prevents the engine from starting new rounds.
+func (e *ConservativeEngine) Pause() {
+	e.pauseLock.Lock()
+}
+
+// Continue allows the engine to continue to make progress.
+func (e *ConservativeEngine) Continue() {
+	e.pauseLock.Unlock()
+}
+
+// CurrentTime returns the time of the earliest event of the current round,
+// or the time of the last event handled if the engine is not running. The
+// partitions may run ahead of the time, so the components should use the
+// CurrentTime of their own partition.
+func (e *ConservativeEngine) CurrentTime() VTimeInSec {
+	return e.readNow()
+}
+
+// RegisterSimulationEndHandler registers a handler to be called after the
+// simulation ends.
+func (e *ConservativeEngine) RegisterSimulationEndHandler(
+	handler SimulationEndHandler,
+) {
+	e.simulationEndHandlers = append(e.simulationEndHandlers, handler)
+}
+
+// Finished should be called after the simulation completes. It calls all the
+// registered SimulationEndHandlers.
+func (e *ConservativeEngine) Finished() {
+	now := e.readNow()
+	for _, h := range e.simulationEndHandlers {
+		h.Handle(now)
+	}
+}
+
+func (e *ConservativeEngine) queues() (primary, secondary []EventQueue) {
+	for _, p := range e.partitions {
+		primary = append(primary, p.queue)
+		secondary = append(secondary, p.secondaryQueue)
+	}
+
+	return primary, secondary
+}
+
+// saveEvents saves the events of the partitions. The partitions run to
+// different times, so the engine can only be checkpointed after it stops,
+// when all the partitions are at the same time.
+func (e *ConservativeEngine) saveEvents(cp *Checkpoint) error {
+	for _, p := range e.partitions {
+		if p.readNow() != cp.Time() {
+			return fmt.Errorf(
+				"partition %d is at a different time from the engine", p.id)
+		}
+	}
+
+	primary, secondary := e.queues()
+	return saveQueues(cp, primary, secondary)
+}
+
+func (e *ConservativeEngine) loadEvents(cp *Checkpoint) error {
+	e.writeNow(cp.Time())
+	for _, p := range e.partitions {
+		p.writeNow(cp.Time())
+	}
+
+	primary, secondary := e.queues()
+
+	return loadQueues(cp, primary, secondary)
+}
+
+// A Partition is a logical process of a ConservativeEngine. It is the engine
+// of the components that belong to the partition.
+type Partition struct {
+	HookableBase
+
+	engine *ConservativeEngine
+	id     int
+
+	timeLock       sync.RWMutex
+	time           VTimeInSec
+	queue          EventQueue
+	secondaryQueue EventQueue
+
+	lookaheads map[*Partition]VTimeInSec
+	roundEnd   VTimeInSec
+	outbox     []remoteEvent
+}
+
+type remoteEvent struct {
+	dst *Partition
+	evt Event
+}
+
+// ID returns the index of the partition in the engine.
+func (p *Partition) ID() int {
+	return p.id
+}
+
+// Engine returns the ConservativeEngine that the partition belongs to.
+func (p *Partition) Engine() *ConservativeEngine {
+	return p.engine
+}
+
+// Lookahead returns the shortest latency of the links from other partitions
+// to the partition, which is how far the partition can run ahead of the other
+// partitions. It is infinite if the partition is not linked.
+func (p *Partition) Lookahead() VTimeInSec {
+	lookahead := VTimeInSec(math.Inf(1))
+	for _, l := range p.lookaheads {
+		if l < lookahead {
+			lookahead = l
+		}
+	}
+
+	return lookahead
+}
+
+func (p *Partition) readNow() VTimeInSec {
+	p.timeLock.RLock()
+	t := p.time
+	p.timeLock.RUnlock()
+	return t
+}
+
+func (p *Partition) writeNow(t VTimeInSec) {
+	p.timeLock.Lock()
+	p.time = t
+	p.timeLock.Unlock()
+}
+
+// Schedule registers an event to happen in the future in the partition. The
+// components in other partitions must not schedule events in the partition
+// directly, but send messages through PartitionLinks.
+func (p *Partition) Schedule(evt Event) {
+	now := p.readNow()
+	if evt.Time() < now {
+		log.Panicf(
+			"cannot schedule event in the past, evt %s @ %.10f, now %.10f",
+			reflect.TypeOf(evt), evt.Time(), now)
+	}
+
+	p.push(evt)
+}
+
+func (p *Partition) push(evt Event) {
+	if evt.IsSecondary() {
+		p.secondaryQueue.Push(evt)
+		return
+	}
+
+	p.queue.Push(evt)
+}
+
+// scheduleRemote schedules an event in another partition. During a round,
+// the event is held until the end of the round, when the partitions exchange
+// their events.
+func (p *Partition) scheduleRemote(dst *Partition, evt Event) {
+	if dst == p || !p.engine.inRound {
+		dst.Schedule(evt)
+		return
+	}
+
+	p.outbox = append(p.outbox, remoteEvent{dst: dst, evt: evt})
+}
+
+// earliestEventTime returns the time of the earliest event of the partition.
+func (p *Partition) earliestEventTime() (VTimeInSec, bool) {
+	queue := p.nextQueue()
+	if queue == nil {
+		return 0, false
+	}
+
+	return queue.Peek().Time(), true
+}
+
+// runUntil handles the events of the partition that happen before the end
+// time, in the same order as the ordered SerialEngine does.
+func (p *Partition) runUntil(end VTimeInSec) {
+	for {
+		queue := p.nextQueue()
+		if queue == nil || queue.Peek().Time() >= end {
+			return
+		}
+
+		evt := queue.Pop()
+		p.writeNow(evt.Time())
+
+		hookCtx := HookCtx{
+			Domain: p,
+			Pos:    HookPosBeforeEvent,
+			Item:   evt,
+		}
+		p.InvokeHook(hookCtx)
+		p.engine.invokeHook(hookCtx)
+
+		handler := evt.Handler()
+		_ = handler.Handle(evt)
+
+		hookCtx.Pos = HookPosAfterEvent
+		p.InvokeHook(hookCtx)
+		p.engine.invokeHook(hookCtx)
+	}
+}
+
+func (p *Partition) nextQueue() EventQueue {
+	switch {
+	case p.queue.Len() == 0 && p.secondaryQueue.Len() == 0:
+		return nil
+	case p.queue.Len() == 0:
+		return p.secondaryQueue
+	case p.secondaryQueue.Len() == 0:
+		return p.queue
+	case p.queue.Peek().Time() <= p.secondaryQueue.Peek().Time():
+		return p.queue
+	default:
+		return p.secondaryQueue
+	}
+}
+
+// Run runs the engine that the partition belongs to.
+func (p *Partition) Run() error {
+	return p.engine.Run()
+}
+
+// Pause pauses the engine that the partition belongs to.
+func (p *Partition) Pause() {
+	p.engine.Pause()
+}
+
+// Continue continues the engine that the partition belongs to.
+func (p *Partition) Continue() {
+	p.engine.Continue()
+}
+
+// CurrentTime returns the time of the event that the partition is handling.
+func (p *Partition) CurrentTime() VTimeInSec {
+	return p.readNow()
+}
+
+// RegisterSimulationEndHandler registers a handler to be called after the
+// simulation ends.
+func (p *Partition) RegisterSimulationEndHandler(
+	handler SimulationEndHandler,
+) {
+	p.engine.RegisterSimulationEndHandler(handler)
+}
+
+// Finished calls all the SimulationEndHandlers registered to the engine.
+func (p *Partition) Finished() {
+	p.engine.Finished()
+}
+
+func (p *Partition) saveEvents(cp *Checkpoint) error {
+	return p.engine.saveEvents(cp)
+}
+
+func (p *Partition) loadEvents(cp *Checkpoint) error {
+	return p.engine.loadEvents(cp)
+}
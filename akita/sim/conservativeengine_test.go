@@ -0,0 +1,173 @@
+package sim
+
+import (
+	"math"
+
+	gomock "github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type recordingHandler struct {
+	partition *Partition
+	handled   []VTimeInSec
+	onHandle  func(e Event)
+}
+
+func (h *recordingHandler) Handle(e Event) error {
+	h.handled = append(h.handled, h.partition.CurrentTime())
+
+	if h.onHandle != nil {
+		h.onHandle(e)
+	}
+
+	return nil
+}
+
+// countingHook counts the hook invocations without synchronization, so that
+// the race detector can tell if the hook is invoked concurrently.
+type countingHook struct {
+	count int
+}
+
+func (h *countingHook) Func(_ HookCtx) {
+	h.count++
+}
+
+var _ = Describe("ConservativeEngine", func() {
+	var (
+		mockCtrl *gomock.Controller
+		engine   *ConservativeEngine
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		engine = NewConservativeEngine()
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	It("should run the events of a partition in order", func() {
+		handler := NewMockHandler(mockCtrl)
+		evt1 := NewMockEvent(mockCtrl)
+		evt2 := NewMockEvent(mockCtrl)
+		evt3 := NewMockEvent(mockCtrl)
+
+		evt1.EXPECT().Time().Return(VTimeInSec(2.0)).AnyTimes()
+		evt1.EXPECT().Handler().Return(handler).AnyTimes()
+		evt1.EXPECT().IsSecondary().Return(true).AnyTimes()
+		evt2.EXPECT().Time().Return(VTimeInSec(2.0)).AnyTimes()
+		evt2.EXPECT().Handler().Return(handler).AnyTimes()
+		evt2.EXPECT().IsSecondary().Return(false).AnyTimes()
+		evt3.EXPECT().Time().Return(VTimeInSec(1.0)).AnyTimes()
+		evt3.EXPECT().Handler().Return(handler).AnyTimes()
+		evt3.EXPECT().IsSecondary().Return(false).AnyTimes()
+
+		handleEvt3 := handler.EXPECT().Handle(evt3)
+		handleEvt2 := handler.EXPECT().Handle(evt2).After(handleEvt3)
+		handler.EXPECT().Handle(evt1).After(handleEvt2)
+
+		partition := engine.Partition(1)
+		partition.Schedule(evt1)
+		partition.Schedule(evt2)
+		partition.Schedule(evt3)
+
+		err := engine.Run()
+
+		Expect(err).To(BeNil())
+		Expect(partition.CurrentTime()).To(Equal(VTimeInSec(2.0)))
+		Expect(engine.CurrentTime()).To(Equal(VTimeInSec(2.0)))
+	})
+
+	It("should let a partition run ahead within the lookahead", func() {
+		p0 := engine.Partition(0)
+		p1 := engine.Partition(1)
+		engine.addLink(p0, p1, 10)
+
+		var roundStarts []VTimeInSec
+		h0 := &recordingHandler{partition: p0}
+		h1 := &recordingHandler{partition: p1}
+		h1.onHandle = func(_ Event) {
+			roundStarts = append(roundStarts, engine.CurrentTime())
+		}
+
+		p0.Schedule(NewEventBase(1, h0))
+		for t := 1; t <= 5; t++ {
+			p1.Schedule(NewEventBase(VTimeInSec(t), h1))
+		}
+		p1.Schedule(NewEventBase(12, h1))
+
+		err := engine.Run()
+
+		Expect(err).To(BeNil())
+		Expect(h1.handled).To(Equal([]VTimeInSec{1, 2, 3, 4, 5, 12}))
+		Expect(roundStarts).To(Equal([]VTimeInSec{1, 1, 1, 1, 1, 12}))
+		Expect(p0.CurrentTime()).To(Equal(VTimeInSec(12)))
+	})
+
+	It("should not let a partition run past the events from others", func() {
+		p0 := engine.Partition(0)
+		p1 := engine.Partition(1)
+		engine.addLink(p0, p1, 3)
+
+		h0 := &recordingHandler{partition: p0}
+		h1 := &recordingHandler{partition: p1}
+		h0.onHandle = func(e Event) {
+			p0.scheduleRemote(p1, NewEventBase(e.Time()+3, h1))
+		}
+		h1.onHandle = func(e Event) {
+			if e.Time() == 4 {
+				p1.scheduleRemote(p0, NewEventBase(e.Time()+3, h0))
+			}
+		}
+
+		p0.Schedule(NewEventBase(1, h0))
+		for t := 1; t <= 10; t++ {
+			p1.Schedule(NewEventBase(VTimeInSec(t), h1))
+		}
+
+		err := engine.Run()
+
+		Expect(err).To(BeNil())
+		Expect(h0.handled).To(Equal([]VTimeInSec{1, 7, 7}))
+		Expect(h1.handled).To(Equal(
+			[]VTimeInSec{1, 2, 3, 4, 4, 5, 6, 7, 8, 9, 10, 10, 10}))
+	})
+
+	It("should run unlinked partitions to the end in parallel", func() {
+		h0 := &recordingHandler{partition: engine.Partition(0)}
+		h1 := &recordingHandler{partition: engine.Partition(1)}
+
+		engine.Partition(0).Schedule(NewEventBase(1, h0))
+		engine.Partition(1).Schedule(NewEventBase(2, h1))
+		engine.Partition(1).Schedule(NewEventBase(3, h1))
+
+		err := engine.Run()
+
+		Expect(err).To(BeNil())
+		Expect(engine.Partition(0).Lookahead()).To(
+			Equal(VTimeInSec(math.Inf(1))))
+		Expect(h1.handled).To(Equal([]VTimeInSec{2, 3}))
+		Expect(engine.CurrentTime()).To(Equal(VTimeInSec(3)))
+		Expect(engine.Partition(0).CurrentTime()).To(Equal(VTimeInSec(3)))
+	})
+
+	It("should invoke the hooks of the engine one at a time", func() {
+		hook := &countingHook{}
+		engine.AcceptHook(hook)
+
+		for i := 0; i < 4; i++ {
+			h := &recordingHandler{partition: engine.Partition(i)}
+			for t := 1; t <= 100; t++ {
+				engine.Partition(i).Schedule(NewEventBase(VTimeInSec(t), h))
+			}
+		}
+
+		err := engine.Run()
+
+		Expect(err).To(BeNil())
+		Expect(hook.count).To(Equal(800))
+	})
+})
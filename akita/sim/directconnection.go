@@ -1,5 +1,7 @@
 package sim
 
+import "fmt"
+
 type directConnectionEnd struct {
 	port    Port
 	buf     []Msg
@@ -159,3 +161,68 @@ func NewDirectConnection(
 	c.ends = make(map[Port]*directConnectionEnd)
 	return c
 }
+
+type directConnectionEndState struct {
+	Buf  []string
+	Busy bool
+}
+
+type directConnectionState struct {
+	NextPortID int
+	Ends       []directConnectionEndState
+}
+
+// SaveState saves the messages that are being delivered by the connection.
+func (c *DirectConnection) SaveState(cp *Checkpoint) error {
+	c.Lock()
+	defer c.Unlock()
+
+	state := directConnectionState{NextPortID: c.nextPortID}
+	for _, port := range c.ports {
+		end := c.ends[port]
+		endState := directConnectionEndState{Busy: end.busy}
+		for _, msg := range end.buf {
+			endState.Buf = append(endState.Buf, cp.MsgRef(msg))
+		}
+
+		state.Ends = append(state.Ends, endState)
+	}
+
+	err := cp.Put(c.Name(), state)
+	if err != nil {
+		return err
+	}
+
+	return c.TickScheduler.SaveStateAs(cp, c.Name()+".TickScheduler")
+}
+
+// LoadState restores the messages that are being delivered by the connection.
+// The same ports must be plugged in the same order as when the checkpoint is
+// saved.
+func (c *DirectConnection) LoadState(cp *Checkpoint) error {
+	c.Lock()
+	defer c.Unlock()
+
+	state := directConnectionState{}
+	err := cp.Get(c.Name(), &state)
+	if err != nil {
+		return err
+	}
+
+	if len(state.Ends) != len(c.ports) {
+		return fmt.Errorf("connection %s has %d ports, but %d are saved",
+			c.Name(), len(c.ports), len(state.Ends))
+	}
+
+	c.nextPortID = state.NextPortID
+	for i, port := range c.ports {
+		end := c.ends[port]
+		end.busy = state.Ends[i].Busy
+		end.buf = nil
+		for _, ref := range state.Ends[i].Buf {
+			end.buf = append(end.buf, cp.Msg(ref))
+		}
+	}
+
+	return c.TickScheduler.LoadStateAs(cp, c.Name()+".TickScheduler")
+}
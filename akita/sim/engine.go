@@ -37,3 +37,12 @@ type Engine interface {
 	// Finished invokes all the registered SimulationEndHandler
 	Finished()
 }
+
+// A Coupler is an engine that needs to know which components interact with
+// each other directly, rather than through connections. For example, a
+// component that calls the ports of another component while handling its own
+// events is coupled with the other component. An engine that runs events in
+// parallel never handles the events of coupled components at the same time.
+type Coupler interface {
+	Couple(components ...Named)
+}
@@ -27,7 +27,7 @@ type EventBase struct {
 // NewEventBase creates a new EventBase
 func NewEventBase(t VTimeInSec, handler Handler) *EventBase {
 	e := new(EventBase)
-	e.ID = GetIDGenerator().Generate()
+	e.ID = idGeneratorForHandler(handler).Generate()
 	e.time = t
 	e.handler = handler
 	e.secondary = false
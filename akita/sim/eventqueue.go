@@ -3,6 +3,7 @@ package sim
 import (
 	"container/heap"
 	"container/list"
+	"sort"
 	"sync"
 )
 
@@ -14,31 +15,66 @@ type EventQueue interface {
 	Peek() Event
 }
 
-// EventQueueImpl provides a thread safe event queue
+// EventQueueImpl provides a thread safe event queue. The events are ordered by
+// their time. An EventQueueImpl created with NewOrderedEventQueue also orders
+// the events that happen at the same time, first by the names of the
+// components that handle them, and then by the order in which they are
+// pushed. So, the order does not depend on the other events in the queue, and
+// the events of a group of components are handled in the same order even if
+// they are split into several queues.
 type EventQueueImpl struct {
 	sync.Mutex
-	events eventHeap
+	events  eventHeap
+	ordered bool
+	nextSeq uint64
 }
 
 // NewEventQueue creates and returns a newly created EventQueue
 func NewEventQueue() *EventQueueImpl {
 	q := new(EventQueueImpl)
-	q.events = make([]Event, 0)
+	q.events = make([]queuedEvent, 0)
 	heap.Init(&q.events)
 	return q
 }
 
+// NewOrderedEventQueue creates an EventQueue that orders the events that
+// happen at the same time by the names of their handlers and then by the
+// order in which they are pushed.
+func NewOrderedEventQueue() *EventQueueImpl {
+	q := NewEventQueue()
+	q.ordered = true
+	return q
+}
+
 // Push adds an event to the event queue
 func (q *EventQueueImpl) Push(evt Event) {
 	q.Lock()
-	heap.Push(&q.events, evt)
+	heap.Push(&q.events, q.wrap(evt))
 	q.Unlock()
 }
 
+// wrap attaches the ordering keys to an event. The keys are left empty if the
+// queue is not ordered, so that the events that happen at the same time are
+// considered equal.
+func (q *EventQueueImpl) wrap(evt Event) queuedEvent {
+	item := queuedEvent{
+		evt:  evt,
+		time: evt.Time(),
+	}
+
+	if q.ordered {
+		item.name = handlerName(evt.Handler())
+		item.seq = q.nextSeq
+		q.nextSeq++
+	}
+
+	return item
+}
+
 // Pop returns the next earliest event
 func (q *EventQueueImpl) Pop() Event {
 	q.Lock()
-	e := heap.Pop(&q.events).(Event)
+	e := heap.Pop(&q.events).(queuedEvent).evt
 	q.Unlock()
 	return e
 }
@@ -55,12 +91,60 @@ func (q *EventQueueImpl) Len() int {
 // queue
 func (q *EventQueueImpl) Peek() Event {
 	q.Lock()
-	evt := q.events[0]
+	evt := q.events[0].evt
 	q.Unlock()
 	return evt
 }
 
-type eventHeap []Event
+// all returns the events in the queue. The events of an ordered queue are
+// returned in the order that they are handled. The events of other queues are
+// returned in the order that they are stored in the heap, so that reset can
+// rebuild the same heap, which handles the events that happen at the same
+// time in the same order. The caller must hold the lock of the queue.
+func (q *EventQueueImpl) all() []Event {
+	items := make(eventHeap, len(q.events))
+	copy(items, q.events)
+
+	if q.ordered {
+		sort.Sort(items)
+	}
+
+	events := make([]Event, 0, len(items))
+	for _, item := range items {
+		events = append(events, item.evt)
+	}
+
+	return events
+}
+
+// reset replaces the events in the queue with the events returned by all.
+// The caller must hold the lock of the queue.
+func (q *EventQueueImpl) reset(events []Event) {
+	q.events = q.events[:0]
+	for _, evt := range events {
+		q.events = append(q.events, q.wrap(evt))
+	}
+
+	heap.Init(&q.events)
+}
+
+func handlerName(h Handler) string {
+	named, ok := h.(Named)
+	if !ok {
+		return ""
+	}
+
+	return named.Name()
+}
+
+type queuedEvent struct {
+	evt  Event
+	time VTimeInSec
+	name string
+	seq  uint64
+}
+
+type eventHeap []queuedEvent
 
 // Len returns the length of the event queue
 func (h eventHeap) Len() int {
@@ -70,7 +154,15 @@ func (h eventHeap) Len() int {
 // Less determines the order between two events. Less returns true if the i-th
 // event happens before the j-th event.
 func (h eventHeap) Less(i, j int) bool {
-	return h[i].Time() < h[j].Time()
+	if h[i].time != h[j].time {
+		return h[i].time < h[j].time
+	}
+
+	if h[i].name != h[j].name {
+		return h[i].name < h[j].name
+	}
+
+	return h[i].seq < h[j].seq
 }
 
 // Swap changes the position of two events in the event queue
@@ -80,17 +172,16 @@ func (h eventHeap) Swap(i, j int) {
 
 // Push adds an event into the event queue
 func (h *eventHeap) Push(x interface{}) {
-	event := x.(Event)
-	*h = append(*h, event)
+	*h = append(*h, x.(queuedEvent))
 }
 
 // Pop removes and returns the next event to happen
 func (h *eventHeap) Pop() interface{} {
 	old := *h
 	n := len(old)
-	event := old[n-1]
+	item := old[n-1]
 	*h = old[0 : n-1]
-	return event
+	return item
 }
 
 // InsertionQueue is a queue that is based on insertion sort
@@ -8,6 +8,18 @@ import (
 	. "github.com/onsi/gomega"
 )
 
+type namedHandler struct {
+	name string
+}
+
+func (h *namedHandler) Name() string {
+	return h.name
+}
+
+func (h *namedHandler) Handle(_ Event) error {
+	return nil
+}
+
 var _ = Describe("EventQueueImpl", func() {
 	var (
 		mockCtrl *gomock.Controller
@@ -31,6 +43,7 @@ var _ = Describe("EventQueueImpl", func() {
 				Time().
 				Return(VTimeInSec(rand.Float64() / 1e8)).
 				AnyTimes()
+			event.EXPECT().Handler().Return(nil).AnyTimes()
 			queue.Push(event)
 		}
 
@@ -41,6 +54,49 @@ var _ = Describe("EventQueueImpl", func() {
 			now = event.Time()
 		}
 	})
+
+	It("should keep the same-time events in the order of the heap", func() {
+		handler := &namedHandler{name: "A"}
+
+		var events []Event
+		for i := 0; i < 4; i++ {
+			evt := NewEventBase(1, handler)
+			events = append(events, evt)
+			queue.Push(evt)
+		}
+
+		queue.Lock()
+		heapOrder := queue.all()
+		queue.Unlock()
+
+		restored := NewEventQueue()
+		restored.reset(heapOrder)
+
+		for restored.Len() > 0 {
+			Expect(restored.Pop()).To(BeIdenticalTo(queue.Pop()))
+		}
+	})
+
+	It("should order the same-time events by the handler names", func() {
+		queue = NewOrderedEventQueue()
+		handlerB := &namedHandler{name: "B"}
+		handlerA := &namedHandler{name: "A"}
+
+		evtB := NewEventBase(1, handlerB)
+		evtA1 := NewEventBase(1, handlerA)
+		evtA2 := NewEventBase(1, handlerA)
+		evt0 := NewEventBase(0, handlerB)
+
+		queue.Push(evtB)
+		queue.Push(evtA1)
+		queue.Push(evtA2)
+		queue.Push(evt0)
+
+		Expect(queue.Pop()).To(BeIdenticalTo(evt0))
+		Expect(queue.Pop()).To(BeIdenticalTo(evtA1))
+		Expect(queue.Pop()).To(BeIdenticalTo(evtA2))
+		Expect(queue.Pop()).To(BeIdenticalTo(evtB))
+	})
 })
 
 var _ = Describe("Insertion Queue", func() {
@@ -55,6 +55,28 @@ func UseParallelIDGenerator() {
 	idGeneratorMutex.Unlock()
 }
 
+// UseDeterministicIDGenerator configures the ID generator to generate IDs from
+// per-component streams. The IDs that a component generates with the
+// generator returned by IDGeneratorFor come from the stream of the component,
+// so that they do not depend on how the events of different components
+// interleave. The IDs generated with GetIDGenerator come from a shared
+// sequential stream.
+func UseDeterministicIDGenerator() {
+	if idGeneratorInstantiated {
+		log.Panic("cannot change id generator type after using it")
+	}
+
+	idGeneratorMutex.Lock()
+	if idGeneratorInstantiated {
+		log.Panic("cannot change id generator type after using it")
+	}
+
+	idGenerator = newDeterministicIDGenerator()
+	idGeneratorInstantiated = true
+
+	idGeneratorMutex.Unlock()
+}
+
 // GetIDGenerator returns the ID generator used in the current simulation
 func GetIDGenerator() IDGenerator {
 	if idGeneratorInstantiated {
@@ -89,3 +111,66 @@ type parallelIDGenerator struct {
 func (g parallelIDGenerator) Generate() string {
 	return xid.New().String()
 }
+
+// IDGeneratorFor returns the generator of the IDs that are generated by the
+// given object, which is usually a component, a port of a component, or an
+// event handler. With the deterministic ID generator, each component has its
+// own stream of IDs, and a port uses the stream of its component. Otherwise,
+// or if the object is nil, the global ID generator is returned.
+func IDGeneratorFor(n Named) IDGenerator {
+	g, ok := GetIDGenerator().(*deterministicIDGenerator)
+	if !ok || n == nil {
+		return GetIDGenerator()
+	}
+
+	if p, ok := n.(Port); ok && p.Component() != nil {
+		n = p.Component()
+	}
+
+	return g.stream(n.Name())
+}
+
+// idGeneratorForHandler returns the generator of the IDs of the events that
+// are handled by the handler.
+func idGeneratorForHandler(h Handler) IDGenerator {
+	named, ok := h.(Named)
+	if !ok {
+		return GetIDGenerator()
+	}
+
+	return IDGeneratorFor(named)
+}
+
+// An idStream generates sequential IDs with a prefix.
+type idStream struct {
+	prefix string
+	nextID uint64
+}
+
+func (s *idStream) Generate() string {
+	idNumber := atomic.AddUint64(&s.nextID, 1)
+	return s.prefix + strconv.FormatUint(idNumber, 10)
+}
+
+// deterministicIDGenerator generates IDs from a stream per component.
+type deterministicIDGenerator struct {
+	streams sync.Map
+	shared  idStream
+}
+
+func newDeterministicIDGenerator() *deterministicIDGenerator {
+	return &deterministicIDGenerator{}
+}
+
+func (g *deterministicIDGenerator) Generate() string {
+	return g.shared.Generate()
+}
+
+func (g *deterministicIDGenerator) stream(name string) *idStream {
+	s, found := g.streams.Load(name)
+	if !found {
+		s, _ = g.streams.LoadOrStore(name, &idStream{prefix: name + "-"})
+	}
+
+	return s.(*idStream)
+}
@@ -0,0 +1,54 @@
+package sim
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DeterministicIDGenerator", func() {
+	It("should generate IDs from the stream of each name", func() {
+		g := newDeterministicIDGenerator()
+
+		Expect(g.Generate()).To(Equal("1"))
+
+		a := g.stream("A")
+		Expect(a.Generate()).To(Equal("A-1"))
+		Expect(a.Generate()).To(Equal("A-2"))
+
+		Expect(g.stream("B").Generate()).To(Equal("B-1"))
+		Expect(g.stream("A").Generate()).To(Equal("A-3"))
+
+		Expect(g.Generate()).To(Equal("2"))
+	})
+
+	It("should restore the streams from a checkpoint", func() {
+		g := newDeterministicIDGenerator()
+		g.Generate()
+		g.stream("A").Generate()
+		g.stream("A").Generate()
+
+		state, err := saveIDGenerator(g)
+		Expect(err).NotTo(HaveOccurred())
+
+		restored := newDeterministicIDGenerator()
+		restored.stream("B").Generate()
+		Expect(restoreIDGenerator(restored, state)).To(Succeed())
+
+		Expect(restored.Generate()).To(Equal("2"))
+		Expect(restored.stream("A").Generate()).To(Equal("A-3"))
+		Expect(restored.stream("B").Generate()).To(Equal("B-1"))
+	})
+
+	It("should not restore sequential IDs", func() {
+		state, err := saveIDGenerator(&sequentialIDGenerator{})
+		Expect(err).NotTo(HaveOccurred())
+
+		err = restoreIDGenerator(newDeterministicIDGenerator(), state)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should not save the parallel ID generator", func() {
+		_, err := saveIDGenerator(parallelIDGenerator{})
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -93,7 +93,7 @@ func (c GeneralRspBuilder) Build() *GeneralRsp {
 			SendTime:     c.SendTime,
 			TrafficClass: c.TrafficClass,
 			TrafficBytes: c.TrafficBytes,
-			ID:           GetIDGenerator().Generate(),
+			ID:           IDGeneratorFor(c.Src).Generate(),
 		},
 		OriginalReq: c.OriginalReq,
 	}
@@ -189,7 +189,7 @@ func (c ControlMsgBuilder) Build() *ControlMsg {
 			SendTime:     c.SendTime,
 			TrafficClass: c.TrafficClass,
 			TrafficBytes: c.TrafficBytes,
-			ID:           GetIDGenerator().Generate(),
+			ID:           IDGeneratorFor(c.Src).Generate(),
 		},
 		Reset:      c.Reset,
 		Disable:    c.Disable,
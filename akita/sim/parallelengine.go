@@ -4,6 +4,7 @@ import (
 	"log"
 	"math"
 	"reflect"
+	"sort"
 	"sync"
 
 	"runtime"
@@ -29,10 +30,19 @@ type ParallelEngine struct {
 	secondaryQueueChan chan EventQueue
 
 	simulationEndHandlers []SimulationEndHandler
+
+	deterministic bool
+	orderLock     sync.Mutex
+	nextSeq       map[string]uint64
+	couplings     map[string]string
 }
 
 // NewParallelEngine creates a ParallelEngine
 func NewParallelEngine() *ParallelEngine {
+	return newParallelEngine(NewEventQueue)
+}
+
+func newParallelEngine(newQueue func() *EventQueueImpl) *ParallelEngine {
 	e := new(ParallelEngine)
 
 	e.eventChan = make(chan Event, 10000)
@@ -46,12 +56,12 @@ func NewParallelEngine() *ParallelEngine {
 	e.secondaryQueues = make([]EventQueue, 0, numQueues)
 	e.secondaryQueueChan = make(chan EventQueue, numQueues)
 	for i := 0; i < numQueues; i++ {
-		queue := NewEventQueue()
+		queue := newQueue()
 		//queue := NewInsertionQueue()
 		e.queueChan <- queue
 		e.queues = append(e.queues, queue)
 
-		secondaryQueue := NewEventQueue()
+		secondaryQueue := newQueue()
 		e.secondaryQueueChan <- secondaryQueue
 		e.secondaryQueues = append(e.secondaryQueues, secondaryQueue)
 	}
@@ -59,6 +69,31 @@ func NewParallelEngine() *ParallelEngine {
 	return e
 }
 
+// NewDeterministicParallelEngine creates a ParallelEngine that runs in the
+// deterministic parallel mode.
+//
+// In this mode, the events that happen at the same time are ordered by the
+// name of the components that handle them and then by the order in which they
+// are scheduled for the component. The events of the same component, as well
+// as the events of the components that are coupled with it, are handled one
+// after another in this order, while the events of other components are
+// handled in parallel. Together with the deterministic ID generator (see
+// UseDeterministicIDGenerator), the simulation produces the same results
+// regardless of how the goroutines are scheduled and how many threads are
+// used.
+//
+// Components should only interact with each other through connections that
+// deliver messages in secondary events, such as the DirectConnection.
+// Components that interact in other ways must be coupled (see Couple).
+func NewDeterministicParallelEngine() *ParallelEngine {
+	e := newParallelEngine(NewOrderedEventQueue)
+	e.deterministic = true
+	e.nextSeq = make(map[string]uint64)
+	e.couplings = make(map[string]string)
+
+	return e
+}
+
 // func (e *ParallelEngine) spawnWorkers() {
 // 	for i := 0; i < e.maxGoRoutine; i++ {
 // 		go e.worker()
@@ -110,6 +145,10 @@ func (e *ParallelEngine) Schedule(evt Event) {
 			reflect.TypeOf(evt), evt.Time(), now)
 	}
 
+	if e.deterministic {
+		evt = e.orderEvent(evt)
+	}
+
 	if evt.IsSecondary() {
 		queue := <-e.secondaryQueueChan
 		queue.Push(evt)
@@ -178,7 +217,13 @@ func (e *ParallelEngine) runRound() {
 	}
 
 	e.emptyQueueChan(queues, queueChan)
-	e.runEventsUntilConflict(queues, queueChan)
+
+	if e.deterministic {
+		e.runEventsInOrder(queues, queueChan)
+	} else {
+		e.runEventsUntilConflict(queues, queueChan)
+	}
+
 	e.waitGroup.Wait()
 }
 
@@ -253,6 +298,11 @@ func (e *ParallelEngine) runEventWithTempWorker(evt Event) {
 }
 
 func (e *ParallelEngine) tempWorkerRun(evt Event) {
+	e.handleEvent(evt)
+	e.waitGroup.Done()
+}
+
+func (e *ParallelEngine) handleEvent(evt Event) {
 	now := e.readNow()
 
 	if evt.Time() < now {
@@ -271,8 +321,6 @@ func (e *ParallelEngine) tempWorkerRun(evt Event) {
 
 	hookCtx.Pos = HookPosAfterEvent
 	e.InvokeHook(hookCtx)
-
-	e.waitGroup.Done()
 }
 
 // Pause will prevent the engine to move forward. For events that is scheduled
@@ -308,3 +356,190 @@ func (e *ParallelEngine) Finished() {
 		h.Handle(now)
 	}
 }
+
+func (e *ParallelEngine) saveEvents(cp *Checkpoint) error {
+	if e.deterministic {
+		primary, secondary := e.unorderedQueues()
+		return saveQueues(cp, primary, secondary)
+	}
+
+	return saveQueues(cp, e.queues, e.secondaryQueues)
+}
+
+func (e *ParallelEngine) loadEvents(cp *Checkpoint) error {
+	e.writeNow(cp.Time())
+
+	if e.deterministic {
+		return e.loadOrderedEvents(cp)
+	}
+
+	return loadQueues(cp, e.queues, e.secondaryQueues)
+}
+
+// An orderedEvent wraps an event that is scheduled to a ParallelEngine in the
+// deterministic parallel mode with the keys that order the same-time events.
+type orderedEvent struct {
+	Event
+	name string
+	seq  uint64
+}
+
+func (e *ParallelEngine) orderEvent(evt Event) *orderedEvent {
+	if ordered, ok := evt.(*orderedEvent); ok {
+		return ordered
+	}
+
+	name := handlerName(evt.Handler())
+
+	e.orderLock.Lock()
+	seq := e.nextSeq[name]
+	e.nextSeq[name] = seq + 1
+	e.orderLock.Unlock()
+
+	return &orderedEvent{Event: evt, name: name, seq: seq}
+}
+
+// Couple makes sure that the events of the components are never handled at
+// the same time in the deterministic parallel mode. It does nothing in the
+// default mode.
+func (e *ParallelEngine) Couple(components ...Named) {
+	if !e.deterministic || len(components) == 0 {
+		return
+	}
+
+	e.orderLock.Lock()
+	defer e.orderLock.Unlock()
+
+	root := e.couplingRoot(components[0].Name())
+	for _, c := range components[1:] {
+		r := e.couplingRoot(c.Name())
+		if r != root {
+			e.couplings[r] = root
+		}
+	}
+}
+
+func (e *ParallelEngine) couplingRoot(name string) string {
+	for {
+		parent, found := e.couplings[name]
+		if !found {
+			return name
+		}
+
+		name = parent
+	}
+}
+
+// runEventsInOrder handles the events at the current time in the
+// deterministic parallel mode. The events are sorted and grouped by the
+// components that handle them. Each group runs in its own goroutine.
+func (e *ParallelEngine) runEventsInOrder(
+	queues []EventQueue,
+	queueChan chan EventQueue,
+) {
+	now := e.readNow()
+
+	var events []*orderedEvent
+	for _, queue := range queues {
+		for queue.Len() > 0 && queue.Peek().Time() == now {
+			events = append(events, queue.Pop().(*orderedEvent))
+		}
+		queueChan <- queue
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].name != events[j].name {
+			return events[i].name < events[j].name
+		}
+
+		return events[i].seq < events[j].seq
+	})
+
+	for _, group := range e.groupEvents(events) {
+		e.waitGroup.Add(1)
+		go e.runEventGroup(group)
+	}
+}
+
+func (e *ParallelEngine) groupEvents(events []*orderedEvent) [][]*orderedEvent {
+	e.orderLock.Lock()
+	defer e.orderLock.Unlock()
+
+	groupIndex := make(map[string]int)
+	groups := make([][]*orderedEvent, 0)
+
+	for _, evt := range events {
+		root := e.couplingRoot(evt.name)
+
+		i, found := groupIndex[root]
+		if !found {
+			i = len(groups)
+			groupIndex[root] = i
+			groups = append(groups, nil)
+		}
+
+		groups[i] = append(groups[i], evt)
+	}
+
+	return groups
+}
+
+func (e *ParallelEngine) runEventGroup(group []*orderedEvent) {
+	for _, evt := range group {
+		e.handleEvent(evt.Event)
+	}
+
+	e.waitGroup.Done()
+}
+
+// unorderedQueues returns copies of the event queues, where the events are
+// not wrapped with the ordering keys.
+func (e *ParallelEngine) unorderedQueues() (primary, secondary []EventQueue) {
+	unwrap := func(queues []EventQueue) []EventQueue {
+		copies := make([]EventQueue, 0, len(queues))
+		for _, q := range queues {
+			impl := q.(*EventQueueImpl)
+			c := NewOrderedEventQueue()
+
+			impl.Lock()
+			events := impl.all()
+			impl.Unlock()
+
+			for i, evt := range events {
+				events[i] = evt.(*orderedEvent).Event
+			}
+			c.reset(events)
+
+			copies = append(copies, c)
+		}
+
+		return copies
+	}
+
+	return unwrap(e.queues), unwrap(e.secondaryQueues)
+}
+
+// loadOrderedEvents restores the events from a checkpoint and wraps them with
+// the ordering keys in the order that they are saved.
+func (e *ParallelEngine) loadOrderedEvents(cp *Checkpoint) error {
+	err := loadQueues(cp, e.queues, e.secondaryQueues)
+	if err != nil {
+		return err
+	}
+
+	for i := range e.queues {
+		for _, q := range []EventQueue{e.queues[i], e.secondaryQueues[i]} {
+			impl := q.(*EventQueueImpl)
+
+			impl.Lock()
+			events := impl.all()
+			for j, evt := range events {
+				events[j] = e.orderEvent(evt)
+			}
+			impl.reset(events)
+			impl.Unlock()
+		}
+	}
+
+	return nil
+}
@@ -2,13 +2,33 @@ package sim
 
 import (
 	"math/rand"
+	"sync"
 	"time"
 
 	gomock "github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/gmeasure"
 )
 
+type namedRecordingHandler struct {
+	name    string
+	lock    *sync.Mutex
+	handled *[]string
+}
+
+func (h *namedRecordingHandler) Name() string {
+	return h.name
+}
+
+func (h *namedRecordingHandler) Handle(e Event) error {
+	h.lock.Lock()
+	*h.handled = append(*h.handled, e.(*EventBase).ID)
+	h.lock.Unlock()
+
+	return nil
+}
+
 var _ = Describe("ParallelEngine", func() {
 	var (
 		mockCtrl *gomock.Controller
@@ -115,3 +135,45 @@ var _ = Describe("ParallelEngine", func() {
 
 	})
 })
+
+var _ = Describe("ParallelEngine in the deterministic mode", func() {
+	var (
+		engine   *ParallelEngine
+		lock     sync.Mutex
+		handled  []string
+		handlerA *namedRecordingHandler
+		handlerB *namedRecordingHandler
+	)
+
+	BeforeEach(func() {
+		engine = NewDeterministicParallelEngine()
+		handled = nil
+		handlerA = &namedRecordingHandler{
+			name: "A", lock: &lock, handled: &handled}
+		handlerB = &namedRecordingHandler{
+			name: "B", lock: &lock, handled: &handled}
+	})
+
+	schedule := func(t VTimeInSec, h Handler, id string, secondary bool) {
+		evt := NewEventBase(t, h)
+		evt.ID = id
+		evt.secondary = secondary
+		engine.Schedule(evt)
+	}
+
+	It("should order same-time events by component and sequence", func() {
+		engine.Couple(handlerA, handlerB)
+
+		schedule(1, handlerB, "B1", false)
+		schedule(1, handlerA, "A1", true)
+		schedule(1, handlerB, "B2", false)
+		schedule(1, handlerA, "A2", false)
+		schedule(0.5, handlerB, "B0", false)
+
+		err := engine.Run()
+
+		Expect(err).To(BeNil())
+		Expect(handled).To(Equal([]string{"B0", "A2", "B1", "B2", "A1"}))
+		Expect(engine.CurrentTime()).To(Equal(VTimeInSec(1)))
+	})
+})
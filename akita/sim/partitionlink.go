@@ -0,0 +1,276 @@
+package sim
+
+import "log"
+
+type partitionLinkEnd struct {
+	port    Port
+	engine  Engine
+	bufSize int
+	other   *partitionLinkEnd
+
+	// The following fields are only accessed by the engine of the end.
+	numInFlight    int
+	busy           bool
+	pending        []Msg
+	retryScheduled bool
+}
+
+// partitionLinkDeliverEvent carries a message to the destination end of a
+// PartitionLink.
+type partitionLinkDeliverEvent struct {
+	*EventBase
+	msg Msg
+	dst *partitionLinkEnd
+}
+
+// partitionLinkAckEvent tells the source end of a PartitionLink that a
+// message has been received, so that the buffer slot of the message can be
+// reused.
+type partitionLinkAckEvent struct {
+	*EventBase
+	src *partitionLinkEnd
+}
+
+// partitionLinkRetryEvent retries to deliver the messages that the
+// destination port could not receive.
+type partitionLinkRetryEvent struct {
+	*EventBase
+	dst *partitionLinkEnd
+}
+
+// A PartitionLink connects two ports with a latency. It is the connection
+// between the components in different partitions of a ConservativeEngine.
+//
+// A message takes the latency of the link to arrive at the destination, and
+// the buffer slot of the message takes the latency again to return to the
+// source after the destination port receives the message. The source side
+// can hold at most a source-side buffer size of messages whose slots have not
+// returned yet. Since a partition cannot affect the other partition earlier
+// than the latency, the link tells the ConservativeEngine its latency as the
+// lookahead between the two partitions.
+//
+// An ideal link, whose latency is 0 cycles, delivers a message half a cycle
+// after the message is sent. The receiver handles the message in the next
+// cycle, which is the same as with a DirectConnection.
+//
+// Each end of the link can be simulated with any engine, so that a simulation
+// with PartitionLinks can also run with a SerialEngine and be compared with
+// the simulation that runs with a ConservativeEngine.
+type PartitionLink struct {
+	*ComponentBase
+
+	latency  VTimeInSec
+	left     Engine
+	right    Engine
+	ends     map[Port]*partitionLinkEnd
+	firstEnd *partitionLinkEnd
+}
+
+// NewPartitionLink creates a PartitionLink that connects a port simulated by
+// the left engine and a port simulated by the right engine. The latency is
+// given in cycles of the frequency.
+func NewPartitionLink(
+	name string,
+	left, right Engine,
+	freq Freq,
+	latency int,
+) *PartitionLink {
+	l := new(PartitionLink)
+	l.ComponentBase = NewComponentBase(name)
+	l.left = left
+	l.right = right
+	l.ends = make(map[Port]*partitionLinkEnd)
+
+	l.latency = VTimeInSec(latency) * freq.Period()
+	if latency == 0 {
+		l.latency = freq.Period() / 2
+	}
+
+	leftPartition, leftIsPartition := left.(*Partition)
+	rightPartition, rightIsPartition := right.(*Partition)
+
+	switch {
+	case leftIsPartition && rightIsPartition:
+		if leftPartition.engine != rightPartition.engine {
+			log.Panic("the partitions must belong to the same engine")
+		}
+
+		leftPartition.engine.addLink(leftPartition, rightPartition, l.latency)
+	case left != right:
+		log.Panic("the ends must use the same engine or two partitions")
+	}
+
+	return l
+}
+
+// Latency returns the time that a message takes to go through the link.
+func (l *PartitionLink) Latency() VTimeInSec {
+	return l.latency
+}
+
+// PlugIn connects a port to the link. The first port that is plugged in is
+// simulated by the left engine and the second port is simulated by the right
+// engine.
+func (l *PartitionLink) PlugIn(port Port, sourceSideBufSize int) {
+	end := &partitionLinkEnd{
+		port:    port,
+		bufSize: sourceSideBufSize,
+	}
+
+	switch len(l.ends) {
+	case 0:
+		end.engine = l.left
+		l.firstEnd = end
+	case 1:
+		end.engine = l.right
+		end.other = l.firstEnd
+		l.firstEnd.other = end
+	default:
+		panic("one partition link can only connect with two ports")
+	}
+
+	l.ends[port] = end
+	port.SetConnection(l)
+}
+
+// Unplug removes the association between the port and the link.
+func (l *PartitionLink) Unplug(_ Port) {
+	panic("not implemented")
+}
+
+// CanSend checks if the link can send a message from the port.
+func (l *PartitionLink) CanSend(src Port) bool {
+	end := l.ends[src]
+
+	canSend := end.numInFlight < end.bufSize
+	if !canSend {
+		end.busy = true
+	}
+
+	return canSend
+}
+
+// Send sends a message to the other end.
+func (l *PartitionLink) Send(msg Msg) *SendError {
+	l.msgMustBeValid(msg)
+
+	src := l.ends[msg.Meta().Src]
+	if src.numInFlight >= src.bufSize {
+		src.busy = true
+		return NewSendError()
+	}
+
+	src.numInFlight++
+
+	evt := partitionLinkDeliverEvent{
+		EventBase: NewEventBase(src.engine.CurrentTime()+l.latency, l),
+		msg:       msg,
+		dst:       src.other,
+	}
+	scheduleAcross(src.engine, src.other.engine, evt)
+
+	return nil
+}
+
+// scheduleAcross schedules an event that the engine of one end creates for
+// the engine of the other end.
+func scheduleAcross(from, to Engine, evt Event) {
+	fromPartition, ok := from.(*Partition)
+	if ok && from != to {
+		fromPartition.scheduleRemote(to.(*Partition), evt)
+		return
+	}
+
+	to.Schedule(evt)
+}
+
+// NotifyAvailable is called by a port to notify that the link can deliver to
+// the port again.
+func (l *PartitionLink) NotifyAvailable(now VTimeInSec, port Port) {
+	end := l.ends[port]
+	if end.retryScheduled {
+		return
+	}
+
+	end.retryScheduled = true
+	evt := partitionLinkRetryEvent{
+		EventBase: NewEventBase(now, l),
+		dst:       end,
+	}
+	evt.secondary = true
+	end.engine.Schedule(evt)
+}
+
+// NotifyRecv does nothing, as no message is sent to the link itself.
+func (l *PartitionLink) NotifyRecv(_ VTimeInSec, _ Port) {
+	// Do nothing
+}
+
+// NotifyPortFree does nothing, as the link does not own ports.
+func (l *PartitionLink) NotifyPortFree(_ VTimeInSec, _ Port) {
+	// Do nothing
+}
+
+// Handle delivers messages and returns buffer slots to the sources.
+func (l *PartitionLink) Handle(e Event) error {
+	now := e.Time()
+
+	switch e := e.(type) {
+	case partitionLinkDeliverEvent:
+		e.dst.pending = append(e.dst.pending, e.msg)
+		l.deliver(now, e.dst)
+	case partitionLinkRetryEvent:
+		e.dst.retryScheduled = false
+		l.deliver(now, e.dst)
+	case partitionLinkAckEvent:
+		e.src.numInFlight--
+		if e.src.busy {
+			e.src.busy = false
+			e.src.port.NotifyAvailable(now)
+		}
+	default:
+		log.Panicf("cannot handle event %T", e)
+	}
+
+	return nil
+}
+
+func (l *PartitionLink) deliver(now VTimeInSec, dst *partitionLinkEnd) {
+	for len(dst.pending) > 0 {
+		msg := dst.pending[0]
+		msg.Meta().RecvTime = now
+
+		err := dst.port.Recv(msg)
+		if err != nil {
+			return
+		}
+
+		dst.pending = dst.pending[1:]
+
+		ack := partitionLinkAckEvent{
+			EventBase: NewEventBase(now+l.latency, l),
+			src:       dst.other,
+		}
+		ack.secondary = true
+		scheduleAcross(dst.engine, dst.other.engine, ack)
+	}
+}
+
+func (l *PartitionLink) msgMustBeValid(msg Msg) {
+	src := msg.Meta().Src
+	dst := msg.Meta().Dst
+
+	if src == nil || dst == nil {
+		panic("src or dst is not given")
+	}
+
+	srcEnd, srcConnected := l.ends[src]
+	_, dstConnected := l.ends[dst]
+	if !srcConnected || !dstConnected {
+		panic("src or dst is not connected")
+	}
+
+	if srcEnd.other == nil || srcEnd.other.port != dst {
+		panic("a partition link can only send to the other end")
+	}
+}
@@ -0,0 +1,126 @@
+package sim
+
+import (
+	gomock "github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PartitionLink", func() {
+	var (
+		mockCtrl *gomock.Controller
+		port1    *MockPort
+		port2    *MockPort
+		engine   *ConservativeEngine
+		link     *PartitionLink
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		port1 = NewMockPort(mockCtrl)
+		port2 = NewMockPort(mockCtrl)
+		engine = NewConservativeEngine()
+		link = NewPartitionLink("Link",
+			engine.Partition(0), engine.Partition(1), 1, 2)
+
+		port1.EXPECT().SetConnection(link)
+		link.PlugIn(port1, 1)
+
+		port2.EXPECT().SetConnection(link)
+		link.PlugIn(port2, 1)
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	It("should panic if the dst is not the other end", func() {
+		msg := &sampleMsg{}
+		msg.Src = port1
+		msg.Dst = port1
+
+		Expect(func() { link.Send(msg) }).To(Panic())
+	})
+
+	It("should deliver the message after the latency", func() {
+		msg := &sampleMsg{}
+		msg.Src = port1
+		msg.Dst = port2
+
+		port2.EXPECT().Recv(msg).Return(nil)
+
+		Expect(link.Send(msg)).To(BeNil())
+		Expect(link.CanSend(port1)).To(BeFalse())
+		Expect(link.Send(msg)).NotTo(BeNil())
+
+		port1.EXPECT().NotifyAvailable(VTimeInSec(4))
+
+		err := engine.Run()
+
+		Expect(err).To(BeNil())
+		Expect(msg.RecvTime).To(Equal(VTimeInSec(2)))
+		Expect(link.CanSend(port1)).To(BeTrue())
+	})
+
+	It("should retry when the dst port is available", func() {
+		msg := &sampleMsg{}
+		msg.Src = port1
+		msg.Dst = port2
+
+		port2.EXPECT().Recv(msg).Return(NewSendError())
+		Expect(link.Send(msg)).To(BeNil())
+		Expect(engine.Run()).To(BeNil())
+		Expect(link.ends[port2].pending).To(ContainElement(msg))
+
+		port2.EXPECT().Recv(msg).Return(nil)
+		link.NotifyAvailable(3, port2)
+		Expect(engine.Run()).To(BeNil())
+
+		Expect(msg.RecvTime).To(Equal(VTimeInSec(3)))
+		Expect(link.ends[port2].pending).To(BeEmpty())
+		Expect(link.CanSend(port1)).To(BeTrue())
+	})
+	It("should derive the lookahead of the partitions from the latency", func() {
+		Expect(link.Latency()).To(Equal(VTimeInSec(2)))
+		Expect(engine.Partition(0).Lookahead()).To(Equal(VTimeInSec(2)))
+		Expect(engine.Partition(1).Lookahead()).To(Equal(VTimeInSec(2)))
+
+		NewPartitionLink("IdealLink",
+			engine.Partition(1), engine.Partition(0), 1, 0)
+
+		Expect(engine.Partition(0).Lookahead()).To(Equal(VTimeInSec(0.5)))
+		Expect(engine.Partition(1).Lookahead()).To(Equal(VTimeInSec(0.5)))
+	})
+
+	It("should connect ports in the same engine", func() {
+		serialEngine := NewOrderedSerialEngine()
+		port3 := NewMockPort(mockCtrl)
+		port4 := NewMockPort(mockCtrl)
+		serialLink := NewPartitionLink("SerialLink",
+			serialEngine, serialEngine, 1, 2)
+
+		port3.EXPECT().SetConnection(serialLink)
+		serialLink.PlugIn(port3, 1)
+		port4.EXPECT().SetConnection(serialLink)
+		serialLink.PlugIn(port4, 1)
+
+		msg := &sampleMsg{}
+		msg.Src = port3
+		msg.Dst = port4
+
+		port4.EXPECT().Recv(msg).Return(nil)
+		port3.EXPECT().NotifyAvailable(VTimeInSec(4))
+
+		Expect(serialLink.Send(msg)).To(BeNil())
+		Expect(serialLink.Send(msg)).NotTo(BeNil())
+		Expect(serialEngine.Run()).To(Succeed())
+		Expect(msg.RecvTime).To(Equal(VTimeInSec(2)))
+	})
+
+	It("should not connect partitions with other engines", func() {
+		Expect(func() {
+			NewPartitionLink("BadLink",
+				engine.Partition(0), NewSerialEngine(), 1, 2)
+		}).To(Panic())
+	})
+})
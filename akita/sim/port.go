@@ -1,6 +1,7 @@
 package sim
 
 import (
+	"fmt"
 	"sync"
 )
 
@@ -34,6 +35,8 @@ type LimitNumMsgPort struct {
 	conn Connection
 
 	buf          Buffer
+	ownsBuf      bool
+	interceptor  RecvInterceptor
 	bufLock      sync.RWMutex
 	portBusy     bool
 	portBusyLock sync.RWMutex
@@ -48,6 +51,26 @@ var HookPosPortMsgRecvd = &HookPos{Name: "Port Msg Recv"}
 // HookPosPortMsgRetrieve marks when an outbound message is sent over a connection
 var HookPosPortMsgRetrieve = &HookPos{Name: "Port Msg Retrieve"}
 
+// A RecvInterceptor can take over the messages that arrive at a port, for
+// example, to inject faults.
+type RecvInterceptor interface {
+	// InterceptRecv is called when the port has room for a message, before
+	// the message is delivered. If it returns false, the message is not
+	// delivered, but the sender considers the message delivered.
+	InterceptRecv(port Port, msg Msg) (deliver bool)
+}
+
+// SetRecvInterceptor sets the interceptor of the messages that arrive at the
+// port. A nil interceptor delivers all the messages.
+func (p *LimitNumMsgPort) SetRecvInterceptor(i RecvInterceptor) {
+	p.interceptor = i
+}
+
+// Connection returns the connection that the port is plugged into.
+func (p *LimitNumMsgPort) Connection() Connection {
+	return p.conn
+}
+
 // SetConnection sets which connection plugged in to this port.
 func (p *LimitNumMsgPort) SetConnection(conn Connection) {
 	p.conn = conn
@@ -96,6 +119,13 @@ func (p *LimitNumMsgPort) Recv(msg Msg) *SendError {
 		return NewSendError()
 	}
 
+	// The interceptor only sees the messages that the port accepts, so that
+	// the sender retrying a rejected message does not apply a fault again.
+	if p.interceptor != nil && !p.interceptor.InterceptRecv(p, msg) {
+		p.bufLock.Unlock()
+		return nil
+	}
+
 	hookCtx := HookCtx{
 		Domain: p,
 		Pos:    HookPosPortMsgRecvd,
@@ -154,6 +184,15 @@ func (p *LimitNumMsgPort) Peek() Msg {
 	return msg
 }
 
+// NumIncoming returns the number of received messages that are waiting to be
+// retrieved.
+func (p *LimitNumMsgPort) NumIncoming() int {
+	p.bufLock.RLock()
+	defer p.bufLock.RUnlock()
+
+	return p.buf.Size()
+}
+
 // NotifyAvailable is called by the connection to notify the port that the
 // connection is available again
 func (p *LimitNumMsgPort) NotifyAvailable(now VTimeInSec) {
@@ -171,6 +210,7 @@ func NewLimitNumMsgPort(
 	p := new(LimitNumMsgPort)
 	p.comp = comp
 	p.buf = NewBuffer(name+".Buf", capacity)
+	p.ownsBuf = true
 	p.name = name
 	return p
 }
@@ -190,3 +230,61 @@ func NewLimitNumMsgPortWithExternalBuffer(
 	p.name = name
 	return p
 }
+
+type limitNumMsgPortState struct {
+	PortBusy bool
+}
+
+// SaveState saves the state of the port. An external buffer is not saved with
+// the port, as it is saved by its owner.
+func (p *LimitNumMsgPort) SaveState(cp *Checkpoint) error {
+	p.portBusyLock.RLock()
+	state := limitNumMsgPortState{PortBusy: p.portBusy}
+	p.portBusyLock.RUnlock()
+
+	err := cp.Put(p.name, state)
+	if err != nil {
+		return err
+	}
+
+	if !p.ownsBuf {
+		return nil
+	}
+
+	buf, ok := p.buf.(Checkpointable)
+	if !ok {
+		return fmt.Errorf("buffer %s cannot be checkpointed", p.buf.Name())
+	}
+
+	p.bufLock.RLock()
+	defer p.bufLock.RUnlock()
+
+	return buf.SaveState(cp)
+}
+
+// LoadState restores the state of the port.
+func (p *LimitNumMsgPort) LoadState(cp *Checkpoint) error {
+	state := limitNumMsgPortState{}
+	err := cp.Get(p.name, &state)
+	if err != nil {
+		return err
+	}
+
+	p.portBusyLock.Lock()
+	p.portBusy = state.PortBusy
+	p.portBusyLock.Unlock()
+
+	if !p.ownsBuf {
+		return nil
+	}
+
+	buf, ok := p.buf.(Checkpointable)
+	if !ok {
+		return fmt.Errorf("buffer %s cannot be checkpointed", p.buf.Name())
+	}
+
+	p.bufLock.Lock()
+	defer p.bufLock.Unlock()
+
+	return buf.LoadState(cp)
+}
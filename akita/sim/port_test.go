@@ -14,6 +14,15 @@ func (m *sampleMsg) Meta() *MsgMeta {
 	return &m.MsgMeta
 }
 
+type dropAllInterceptor struct {
+	numIntercepted int
+}
+
+func (i *dropAllInterceptor) InterceptRecv(_ Port, _ Msg) bool {
+	i.numIntercepted++
+	return false
+}
+
 var _ = Describe("LimitNumMsgPort", func() {
 	var (
 		mockController *gomock.Controller
@@ -90,6 +99,32 @@ var _ = Describe("LimitNumMsgPort", func() {
 		Expect(errRet).NotTo(BeNil())
 	})
 
+	It("should not deliver messages that the interceptor takes", func() {
+		msg := &sampleMsg{}
+		msg.RecvTime = 10
+		interceptor := &dropAllInterceptor{}
+		port.SetRecvInterceptor(interceptor)
+
+		errRet := port.Recv(msg)
+
+		Expect(errRet).To(BeNil())
+		Expect(port.Peek()).To(BeNil())
+		Expect(interceptor.numIntercepted).To(Equal(1))
+	})
+
+	It("should not intercept messages when the buffer is full", func() {
+		msg := &sampleMsg{}
+		port.buf = NewBuffer("Buf", 1)
+		port.buf.Push(msg)
+		interceptor := &dropAllInterceptor{}
+		port.SetRecvInterceptor(interceptor)
+
+		errRet := port.Recv(&sampleMsg{})
+
+		Expect(errRet).NotTo(BeNil())
+		Expect(interceptor.numIntercepted).To(Equal(0))
+	})
+
 	It("should return nil when peeking empty port", func() {
 		msg := port.Peek()
 
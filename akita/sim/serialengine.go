@@ -35,6 +35,20 @@ func NewSerialEngine() *SerialEngine {
 	return e
 }
 
+// NewOrderedSerialEngine creates a SerialEngine that orders the events that
+// happen at the same time by the names of the components that handle them,
+// and then by the order in which they are scheduled. It handles the events in
+// the same order as the ConservativeEngine and the deterministic
+// ParallelEngine, so that their results can be compared.
+func NewOrderedSerialEngine() *SerialEngine {
+	e := NewSerialEngine()
+
+	e.queue = NewOrderedEventQueue()
+	e.secondaryQueue = NewOrderedEventQueue()
+
+	return e
+}
+
 // Schedule register an event to be happen in the future
 func (e *SerialEngine) Schedule(evt Event) {
 	now := e.readNow()
@@ -175,3 +189,15 @@ func (e *SerialEngine) Finished() {
 		h.Handle(now)
 	}
 }
+
+func (e *SerialEngine) saveEvents(cp *Checkpoint) error {
+	return saveQueues(cp,
+		[]EventQueue{e.queue}, []EventQueue{e.secondaryQueue})
+}
+
+func (e *SerialEngine) loadEvents(cp *Checkpoint) error {
+	e.writeNow(cp.Time())
+
+	return loadQueues(cp,
+		[]EventQueue{e.queue}, []EventQueue{e.secondaryQueue})
+}
@@ -13,7 +13,7 @@ type TickEvent struct {
 // MakeTickEvent creates a new TickEvent
 func MakeTickEvent(t VTimeInSec, handler Handler) TickEvent {
 	evt := TickEvent{}
-	evt.ID = GetIDGenerator().Generate()
+	evt.ID = idGeneratorForHandler(handler).Generate()
 	evt.handler = handler
 	evt.time = t
 	evt.secondary = false
@@ -171,3 +171,32 @@ func NewSecondaryTickingComponent(
 	tc.ticker = ticker
 	return tc
 }
+
+type tickSchedulerState struct {
+	NextTickTime VTimeInSec
+}
+
+// SaveStateAs saves the time of the next tick in the checkpoint under the key.
+// Components that tick call this function in their SaveState method.
+func (t *TickScheduler) SaveStateAs(cp *Checkpoint, key string) error {
+	t.lock.Lock()
+	state := tickSchedulerState{NextTickTime: t.nextTickTime}
+	t.lock.Unlock()
+
+	return cp.Put(key, state)
+}
+
+// LoadStateAs restores the time of the next tick from the checkpoint.
+func (t *TickScheduler) LoadStateAs(cp *Checkpoint, key string) error {
+	state := tickSchedulerState{}
+	err := cp.Get(key, &state)
+	if err != nil {
+		return err
+	}
+
+	t.lock.Lock()
+	t.nextTickTime = state.NextTickTime
+	t.lock.Unlock()
+
+	return nil
+}
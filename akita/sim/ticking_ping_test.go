@@ -168,3 +168,44 @@ func Example_pingWithTicking() {
 	// Ping 0, 5.00
 	// Ping 1, 5.00
 }
+
+func Example_pingWithTickingInPartitions() {
+	engine := sim.NewConservativeEngine()
+	agentA := NewTickingPingAgent("AgentA", engine.Partition(0), 1*sim.Hz)
+	agentB := NewTickingPingAgent("AgentB", engine.Partition(1), 1*sim.Hz)
+	conn := sim.NewPartitionLink("Conn",
+		engine.Partition(0), engine.Partition(1), 1*sim.Hz, 0)
+
+	conn.PlugIn(agentA.OutPort, 1)
+	conn.PlugIn(agentB.OutPort, 1)
+
+	agentA.pingDst = agentB.OutPort
+	agentA.numPingNeedToSend = 2
+
+	agentA.TickLater(0)
+
+	engine.Run()
+	// Output:
+	// Ping 0, 5.00
+	// Ping 1, 5.00
+}
+
+func Example_pingWithTickingAndPartitionLink() {
+	engine := sim.NewOrderedSerialEngine()
+	agentA := NewTickingPingAgent("AgentA", engine, 1*sim.Hz)
+	agentB := NewTickingPingAgent("AgentB", engine, 1*sim.Hz)
+	conn := sim.NewPartitionLink("Conn", engine, engine, 1*sim.Hz, 0)
+
+	conn.PlugIn(agentA.OutPort, 1)
+	conn.PlugIn(agentB.OutPort, 1)
+
+	agentA.pingDst = agentB.OutPort
+	agentA.numPingNeedToSend = 2
+
+	agentA.TickLater(0)
+
+	engine.Run()
+	// Output:
+	// Ping 0, 5.00
+	// Ping 1, 5.00
+}
@@ -2,6 +2,7 @@ package tracing
 
 import (
 	"container/list"
+	"errors"
 
 	"github.com/sarchlab/akita/v3/sim"
 )
@@ -44,6 +45,35 @@ func (t *BusyTimeTracer) BusyTime() sim.VTimeInSec {
 	return t.busyTime
 }
 
+type busyTimeTracerState struct {
+	BusyTime sim.VTimeInSec
+}
+
+// SaveStateAs saves the busy time in the checkpoint under the key. The tracer
+// can only be saved when no task is in flight.
+func (t *BusyTimeTracer) SaveStateAs(cp *sim.Checkpoint, key string) error {
+	if t.taskTimes.Len() > 0 {
+		return errors.New("busy time tracer has tasks in flight")
+	}
+
+	return cp.Put(key, busyTimeTracerState{BusyTime: t.busyTime})
+}
+
+// LoadStateAs restores the busy time from the checkpoint.
+func (t *BusyTimeTracer) LoadStateAs(cp *sim.Checkpoint, key string) error {
+	state := busyTimeTracerState{}
+	err := cp.Get(key, &state)
+	if err != nil {
+		return err
+	}
+
+	t.busyTime = state.BusyTime
+	t.inflightTasks = make(map[string]*list.Element)
+	t.taskTimes.Init()
+
+	return nil
+}
+
 // TerminateAllTasks will mark all the tasks as completed.
 func (t *BusyTimeTracer) TerminateAllTasks(now sim.VTimeInSec) {
 	for e := t.taskTimes.Front(); e != nil; e = e.Next() {
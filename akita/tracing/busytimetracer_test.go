@@ -1,6 +1,7 @@
 package tracing
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/sarchlab/akita/v3/sim"
@@ -11,6 +12,22 @@ import (
 	"github.com/onsi/gomega/gmeasure"
 )
 
+type busyTimeTracerHolder struct {
+	tracer *BusyTimeTracer
+}
+
+func (h *busyTimeTracerHolder) Name() string {
+	return "Holder"
+}
+
+func (h *busyTimeTracerHolder) SaveState(cp *sim.Checkpoint) error {
+	return h.tracer.SaveStateAs(cp, "Holder.BusyTime")
+}
+
+func (h *busyTimeTracerHolder) LoadState(cp *sim.Checkpoint) error {
+	return h.tracer.LoadStateAs(cp, "Holder.BusyTime")
+}
+
 var _ = Describe("BusyTimeTracer", func() {
 	var (
 		mockCtrl   *gomock.Controller
@@ -119,6 +136,35 @@ var _ = Describe("BusyTimeTracer", func() {
 		Expect(t.BusyTime()).To(BeNumerically("~", 2.5, 0.01))
 	})
 
+	It("should restore the busy time from a checkpoint", func() {
+		timeTeller.EXPECT().CurrentTime().Return(sim.VTimeInSec(1))
+		t.StartTask(Task{ID: "1"})
+		timeTeller.EXPECT().CurrentTime().Return(sim.VTimeInSec(2))
+		t.EndTask(Task{ID: "1"})
+
+		checkpointer := sim.NewCheckpointer(sim.NewSerialEngine())
+		checkpointer.Register(&busyTimeTracerHolder{tracer: t})
+		buf := bytes.NewBuffer(nil)
+		Expect(checkpointer.Save(buf)).To(Succeed())
+
+		timeTeller.EXPECT().CurrentTime().Return(sim.VTimeInSec(3))
+		t.StartTask(Task{ID: "2"})
+		Expect(checkpointer.Restore(buf)).To(Succeed())
+
+		timeTeller.EXPECT().CurrentTime().Return(sim.VTimeInSec(4))
+		t.EndTask(Task{ID: "2"})
+		Expect(t.BusyTime()).To(Equal(sim.VTimeInSec(1.0)))
+	})
+
+	It("should not save a checkpoint with tasks in flight", func() {
+		timeTeller.EXPECT().CurrentTime().Return(sim.VTimeInSec(1))
+		t.StartTask(Task{ID: "1"})
+
+		checkpointer := sim.NewCheckpointer(sim.NewSerialEngine())
+		checkpointer.Register(&busyTimeTracerHolder{tracer: t})
+		Expect(checkpointer.Save(bytes.NewBuffer(nil))).NotTo(Succeed())
+	})
+
 	It("measure busy time tracer", func() {
 		experiment := gmeasure.NewExperiment("Busy Time Tracer Performance")
 		AddReportEntry(experiment.Name, experiment)
@@ -0,0 +1,331 @@
+package tracing
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/rs/xid"
+)
+
+// ChromeTraceWriter is a task tracer backend that writes the tasks as Chrome
+// JSON trace events, which can be opened with Perfetto
+// (https://ui.perfetto.dev) or chrome://tracing.
+//
+// Each component (the Where field of a task) becomes a process in the trace.
+// The tasks of a component are placed on the threads (lanes) of the process
+// so that the tasks on a lane either nest or do not overlap. Parent and child
+// tasks are connected with flow events and the task steps become instant
+// events.
+//
+// The writer assumes that the tasks are written in the order of their end
+// time, which is the order that the DBTracer writes tasks. Otherwise, the
+// tasks are still written, but they may take more lanes.
+type ChromeTraceWriter struct {
+	path   string
+	file   *os.File
+	writer *bufio.Writer
+
+	numEvents    int
+	processes    map[string]*chromeProcess
+	nextFlowID   uint64
+	pendingFlows map[string][]chromeTaskLocation
+}
+
+const chromeTraceTrailer = "\n]}\n"
+
+type chromeProcess struct {
+	pid   int
+	lanes []*chromeLane
+}
+
+// A chromeLane keeps the outermost tasks that are already placed on the
+// lane, ordered by their end time.
+type chromeLane struct {
+	outerTasks []chromeInterval
+}
+
+type chromeInterval struct {
+	start, end float64
+}
+
+type chromeTaskLocation struct {
+	pid, tid int
+	start    float64
+}
+
+type chromeEvent struct {
+	Name  string         `json:"name,omitempty"`
+	Cat   string         `json:"cat,omitempty"`
+	Ph    string         `json:"ph"`
+	Ts    float64        `json:"ts"`
+	Dur   *float64       `json:"dur,omitempty"`
+	Pid   int            `json:"pid"`
+	Tid   int            `json:"tid"`
+	ID    *uint64        `json:"id,omitempty"`
+	Bp    string         `json:"bp,omitempty"`
+	Scope string         `json:"s,omitempty"`
+	Args  map[string]any `json:"args,omitempty"`
+}
+
+// NewChromeTraceWriter creates a new ChromeTraceWriter.
+func NewChromeTraceWriter(path string) *ChromeTraceWriter {
+	return &ChromeTraceWriter{
+		path:         path,
+		processes:    make(map[string]*chromeProcess),
+		pendingFlows: make(map[string][]chromeTaskLocation),
+	}
+}
+
+// Init creates the trace file. If the file already exists, it will panic.
+func (t *ChromeTraceWriter) Init() {
+	if t.path == "" {
+		t.path = "akita_trace_" + xid.New().String()
+	}
+
+	filename := t.path + ".json"
+	_, err := os.Stat(filename)
+	if err == nil {
+		panic(fmt.Errorf("file %s already exists", filename))
+	}
+
+	file, err := os.Create(filename)
+	if err != nil {
+		panic(err)
+	}
+
+	t.file = file
+	t.writer = bufio.NewWriter(file)
+
+	fmt.Fprint(t.writer, "{\"displayTimeUnit\":\"ns\",\"traceEvents\":[\n")
+}
+
+// Write writes a task to the trace file.
+func (t *ChromeTraceWriter) Write(task Task) {
+	start := secondToMicrosecond(float64(task.StartTime))
+	end := secondToMicrosecond(float64(task.EndTime))
+	dur := end - start
+
+	process := t.process(task.Where)
+	tid := t.placeTask(process, start, end)
+	location := chromeTaskLocation{pid: process.pid, tid: tid, start: start}
+
+	t.writeEvent(chromeEvent{
+		Name: task.What,
+		Cat:  task.Kind,
+		Ph:   "X",
+		Ts:   start,
+		Dur:  &dur,
+		Pid:  process.pid,
+		Tid:  tid,
+		Args: map[string]any{"id": task.ID, "parent_id": task.ParentID},
+	})
+
+	for _, step := range task.Steps {
+		t.writeEvent(chromeEvent{
+			Name:  step.What,
+			Cat:   task.Kind,
+			Ph:    "i",
+			Ts:    secondToMicrosecond(float64(step.Time)),
+			Pid:   process.pid,
+			Tid:   tid,
+			Scope: "t",
+			Args:  map[string]any{"task_id": task.ID},
+		})
+	}
+
+	t.connectWithChildren(task, location)
+}
+
+func secondToMicrosecond(t float64) float64 {
+	return t * 1e6
+}
+
+func (t *ChromeTraceWriter) process(where string) *chromeProcess {
+	process, found := t.processes[where]
+	if found {
+		return process
+	}
+
+	process = &chromeProcess{pid: len(t.processes) + 1}
+	t.processes[where] = process
+
+	t.writeEvent(chromeEvent{
+		Name: "process_name",
+		Ph:   "M",
+		Pid:  process.pid,
+		Args: map[string]any{"name": where},
+	})
+
+	return process
+}
+
+// placeTask finds the first lane that the task can be placed on and returns
+// the thread ID of the lane. A task can be placed on a lane if it does not
+// partially overlap with any task on the lane.
+func (t *ChromeTraceWriter) placeTask(
+	process *chromeProcess,
+	start, end float64,
+) int {
+	for i, lane := range process.lanes {
+		if lane.tryPlace(start, end) {
+			return i
+		}
+	}
+
+	lane := &chromeLane{}
+	lane.tryPlace(start, end)
+	process.lanes = append(process.lanes, lane)
+	tid := len(process.lanes) - 1
+
+	t.writeEvent(chromeEvent{
+		Name: "thread_name",
+		Ph:   "M",
+		Pid:  process.pid,
+		Tid:  tid,
+		Args: map[string]any{"name": fmt.Sprintf("lane %d", tid)},
+	})
+
+	return tid
+}
+
+func (l *chromeLane) tryPlace(start, end float64) bool {
+	n := len(l.outerTasks)
+
+	// The tasks that start after the new task ends can only be there if the
+	// tasks are not written in the order of their end time.
+	if n > 0 && l.outerTasks[n-1].end > end {
+		return false
+	}
+
+	// The outer tasks that start within the new task become its children.
+	i := n
+	for i > 0 && l.outerTasks[i-1].start >= start {
+		i--
+	}
+
+	if i > 0 && l.outerTasks[i-1].end > start {
+		return false
+	}
+
+	l.outerTasks = append(l.outerTasks[:i], chromeInterval{start, end})
+
+	return true
+}
+
+// connectWithChildren draws the flows from the task to its children. Since
+// a child usually ends before its parent, the children wait for the parent
+// to be written. The flows of the children that end after the parent are
+// not drawn.
+func (t *ChromeTraceWriter) connectWithChildren(
+	task Task,
+	location chromeTaskLocation,
+) {
+	if task.ParentID != "" {
+		t.pendingFlows[task.ParentID] = append(
+			t.pendingFlows[task.ParentID], location)
+	}
+
+	for _, child := range t.pendingFlows[task.ID] {
+		t.writeFlow(location, child)
+	}
+
+	delete(t.pendingFlows, task.ID)
+}
+
+// writeFlow connects a parent task with a child task with an arrow that
+// starts at the parent at the time when the child starts.
+func (t *ChromeTraceWriter) writeFlow(parent, child chromeTaskLocation) {
+	t.nextFlowID++
+	id := t.nextFlowID
+
+	ts := child.start
+	if ts < parent.start {
+		ts = parent.start
+	}
+
+	t.writeEvent(chromeEvent{
+		Name: "subtask",
+		Cat:  "flow",
+		Ph:   "s",
+		Ts:   ts,
+		Pid:  parent.pid,
+		Tid:  parent.tid,
+		ID:   &id,
+	})
+
+	t.writeEvent(chromeEvent{
+		Name: "subtask",
+		Cat:  "flow",
+		Ph:   "f",
+		Bp:   "e",
+		Ts:   child.start,
+		Pid:  child.pid,
+		Tid:  child.tid,
+		ID:   &id,
+	})
+}
+
+func (t *ChromeTraceWriter) writeEvent(evt chromeEvent) {
+	bytes, err := json.Marshal(evt)
+	if err != nil {
+		panic(err)
+	}
+
+	if t.numEvents > 0 {
+		_, err = t.writer.WriteString(",\n")
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	_, err = t.writer.Write(bytes)
+	if err != nil {
+		panic(err)
+	}
+
+	t.numEvents++
+}
+
+// Flush writes the buffered events to the trace file. After flushing, the
+// file is a complete JSON document. The events that are written later
+// overwrite the end of the document.
+//
+// The flush marks the end of the trace, so the children that still wait for
+// their parents are dropped. Their parents either end after the trace or are
+// not traced at all.
+func (t *ChromeTraceWriter) Flush() {
+	t.pendingFlows = make(map[string][]chromeTaskLocation)
+
+	_, err := t.writer.WriteString(chromeTraceTrailer)
+	if err != nil {
+		panic(err)
+	}
+
+	err = t.writer.Flush()
+	if err != nil {
+		panic(err)
+	}
+
+	_, err = t.file.Seek(-int64(len(chromeTraceTrailer)), io.SeekCurrent)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// ConvertSQLiteTraceToChrome converts a trace in a SQLite database to a
+// Chrome JSON trace file. The extension name of the output file is not
+// required.
+func ConvertSQLiteTraceToChrome(sqliteFile, chromeFile string) {
+	reader := NewSQLiteTraceReader(sqliteFile)
+	reader.Init()
+
+	writer := NewChromeTraceWriter(chromeFile)
+	writer.Init()
+
+	reader.ForEachTaskByEndTime(writer.Write)
+
+	writer.Flush()
+}
@@ -0,0 +1,164 @@
+package tracing
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ChromeTraceWriter", func() {
+	var (
+		dir    string
+		writer *ChromeTraceWriter
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "akita_chrome_trace")
+		Expect(err).To(BeNil())
+
+		writer = NewChromeTraceWriter(filepath.Join(dir, "trace"))
+		writer.Init()
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	readEvents := func() []map[string]any {
+		bytes, err := os.ReadFile(filepath.Join(dir, "trace.json"))
+		Expect(err).To(BeNil())
+
+		doc := struct {
+			TraceEvents []map[string]any `json:"traceEvents"`
+		}{}
+		Expect(json.Unmarshal(bytes, &doc)).To(Succeed())
+
+		return doc.TraceEvents
+	}
+
+	eventsWithPhase := func(events []map[string]any, ph string) []map[string]any {
+		selected := []map[string]any{}
+		for _, e := range events {
+			if e["ph"] == ph {
+				selected = append(selected, e)
+			}
+		}
+
+		return selected
+	}
+
+	It("should write tasks as complete events on component tracks", func() {
+		writer.Write(Task{
+			ID: "2", ParentID: "1", Kind: "req_in", What: "read", Where: "L2",
+			StartTime: 2e-9, EndTime: 4e-9,
+			Steps: []TaskStep{{Time: 3e-9, What: "hit"}},
+		})
+		writer.Write(Task{
+			ID: "1", Kind: "req_out", What: "read", Where: "L1",
+			StartTime: 1e-9, EndTime: 5e-9,
+		})
+		writer.Flush()
+
+		events := readEvents()
+
+		processNames := []any{}
+		for _, e := range eventsWithPhase(events, "M") {
+			if e["name"] == "process_name" {
+				processNames = append(processNames,
+					e["args"].(map[string]any)["name"])
+			}
+		}
+		Expect(processNames).To(ConsistOf("L2", "L1"))
+
+		slices := eventsWithPhase(events, "X")
+		Expect(slices).To(HaveLen(2))
+		Expect(slices[0]["name"]).To(Equal("read"))
+		Expect(slices[0]["cat"]).To(Equal("req_in"))
+		Expect(slices[0]["ts"]).To(BeNumerically("~", 0.002, 1e-9))
+		Expect(slices[0]["dur"]).To(BeNumerically("~", 0.002, 1e-9))
+
+		instants := eventsWithPhase(events, "i")
+		Expect(instants).To(HaveLen(1))
+		Expect(instants[0]["name"]).To(Equal("hit"))
+		Expect(instants[0]["ts"]).To(BeNumerically("~", 0.003, 1e-9))
+
+		flowStarts := eventsWithPhase(events, "s")
+		flowEnds := eventsWithPhase(events, "f")
+		Expect(flowStarts).To(HaveLen(1))
+		Expect(flowEnds).To(HaveLen(1))
+		Expect(flowStarts[0]["pid"]).To(Equal(slices[1]["pid"]))
+		Expect(flowEnds[0]["pid"]).To(Equal(slices[0]["pid"]))
+		Expect(flowStarts[0]["id"]).To(Equal(flowEnds[0]["id"]))
+	})
+
+	It("should place overlapping tasks on different lanes", func() {
+		writer.Write(Task{ID: "1", Kind: "k", What: "a", Where: "C",
+			StartTime: 1, EndTime: 3})
+		writer.Write(Task{ID: "2", Kind: "k", What: "b", Where: "C",
+			StartTime: 2, EndTime: 4})
+		writer.Write(Task{ID: "3", Kind: "k", What: "c", Where: "C",
+			StartTime: 0, EndTime: 5})
+		writer.Flush()
+
+		slices := eventsWithPhase(readEvents(), "X")
+		Expect(slices[0]["tid"]).To(BeNumerically("==", 0))
+		Expect(slices[1]["tid"]).To(BeNumerically("==", 1))
+		Expect(slices[2]["tid"]).To(BeNumerically("==", 0))
+	})
+
+	It("should keep the file valid after more tasks are flushed", func() {
+		writer.Write(Task{ID: "1", Kind: "k", What: "a", Where: "C",
+			StartTime: 1, EndTime: 3})
+		writer.Flush()
+		writer.Write(Task{ID: "2", Kind: "k", What: "b", Where: "C",
+			StartTime: 3, EndTime: 4})
+		writer.Flush()
+
+		Expect(eventsWithPhase(readEvents(), "X")).To(HaveLen(2))
+	})
+
+	It("should drop the children whose parents are not written", func() {
+		writer.Write(Task{ID: "2", ParentID: "1", Kind: "k", What: "a",
+			Where: "C", StartTime: 1, EndTime: 3})
+		writer.Flush()
+
+		Expect(writer.pendingFlows).To(BeEmpty())
+		Expect(eventsWithPhase(readEvents(), "s")).To(BeEmpty())
+	})
+
+	It("should convert the steps in SQLite traces", func() {
+		sqliteWriter := NewSQLiteTraceWriter(filepath.Join(dir, "db"))
+		sqliteWriter.Init()
+		sqliteWriter.Write(Task{
+			ID: "2", ParentID: "1", Kind: "req_in", What: "read", Where: "L2",
+			StartTime: 2e-9, EndTime: 4e-9,
+			Steps: []TaskStep{
+				{Time: 3e-9, What: "hit"},
+				{Time: 3.5e-9, What: "read_data"},
+			},
+		})
+		sqliteWriter.Write(Task{
+			ID: "1", Kind: "req_out", What: "read", Where: "L1",
+			StartTime: 1e-9, EndTime: 5e-9,
+		})
+		sqliteWriter.Flush()
+		Expect(sqliteWriter.Close()).To(Succeed())
+
+		Expect(os.Remove(filepath.Join(dir, "trace.json"))).To(Succeed())
+		ConvertSQLiteTraceToChrome(
+			filepath.Join(dir, "db.sqlite3"), filepath.Join(dir, "trace"))
+
+		events := readEvents()
+		Expect(eventsWithPhase(events, "X")).To(HaveLen(2))
+		Expect(eventsWithPhase(events, "s")).To(HaveLen(1))
+
+		instants := eventsWithPhase(events, "i")
+		Expect(instants).To(HaveLen(2))
+		Expect(instants[0]["name"]).To(Equal("hit"))
+		Expect(instants[1]["name"]).To(Equal("read_data"))
+	})
+})
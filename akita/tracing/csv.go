@@ -101,23 +101,22 @@ func NewCSVTraceReader(path string) *CSVTraceReader {
 	return r
 }
 
-// ListComponents queries the components that have tasks.
-func (r *CSVTraceReader) ListComponents() []string {
-	f, err := os.Open(r.path)
+// ForEachTask calls the given function with every task in the CSV file.
+func (r *CSVTraceReader) ForEachTask(f func(task Task)) {
+	file, err := os.Open(r.path)
 	if err != nil {
 		panic(err)
 	}
 	defer func() {
-		err := f.Close()
+		err := file.Close()
 		if err != nil {
 			panic(err)
 		}
 	}()
 
-	reader := csv.NewReader(f)
+	reader := csv.NewReader(file)
 	r.skipCSVHeader(reader)
 
-	components := make(map[string]bool)
 	for {
 		record, err := reader.Read()
 		if err == io.EOF {
@@ -128,10 +127,16 @@ func (r *CSVTraceReader) ListComponents() []string {
 			panic(err)
 		}
 
-		task := r.parseCSVRecord(record)
+		f(r.parseCSVRecord(record))
+	}
+}
 
+// ListComponents queries the components that have tasks.
+func (r *CSVTraceReader) ListComponents() []string {
+	components := make(map[string]bool)
+	r.ForEachTask(func(task Task) {
 		components[task.Where] = true
-	}
+	})
 
 	componentSlice := make([]string, 0, len(components))
 	for k := range components {
@@ -145,36 +150,10 @@ func (r *CSVTraceReader) ListComponents() []string {
 
 // ListTasks queries tasks .
 func (r *CSVTraceReader) ListTasks(query TaskQuery) []Task {
-	f, err := os.Open(r.path)
-	if err != nil {
-		panic(err)
-	}
-
-	defer func() {
-		err := f.Close()
-		if err != nil {
-			panic(err)
-		}
-	}()
-
-	reader := csv.NewReader(f)
-	r.skipCSVHeader(reader)
-
 	tasks := make([]Task, 0)
-	for {
-		record, err := reader.Read()
-		if err == io.EOF {
-			break
-		}
-
-		if err != nil {
-			panic(err)
-		}
-
-		task := r.parseCSVRecord(record)
-
+	r.ForEachTask(func(task Task) {
 		if !r.keepTask(task, query) {
-			continue
+			return
 		}
 
 		if query.EnableParentTask {
@@ -188,7 +167,7 @@ func (r *CSVTraceReader) ListTasks(query TaskQuery) []Task {
 		}
 
 		tasks = append(tasks, task)
-	}
+	})
 
 	return tasks
 }
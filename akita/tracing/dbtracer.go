@@ -1,6 +1,8 @@
 package tracing
 
 import (
+	"sync"
+
 	"github.com/sarchlab/akita/v3/sim"
 
 	"github.com/tebeka/atexit"
@@ -19,7 +21,9 @@ type TracerBackend interface {
 // DBTracer is a tracer that can store tasks into a database.
 // DBTracers can connect with different backends so that the tasks can be stored
 // in different types of databases (e.g., CSV files, SQL databases, etc.)
+// A DBTracer can be shared by components that run in parallel.
 type DBTracer struct {
+	lock       sync.Mutex
 	timeTeller sim.TimeTeller
 	backend    TracerBackend
 
@@ -32,6 +36,9 @@ type DBTracer struct {
 func (t *DBTracer) StartTask(task Task) {
 	t.startingTaskMustBeValid(task)
 
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
 	task.StartTime = t.timeTeller.CurrentTime()
 	if t.endTime > 0 && task.StartTime > t.endTime {
 		return
@@ -58,13 +65,30 @@ func (t *DBTracer) startingTaskMustBeValid(task Task) {
 	}
 }
 
-// StepTask marks a step of a task.
-func (t *DBTracer) StepTask(_ Task) {
-	// Do nothing for now.
+// StepTask marks a step of a task. The steps are kept with the task and are
+// written together with the task.
+func (t *DBTracer) StepTask(task Task) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	originalTask, ok := t.tracingTasks[task.ID]
+	if !ok {
+		return
+	}
+
+	for _, step := range task.Steps {
+		step.Time = t.timeTeller.CurrentTime()
+		originalTask.Steps = append(originalTask.Steps, step)
+	}
+
+	t.tracingTasks[task.ID] = originalTask
 }
 
 // EndTask marks the end of a task.
 func (t *DBTracer) EndTask(task Task) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
 	task.EndTime = t.timeTeller.CurrentTime()
 
 	if t.startTime > 0 && task.EndTime < t.startTime {
@@ -85,6 +109,9 @@ func (t *DBTracer) EndTask(task Task) {
 
 // Terminate terminates the tracer.
 func (t *DBTracer) Terminate() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
 	for _, task := range t.tracingTasks {
 		task.EndTime = t.timeTeller.CurrentTime()
 		t.backend.Write(task)
@@ -173,11 +173,46 @@ func (r *MySQLTraceReader) Init() {
 	r.dbConnection.init(r.dbName)
 }
 
+// ForEachTask calls the given function with every task in the database.
+func (r *MySQLTraceReader) ForEachTask(f func(task Task)) {
+	rows, err := r.Query(`
+		SELECT task_id, parent_id, kind, what, location, start_time, end_time
+		FROM trace
+	`)
+	if err != nil {
+		panic(err)
+	}
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			panic(err)
+		}
+	}()
+
+	for rows.Next() {
+		t := Task{}
+		err := rows.Scan(
+			&t.ID,
+			&t.ParentID,
+			&t.Kind,
+			&t.What,
+			&t.Where,
+			&t.StartTime,
+			&t.EndTime,
+		)
+		if err != nil {
+			panic(err)
+		}
+
+		f(t)
+	}
+}
+
 // ListComponents returns a list of components in the trace.
 func (r *MySQLTraceReader) ListComponents() []string {
 	var components []string
 
-	rows, err := r.Query("SELECT DISTINCT location FROM tasks")
+	rows, err := r.Query("SELECT DISTINCT location FROM trace")
 	if err != nil {
 		panic(err)
 	}
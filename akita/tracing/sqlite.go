@@ -9,13 +9,15 @@ import (
 	_ "github.com/mattn/go-sqlite3"
 
 	"github.com/rs/xid"
+	"github.com/sarchlab/akita/v3/sim"
 	"github.com/tebeka/atexit"
 )
 
 // SQLiteTraceWriter is a writer that writes trace data to a SQLite database.
 type SQLiteTraceWriter struct {
 	*sql.DB
-	statement *sql.Stmt
+	statement     *sql.Stmt
+	stepStatement *sql.Stmt
 
 	dbName           string
 	tasksToWriteToDB []Task
@@ -72,6 +74,13 @@ func (t *SQLiteTraceWriter) Flush() {
 			fmt.Println(task)
 			panic(err)
 		}
+
+		for _, step := range task.Steps {
+			_, err := t.stepStatement.Exec(task.ID, step.Time, step.What)
+			if err != nil {
+				panic(err)
+			}
+		}
 	}
 
 	t.tasksToWriteToDB = nil
@@ -148,6 +157,20 @@ func (t *SQLiteTraceWriter) createTable() {
 		create index trace_parent_id_index
 			on trace (parent_id);
 	`)
+
+	t.mustExecute(`
+		create table step
+		(
+			task_id varchar(200) null,
+			time    float        null,
+			what    varchar(100) null
+		);
+	`)
+
+	t.mustExecute(`
+		create index step_task_id_index
+			on step (task_id);
+	`)
 }
 
 func (t *SQLiteTraceWriter) prepareStatement() {
@@ -159,6 +182,13 @@ func (t *SQLiteTraceWriter) prepareStatement() {
 	}
 
 	t.statement = stmt
+
+	stmt, err = t.Prepare(`INSERT INTO step VALUES (?, ?, ?)`)
+	if err != nil {
+		panic(err)
+	}
+
+	t.stepStatement = stmt
 }
 
 func (t *SQLiteTraceWriter) mustExecute(query string) sql.Result {
@@ -196,6 +226,104 @@ func (r *SQLiteTraceReader) Init() {
 	r.DB = db
 }
 
+// ForEachTask calls the given function with every task in the database. The
+// steps of the tasks are included.
+func (r *SQLiteTraceReader) ForEachTask(f func(task Task)) {
+	r.forEachTask("t.rowid", f)
+}
+
+// ForEachTaskByEndTime calls the given function with every task in the
+// database in the order of the end time. If two tasks end at the same time,
+// the task that starts later is visited first. The steps of the tasks are
+// included.
+func (r *SQLiteTraceReader) ForEachTaskByEndTime(f func(task Task)) {
+	r.forEachTask("t.end_time, t.start_time DESC, t.rowid", f)
+}
+
+// forEachTask visits the tasks joined with their steps. As a task has a row
+// for each step, the rows of the same task are merged before the task is
+// visited.
+func (r *SQLiteTraceReader) forEachTask(orderBy string, f func(task Task)) {
+	stepTable := "(SELECT NULL AS task_id, NULL AS time, NULL AS what)"
+	if r.hasStepTable() {
+		stepTable = "step"
+	}
+
+	rows, err := r.Query(`
+		SELECT t.task_id, t.parent_id, t.kind, t.what, t.location,
+			t.start_time, t.end_time, s.time, s.what
+		FROM trace t
+		LEFT JOIN ` + stepTable + ` s ON s.task_id = t.task_id
+		ORDER BY ` + orderBy + `, s.time`)
+	if err != nil {
+		panic(err)
+	}
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			panic(err)
+		}
+	}()
+
+	var task *Task
+
+	for rows.Next() {
+		t := Task{}
+		stepTime := sql.NullFloat64{}
+		stepWhat := sql.NullString{}
+		err := rows.Scan(
+			&t.ID,
+			&t.ParentID,
+			&t.Kind,
+			&t.What,
+			&t.Where,
+			&t.StartTime,
+			&t.EndTime,
+			&stepTime,
+			&stepWhat,
+		)
+		if err != nil {
+			panic(err)
+		}
+
+		if task != nil && task.ID != t.ID {
+			f(*task)
+			task = nil
+		}
+
+		if task == nil {
+			task = &t
+		}
+
+		if stepTime.Valid {
+			task.Steps = append(task.Steps, TaskStep{
+				Time: sim.VTimeInSec(stepTime.Float64),
+				What: stepWhat.String,
+			})
+		}
+	}
+
+	if task != nil {
+		f(*task)
+	}
+}
+
+// hasStepTable checks if the database has the step table, which is not
+// created by the older versions of the SQLiteTraceWriter.
+func (r *SQLiteTraceReader) hasStepTable() bool {
+	var n int
+
+	err := r.QueryRow(`
+		SELECT COUNT(*) FROM sqlite_master
+		WHERE type = 'table' AND name = 'step'
+	`).Scan(&n)
+	if err != nil {
+		panic(err)
+	}
+
+	return n > 0
+}
+
 // ListComponents returns a list of components in the trace.
 func (r *SQLiteTraceReader) ListComponents() []string {
 	var components []string
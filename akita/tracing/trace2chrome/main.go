@@ -0,0 +1,41 @@
+// Trace2chrome converts a SQLite trace to a Chrome JSON trace that can be
+// opened with Perfetto (https://ui.perfetto.dev) or chrome://tracing.
+//
+// Usage:
+//
+//	trace2chrome -sqlite akita_trace_xxx.sqlite3 -out akita_trace_xxx
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/sarchlab/akita/v3/tracing"
+)
+
+var (
+	sqliteFileName = flag.String("sqlite", "",
+		"Name of the SQLite file to read from.")
+	outFileName = flag.String("out", "",
+		"Name of the JSON file to write to, without the extension name. "+
+			"By default, the name of the SQLite file is used.")
+)
+
+func main() {
+	flag.Parse()
+
+	if *sqliteFileName == "" {
+		flag.PrintDefaults()
+		os.Exit(1)
+	}
+
+	out := *outFileName
+	if out == "" {
+		out = *sqliteFileName
+	}
+
+	tracing.ConvertSQLiteTraceToChrome(*sqliteFileName, out)
+
+	fmt.Printf("Trace is written to %s.json\n", out)
+}
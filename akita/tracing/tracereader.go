@@ -33,3 +33,10 @@ type TraceReader interface {
 	// ListTasks queries tasks .
 	ListTasks(query TaskQuery) []Task
 }
+
+// TaskIterator can visit all the tasks in a trace one by one, without loading
+// the whole trace into memory.
+type TaskIterator interface {
+	// ForEachTask calls the given function with every task in the trace.
+	ForEachTask(f func(task Task))
+}
@@ -0,0 +1,694 @@
+package tracing
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/mattn/go-sqlite3"
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+const traceStoreDriverName = "sqlite3_akita_trace_store"
+
+var registerTraceStoreDriverOnce sync.Once
+
+// registerTraceStoreDriver registers a SQLite driver that supports the REGEXP
+// operator.
+func registerTraceStoreDriver() {
+	registerTraceStoreDriverOnce.Do(func() {
+		var regexps sync.Map
+
+		sql.Register(traceStoreDriverName, &sqlite3.SQLiteDriver{
+			ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+				return conn.RegisterFunc("regexp",
+					func(pattern, s string) (bool, error) {
+						re, found := regexps.Load(pattern)
+						if !found {
+							compiled, err := regexp.Compile(pattern)
+							if err != nil {
+								return false, err
+							}
+
+							re, _ = regexps.LoadOrStore(pattern, compiled)
+						}
+
+						return re.(*regexp.Regexp).MatchString(s), nil
+					}, true)
+			},
+		})
+	})
+}
+
+// TraceStoreQuery extends the TaskQuery with the filters and the pagination
+// that are only supported by the TraceStore. Empty fields are ignored.
+type TraceStoreQuery struct {
+	TaskQuery
+
+	// Use WherePrefix to select the tasks that are executed at the locations
+	// that start with the prefix.
+	WherePrefix string
+
+	// Use WhereRegex to select the tasks that are executed at the locations
+	// that match the regular expression.
+	WhereRegex string
+
+	// Use What to select the tasks that have the given What field.
+	What string
+
+	// Enable task depth selection. The depth of a task without a parent is 0.
+	EnableDepthRange bool
+
+	// Use MinDepth and MaxDepth to select the tasks with a depth in the
+	// range, inclusive.
+	MinDepth, MaxDepth int
+
+	// Offset skips the given number of tasks. The tasks are ordered by
+	// their start time when Offset or Limit is set.
+	Offset int
+
+	// Limit is the maximum number of tasks to return. Value 0 means no limit.
+	Limit int
+}
+
+// TaskAggregationQuery defines the time bins that the tasks are aggregated
+// into. Only the tasks that match the filters are counted. The time range of
+// the embedded query is ignored.
+type TaskAggregationQuery struct {
+	TraceStoreQuery
+
+	// StartTime and EndTime define the time range to aggregate.
+	StartTime, EndTime float64
+
+	// NumBins is the number of equal-sized time bins in the time range.
+	NumBins int
+}
+
+// TaskBin is the aggregated statistics of the tasks at a location in a time
+// bin.
+type TaskBin struct {
+	Where     string  `json:"where"`
+	StartTime float64 `json:"start_time"`
+	EndTime   float64 `json:"end_time"`
+
+	// NumStarted is the number of tasks that start in the bin.
+	NumStarted int `json:"num_started"`
+
+	// NumCompleted is the number of tasks that end in the bin.
+	NumCompleted int `json:"num_completed"`
+
+	// AvgLatency is the average duration of the tasks that end in the bin.
+	AvgLatency float64 `json:"avg_latency"`
+}
+
+// TraceStore is a SQLite database that indexes the tasks for fast queries.
+// Unlike the trace readers, it keeps the depth of each task and a table of
+// the locations, and it can aggregate the tasks without sending them to the
+// caller.
+type TraceStore struct {
+	*sql.DB
+
+	filename string
+}
+
+// NewTraceStore creates a new TraceStore that is stored in the given file.
+// The Init function must be called before using the store.
+func NewTraceStore(filename string) *TraceStore {
+	s := &TraceStore{
+		filename: filename,
+	}
+
+	return s
+}
+
+// Init opens the database file. If the file does not exist, an empty store
+// is created.
+func (s *TraceStore) Init() {
+	registerTraceStoreDriver()
+
+	db, err := sql.Open(traceStoreDriverName, s.filename)
+	if err != nil {
+		panic(err)
+	}
+
+	s.DB = db
+
+	s.mustExecute(`
+		CREATE TABLE IF NOT EXISTS trace
+		(
+			task_id    TEXT,
+			parent_id  TEXT,
+			kind       TEXT,
+			what       TEXT,
+			location   TEXT,
+			start_time REAL,
+			end_time   REAL,
+			depth      INTEGER
+		);
+	`)
+
+	s.mustExecute(`
+		CREATE TABLE IF NOT EXISTS location
+		(
+			name TEXT PRIMARY KEY
+		);
+	`)
+
+	s.mustExecute(`
+		CREATE TABLE IF NOT EXISTS source
+		(
+			stamp TEXT
+		);
+	`)
+}
+
+// SetSourceStamp records a stamp that identifies the version of the trace
+// that the store is built from, so that users of the store can tell if the
+// trace has changed since.
+func (s *TraceStore) SetSourceStamp(stamp string) {
+	s.mustExecute("DELETE FROM source")
+	s.mustExecute("INSERT INTO source (stamp) VALUES (?)", stamp)
+}
+
+// SourceStamp returns the stamp recorded with SetSourceStamp. An empty string
+// is returned if no stamp is recorded.
+func (s *TraceStore) SourceStamp() string {
+	var stamp string
+
+	err := s.QueryRow("SELECT stamp FROM source").Scan(&stamp)
+	if err == sql.ErrNoRows {
+		return ""
+	}
+
+	if err != nil {
+		panic(err)
+	}
+
+	return stamp
+}
+
+// ConvertToTraceStore copies all the tasks from a trace to a new TraceStore
+// that is stored in the given file.
+func ConvertToTraceStore(src TaskIterator, filename string) *TraceStore {
+	_, err := os.Stat(filename)
+	if err == nil {
+		panic(fmt.Errorf("file %s already exists", filename))
+	}
+
+	s := NewTraceStore(filename)
+	s.Init()
+	s.Import(src)
+
+	return s
+}
+
+// Import adds all the tasks from a trace to the store and updates the
+// indexes.
+func (s *TraceStore) Import(src TaskIterator) {
+	const batchSize = 100000
+
+	tx := s.mustBegin()
+	stmt := s.mustPrepareInsert(tx)
+	numTasksInTx := 0
+
+	src.ForEachTask(func(task Task) {
+		_, err := stmt.Exec(
+			task.ID,
+			task.ParentID,
+			task.Kind,
+			task.What,
+			task.Where,
+			float64(task.StartTime),
+			float64(task.EndTime),
+		)
+		if err != nil {
+			panic(err)
+		}
+
+		numTasksInTx++
+		if numTasksInTx >= batchSize {
+			s.mustCommit(tx)
+			tx = s.mustBegin()
+			stmt = s.mustPrepareInsert(tx)
+			numTasksInTx = 0
+		}
+	})
+
+	s.mustCommit(tx)
+
+	s.createIndexes()
+	s.calculateDepth()
+	s.updateLocations()
+}
+
+func (s *TraceStore) mustBegin() *sql.Tx {
+	tx, err := s.Begin()
+	if err != nil {
+		panic(err)
+	}
+
+	return tx
+}
+
+func (s *TraceStore) mustCommit(tx *sql.Tx) {
+	err := tx.Commit()
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (s *TraceStore) mustPrepareInsert(tx *sql.Tx) *sql.Stmt {
+	stmt, err := tx.Prepare(`
+		INSERT INTO trace
+		(task_id, parent_id, kind, what, location, start_time, end_time)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`)
+	if err != nil {
+		panic(err)
+	}
+
+	return stmt
+}
+
+func (s *TraceStore) createIndexes() {
+	indexes := map[string]string{
+		"trace_task_id_index":             "task_id",
+		"trace_parent_id_index":           "parent_id",
+		"trace_kind_index":                "kind",
+		"trace_what_index":                "what",
+		"trace_depth_index":               "depth",
+		"trace_start_time_index":          "start_time",
+		"trace_end_time_index":            "end_time",
+		"trace_location_start_time_index": "location, start_time",
+		"trace_location_end_time_index":   "location, end_time",
+	}
+
+	names := make([]string, 0, len(indexes))
+	for name := range indexes {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		s.mustExecute(fmt.Sprintf(
+			"CREATE INDEX IF NOT EXISTS %s ON trace (%s)",
+			name, indexes[name]))
+	}
+}
+
+// calculateDepth sets the depth of the tasks level by level, starting from
+// the tasks whose parents are not in the trace.
+func (s *TraceStore) calculateDepth() {
+	s.mustExecute(`
+		UPDATE trace SET depth = 0
+		WHERE depth IS NULL
+			AND (parent_id = ''
+				OR parent_id NOT IN (SELECT task_id FROM trace))
+	`)
+
+	var maxDepth int
+	err := s.QueryRow("SELECT COALESCE(MAX(depth), 0) FROM trace").
+		Scan(&maxDepth)
+	if err != nil {
+		panic(err)
+	}
+
+	for depth := 0; ; depth++ {
+		res := s.mustExecute(`
+			UPDATE trace SET depth = ?
+			WHERE depth IS NULL
+				AND parent_id IN (SELECT task_id FROM trace WHERE depth = ?)
+		`, depth+1, depth)
+
+		numRows, err := res.RowsAffected()
+		if err != nil {
+			panic(err)
+		}
+
+		// The tasks that are imported later may have parents at any
+		// existing depth.
+		if numRows == 0 && depth >= maxDepth {
+			break
+		}
+	}
+}
+
+func (s *TraceStore) updateLocations() {
+	s.mustExecute(`
+		INSERT OR IGNORE INTO location (name)
+		SELECT DISTINCT location FROM trace
+	`)
+}
+
+func (s *TraceStore) mustExecute(query string, args ...any) sql.Result {
+	res, err := s.Exec(query, args...)
+	if err != nil {
+		fmt.Printf("Failed to execute: %s\n", query)
+		panic(err)
+	}
+
+	return res
+}
+
+// ForEachTask calls the given function with every task in the store.
+func (s *TraceStore) ForEachTask(f func(task Task)) {
+	s.queryTasks(TraceStoreQuery{}, f)
+}
+
+// ListComponents returns the locations of the tasks in the store.
+func (s *TraceStore) ListComponents() []string {
+	components := []string{}
+
+	rows, err := s.Query("SELECT name FROM location ORDER BY name")
+	if err != nil {
+		panic(err)
+	}
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			panic(err)
+		}
+	}()
+
+	for rows.Next() {
+		var component string
+		err := rows.Scan(&component)
+		if err != nil {
+			panic(err)
+		}
+		components = append(components, component)
+	}
+
+	return components
+}
+
+// ListTasks returns the tasks that match the query.
+func (s *TraceStore) ListTasks(query TaskQuery) []Task {
+	return s.QueryTasks(TraceStoreQuery{TaskQuery: query})
+}
+
+// QueryTasks returns the tasks that match the query.
+func (s *TraceStore) QueryTasks(query TraceStoreQuery) []Task {
+	tasks := []Task{}
+	s.queryTasks(query, func(t Task) {
+		tasks = append(tasks, t)
+	})
+
+	return tasks
+}
+
+func (s *TraceStore) queryTasks(query TraceStoreQuery, f func(t Task)) {
+	sqlStr, args := s.prepareTaskQueryStr(query)
+
+	rows, err := s.Query(sqlStr, args...)
+	if err != nil {
+		panic(err)
+	}
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			panic(err)
+		}
+	}()
+
+	for rows.Next() {
+		f(s.scanTask(rows, query.EnableParentTask))
+	}
+}
+
+func (s *TraceStore) scanTask(rows *sql.Rows, withParent bool) Task {
+	t := Task{}
+	fields := []any{
+		&t.ID, &t.ParentID, &t.Kind, &t.What, &t.Where,
+		&t.StartTime, &t.EndTime,
+	}
+
+	var ptID, ptParentID, ptKind, ptWhat, ptWhere sql.NullString
+	var ptStartTime, ptEndTime sql.NullFloat64
+	if withParent {
+		fields = append(fields,
+			&ptID, &ptParentID, &ptKind, &ptWhat, &ptWhere,
+			&ptStartTime, &ptEndTime)
+	}
+
+	err := rows.Scan(fields...)
+	if err != nil {
+		panic(err)
+	}
+
+	if withParent && ptID.Valid {
+		t.ParentTask = &Task{
+			ID:        ptID.String,
+			ParentID:  ptParentID.String,
+			Kind:      ptKind.String,
+			What:      ptWhat.String,
+			Where:     ptWhere.String,
+			StartTime: sim.VTimeInSec(ptStartTime.Float64),
+			EndTime:   sim.VTimeInSec(ptEndTime.Float64),
+		}
+	}
+
+	return t
+}
+
+func (s *TraceStore) prepareTaskQueryStr(
+	query TraceStoreQuery,
+) (string, []any) {
+	sqlStr := `
+		SELECT
+			t.task_id,
+			t.parent_id,
+			t.kind,
+			t.what,
+			t.location,
+			t.start_time,
+			t.end_time
+	`
+
+	if query.EnableParentTask {
+		sqlStr += `,
+			pt.task_id,
+			pt.parent_id,
+			pt.kind,
+			pt.what,
+			pt.location,
+			pt.start_time,
+			pt.end_time
+		`
+	}
+
+	sqlStr += `
+		FROM trace t
+	`
+
+	if query.EnableParentTask {
+		sqlStr += `
+			LEFT JOIN trace pt
+			ON t.parent_id = pt.task_id
+		`
+	}
+
+	conditions, args := s.queryConditions(query)
+	sqlStr += conditions
+
+	if query.Offset > 0 || query.Limit > 0 {
+		limit := query.Limit
+		if limit == 0 {
+			limit = -1
+		}
+
+		sqlStr += `
+			ORDER BY t.start_time, t.task_id
+			LIMIT ? OFFSET ?
+		`
+		args = append(args, limit, query.Offset)
+	}
+
+	return sqlStr, args
+}
+
+func (*TraceStore) queryConditions(query TraceStoreQuery) (string, []any) {
+	var conditions []string
+	var args []any
+
+	addCondition := func(condition string, conditionArgs ...any) {
+		conditions = append(conditions, condition)
+		args = append(args, conditionArgs...)
+	}
+
+	if query.ID != "" {
+		addCondition("t.task_id = ?", query.ID)
+	}
+
+	if query.ParentID != "" {
+		addCondition("t.parent_id = ?", query.ParentID)
+	}
+
+	if query.Kind != "" {
+		addCondition("t.kind = ?", query.Kind)
+	}
+
+	if query.What != "" {
+		addCondition("t.what = ?", query.What)
+	}
+
+	if query.Where != "" {
+		addCondition("t.location = ?", query.Where)
+	}
+
+	if query.WherePrefix != "" {
+		// 0xff never appears in UTF-8 strings, so all the strings with the
+		// prefix are smaller than the upper bound.
+		addCondition("t.location >= ? AND t.location < ?",
+			query.WherePrefix, query.WherePrefix+"\xff")
+	}
+
+	if query.WhereRegex != "" {
+		addCondition("t.location REGEXP ?", query.WhereRegex)
+	}
+
+	if query.EnableDepthRange {
+		addCondition("t.depth BETWEEN ? AND ?", query.MinDepth, query.MaxDepth)
+	}
+
+	if query.EnableTimeRange {
+		addCondition("t.end_time > ? AND t.start_time < ?",
+			query.StartTime, query.EndTime)
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// AggregateTasks counts the tasks that start and end in each time bin and
+// calculates the average latency of the tasks that end in each bin. The
+// aggregation is grouped by location. The bins of a location are returned
+// in time order, and the locations are sorted by name. Only the locations
+// that have tasks in the time range are returned.
+func (s *TraceStore) AggregateTasks(query TaskAggregationQuery) []TaskBin {
+	if query.NumBins <= 0 || query.EndTime <= query.StartTime {
+		panic("invalid aggregation time range")
+	}
+
+	filter := query.TraceStoreQuery
+	filter.EnableTimeRange = false
+	filter.EnableParentTask = false
+	filter.Offset = 0
+	filter.Limit = 0
+
+	bins := make(map[string][]TaskBin)
+
+	s.aggregateByTime(query, filter, bins, "start_time",
+		func(bin *TaskBin, count int, _ float64) {
+			bin.NumStarted += count
+		})
+
+	s.aggregateByTime(query, filter, bins, "end_time",
+		func(bin *TaskBin, count int, avgLatency float64) {
+			totalLatency := bin.AvgLatency*float64(bin.NumCompleted) +
+				avgLatency*float64(count)
+			bin.NumCompleted += count
+			bin.AvgLatency = totalLatency / float64(bin.NumCompleted)
+		})
+
+	result := []TaskBin{}
+	locations := make([]string, 0, len(bins))
+	for location := range bins {
+		locations = append(locations, location)
+	}
+
+	sort.Strings(locations)
+
+	for _, location := range locations {
+		result = append(result, bins[location]...)
+	}
+
+	return result
+}
+
+func (s *TraceStore) aggregateByTime(
+	query TaskAggregationQuery,
+	filter TraceStoreQuery,
+	bins map[string][]TaskBin,
+	timeColumn string,
+	update func(bin *TaskBin, count int, avgLatency float64),
+) {
+	conditions, args := s.queryConditions(filter)
+	if conditions == "" {
+		conditions = " WHERE 1=1"
+	}
+
+	binDuration := (query.EndTime - query.StartTime) / float64(query.NumBins)
+
+	sqlStr := fmt.Sprintf(`
+		SELECT
+			t.location,
+			CAST((t.%[1]s - ?) / ? AS INTEGER) AS bin,
+			COUNT(*),
+			AVG(t.end_time - t.start_time)
+		FROM trace t
+		%[2]s AND t.%[1]s >= ? AND t.%[1]s < ?
+		GROUP BY t.location, bin
+	`, timeColumn, conditions)
+
+	args = append([]any{query.StartTime, binDuration}, args...)
+	args = append(args, query.StartTime, query.EndTime)
+
+	rows, err := s.Query(sqlStr, args...)
+	if err != nil {
+		panic(err)
+	}
+	defer func() {
+		err := rows.Close()
+		if err != nil {
+			panic(err)
+		}
+	}()
+
+	for rows.Next() {
+		var location string
+		var binIndex, count int
+		var avgLatency float64
+
+		err := rows.Scan(&location, &binIndex, &count, &avgLatency)
+		if err != nil {
+			panic(err)
+		}
+
+		// Rounding errors may put a task at the end of the time range into a
+		// bin after the last one.
+		binIndex = int(math.Min(float64(binIndex), float64(query.NumBins-1)))
+
+		locationBins, found := bins[location]
+		if !found {
+			locationBins = emptyTaskBins(query, location)
+			bins[location] = locationBins
+		}
+
+		update(&locationBins[binIndex], count, avgLatency)
+	}
+}
+
+func emptyTaskBins(query TaskAggregationQuery, location string) []TaskBin {
+	bins := make([]TaskBin, query.NumBins)
+	binDuration := (query.EndTime - query.StartTime) / float64(query.NumBins)
+
+	for i := range bins {
+		bins[i] = TaskBin{
+			Where:     location,
+			StartTime: query.StartTime + float64(i)*binDuration,
+			EndTime:   query.StartTime + float64(i+1)*binDuration,
+		}
+	}
+
+	return bins
+}
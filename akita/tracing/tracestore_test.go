@@ -0,0 +1,156 @@
+package tracing
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TraceStore", func() {
+	var (
+		dir   string
+		store *TraceStore
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "akita_trace_store")
+		Expect(err).To(BeNil())
+
+		csvFile := filepath.Join(dir, "trace.csv")
+		err = os.WriteFile(csvFile, []byte(
+			"ID, ParentID, Kind, What, Where, Start, End\n"+
+				"1, , kernel, k, Driver, 0, 10\n"+
+				"2, 1, req_in, read, GPU1.L2[0], 1, 3\n"+
+				"3, 2, req_in, write, GPU1.L2[1], 2, 6\n"+
+				"4, 2, req_out, read, GPU2.L2[0], 5, 6\n"),
+			0644)
+		Expect(err).To(BeNil())
+
+		store = ConvertToTraceStore(
+			NewCSVTraceReader(csvFile),
+			filepath.Join(dir, "trace.sqlite3"))
+	})
+
+	AfterEach(func() {
+		Expect(store.Close()).To(Succeed())
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	taskIDs := func(tasks []Task) []string {
+		ids := []string{}
+		for _, t := range tasks {
+			ids = append(ids, t.ID)
+		}
+
+		return ids
+	}
+
+	It("should list components", func() {
+		Expect(store.ListComponents()).To(Equal([]string{
+			"Driver", "GPU1.L2[0]", "GPU1.L2[1]", "GPU2.L2[0]",
+		}))
+	})
+
+	It("should list tasks with the basic query", func() {
+		tasks := store.ListTasks(TaskQuery{
+			Kind:             "req_in",
+			EnableTimeRange:  true,
+			StartTime:        4,
+			EndTime:          8,
+			EnableParentTask: true,
+		})
+
+		Expect(taskIDs(tasks)).To(Equal([]string{"3"}))
+		Expect(tasks[0].ParentTask.ID).To(Equal("2"))
+		Expect(float64(tasks[0].ParentTask.EndTime)).To(Equal(3.0))
+	})
+
+	It("should filter by location prefix", func() {
+		tasks := store.QueryTasks(TraceStoreQuery{WherePrefix: "GPU1."})
+
+		Expect(taskIDs(tasks)).To(ConsistOf("2", "3"))
+	})
+
+	It("should filter by location regular expression", func() {
+		tasks := store.QueryTasks(TraceStoreQuery{WhereRegex: `L2\[0\]$`})
+
+		Expect(taskIDs(tasks)).To(ConsistOf("2", "4"))
+	})
+
+	It("should filter by what", func() {
+		tasks := store.QueryTasks(TraceStoreQuery{What: "read"})
+
+		Expect(taskIDs(tasks)).To(ConsistOf("2", "4"))
+	})
+
+	It("should filter by depth", func() {
+		tasks := store.QueryTasks(TraceStoreQuery{
+			EnableDepthRange: true,
+			MinDepth:         1,
+			MaxDepth:         1,
+		})
+		Expect(taskIDs(tasks)).To(ConsistOf("2"))
+
+		tasks = store.QueryTasks(TraceStoreQuery{
+			EnableDepthRange: true,
+			MinDepth:         2,
+			MaxDepth:         5,
+		})
+		Expect(taskIDs(tasks)).To(ConsistOf("3", "4"))
+	})
+
+	It("should paginate", func() {
+		tasks := store.QueryTasks(TraceStoreQuery{Offset: 1, Limit: 2})
+		Expect(taskIDs(tasks)).To(Equal([]string{"2", "3"}))
+
+		tasks = store.QueryTasks(TraceStoreQuery{Offset: 3})
+		Expect(taskIDs(tasks)).To(Equal([]string{"4"}))
+	})
+
+	It("should aggregate tasks by location and time", func() {
+		bins := store.AggregateTasks(TaskAggregationQuery{
+			TraceStoreQuery: TraceStoreQuery{
+				TaskQuery: TaskQuery{Kind: "req_in"},
+			},
+			StartTime: 0,
+			EndTime:   8,
+			NumBins:   4,
+		})
+
+		Expect(bins).To(HaveLen(8))
+
+		Expect(bins[0]).To(Equal(TaskBin{
+			Where: "GPU1.L2[0]", StartTime: 0, EndTime: 2, NumStarted: 1,
+		}))
+		Expect(bins[1]).To(Equal(TaskBin{
+			Where: "GPU1.L2[0]", StartTime: 2, EndTime: 4,
+			NumCompleted: 1, AvgLatency: 2,
+		}))
+		Expect(bins[5].NumStarted).To(Equal(1))
+		Expect(bins[7].NumCompleted).To(Equal(1))
+		Expect(bins[7].AvgLatency).To(Equal(4.0))
+	})
+
+	It("should read back all the tasks", func() {
+		n := 0
+		store.ForEachTask(func(task Task) { n++ })
+
+		Expect(n).To(Equal(4))
+	})
+
+	It("should keep the source stamp after reopening", func() {
+		Expect(store.SourceStamp()).To(Equal(""))
+
+		store.SetSourceStamp("100:1")
+		store.SetSourceStamp("200:2")
+		Expect(store.Close()).To(Succeed())
+
+		store = NewTraceStore(filepath.Join(dir, "trace.sqlite3"))
+		store.Init()
+
+		Expect(store.SourceStamp()).To(Equal("200:2"))
+	})
+})
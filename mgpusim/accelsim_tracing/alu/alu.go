@@ -1,16 +1,28 @@
 package alu
 
-import "github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
-
+// ALU describes a type of the functional units.
 type ALU interface {
 	withParent(aluGroup *ALUGroup) ALU
-	Execute(inst nvidia.Instruction)
+
+	// Latency returns the number of cycles from the issue of an instruction
+	// to the time that its results can be used.
+	Latency() int
 }
 
 func (a *ALUGroup) newALU() ALU {
 	switch a.meta.aluType {
 	case "int32":
 		return newInt32ALU().withParent(a)
+	case "fp32":
+		return newFP32ALU().withParent(a)
+	case "fp64":
+		return newFP64ALU().withParent(a)
+	case "sfu":
+		return newSFUALU().withParent(a)
+	case "ldst":
+		return newLDSTALU().withParent(a)
+	case "tensor":
+		return newTensorALU().withParent(a)
 	default:
 		panic("Unknown ALU type")
 	}
@@ -2,14 +2,22 @@ package alu
 
 import "github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
 
+// ALUGroup is a group of the lanes of the same type of functional units. A
+// warp instruction occupies the group for as many cycles as it takes the lanes
+// to process all the threads of the warp.
 type ALUGroup struct {
 	meta *aluGroupMetaData
-	alus []ALU
+	alu  ALU
+
+	nextIssueCycle uint64
 }
 
 type aluGroupMetaData struct {
 	aluType string
 	aluNum  int32
+	latency int
+
+	initiationInterval int
 }
 
 func NewALUGroup() *ALUGroup {
@@ -17,6 +25,7 @@ func NewALUGroup() *ALUGroup {
 		meta: &aluGroupMetaData{
 			aluType: "undefined",
 			aluNum:  0,
+			latency: 0,
 		},
 	}
 }
@@ -31,15 +40,66 @@ func (a *ALUGroup) WithALUNum(num int32) *ALUGroup {
 	return a
 }
 
+// WithLatency overrides the default latency of the type of the ALUs.
+func (a *ALUGroup) WithLatency(cycles int) *ALUGroup {
+	a.meta.latency = cycles
+	return a
+}
+
+// WithInitiationInterval sets the number of cycles between two instructions
+// that the group accepts. If not set, the interval is derived from the number
+// of the ALUs.
+func (a *ALUGroup) WithInitiationInterval(cycles int) *ALUGroup {
+	a.meta.initiationInterval = cycles
+	return a
+}
+
 func (a *ALUGroup) Build() {
-	a.alus = make([]ALU, a.meta.aluNum)
-	for i := range a.alus {
-		a.alus[i] = a.newALU()
+	if a.meta.aluNum <= 0 {
+		panic("ALU number must be positive")
+	}
+
+	a.alu = a.newALU()
+	if a.meta.latency == 0 {
+		a.meta.latency = a.alu.Latency()
+	}
+}
+
+// Type returns the type of the ALUs in the group.
+func (a *ALUGroup) Type() string {
+	return a.meta.aluType
+}
+
+// IssueInterval returns the number of cycles that a warp instruction occupies
+// the group.
+func (a *ALUGroup) IssueInterval() uint64 {
+	if a.meta.initiationInterval > 0 {
+		return uint64(a.meta.initiationInterval)
 	}
+
+	lanes := int(a.meta.aluNum)
+	return uint64((nvidia.WarpSize + lanes - 1) / lanes)
 }
 
-func (a *ALUGroup) Execute(inst nvidia.Instruction) {
-	for _, alu := range a.alus {
-		alu.Execute(inst)
+// CanIssue checks if the group can accept an instruction in the given cycle.
+func (a *ALUGroup) CanIssue(cycle uint64) bool {
+	return cycle >= a.nextIssueCycle
+}
+
+// NextIssueCycle returns the first cycle that the group can accept another
+// instruction.
+func (a *ALUGroup) NextIssueCycle() uint64 {
+	return a.nextIssueCycle
+}
+
+// Issue starts the execution of an instruction in the given cycle and returns
+// the cycle that the results of the instruction are ready.
+func (a *ALUGroup) Issue(cycle uint64) uint64 {
+	if !a.CanIssue(cycle) {
+		panic("ALU group is busy")
 	}
+
+	a.nextIssueCycle = cycle + a.IssueInterval()
+
+	return cycle + uint64(a.meta.latency)
 }
@@ -0,0 +1,18 @@
+package alu
+
+type fp32ALU struct {
+	parent *ALUGroup
+}
+
+func newFP32ALU() *fp32ALU {
+	return &fp32ALU{}
+}
+
+func (a *fp32ALU) withParent(aluGroup *ALUGroup) ALU {
+	a.parent = aluGroup
+	return a
+}
+
+func (a *fp32ALU) Latency() int {
+	return 4
+}
@@ -0,0 +1,18 @@
+package alu
+
+type fp64ALU struct {
+	parent *ALUGroup
+}
+
+func newFP64ALU() *fp64ALU {
+	return &fp64ALU{}
+}
+
+func (a *fp64ALU) withParent(aluGroup *ALUGroup) ALU {
+	a.parent = aluGroup
+	return a
+}
+
+func (a *fp64ALU) Latency() int {
+	return 8
+}
@@ -1,7 +1,5 @@
 package alu
 
-import "github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
-
 type int32ALU struct {
 	parent *ALUGroup
 }
@@ -15,5 +13,6 @@ func (a *int32ALU) withParent(aluGroup *ALUGroup) ALU {
 	return a
 }
 
-func (a *int32ALU) Execute(inst nvidia.Instruction) {
+func (a *int32ALU) Latency() int {
+	return 4
 }
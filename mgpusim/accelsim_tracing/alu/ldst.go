@@ -0,0 +1,18 @@
+package alu
+
+type ldstALU struct {
+	parent *ALUGroup
+}
+
+func newLDSTALU() *ldstALU {
+	return &ldstALU{}
+}
+
+func (a *ldstALU) withParent(aluGroup *ALUGroup) ALU {
+	a.parent = aluGroup
+	return a
+}
+
+func (a *ldstALU) Latency() int {
+	return 20
+}
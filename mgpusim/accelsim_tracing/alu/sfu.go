@@ -0,0 +1,18 @@
+package alu
+
+type sfuALU struct {
+	parent *ALUGroup
+}
+
+func newSFUALU() *sfuALU {
+	return &sfuALU{}
+}
+
+func (a *sfuALU) withParent(aluGroup *ALUGroup) ALU {
+	a.parent = aluGroup
+	return a
+}
+
+func (a *sfuALU) Latency() int {
+	return 21
+}
@@ -0,0 +1,18 @@
+package alu
+
+type tensorALU struct {
+	parent *ALUGroup
+}
+
+func newTensorALU() *tensorALU {
+	return &tensorALU{}
+}
+
+func (a *tensorALU) withParent(aluGroup *ALUGroup) ALU {
+	a.parent = aluGroup
+	return a
+}
+
+func (a *tensorALU) Latency() int {
+	return 8
+}
@@ -43,3 +43,8 @@ func (bm *BenchMark) Exec(gpu *gpu.GPU) error {
 	err := bm.trace.Exec(gpu)
 	return err
 }
+
+// KernelStats returns the results of the kernels that have been executed.
+func (bm *BenchMark) KernelStats() []trace.KernelStats {
+	return bm.trace.KernelStats()
+}
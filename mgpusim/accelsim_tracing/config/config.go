@@ -0,0 +1,362 @@
+package config
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/gpu"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
+)
+
+// Config holds the GPU parameters read from Accel-Sim config files, such as
+// gpgpusim.config and trace.config. The options that the model does not use
+// are ignored.
+type Config struct {
+	filePaths []string
+
+	gpcNum       int32
+	smNumPerGPC  int32
+	smUnitNum    int32
+	scheduler    string
+	freqInMHz    float64
+	maxTBNumInSM int32
+	maxThreads   int32
+	registerNum  int32
+
+	l1CacheSize      int32
+	l2CacheSize      int32
+	sharedMemSize    int32
+	l1Latency        int32
+	l2Latency        int32
+	sharedMemLatency int32
+	memChannelNum    int32
+	l2NumPerChannel  int32
+
+	units map[string]*unitConfig
+}
+
+// unitConfig describes a class of functional units. The numbers of units are
+// counted per SM, as Accel-Sim does.
+type unitConfig struct {
+	aluType            string
+	numPerSM           int32
+	latency            int32
+	initiationInterval int32
+}
+
+// NewConfig creates a Config with the default parameters.
+func NewConfig() *Config {
+	c := &Config{
+		gpcNum:       8,
+		smNumPerGPC:  16,
+		smUnitNum:    4,
+		scheduler:    "gto",
+		freqInMHz:    1000,
+		maxTBNumInSM: 32,
+		maxThreads:   2048,
+		registerNum:  65536,
+
+		l1CacheSize:      128 * 1024,
+		l2CacheSize:      256 * 1024,
+		sharedMemSize:    96 * 1024,
+		l1Latency:        20,
+		l2Latency:        100,
+		sharedMemLatency: 20,
+		memChannelNum:    8,
+		l2NumPerChannel:  2,
+
+		units: make(map[string]*unitConfig),
+	}
+
+	c.addUnit("int", "int32", 4, 4, 2)
+	c.addUnit("sp", "fp32", 4, 4, 2)
+	c.addUnit("dp", "fp64", 4, 8, 4)
+	c.addUnit("sfu", "sfu", 4, 21, 8)
+	c.addUnit("tensor", "tensor", 4, 8, 4)
+	c.addUnit("mem", "ldst", 4, 20, 1)
+
+	return c
+}
+
+func (c *Config) addUnit(
+	name, aluType string,
+	numPerSM, latency, initiationInterval int32,
+) {
+	c.units[name] = &unitConfig{
+		aluType:            aluType,
+		numPerSM:           numPerSM,
+		latency:            latency,
+		initiationInterval: initiationInterval,
+	}
+}
+
+// WithFilePath adds a config file. The files are read in the order that they
+// are added, and the later files override the earlier ones.
+func (c *Config) WithFilePath(path string) *Config {
+	c.filePaths = append(c.filePaths, path)
+	return c
+}
+
+// Build reads the config files.
+func (c *Config) Build() error {
+	if len(c.filePaths) == 0 {
+		return errors.New("no config file specified")
+	}
+
+	for _, path := range c.filePaths {
+		err := c.parseFile(path)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Config) parseFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		optionLineNum := lineNum
+
+		line := stripComment(scanner.Text())
+		elems := strings.Fields(line)
+		if len(elems) == 0 {
+			continue
+		}
+
+		if len(elems) < 2 || !strings.HasPrefix(elems[0], "-") {
+			return fmt.Errorf("%s:%d: invalid option: %s",
+				path, lineNum, scanner.Text())
+		}
+
+		value := elems[1]
+		if strings.HasPrefix(value, "\"") {
+			value, err = readQuotedValue(scanner, line, &lineNum)
+			if err != nil {
+				return fmt.Errorf("%s:%d: %w", path, optionLineNum, err)
+			}
+		}
+
+		err = c.updateParam(elems[0][1:], value)
+		if err != nil {
+			return fmt.Errorf("%s:%d: %w", path, optionLineNum, err)
+		}
+	}
+
+	return scanner.Err()
+}
+
+func stripComment(line string) string {
+	if i := strings.Index(line, "#"); i >= 0 {
+		return line[:i]
+	}
+
+	return line
+}
+
+// readQuotedValue returns the value of an option that is given in quotes.
+// The value can span multiple lines, such as
+//
+//	-gpgpu_dram_timing_opt "nbk=16:CCD=2:RRD=6:RCD=12:RAS=28:RP=12:RC=40:
+//	                        CL=12:WL=4:CDLR=5:WR=12:nbkgrp=4:CCDL=3:RTPL=2"
+//
+// The lines are joined after removing the surrounding spaces.
+func readQuotedValue(
+	scanner *bufio.Scanner,
+	line string,
+	lineNum *int,
+) (string, error) {
+	value := strings.TrimSpace(line[strings.Index(line, "\"")+1:])
+
+	for !strings.Contains(value, "\"") {
+		if !scanner.Scan() {
+			return "", errors.New("unterminated quoted value")
+		}
+
+		*lineNum++
+		value += strings.TrimSpace(stripComment(scanner.Text()))
+	}
+
+	return value[:strings.Index(value, "\"")], nil
+}
+
+//nolint:gocyclo
+func (c *Config) updateParam(key, value string) error {
+	var err error
+
+	switch key {
+	case "gpgpu_n_clusters":
+		c.gpcNum, err = parseInt32(value)
+	case "gpgpu_n_cores_per_cluster":
+		c.smNumPerGPC, err = parseInt32(value)
+	case "gpgpu_num_sched_per_core":
+		c.smUnitNum, err = parseInt32(value)
+	case "gpgpu_scheduler":
+		c.scheduler = value
+	case "gpgpu_clock_domains":
+		c.freqInMHz, err = strconv.ParseFloat(strings.Split(value, ":")[0], 64)
+	case "gpgpu_shader_cta":
+		c.maxTBNumInSM, err = parseInt32(value)
+	case "gpgpu_shader_core_pipeline":
+		c.maxThreads, err = parseInt32(strings.Split(value, ":")[0])
+	case "gpgpu_shader_registers":
+		c.registerNum, err = parseInt32(value)
+	case "gpgpu_num_int_units":
+		c.units["int"].numPerSM, err = parseInt32(value)
+	case "gpgpu_num_sp_units":
+		c.units["sp"].numPerSM, err = parseInt32(value)
+	case "gpgpu_num_dp_units":
+		c.units["dp"].numPerSM, err = parseInt32(value)
+	case "gpgpu_num_sfu_units":
+		c.units["sfu"].numPerSM, err = parseInt32(value)
+	case "gpgpu_num_tensor_core_units":
+		c.units["tensor"].numPerSM, err = parseInt32(value)
+	case "gpgpu_num_mem_units":
+		c.units["mem"].numPerSM, err = parseInt32(value)
+	case "gpgpu_tensor_core_avail":
+		if value == "0" {
+			c.units["tensor"].numPerSM = 0
+		}
+	case "gpgpu_l1_latency":
+		c.l1Latency, err = parseInt32(value)
+	case "gpgpu_smem_latency":
+		c.sharedMemLatency, err = parseInt32(value)
+	case "gpgpu_l2_rop_latency":
+		c.l2Latency, err = parseInt32(value)
+	case "gpgpu_shmem_size":
+		c.sharedMemSize, err = parseInt32(value)
+	case "gpgpu_cache:dl1":
+		c.l1CacheSize, err = parseCacheSize(value)
+	case "gpgpu_cache:dl2":
+		c.l2CacheSize, err = parseCacheSize(value)
+	case "gpgpu_n_mem":
+		c.memChannelNum, err = parseInt32(value)
+	case "gpgpu_n_sub_partition_per_mchannel":
+		c.l2NumPerChannel, err = parseInt32(value)
+	case "trace_opcode_latency_initiation_int":
+		err = c.units["int"].parseTiming(value)
+	case "trace_opcode_latency_initiation_sp":
+		err = c.units["sp"].parseTiming(value)
+	case "trace_opcode_latency_initiation_dp":
+		err = c.units["dp"].parseTiming(value)
+	case "trace_opcode_latency_initiation_sfu":
+		err = c.units["sfu"].parseTiming(value)
+	case "trace_opcode_latency_initiation_tensor":
+		err = c.units["tensor"].parseTiming(value)
+	}
+
+	if err != nil {
+		return fmt.Errorf("invalid value %s of option %s", value, key)
+	}
+
+	return nil
+}
+
+// parseTiming parses values in the format of "latency,initiation".
+func (u *unitConfig) parseTiming(value string) error {
+	elems := strings.Split(value, ",")
+	if len(elems) != 2 {
+		return errors.New("timing should be latency,initiation")
+	}
+
+	latency, err := parseInt32(elems[0])
+	if err != nil {
+		return err
+	}
+
+	initiationInterval, err := parseInt32(elems[1])
+	if err != nil {
+		return err
+	}
+
+	u.latency = latency
+	u.initiationInterval = initiationInterval
+
+	return nil
+}
+
+// parseCacheSize returns the size in bytes of a cache described in the
+// format of "type:sets:line_size:associativity,...". The size of the L2 cache
+// is given per memory sub-partition.
+func parseCacheSize(value string) (int32, error) {
+	elems := strings.Split(strings.Split(value, ",")[0], ":")
+	if len(elems) != 4 {
+		return 0, errors.New("cache should be type:sets:line_size:assoc")
+	}
+
+	size := int32(1)
+	for _, e := range elems[1:] {
+		v, err := parseInt32(e)
+		if err != nil {
+			return 0, err
+		}
+
+		size *= v
+	}
+
+	return size, nil
+}
+
+func parseInt32(s string) (int32, error) {
+	v, err := strconv.ParseInt(s, 10, 32)
+	return int32(v), err
+}
+
+// Configure sets the parameters of the GPU builder. The units of each class
+// in an SM are evenly divided among the SM units, and each of them processes
+// a warp instruction every initiation interval.
+func (c *Config) Configure(g *gpu.GPU) *gpu.GPU {
+	if c.smUnitNum <= 0 {
+		panic("the number of schedulers per SM must be positive")
+	}
+
+	g.WithFreq(sim.Freq(c.freqInMHz) * sim.MHz).
+		WithGPCNum(c.gpcNum).
+		WithSMNum(c.smNumPerGPC).
+		WithSMUnitNum(c.smUnitNum).
+		WithSMUnitStrategy(c.scheduler).
+		WithMaxThreadBlockNumPerSM(c.maxTBNumInSM).
+		WithMaxWarpNumPerSMUnit(
+			c.maxThreads / nvidia.WarpSize / c.smUnitNum).
+		WithRegisterFileSize(c.registerNum * nvidia.DWORD).
+		WithL1CacheSize(c.l1CacheSize * nvidia.BYTE).
+		WithL2CacheSize(
+			c.l2CacheSize * c.memChannelNum * c.l2NumPerChannel * nvidia.BYTE).
+		WithSharedMemSize(c.sharedMemSize * nvidia.BYTE).
+		WithL1Latency(c.l1Latency).
+		WithL2Latency(c.l2Latency).
+		WithSharedMemLatency(c.sharedMemLatency).
+		WithMemBankNum(c.memChannelNum * c.l2NumPerChannel)
+
+	for _, name := range []string{"int", "sp", "dp", "sfu", "tensor", "mem"} {
+		u := c.units[name]
+		if u.numPerSM <= 0 {
+			continue
+		}
+
+		num := u.numPerSM / c.smUnitNum
+		if num == 0 {
+			num = 1
+		}
+
+		for i := int32(0); i < num; i++ {
+			g.WithALUConfig(u.aluType, nvidia.WarpSize,
+				u.latency, u.initiationInterval)
+		}
+	}
+
+	return g
+}
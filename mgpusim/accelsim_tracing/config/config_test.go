@@ -0,0 +1,97 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/gpu"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
+)
+
+var _ = Describe("Config", func() {
+	It("should read the Accel-Sim config files", func() {
+		c := NewConfig().
+			WithFilePath("testdata/gpgpusim.config").
+			WithFilePath("testdata/trace.config")
+
+		Expect(c.Build()).To(Succeed())
+
+		Expect(c.gpcNum).To(Equal(int32(80)))
+		Expect(c.smNumPerGPC).To(Equal(int32(1)))
+		Expect(c.smUnitNum).To(Equal(int32(4)))
+		Expect(c.scheduler).To(Equal("gto"))
+		Expect(c.freqInMHz).To(Equal(1132.0))
+		Expect(c.maxThreads).To(Equal(int32(2048)))
+		Expect(c.l1CacheSize).To(Equal(int32(32 * 1024)))
+		Expect(c.l2CacheSize).To(Equal(int32(96 * 1024)))
+		Expect(c.memChannelNum).To(Equal(int32(32)))
+		Expect(c.sharedMemSize).To(Equal(int32(98304)))
+		Expect(c.units["sfu"].latency).To(Equal(int32(20)))
+		Expect(c.units["sfu"].initiationInterval).To(Equal(int32(8)))
+	})
+
+	It("should read the options after a multi-line quoted value", func() {
+		c := NewConfig().WithFilePath("testdata/gpgpusim.config")
+
+		Expect(c.Build()).To(Succeed())
+
+		Expect(c.l2Latency).To(Equal(int32(160)))
+	})
+
+	It("should report an unterminated quoted value", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "gpgpusim.config")
+		err := os.WriteFile(path,
+			[]byte("-gpgpu_dram_timing_opt \"nbk=16:CCD=1\n-gpgpu_n_mem 32\n"),
+			0o600)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = NewConfig().WithFilePath(path).Build()
+
+		Expect(err).To(MatchError(ContainSubstring("gpgpusim.config:1")))
+	})
+
+	It("should compute the cache sizes", func() {
+		size, err := parseCacheSize("S:4:128:64,L:T:m:L:L,A:512:8,16:0,32")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(size).To(Equal(int32(32 * 1024)))
+
+		size, err = parseCacheSize("N:64:128:6,L:L:m:N:H,A:128:8,8")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(size).To(Equal(int32(48 * 1024)))
+
+		_, err = parseCacheSize("S:4:128,L:T:m:L:L")
+		Expect(err).To(HaveOccurred())
+
+		_, err = parseCacheSize("S:4:128:x,L:T:m:L:L")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should configure the GPU", func() {
+		c := NewConfig()
+		c.gpcNum = 2
+		c.smNumPerGPC = 3
+		c.smUnitNum = 4
+		c.freqInMHz = 1132
+		c.maxThreads = 2048
+		c.memChannelNum = 2
+		c.l2NumPerChannel = 2
+
+		g := c.Configure(gpu.NewGPU().WithEngine(sim.NewSerialEngine())).
+			WithL0CacheSize(16 * 1024 * nvidia.BYTE).
+			WithLaneSize(4 * nvidia.BYTE)
+		g.Build("GPU")
+
+		Expect(g.Freq()).To(Equal(1132 * sim.MHz))
+		Expect(g.GPCs()).To(HaveLen(2))
+		Expect(g.L2Caches()).To(HaveLen(4))
+		Expect(g.DRAMs()).To(HaveLen(4))
+		Expect(g.GPCs()[0].SMs()).To(HaveLen(3))
+
+		sm := g.GPCs()[0].SMs()[0]
+		Expect(sm.SMUnits()).To(HaveLen(4))
+		Expect(sm.MaxWarpNum()).To(Equal(64))
+	})
+})
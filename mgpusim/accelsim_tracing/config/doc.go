@@ -0,0 +1,2 @@
+// Package config reads the GPU parameters from Accel-Sim-style config files
+package config
@@ -1,17 +1,39 @@
 package gpc
 
 import (
+	"fmt"
+
+	"github.com/sarchlab/akita/v3/metrics"
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/memory"
 	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
 	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/sm"
 )
 
+// GPC is a graphics processing cluster. It distributes the thread blocks that
+// the GPU sends to its SMs.
 type GPC struct {
+	*sim.TickingComponent
+
+	ToGPU sim.Port
+	ToSMs sim.Port
+
 	meta       *gpcMetaData
 	dispatcher gpcDispatcher
 	sms        []*sm.SM
+
+	smIndex      map[sim.Port]int
+	smTBNum      []int
+	smWarpNum    []int
+	threadBlocks []*threadBlockState
+	runningTBs   map[*nvidia.ThreadBlock]*threadBlockState
+	finishedTBs  []*threadBlockState
 }
 
 type gpcMetaData struct {
+	engine sim.Engine
+	freq   sim.Freq
+
 	smNum     int32
 	smUnitNum int32
 
@@ -19,6 +41,9 @@ type gpcMetaData struct {
 	smStrategy     string
 	smUnitStrategy string
 
+	maxThreadBlockNumPerSM int32
+	maxWarpNumPerSMUnit    int32
+
 	l2CacheSize int32
 	l1CacheSize int32
 	l0CacheSize int32
@@ -26,15 +51,25 @@ type gpcMetaData struct {
 	registerFileSize int32
 	laneSize         int32
 
+	l1Latency        int32
+	sharedMemSize    int32
+	sharedMemLatency int32
+	pageTable        *memory.PageTable
+	metrics          *metrics.Registry
+
 	alus []struct {
-		aluType string
-		aluNum  int32
+		aluType            string
+		aluNum             int32
+		latency            int32
+		initiationInterval int32
 	}
 }
 
 func NewGPC() *GPC {
 	return &GPC{
 		meta: &gpcMetaData{
+			freq: 1 * sim.GHz,
+
 			smNum:     0,
 			smUnitNum: 0,
 
@@ -42,6 +77,9 @@ func NewGPC() *GPC {
 			smStrategy:     "default",
 			smUnitStrategy: "default",
 
+			maxThreadBlockNumPerSM: 32,
+			maxWarpNumPerSMUnit:    16,
+
 			l2CacheSize: 0,
 			l1CacheSize: 0,
 			l0CacheSize: 0,
@@ -49,6 +87,11 @@ func NewGPC() *GPC {
 			registerFileSize: 0,
 			laneSize:         0,
 
+			l1Latency:        20,
+			sharedMemSize:    0,
+			sharedMemLatency: 20,
+			metrics:          metrics.DefaultRegistry(),
+
 			alus: nil,
 		},
 		dispatcher: nil,
@@ -56,6 +99,16 @@ func NewGPC() *GPC {
 	}
 }
 
+func (g *GPC) WithEngine(engine sim.Engine) *GPC {
+	g.meta.engine = engine
+	return g
+}
+
+func (g *GPC) WithFreq(freq sim.Freq) *GPC {
+	g.meta.freq = freq
+	return g
+}
+
 func (g *GPC) WithSMNum(num int32) *GPC {
 	g.meta.smNum = num
 	return g
@@ -81,6 +134,19 @@ func (g *GPC) WithSMUnitStrategy(strategy string) *GPC {
 	return g
 }
 
+// WithMaxThreadBlockNumPerSM sets the number of thread blocks that each SM can
+// hold.
+func (g *GPC) WithMaxThreadBlockNumPerSM(num int32) *GPC {
+	g.meta.maxThreadBlockNumPerSM = num
+	return g
+}
+
+// WithMaxWarpNumPerSMUnit sets the number of warps that each SM unit can hold.
+func (g *GPC) WithMaxWarpNumPerSMUnit(num int32) *GPC {
+	g.meta.maxWarpNumPerSMUnit = num
+	return g
+}
+
 func (g *GPC) WithL2CacheSize(size int32) *GPC {
 	g.meta.l2CacheSize = size
 	return g
@@ -96,6 +162,38 @@ func (g *GPC) WithL0CacheSize(size int32) *GPC {
 	return g
 }
 
+// WithL1Latency sets the number of cycles that the L1 caches take to access a
+// cache line.
+func (g *GPC) WithL1Latency(cycles int32) *GPC {
+	g.meta.l1Latency = cycles
+	return g
+}
+
+func (g *GPC) WithSharedMemSize(size int32) *GPC {
+	g.meta.sharedMemSize = size
+	return g
+}
+
+// WithSharedMemLatency sets the number of cycles that the shared memory takes
+// to respond.
+func (g *GPC) WithSharedMemLatency(cycles int32) *GPC {
+	g.meta.sharedMemLatency = cycles
+	return g
+}
+
+// WithPageTable sets the page table that translates the global addresses.
+func (g *GPC) WithPageTable(pageTable *memory.PageTable) *GPC {
+	g.meta.pageTable = pageTable
+	return g
+}
+
+// WithMetricsRegistry sets the registry that the metrics of the L1 caches are
+// registered in. By default, the default registry is used.
+func (g *GPC) WithMetricsRegistry(registry *metrics.Registry) *GPC {
+	g.meta.metrics = registry
+	return g
+}
+
 func (g *GPC) WithRegisterFileSize(size int32) *GPC {
 	g.meta.registerFileSize = size
 	return g
@@ -107,36 +205,86 @@ func (g *GPC) WithLaneSize(size int32) *GPC {
 }
 
 func (g *GPC) WithALU(aluType string, num int32) *GPC {
+	return g.WithALUConfig(aluType, num, 0, 0)
+}
+
+// WithALUConfig adds a group of ALUs with the given latency and initiation
+// interval. A zero latency or interval keeps the default of the ALU type.
+func (g *GPC) WithALUConfig(
+	aluType string,
+	num, latency, initiationInterval int32,
+) *GPC {
 	g.meta.alus = append(g.meta.alus, struct {
-		aluType string
-		aluNum  int32
-	}{aluType: aluType, aluNum: num})
+		aluType            string
+		aluNum             int32
+		latency            int32
+		initiationInterval int32
+	}{
+		aluType:            aluType,
+		aluNum:             num,
+		latency:            latency,
+		initiationInterval: initiationInterval,
+	})
 	return g
 }
 
-func (g *GPC) Build() {
+func (g *GPC) Build(name string) {
+	if g.meta.engine == nil {
+		panic("engine is not set")
+	}
+
+	g.TickingComponent = sim.NewTickingComponent(
+		name, g.meta.engine, g.meta.freq, g)
+	g.ToGPU = sim.NewLimitNumMsgPort(g, 4, name+".ToGPU")
+	g.ToSMs = sim.NewLimitNumMsgPort(g, 4, name+".ToSMs")
+	conn := sim.NewDirectConnection(name+".SMConn", g.meta.engine, g.meta.freq)
+	conn.PlugIn(g.ToSMs, 4)
+
 	g.buildDispatcher()
+	g.smIndex = make(map[sim.Port]int)
+	g.smTBNum = make([]int, g.meta.smNum)
+	g.smWarpNum = make([]int, g.meta.smNum)
+	g.runningTBs = make(map[*nvidia.ThreadBlock]*threadBlockState)
 	g.sms = make([]*sm.SM, g.meta.smNum)
 	for i := 0; i < int(g.meta.smNum); i++ {
 		g.sms[i] = sm.NewSM().
-		WithSMStrategy(g.meta.smStrategy).
+			WithEngine(g.meta.engine).
+			WithFreq(g.meta.freq).
+			WithSMStrategy(g.meta.smStrategy).
 			WithSMUnitNum(g.meta.smUnitNum).
 			WithSMUnitStrategy(g.meta.smUnitStrategy).
+			WithMaxWarpNumPerSMUnit(g.meta.maxWarpNumPerSMUnit).
 			WithL1CacheSize(g.meta.l1CacheSize).
 			WithL0CacheSize(g.meta.l0CacheSize).
 			WithRegisterFileSize(g.meta.registerFileSize).
-			WithLaneSize(g.meta.laneSize)
+			WithLaneSize(g.meta.laneSize).
+			WithL1Latency(g.meta.l1Latency).
+			WithSharedMemSize(g.meta.sharedMemSize).
+			WithSharedMemLatency(g.meta.sharedMemLatency).
+			WithPageTable(g.meta.pageTable).
+			WithMetricsRegistry(g.meta.metrics)
 		for _, alu := range g.meta.alus {
-			g.sms[i].WithALU(alu.aluType, alu.aluNum)
+			g.sms[i].WithALUConfig(alu.aluType, alu.aluNum,
+				alu.latency, alu.initiationInterval)
 		}
-		g.sms[i].Build()
+		g.sms[i].Build(fmt.Sprintf("%s.SM[%d]", name, i))
+
+		conn.PlugIn(g.sms[i].ToGPC, 4)
+		g.smIndex[g.sms[i].ToGPC] = i
 	}
 }
 
-func (g *GPC) IsFree() bool {
-	return true
+// SMs returns the SMs of the GPC.
+func (g *GPC) SMs() []*sm.SM {
+	return g.sms
+}
+
+// MaxThreadBlockNum returns the number of thread blocks that the GPC can hold.
+func (g *GPC) MaxThreadBlockNum() int {
+	return int(g.meta.smNum * g.meta.maxThreadBlockNumPerSM)
 }
 
-func (g *GPC) Execute(tb *nvidia.ThreadBlock) {
-	g.dispatcher.dispatch(tb)
+// MaxWarpNum returns the number of warps that the GPC can hold.
+func (g *GPC) MaxWarpNum() int {
+	return int(g.meta.smNum * g.meta.smUnitNum * g.meta.maxWarpNumPerSMUnit)
 }
@@ -1,9 +1,15 @@
 package gpc
 
-import "github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
+import (
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/message"
+)
 
+// defaultDispatcher sends the thread blocks in order to the SMs in a
+// round-robin fashion, skipping the SMs that do not have enough resources.
 type defaultDispatcher struct {
 	parent *GPC
+	next   int
 }
 
 func newDefaultDispatcher() *defaultDispatcher {
@@ -15,18 +21,32 @@ func (d *defaultDispatcher) withParent(gpc *GPC) gpcDispatcher {
 	return d
 }
 
-func (d *defaultDispatcher) dispatch(tb *nvidia.ThreadBlock) {
-	for {
-		flag := false
-		for _, sm := range d.parent.sms {
-			if sm.IsFree() {
-				sm.Execute(tb)
-				flag = true
-				break
-			}
+func (d *defaultDispatcher) dispatch(now sim.VTimeInSec) bool {
+	g := d.parent
+	if len(g.threadBlocks) == 0 || len(g.sms) == 0 {
+		return false
+	}
+
+	tb := g.threadBlocks[0]
+	for i := 0; i < len(g.sms); i++ {
+		index := (d.next + i) % len(g.sms)
+		if !g.smCanHold(index, tb.tb.WarpNum) {
+			continue
 		}
-		if flag {
-			break
+
+		msg := message.NewThreadBlockMsg(now, g.ToSMs, g.sms[index].ToGPC, tb.tb)
+		if err := g.ToSMs.Send(msg); err != nil {
+			return false
 		}
+
+		g.threadBlocks = g.threadBlocks[1:]
+		g.runningTBs[tb.tb] = tb
+		g.smTBNum[index]++
+		g.smWarpNum[index] += tb.tb.WarpNum
+		d.next = index + 1
+
+		return true
 	}
+
+	return false
 }
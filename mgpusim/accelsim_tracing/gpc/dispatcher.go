@@ -1,10 +1,11 @@
 package gpc
 
-import "github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
+import "github.com/sarchlab/akita/v3/sim"
 
+// gpcDispatcher distributes the thread blocks to the SMs.
 type gpcDispatcher interface {
 	withParent(gpc *GPC) gpcDispatcher
-	dispatch(tb *nvidia.ThreadBlock)
+	dispatch(now sim.VTimeInSec) bool
 }
 
 func (g *GPC) buildDispatcher() {
@@ -0,0 +1,96 @@
+package gpc
+
+import (
+	"fmt"
+
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/message"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
+)
+
+type threadBlockState struct {
+	tb     *nvidia.ThreadBlock
+	gpuSrc sim.Port
+}
+
+// Tick updates the state of the GPC by one cycle.
+func (g *GPC) Tick(now sim.VTimeInSec) (madeProgress bool) {
+	madeProgress = g.reportFinishedThreadBlocks(now) || madeProgress
+	madeProgress = g.receiveThreadBlockDone(now) || madeProgress
+	madeProgress = g.dispatcher.dispatch(now) || madeProgress
+	madeProgress = g.receiveThreadBlock(now) || madeProgress
+
+	return madeProgress
+}
+
+func (g *GPC) smCanHold(index int, warpNum int) bool {
+	sm := g.sms[index]
+
+	if warpNum > sm.MaxWarpNum() {
+		panic(fmt.Sprintf("a thread block of %d warps cannot fit in an SM",
+			warpNum))
+	}
+
+	return g.smTBNum[index] < int(g.meta.maxThreadBlockNumPerSM) &&
+		g.smWarpNum[index]+warpNum <= sm.MaxWarpNum()
+}
+
+func (g *GPC) receiveThreadBlock(now sim.VTimeInSec) bool {
+	item := g.ToGPU.Peek()
+	if item == nil {
+		return false
+	}
+
+	msg, ok := item.(*message.ThreadBlockMsg)
+	if !ok {
+		panic(fmt.Sprintf("cannot handle message %T", item))
+	}
+
+	g.threadBlocks = append(g.threadBlocks, &threadBlockState{
+		tb:     msg.ThreadBlock,
+		gpuSrc: msg.Src,
+	})
+	g.ToGPU.Retrieve(now)
+
+	return true
+}
+
+func (g *GPC) receiveThreadBlockDone(now sim.VTimeInSec) bool {
+	item := g.ToSMs.Peek()
+	if item == nil {
+		return false
+	}
+
+	msg, ok := item.(*message.ThreadBlockDoneMsg)
+	if !ok {
+		panic(fmt.Sprintf("cannot handle message %T", item))
+	}
+
+	index := g.smIndex[msg.Src]
+	g.smTBNum[index]--
+	g.smWarpNum[index] -= msg.ThreadBlock.WarpNum
+
+	g.finishedTBs = append(g.finishedTBs, g.runningTBs[msg.ThreadBlock])
+	delete(g.runningTBs, msg.ThreadBlock)
+	g.ToSMs.Retrieve(now)
+
+	return true
+}
+
+func (g *GPC) reportFinishedThreadBlocks(now sim.VTimeInSec) bool {
+	if len(g.finishedTBs) == 0 {
+		return false
+	}
+
+	tb := g.finishedTBs[0]
+	msg := message.NewThreadBlockDoneMsg(now, g.ToGPU, tb.gpuSrc, tb.tb)
+
+	err := g.ToGPU.Send(msg)
+	if err != nil {
+		return false
+	}
+
+	g.finishedTBs = g.finishedTBs[1:]
+
+	return true
+}
@@ -1,17 +1,42 @@
 package gpu
 
 import (
+	"fmt"
+
+	"github.com/sarchlab/akita/v3/mem/cache/writeback"
+	"github.com/sarchlab/akita/v3/mem/dram"
+	"github.com/sarchlab/akita/v3/mem/mem"
+	"github.com/sarchlab/akita/v3/metrics"
+	"github.com/sarchlab/akita/v3/sim"
 	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/gpc"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/memory"
 	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
 )
 
+// GPU is the top level of the NVIDIA GPU model. It holds the thread blocks of
+// the running kernels and distributes them to the GPCs.
 type GPU struct {
+	*sim.TickingComponent
+
+	ToGPCs sim.Port
+
 	meta       *gpuMetaData
 	dispatcher gpuDispatcher
 	gpcs       []*gpc.GPC
+	l2Caches   []*writeback.Cache
+	drams      []*dram.MemController
+	pageTable  *memory.PageTable
+
+	gpcIndex     map[sim.Port]int
+	gpcTBNum     []int
+	gpcWarpNum   []int
+	threadBlocks []*nvidia.ThreadBlock
 }
 
 type gpuMetaData struct {
+	engine sim.Engine
+	freq   sim.Freq
+
 	gpcNum    int32
 	smNum     int32
 	smUnitNum int32
@@ -21,6 +46,9 @@ type gpuMetaData struct {
 	smStrategy     string
 	smUnitStrategy string
 
+	maxThreadBlockNumPerSM int32
+	maxWarpNumPerSMUnit    int32
+
 	l2CacheSize int32
 	l1CacheSize int32
 	l0CacheSize int32
@@ -28,15 +56,28 @@ type gpuMetaData struct {
 	registerFileSize int32
 	laneSize         int32
 
+	l1Latency        int32
+	l2Latency        int32
+	sharedMemSize    int32
+	sharedMemLatency int32
+	memBankNum       int32
+	dramSize         uint64
+
+	metrics *metrics.Registry
+
 	alus []struct {
-		aluType string
-		aluNum  int32
+		aluType            string
+		aluNum             int32
+		latency            int32
+		initiationInterval int32
 	}
 }
 
 func NewGPU() *GPU {
 	return &GPU{
 		meta: &gpuMetaData{
+			freq: 1 * sim.GHz,
+
 			gpcNum:    0,
 			smNum:     0,
 			smUnitNum: 0,
@@ -46,6 +87,9 @@ func NewGPU() *GPU {
 			smStrategy:     "default",
 			smUnitStrategy: "default",
 
+			maxThreadBlockNumPerSM: 32,
+			maxWarpNumPerSMUnit:    16,
+
 			l2CacheSize: 0,
 			l1CacheSize: 0,
 			l0CacheSize: 0,
@@ -53,6 +97,15 @@ func NewGPU() *GPU {
 			registerFileSize: 0,
 			laneSize:         0,
 
+			l1Latency:        20,
+			l2Latency:        100,
+			sharedMemSize:    0,
+			sharedMemLatency: 20,
+			memBankNum:       16,
+			dramSize:         16 * mem.GB,
+
+			metrics: metrics.DefaultRegistry(),
+
 			alus: nil,
 		},
 		dispatcher: nil,
@@ -60,6 +113,16 @@ func NewGPU() *GPU {
 	}
 }
 
+func (g *GPU) WithEngine(engine sim.Engine) *GPU {
+	g.meta.engine = engine
+	return g
+}
+
+func (g *GPU) WithFreq(freq sim.Freq) *GPU {
+	g.meta.freq = freq
+	return g
+}
+
 func (g *GPU) WithGPCNum(num int32) *GPU {
 	g.meta.gpcNum = num
 	return g
@@ -95,6 +158,19 @@ func (g *GPU) WithSMUnitStrategy(strategy string) *GPU {
 	return g
 }
 
+// WithMaxThreadBlockNumPerSM sets the number of thread blocks that each SM can
+// hold.
+func (g *GPU) WithMaxThreadBlockNumPerSM(num int32) *GPU {
+	g.meta.maxThreadBlockNumPerSM = num
+	return g
+}
+
+// WithMaxWarpNumPerSMUnit sets the number of warps that each SM unit can hold.
+func (g *GPU) WithMaxWarpNumPerSMUnit(num int32) *GPU {
+	g.meta.maxWarpNumPerSMUnit = num
+	return g
+}
+
 func (g *GPU) WithL2CacheSize(size int32) *GPU {
 	g.meta.l2CacheSize = size
 	return g
@@ -110,6 +186,53 @@ func (g *GPU) WithL0CacheSize(size int32) *GPU {
 	return g
 }
 
+// WithL1Latency sets the number of cycles that the L1 caches take to access a
+// cache line.
+func (g *GPU) WithL1Latency(cycles int32) *GPU {
+	g.meta.l1Latency = cycles
+	return g
+}
+
+// WithL2Latency sets the number of cycles that the L2 caches take to access a
+// cache line.
+func (g *GPU) WithL2Latency(cycles int32) *GPU {
+	g.meta.l2Latency = cycles
+	return g
+}
+
+func (g *GPU) WithSharedMemSize(size int32) *GPU {
+	g.meta.sharedMemSize = size
+	return g
+}
+
+// WithSharedMemLatency sets the number of cycles that the shared memory takes
+// to respond.
+func (g *GPU) WithSharedMemLatency(cycles int32) *GPU {
+	g.meta.sharedMemLatency = cycles
+	return g
+}
+
+// WithMemBankNum sets the number of the L2 cache banks. Each bank has its own
+// DRAM controller.
+func (g *GPU) WithMemBankNum(num int32) *GPU {
+	g.meta.memBankNum = num
+	return g
+}
+
+// WithDRAMSize sets the capacity of the GPU memory in bytes.
+func (g *GPU) WithDRAMSize(byteSize uint64) *GPU {
+	g.meta.dramSize = byteSize
+	return g
+}
+
+// WithMetricsRegistry sets the registry that the metrics of the caches and the
+// DRAM controllers are registered in. By default, the default registry is
+// used.
+func (g *GPU) WithMetricsRegistry(registry *metrics.Registry) *GPU {
+	g.meta.metrics = registry
+	return g
+}
+
 func (g *GPU) WithRegisterFileSize(size int32) *GPU {
 	g.meta.registerFileSize = size
 	return g
@@ -121,37 +244,110 @@ func (g *GPU) WithLaneSize(size int32) *GPU {
 }
 
 func (g *GPU) WithALU(aluType string, num int32) *GPU {
+	return g.WithALUConfig(aluType, num, 0, 0)
+}
+
+// WithALUConfig adds a group of ALUs with the given latency and initiation
+// interval. A zero latency or interval keeps the default of the ALU type.
+func (g *GPU) WithALUConfig(
+	aluType string,
+	num, latency, initiationInterval int32,
+) *GPU {
 	g.meta.alus = append(g.meta.alus, struct {
-		aluType string
-		aluNum  int32
-	}{aluType: aluType, aluNum: num})
+		aluType            string
+		aluNum             int32
+		latency            int32
+		initiationInterval int32
+	}{
+		aluType:            aluType,
+		aluNum:             num,
+		latency:            latency,
+		initiationInterval: initiationInterval,
+	})
 	return g
 }
 
-func (g *GPU) Build() {
+func (g *GPU) Build(name string) {
+	if g.meta.engine == nil {
+		panic("engine is not set")
+	}
+
+	g.TickingComponent = sim.NewTickingComponent(
+		name, g.meta.engine, g.meta.freq, g)
+	g.ToGPCs = sim.NewLimitNumMsgPort(g, 4, name+".ToGPCs")
+	conn := sim.NewDirectConnection(name+".GPCConn", g.meta.engine, g.meta.freq)
+	conn.PlugIn(g.ToGPCs, 4)
+
 	g.buildDispatcher()
+	g.buildMemory(name)
+	g.gpcIndex = make(map[sim.Port]int)
+	g.gpcTBNum = make([]int, g.meta.gpcNum)
+	g.gpcWarpNum = make([]int, g.meta.gpcNum)
 	g.gpcs = make([]*gpc.GPC, g.meta.gpcNum)
 	for i := 0; i < int(g.meta.gpcNum); i++ {
 		g.gpcs[i] = gpc.NewGPC().
+			WithEngine(g.meta.engine).
+			WithFreq(g.meta.freq).
 			WithSMNum(g.meta.smNum).
 			WithSMUnitNum(g.meta.smUnitNum).
 			WithGPCStrategy(g.meta.gpcStrategy).
 			WithSMStrategy(g.meta.smStrategy).
 			WithSMUnitStrategy(g.meta.smUnitStrategy).
+			WithMaxThreadBlockNumPerSM(g.meta.maxThreadBlockNumPerSM).
+			WithMaxWarpNumPerSMUnit(g.meta.maxWarpNumPerSMUnit).
 			WithL2CacheSize(g.meta.l2CacheSize).
 			WithL1CacheSize(g.meta.l1CacheSize).
 			WithL0CacheSize(g.meta.l0CacheSize).
 			WithRegisterFileSize(g.meta.registerFileSize).
-			WithLaneSize(g.meta.laneSize)
+			WithLaneSize(g.meta.laneSize).
+			WithL1Latency(g.meta.l1Latency).
+			WithSharedMemSize(g.meta.sharedMemSize).
+			WithSharedMemLatency(g.meta.sharedMemLatency).
+			WithPageTable(g.pageTable).
+			WithMetricsRegistry(g.meta.metrics)
 		for _, alu := range g.meta.alus {
-			g.gpcs[i].WithALU(alu.aluType, alu.aluNum)
+			g.gpcs[i].WithALUConfig(alu.aluType, alu.aluNum,
+				alu.latency, alu.initiationInterval)
 		}
-		g.gpcs[i].Build()
+		g.gpcs[i].Build(fmt.Sprintf("%s.GPC[%d]", name, i))
+
+		conn.PlugIn(g.gpcs[i].ToGPU, 4)
+		g.gpcIndex[g.gpcs[i].ToGPU] = i
 	}
+
+	g.connectL1ToL2(name)
+}
+
+// GPCs returns the GPCs of the GPU.
+func (g *GPU) GPCs() []*gpc.GPC {
+	return g.gpcs
 }
 
-// RunThreadBlock runs a threadblock on the GPU
-// [todo] how to handle the relationship between trace.threadblock and truethreadblock
+// L2Caches returns the L2 cache banks of the GPU.
+func (g *GPU) L2Caches() []*writeback.Cache {
+	return g.l2Caches
+}
+
+// DRAMs returns the DRAM controllers of the GPU.
+func (g *GPU) DRAMs() []*dram.MemController {
+	return g.drams
+}
+
+// Freq returns the frequency of the GPU.
+func (g *GPU) Freq() sim.Freq {
+	return g.meta.freq
+}
+
+// RunThreadBlock puts a thread block in the queue of the GPU. The thread
+// block runs when the engine runs.
 func (g *GPU) RunThreadBlock(tb *nvidia.ThreadBlock) {
-	g.dispatcher.dispatch(tb)
+	g.threadBlocks = append(g.threadBlocks, tb)
+	g.TickLater(g.Engine.CurrentTime())
+}
+
+// CopyFromHost places a buffer that the host copies to the GPU in the GPU
+// memory. The data is not simulated, so the copy only maps the pages of the
+// buffer.
+func (g *GPU) CopyFromHost(vAddr, byteSize uint64) {
+	g.pageTable.Map(vAddr, byteSize)
 }
@@ -1,11 +1,15 @@
 package gpu
 
 import (
-	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/message"
 )
 
+// defaultDispatcher sends the thread blocks in order to the GPCs in a
+// round-robin fashion, skipping the GPCs that are full.
 type defaultDispatcher struct {
 	parent *GPU
+	next   int
 }
 
 func newDefaultDispatcher() *defaultDispatcher {
@@ -17,18 +21,31 @@ func (d *defaultDispatcher) withParent(gpu *GPU) gpuDispatcher {
 	return d
 }
 
-func (d *defaultDispatcher) dispatch(tb *nvidia.ThreadBlock) {
-	for {
-		flag := false
-		for _, gpc := range d.parent.gpcs {
-			if gpc.IsFree() {
-				gpc.Execute(tb)
-				flag = true
-				break
-			}
+func (d *defaultDispatcher) dispatch(now sim.VTimeInSec) bool {
+	g := d.parent
+	if len(g.threadBlocks) == 0 || len(g.gpcs) == 0 {
+		return false
+	}
+
+	tb := g.threadBlocks[0]
+	for i := 0; i < len(g.gpcs); i++ {
+		index := (d.next + i) % len(g.gpcs)
+		if !g.gpcCanHold(index, tb.WarpNum) {
+			continue
 		}
-		if flag {
-			break
+
+		msg := message.NewThreadBlockMsg(now, g.ToGPCs, g.gpcs[index].ToGPU, tb)
+		if err := g.ToGPCs.Send(msg); err != nil {
+			return false
 		}
+
+		g.threadBlocks = g.threadBlocks[1:]
+		g.gpcTBNum[index]++
+		g.gpcWarpNum[index] += tb.WarpNum
+		d.next = index + 1
+
+		return true
 	}
+
+	return false
 }
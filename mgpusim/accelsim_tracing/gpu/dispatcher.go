@@ -1,10 +1,11 @@
 package gpu
 
-import "github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
+import "github.com/sarchlab/akita/v3/sim"
 
+// gpuDispatcher distributes the thread blocks to the GPCs.
 type gpuDispatcher interface {
 	withParent(gpu *GPU) gpuDispatcher
-	dispatch(tb *nvidia.ThreadBlock)
+	dispatch(now sim.VTimeInSec) bool
 }
 
 func (g *GPU) buildDispatcher() {
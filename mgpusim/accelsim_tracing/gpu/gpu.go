@@ -0,0 +1,42 @@
+package gpu
+
+import (
+	"fmt"
+
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/message"
+)
+
+// Tick updates the state of the GPU by one cycle.
+func (g *GPU) Tick(now sim.VTimeInSec) (madeProgress bool) {
+	madeProgress = g.receiveThreadBlockDone(now) || madeProgress
+	madeProgress = g.dispatcher.dispatch(now) || madeProgress
+
+	return madeProgress
+}
+
+func (g *GPU) gpcCanHold(index int, warpNum int) bool {
+	gpc := g.gpcs[index]
+
+	return g.gpcTBNum[index] < gpc.MaxThreadBlockNum() &&
+		g.gpcWarpNum[index]+warpNum <= gpc.MaxWarpNum()
+}
+
+func (g *GPU) receiveThreadBlockDone(now sim.VTimeInSec) bool {
+	item := g.ToGPCs.Peek()
+	if item == nil {
+		return false
+	}
+
+	msg, ok := item.(*message.ThreadBlockDoneMsg)
+	if !ok {
+		panic(fmt.Sprintf("cannot handle message %T", item))
+	}
+
+	index := g.gpcIndex[msg.Src]
+	g.gpcTBNum[index]--
+	g.gpcWarpNum[index] -= msg.ThreadBlock.WarpNum
+	g.ToGPCs.Retrieve(now)
+
+	return true
+}
@@ -0,0 +1,138 @@
+package gpu
+
+import (
+	"fmt"
+
+	"github.com/sarchlab/akita/v3/mem/cache/writeback"
+	"github.com/sarchlab/akita/v3/mem/dram"
+	"github.com/sarchlab/akita/v3/mem/mem"
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/memory"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
+)
+
+const (
+	log2PageSize         = 12
+	log2CacheLineSize    = 7
+	log2SectorSize       = 5
+	log2InterleavingSize = 8
+)
+
+// buildMemory builds the page table, the L2 cache banks, and the DRAM
+// controllers. The physical addresses are interleaved across the banks.
+func (g *GPU) buildMemory(name string) {
+	if g.meta.l2CacheSize <= 0 || g.meta.memBankNum <= 0 {
+		panic("L2 cache size and the number of memory banks must be positive")
+	}
+
+	g.pageTable = memory.NewPageTable(log2PageSize, g.meta.dramSize)
+
+	bankNum := int(g.meta.memBankNum)
+	l2Builder := writeback.MakeBuilder().
+		WithEngine(g.meta.engine).
+		WithFreq(g.meta.freq).
+		WithMetricsRegistry(g.meta.metrics).
+		WithLog2BlockSize(log2CacheLineSize).
+		WithLog2SectorSize(log2SectorSize).
+		WithWayAssociativity(16).
+		WithByteSize(uint64(g.meta.l2CacheSize/nvidia.BYTE) / uint64(bankNum)).
+		WithNumMSHREntry(64).
+		WithNumReqPerCycle(4).
+		WithBankLatency(int(g.meta.l2Latency))
+	dramBuilder := g.dramBuilder()
+
+	conn := sim.NewDirectConnection(
+		name+".L2ToDRAM", g.meta.engine, g.meta.freq)
+	for i := 0; i < bankNum; i++ {
+		l2 := l2Builder.
+			WithInterleaving(
+				1<<(log2InterleavingSize-log2CacheLineSize), bankNum, i).
+			Build(fmt.Sprintf("%s.L2[%d]", name, i))
+		d := dramBuilder.
+			WithInterleavingAddrConversion(
+				1<<log2InterleavingSize, bankNum, i, 0, g.meta.dramSize).
+			Build(fmt.Sprintf("%s.DRAM[%d]", name, i))
+
+		l2.SetLowModuleFinder(&mem.SingleLowModuleFinder{
+			LowModule: d.GetPortByName("Top"),
+		})
+		conn.PlugIn(l2.GetPortByName("Bottom"), 64)
+		conn.PlugIn(d.GetPortByName("Top"), 64)
+
+		g.l2Caches = append(g.l2Caches, l2)
+		g.drams = append(g.drams, d)
+	}
+}
+
+func (g *GPU) dramBuilder() dram.Builder {
+	bankSize := g.meta.dramSize / uint64(g.meta.memBankNum)
+
+	dramCol := 64
+	dramRow := 16384
+	dramDeviceWidth := 128
+	dramBusWidth := 256
+	dramBank := 4
+	dramBankGroup := 4
+	dramDevicePerRank := dramBusWidth / dramDeviceWidth
+	dramRankSize := dramCol * dramRow * dramDeviceWidth / 8 *
+		dramDevicePerRank * dramBank
+	dramRank := int(bankSize / uint64(dramRankSize))
+	if dramRank == 0 {
+		dramRank = 1
+	}
+
+	return dram.MakeBuilder().
+		WithEngine(g.meta.engine).
+		WithFreq(1 * sim.GHz).
+		WithMetricsRegistry(g.meta.metrics).
+		WithProtocol(dram.HBM2).
+		WithBurstLength(4).
+		WithDeviceWidth(dramDeviceWidth).
+		WithBusWidth(dramBusWidth).
+		WithNumChannel(1).
+		WithNumRank(dramRank).
+		WithNumBankGroup(dramBankGroup).
+		WithNumBank(dramBank).
+		WithNumCol(dramCol).
+		WithNumRow(dramRow).
+		WithCommandQueueSize(8).
+		WithTransactionQueueSize(32).
+		WithTCL(7).
+		WithTCWL(2).
+		WithTRCDRD(7).
+		WithTRCDWR(7).
+		WithTRP(7).
+		WithTRAS(17).
+		WithTREFI(1950).
+		WithTRRDS(2).
+		WithTRRDL(3).
+		WithTWTRS(3).
+		WithTWTRL(4).
+		WithTWR(8).
+		WithTCCDS(1).
+		WithTCCDL(1).
+		WithTRTRS(0).
+		WithTRTP(3).
+		WithTPPD(2)
+}
+
+// connectL1ToL2 connects the L1 caches of all the SMs to the L2 cache banks.
+func (g *GPU) connectL1ToL2(name string) {
+	lowModuleFinder := mem.NewInterleavedLowModuleFinder(
+		1 << log2InterleavingSize)
+	conn := sim.NewDirectConnection(
+		name+".L1ToL2", g.meta.engine, g.meta.freq)
+
+	for _, l2 := range g.l2Caches {
+		lowModuleFinder.LowModules = append(lowModuleFinder.LowModules,
+			l2.GetPortByName("Top"))
+		conn.PlugIn(l2.GetPortByName("Top"), 64)
+	}
+
+	for _, gpc := range g.gpcs {
+		for _, sm := range gpc.SMs() {
+			sm.L1Cache().SetLowModuleFinder(lowModuleFinder)
+			conn.PlugIn(sm.L1Cache().GetPortByName("Bottom"), 16)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package memory
+
+import (
+	"sort"
+
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
+)
+
+// Access is a memory access to a sector after coalescing.
+type Access struct {
+	Address uint64
+	IsStore bool
+}
+
+// Coalescer merges the per-thread addresses of a warp instruction into
+// accesses to the sectors. Each thread accesses MemWidth bytes, which may
+// span more than one sector.
+type Coalescer struct {
+	log2SectorSize uint64
+}
+
+// NewCoalescer creates a Coalescer with the given sector size.
+func NewCoalescer(log2SectorSize uint64) *Coalescer {
+	return &Coalescer{log2SectorSize: log2SectorSize}
+}
+
+// SectorSize returns the number of bytes in a sector.
+func (c *Coalescer) SectorSize() uint64 {
+	return 1 << c.log2SectorSize
+}
+
+// Coalesce returns the sector accesses of an instruction in the order of the
+// addresses.
+func (c *Coalescer) Coalesce(inst *nvidia.Instruction) []Access {
+	sectors := make(map[uint64]bool)
+	for _, addr := range inst.MemAddresses {
+		first := addr >> c.log2SectorSize
+		last := (addr + uint64(inst.MemWidth) - 1) >> c.log2SectorSize
+		for s := first; s <= last; s++ {
+			sectors[s] = true
+		}
+	}
+
+	accesses := make([]Access, 0, len(sectors))
+	for s := range sectors {
+		accesses = append(accesses, Access{
+			Address: s << c.log2SectorSize,
+			IsStore: inst.OpCode.IsStore(),
+		})
+	}
+
+	sort.Slice(accesses, func(i, j int) bool {
+		return accesses[i].Address < accesses[j].Address
+	})
+
+	return accesses
+}
@@ -0,0 +1,68 @@
+package memory
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
+)
+
+var _ = Describe("Coalescer", func() {
+	var c *Coalescer
+
+	BeforeEach(func() {
+		c = NewCoalescer(5)
+	})
+
+	load := func(width int32, addrs ...uint64) *nvidia.Instruction {
+		return &nvidia.Instruction{
+			OpCode:       nvidia.NewOpcode("LDG.E"),
+			MemWidth:     width,
+			MemAddresses: addrs,
+		}
+	}
+
+	It("should merge the addresses in the same sector", func() {
+		var addrs []uint64
+		for i := uint64(0); i < 32; i++ {
+			addrs = append(addrs, 0x1000+i*4)
+		}
+
+		accesses := c.Coalesce(load(4, addrs...))
+
+		Expect(accesses).To(Equal([]Access{
+			{Address: 0x1000},
+			{Address: 0x1020},
+			{Address: 0x1040},
+			{Address: 0x1060},
+		}))
+	})
+
+	It("should access all the sectors that a thread spans", func() {
+		accesses := c.Coalesce(load(8, 0x101c))
+
+		Expect(accesses).To(Equal([]Access{
+			{Address: 0x1000},
+			{Address: 0x1020},
+		}))
+	})
+
+	It("should sort the sectors and remove the duplicates", func() {
+		accesses := c.Coalesce(load(4, 0x2000, 0x1000, 0x2004, 0x1000))
+
+		Expect(accesses).To(Equal([]Access{
+			{Address: 0x1000},
+			{Address: 0x2000},
+		}))
+	})
+
+	It("should mark the accesses of the stores", func() {
+		inst := load(4, 0x1000)
+		inst.OpCode = nvidia.NewOpcode("STG.E")
+
+		accesses := c.Coalesce(inst)
+
+		Expect(accesses).To(Equal([]Access{
+			{Address: 0x1000, IsStore: true},
+		}))
+	})
+})
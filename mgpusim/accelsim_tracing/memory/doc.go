@@ -0,0 +1,3 @@
+// Package memory translates the traced addresses and coalesces the memory
+// accesses of the warps
+package memory
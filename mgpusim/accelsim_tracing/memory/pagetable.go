@@ -0,0 +1,57 @@
+package memory
+
+import "sync"
+
+// PageTable maps the virtual addresses in the traces to the physical
+// addresses of the simulated GPU memory. A page is allocated when it is first
+// accessed, so the pages that a kernel uses are packed in the GPU memory.
+type PageTable struct {
+	sync.Mutex
+
+	log2PageSize uint64
+	capacity     uint64
+	pages        map[uint64]uint64
+	nextPage     uint64
+}
+
+// NewPageTable creates a PageTable that allocates pages from a physical
+// memory of the given capacity.
+func NewPageTable(log2PageSize uint64, capacity uint64) *PageTable {
+	return &PageTable{
+		log2PageSize: log2PageSize,
+		capacity:     capacity,
+		pages:        make(map[uint64]uint64),
+	}
+}
+
+// Translate returns the physical address of a virtual address.
+func (t *PageTable) Translate(vAddr uint64) uint64 {
+	t.Lock()
+	defer t.Unlock()
+
+	vPage := vAddr >> t.log2PageSize
+	pPage, ok := t.pages[vPage]
+	if !ok {
+		pPage = t.nextPage
+		if (pPage+1)<<t.log2PageSize > t.capacity {
+			panic("the traces access more memory than the GPU has")
+		}
+
+		t.pages[vPage] = pPage
+		t.nextPage++
+	}
+
+	offset := vAddr & (1<<t.log2PageSize - 1)
+
+	return pPage<<t.log2PageSize | offset
+}
+
+// Map allocates the pages that hold the bytes from the virtual address, so
+// that the buffers are placed in the GPU memory in the order that the host
+// copies them.
+func (t *PageTable) Map(vAddr, byteSize uint64) {
+	pageSize := uint64(1) << t.log2PageSize
+	for addr := vAddr &^ (pageSize - 1); addr < vAddr+byteSize; addr += pageSize {
+		t.Translate(addr)
+	}
+}
@@ -0,0 +1,33 @@
+package memory
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PageTable", func() {
+	var t *PageTable
+
+	BeforeEach(func() {
+		t = NewPageTable(12, 4*4096)
+	})
+
+	It("should pack the pages in the order of the first access", func() {
+		Expect(t.Translate(0x7f0000005004)).To(Equal(uint64(0x0004)))
+		Expect(t.Translate(0x7f0000001008)).To(Equal(uint64(0x1008)))
+		Expect(t.Translate(0x7f0000005010)).To(Equal(uint64(0x0010)))
+	})
+
+	It("should map all the pages of a buffer", func() {
+		t.Map(0x7f0000000ff0, 0x20)
+
+		Expect(t.Translate(0x7f0000002000)).To(Equal(uint64(0x2000)))
+		Expect(t.Translate(0x7f0000001000)).To(Equal(uint64(0x1000)))
+	})
+
+	It("should panic if the GPU memory is full", func() {
+		t.Map(0, 4*4096)
+
+		Expect(func() { t.Translate(4 * 4096) }).To(Panic())
+	})
+})
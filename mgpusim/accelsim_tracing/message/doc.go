@@ -0,0 +1,3 @@
+// Package message defines the messages that the GPU, the GPCs, the SMs, and
+// the SM units send to each other.
+package message
@@ -0,0 +1,165 @@
+package message
+
+import (
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
+)
+
+// ThreadBlockMsg asks a GPC or an SM to run a thread block.
+type ThreadBlockMsg struct {
+	sim.MsgMeta
+	ThreadBlock *nvidia.ThreadBlock
+}
+
+// Meta returns the meta data of the message.
+func (m *ThreadBlockMsg) Meta() *sim.MsgMeta {
+	return &m.MsgMeta
+}
+
+// NewThreadBlockMsg creates a ThreadBlockMsg.
+func NewThreadBlockMsg(
+	now sim.VTimeInSec,
+	src, dst sim.Port,
+	tb *nvidia.ThreadBlock,
+) *ThreadBlockMsg {
+	m := &ThreadBlockMsg{ThreadBlock: tb}
+	m.ID = sim.IDGeneratorFor(src).Generate()
+	m.Src = src
+	m.Dst = dst
+	m.SendTime = now
+
+	return m
+}
+
+// ThreadBlockDoneMsg reports that all the warps of a thread block have
+// completed.
+type ThreadBlockDoneMsg struct {
+	sim.MsgMeta
+	ThreadBlock *nvidia.ThreadBlock
+}
+
+// Meta returns the meta data of the message.
+func (m *ThreadBlockDoneMsg) Meta() *sim.MsgMeta {
+	return &m.MsgMeta
+}
+
+// NewThreadBlockDoneMsg creates a ThreadBlockDoneMsg.
+func NewThreadBlockDoneMsg(
+	now sim.VTimeInSec,
+	src, dst sim.Port,
+	tb *nvidia.ThreadBlock,
+) *ThreadBlockDoneMsg {
+	m := &ThreadBlockDoneMsg{ThreadBlock: tb}
+	m.ID = sim.IDGeneratorFor(src).Generate()
+	m.Src = src
+	m.Dst = dst
+	m.SendTime = now
+
+	return m
+}
+
+// WarpMsg asks an SM unit to run a warp.
+type WarpMsg struct {
+	sim.MsgMeta
+	Warp *nvidia.Warp
+}
+
+// Meta returns the meta data of the message.
+func (m *WarpMsg) Meta() *sim.MsgMeta {
+	return &m.MsgMeta
+}
+
+// NewWarpMsg creates a WarpMsg.
+func NewWarpMsg(
+	now sim.VTimeInSec,
+	src, dst sim.Port,
+	warp *nvidia.Warp,
+) *WarpMsg {
+	m := &WarpMsg{Warp: warp}
+	m.ID = sim.IDGeneratorFor(src).Generate()
+	m.Src = src
+	m.Dst = dst
+	m.SendTime = now
+
+	return m
+}
+
+// WarpDoneMsg reports that all the instructions of a warp have completed.
+type WarpDoneMsg struct {
+	sim.MsgMeta
+	Warp *nvidia.Warp
+}
+
+// Meta returns the meta data of the message.
+func (m *WarpDoneMsg) Meta() *sim.MsgMeta {
+	return &m.MsgMeta
+}
+
+// NewWarpDoneMsg creates a WarpDoneMsg.
+func NewWarpDoneMsg(
+	now sim.VTimeInSec,
+	src, dst sim.Port,
+	warp *nvidia.Warp,
+) *WarpDoneMsg {
+	m := &WarpDoneMsg{Warp: warp}
+	m.ID = sim.IDGeneratorFor(src).Generate()
+	m.Src = src
+	m.Dst = dst
+	m.SendTime = now
+
+	return m
+}
+
+// BarrierArriveMsg reports that a warp has reached a barrier and waits for
+// the other warps of its thread block.
+type BarrierArriveMsg struct {
+	sim.MsgMeta
+	Warp *nvidia.Warp
+}
+
+// Meta returns the meta data of the message.
+func (m *BarrierArriveMsg) Meta() *sim.MsgMeta {
+	return &m.MsgMeta
+}
+
+// NewBarrierArriveMsg creates a BarrierArriveMsg.
+func NewBarrierArriveMsg(
+	now sim.VTimeInSec,
+	src, dst sim.Port,
+	warp *nvidia.Warp,
+) *BarrierArriveMsg {
+	m := &BarrierArriveMsg{Warp: warp}
+	m.ID = sim.IDGeneratorFor(src).Generate()
+	m.Src = src
+	m.Dst = dst
+	m.SendTime = now
+
+	return m
+}
+
+// BarrierReleaseMsg lets a warp that waits at a barrier continue, as all the
+// warps of its thread block have arrived.
+type BarrierReleaseMsg struct {
+	sim.MsgMeta
+	Warp *nvidia.Warp
+}
+
+// Meta returns the meta data of the message.
+func (m *BarrierReleaseMsg) Meta() *sim.MsgMeta {
+	return &m.MsgMeta
+}
+
+// NewBarrierReleaseMsg creates a BarrierReleaseMsg.
+func NewBarrierReleaseMsg(
+	now sim.VTimeInSec,
+	src, dst sim.Port,
+	warp *nvidia.Warp,
+) *BarrierReleaseMsg {
+	m := &BarrierReleaseMsg{Warp: warp}
+	m.ID = sim.IDGeneratorFor(src).Generate()
+	m.Src = src
+	m.Dst = dst
+	m.SendTime = now
+
+	return m
+}
@@ -1,6 +1,9 @@
 package nvidia
 
-import "log"
+import (
+	"log"
+	"strings"
+)
 
 // VariableType [todo] how to construct these?
 type VariableType int32
@@ -13,26 +16,75 @@ const (
 	VariableFP64
 )
 
-type OpCodeType int32
+// ExecUnit is the class of the functional units that execute an opcode.
+type ExecUnit int32
 
 const (
-	OpCodeDefault OpCodeType = iota
-	OpCodeError
-	IMADMOVU32
+	ExecUnitDefault ExecUnit = iota
+	ExecUnitError
+	ExecUnitINT
+	ExecUnitFP32
+	ExecUnitFP64
+	ExecUnitSFU
+	ExecUnitLDST
+	ExecUnitTensor
+)
+
+// String returns the name of the ALU type that implements the unit class.
+func (u ExecUnit) String() string {
+	switch u {
+	case ExecUnitINT:
+		return "int32"
+	case ExecUnitFP32:
+		return "fp32"
+	case ExecUnitFP64:
+		return "fp64"
+	case ExecUnitSFU:
+		return "sfu"
+	case ExecUnitLDST:
+		return "ldst"
+	case ExecUnitTensor:
+		return "tensor"
+	case ExecUnitError:
+		return "error"
+	default:
+		return "default"
+	}
+}
+
+// MemSpace is the memory space that an opcode accesses.
+type MemSpace int32
+
+const (
+	MemSpaceNone MemSpace = iota
+	MemSpaceGlobal
+	MemSpaceShared
 )
 
 type Opcode struct {
-	rawText string
-	opType  OpCodeType
-	varType VariableType
+	rawText  string
+	opType   OpCodeType
+	varType  VariableType
+	execUnit ExecUnit
 }
 
+// NewOpcode looks up an opcode by its full text first, and then by its base
+// name, which is the part before the first modifier. For example,
+// "LDG.E.64.SYS" is looked up as "LDG".
 func NewOpcode(rawText string) *Opcode {
 	op, ok := opcodeTable[rawText]
 	if !ok {
-		op = Opcode{rawText, OpCodeError, VariableError}
+		base := strings.SplitN(rawText, ".", 2)[0]
+		op, ok = opcodeTable[base]
+	}
+
+	if !ok {
+		op = Opcode{rawText, OpCodeError, VariableError, ExecUnitError}
 		log.Panic("Unknown opcode: ", rawText)
 	}
+
+	op.rawText = rawText
+
 	return &op
 }
 
@@ -48,10 +100,24 @@ func (op *Opcode) VariableType() VariableType {
 	return op.varType
 }
 
-var opcodeTable map[string]Opcode
+// ExecUnit returns the class of the functional units that execute the opcode.
+func (op *Opcode) ExecUnit() ExecUnit {
+	return op.execUnit
+}
+
+// MemSpace returns the memory space that the opcode accesses. The local memory
+// is part of the global memory.
+func (op *Opcode) MemSpace() MemSpace {
+	return memOpcodeTable[op.opType].space
+}
 
-func init() {
-	opcodeTable = make(map[string]Opcode)
+// IsStore checks if the opcode writes to the memory without returning data.
+func (op *Opcode) IsStore() bool {
+	return memOpcodeTable[op.opType].isStore
+}
 
-	opcodeTable["IMAD.MOV.U32"] = Opcode{"IMAD.MOV.U32", IMADMOVU32, VariableINT32}
+// IsBarrier checks if the opcode makes the warp wait until all the warps of
+// its thread block reach the barrier.
+func (op *Opcode) IsBarrier() bool {
+	return op.opType == BAR
 }
@@ -0,0 +1,406 @@
+package nvidia
+
+// OpCodeType identifies the base SASS opcodes of Volta, Turing, and Ampere.
+type OpCodeType int32
+
+const (
+	OpCodeDefault OpCodeType = iota
+	OpCodeError
+	IMADMOVU32
+
+	// Floating point 32-bit instructions
+	FADD
+	FADD32I
+	FCHK
+	FFMA32I
+	FFMA
+	FMNMX
+	FMUL
+	FMUL32I
+	FSEL
+	FSET
+	FSETP
+	FSWZADD
+
+	// Floating point 16-bit instructions, executed by the FP32 units
+	HADD2
+	HFMA2
+	HMNMX2
+	HMUL2
+	HSET2
+	HSETP2
+
+	// Floating point 64-bit instructions
+	DADD
+	DFMA
+	DMUL
+	DSETP
+
+	// Special function and conversion instructions
+	MUFU
+	BREV
+	FLO
+	POPC
+	F2F
+	F2I
+	I2F
+	FRND
+
+	// Tensor core instructions
+	HMMA
+	IMMA
+	BMMA
+	DMMA
+
+	// Integer instructions
+	BMSK
+	F2FP
+	I2I
+	I2IP
+	IABS
+	IADD
+	IADD3
+	IADD32I
+	IDP
+	IDP4A
+	IMAD
+	IMNMX
+	IMUL
+	IMUL32I
+	ISCADD
+	ISCADD32I
+	ISETP
+	LEA
+	LOP
+	LOP3
+	LOP32I
+	SGXT
+	SHF
+	SHL
+	SHR
+	VABSDIFF
+	VABSDIFF4
+	REDUX
+
+	// Movement, predicate, and warp-level instructions
+	MOV
+	MOV32I
+	MOVM
+	PRMT
+	SEL
+	CS2R
+	S2R
+	P2R
+	R2P
+	PLOP3
+	PSETP
+	VOTE
+	MATCH
+	NOP
+
+	// Uniform datapath instructions of Turing and Ampere
+	R2UR
+	S2UR
+	UBMSK
+	UBREV
+	UCLEA
+	UFLO
+	UIADD3
+	UIMAD
+	UISETP
+	ULDC
+	ULEA
+	ULOP
+	ULOP3
+	ULOP32I
+	UMOV
+	UP2UR
+	UPLOP3
+	UPOPC
+	UPRMT
+	UPSETP
+	UR2UP
+	USEL
+	USGXT
+	USHF
+	USHL
+	USHR
+	VOTEU
+
+	// Control instructions
+	BAR
+	BMOV
+	BPT
+	BRA
+	BREAK
+	BRX
+	BRXU
+	BSSY
+	BSYNC
+	CALL
+	DEPBAR
+	EXIT
+	JMP
+	JMX
+	JMXU
+	KILL
+	NANOSLEEP
+	RET
+	RPCMOV
+	WARPSYNC
+	YIELD
+	B2R
+	R2B
+	LEPC
+	PMTRIG
+	ARRIVES
+
+	// Memory, texture, and shuffle instructions
+	LD
+	LDC
+	LDG
+	LDL
+	LDS
+	LDSM
+	LDGSTS
+	LDGDEPBAR
+	ST
+	STG
+	STL
+	STS
+	ATOM
+	ATOMS
+	ATOMG
+	RED
+	CCTL
+	CCTLL
+	CCTLT
+	ERRBAR
+	MEMBAR
+	QSPC
+	SHFL
+	SUATOM
+	SULD
+	SURED
+	SUST
+	TEX
+	TLD
+	TLD4
+	TMML
+	TXD
+	TXQ
+)
+
+var opcodeTable map[string]Opcode
+
+type memOpcode struct {
+	space   MemSpace
+	isStore bool
+}
+
+// memOpcodeTable lists the opcodes that send requests to the memory system.
+// The atomics return data, so they are treated as loads.
+var memOpcodeTable = map[OpCodeType]memOpcode{
+	LD:     {MemSpaceGlobal, false},
+	LDG:    {MemSpaceGlobal, false},
+	LDL:    {MemSpaceGlobal, false},
+	LDGSTS: {MemSpaceGlobal, false},
+	ATOM:   {MemSpaceGlobal, false},
+	ATOMG:  {MemSpaceGlobal, false},
+	ST:     {MemSpaceGlobal, true},
+	STG:    {MemSpaceGlobal, true},
+	STL:    {MemSpaceGlobal, true},
+	RED:    {MemSpaceGlobal, true},
+	LDS:    {MemSpaceShared, false},
+	LDSM:   {MemSpaceShared, false},
+	ATOMS:  {MemSpaceShared, false},
+	STS:    {MemSpaceShared, true},
+}
+
+func addOpcode(
+	name string,
+	opType OpCodeType,
+	varType VariableType,
+	execUnit ExecUnit,
+) {
+	opcodeTable[name] = Opcode{name, opType, varType, execUnit}
+}
+
+//nolint:funlen
+func init() {
+	opcodeTable = make(map[string]Opcode)
+
+	addOpcode("IMAD.MOV.U32", IMADMOVU32, VariableINT32, ExecUnitINT)
+
+	addOpcode("FADD", FADD, VariableFP32, ExecUnitFP32)
+	addOpcode("FADD32I", FADD32I, VariableFP32, ExecUnitFP32)
+	addOpcode("FCHK", FCHK, VariableFP32, ExecUnitFP32)
+	addOpcode("FFMA32I", FFMA32I, VariableFP32, ExecUnitFP32)
+	addOpcode("FFMA", FFMA, VariableFP32, ExecUnitFP32)
+	addOpcode("FMNMX", FMNMX, VariableFP32, ExecUnitFP32)
+	addOpcode("FMUL", FMUL, VariableFP32, ExecUnitFP32)
+	addOpcode("FMUL32I", FMUL32I, VariableFP32, ExecUnitFP32)
+	addOpcode("FSEL", FSEL, VariableFP32, ExecUnitFP32)
+	addOpcode("FSET", FSET, VariableFP32, ExecUnitFP32)
+	addOpcode("FSETP", FSETP, VariableFP32, ExecUnitFP32)
+	addOpcode("FSWZADD", FSWZADD, VariableFP32, ExecUnitFP32)
+
+	addOpcode("HADD2", HADD2, VariableDefault, ExecUnitFP32)
+	addOpcode("HFMA2", HFMA2, VariableDefault, ExecUnitFP32)
+	addOpcode("HMNMX2", HMNMX2, VariableDefault, ExecUnitFP32)
+	addOpcode("HMUL2", HMUL2, VariableDefault, ExecUnitFP32)
+	addOpcode("HSET2", HSET2, VariableDefault, ExecUnitFP32)
+	addOpcode("HSETP2", HSETP2, VariableDefault, ExecUnitFP32)
+
+	addOpcode("DADD", DADD, VariableFP64, ExecUnitFP64)
+	addOpcode("DFMA", DFMA, VariableFP64, ExecUnitFP64)
+	addOpcode("DMUL", DMUL, VariableFP64, ExecUnitFP64)
+	addOpcode("DSETP", DSETP, VariableFP64, ExecUnitFP64)
+
+	addOpcode("MUFU", MUFU, VariableDefault, ExecUnitSFU)
+	addOpcode("BREV", BREV, VariableDefault, ExecUnitSFU)
+	addOpcode("FLO", FLO, VariableDefault, ExecUnitSFU)
+	addOpcode("POPC", POPC, VariableDefault, ExecUnitSFU)
+	addOpcode("F2F", F2F, VariableDefault, ExecUnitSFU)
+	addOpcode("F2I", F2I, VariableDefault, ExecUnitSFU)
+	addOpcode("I2F", I2F, VariableDefault, ExecUnitSFU)
+	addOpcode("FRND", FRND, VariableDefault, ExecUnitSFU)
+
+	addOpcode("HMMA", HMMA, VariableDefault, ExecUnitTensor)
+	addOpcode("IMMA", IMMA, VariableDefault, ExecUnitTensor)
+	addOpcode("BMMA", BMMA, VariableDefault, ExecUnitTensor)
+	addOpcode("DMMA", DMMA, VariableDefault, ExecUnitTensor)
+
+	addOpcode("BMSK", BMSK, VariableINT32, ExecUnitINT)
+	addOpcode("F2FP", F2FP, VariableINT32, ExecUnitINT)
+	addOpcode("I2I", I2I, VariableINT32, ExecUnitINT)
+	addOpcode("I2IP", I2IP, VariableINT32, ExecUnitINT)
+	addOpcode("IABS", IABS, VariableINT32, ExecUnitINT)
+	addOpcode("IADD", IADD, VariableINT32, ExecUnitINT)
+	addOpcode("IADD3", IADD3, VariableINT32, ExecUnitINT)
+	addOpcode("IADD32I", IADD32I, VariableINT32, ExecUnitINT)
+	addOpcode("IDP", IDP, VariableINT32, ExecUnitINT)
+	addOpcode("IDP4A", IDP4A, VariableINT32, ExecUnitINT)
+	addOpcode("IMAD", IMAD, VariableINT32, ExecUnitINT)
+	addOpcode("IMNMX", IMNMX, VariableINT32, ExecUnitINT)
+	addOpcode("IMUL", IMUL, VariableINT32, ExecUnitINT)
+	addOpcode("IMUL32I", IMUL32I, VariableINT32, ExecUnitINT)
+	addOpcode("ISCADD", ISCADD, VariableINT32, ExecUnitINT)
+	addOpcode("ISCADD32I", ISCADD32I, VariableINT32, ExecUnitINT)
+	addOpcode("ISETP", ISETP, VariableINT32, ExecUnitINT)
+	addOpcode("LEA", LEA, VariableINT32, ExecUnitINT)
+	addOpcode("LOP", LOP, VariableINT32, ExecUnitINT)
+	addOpcode("LOP3", LOP3, VariableINT32, ExecUnitINT)
+	addOpcode("LOP32I", LOP32I, VariableINT32, ExecUnitINT)
+	addOpcode("SGXT", SGXT, VariableINT32, ExecUnitINT)
+	addOpcode("SHF", SHF, VariableINT32, ExecUnitINT)
+	addOpcode("SHL", SHL, VariableINT32, ExecUnitINT)
+	addOpcode("SHR", SHR, VariableINT32, ExecUnitINT)
+	addOpcode("VABSDIFF", VABSDIFF, VariableINT32, ExecUnitINT)
+	addOpcode("VABSDIFF4", VABSDIFF4, VariableINT32, ExecUnitINT)
+	addOpcode("REDUX", REDUX, VariableINT32, ExecUnitINT)
+
+	addOpcode("MOV", MOV, VariableDefault, ExecUnitINT)
+	addOpcode("MOV32I", MOV32I, VariableDefault, ExecUnitINT)
+	addOpcode("MOVM", MOVM, VariableDefault, ExecUnitINT)
+	addOpcode("PRMT", PRMT, VariableDefault, ExecUnitINT)
+	addOpcode("SEL", SEL, VariableDefault, ExecUnitINT)
+	addOpcode("CS2R", CS2R, VariableDefault, ExecUnitINT)
+	addOpcode("S2R", S2R, VariableDefault, ExecUnitINT)
+	addOpcode("P2R", P2R, VariableDefault, ExecUnitINT)
+	addOpcode("R2P", R2P, VariableDefault, ExecUnitINT)
+	addOpcode("PLOP3", PLOP3, VariableDefault, ExecUnitINT)
+	addOpcode("PSETP", PSETP, VariableDefault, ExecUnitINT)
+	addOpcode("VOTE", VOTE, VariableDefault, ExecUnitINT)
+	addOpcode("MATCH", MATCH, VariableDefault, ExecUnitINT)
+	addOpcode("NOP", NOP, VariableDefault, ExecUnitINT)
+
+	addOpcode("R2UR", R2UR, VariableDefault, ExecUnitINT)
+	addOpcode("S2UR", S2UR, VariableDefault, ExecUnitINT)
+	addOpcode("UBMSK", UBMSK, VariableDefault, ExecUnitINT)
+	addOpcode("UBREV", UBREV, VariableDefault, ExecUnitINT)
+	addOpcode("UCLEA", UCLEA, VariableDefault, ExecUnitINT)
+	addOpcode("UFLO", UFLO, VariableDefault, ExecUnitINT)
+	addOpcode("UIADD3", UIADD3, VariableDefault, ExecUnitINT)
+	addOpcode("UIMAD", UIMAD, VariableDefault, ExecUnitINT)
+	addOpcode("UISETP", UISETP, VariableDefault, ExecUnitINT)
+	addOpcode("ULDC", ULDC, VariableDefault, ExecUnitINT)
+	addOpcode("ULEA", ULEA, VariableDefault, ExecUnitINT)
+	addOpcode("ULOP", ULOP, VariableDefault, ExecUnitINT)
+	addOpcode("ULOP3", ULOP3, VariableDefault, ExecUnitINT)
+	addOpcode("ULOP32I", ULOP32I, VariableDefault, ExecUnitINT)
+	addOpcode("UMOV", UMOV, VariableDefault, ExecUnitINT)
+	addOpcode("UP2UR", UP2UR, VariableDefault, ExecUnitINT)
+	addOpcode("UPLOP3", UPLOP3, VariableDefault, ExecUnitINT)
+	addOpcode("UPOPC", UPOPC, VariableDefault, ExecUnitINT)
+	addOpcode("UPRMT", UPRMT, VariableDefault, ExecUnitINT)
+	addOpcode("UPSETP", UPSETP, VariableDefault, ExecUnitINT)
+	addOpcode("UR2UP", UR2UP, VariableDefault, ExecUnitINT)
+	addOpcode("USEL", USEL, VariableDefault, ExecUnitINT)
+	addOpcode("USGXT", USGXT, VariableDefault, ExecUnitINT)
+	addOpcode("USHF", USHF, VariableDefault, ExecUnitINT)
+	addOpcode("USHL", USHL, VariableDefault, ExecUnitINT)
+	addOpcode("USHR", USHR, VariableDefault, ExecUnitINT)
+	addOpcode("VOTEU", VOTEU, VariableDefault, ExecUnitINT)
+
+	addOpcode("BAR", BAR, VariableDefault, ExecUnitINT)
+	addOpcode("BMOV", BMOV, VariableDefault, ExecUnitINT)
+	addOpcode("BPT", BPT, VariableDefault, ExecUnitINT)
+	addOpcode("BRA", BRA, VariableDefault, ExecUnitINT)
+	addOpcode("BREAK", BREAK, VariableDefault, ExecUnitINT)
+	addOpcode("BRX", BRX, VariableDefault, ExecUnitINT)
+	addOpcode("BRXU", BRXU, VariableDefault, ExecUnitINT)
+	addOpcode("BSSY", BSSY, VariableDefault, ExecUnitINT)
+	addOpcode("BSYNC", BSYNC, VariableDefault, ExecUnitINT)
+	addOpcode("CALL", CALL, VariableDefault, ExecUnitINT)
+	addOpcode("DEPBAR", DEPBAR, VariableDefault, ExecUnitINT)
+	addOpcode("EXIT", EXIT, VariableDefault, ExecUnitINT)
+	addOpcode("JMP", JMP, VariableDefault, ExecUnitINT)
+	addOpcode("JMX", JMX, VariableDefault, ExecUnitINT)
+	addOpcode("JMXU", JMXU, VariableDefault, ExecUnitINT)
+	addOpcode("KILL", KILL, VariableDefault, ExecUnitINT)
+	addOpcode("NANOSLEEP", NANOSLEEP, VariableDefault, ExecUnitINT)
+	addOpcode("RET", RET, VariableDefault, ExecUnitINT)
+	addOpcode("RPCMOV", RPCMOV, VariableDefault, ExecUnitINT)
+	addOpcode("WARPSYNC", WARPSYNC, VariableDefault, ExecUnitINT)
+	addOpcode("YIELD", YIELD, VariableDefault, ExecUnitINT)
+	addOpcode("B2R", B2R, VariableDefault, ExecUnitINT)
+	addOpcode("R2B", R2B, VariableDefault, ExecUnitINT)
+	addOpcode("LEPC", LEPC, VariableDefault, ExecUnitINT)
+	addOpcode("PMTRIG", PMTRIG, VariableDefault, ExecUnitINT)
+	addOpcode("ARRIVES", ARRIVES, VariableDefault, ExecUnitINT)
+
+	addOpcode("LD", LD, VariableDefault, ExecUnitLDST)
+	addOpcode("LDC", LDC, VariableDefault, ExecUnitLDST)
+	addOpcode("LDG", LDG, VariableDefault, ExecUnitLDST)
+	addOpcode("LDL", LDL, VariableDefault, ExecUnitLDST)
+	addOpcode("LDS", LDS, VariableDefault, ExecUnitLDST)
+	addOpcode("LDSM", LDSM, VariableDefault, ExecUnitLDST)
+	addOpcode("LDGSTS", LDGSTS, VariableDefault, ExecUnitLDST)
+	addOpcode("LDGDEPBAR", LDGDEPBAR, VariableDefault, ExecUnitLDST)
+	addOpcode("ST", ST, VariableDefault, ExecUnitLDST)
+	addOpcode("STG", STG, VariableDefault, ExecUnitLDST)
+	addOpcode("STL", STL, VariableDefault, ExecUnitLDST)
+	addOpcode("STS", STS, VariableDefault, ExecUnitLDST)
+	addOpcode("ATOM", ATOM, VariableDefault, ExecUnitLDST)
+	addOpcode("ATOMS", ATOMS, VariableDefault, ExecUnitLDST)
+	addOpcode("ATOMG", ATOMG, VariableDefault, ExecUnitLDST)
+	addOpcode("RED", RED, VariableDefault, ExecUnitLDST)
+	addOpcode("CCTL", CCTL, VariableDefault, ExecUnitLDST)
+	addOpcode("CCTLL", CCTLL, VariableDefault, ExecUnitLDST)
+	addOpcode("CCTLT", CCTLT, VariableDefault, ExecUnitLDST)
+	addOpcode("ERRBAR", ERRBAR, VariableDefault, ExecUnitLDST)
+	addOpcode("MEMBAR", MEMBAR, VariableDefault, ExecUnitLDST)
+	addOpcode("QSPC", QSPC, VariableDefault, ExecUnitLDST)
+	addOpcode("SHFL", SHFL, VariableDefault, ExecUnitLDST)
+	addOpcode("SUATOM", SUATOM, VariableDefault, ExecUnitLDST)
+	addOpcode("SULD", SULD, VariableDefault, ExecUnitLDST)
+	addOpcode("SURED", SURED, VariableDefault, ExecUnitLDST)
+	addOpcode("SUST", SUST, VariableDefault, ExecUnitLDST)
+	addOpcode("TEX", TEX, VariableDefault, ExecUnitLDST)
+	addOpcode("TLD", TLD, VariableDefault, ExecUnitLDST)
+	addOpcode("TLD4", TLD4, VariableDefault, ExecUnitLDST)
+	addOpcode("TMML", TMML, VariableDefault, ExecUnitLDST)
+	addOpcode("TXD", TXD, VariableDefault, ExecUnitLDST)
+	addOpcode("TXQ", TXQ, VariableDefault, ExecUnitLDST)
+}
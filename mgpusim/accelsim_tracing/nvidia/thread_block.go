@@ -1,5 +1,8 @@
 package nvidia
 
+// WarpSize is the number of threads in a warp.
+const WarpSize = 32
+
 type ThreadBlock struct {
 	WarpNum int
 	Warps   []*Warp
@@ -23,4 +26,8 @@ type Instruction struct {
 	MemAddress        int64
 	MemAddressSuffix1 int32
 	MemAddressSuffix2 []int32
+
+	// MemAddresses are the addresses that the active threads access, in the
+	// order of the lanes.
+	MemAddresses []uint64
 }
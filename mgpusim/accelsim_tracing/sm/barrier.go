@@ -0,0 +1,55 @@
+package sm
+
+import (
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/message"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
+)
+
+// barrierWarp is a warp that waits at a barrier, and the port of the SM unit
+// that runs it.
+type barrierWarp struct {
+	warp *nvidia.Warp
+	unit sim.Port
+}
+
+func (s *SM) arriveAtBarrier(msg *message.BarrierArriveMsg) {
+	tb := s.warpToTB[msg.Warp]
+	tb.barrierWarps = append(tb.barrierWarps, barrierWarp{
+		warp: msg.Warp,
+		unit: msg.Src,
+	})
+
+	s.checkBarrier(tb)
+}
+
+// checkBarrier releases the warps that wait at the barrier of the thread
+// block once all the warps that have not exited arrive. The warps that are
+// not dispatched yet count as not arrived.
+func (s *SM) checkBarrier(tb *threadBlockState) {
+	if len(tb.barrierWarps) == 0 ||
+		len(tb.barrierWarps) < tb.numUnfinishedWarps {
+		return
+	}
+
+	s.releasingWarps = append(s.releasingWarps, tb.barrierWarps...)
+	tb.barrierWarps = nil
+}
+
+func (s *SM) releaseBarrierWarps(now sim.VTimeInSec) bool {
+	if len(s.releasingWarps) == 0 {
+		return false
+	}
+
+	w := s.releasingWarps[0]
+	msg := message.NewBarrierReleaseMsg(now, s.ToSMUnits, w.unit, w.warp)
+
+	err := s.ToSMUnits.Send(msg)
+	if err != nil {
+		return false
+	}
+
+	s.releasingWarps = s.releasingWarps[1:]
+
+	return true
+}
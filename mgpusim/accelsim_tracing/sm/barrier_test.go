@@ -0,0 +1,157 @@
+package sm
+
+import (
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/message"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
+)
+
+var _ = Describe("Barrier", func() {
+	var (
+		mockCtrl  *gomock.Controller
+		toSMUnits *MockPort
+		unit0     *MockPort
+		unit1     *MockPort
+		s         *SM
+		warps     []*nvidia.Warp
+		tb        *threadBlockState
+	)
+
+	receive := func(msg sim.Msg) {
+		toSMUnits.EXPECT().Peek().Return(msg)
+		toSMUnits.EXPECT().Retrieve(sim.VTimeInSec(10))
+
+		Expect(s.receiveFromSMUnits(10)).To(BeTrue())
+	}
+
+	arrive := func(unit sim.Port, w *nvidia.Warp) {
+		receive(message.NewBarrierArriveMsg(10, unit, toSMUnits, w))
+	}
+
+	exit := func(unit sim.Port, w *nvidia.Warp) {
+		receive(message.NewWarpDoneMsg(10, unit, toSMUnits, w))
+	}
+
+	releasedWarps := func() []*nvidia.Warp {
+		var released []*nvidia.Warp
+		for _, w := range s.releasingWarps {
+			released = append(released, w.warp)
+		}
+
+		return released
+	}
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		toSMUnits = NewMockPort(mockCtrl)
+		unit0 = NewMockPort(mockCtrl)
+		unit1 = NewMockPort(mockCtrl)
+
+		warps = []*nvidia.Warp{{}, {}, {}}
+		tb = &threadBlockState{
+			tb:                 &nvidia.ThreadBlock{WarpNum: 3, Warps: warps},
+			numUnfinishedWarps: 3,
+		}
+
+		s = &SM{
+			ToSMUnits:   toSMUnits,
+			unitIndex:   map[sim.Port]int{unit0: 0, unit1: 1},
+			unitWarpNum: []int{2, 1},
+			warpToTB:    make(map[*nvidia.Warp]*threadBlockState),
+		}
+		s.threadBlocks = append(s.threadBlocks, tb)
+		for _, w := range warps {
+			s.warpToTB[w] = tb
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	It("should hold the warps until all the warps arrive", func() {
+		arrive(unit0, warps[0])
+		arrive(unit1, warps[2])
+
+		Expect(s.releasingWarps).To(BeEmpty())
+
+		arrive(unit0, warps[1])
+
+		Expect(releasedWarps()).To(ConsistOf(warps[0], warps[1], warps[2]))
+		Expect(tb.barrierWarps).To(BeEmpty())
+	})
+
+	It("should not count the warps that have exited", func() {
+		arrive(unit0, warps[0])
+		exit(unit1, warps[2])
+
+		Expect(s.releasingWarps).To(BeEmpty())
+
+		arrive(unit0, warps[1])
+
+		Expect(releasedWarps()).To(ConsistOf(warps[0], warps[1]))
+	})
+
+	It("should release the warps when the last warp that has not arrived exits",
+		func() {
+			arrive(unit0, warps[0])
+			arrive(unit0, warps[1])
+			exit(unit1, warps[2])
+
+			Expect(releasedWarps()).To(ConsistOf(warps[0], warps[1]))
+			Expect(s.unitWarpNum).To(Equal([]int{2, 0}))
+		})
+
+	It("should send the release to the SM unit of the warp", func() {
+		arrive(unit0, warps[0])
+		arrive(unit0, warps[1])
+		arrive(unit1, warps[2])
+
+		var sent []*message.BarrierReleaseMsg
+		toSMUnits.EXPECT().
+			Send(gomock.Any()).
+			DoAndReturn(func(msg sim.Msg) *sim.SendError {
+				sent = append(sent, msg.(*message.BarrierReleaseMsg))
+				return nil
+			}).
+			Times(3)
+
+		for i := 0; i < 3; i++ {
+			Expect(s.releaseBarrierWarps(11)).To(BeTrue())
+		}
+
+		Expect(s.releaseBarrierWarps(11)).To(BeFalse())
+		Expect(sent[0].Warp).To(BeIdenticalTo(warps[0]))
+		Expect(sent[0].Dst).To(BeIdenticalTo(unit0))
+		Expect(sent[2].Warp).To(BeIdenticalTo(warps[2]))
+		Expect(sent[2].Dst).To(BeIdenticalTo(unit1))
+	})
+
+	It("should retry the release if the port is busy", func() {
+		arrive(unit0, warps[0])
+		arrive(unit0, warps[1])
+		arrive(unit1, warps[2])
+
+		toSMUnits.EXPECT().
+			Send(gomock.Any()).
+			Return(sim.NewSendError())
+
+		Expect(s.releaseBarrierWarps(11)).To(BeFalse())
+		Expect(s.releasingWarps).To(HaveLen(3))
+	})
+
+	It("should wait for the next barrier after a release", func() {
+		arrive(unit0, warps[0])
+		arrive(unit0, warps[1])
+		arrive(unit1, warps[2])
+		s.releasingWarps = nil
+
+		arrive(unit0, warps[0])
+
+		Expect(s.releasingWarps).To(BeEmpty())
+		Expect(tb.barrierWarps).To(HaveLen(1))
+	})
+})
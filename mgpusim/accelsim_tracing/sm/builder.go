@@ -1,22 +1,51 @@
 package sm
 
 import (
+	"fmt"
+
+	"github.com/sarchlab/akita/v3/mem/cache/writearound"
+	"github.com/sarchlab/akita/v3/mem/idealmemcontroller"
+	"github.com/sarchlab/akita/v3/metrics"
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/memory"
 	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
 	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/smunit"
 )
 
+// SM is a streaming multiprocessor. It holds a few thread blocks and
+// distributes their warps to its SM units.
 type SM struct {
+	*sim.TickingComponent
+
+	ToGPC     sim.Port
+	ToSMUnits sim.Port
+
 	meta       *smMetaData
 	dispatcher smDispatcher
 	smUnits    []*smunit.SMUnit
+	l1Cache    *writearound.Cache
+	sharedMem  *idealmemcontroller.Comp
+
+	unitIndex    map[sim.Port]int
+	unitWarpNum  []int
+	threadBlocks []*threadBlockState
+	warpToTB     map[*nvidia.Warp]*threadBlockState
+	finishedTBs  []*threadBlockState
+
+	releasingWarps []barrierWarp
 }
 
 type smMetaData struct {
+	engine sim.Engine
+	freq   sim.Freq
+
 	smUnitNum int32
 
 	smStrategy     string
 	smUnitStrategy string
 
+	maxWarpNumPerSMUnit int32
+
 	l2CacheSize int32
 	l1CacheSize int32
 	l0CacheSize int32
@@ -24,26 +53,43 @@ type smMetaData struct {
 	registerFileSize int32
 	laneSize         int32
 
+	l1Latency        int32
+	sharedMemSize    int32
+	sharedMemLatency int32
+	pageTable        *memory.PageTable
+	metrics          *metrics.Registry
+
 	alus []struct {
-		aluType string
-		aluNum  int32
+		aluType            string
+		aluNum             int32
+		latency            int32
+		initiationInterval int32
 	}
 }
 
 func NewSM() *SM {
 	return &SM{
 		meta: &smMetaData{
+			freq: 1 * sim.GHz,
+
 			smUnitNum: 0,
 
 			smStrategy:     "default",
 			smUnitStrategy: "default",
 
+			maxWarpNumPerSMUnit: 16,
+
 			l1CacheSize: 0,
 			l0CacheSize: 0,
 
 			registerFileSize: 0,
 			laneSize:         0,
 
+			l1Latency:        20,
+			sharedMemSize:    0,
+			sharedMemLatency: 20,
+			metrics:          metrics.DefaultRegistry(),
+
 			alus: nil,
 		},
 		dispatcher: nil,
@@ -51,6 +97,16 @@ func NewSM() *SM {
 	}
 }
 
+func (s *SM) WithEngine(engine sim.Engine) *SM {
+	s.meta.engine = engine
+	return s
+}
+
+func (s *SM) WithFreq(freq sim.Freq) *SM {
+	s.meta.freq = freq
+	return s
+}
+
 func (s *SM) WithSMStrategy(strategy string) *SM {
 	s.meta.smStrategy = strategy
 	return s
@@ -66,6 +122,12 @@ func (s *SM) WithSMUnitStrategy(strategy string) *SM {
 	return s
 }
 
+// WithMaxWarpNumPerSMUnit sets the number of warps that each SM unit can hold.
+func (s *SM) WithMaxWarpNumPerSMUnit(num int32) *SM {
+	s.meta.maxWarpNumPerSMUnit = num
+	return s
+}
+
 func (s *SM) WithL1CacheSize(size int32) *SM {
 	s.meta.l1CacheSize = size
 	return s
@@ -76,6 +138,38 @@ func (s *SM) WithL0CacheSize(size int32) *SM {
 	return s
 }
 
+// WithL1Latency sets the number of cycles that the L1 cache takes to access a
+// cache line.
+func (s *SM) WithL1Latency(cycles int32) *SM {
+	s.meta.l1Latency = cycles
+	return s
+}
+
+func (s *SM) WithSharedMemSize(size int32) *SM {
+	s.meta.sharedMemSize = size
+	return s
+}
+
+// WithSharedMemLatency sets the number of cycles that the shared memory takes
+// to respond.
+func (s *SM) WithSharedMemLatency(cycles int32) *SM {
+	s.meta.sharedMemLatency = cycles
+	return s
+}
+
+// WithPageTable sets the page table that translates the global addresses.
+func (s *SM) WithPageTable(pageTable *memory.PageTable) *SM {
+	s.meta.pageTable = pageTable
+	return s
+}
+
+// WithMetricsRegistry sets the registry that the metrics of the L1 cache are
+// registered in. By default, the default registry is used.
+func (s *SM) WithMetricsRegistry(registry *metrics.Registry) *SM {
+	s.meta.metrics = registry
+	return s
+}
+
 func (s *SM) WithRegisterFileSize(size int32) *SM {
 	s.meta.registerFileSize = size
 	return s
@@ -87,33 +181,124 @@ func (s *SM) WithLaneSize(size int32) *SM {
 }
 
 func (s *SM) WithALU(aluType string, aluNum int32) *SM {
+	return s.WithALUConfig(aluType, aluNum, 0, 0)
+}
+
+// WithALUConfig adds a group of ALUs with the given latency and initiation
+// interval. A zero latency or interval keeps the default of the ALU type.
+func (s *SM) WithALUConfig(
+	aluType string,
+	aluNum, latency, initiationInterval int32,
+) *SM {
 	s.meta.alus = append(s.meta.alus, struct {
-		aluType string
-		aluNum  int32
-	}{aluType: aluType, aluNum: aluNum})
+		aluType            string
+		aluNum             int32
+		latency            int32
+		initiationInterval int32
+	}{
+		aluType:            aluType,
+		aluNum:             aluNum,
+		latency:            latency,
+		initiationInterval: initiationInterval,
+	})
 	return s
 }
 
-func (s *SM) Build() {
+func (s *SM) Build(name string) {
+	if s.meta.engine == nil {
+		panic("engine is not set")
+	}
+
+	s.TickingComponent = sim.NewTickingComponent(
+		name, s.meta.engine, s.meta.freq, s)
+	s.ToGPC = sim.NewLimitNumMsgPort(s, 4, name+".ToGPC")
+	s.ToSMUnits = sim.NewLimitNumMsgPort(s, 4, name+".ToSMUnits")
+	conn := sim.NewDirectConnection(
+		name+".SMUnitConn", s.meta.engine, s.meta.freq)
+	conn.PlugIn(s.ToSMUnits, 4)
+
 	s.buildDispatcher()
+	memConn := s.buildMemory(name)
+	s.unitIndex = make(map[sim.Port]int)
+	s.unitWarpNum = make([]int, s.meta.smUnitNum)
+	s.warpToTB = make(map[*nvidia.Warp]*threadBlockState)
 	s.smUnits = make([]*smunit.SMUnit, s.meta.smUnitNum)
 	for i := 0; i < int(s.meta.smUnitNum); i++ {
 		s.smUnits[i] = smunit.NewSMUnit().
-		WithSMUnitStrategy(s.meta.smUnitStrategy).
+			WithEngine(s.meta.engine).
+			WithFreq(s.meta.freq).
+			WithSMUnitStrategy(s.meta.smUnitStrategy).
 			WithL0CacheSize(s.meta.l0CacheSize).
 			WithRegisterFileSize(s.meta.registerFileSize).
-			WithLaneSize(s.meta.laneSize)
+			WithLaneSize(s.meta.laneSize).
+			WithL1Port(s.l1Cache.GetPortByName("Top")).
+			WithSharedMem(s.sharedMem.GetPortByName("Top"),
+				uint64(s.meta.sharedMemSize/nvidia.BYTE)).
+			WithPageTable(s.meta.pageTable)
 		for _, alu := range s.meta.alus {
-			s.smUnits[i].WithALU(alu.aluType, alu.aluNum)
+			s.smUnits[i].WithALUConfig(alu.aluType, alu.aluNum,
+				alu.latency, alu.initiationInterval)
 		}
-		s.smUnits[i].Build()
+		s.smUnits[i].Build(fmt.Sprintf("%s.SMUnit[%d]", name, i))
+
+		conn.PlugIn(s.smUnits[i].ToSM, 4)
+		memConn.PlugIn(s.smUnits[i].ToMem, 64)
+		s.unitIndex[s.smUnits[i].ToSM] = i
+	}
+}
+
+// buildMemory builds the L1 cache and the shared memory, and returns the
+// connection between them and the SM units.
+func (s *SM) buildMemory(name string) sim.Connection {
+	if s.meta.l1CacheSize <= 0 || s.meta.sharedMemSize <= 0 {
+		panic("L1 cache size and shared memory size must be positive")
+	}
+
+	if s.meta.pageTable == nil {
+		panic("page table is not set")
 	}
+
+	s.l1Cache = writearound.NewBuilder().
+		WithEngine(s.meta.engine).
+		WithFreq(s.meta.freq).
+		WithMetricsRegistry(s.meta.metrics).
+		WithLog2BlockSize(7).
+		WithTotalByteSize(uint64(s.meta.l1CacheSize / nvidia.BYTE)).
+		WithWayAssociativity(4).
+		WithNumBanks(4).
+		WithBankLatency(int(s.meta.l1Latency)).
+		WithNumMSHREntry(32).
+		WithNumReqsPerCycle(4).
+		WithMaxNumConcurrentTrans(256).
+		Build(name + ".L1")
+
+	s.sharedMem = idealmemcontroller.MakeBuilder().
+		WithEngine(s.meta.engine).
+		WithFreq(s.meta.freq).
+		WithLatency(int(s.meta.sharedMemLatency)).
+		WithWidth(4).
+		WithNewStorage(uint64(s.meta.sharedMemSize / nvidia.BYTE)).
+		WithTopBufSize(64).
+		Build(name + ".SharedMem")
+
+	conn := sim.NewDirectConnection(name+".MemConn", s.meta.engine, s.meta.freq)
+	conn.PlugIn(s.l1Cache.GetPortByName("Top"), 64)
+	conn.PlugIn(s.sharedMem.GetPortByName("Top"), 64)
+
+	return conn
+}
+
+// L1Cache returns the L1 cache of the SM.
+func (s *SM) L1Cache() *writearound.Cache {
+	return s.l1Cache
 }
 
-func (s *SM) IsFree() bool {
-	return true
+// SMUnits returns the SM units of the SM.
+func (s *SM) SMUnits() []*smunit.SMUnit {
+	return s.smUnits
 }
 
-func (s *SM) Execute(tb *nvidia.ThreadBlock) {
-	s.dispatcher.dispatch(tb)
+// MaxWarpNum returns the number of warps that the SM can hold.
+func (s *SM) MaxWarpNum() int {
+	return int(s.meta.smUnitNum * s.meta.maxWarpNumPerSMUnit)
 }
@@ -1,7 +1,12 @@
 package sm
 
-import "github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
+import (
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/message"
+)
 
+// defaultDispatcher sends the warps in order, each to the SM unit that holds
+// the fewest warps.
 type defaultDispatcher struct {
 	parent *SM
 }
@@ -15,20 +20,38 @@ func (d *defaultDispatcher) withParent(sm *SM) smDispatcher {
 	return d
 }
 
-func (d *defaultDispatcher) dispatch(tb *nvidia.ThreadBlock) {
-	for _, warp := range tb.Warps {
-		for {
-			flag := false
-			for _, smUnit := range d.parent.smUnits {
-				if smUnit.IsFree() {
-					smUnit.Execute(warp)
-					flag = true
-					break
-				}
-			}
-			if flag {
-				break
-			}
+func (d *defaultDispatcher) dispatch(now sim.VTimeInSec) bool {
+	tb := d.parent.nextThreadBlockToDispatch()
+	if tb == nil {
+		return false
+	}
+
+	unit := -1
+	for i, n := range d.parent.unitWarpNum {
+		if n >= int(d.parent.meta.maxWarpNumPerSMUnit) {
+			continue
+		}
+
+		if unit < 0 || n < d.parent.unitWarpNum[unit] {
+			unit = i
 		}
 	}
+
+	if unit < 0 {
+		return false
+	}
+
+	warp := tb.warpsToDispatch[0]
+	msg := message.NewWarpMsg(now,
+		d.parent.ToSMUnits, d.parent.smUnits[unit].ToSM, warp)
+
+	err := d.parent.ToSMUnits.Send(msg)
+	if err != nil {
+		return false
+	}
+
+	tb.warpsToDispatch = tb.warpsToDispatch[1:]
+	d.parent.unitWarpNum[unit]++
+
+	return true
 }
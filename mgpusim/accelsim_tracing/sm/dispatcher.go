@@ -1,10 +1,11 @@
 package sm
 
-import "github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
+import "github.com/sarchlab/akita/v3/sim"
 
+// smDispatcher distributes the warps of the thread blocks to the SM units.
 type smDispatcher interface {
 	withParent(sm *SM) smDispatcher
-	dispatch(tb *nvidia.ThreadBlock)
+	dispatch(now sim.VTimeInSec) bool
 }
 
 func (s *SM) buildDispatcher() {
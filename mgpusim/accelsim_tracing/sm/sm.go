@@ -0,0 +1,136 @@
+package sm
+
+import (
+	"fmt"
+
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/message"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
+)
+
+// threadBlockState tracks the warps of a thread block that runs on an SM.
+type threadBlockState struct {
+	tb                 *nvidia.ThreadBlock
+	gpcSrc             sim.Port
+	warpsToDispatch    []*nvidia.Warp
+	numUnfinishedWarps int
+	barrierWarps       []barrierWarp
+}
+
+// Tick updates the state of the SM by one cycle.
+func (s *SM) Tick(now sim.VTimeInSec) (madeProgress bool) {
+	madeProgress = s.reportFinishedThreadBlocks(now) || madeProgress
+	madeProgress = s.releaseBarrierWarps(now) || madeProgress
+	madeProgress = s.receiveFromSMUnits(now) || madeProgress
+	madeProgress = s.dispatcher.dispatch(now) || madeProgress
+	madeProgress = s.receiveThreadBlock(now) || madeProgress
+
+	return madeProgress
+}
+
+func (s *SM) receiveThreadBlock(now sim.VTimeInSec) bool {
+	item := s.ToGPC.Peek()
+	if item == nil {
+		return false
+	}
+
+	msg, ok := item.(*message.ThreadBlockMsg)
+	if !ok {
+		panic(fmt.Sprintf("cannot handle message %T", item))
+	}
+
+	tb := &threadBlockState{
+		tb:                 msg.ThreadBlock,
+		gpcSrc:             msg.Src,
+		warpsToDispatch:    msg.ThreadBlock.Warps,
+		numUnfinishedWarps: len(msg.ThreadBlock.Warps),
+	}
+	for _, w := range msg.ThreadBlock.Warps {
+		s.warpToTB[w] = tb
+	}
+
+	if tb.numUnfinishedWarps == 0 {
+		s.finishedTBs = append(s.finishedTBs, tb)
+	} else {
+		s.threadBlocks = append(s.threadBlocks, tb)
+	}
+
+	s.ToGPC.Retrieve(now)
+
+	return true
+}
+
+func (s *SM) nextThreadBlockToDispatch() *threadBlockState {
+	for _, tb := range s.threadBlocks {
+		if len(tb.warpsToDispatch) > 0 {
+			return tb
+		}
+	}
+
+	return nil
+}
+
+func (s *SM) receiveFromSMUnits(now sim.VTimeInSec) bool {
+	item := s.ToSMUnits.Peek()
+	if item == nil {
+		return false
+	}
+
+	switch msg := item.(type) {
+	case *message.WarpDoneMsg:
+		s.finishWarp(msg)
+	case *message.BarrierArriveMsg:
+		s.arriveAtBarrier(msg)
+	default:
+		panic(fmt.Sprintf("cannot handle message %T", item))
+	}
+
+	s.ToSMUnits.Retrieve(now)
+
+	return true
+}
+
+func (s *SM) finishWarp(msg *message.WarpDoneMsg) {
+	s.unitWarpNum[s.unitIndex[msg.Src]]--
+
+	tb := s.warpToTB[msg.Warp]
+	delete(s.warpToTB, msg.Warp)
+	tb.numUnfinishedWarps--
+
+	if tb.numUnfinishedWarps == 0 {
+		s.removeThreadBlock(tb)
+		s.finishedTBs = append(s.finishedTBs, tb)
+
+		return
+	}
+
+	// The warps that have exited do not take part in the barriers anymore.
+	s.checkBarrier(tb)
+}
+
+func (s *SM) removeThreadBlock(tb *threadBlockState) {
+	for i, t := range s.threadBlocks {
+		if t == tb {
+			s.threadBlocks = append(s.threadBlocks[:i], s.threadBlocks[i+1:]...)
+			return
+		}
+	}
+}
+
+func (s *SM) reportFinishedThreadBlocks(now sim.VTimeInSec) bool {
+	if len(s.finishedTBs) == 0 {
+		return false
+	}
+
+	tb := s.finishedTBs[0]
+	msg := message.NewThreadBlockDoneMsg(now, s.ToGPC, tb.gpcSrc, tb.tb)
+
+	err := s.ToGPC.Send(msg)
+	if err != nil {
+		return false
+	}
+
+	s.finishedTBs = s.finishedTBs[1:]
+
+	return true
+}
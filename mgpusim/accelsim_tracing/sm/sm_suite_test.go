@@ -0,0 +1,15 @@
+package sm
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+//go:generate mockgen -destination "mock_sim_test.go" -package $GOPACKAGE -write_package_comment=false github.com/sarchlab/akita/v3/sim Port
+
+func TestSM(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "SM")
+}
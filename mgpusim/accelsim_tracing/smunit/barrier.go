@@ -0,0 +1,43 @@
+package smunit
+
+import (
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/message"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
+)
+
+// arriveAtBarrier stops the warp from issuing and queues the report to the
+// SM, which tracks the warps of the thread block that have arrived.
+func (s *SMUnit) arriveAtBarrier(w *warpState) {
+	w.atBarrier = true
+	s.barrierArrivals = append(s.barrierArrivals, w)
+}
+
+func (s *SMUnit) sendBarrierArrival(now sim.VTimeInSec) bool {
+	if len(s.barrierArrivals) == 0 {
+		return false
+	}
+
+	w := s.barrierArrivals[0]
+	msg := message.NewBarrierArriveMsg(now, s.ToSM, w.smSrc, w.warp)
+
+	err := s.ToSM.Send(msg)
+	if err != nil {
+		return false
+	}
+
+	s.barrierArrivals = s.barrierArrivals[1:]
+
+	return true
+}
+
+func (s *SMUnit) releaseBarrier(warp *nvidia.Warp) {
+	for _, w := range s.warps {
+		if w.warp == warp {
+			w.atBarrier = false
+			return
+		}
+	}
+
+	panic("cannot find the warp to release from the barrier")
+}
@@ -1,18 +1,40 @@
 package smunit
 
 import (
+	"github.com/sarchlab/akita/v3/sim"
 	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/alu"
-	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/memory"
 )
 
+// SMUnit is a processing block of an SM. It holds a few warps, issues one
+// instruction per cycle from one of them, and executes the instructions with
+// its ALUs.
 type SMUnit struct {
+	*sim.TickingComponent
+
+	ToSM  sim.Port
+	ToMem sim.Port
+
 	meta         *smUnitMetaData
 	dispatcher   smUnitDispatcher
 	registerFile *RegisterFile
 	aluGroup     []*alu.ALUGroup
+
+	warps          []*warpState
+	finishedWarps  []*warpState
+	numIssuedInsts uint64
+
+	coalescer   *memory.Coalescer
+	memAccesses []*memAccess
+	inflightMem map[string]*memAccess
+
+	barrierArrivals []*warpState
 }
 
 type smUnitMetaData struct {
+	engine sim.Engine
+	freq   sim.Freq
+
 	smUnitStrategy string
 
 	l0CacheSize int32
@@ -20,15 +42,24 @@ type smUnitMetaData struct {
 	registerFileSize int32
 	laneSize         int32
 
+	l1Port        sim.Port
+	sharedMemPort sim.Port
+	sharedMemSize uint64
+	pageTable     *memory.PageTable
+
 	alus []struct {
-		aluType string
-		aluNum  int32
+		aluType            string
+		aluNum             int32
+		latency            int32
+		initiationInterval int32
 	}
 }
 
 func NewSMUnit() *SMUnit {
 	return &SMUnit{
 		meta: &smUnitMetaData{
+			freq: 1 * sim.GHz,
+
 			smUnitStrategy: "default",
 
 			l0CacheSize: 0,
@@ -44,6 +75,16 @@ func NewSMUnit() *SMUnit {
 	}
 }
 
+func (s *SMUnit) WithEngine(engine sim.Engine) *SMUnit {
+	s.meta.engine = engine
+	return s
+}
+
+func (s *SMUnit) WithFreq(freq sim.Freq) *SMUnit {
+	s.meta.freq = freq
+	return s
+}
+
 func (s *SMUnit) WithSMUnitStrategy(strategy string) *SMUnit {
 	s.meta.smUnitStrategy = strategy
 	return s
@@ -64,30 +105,78 @@ func (s *SMUnit) WithLaneSize(size int32) *SMUnit {
 	return s
 }
 
+// WithL1Port sets the port of the L1 cache that serves the global memory
+// accesses.
+func (s *SMUnit) WithL1Port(port sim.Port) *SMUnit {
+	s.meta.l1Port = port
+	return s
+}
+
+// WithSharedMem sets the port and the size in bytes of the shared memory.
+func (s *SMUnit) WithSharedMem(port sim.Port, byteSize uint64) *SMUnit {
+	s.meta.sharedMemPort = port
+	s.meta.sharedMemSize = byteSize
+	return s
+}
+
+// WithPageTable sets the page table that translates the global addresses.
+func (s *SMUnit) WithPageTable(pageTable *memory.PageTable) *SMUnit {
+	s.meta.pageTable = pageTable
+	return s
+}
+
 func (s *SMUnit) WithALU(aluType string, num int32) *SMUnit {
+	return s.WithALUConfig(aluType, num, 0, 0)
+}
+
+// WithALUConfig adds a group of ALUs with the given latency and initiation
+// interval. A zero latency or interval keeps the default of the ALU type.
+func (s *SMUnit) WithALUConfig(
+	aluType string,
+	num, latency, initiationInterval int32,
+) *SMUnit {
 	s.meta.alus = append(s.meta.alus, struct {
-		aluType string
-		aluNum  int32
-	}{aluType: aluType, aluNum: num})
+		aluType            string
+		aluNum             int32
+		latency            int32
+		initiationInterval int32
+	}{
+		aluType:            aluType,
+		aluNum:             num,
+		latency:            latency,
+		initiationInterval: initiationInterval,
+	})
 	return s
 }
 
-func (s *SMUnit) Build() {
+func (s *SMUnit) Build(name string) {
+	if s.meta.engine == nil {
+		panic("engine is not set")
+	}
+
+	s.TickingComponent = sim.NewTickingComponent(
+		name, s.meta.engine, s.meta.freq, s)
+	s.ToSM = sim.NewLimitNumMsgPort(s, 4, name+".ToSM")
+	s.ToMem = sim.NewLimitNumMsgPort(s, 64, name+".ToMem")
+
+	s.coalescer = memory.NewCoalescer(5)
+	s.inflightMem = make(map[string]*memAccess)
+
 	s.buildDispatcher()
 	s.buildRegisterFile(s.meta.registerFileSize, s.meta.laneSize)
 	s.aluGroup = make([]*alu.ALUGroup, len(s.meta.alus))
 	for i, a := range s.meta.alus {
 		s.aluGroup[i] = alu.NewALUGroup().
 			WithALUType(a.aluType).
-			WithALUNum(a.aluNum)
+			WithALUNum(a.aluNum).
+			WithLatency(int(a.latency)).
+			WithInitiationInterval(int(a.initiationInterval))
 		s.aluGroup[i].Build()
 	}
 }
 
-func (s *SMUnit) IsFree() bool {
-	return true
-}
-
-func (s *SMUnit) Execute(warp *nvidia.Warp) {
-	s.dispatcher.dispatch(warp)
+// NumIssuedInsts returns the number of warp instructions that the SM unit has
+// issued.
+func (s *SMUnit) NumIssuedInsts() uint64 {
+	return s.numIssuedInsts
 }
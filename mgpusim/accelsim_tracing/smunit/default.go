@@ -1,9 +1,11 @@
 package smunit
 
-import "github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
-
+// defaultDispatcher is a greedy-then-oldest warp scheduler. It keeps issuing
+// from the same warp until the warp stalls, and then issues from the oldest
+// warp that is ready.
 type defaultDispatcher struct {
 	parent *SMUnit
+	greedy *warpState
 }
 
 func newDefaultDispatcher() *defaultDispatcher {
@@ -15,5 +17,21 @@ func (d *defaultDispatcher) withParent(sm *SMUnit) smUnitDispatcher {
 	return d
 }
 
-func (d *defaultDispatcher) dispatch(warp *nvidia.Warp) {
+func (d *defaultDispatcher) dispatch(cycle uint64) bool {
+	if d.greedy != nil && d.parent.tryIssue(d.greedy, cycle) {
+		return true
+	}
+
+	for _, w := range d.parent.warps {
+		if w == d.greedy {
+			continue
+		}
+
+		if d.parent.tryIssue(w, cycle) {
+			d.greedy = w
+			return true
+		}
+	}
+
+	return false
 }
@@ -1,16 +1,18 @@
 package smunit
 
-import "github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
-
+// smUnitDispatcher selects the warp to issue an instruction from in each
+// cycle.
 type smUnitDispatcher interface {
 	withParent(sm *SMUnit) smUnitDispatcher
-	dispatch(tb *nvidia.Warp)
+	dispatch(cycle uint64) bool
 }
 
 func (s *SMUnit) buildDispatcher() {
 	switch s.meta.smUnitStrategy {
-	case "default":
+	case "default", "gto":
 		s.dispatcher = newDefaultDispatcher().withParent(s)
+	case "lrr":
+		s.dispatcher = newLRRDispatcher().withParent(s)
 	default:
 		panic("Unknown dispatch strategy")
 	}
@@ -1,2 +1,2 @@
 // Package smunit implements the simulation components for the SM Unit level.
-package smunit
\ No newline at end of file
+package smunit
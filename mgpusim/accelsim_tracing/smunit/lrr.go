@@ -0,0 +1,31 @@
+package smunit
+
+// lrrDispatcher is a loose round-robin warp scheduler. It starts to look for
+// a ready warp from the warp after the one that issued last.
+type lrrDispatcher struct {
+	parent *SMUnit
+	next   int
+}
+
+func newLRRDispatcher() *lrrDispatcher {
+	return &lrrDispatcher{}
+}
+
+func (d *lrrDispatcher) withParent(sm *SMUnit) smUnitDispatcher {
+	d.parent = sm
+	return d
+}
+
+func (d *lrrDispatcher) dispatch(cycle uint64) bool {
+	warps := d.parent.warps
+
+	for i := 0; i < len(warps); i++ {
+		index := (d.next + i) % len(warps)
+		if d.parent.tryIssue(warps[index], cycle) {
+			d.next = index + 1
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,127 @@
+package smunit
+
+import (
+	"fmt"
+
+	"github.com/sarchlab/akita/v3/mem/mem"
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/memory"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
+)
+
+// memInst tracks a memory instruction that waits for the responses of its
+// sector accesses.
+type memInst struct {
+	warp        *warpState
+	inst        *nvidia.Instruction
+	numAccesses int
+}
+
+// memAccess is a sector access of a memory instruction.
+type memAccess struct {
+	memInst *memInst
+	access  memory.Access
+	dst     sim.Port
+	req     mem.AccessReq
+}
+
+// isMemInst checks if the instruction sends requests to the memory system.
+func isMemInst(inst *nvidia.Instruction) bool {
+	return inst.OpCode.MemSpace() != nvidia.MemSpaceNone &&
+		len(inst.MemAddresses) > 0
+}
+
+// issueMemInst coalesces the addresses of the instruction and queues the
+// sector accesses to be sent.
+func (s *SMUnit) issueMemInst(w *warpState, inst *nvidia.Instruction) {
+	accesses := s.coalescer.Coalesce(inst)
+	mi := &memInst{
+		warp:        w,
+		inst:        inst,
+		numAccesses: len(accesses),
+	}
+
+	for _, a := range accesses {
+		ma := &memAccess{memInst: mi, access: a}
+
+		switch inst.OpCode.MemSpace() {
+		case nvidia.MemSpaceGlobal:
+			ma.dst = s.meta.l1Port
+			ma.access.Address = s.meta.pageTable.Translate(a.Address)
+		case nvidia.MemSpaceShared:
+			ma.dst = s.meta.sharedMemPort
+			ma.access.Address = a.Address % s.meta.sharedMemSize
+		}
+
+		s.memAccesses = append(s.memAccesses, ma)
+	}
+
+	w.issuedMem(inst)
+}
+
+// sendMemReq sends one sector access to the memory system.
+func (s *SMUnit) sendMemReq(now sim.VTimeInSec) bool {
+	if len(s.memAccesses) == 0 {
+		return false
+	}
+
+	ma := s.memAccesses[0]
+	if ma.access.IsStore {
+		ma.req = mem.WriteReqBuilder{}.
+			WithSendTime(now).
+			WithSrc(s.ToMem).
+			WithDst(ma.dst).
+			WithAddress(ma.access.Address).
+			WithData(make([]byte, s.coalescer.SectorSize())).
+			Build()
+	} else {
+		ma.req = mem.ReadReqBuilder{}.
+			WithSendTime(now).
+			WithSrc(s.ToMem).
+			WithDst(ma.dst).
+			WithAddress(ma.access.Address).
+			WithByteSize(s.coalescer.SectorSize()).
+			Build()
+	}
+
+	err := s.ToMem.Send(ma.req)
+	if err != nil {
+		return false
+	}
+
+	s.memAccesses = s.memAccesses[1:]
+	s.inflightMem[ma.req.Meta().ID] = ma
+
+	return true
+}
+
+// receiveMemRsp processes a response from the memory system. When all the
+// accesses of an instruction complete, the destination registers of the
+// instruction become ready.
+func (s *SMUnit) receiveMemRsp(now sim.VTimeInSec, cycle uint64) bool {
+	item := s.ToMem.Peek()
+	if item == nil {
+		return false
+	}
+
+	rsp, ok := item.(mem.AccessRsp)
+	if !ok {
+		panic(fmt.Sprintf("cannot handle message %T", item))
+	}
+
+	ma, found := s.inflightMem[rsp.GetRspTo()]
+	if !found {
+		panic("cannot find the request of the response")
+	}
+
+	delete(s.inflightMem, rsp.GetRspTo())
+	s.ToMem.Retrieve(now)
+
+	mi := ma.memInst
+	mi.numAccesses--
+	if mi.numAccesses == 0 {
+		mi.warp.memInstDone(mi.inst, cycle)
+	}
+
+	return true
+}
@@ -0,0 +1,180 @@
+package smunit
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/alu"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/message"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
+)
+
+// Tick updates the state of the SM unit by one cycle.
+func (s *SMUnit) Tick(now sim.VTimeInSec) (madeProgress bool) {
+	cycle := s.meta.freq.Cycle(now)
+
+	madeProgress = s.reportFinishedWarps(now) || madeProgress
+	madeProgress = s.retireWarps(cycle) || madeProgress
+	madeProgress = s.receiveMemRsp(now, cycle) || madeProgress
+	madeProgress = s.dispatcher.dispatch(cycle) || madeProgress
+	madeProgress = s.sendMemReq(now) || madeProgress
+	madeProgress = s.sendBarrierArrival(now) || madeProgress
+	madeProgress = s.receiveFromSM(now) || madeProgress
+
+	if !madeProgress {
+		s.wakeUpWhenReady(now, cycle)
+	}
+
+	return madeProgress
+}
+
+// wakeUpWhenReady schedules a tick at the cycle that an ALU result becomes
+// ready or an ALU group becomes free, as the SM unit stops ticking when it
+// cannot make progress. The ports wake the unit up when the memory responses
+// and the barrier releases arrive.
+func (s *SMUnit) wakeUpWhenReady(now sim.VTimeInSec, cycle uint64) {
+	wakeUpCycle := uint64(math.MaxUint64)
+
+	for _, w := range s.warps {
+		c := w.nextReadyCycle(cycle)
+		if c < wakeUpCycle {
+			wakeUpCycle = c
+		}
+	}
+
+	for _, g := range s.aluGroup {
+		c := g.NextIssueCycle()
+		if c > cycle && c < wakeUpCycle {
+			wakeUpCycle = c
+		}
+	}
+
+	if wakeUpCycle == math.MaxUint64 {
+		return
+	}
+
+	s.TickNow(s.meta.freq.NCyclesLater(int(wakeUpCycle-cycle), now))
+}
+
+func (s *SMUnit) receiveFromSM(now sim.VTimeInSec) bool {
+	item := s.ToSM.Peek()
+	if item == nil {
+		return false
+	}
+
+	switch msg := item.(type) {
+	case *message.WarpMsg:
+		s.warps = append(s.warps, newWarpState(msg.Warp, msg.Src))
+	case *message.BarrierReleaseMsg:
+		s.releaseBarrier(msg.Warp)
+	default:
+		panic(fmt.Sprintf("cannot handle message %T", item))
+	}
+
+	s.ToSM.Retrieve(now)
+
+	return true
+}
+
+// tryIssue issues the next instruction of the warp if the operands of the
+// instruction are ready and the ALU is available.
+func (s *SMUnit) tryIssue(w *warpState, cycle uint64) bool {
+	if w.atBarrier {
+		return false
+	}
+
+	inst := w.nextInst()
+	if inst == nil {
+		return false
+	}
+
+	if !w.operandsReady(inst, cycle) {
+		return false
+	}
+
+	group := s.aluGroupFor(inst, cycle)
+	if group == nil {
+		return false
+	}
+
+	readyCycle := group.Issue(cycle)
+	if isMemInst(inst) {
+		s.issueMemInst(w, inst)
+	} else {
+		w.issued(inst, readyCycle)
+	}
+
+	if inst.OpCode.IsBarrier() {
+		s.arriveAtBarrier(w)
+	}
+
+	s.numIssuedInsts++
+
+	return true
+}
+
+// aluGroupFor returns an ALU group that can execute the instruction in the
+// given cycle, or nil if all the groups of the required type are busy.
+func (s *SMUnit) aluGroupFor(
+	inst *nvidia.Instruction,
+	cycle uint64,
+) *alu.ALUGroup {
+	aluType := inst.OpCode.ExecUnit().String()
+
+	found := false
+	for _, g := range s.aluGroup {
+		if g.Type() != aluType {
+			continue
+		}
+
+		found = true
+		if g.CanIssue(cycle) {
+			return g
+		}
+	}
+
+	if !found {
+		panic(fmt.Sprintf("no ALU can execute %s", inst.OpCode))
+	}
+
+	return nil
+}
+
+func (s *SMUnit) retireWarps(cycle uint64) bool {
+	madeProgress := false
+
+	remaining := s.warps[:0]
+	for _, w := range s.warps {
+		if w.isDone(cycle) {
+			s.finishedWarps = append(s.finishedWarps, w)
+			madeProgress = true
+
+			continue
+		}
+
+		remaining = append(remaining, w)
+	}
+
+	s.warps = remaining
+
+	return madeProgress
+}
+
+func (s *SMUnit) reportFinishedWarps(now sim.VTimeInSec) bool {
+	if len(s.finishedWarps) == 0 {
+		return false
+	}
+
+	w := s.finishedWarps[0]
+	msg := message.NewWarpDoneMsg(now, s.ToSM, w.smSrc, w.warp)
+
+	err := s.ToSM.Send(msg)
+	if err != nil {
+		return false
+	}
+
+	s.finishedWarps = s.finishedWarps[1:]
+
+	return true
+}
@@ -0,0 +1,213 @@
+package smunit
+
+import (
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
+)
+
+var _ = Describe("SM Unit", func() {
+	var (
+		mockCtrl *gomock.Controller
+		engine   *MockEngine
+		unit     *SMUnit
+	)
+
+	inst := func(op string, dst string, srcs ...string) *nvidia.Instruction {
+		i := &nvidia.Instruction{OpCode: nvidia.NewOpcode(op)}
+		if dst != "" {
+			i.DestRegs = []*nvidia.Register{nvidia.NewRegister(dst)}
+		}
+
+		for _, s := range srcs {
+			i.SrcRegs = append(i.SrcRegs, nvidia.NewRegister(s))
+		}
+
+		return i
+	}
+
+	addWarp := func(insts ...*nvidia.Instruction) *warpState {
+		w := newWarpState(&nvidia.Warp{InstNum: len(insts), Insts: insts}, nil)
+		unit.warps = append(unit.warps, w)
+
+		return w
+	}
+
+	build := func(strategy string) {
+		unit = NewSMUnit().
+			WithEngine(engine).
+			WithSMUnitStrategy(strategy).
+			WithALUConfig("int32", 32, 4, 1)
+		unit.Build("SMUnit")
+	}
+
+	// issueOrder dispatches once per cycle and records the warps that issue.
+	issueOrder := func(cycles int) []*warpState {
+		var order []*warpState
+
+		for c := 0; c < cycles; c++ {
+			pcs := make([]int, len(unit.warps))
+			for i, w := range unit.warps {
+				pcs[i] = w.pc
+			}
+
+			if !unit.dispatcher.dispatch(uint64(c)) {
+				order = append(order, nil)
+				continue
+			}
+
+			for i, w := range unit.warps {
+				if w.pc != pcs[i] {
+					order = append(order, w)
+				}
+			}
+		}
+
+		return order
+	}
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		engine = NewMockEngine(mockCtrl)
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("LRR dispatcher", func() {
+		BeforeEach(func() {
+			build("lrr")
+		})
+
+		It("should issue from the warps in turn", func() {
+			w0 := addWarp(inst("IADD3", "R1"), inst("IADD3", "R2"))
+			w1 := addWarp(inst("IADD3", "R1"), inst("IADD3", "R2"))
+			w2 := addWarp(inst("IADD3", "R1"), inst("IADD3", "R2"))
+
+			Expect(issueOrder(6)).To(Equal([]*warpState{
+				w0, w1, w2, w0, w1, w2,
+			}))
+		})
+
+		It("should skip the warps that cannot issue", func() {
+			w0 := addWarp(inst("IADD3", "R1"), inst("IADD3", "R2"))
+			w1 := addWarp(inst("IADD3", "R1"), inst("IADD3", "R2"))
+			w2 := addWarp(inst("IADD3", "R1"), inst("IADD3", "R2"))
+			w1.atBarrier = true
+
+			Expect(issueOrder(5)).To(Equal([]*warpState{
+				w0, w2, w0, w2, nil,
+			}))
+		})
+	})
+
+	Context("GTO dispatcher", func() {
+		BeforeEach(func() {
+			build("gto")
+		})
+
+		It("should keep issuing from a warp until it stalls", func() {
+			w0 := addWarp(
+				inst("IADD3", "R1"), inst("IADD3", "R2"), inst("IADD3", "R3", "R2"))
+			w1 := addWarp(inst("IADD3", "R1"), inst("IADD3", "R2"))
+
+			Expect(issueOrder(4)).To(Equal([]*warpState{
+				w0, w0, w1, w1,
+			}))
+		})
+	})
+
+	Context("register dependency", func() {
+		BeforeEach(func() {
+			build("lrr")
+		})
+
+		It("should stall a read until the register is written", func() {
+			w := addWarp(inst("IADD3", "R1"), inst("IADD3", "R2", "R1"))
+
+			Expect(issueOrder(5)).To(Equal([]*warpState{
+				w, nil, nil, nil, w,
+			}))
+		})
+
+		It("should stall a write until the pending write completes", func() {
+			w := addWarp(inst("IADD3", "R1"), inst("IADD3", "R1"))
+
+			Expect(issueOrder(5)).To(Equal([]*warpState{
+				w, nil, nil, nil, w,
+			}))
+		})
+
+		It("should not stall on the zero register", func() {
+			w := addWarp(inst("IADD3", "R255"), inst("IADD3", "R2", "R255"))
+
+			Expect(issueOrder(2)).To(Equal([]*warpState{w, w}))
+		})
+
+		It("should let other warps issue while a warp stalls", func() {
+			w0 := addWarp(inst("IADD3", "R1"), inst("IADD3", "R2", "R1"))
+			w1 := addWarp(
+				inst("IADD3", "R1"), inst("IADD3", "R2"), inst("IADD3", "R3"))
+
+			Expect(issueOrder(5)).To(Equal([]*warpState{
+				w0, w1, w1, w1, w0,
+			}))
+		})
+	})
+
+	Context("barrier", func() {
+		BeforeEach(func() {
+			build("lrr")
+		})
+
+		It("should stop the warp at the barrier until released", func() {
+			w0 := addWarp(inst("BAR", ""), inst("IADD3", "R1"))
+			w1 := addWarp(inst("IADD3", "R1"), inst("IADD3", "R2"))
+
+			Expect(issueOrder(4)).To(Equal([]*warpState{
+				w0, w1, w1, nil,
+			}))
+			Expect(w0.atBarrier).To(BeTrue())
+			Expect(unit.barrierArrivals).To(ConsistOf(w0))
+
+			unit.releaseBarrier(w0.warp)
+
+			Expect(w0.atBarrier).To(BeFalse())
+			Expect(unit.tryIssue(w0, 4)).To(BeTrue())
+		})
+	})
+
+	Context("progress", func() {
+		BeforeEach(func() {
+			build("lrr")
+		})
+
+		It("should not tick when there is nothing to do", func() {
+			Expect(unit.Tick(0)).To(BeFalse())
+		})
+
+		It("should wake up when an ALU result is ready", func() {
+			addWarp(inst("IADD3", "R1"), inst("IADD3", "R2", "R1"))
+			Expect(unit.Tick(0)).To(BeTrue())
+
+			var wakeUpTime sim.VTimeInSec
+			engine.EXPECT().
+				Schedule(gomock.Any()).
+				Do(func(e sim.Event) { wakeUpTime = e.Time() })
+
+			Expect(unit.Tick(1e-9)).To(BeFalse())
+			Expect(wakeUpTime).To(BeNumerically("~", 4e-9, 1e-12))
+		})
+
+		It("should not wake up for the data from the memory", func() {
+			w := addWarp(inst("IADD3", "R2", "R1"))
+			w.issuedMem(inst("LDG.E", "R1"))
+			w.pc = 0
+
+			Expect(unit.Tick(1e-9)).To(BeFalse())
+		})
+	})
+})
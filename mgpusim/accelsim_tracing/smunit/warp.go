@@ -0,0 +1,134 @@
+package smunit
+
+import (
+	"math"
+
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
+)
+
+// warpState tracks the progress of a warp that resides in an SM unit.
+type warpState struct {
+	warp  *nvidia.Warp
+	smSrc sim.Port
+
+	pc int
+
+	// regReadyCycle is the scoreboard of the warp. It records the cycle that
+	// the pending writes to the registers complete.
+	regReadyCycle map[int32]uint64
+	doneCycle     uint64
+
+	numPendingMemInsts int
+
+	// atBarrier is set when the warp waits for the other warps of its thread
+	// block to reach a barrier.
+	atBarrier bool
+}
+
+func newWarpState(warp *nvidia.Warp, smSrc sim.Port) *warpState {
+	return &warpState{
+		warp:          warp,
+		smSrc:         smSrc,
+		regReadyCycle: make(map[int32]uint64),
+	}
+}
+
+func (w *warpState) nextInst() *nvidia.Instruction {
+	if w.pc >= len(w.warp.Insts) {
+		return nil
+	}
+
+	return w.warp.Insts[w.pc]
+}
+
+// operandsReady checks if the instruction reads or writes registers that have
+// pending writes.
+func (w *warpState) operandsReady(inst *nvidia.Instruction, cycle uint64) bool {
+	for _, regs := range [][]*nvidia.Register{inst.SrcRegs, inst.DestRegs} {
+		for _, r := range regs {
+			if r.IsZeroRegister() {
+				continue
+			}
+
+			if w.regReadyCycle[r.ID()] > cycle {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func (w *warpState) issued(inst *nvidia.Instruction, readyCycle uint64) {
+	for _, r := range inst.DestRegs {
+		if r.IsZeroRegister() {
+			continue
+		}
+
+		w.regReadyCycle[r.ID()] = readyCycle
+	}
+
+	if readyCycle > w.doneCycle {
+		w.doneCycle = readyCycle
+	}
+
+	w.pc++
+}
+
+// issuedMem marks the destination registers of a memory instruction as
+// pending until the memory system responds.
+func (w *warpState) issuedMem(inst *nvidia.Instruction) {
+	for _, r := range inst.DestRegs {
+		if r.IsZeroRegister() {
+			continue
+		}
+
+		w.regReadyCycle[r.ID()] = math.MaxUint64
+	}
+
+	w.numPendingMemInsts++
+	w.pc++
+}
+
+func (w *warpState) memInstDone(inst *nvidia.Instruction, cycle uint64) {
+	for _, r := range inst.DestRegs {
+		if r.IsZeroRegister() {
+			continue
+		}
+
+		w.regReadyCycle[r.ID()] = cycle
+	}
+
+	if cycle > w.doneCycle {
+		w.doneCycle = cycle
+	}
+
+	w.numPendingMemInsts--
+}
+
+// nextReadyCycle returns the earliest cycle after the given cycle that a
+// register becomes ready or the last instruction of the warp completes. The
+// registers that wait for the memory system are not considered.
+func (w *warpState) nextReadyCycle(cycle uint64) uint64 {
+	next := uint64(math.MaxUint64)
+
+	for _, c := range w.regReadyCycle {
+		if c > cycle && c < next {
+			next = c
+		}
+	}
+
+	if w.doneCycle > cycle && w.doneCycle < next {
+		next = w.doneCycle
+	}
+
+	return next
+}
+
+func (w *warpState) isDone(cycle uint64) bool {
+	return w.pc >= len(w.warp.Insts) &&
+		w.numPendingMemInsts == 0 &&
+		!w.atBarrier &&
+		cycle >= w.doneCycle
+}
@@ -36,6 +36,7 @@ func (inst *instruction) generateNVInst() *nvidia.Instruction {
 		MemAddress:        inst.MemAddress,
 		MemAddressSuffix1: inst.MemAddressSuffix1,
 		MemAddressSuffix2: inst.MemAddressSuffix2,
+		MemAddresses:      inst.MemAddresses,
 	}
 	return nvinst
 }
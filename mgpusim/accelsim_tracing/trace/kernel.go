@@ -21,6 +21,15 @@ func (te *kernel) Type() string {
 func (te *kernel) Execute(gpu *gpu.GPU) error {
 	tg := NewTraceGroup().WithFilePath(path.Join(te.parent.traceDirPath, te.filePath))
 	tg.Build()
+
+	start := gpu.Engine.CurrentTime()
 	err := tg.Exec(gpu)
+	end := gpu.Engine.CurrentTime()
+
+	te.parent.kernelStats = append(te.parent.kernelStats, KernelStats{
+		Name:      tg.traceHeader.kernelName,
+		NumCycles: gpu.Freq().Cycle(end) - gpu.Freq().Cycle(start),
+	})
+
 	return err
 }
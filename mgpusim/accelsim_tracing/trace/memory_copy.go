@@ -19,6 +19,13 @@ func (te *memCopy) Type() string {
 	return "memcopy"
 }
 
+// Execute maps the buffers that the host copies to the GPU. The copies from
+// the GPU to the host read the buffers that the kernels have mapped, so they
+// do not change the GPU.
 func (te *memCopy) Execute(gpu *gpu.GPU) error {
+	if te.h2d {
+		gpu.CopyFromHost(te.startAddr, te.length)
+	}
+
 	return nil
 }
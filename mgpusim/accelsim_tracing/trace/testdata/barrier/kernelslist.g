@@ -0,0 +1,2 @@
+MemcpyHtoD,0x00007f0000000000,256
+kernel-1.traceg
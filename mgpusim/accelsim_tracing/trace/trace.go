@@ -12,6 +12,13 @@ import (
 type Trace struct {
 	traceDirPath string
 	traceExecs   []traceExecs
+	kernelStats  []KernelStats
+}
+
+// KernelStats is the simulation result of a traced kernel.
+type KernelStats struct {
+	Name      string
+	NumCycles uint64
 }
 
 func NewTrace() *Trace {
@@ -40,6 +47,11 @@ func (t *Trace) Exec(gpu *gpu.GPU) error {
 	return nil
 }
 
+// KernelStats returns the results of the kernels that have been executed.
+func (t *Trace) KernelStats() []KernelStats {
+	return t.kernelStats
+}
+
 func (t *Trace) parseKernelsList() {
 	filePath := path.Join(t.traceDirPath, "kernelslist.g")
 	file, err := os.Open(filePath)
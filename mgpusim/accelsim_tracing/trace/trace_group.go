@@ -45,7 +45,7 @@ func (tg *traceGroup) Exec(gpu *gpu.GPU) error {
 	}
 
 	tg.file.Close()
-	return nil
+	return gpu.Engine.Run()
 }
 
 func (tg *traceGroup) buildFileScanner() {
@@ -0,0 +1,72 @@
+package trace
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/akita/v3/metrics"
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/gpu"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
+)
+
+var _ = Describe("Trace", func() {
+	var registry *metrics.Registry
+
+	BeforeEach(func() {
+		registry = metrics.NewRegistry()
+	})
+
+	counter := func(where, what string) float64 {
+		m, found := registry.Lookup(where, what)
+		Expect(found).To(BeTrue())
+
+		return m.(*metrics.Counter).Value()
+	}
+
+	It("should run the kernels of a trace", func() {
+		g := gpu.NewGPU().
+			WithEngine(sim.NewSerialEngine()).
+			WithGPCNum(1).
+			WithSMNum(1).
+			WithSMUnitNum(2).
+			WithMemBankNum(2).
+			WithL2CacheSize(256*1024*nvidia.BYTE).
+			WithL1CacheSize(32*1024*nvidia.BYTE).
+			WithSharedMemSize(16*1024*nvidia.BYTE).
+			WithL0CacheSize(16*1024*nvidia.BYTE).
+			WithRegisterFileSize(64*1024*nvidia.BYTE).
+			WithLaneSize(4*nvidia.BYTE).
+			WithALU("int32", 16).
+			WithALU("fp32", 16).
+			WithALU("ldst", 8).
+			WithMetricsRegistry(registry)
+		g.Build("GPU")
+
+		t := NewTrace().WithTraceDirPath("testdata/barrier")
+		t.Build()
+
+		Expect(t.Exec(g)).To(Succeed())
+
+		Expect(t.KernelStats()).To(Equal([]KernelStats{
+			{Name: "_Z6kernelPf", NumCycles: 219},
+		}))
+
+		// Each warp loads a cache line, waits at the barrier, and then loads
+		// the line of the other warp, which is already in the L1 cache.
+		l1 := g.GPCs()[0].SMs()[0].L1Cache().Name()
+		Expect(counter(l1, "read_miss")).To(Equal(2.0))
+		Expect(counter(l1, "read_mshr_hit")).To(Equal(6.0))
+		Expect(counter(l1, "read_hit")).To(Equal(8.0))
+
+		l2Misses, l2Hits := 0.0, 0.0
+		for _, l2 := range g.L2Caches() {
+			l2Misses += counter(l2.Name(), "read_miss")
+			l2Hits += counter(l2.Name(), "read_hit")
+		}
+		Expect(l2Misses).To(Equal(2.0))
+		Expect(l2Hits).To(Equal(0.0))
+
+		_, found := metrics.DefaultRegistry().Lookup(l1, "read_hit")
+		Expect(found).To(BeFalse())
+	})
+})
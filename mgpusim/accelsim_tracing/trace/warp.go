@@ -3,6 +3,7 @@ package trace
 import (
 	"fmt"
 	"log"
+	"math/bits"
 	"strconv"
 	"strings"
 
@@ -37,6 +38,7 @@ type instruction struct {
 	MemAddress        int64
 	MemAddressSuffix1 int32
 	MemAddressSuffix2 []int32
+	MemAddresses      []uint64
 }
 
 func parseWarp(lines []string) *warp {
@@ -61,35 +63,112 @@ func parseWarp(lines []string) *warp {
 	return wp
 }
 
+// parseInst parses an instruction line, whose format is
+//
+//	PC mask dest_num [dest_regs] opcode src_num [src_regs] mem_width [mem_addrs]
 func parseInst(line string) instruction {
-	inst := &instruction{}
+	inst := &instruction{rawText: line}
 	elems := strings.Fields(line)
-	fmt.Sscanf(elems[0]+elems[1]+elems[2], "%x%x%d", &inst.PC, &inst.Mask, &inst.SrcNum)
-	for i := 0; i < int(inst.SrcNum); i++ {
-		inst.SrcRegs = append(inst.SrcRegs, nvidia.NewRegister(elems[3+i]))
+	if len(elems) < 5 {
+		log.Panicf("Invalid instruction: %s", line)
 	}
-	fmt.Sscanf(elems[3+int(inst.SrcNum)], "%d", &inst.DestNum)
-	for i := 0; i < int(inst.DestNum); i++ {
-		inst.DestRegs = append(inst.DestRegs, nvidia.NewRegister(elems[4+int(inst.SrcNum)+i]))
+
+	inst.PC = int32(mustParseInt(elems[0], 16, line))
+	inst.Mask = mustParseInt(elems[1], 16, line)
+
+	i := 2
+	inst.DestNum = int32(mustParseInt(elems[i], 10, line))
+	i++
+	for j := 0; j < int(inst.DestNum); j++ {
+		inst.DestRegs = append(inst.DestRegs, nvidia.NewRegister(elems[i]))
+		i++
+	}
+
+	inst.OpCode = nvidia.NewOpcode(elems[i])
+	i++
+
+	inst.SrcNum = int32(mustParseInt(elems[i], 10, line))
+	i++
+	for j := 0; j < int(inst.SrcNum); j++ {
+		inst.SrcRegs = append(inst.SrcRegs, nvidia.NewRegister(elems[i]))
+		i++
 	}
-	inst.parseMemory(elems[4+int(inst.SrcNum)+int(inst.DestNum):])
+
+	inst.parseMemory(elems[i:], line)
 	return *inst
 }
 
-// [todo]: understand memory format
-func (inst *instruction) parseMemory(elems []string) {
-	fmt.Sscanf(elems[0], "%d", &inst.MemWidth)
+// parseMemory parses the memory width and the addresses. The addresses are
+// either listed for all the active threads (mode 0), given as a base and a
+// stride (mode 1), or given as a base followed by the deltas between the
+// addresses of the consecutive active threads (mode 2).
+func (inst *instruction) parseMemory(elems []string, line string) {
+	if len(elems) == 0 {
+		log.Panicf("Invalid instruction, missing memory width: %s", line)
+	}
+
+	inst.MemWidth = int32(mustParseInt(elems[0], 10, line))
 	if inst.MemWidth == 0 {
 		return
 	}
-	fmt.Sscanf(elems[1]+elems[2], "%d0x%x", &inst.AddressCompress, &inst.MemAddress)
+
+	inst.AddressCompress = int32(mustParseInt(elems[1], 10, line))
+	inst.MemAddress = mustParseInt(elems[2], 0, line)
+
 	switch inst.AddressCompress {
+	case 0:
+		for _, s := range elems[2:] {
+			inst.MemAddresses = append(inst.MemAddresses,
+				uint64(mustParseInt(s, 0, line)))
+		}
 	case 1:
-		fmt.Sscanf(elems[2], "%d", &inst.MemAddressSuffix1)
+		inst.MemAddressSuffix1 = int32(mustParseInt(elems[3], 10, line))
 	case 2:
-		for _, s := range elems[2:] {
-			s32, _ := strconv.Atoi(s)
-			inst.MemAddressSuffix2 = append(inst.MemAddressSuffix2, int32(s32))
+		for _, s := range elems[3:] {
+			inst.MemAddressSuffix2 = append(inst.MemAddressSuffix2,
+				int32(mustParseInt(s, 10, line)))
 		}
 	}
+
+	inst.expandMemAddresses(line)
+}
+
+// expandMemAddresses recovers the address of each active thread from the
+// compressed forms.
+func (inst *instruction) expandMemAddresses(line string) {
+	numActive := bits.OnesCount32(uint32(inst.Mask))
+
+	switch inst.AddressCompress {
+	case 0:
+	case 1:
+		inst.MemAddresses = make([]uint64, numActive)
+		for i := range inst.MemAddresses {
+			inst.MemAddresses[i] = uint64(inst.MemAddress +
+				int64(i)*int64(inst.MemAddressSuffix1))
+		}
+	case 2:
+		addr := uint64(inst.MemAddress)
+		inst.MemAddresses = append(inst.MemAddresses, addr)
+		for _, delta := range inst.MemAddressSuffix2 {
+			addr = uint64(int64(addr) + int64(delta))
+			inst.MemAddresses = append(inst.MemAddresses, addr)
+		}
+	default:
+		log.Panicf("Invalid address format %d in instruction: %s",
+			inst.AddressCompress, line)
+	}
+
+	if len(inst.MemAddresses) != numActive {
+		log.Panicf("%d addresses for %d active threads in instruction: %s",
+			len(inst.MemAddresses), numActive, line)
+	}
+}
+
+func mustParseInt(s string, base int, line string) int64 {
+	v, err := strconv.ParseInt(s, base, 64)
+	if err != nil {
+		log.Panicf("Invalid number %s in instruction: %s", s, line)
+	}
+
+	return v
 }
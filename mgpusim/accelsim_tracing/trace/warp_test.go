@@ -0,0 +1,58 @@
+package trace
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Instruction", func() {
+	It("should parse the registers", func() {
+		inst := parseInst("0040 ffffffff 1 R3 FFMA 3 R1 R2 R255 0")
+
+		Expect(inst.PC).To(Equal(int32(0x40)))
+		Expect(inst.Mask).To(Equal(int64(0xffffffff)))
+		Expect(inst.DestRegs).To(HaveLen(1))
+		Expect(inst.OpCode.String()).To(Equal("FFMA"))
+		Expect(inst.SrcRegs).To(HaveLen(3))
+		Expect(inst.MemWidth).To(Equal(int32(0)))
+		Expect(inst.MemAddresses).To(BeEmpty())
+	})
+
+	It("should parse the listed addresses", func() {
+		inst := parseInst(
+			"0010 0000000b 1 R3 LDG.E 1 R2 4 0 0x100 0x200 0x104")
+
+		Expect(inst.MemAddresses).To(Equal([]uint64{0x100, 0x200, 0x104}))
+	})
+
+	It("should expand the addresses of a base and a stride", func() {
+		inst := parseInst("0010 0000000f 1 R3 LDG.E 1 R2 4 1 0x100 8")
+
+		Expect(inst.MemAddresses).To(
+			Equal([]uint64{0x100, 0x108, 0x110, 0x118}))
+	})
+
+	It("should expand the addresses of a base and the deltas", func() {
+		inst := parseInst("0010 0000000f 1 R3 LDG.E 1 R2 4 2 0x100 4 -8 12")
+
+		Expect(inst.MemAddresses).To(
+			Equal([]uint64{0x100, 0x104, 0xfc, 0x108}))
+	})
+
+	It("should panic if the addresses do not match the active threads",
+		func() {
+			Expect(func() {
+				parseInst("0010 000000ff 1 R3 LDG.E 1 R2 4 0 0x100 0x104")
+			}).To(Panic())
+
+			Expect(func() {
+				parseInst("0010 0000000f 1 R3 LDG.E 1 R2 4 2 0x100 4")
+			}).To(Panic())
+		})
+
+	It("should panic on an unknown address format", func() {
+		Expect(func() {
+			parseInst("0010 00000001 1 R3 LDG.E 1 R2 4 3 0x100")
+		}).To(Panic())
+	})
+})
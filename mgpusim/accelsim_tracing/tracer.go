@@ -4,14 +4,30 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"strings"
 
+	"github.com/sarchlab/akita/v3/sim"
 	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/benchmark"
+	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/config"
 	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/gpu"
 	"github.com/sarchlab/mgpusim/v3/accelsim_tracing/nvidia"
 )
 
+// configFiles collects the values of the repeated -config flags.
+type configFiles []string
+
+func (c *configFiles) String() string {
+	return strings.Join(*c, ",")
+}
+
+func (c *configFiles) Set(value string) error {
+	*c = append(*c, value)
+	return nil
+}
+
 type inputArguments struct {
 	inputTraceDir string
+	configFiles   configFiles
 	// deparse        bool
 	// outputTraceDir string
 }
@@ -19,6 +35,9 @@ type inputArguments struct {
 func getInputArguments() *inputArguments {
 	i := &inputArguments{}
 
+	flag.Var(&i.configFiles, "config",
+		"Accel-Sim config file, such as gpgpusim.config and trace.config. "+
+			"Can be repeated, and the later files override the earlier ones.")
 	flag.Usage = func() {
 		fmt.Println("Usage: ./as_trace_parser [options] trace")
 		flag.PrintDefaults()
@@ -34,8 +53,10 @@ func getInputArguments() *inputArguments {
 	return i
 }
 
-func buildAmpereGPU() *gpu.GPU {
+func buildAmpereGPU(engine sim.Engine) *gpu.GPU {
 	gpu := gpu.NewGPU().
+		WithEngine(engine).
+		WithFreq(1*sim.GHz).
 		WithGPUStrategy("default").
 		WithGPCNum(8).
 		WithSMNum(16).
@@ -44,19 +65,56 @@ func buildAmpereGPU() *gpu.GPU {
 		WithSMStrategy("default").
 		WithSMUnitStrategy("default").
 		WithL2CacheSize(4*1024*1024*nvidia.BYTE).
-		WithL1CacheSize(192*1024*nvidia.BYTE).
+		WithL1CacheSize(128*1024*nvidia.BYTE).
+		WithSharedMemSize(64*1024*nvidia.BYTE).
 		WithL0CacheSize(16*1024*nvidia.BYTE).
 		WithRegisterFileSize(256*1024*nvidia.BYTE).
 		WithLaneSize(4*nvidia.BYTE).
-		WithALU("int32", 16)
-	gpu.Build()
+		WithALU("int32", 16).
+		WithALU("fp32", 16).
+		WithALU("fp64", 8).
+		WithALU("sfu", 4).
+		WithALU("ldst", 8).
+		WithALU("tensor", 8)
+	gpu.Build("GPU")
+	return gpu
+}
+
+func buildGPUFromConfig(engine sim.Engine, files []string) *gpu.GPU {
+	c := config.NewConfig()
+	for _, f := range files {
+		c.WithFilePath(f)
+	}
+
+	err := c.Build()
+	if err != nil {
+		log.Panic(err)
+	}
+
+	gpu := c.Configure(gpu.NewGPU().WithEngine(engine)).
+		WithL0CacheSize(16 * 1024 * nvidia.BYTE).
+		WithLaneSize(4 * nvidia.BYTE)
+	gpu.Build("GPU")
 	return gpu
 }
 
 func main() {
 	args := getInputArguments()
-	gpu := buildAmpereGPU()
+	engine := sim.NewSerialEngine()
+	var gpu *gpu.GPU
+	if len(args.configFiles) > 0 {
+		gpu = buildGPUFromConfig(engine, args.configFiles)
+	} else {
+		gpu = buildAmpereGPU(engine)
+	}
 	benchmark := benchmark.NewBenchMark().WithTraceDirPath(args.inputTraceDir)
 	benchmark.Build()
-	benchmark.Exec(gpu)
+	err := benchmark.Exec(gpu)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	for _, k := range benchmark.KernelStats() {
+		fmt.Printf("%s: %d cycles\n", k.Name, k.NumCycles)
+	}
 }
@@ -112,19 +112,32 @@ func (d *Driver) CreateCommandQueue(c *Context) *CommandQueue {
 	return q
 }
 
-// DrainCommandQueue will return when there is no command to execute
+// HookPosCommandQueueDrained marks when a command queue is drained and the
+// engine stops. As no event is pending, the simulation can be checkpointed at
+// this point. The item of the hook context is the CommandQueue.
+var HookPosCommandQueueDrained = &sim.HookPos{Name: "CommandQueueDrained"}
+
+// DrainCommandQueue will return when there is no command to execute and the
+// engine stops
 func (d *Driver) DrainCommandQueue(q *CommandQueue) {
 	listener := q.Subscribe()
 	defer q.Unsubscribe(listener)
 
 	d.enqueueSignal <- true
 
-	for {
-		if q.NumCommand() == 0 {
-			return
-		}
+	for q.NumCommand() > 0 {
 		listener.Wait()
 	}
+
+	d.waitForEngineToStop()
+
+	if d.NumHooks() > 0 {
+		d.InvokeHook(sim.HookCtx{
+			Domain: d,
+			Pos:    HookPosCommandQueueDrained,
+			Item:   q,
+		})
+	}
 }
 
 // AllocateMemory allocates a chunk of memory of size byteSize in storage.
@@ -17,6 +17,7 @@ type Builder struct {
 	useMagicMemoryCopy  bool
 	middlewareD2HCycles int
 	middlewareH2DCycles int
+	noCacheFlushing     bool
 }
 
 // MakeBuilder creates a driver builder with some default configuration
@@ -74,6 +75,14 @@ func (b Builder) WithH2DCycles(h2dCycles int) Builder {
 	return b
 }
 
+// WithoutCacheFlushing lets the driver copy memory without flushing the GPU
+// caches first. It should only be used when the GPU caches are kept coherent
+// by hardware.
+func (b Builder) WithoutCacheFlushing() Builder {
+	b.noCacheFlushing = true
+	return b
+}
+
 // Build creates a driver.
 func (b Builder) Build(name string) *Driver {
 	driver := new(Driver)
@@ -99,9 +108,10 @@ func (b Builder) Build(name string) *Driver {
 		driver.middlewares = append(driver.middlewares, globalStorageMemoryCopyMiddleware)
 	} else {
 		defaultMemoryCopyMiddleware := &defaultMemoryCopyMiddleware{
-			driver:       driver,
-			cyclesPerD2H: b.middlewareD2HCycles,
-			cyclesPerH2D: b.middlewareH2DCycles,
+			driver:          driver,
+			cyclesPerD2H:    b.middlewareD2HCycles,
+			cyclesPerH2D:    b.middlewareH2DCycles,
+			noCacheFlushing: b.noCacheFlushing,
 		}
 		driver.middlewares = append(driver.middlewares, defaultMemoryCopyMiddleware)
 	}
@@ -67,12 +67,24 @@ func (d *Driver) Run() {
 	go d.runAsync()
 }
 
-// Terminate stops the driver thread execution.
+// Terminate stops the driver thread execution. It waits for the engine to
+// handle the events that have already been scheduled, so that the state of the
+// simulation does not change after the driver terminates.
 func (d *Driver) Terminate() {
 	d.driverStopped <- true
+	d.waitForEngineToStop()
 	d.logSimulationTerminate()
 }
 
+// waitForEngineToStop blocks until the engine handles all the events that
+// have been scheduled. The commands that are enqueued afterward start at the
+// time that the engine stops, rather than at a time that depends on how fast
+// the engine runs.
+func (d *Driver) waitForEngineToStop() {
+	d.engineMutex.Lock()
+	d.engineMutex.Unlock()
+}
+
 func (d *Driver) logSimulationStart() {
 	d.simulationID = xid.New().String()
 	tracing.StartTask(
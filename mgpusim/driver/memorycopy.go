@@ -17,6 +17,10 @@ type defaultMemoryCopyMiddleware struct {
 	cyclesPerD2H int
 	cyclesLeft   int
 
+	// noCacheFlushing is set when the caches are kept coherent by hardware,
+	// so that memory copies do not need to flush the GPU caches.
+	noCacheFlushing bool
+
 	awaitingReqs []sim.Msg
 }
 
@@ -144,6 +148,10 @@ func (m *defaultMemoryCopyMiddleware) needFlushing(
 	vAddr Ptr,
 	size uint64,
 ) bool {
+	if m.noCacheFlushing {
+		return false
+	}
+
 	startAddr := uint64(vAddr)
 	endAddr := uint64(vAddr) + size
 	for _, buf := range ctx.buffers {
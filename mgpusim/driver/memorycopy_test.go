@@ -2,9 +2,36 @@ package driver
 
 import (
 	"github.com/onsi/ginkgo/v2"
-	// . "github.com/onsi/gomega"
+	. "github.com/onsi/gomega"
 )
 
 var _ = ginkgo.Describe("Defaultmemorycopymiddleware", func() {
+	var (
+		ctx *Context
+		m   *defaultMemoryCopyMiddleware
+	)
 
+	ginkgo.BeforeEach(func() {
+		ctx = &Context{}
+		ctx.buffers = append(ctx.buffers, &buffer{
+			vAddr:   0x1000,
+			size:    0x1000,
+			l2Dirty: true,
+		})
+		m = &defaultMemoryCopyMiddleware{}
+	})
+
+	ginkgo.It("should flush when copying a dirty buffer", func() {
+		Expect(m.needFlushing(ctx, 0x1100, 0x100)).To(BeTrue())
+	})
+
+	ginkgo.It("should not flush when copying a clean buffer", func() {
+		Expect(m.needFlushing(ctx, 0x3000, 0x100)).To(BeFalse())
+	})
+
+	ginkgo.It("should not flush if the caches are coherent", func() {
+		m.noCacheFlushing = true
+
+		Expect(m.needFlushing(ctx, 0x1100, 0x100)).To(BeFalse())
+	})
 })
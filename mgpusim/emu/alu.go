@@ -69,6 +69,8 @@ func (u *ALUImpl) Run(state InstEmuState) {
 		u.runVOPC(state)
 	case insts.FLAT:
 		u.runFlat(state)
+	case insts.MUBUF:
+		u.runMUBUF(state)
 	case insts.SOPP:
 		u.runSOPP(state)
 	case insts.SOPK:
@@ -80,6 +82,17 @@ func (u *ALUImpl) Run(state InstEmuState) {
 	}
 }
 
+// runMUBUF runs the cache control instructions. As the emulator does not model
+// caches, they do not have any effect.
+func (u *ALUImpl) runMUBUF(state InstEmuState) {
+	inst := state.Inst()
+	switch inst.Opcode {
+	case 62, 63: // buffer_wbinvl1, buffer_wbinvl1_vol
+	default:
+		log.Panicf("Opcode %d for MUBUF format is not implemented", inst.Opcode)
+	}
+}
+
 func (u *ALUImpl) runSMEM(state InstEmuState) {
 	inst := state.Inst()
 	switch inst.Opcode {
@@ -31,6 +31,7 @@ type ComputeUnit struct {
 	storageAccessor    *storageAccessor
 
 	nextTick    sim.VTimeInSec
+	timingClock bool
 	queueingWGs []*protocol.MapWGReq
 	wfs         map[*kernels.WorkGroup][]*Wavefront
 	LDSStorage  []byte
@@ -111,8 +112,7 @@ func (cu *ComputeUnit) processMapWGReq(now sim.VTimeInSec) {
 	req := msg.(*protocol.MapWGReq)
 
 	if cu.nextTick <= now {
-		cu.nextTick = sim.VTimeInSec(math.Ceil(float64(now)))
-		//cu.nextTick = cu.Freq.NextTick(req.RecvTime())
+		cu.nextTick = cu.emulationTime(now)
 		evt := &emulationEvent{
 			sim.NewEventBase(cu.nextTick, cu),
 		}
@@ -123,6 +123,26 @@ func (cu *ComputeUnit) processMapWGReq(now sim.VTimeInSec) {
 	cu.wfs[req.WorkGroup] = make([]*Wavefront, 0, 64)
 }
 
+// emulationTime returns the time to run the work-groups that arrive at the
+// given time. The work-groups that arrive before the emulation runs are
+// emulated together.
+func (cu *ComputeUnit) emulationTime(now sim.VTimeInSec) sim.VTimeInSec {
+	if cu.timingClock {
+		return cu.Freq.NextTick(now)
+	}
+
+	return sim.VTimeInSec(math.Ceil(float64(now)))
+}
+
+// UseTimingClock lets the CU emulate the work-groups at the next cycle of the
+// given frequency, rather than at the next whole second. A CU that
+// fast-forwards the kernels of a timing simulation should use the frequency
+// of the GPU, so that fast-forwarding only advances the time by cycles.
+func (cu *ComputeUnit) UseTimingClock(freq sim.Freq) {
+	cu.Freq = freq
+	cu.timingClock = true
+}
+
 func (cu *ComputeUnit) runEmulation(evt *emulationEvent) error {
 	for len(cu.queueingWGs) > 0 {
 		wg := cu.queueingWGs[0]
@@ -312,6 +332,7 @@ func (cu *ComputeUnit) isAllWfCompleted(wg *kernels.WorkGroup) bool {
 
 func (cu *ComputeUnit) runWfUntilBarrier(wf *Wavefront) error {
 	for {
+		cu.storageAccessor.kind = MemAccessInst
 		instBuf := cu.storageAccessor.Read(wf.pid, wf.PC, 8)
 
 		inst, _ := cu.decoder.Decode(instBuf)
@@ -348,6 +369,11 @@ func (cu *ComputeUnit) logInst(wf *Wavefront, inst *insts.Inst) {
 }
 
 func (cu *ComputeUnit) executeInst(wf *Wavefront) {
+	cu.storageAccessor.kind = MemAccessVector
+	if wf.inst.FormatType == insts.SMEM {
+		cu.storageAccessor.kind = MemAccessScalar
+	}
+
 	cu.scratchpadPreparer.Prepare(wf, wf)
 	cu.alu.Run(wf)
 	cu.scratchpadPreparer.Commit(wf, wf)
@@ -419,6 +445,9 @@ func NewComputeUnit(
 	cu.scratchpadPreparer = scratchpadPreparer
 	cu.alu = alu
 	cu.storageAccessor = sAccessor
+	if sAccessor != nil {
+		sAccessor.domain = cu
+	}
 
 	cu.queueingWGs = make([]*protocol.MapWGReq, 0)
 	cu.wfs = make(map[*kernels.WorkGroup][]*Wavefront)
@@ -57,6 +57,8 @@ func (p *ScratchpadPreparerImpl) Prepare(
 		p.prepareVOPC(instEmuState, wf)
 	case insts.FLAT:
 		p.prepareFlat(instEmuState, wf)
+	case insts.MUBUF:
+		// The cache control instructions do not have operands.
 	case insts.SMEM:
 		p.prepareSMEM(instEmuState, wf)
 	case insts.SOPP:
@@ -333,6 +335,8 @@ func (p *ScratchpadPreparerImpl) Commit(
 		p.commitVOPC(instEmuState, wf)
 	case insts.FLAT:
 		p.commitFlat(instEmuState, wf)
+	case insts.MUBUF:
+		// The cache control instructions do not have operands.
 	case insts.SMEM:
 		p.commitSMEM(instEmuState, wf)
 	case insts.SOPP:
@@ -5,13 +5,70 @@ import (
 
 	"github.com/sarchlab/akita/v3/mem/mem"
 	"github.com/sarchlab/akita/v3/mem/vm"
+	"github.com/sarchlab/akita/v3/sim"
 )
 
+// HookPosMemAccess marks when an emulated wavefront accesses the memory. The
+// item of the hook context is a MemAccess.
+var HookPosMemAccess = &sim.HookPos{Name: "MemAccess"}
+
+// MemAccessKind tells which type of instruction makes a memory access.
+type MemAccessKind int
+
+// A list of all the memory access kinds.
+const (
+	MemAccessInst MemAccessKind = iota
+	MemAccessScalar
+	MemAccessVector
+)
+
+// MemAccess describes a memory access made by the emulator. An access that
+// crosses a page boundary is reported once for each page.
+type MemAccess struct {
+	Kind     MemAccessKind
+	Page     vm.Page
+	VAddr    uint64
+	PAddr    uint64
+	ByteSize uint64
+	IsWrite  bool
+}
+
 type storageAccessor struct {
 	storage       *mem.Storage
 	addrConverter mem.AddressConverter
 	pageTable     vm.PageTable
 	log2PageSize  uint64
+
+	domain hookInvoker
+	kind   MemAccessKind
+}
+
+type hookInvoker interface {
+	sim.Hookable
+	InvokeHook(ctx sim.HookCtx)
+}
+
+func (a *storageAccessor) notifyAccess(
+	page vm.Page,
+	vAddr, pAddr, byteSize uint64,
+	isWrite bool,
+) {
+	if a.domain == nil || a.domain.NumHooks() == 0 {
+		return
+	}
+
+	a.domain.InvokeHook(sim.HookCtx{
+		Domain: a.domain,
+		Pos:    HookPosMemAccess,
+		Item: MemAccess{
+			Kind:     a.kind,
+			Page:     page,
+			VAddr:    vAddr,
+			PAddr:    pAddr,
+			ByteSize: byteSize,
+			IsWrite:  isWrite,
+		},
+	})
 }
 
 func (a *storageAccessor) Read(pid vm.PID, vAddr, byteSize uint64) []byte {
@@ -45,6 +102,7 @@ func (a *storageAccessor) Read(pid vm.PID, vAddr, byteSize uint64) []byte {
 		}
 
 		copy(data[offset:], d)
+		a.notifyAccess(page, currVAddr, pAddr, sizeToRead, false)
 
 		offset += sizeToRead
 		sizeLeft -= sizeToRead
@@ -82,6 +140,8 @@ func (a *storageAccessor) Write(pid vm.PID, vAddr uint64, data []byte) {
 			log.Panic(err)
 		}
 
+		a.notifyAccess(page, currVAddr, pAddr, sizeToWrite, true)
+
 		offset += sizeToWrite
 		sizeLeft -= sizeToWrite
 	}
@@ -224,6 +224,10 @@ func (d *Disassembler) initializeDecodeTable() {
 	d.addInstType(&InstType{"flat_atomic_inc_x2", 92, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
 	d.addInstType(&InstType{"flat_atomic_dec_x2", 93, FormatTable[FLAT], 0, ExeUnitVMem, 32, 32, 32, 0, 0})
 
+	// MUBUF Instructions
+	d.addInstType(&InstType{"buffer_wbinvl1", 62, FormatTable[MUBUF], 0, ExeUnitVMem, 0, 0, 0, 0, 0})
+	d.addInstType(&InstType{"buffer_wbinvl1_vol", 63, FormatTable[MUBUF], 0, ExeUnitVMem, 0, 0, 0, 0, 0})
+
 	// SMEM instructions
 	d.addInstType(&InstType{"s_load_dword", 0, FormatTable[SMEM], 0, ExeUnitScalar, 32, 32, 32, 0, 0})
 	d.addInstType(&InstType{"s_load_dwordx2", 1, FormatTable[SMEM], 0, ExeUnitScalar, 32, 32, 32, 0, 0})
@@ -379,6 +379,20 @@ func (d *Disassembler) decodeFLAT(inst *Inst, buf []byte) error {
 	return nil
 }
 
+func (d *Disassembler) decodeMUBUF(inst *Inst, buf []byte) error {
+	bytesLo := binary.LittleEndian.Uint32(buf)
+
+	if extractBits(bytesLo, 14, 14) != 0 {
+		inst.GlobalLevelCoherent = true
+	}
+
+	if extractBits(bytesLo, 17, 17) != 0 {
+		inst.SystemLevelCoherent = true
+	}
+
+	return nil
+}
+
 //nolint:gocyclo,funlen
 func (d *Disassembler) decodeSMEM(inst *Inst, buf []byte) error {
 	bytesLo := binary.LittleEndian.Uint32(buf)
@@ -732,6 +746,8 @@ func (d *Disassembler) Decode(buf []byte) (*Inst, error) {
 		err = d.decodeVOP1(inst, buf)
 	case FLAT:
 		err = d.decodeFLAT(inst, buf)
+	case MUBUF:
+		err = d.decodeMUBUF(inst, buf)
 	case SOPP:
 		err = d.decodeSOPP(inst, buf)
 	case VOPC:
@@ -41,6 +41,15 @@ var _ = Describe("Disassembler", func() {
 		Expect(inst.String(nil)).To(Equal("s_waitcnt vmcnt(1) lgkmcnt(1)"))
 	})
 
+	It("should decode E0F80000 00000000", func() {
+		buf := []byte{0x00, 0x00, 0xf8, 0xe0, 0x00, 0x00, 0x00, 0x00}
+
+		inst, err := disassembler.Decode(buf)
+
+		Expect(err).To(BeNil())
+		Expect(inst.String(nil)).To(Equal("buffer_wbinvl1"))
+	})
+
 	It("should decode D81A0004 00000210", func() {
 		buf := []byte{0x04, 0x00, 0x1A, 0xd8, 0x10, 0x02, 0x00, 0x00}
 
@@ -119,6 +119,12 @@ func (i Inst) flatString() string {
 	return s
 }
 
+// mubufString only supports the cache control instructions, which do not
+// have operands.
+func (i Inst) mubufString() string {
+	return i.InstName
+}
+
 func (i Inst) smemString() string {
 	// TODO: Consider store instructions, and the case if imm = 0
 	s := fmt.Sprintf("%s %s, %s, %#x",
@@ -345,6 +351,8 @@ func (i Inst) String(file *elf.File) string {
 		return i.vop2String()
 	case FLAT:
 		return i.flatString()
+	case MUBUF:
+		return i.mubufString()
 	case SOPP:
 		return i.soppString(file)
 	case VOPC:
@@ -39,8 +39,12 @@ type WorkGroup struct {
 
 // NewWorkGroup creates a workgroup object.
 func NewWorkGroup() *WorkGroup {
+	return newWorkGroup(sim.GetIDGenerator())
+}
+
+func newWorkGroup(idGenerator sim.IDGenerator) *WorkGroup {
 	wg := new(WorkGroup)
-	wg.UID = sim.GetIDGenerator().Generate()
+	wg.UID = idGenerator.Generate()
 	wg.Wavefronts = make([]*Wavefront, 0)
 	wg.WorkItems = make([]*WorkItem, 0)
 	return wg
@@ -61,8 +65,12 @@ type Wavefront struct {
 
 // NewWavefront returns a new Wavefront.
 func NewWavefront() *Wavefront {
+	return newWavefront(sim.GetIDGenerator())
+}
+
+func newWavefront(idGenerator sim.IDGenerator) *Wavefront {
 	wf := new(Wavefront)
-	wf.UID = sim.GetIDGenerator().Generate()
+	wf.UID = idGenerator.Generate()
 	wf.WorkItems = make([]*WorkItem, 0, 64)
 	return wf
 }
@@ -1,6 +1,9 @@
 package kernels
 
-import "github.com/sarchlab/mgpusim/v3/insts"
+import (
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/mgpusim/v3/insts"
+)
 
 // WGFilterFunc is a filter
 type WGFilterFunc func(
@@ -25,17 +28,19 @@ type GridBuilder interface {
 	Skip(n int)
 }
 
-// NewGridBuilder creates a default grid builder
-func NewGridBuilder() GridBuilder {
-	return &gridBuilderImpl{}
+// NewGridBuilder creates a default grid builder. The IDs of the work-groups
+// and the wavefronts are generated by the given ID generator.
+func NewGridBuilder(idGenerator sim.IDGenerator) GridBuilder {
+	return &gridBuilderImpl{idGenerator: idGenerator}
 }
 
 type gridBuilderImpl struct {
-	hsaco      *insts.HsaCo
-	packet     *HsaKernelDispatchPacket
-	filter     WGFilterFunc
-	packetAddr uint64
-	numWG      int
+	idGenerator sim.IDGenerator
+	hsaco       *insts.HsaCo
+	packet      *HsaKernelDispatchPacket
+	filter      WGFilterFunc
+	packetAddr  uint64
+	numWG       int
 
 	xid, yid, zid int
 }
@@ -93,7 +98,7 @@ func (b *gridBuilderImpl) NumWG() int {
 }
 
 func (b *gridBuilderImpl) NextWG() *WorkGroup {
-	wg := NewWorkGroup()
+	wg := newWorkGroup(b.idGenerator)
 
 	for {
 		xLeft := int(b.packet.GridSizeX) - b.xid*int(b.packet.WorkgroupSizeX)
@@ -169,7 +174,7 @@ func (b *gridBuilderImpl) formWavefronts(wg *WorkGroup) {
 		wg := wi.WG
 		inWGID := wi.IDZ*wg.SizeX*wg.SizeY + wi.IDY*wg.SizeX + wi.IDX
 		if inWGID%wavefrontSize == 0 {
-			wf = NewWavefront()
+			wf = newWavefront(b.idGenerator)
 			wf.FirstWiFlatID = wg.WorkItems[i].FlattenedID()
 			wf.CodeObject = b.hsaco
 			wf.Packet = b.packet
@@ -3,6 +3,7 @@ package kernels
 import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/sarchlab/akita/v3/sim"
 	"github.com/sarchlab/mgpusim/v3/insts"
 )
 
@@ -13,7 +14,7 @@ var _ = Describe("GridBuilder", func() {
 	)
 
 	BeforeEach(func() {
-		builder = &gridBuilderImpl{}
+		builder = &gridBuilderImpl{idGenerator: sim.GetIDGenerator()}
 	})
 
 	It("should build partial wavefront", func() {
@@ -45,7 +45,7 @@ func (b CUPipelineRestartReqBuilder) WithDst(dst sim.Port) CUPipelineRestartReqB
 // Build creats a new CUPipelineRestartReq
 func (b CUPipelineRestartReqBuilder) Build() *CUPipelineRestartReq {
 	r := &CUPipelineRestartReq{}
-	r.ID = sim.GetIDGenerator().Generate()
+	r.ID = sim.IDGeneratorFor(b.src).Generate()
 	r.Src = b.src
 	r.Dst = b.dst
 	r.SendTime = b.sendTime
@@ -91,7 +91,7 @@ func (b CUPipelineRestartRspBuilder) WithDst(dst sim.Port) CUPipelineRestartRspB
 // Build creats a new CUPipelineRestartRsp
 func (b CUPipelineRestartRspBuilder) Build() *CUPipelineRestartRsp {
 	r := &CUPipelineRestartRsp{}
-	r.ID = sim.GetIDGenerator().Generate()
+	r.ID = sim.IDGeneratorFor(b.src).Generate()
 	r.Src = b.src
 	r.Dst = b.dst
 	r.SendTime = b.sendTime
@@ -137,7 +137,7 @@ func (b CUPipelineFlushReqBuilder) WithDst(dst sim.Port) CUPipelineFlushReqBuild
 // Build creats a new CUPipelineFlushReq
 func (b CUPipelineFlushReqBuilder) Build() *CUPipelineFlushReq {
 	r := &CUPipelineFlushReq{}
-	r.ID = sim.GetIDGenerator().Generate()
+	r.ID = sim.IDGeneratorFor(b.src).Generate()
 	r.Src = b.src
 	r.Dst = b.dst
 	r.SendTime = b.sendTime
@@ -183,7 +183,7 @@ func (b CUPipelineFlushRspBuilder) WithDst(dst sim.Port) CUPipelineFlushRspBuild
 // Build creates a new CUPipelineFlushRsp
 func (b CUPipelineFlushRspBuilder) Build() *CUPipelineFlushRsp {
 	r := &CUPipelineFlushRsp{}
-	r.ID = sim.GetIDGenerator().Generate()
+	r.ID = sim.IDGeneratorFor(b.src).Generate()
 	r.Src = b.src
 	r.Dst = b.dst
 	r.SendTime = b.sendTime
@@ -261,7 +261,7 @@ func (b MapWGReqBuilder) AddWf(wf WfDispatchLocation) MapWGReqBuilder {
 // Build creates the MapWGReq.
 func (b MapWGReqBuilder) Build() *MapWGReq {
 	r := &MapWGReq{}
-	r.Meta().ID = sim.GetIDGenerator().Generate()
+	r.Meta().ID = sim.IDGeneratorFor(b.src).Generate()
 	r.Meta().SendTime = b.sendTime
 	r.Meta().Src = b.src
 	r.Meta().Dst = b.dst
@@ -325,7 +325,7 @@ func (b WGCompletionMsgBuilder) WithRspTo(
 // Build builds WGCompletionMsg
 func (b WGCompletionMsgBuilder) Build() *WGCompletionMsg {
 	msg := &WGCompletionMsg{}
-	msg.Meta().ID = sim.GetIDGenerator().Generate()
+	msg.Meta().ID = sim.IDGeneratorFor(b.src).Generate()
 	msg.Meta().SendTime = b.sendTime
 	msg.Meta().Src = b.src
 	msg.Meta().Dst = b.dst
@@ -21,7 +21,7 @@ func (m *FlushReq) Meta() *sim.MsgMeta {
 // with time and the source and destination.
 func NewFlushReq(time sim.VTimeInSec, src, dst sim.Port) *FlushReq {
 	cmd := new(FlushReq)
-	cmd.ID = sim.GetIDGenerator().Generate()
+	cmd.ID = sim.IDGeneratorFor(src).Generate()
 	cmd.SendTime = time
 	cmd.Src = src
 	cmd.Dst = dst
@@ -51,7 +51,7 @@ func NewLaunchKernelReq(
 	src, dst sim.Port,
 ) *LaunchKernelReq {
 	r := new(LaunchKernelReq)
-	r.ID = sim.GetIDGenerator().Generate()
+	r.ID = sim.IDGeneratorFor(src).Generate()
 	r.SendTime = time
 	r.Src = src
 	r.Dst = dst
@@ -78,7 +78,7 @@ func NewLaunchKernelRsp(
 	rspTo string,
 ) *LaunchKernelRsp {
 	r := new(LaunchKernelRsp)
-	r.ID = sim.GetIDGenerator().Generate()
+	r.ID = sim.IDGeneratorFor(src).Generate()
 	r.SendTime = time
 	r.Src = src
 	r.Dst = dst
@@ -109,7 +109,7 @@ func NewMemCopyH2DReq(
 	dstAddress uint64,
 ) *MemCopyH2DReq {
 	req := new(MemCopyH2DReq)
-	req.ID = sim.GetIDGenerator().Generate()
+	req.ID = sim.IDGeneratorFor(src).Generate()
 	req.MsgMeta.TrafficBytes = len(srcBuffer)
 	req.SendTime = time
 	req.Src = src
@@ -140,7 +140,7 @@ func NewMemCopyD2HReq(
 	dstBuffer []byte,
 ) *MemCopyD2HReq {
 	req := new(MemCopyD2HReq)
-	req.ID = sim.GetIDGenerator().Generate()
+	req.ID = sim.IDGeneratorFor(src).Generate()
 	req.MsgMeta.TrafficBytes = len(dstBuffer)
 	req.SendTime = time
 	req.Src = src
@@ -175,7 +175,7 @@ func NewShootdownCommand(
 	pID vm.PID,
 ) *ShootDownCommand {
 	cmd := new(ShootDownCommand)
-	cmd.ID = sim.GetIDGenerator().Generate()
+	cmd.ID = sim.IDGeneratorFor(src).Generate()
 	cmd.SendTime = time
 	cmd.Src = src
 	cmd.Dst = dst
@@ -203,7 +203,7 @@ func NewShootdownCompleteRsp(
 	src, dst sim.Port,
 ) *ShootDownCompleteRsp {
 	cmd := new(ShootDownCompleteRsp)
-	cmd.ID = sim.GetIDGenerator().Generate()
+	cmd.ID = sim.IDGeneratorFor(src).Generate()
 	cmd.SendTime = time
 	cmd.Src = src
 	cmd.Dst = dst
@@ -229,7 +229,7 @@ func NewRDMADrainCmdFromDriver(
 	src, dst sim.Port,
 ) *RDMADrainCmdFromDriver {
 	cmd := new(RDMADrainCmdFromDriver)
-	cmd.ID = sim.GetIDGenerator().Generate()
+	cmd.ID = sim.IDGeneratorFor(src).Generate()
 	cmd.SendTime = time
 	cmd.Src = src
 	cmd.Dst = dst
@@ -255,7 +255,7 @@ func NewRDMADrainRspToDriver(
 	src, dst sim.Port,
 ) *RDMADrainRspToDriver {
 	cmd := new(RDMADrainRspToDriver)
-	cmd.ID = sim.GetIDGenerator().Generate()
+	cmd.ID = sim.IDGeneratorFor(src).Generate()
 	cmd.SendTime = time
 	cmd.Src = src
 	cmd.Dst = dst
@@ -281,7 +281,7 @@ func NewRDMARestartCmdFromDriver(
 	src, dst sim.Port,
 ) *RDMARestartCmdFromDriver {
 	cmd := new(RDMARestartCmdFromDriver)
-	cmd.ID = sim.GetIDGenerator().Generate()
+	cmd.ID = sim.IDGeneratorFor(src).Generate()
 	cmd.SendTime = time
 	cmd.Src = src
 	cmd.Dst = dst
@@ -307,7 +307,7 @@ func NewGPURestartReq(
 	src, dst sim.Port,
 ) *GPURestartReq {
 	cmd := new(GPURestartReq)
-	cmd.ID = sim.GetIDGenerator().Generate()
+	cmd.ID = sim.IDGeneratorFor(src).Generate()
 	cmd.SendTime = time
 	cmd.Src = src
 	cmd.Dst = dst
@@ -333,7 +333,7 @@ func NewGPURestartRsp(
 	src, dst sim.Port,
 ) *GPURestartRsp {
 	cmd := new(GPURestartRsp)
-	cmd.ID = sim.GetIDGenerator().Generate()
+	cmd.ID = sim.IDGeneratorFor(src).Generate()
 	cmd.SendTime = time
 	cmd.Src = src
 	cmd.Dst = dst
@@ -364,7 +364,7 @@ func NewPageMigrationReqToCP(
 	src, dst sim.Port,
 ) *PageMigrationReqToCP {
 	cmd := new(PageMigrationReqToCP)
-	cmd.ID = sim.GetIDGenerator().Generate()
+	cmd.ID = sim.IDGeneratorFor(src).Generate()
 	cmd.SendTime = time
 	cmd.Src = src
 	cmd.Dst = dst
@@ -390,7 +390,7 @@ func NewPageMigrationRspToDriver(
 	src, dst sim.Port,
 ) *PageMigrationRspToDriver {
 	cmd := new(PageMigrationRspToDriver)
-	cmd.ID = sim.GetIDGenerator().Generate()
+	cmd.ID = sim.IDGeneratorFor(src).Generate()
 	cmd.SendTime = time
 	cmd.Src = src
 	cmd.Dst = dst
@@ -0,0 +1,374 @@
+package runner
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/akita/v3/tracing"
+	"github.com/sarchlab/mgpusim/v3/driver"
+	"github.com/sarchlab/mgpusim/v3/protocol"
+)
+
+// A stateSaver saves its state under a given key of a checkpoint, like the
+// TickScheduler, the storage, the metrics registry, and the tracers.
+type stateSaver interface {
+	SaveStateAs(cp *sim.Checkpoint, key string) error
+	LoadStateAs(cp *sim.Checkpoint, key string) error
+}
+
+// A namedState saves the state of a stateSaver under its name, so that the
+// states that are not owned by any component, such as the global storage
+// shared by the driver and the DRAM controllers, are in the checkpoints.
+type namedState struct {
+	name  string
+	saver stateSaver
+}
+
+func (s namedState) Name() string {
+	return s.name
+}
+
+func (s namedState) SaveState(cp *sim.Checkpoint) error {
+	return s.saver.SaveStateAs(cp, s.name)
+}
+
+func (s namedState) LoadState(cp *sim.Checkpoint) error {
+	return s.saver.LoadStateAs(cp, s.name)
+}
+
+type runnerCheckpointState struct {
+	AfterKernel int
+}
+
+// A runnerState makes sure that a checkpoint is restored at the point where
+// it is saved.
+type runnerState struct {
+	afterKernel int
+}
+
+func (s runnerState) Name() string {
+	return "Runner"
+}
+
+func (s runnerState) SaveState(cp *sim.Checkpoint) error {
+	return cp.Put(s.Name(), runnerCheckpointState{AfterKernel: s.afterKernel})
+}
+
+func (s runnerState) LoadState(cp *sim.Checkpoint) error {
+	state := runnerCheckpointState{}
+	err := cp.Get(s.Name(), &state)
+	if err != nil {
+		return err
+	}
+
+	if state.AfterKernel != s.afterKernel {
+		return fmt.Errorf(
+			"the checkpoint is saved after kernel %d, not after kernel %d",
+			state.AfterKernel, s.afterKernel)
+	}
+
+	return nil
+}
+
+// A kernelCheckpointer saves a checkpoint when the command queue is drained
+// after a number of kernels complete. It immediately restores the simulation
+// from the checkpoint and lets the simulation continue, so that a run with the
+// checkpoint can be compared with a run without it.
+//
+// If a restore file is given, the kernelCheckpointer restores the checkpoint
+// from the file instead. As the KernelSampler of the Command Processors, it
+// fast-forwards the kernels before the checkpoint, which are replaced by the
+// checkpoint anyway.
+type kernelCheckpointer struct {
+	lock         sync.Mutex
+	checkpointer *sim.Checkpointer
+	fileName     string
+	restoreFile  string
+
+	afterKernel   int
+	kernelIDs     map[string]bool
+	numKernelDone int
+	done          bool
+}
+
+// StartTask records the kernel launching commands.
+func (c *kernelCheckpointer) StartTask(task tracing.Task) {
+	if task.What != "*driver.LaunchKernelCommand" &&
+		task.What != "*driver.LaunchUnifiedMultiGPUKernelCommand" {
+		return
+	}
+
+	c.lock.Lock()
+	c.kernelIDs[task.ID] = true
+	c.lock.Unlock()
+}
+
+// StepTask does nothing.
+func (c *kernelCheckpointer) StepTask(task tracing.Task) {
+	// Do nothing
+}
+
+// EndTask counts the completed kernels.
+func (c *kernelCheckpointer) EndTask(task tracing.Task) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.kernelIDs[task.ID] {
+		delete(c.kernelIDs, task.ID)
+		c.numKernelDone++
+	}
+}
+
+// Sample simulates the kernels in detail only after the checkpoint is
+// restored.
+func (c *kernelCheckpointer) Sample(_ *protocol.LaunchKernelReq) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.done
+}
+
+// Func saves and restores the checkpoint when the command queue is drained.
+func (c *kernelCheckpointer) Func(ctx sim.HookCtx) {
+	if ctx.Pos != driver.HookPosCommandQueueDrained {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.done || c.numKernelDone < c.afterKernel {
+		return
+	}
+
+	c.done = true
+
+	if c.restoreFile != "" {
+		c.restoreFromFile()
+		return
+	}
+
+	buf := bytes.NewBuffer(nil)
+	err := c.checkpointer.Save(buf)
+	if err != nil {
+		log.Panicf("cannot save checkpoint: %v", err)
+	}
+
+	if c.fileName != "" {
+		err = os.WriteFile(c.fileName, buf.Bytes(), 0o644)
+		if err != nil {
+			log.Panicf("cannot write checkpoint: %v", err)
+		}
+	}
+
+	err = c.checkpointer.Restore(buf)
+	if err != nil {
+		log.Panicf("cannot restore checkpoint: %v", err)
+	}
+}
+
+func (c *kernelCheckpointer) restoreFromFile() {
+	f, err := os.Open(c.restoreFile)
+	if err != nil {
+		log.Panicf("cannot read checkpoint: %v", err)
+	}
+	defer f.Close()
+
+	err = c.checkpointer.Restore(bufio.NewReader(f))
+	if err != nil {
+		log.Panicf("cannot restore checkpoint: %v", err)
+	}
+}
+
+func (r *Runner) addCheckpointer() {
+	if r.CheckpointAfterKernel == 0 {
+		if r.RestoreCheckpoint != "" {
+			log.Panic("-restore-checkpoint requires -checkpoint-after-kernel")
+		}
+
+		return
+	}
+
+	r.mustBeCheckpointable()
+
+	c := &kernelCheckpointer{
+		checkpointer: sim.NewCheckpointer(r.platform.Engine),
+		fileName:     *checkpointFileFlag,
+		restoreFile:  r.RestoreCheckpoint,
+		afterKernel:  r.CheckpointAfterKernel,
+		kernelIDs:    make(map[string]bool),
+	}
+
+	r.registerCheckpointState(c.checkpointer)
+
+	if c.restoreFile != "" {
+		for _, gpu := range r.platform.GPUs {
+			gpu.CommandProcessor.KernelSampler = c
+		}
+	}
+
+	tracing.CollectTrace(r.platform.Driver, c)
+	r.platform.Driver.AcceptHook(c)
+}
+
+// mustBeCheckpointable panics before the simulation starts if the simulation
+// is configured with a feature that cannot be checkpointed.
+func (r *Runner) mustBeCheckpointable() {
+	if !r.Timing {
+		log.Panic("-checkpoint-after-kernel only works with -timing")
+	}
+
+	if r.isSampling() {
+		log.Panic("-checkpoint-after-kernel does not work with " +
+			"-fast-forward-kernels, -fast-forward-insts, or -sample-kernels")
+	}
+
+	if *maxInstCount != 0 {
+		log.Panic("-checkpoint-after-kernel does not work with -max-inst")
+	}
+
+	if r.RestoreCheckpoint != "" &&
+		(*metricsPeriodFlag != 0 || *analyszerNameFlag != "") {
+		log.Panic("-restore-checkpoint does not work with -metrics-period " +
+			"or -analyszer-name")
+	}
+
+	for _, gpu := range r.platform.GPUs {
+		caches := concatComponents(
+			gpu.L1VCaches, gpu.L1SCaches, gpu.L1ICaches, gpu.L2Caches)
+		for _, c := range caches {
+			checker, ok := c.(interface{ CheckCheckpointable() error })
+			if !ok {
+				continue
+			}
+
+			err := checker.CheckCheckpointable()
+			if err != nil {
+				log.Panicf("-checkpoint-after-kernel: %v", err)
+			}
+		}
+	}
+}
+
+// registerCheckpointState registers all the states that a simulation restored
+// in a new process needs to continue exactly as the simulation that saves the
+// checkpoint, including the metrics and the tracers that the results are
+// reported from.
+func (r *Runner) registerCheckpointState(checkpointer *sim.Checkpointer) {
+	checkpointer.Register(runnerState{afterKernel: r.CheckpointAfterKernel})
+
+	if r.platform.GlobalStorage != nil {
+		checkpointer.Register(namedState{
+			name:  "GlobalStorage",
+			saver: r.platform.GlobalStorage,
+		})
+	}
+
+	checkpointer.Register(
+		namedState{name: "Metrics", saver: r.platform.Metrics},
+		namedState{
+			name:  r.platform.Driver.Name() + ".KernelTime",
+			saver: r.kernelTimeCounter,
+		})
+
+	for i, t := range r.perGPUKernelTimeCounter {
+		checkpointer.Register(namedState{
+			name:  r.platform.GPUs[i].CommandProcessor.Name() + ".KernelTime",
+			saver: t,
+		})
+	}
+
+	for _, t := range r.cuCPITraces {
+		checkpointer.Register(
+			namedState{name: t.cu.Name() + ".CPIStack", saver: t.tracer})
+	}
+
+	for _, gpu := range r.platform.GPUs {
+		checkpointer.Register(gpu.CommandProcessor)
+		registerCheckpointables(checkpointer,
+			gpu.CUs,
+			gpu.L1VCaches, gpu.L1SCaches, gpu.L1ICaches, gpu.L2Caches,
+			gpu.L1VTLBs, gpu.L1STLBs, gpu.L1ITLBs, gpu.L2TLBs,
+			gpu.MemControllers, gpu.Directories)
+	}
+
+	r.registerOtherComponents(checkpointer)
+}
+
+// registerOtherComponents registers the ports, the connections, and the tick
+// schedulers of the components that are not checkpointed. These components
+// are idle when the checkpoint is saved. However, in a new process that
+// fast-forwards to the checkpoint, they may have scheduled to tick at a time
+// that is later than the time of the checkpoint and would not tick again
+// until then.
+func (r *Runner) registerOtherComponents(checkpointer *sim.Checkpointer) {
+	components := r.monitor.Components()
+	for _, gpu := range r.platform.GPUs {
+		for _, cu := range gpu.FastForwardCUs {
+			components = append(components, cu.(sim.Component))
+		}
+	}
+
+	connections := make(map[string]sim.Checkpointable)
+	for _, comp := range components {
+		for _, port := range comp.Ports() {
+			checkpointer.RegisterPort(port)
+
+			p, ok := port.(interface{ Connection() sim.Connection })
+			if !ok {
+				continue
+			}
+
+			conn, ok := p.Connection().(sim.Checkpointable)
+			if ok {
+				connections[conn.Name()] = conn
+			}
+		}
+
+		if _, ok := comp.(sim.Checkpointable); ok {
+			continue
+		}
+
+		checkpointer.RegisterHandler(comp.Name(), comp)
+
+		scheduler, ok := comp.(stateSaver)
+		if ok {
+			checkpointer.Register(namedState{
+				name:  comp.Name() + ".TickScheduler",
+				saver: scheduler,
+			})
+		}
+	}
+
+	names := make([]string, 0, len(connections))
+	for name := range connections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		checkpointer.Register(connections[name])
+	}
+}
+
+func registerCheckpointables(
+	checkpointer *sim.Checkpointer,
+	components ...[]TraceableComponent,
+) {
+	for _, list := range components {
+		for _, comp := range list {
+			checkpointable, ok := comp.(sim.Checkpointable)
+			if !ok {
+				log.Panicf("%s cannot be checkpointed", comp.Name())
+			}
+
+			checkpointer.Register(checkpointable)
+		}
+	}
+}
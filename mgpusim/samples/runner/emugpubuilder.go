@@ -114,6 +114,7 @@ func (b EmuGPUBuilder) Build(name string) *GPU {
 	b.populateExternalPorts()
 
 	return &GPU{
+		Engine:           b.engine,
 		Domain:           b.gpu,
 		CommandProcessor: b.commandProcessor,
 	}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/sarchlab/akita/v3/mem/mem"
 	"github.com/sarchlab/akita/v3/mem/vm"
+	"github.com/sarchlab/akita/v3/metrics"
 	"github.com/sarchlab/akita/v3/sim"
 	"github.com/sarchlab/mgpusim/v3/driver"
 )
@@ -12,6 +13,8 @@ import (
 // EmuBuilder can build a platform for emulation purposes.
 type EmuBuilder struct {
 	useParallelEngine  bool
+	deterministic      bool
+	orderedEvents      bool
 	debugISA           bool
 	traceVis           bool
 	traceMem           bool
@@ -36,6 +39,22 @@ func (b EmuBuilder) WithParallelEngine() EmuBuilder {
 	return b
 }
 
+// WithDeterministicParallelEngine lets the EmuBuilder to use a parallel engine
+// that runs in the deterministic parallel mode.
+func (b EmuBuilder) WithDeterministicParallelEngine() EmuBuilder {
+	b.useParallelEngine = true
+	b.deterministic = true
+	return b
+}
+
+// WithOrderedSerialEngine lets the platform use a serial engine that orders
+// the events that happen at the same time in the same way as the
+// deterministic parallel mode and the conservative engine.
+func (b EmuBuilder) WithOrderedSerialEngine() EmuBuilder {
+	b.orderedEvents = true
+	return b
+}
+
 // WithISADebugging enables ISA debugging in the simulation.
 func (b EmuBuilder) WithISADebugging() EmuBuilder {
 	b.debugISA = true
@@ -75,9 +94,14 @@ func (b EmuBuilder) WithMagicMemoryCopy() EmuBuilder {
 // Build builds a emulation platform.
 func (b EmuBuilder) Build() *Platform {
 	var engine sim.Engine
-	if b.useParallelEngine {
+	switch {
+	case b.deterministic:
+		engine = sim.NewDeterministicParallelEngine()
+	case b.useParallelEngine:
 		engine = sim.NewParallelEngine()
-	} else {
+	case b.orderedEvents:
+		engine = sim.NewOrderedSerialEngine()
+	default:
 		engine = sim.NewSerialEngine()
 	}
 	// engine.AcceptHook(sim.NewEventLogger(log.New(os.Stdout, "", 0)))
@@ -107,9 +131,10 @@ func (b EmuBuilder) Build() *Platform {
 	connection.PlugIn(gpuDriver.GetPortByName("GPU"), 4)
 
 	return &Platform{
-		Engine: engine,
-		Driver: gpuDriver,
-		GPUs:   b.gpus,
+		Engine:  engine,
+		Driver:  gpuDriver,
+		GPUs:    b.gpus,
+		Metrics: metrics.DefaultRegistry(),
 	}
 }
 
@@ -0,0 +1,356 @@
+package runner
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/sarchlab/akita/v3/mem/cache/writearound"
+	"github.com/sarchlab/akita/v3/mem/cache/writeback"
+	"github.com/sarchlab/akita/v3/mem/cache/writethrough"
+	"github.com/sarchlab/akita/v3/mem/mem"
+	"github.com/sarchlab/akita/v3/mem/vm"
+	"github.com/sarchlab/akita/v3/mem/vm/tlb"
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/akita/v3/tracing"
+	"github.com/sarchlab/mgpusim/v3/emu"
+	"github.com/sarchlab/mgpusim/v3/insts"
+	"github.com/sarchlab/mgpusim/v3/protocol"
+)
+
+// cuWarmUpTargets are the TLBs and caches that serve the memory accesses of a
+// compute unit.
+type cuWarmUpTargets struct {
+	l1vTLB   *tlb.TLB
+	l1vCache *writearound.Cache
+	l1sTLB   *tlb.TLB
+	l1sCache *writethrough.Cache
+	l1iTLB   *tlb.TLB
+}
+
+// A functionalWarmer keeps the TLBs and the caches of a GPU warm while the
+// functional compute units run the fast-forwarded kernels. It replays the
+// memory accesses of each functional compute unit on the TLBs and caches that
+// would serve the accesses of the corresponding timing compute unit.
+//
+// The instruction caches are virtually addressed and are not warmed up.
+type functionalWarmer struct {
+	storage           *mem.Storage
+	log2CacheLineSize uint64
+	memLow, memHigh   uint64
+
+	targets   map[sim.Hookable]cuWarmUpTargets
+	l1vCaches []*writearound.Cache
+	l1sCaches []*writethrough.Cache
+	l2Caches  []*writeback.Cache
+	l2TLBs    []*tlb.TLB
+
+	// pending is true if some lines are warmed up after the last refill.
+	pending bool
+}
+
+// Func warms up the TLBs and the caches with a memory access.
+func (w *functionalWarmer) Func(ctx sim.HookCtx) {
+	if ctx.Pos != emu.HookPosMemAccess {
+		return
+	}
+
+	targets, ok := w.targets[ctx.Domain]
+	if !ok {
+		return
+	}
+
+	access := ctx.Item.(emu.MemAccess)
+	w.pending = true
+
+	switch access.Kind {
+	case emu.MemAccessInst:
+		targets.l1iTLB.WarmUp(access.Page)
+	case emu.MemAccessScalar:
+		targets.l1sTLB.WarmUp(access.Page)
+	case emu.MemAccessVector:
+		targets.l1vTLB.WarmUp(access.Page)
+	}
+
+	for _, l2TLB := range w.l2TLBs {
+		l2TLB.WarmUp(access.Page)
+	}
+
+	w.warmUpCaches(targets, access)
+}
+
+func (w *functionalWarmer) warmUpCaches(
+	targets cuWarmUpTargets,
+	access emu.MemAccess,
+) {
+	lineSize := uint64(1) << w.log2CacheLineSize
+	firstLine := access.PAddr >> w.log2CacheLineSize << w.log2CacheLineSize
+	end := access.PAddr + access.ByteSize
+	pid := access.Page.PID
+
+	for addr := firstLine; addr < end; addr += lineSize {
+		if !access.IsWrite {
+			switch access.Kind {
+			case emu.MemAccessScalar:
+				targets.l1sCache.WarmUp(pid, addr)
+			case emu.MemAccessVector:
+				targets.l1vCache.WarmUp(pid, addr)
+			}
+		}
+
+		if addr < w.memLow || addr >= w.memHigh {
+			continue
+		}
+
+		w.warmUpL2Caches(pid, addr, access)
+	}
+}
+
+// warmUpL2Caches brings a line into the L2 caches. The L1 caches never hold
+// dirty data, so the written bytes only make the L2 lines dirty.
+func (w *functionalWarmer) warmUpL2Caches(
+	pid vm.PID,
+	lineAddr uint64,
+	access emu.MemAccess,
+) {
+	if !access.IsWrite {
+		for _, l2 := range w.l2Caches {
+			l2.WarmUp(pid, lineAddr)
+		}
+
+		return
+	}
+
+	lineSize := uint64(1) << w.log2CacheLineSize
+	start := lineAddr
+	if access.PAddr > start {
+		start = access.PAddr
+	}
+
+	end := lineAddr + lineSize
+	if access.PAddr+access.ByteSize < end {
+		end = access.PAddr + access.ByteSize
+	}
+
+	for _, l2 := range w.l2Caches {
+		l2.WarmUpWrite(pid, start, end-start)
+	}
+}
+
+// FinishWarmUp loads the latest data of the warmed-up cache lines from the
+// memory. The caches are not refilled if no line is warmed up after the last
+// refill.
+func (w *functionalWarmer) FinishWarmUp() {
+	if !w.pending {
+		return
+	}
+
+	w.pending = false
+
+	for _, c := range w.l1vCaches {
+		w.mustRefill(c.Refill(w.storage))
+	}
+
+	for _, c := range w.l1sCaches {
+		w.mustRefill(c.Refill(w.storage))
+	}
+
+	for _, c := range w.l2Caches {
+		w.mustRefill(c.Refill(w.storage))
+	}
+}
+
+func (w *functionalWarmer) mustRefill(err error) {
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+// A kernelCluster is a group of kernels that are expected to perform
+// similarly. The kernels in a cluster run the same code with the same grid
+// and work-group sizes.
+type kernelCluster struct {
+	numDetailed        int
+	numFastForwarded   int
+	detailedKernelTime sim.VTimeInSec
+}
+
+// A kernelSampler decides which kernels of a GPU are simulated in detail.
+//
+// The kernels are fast-forwarded until both the given number of kernels and
+// the given number of instructions are executed. After that, if the number of
+// samples per cluster is set, only the first few kernels of each cluster are
+// simulated in detail and the others are fast-forwarded. The time of the
+// fast-forwarded kernels is estimated with the average time of the detailed
+// kernels of the same cluster.
+type kernelSampler struct {
+	timeTeller         sim.TimeTeller
+	fastForwardKernels int
+	fastForwardInsts   uint64
+	samplesPerCluster  int
+
+	numKernels int
+	numInsts   uint64
+	clusters   map[string]*kernelCluster
+	detailed   map[*protocol.LaunchKernelReq]*kernelCluster
+	startTimes map[string]sim.VTimeInSec
+	running    map[string]*kernelCluster
+}
+
+func newKernelSampler(
+	timeTeller sim.TimeTeller,
+	fastForwardKernels int,
+	fastForwardInsts uint64,
+	samplesPerCluster int,
+) *kernelSampler {
+	return &kernelSampler{
+		timeTeller:         timeTeller,
+		fastForwardKernels: fastForwardKernels,
+		fastForwardInsts:   fastForwardInsts,
+		samplesPerCluster:  samplesPerCluster,
+		clusters:           make(map[string]*kernelCluster),
+		detailed:           make(map[*protocol.LaunchKernelReq]*kernelCluster),
+		startTimes:         make(map[string]sim.VTimeInSec),
+		running:            make(map[string]*kernelCluster),
+	}
+}
+
+// Sample returns true if the kernel should be simulated in detail.
+func (s *kernelSampler) Sample(req *protocol.LaunchKernelReq) bool {
+	cluster := s.cluster(req)
+	s.numKernels++
+
+	if s.numKernels <= s.fastForwardKernels ||
+		s.numInsts < s.fastForwardInsts ||
+		(s.samplesPerCluster > 0 &&
+			cluster.numDetailed >= s.samplesPerCluster) {
+		cluster.numFastForwarded++
+		return false
+	}
+
+	cluster.numDetailed++
+	s.detailed[req] = cluster
+
+	return true
+}
+
+func (s *kernelSampler) cluster(
+	req *protocol.LaunchKernelReq,
+) *kernelCluster {
+	name := ""
+	if req.HsaCo != nil && req.HsaCo.Symbol != nil {
+		name = req.HsaCo.Symbol.Name
+	}
+
+	p := req.Packet
+	key := fmt.Sprintf("%s-%d-%d-%d-%d-%d-%d", name,
+		p.GridSizeX, p.GridSizeY, p.GridSizeZ,
+		p.WorkgroupSizeX, p.WorkgroupSizeY, p.WorkgroupSizeZ)
+
+	cluster, ok := s.clusters[key]
+	if !ok {
+		cluster = &kernelCluster{}
+		s.clusters[key] = cluster
+	}
+
+	return cluster
+}
+
+// Func counts the instructions executed by the functional compute units.
+func (s *kernelSampler) Func(ctx sim.HookCtx) {
+	if _, ok := ctx.Item.(*emu.Wavefront); !ok {
+		return
+	}
+
+	if _, ok := ctx.Detail.(*insts.Inst); !ok {
+		return
+	}
+
+	s.numInsts++
+}
+
+// StartTask records the start time of the kernels simulated in detail.
+func (s *kernelSampler) StartTask(task tracing.Task) {
+	req, ok := task.Detail.(*protocol.LaunchKernelReq)
+	if !ok {
+		return
+	}
+
+	cluster, ok := s.detailed[req]
+	if !ok {
+		return
+	}
+
+	delete(s.detailed, req)
+	s.startTimes[task.ID] = s.timeTeller.CurrentTime()
+	s.running[task.ID] = cluster
+}
+
+// StepTask does nothing.
+func (s *kernelSampler) StepTask(_ tracing.Task) {
+	// Do nothing
+}
+
+// EndTask accumulates the time of the kernels simulated in detail.
+func (s *kernelSampler) EndTask(task tracing.Task) {
+	cluster, ok := s.running[task.ID]
+	if !ok {
+		return
+	}
+
+	cluster.detailedKernelTime +=
+		s.timeTeller.CurrentTime() - s.startTimes[task.ID]
+
+	delete(s.running, task.ID)
+	delete(s.startTimes, task.ID)
+}
+
+// numDetailedKernels returns the number of kernels simulated in detail.
+func (s *kernelSampler) numDetailedKernels() (n int) {
+	for _, c := range s.clusters {
+		n += c.numDetailed
+	}
+
+	return n
+}
+
+// numFastForwardedKernels returns the number of fast-forwarded kernels.
+func (s *kernelSampler) numFastForwardedKernels() (n int) {
+	for _, c := range s.clusters {
+		n += c.numFastForwarded
+	}
+
+	return n
+}
+
+// detailedKernelTime returns the total time of the kernels simulated in
+// detail.
+func (s *kernelSampler) detailedKernelTime() (t sim.VTimeInSec) {
+	for _, c := range s.clusters {
+		t += c.detailedKernelTime
+	}
+
+	return t
+}
+
+// estimatedKernelTime returns the estimated total time of all the kernels and
+// the number of fast-forwarded kernels whose time cannot be estimated because
+// no kernel of the same cluster is simulated in detail.
+func (s *kernelSampler) estimatedKernelTime() (
+	t sim.VTimeInSec,
+	numUnestimated int,
+) {
+	for _, c := range s.clusters {
+		t += c.detailedKernelTime
+
+		if c.numDetailed == 0 {
+			numUnestimated += c.numFastForwarded
+			continue
+		}
+
+		t += c.detailedKernelTime /
+			sim.VTimeInSec(c.numDetailed) *
+			sim.VTimeInSec(c.numFastForwarded)
+	}
+
+	return t, numUnestimated
+}
@@ -7,6 +7,14 @@ var maxInstCount = flag.Uint64("max-inst", 0,
 	"Terminate the simulation after the given number of instructions is retired.")
 var parallelFlag = flag.Bool("parallel", false,
 	"Run the simulation in parallel.")
+var deterministicFlag = flag.Bool("deterministic", false,
+	"Make the parallel simulation deterministic, so that the results do not "+
+		"change across runs and thread counts. Without -parallel, the serial "+
+		"engine handles the events in the same order as the deterministic "+
+		"and the conservative modes.")
+var conservativeFlag = flag.Bool("conservative", false,
+	"Run the simulation in parallel with a conservative engine, which "+
+		"simulates each GPU in a separate partition. Only works with -timing.")
 var isaDebug = flag.Bool("debug-isa", false, "Generate the ISA debugging file.")
 
 var verifyFlag = flag.Bool("verify", false, "Verify the emulation result.")
@@ -53,11 +61,77 @@ var analyszerNameFlag = flag.String("analyzer-name", "",
 var analyszerPeriodFlag = flag.Float64("analyzer-period", 0.0,
 	"The period to dump the analyzer results.")
 
+var l1vPrefetcherFlag = flag.String("l1v-prefetcher", "none",
+	"The prefetcher of the L1 vector caches. Possible values are none, "+
+		"next-line, stride, and stream-buffer.")
+var l2PrefetcherFlag = flag.String("l2-prefetcher", "none",
+	"The prefetcher of the L2 caches. Possible values are none, "+
+		"next-line, stride, and stream-buffer.")
+
+var log2L2SectorSizeFlag = flag.Uint64("log2-l2-sector-size", 0,
+	"The sector size of the L2 caches as a power of 2. By default, the L2 "+
+		"caches are not sectored.")
+
+var coherentL2Flag = flag.Bool("coherent-l2", false,
+	"Keep the L2 caches of all the GPUs coherent with a directory-based "+
+		"protocol. The L2 caches cache remote data and the driver does not "+
+		"flush the caches before memory copies. Only works with -timing.")
+
+var fastForwardKernelsFlag = flag.Int("fast-forward-kernels", 0,
+	"Fast-forward the given number of kernels with functional emulation "+
+		"before starting the detailed timing simulation. Only works with "+
+		"-timing.")
+var fastForwardInstsFlag = flag.Uint64("fast-forward-insts", 0,
+	"Fast-forward the kernels with functional emulation until the given "+
+		"number of instructions is executed on each GPU. Only works with "+
+		"-timing.")
+var sampleKernelsFlag = flag.Int("sample-kernels", 0,
+	"Only simulate the first given number of kernels of each kernel "+
+		"cluster in detail and fast-forward the others. A cluster includes "+
+		"the kernels that run the same code with the same grid and "+
+		"work-group sizes. By default, all the kernels are simulated in "+
+		"detail. Only works with -timing.")
+
+var checkpointAfterKernelFlag = flag.Int("checkpoint-after-kernel", 0,
+	"Save a checkpoint when the command queue is drained after the given "+
+		"number of kernels complete, restore the simulation from the "+
+		"checkpoint, and continue. The results should be the same as the "+
+		"results without the checkpoint. By default, no checkpoint is "+
+		"saved. Only works with -timing.")
+var checkpointFileFlag = flag.String("checkpoint-file", "",
+	"The file to write the checkpoint saved with -checkpoint-after-kernel "+
+		"to. By default, the checkpoint is only kept in memory.")
+var restoreCheckpointFlag = flag.String("restore-checkpoint", "",
+	"Restore the simulation from the checkpoint file written with "+
+		"-checkpoint-file when the command queue is drained after the "+
+		"kernels given by -checkpoint-after-kernel complete. The kernels "+
+		"before the checkpoint are fast-forwarded. The other flags must be "+
+		"the same as when the checkpoint is saved.")
+
+var timeLimitFlag = flag.Float64("time-limit", 0,
+	"Terminate the simulation with a report of the outstanding requests "+
+		"when the simulated time exceeds the given number of seconds. "+
+		"By default, there is no limit.")
+var watchdogTimeoutFlag = flag.Float64("watchdog-timeout", 0,
+	"Terminate the simulation with a report of the outstanding requests "+
+		"when no event is handled for the given number of wall-clock "+
+		"seconds while requests are outstanding. By default, the watchdog "+
+		"is disabled.")
+
+var metricsPeriodFlag = flag.Float64("metrics-period", 0,
+	"Record the values of the registered metrics every given number of "+
+		"simulated seconds. The time series is written to "+
+		"<metric-file-name>_series. By default, no time series is recorded.")
+var metricsFormatFlag = flag.String("metrics-format", "csv",
+	"The format of the metric time series. Possible values are csv and "+
+		"sqlite.")
+
 var visTracing = flag.Bool("trace-vis", false,
 	"Generate trace for visualization purposes.")
 var visTracerDB = flag.String("trace-vis-db", "sqlite",
 	"The database to store the visualization trace. Possible values are "+
-		"sqlite, mysql, and csv.")
+		"sqlite, mysql, csv, and chrome. The chrome format can be opened "+
+		"with Perfetto or chrome://tracing.")
 var visTracerDBFileName = flag.String("trace-vis-db-file", "",
 	"The file name of the database to store the visualization trace. "+
 		"Extension names are not required. "+
@@ -79,6 +153,14 @@ func (r *Runner) ParseFlag() *Runner {
 		r.Parallel = true
 	}
 
+	if *deterministicFlag {
+		r.Deterministic = true
+	}
+
+	if *conservativeFlag {
+		r.Conservative = true
+	}
+
 	if *verifyFlag {
 		r.Verify = true
 	}
@@ -123,6 +205,12 @@ func (r *Runner) ParseFlag() *Runner {
 		r.ReportCPIStack = true
 	}
 
+	r.FastForwardKernels = *fastForwardKernelsFlag
+	r.FastForwardInsts = *fastForwardInstsFlag
+	r.SampleKernels = *sampleKernelsFlag
+	r.CheckpointAfterKernel = *checkpointAfterKernelFlag
+	r.RestoreCheckpoint = *restoreCheckpointFlag
+
 	if *reportAll {
 		r.ReportInstCount = true
 		r.ReportCacheLatency = true
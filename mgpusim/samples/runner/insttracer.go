@@ -7,22 +7,12 @@ import (
 
 // instTracer can trace the number of instruction completed.
 type instTracer struct {
-	count     uint64
-	simdInst  bool
-	simdCount uint64
-	maxCount  uint64
+	count    uint64
+	maxCount uint64
 
 	inflightInst map[string]tracing.Task
 }
 
-// newInstTracer creates a tracer that can count the number of instructions.
-func newInstTracer() *instTracer {
-	t := &instTracer{
-		inflightInst: map[string]tracing.Task{},
-	}
-	return t
-}
-
 // newInstStopper with stop the execution after a given number of instructions
 // is retired.
 func newInstStopper(maxInst uint64) *instTracer {
@@ -38,12 +28,6 @@ func (t *instTracer) StartTask(task tracing.Task) {
 		return
 	}
 
-	if task.What == "VALU" {
-		t.simdInst = true
-	} else {
-		t.simdInst = false
-	}
-
 	t.inflightInst[task.ID] = task
 }
 
@@ -57,10 +41,6 @@ func (t *instTracer) EndTask(task tracing.Task) {
 		return
 	}
 
-	if t.simdInst {
-		t.simdCount++
-	}
-
 	delete(t.inflightInst, task.ID)
 
 	t.count++
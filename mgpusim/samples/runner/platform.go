@@ -1,6 +1,8 @@
 package runner
 
 import (
+	"github.com/sarchlab/akita/v3/mem/mem"
+	"github.com/sarchlab/akita/v3/metrics"
 	"github.com/sarchlab/akita/v3/sim"
 	"github.com/sarchlab/akita/v3/tracing"
 	"github.com/sarchlab/mgpusim/v3/driver"
@@ -20,10 +22,23 @@ type Platform struct {
 	Engine sim.Engine
 	Driver *driver.Driver
 	GPUs   []*GPU
+
+	// GlobalStorage holds the data of the memory of the whole platform. It is
+	// nil if the platform does not use a global storage.
+	GlobalStorage *mem.Storage
+
+	// Metrics is the registry that the components of the platform register
+	// their metrics in.
+	Metrics *metrics.Registry
 }
 
 // A GPU is a collection of GPU internal Components
 type GPU struct {
+	// Engine is the engine that simulates the components of the GPU. It is a
+	// partition of the engine of the platform if the platform uses a
+	// conservative engine.
+	Engine sim.Engine
+
 	Domain           *sim.Domain
 	CommandProcessor *cp.CommandProcessor
 	RDMAEngine       *rdma.Comp
@@ -39,4 +54,16 @@ type GPU struct {
 	L1ITLBs          []TraceableComponent
 	L2TLBs           []TraceableComponent
 	MemControllers   []TraceableComponent
+
+	// FastForwardCUs are the functional compute units that run the
+	// fast-forwarded kernels. It is empty if fast-forwarding is disabled.
+	FastForwardCUs []TraceableComponent
+
+	// Directories are the coherence directories in front of the memory
+	// controllers. It is empty if the L2 caches are not coherent.
+	Directories []TraceableComponent
+
+	// DirectoryFinder finds the directory that is the home of an address in
+	// the memory of the GPU. It is nil if the L2 caches are not coherent.
+	DirectoryFinder mem.LowModuleFinder
 }
@@ -6,17 +6,23 @@ import (
 	rob2 "github.com/sarchlab/mgpusim/v3/timing/rob"
 
 	"github.com/sarchlab/akita/v3/analysis"
+	"github.com/sarchlab/akita/v3/mem/cache"
+	"github.com/sarchlab/akita/v3/mem/cache/coherence"
 	"github.com/sarchlab/akita/v3/mem/cache/writearound"
 	"github.com/sarchlab/akita/v3/mem/cache/writeback"
 	"github.com/sarchlab/akita/v3/mem/cache/writethrough"
 	"github.com/sarchlab/akita/v3/mem/dram"
 	"github.com/sarchlab/akita/v3/mem/mem"
+	"github.com/sarchlab/akita/v3/mem/vm"
 	"github.com/sarchlab/akita/v3/mem/vm/addresstranslator"
 	"github.com/sarchlab/akita/v3/mem/vm/mmu"
 	"github.com/sarchlab/akita/v3/mem/vm/tlb"
+	"github.com/sarchlab/akita/v3/metrics"
 	"github.com/sarchlab/akita/v3/monitoring"
 	"github.com/sarchlab/akita/v3/sim"
 	"github.com/sarchlab/akita/v3/tracing"
+	"github.com/sarchlab/mgpusim/v3/emu"
+	"github.com/sarchlab/mgpusim/v3/insts"
 	"github.com/sarchlab/mgpusim/v3/timing/cp"
 	"github.com/sarchlab/mgpusim/v3/timing/cu"
 	"github.com/sarchlab/mgpusim/v3/timing/pagemigrationcontroller"
@@ -37,6 +43,12 @@ type R9NanoGPUBuilder struct {
 	log2PageSize                   uint64
 	log2CacheLineSize              uint64
 	log2MemoryBankInterleavingSize uint64
+	l1vPrefetcher                  cache.PrefetcherType
+	l2Prefetcher                   cache.PrefetcherType
+	log2L2SectorSize               uint64
+	fastForwardPageTable           vm.PageTable
+	coherenceNetwork               *sim.DirectConnection
+	homeDirectoryFinder            mem.LowModuleFinder
 
 	enableISADebugging bool
 	enableMemTracing   bool
@@ -45,6 +57,7 @@ type R9NanoGPUBuilder struct {
 	memTracer          tracing.Tracer
 	monitor            *monitoring.Monitor
 	perfAnalyzer       *analysis.PerfAnalyzer
+	metrics            *metrics.Registry
 
 	gpuName                 string
 	gpu                     *GPU
@@ -66,6 +79,8 @@ type R9NanoGPUBuilder struct {
 	l1iTLBs                 []*tlb.TLB
 	l2TLBs                  []*tlb.TLB
 	drams                   []*dram.MemController
+	directories             []*coherence.Directory
+	directoryFinder         *mem.InterleavedLowModuleFinder
 	lowModuleFinderForL1    *mem.InterleavedLowModuleFinder
 	lowModuleFinderForL2    *mem.InterleavedLowModuleFinder
 	lowModuleFinderForPMC   *mem.InterleavedLowModuleFinder
@@ -73,6 +88,7 @@ type R9NanoGPUBuilder struct {
 	rdmaEngine              *rdma.Comp
 	pageMigrationController *pagemigrationcontroller.PageMigrationController
 	globalStorage           *mem.Storage
+	fastForwardCUs          []*emu.ComputeUnit
 
 	internalConn           *sim.DirectConnection
 	l1TLBToL2TLBConnection *sim.DirectConnection
@@ -92,6 +108,7 @@ func MakeR9NanoGPUBuilder() R9NanoGPUBuilder {
 		log2MemoryBankInterleavingSize: 12,
 		l2CacheSize:                    2 * mem.MB,
 		dramSize:                       4 * mem.GB,
+		metrics:                        metrics.DefaultRegistry(),
 	}
 	return b
 }
@@ -203,6 +220,38 @@ func (b R9NanoGPUBuilder) WithPerfAnalyzer(
 	return b
 }
 
+// WithMetricsRegistry sets the registry that the metrics of the GPU
+// components are registered in. By default, the default registry is used.
+func (b R9NanoGPUBuilder) WithMetricsRegistry(
+	registry *metrics.Registry,
+) R9NanoGPUBuilder {
+	b.metrics = registry
+	return b
+}
+
+// WithL1VPrefetcher sets the prefetcher of the L1 vector caches.
+func (b R9NanoGPUBuilder) WithL1VPrefetcher(
+	prefetcher cache.PrefetcherType,
+) R9NanoGPUBuilder {
+	b.l1vPrefetcher = prefetcher
+	return b
+}
+
+// WithL2Prefetcher sets the prefetcher of the L2 caches.
+func (b R9NanoGPUBuilder) WithL2Prefetcher(
+	prefetcher cache.PrefetcherType,
+) R9NanoGPUBuilder {
+	b.l2Prefetcher = prefetcher
+	return b
+}
+
+// WithLog2L2SectorSize sets the sector size of the L2 caches as a power of 2.
+// By default, the L2 caches are not sectored.
+func (b R9NanoGPUBuilder) WithLog2L2SectorSize(n uint64) R9NanoGPUBuilder {
+	b.log2L2SectorSize = n
+	return b
+}
+
 // WithL2CacheSize set the total L2 cache size. The size of the L2 cache is
 // split between memory banks.
 func (b R9NanoGPUBuilder) WithL2CacheSize(size uint64) R9NanoGPUBuilder {
@@ -225,20 +274,52 @@ func (b R9NanoGPUBuilder) WithGlobalStorage(
 	return b
 }
 
+// WithFastForward equips the GPU with a functional compute unit for each
+// compute unit so that the GPU can fast-forward kernels. The functional
+// compute units translate addresses with the given page table and access the
+// global storage directly.
+func (b R9NanoGPUBuilder) WithFastForward(
+	pageTable vm.PageTable,
+) R9NanoGPUBuilder {
+	b.fastForwardPageTable = pageTable
+	return b
+}
+
+// WithCoherentL2 keeps the L2 caches coherent with the L2 caches of the other
+// GPUs that are connected to the same network. The L1 caches send all the
+// requests to the local L2 caches, which cache both local and remote data. A
+// coherence directory in front of each memory controller tracks the L2 caches
+// that hold a copy of the data in the memory controller. The L2 caches, the
+// directories, the DMA engine, and the page migration controller connect to
+// the given network. The home directory finder finds the directory of any
+// address in the unified memory.
+func (b R9NanoGPUBuilder) WithCoherentL2(
+	network *sim.DirectConnection,
+	homeDirectoryFinder mem.LowModuleFinder,
+) R9NanoGPUBuilder {
+	b.coherenceNetwork = network
+	b.homeDirectoryFinder = homeDirectoryFinder
+	return b
+}
+
 // Build creates a pre-configure GPU similar to the AMD R9 Nano GPU.
 func (b R9NanoGPUBuilder) Build(name string, id uint64) *GPU {
 	b.createGPU(name, id)
 	b.buildSAs()
 	b.buildL2Caches()
 	b.buildDRAMControllers()
+	b.buildDirectories()
 	b.buildCP()
 	b.buildL2TLB()
 
 	b.connectCP()
 	b.connectL2AndDRAM()
+	b.connectL2AndDirectories()
 	b.connectL1ToL2()
 	b.connectL1TLBToL2TLB()
 
+	b.buildFastForwardCUs()
+
 	b.populateExternalPorts()
 
 	return b.gpu
@@ -259,7 +340,7 @@ func (b *R9NanoGPUBuilder) populateExternalPorts() {
 func (b *R9NanoGPUBuilder) createGPU(name string, id uint64) {
 	b.gpuName = name
 
-	b.gpu = &GPU{}
+	b.gpu = &GPU{Engine: b.engine}
 	b.gpu.Domain = sim.NewDomain(b.gpuName)
 	b.gpuID = id
 }
@@ -297,7 +378,7 @@ func (b *R9NanoGPUBuilder) connectL1ToL2() {
 	lowModuleFinder := mem.NewInterleavedLowModuleFinder(
 		1 << b.log2MemoryBankInterleavingSize)
 	lowModuleFinder.ModuleForOtherAddresses = b.rdmaEngine.ToL1
-	lowModuleFinder.UseAddressSpaceLimitation = true
+	lowModuleFinder.UseAddressSpaceLimitation = !b.isCoherent()
 	lowModuleFinder.LowAddress = b.memAddrOffset
 	lowModuleFinder.HighAddress = b.memAddrOffset + 4*mem.GB
 
@@ -334,6 +415,11 @@ func (b *R9NanoGPUBuilder) connectL2AndDRAM() {
 	b.l2ToDramConnection = sim.NewDirectConnection(
 		b.gpuName+".L2ToDRAM", b.engine, b.freq)
 
+	if b.isCoherent() {
+		b.connectDirectoriesAndDRAM()
+		return
+	}
+
 	lowModuleFinder := mem.NewInterleavedLowModuleFinder(
 		1 << b.log2MemoryBankInterleavingSize)
 
@@ -358,6 +444,45 @@ func (b *R9NanoGPUBuilder) connectL2AndDRAM() {
 		b.pageMigrationController.GetPortByName("LocalMem"), 16)
 }
 
+func (b *R9NanoGPUBuilder) connectDirectoriesAndDRAM() {
+	for i, d := range b.directories {
+		b.l2ToDramConnection.PlugIn(d.GetPortByName("Bottom"), 64)
+		d.SetLowModuleFinder(&mem.SingleLowModuleFinder{
+			LowModule: b.drams[i].GetPortByName("Top"),
+		})
+	}
+
+	for _, dram := range b.drams {
+		b.l2ToDramConnection.PlugIn(dram.GetPortByName("Top"), 64)
+	}
+}
+
+// connectL2AndDirectories connects the L2 caches with the directories of all
+// the GPUs. The DMA engine and the page migration controller also access the
+// memory through the directories so that the copies of the data in the L2
+// caches are kept up to date.
+func (b *R9NanoGPUBuilder) connectL2AndDirectories() {
+	if !b.isCoherent() {
+		return
+	}
+
+	for _, l2 := range b.l2Caches {
+		b.coherenceNetwork.PlugIn(l2.GetPortByName("Bottom"), 64)
+		l2.SetLowModuleFinder(b.homeDirectoryFinder)
+	}
+
+	for _, d := range b.directories {
+		b.coherenceNetwork.PlugIn(d.GetPortByName("Top"), 64)
+	}
+
+	b.dmaEngine.SetLocalDataSource(b.directoryFinder)
+	b.coherenceNetwork.PlugIn(b.dmaEngine.ToMem, 64)
+
+	b.pageMigrationController.MemCtrlFinder = b.directoryFinder
+	b.coherenceNetwork.PlugIn(
+		b.pageMigrationController.GetPortByName("LocalMem"), 16)
+}
+
 func (b *R9NanoGPUBuilder) connectL1TLBToL2TLB() {
 	tlbConn := sim.NewDirectConnection(b.gpuName+".L1TLBToL2TLB",
 		b.engine, b.freq)
@@ -488,7 +613,9 @@ func (b *R9NanoGPUBuilder) buildSAs() {
 		withGPUID(b.gpuID).
 		withLog2CachelineSize(b.log2CacheLineSize).
 		withLog2PageSize(b.log2PageSize).
-		withNumCU(b.numCUPerShaderArray)
+		withNumCU(b.numCUPerShaderArray).
+		withL1VPrefetcher(b.l1vPrefetcher).
+		withMetricsRegistry(b.metrics)
 
 	if b.enableISADebugging {
 		saBuilder = saBuilder.withIsaDebugging()
@@ -513,11 +640,18 @@ func (b *R9NanoGPUBuilder) buildL2Caches() {
 	l2Builder := writeback.MakeBuilder().
 		WithEngine(b.engine).
 		WithFreq(b.freq).
+		WithMetricsRegistry(b.metrics).
 		WithLog2BlockSize(b.log2CacheLineSize).
 		WithWayAssociativity(16).
 		WithByteSize(byteSize).
 		WithNumMSHREntry(64).
-		WithNumReqPerCycle(16)
+		WithNumReqPerCycle(16).
+		WithPrefetcher(b.l2Prefetcher).
+		WithLog2SectorSize(b.log2L2SectorSize)
+
+	if b.isCoherent() {
+		l2Builder = l2Builder.WithCoherence()
+	}
 
 	for i := 0; i < b.numMemoryBank; i++ {
 		cacheName := fmt.Sprintf("%s.L2[%d]", b.gpuName, i)
@@ -566,6 +700,43 @@ func (b *R9NanoGPUBuilder) buildDRAMControllers() {
 	}
 }
 
+func (b *R9NanoGPUBuilder) buildDirectories() {
+	if !b.isCoherent() {
+		return
+	}
+
+	directoryBuilder := coherence.MakeBuilder().
+		WithEngine(b.engine).
+		WithFreq(b.freq).
+		WithLog2BlockSize(b.log2CacheLineSize).
+		WithNumReqPerCycle(16)
+
+	b.directoryFinder = mem.NewInterleavedLowModuleFinder(
+		1 << b.log2MemoryBankInterleavingSize)
+	b.gpu.DirectoryFinder = b.directoryFinder
+
+	for i := 0; i < b.numMemoryBank; i++ {
+		name := fmt.Sprintf("%s.Directory[%d]", b.gpuName, i)
+		d := directoryBuilder.Build(name)
+		b.directories = append(b.directories, d)
+		b.gpu.Directories = append(b.gpu.Directories, d)
+		b.directoryFinder.LowModules = append(b.directoryFinder.LowModules,
+			d.GetPortByName("Top"))
+
+		if b.enableVisTracing {
+			tracing.CollectTrace(d, b.visTracer)
+		}
+
+		if b.monitor != nil {
+			b.monitor.RegisterComponent(d)
+		}
+	}
+}
+
+func (b *R9NanoGPUBuilder) isCoherent() bool {
+	return b.coherenceNetwork != nil
+}
+
 func (b *R9NanoGPUBuilder) createDramControllerBuilder() dram.Builder {
 	memBankSize := 4 * mem.GB / uint64(b.numMemoryBank)
 	if 4*mem.GB%uint64(b.numMemoryBank) != 0 {
@@ -586,6 +757,7 @@ func (b *R9NanoGPUBuilder) createDramControllerBuilder() dram.Builder {
 	memCtrlBuilder := dram.MakeBuilder().
 		WithEngine(b.engine).
 		WithFreq(500 * sim.MHz).
+		WithMetricsRegistry(b.metrics).
 		WithProtocol(dram.HBM).
 		WithBurstLength(4).
 		WithDeviceWidth(dramDeviceWidth).
@@ -749,6 +921,7 @@ func (b *R9NanoGPUBuilder) buildRDMAEngine() {
 	b.rdmaEngine = rdma.MakeBuilder().
 		WithEngine(b.engine).
 		WithFreq(1 * sim.GHz).
+		WithMetricsRegistry(b.metrics).
 		WithLocalModules(b.lowModuleFinderForL1).
 		Build(name)
 	b.gpu.RDMAEngine = b.rdmaEngine
@@ -802,6 +975,10 @@ func (b *R9NanoGPUBuilder) buildCP() {
 		builder = builder.WithVisTracer(b.visTracer)
 	}
 
+	if b.fastForwardPageTable != nil {
+		builder = builder.WithFastForward()
+	}
+
 	b.cp = builder.Build(b.gpuName + ".CommandProcessor")
 	b.gpu.CommandProcessor = b.cp
 
@@ -819,6 +996,7 @@ func (b *R9NanoGPUBuilder) buildL2TLB() {
 	builder := tlb.MakeBuilder().
 		WithEngine(b.engine).
 		WithFreq(b.freq).
+		WithMetricsRegistry(b.metrics).
 		WithNumWays(numWays).
 		WithNumSets(int(b.dramSize / (1 << b.log2PageSize) / uint64(numWays))).
 		WithNumMSHREntry(64).
@@ -839,6 +1017,64 @@ func (b *R9NanoGPUBuilder) buildL2TLB() {
 	}
 }
 
+func (b *R9NanoGPUBuilder) buildFastForwardCUs() {
+	if b.fastForwardPageTable == nil {
+		return
+	}
+
+	if b.globalStorage == nil {
+		panic("fast-forwarding requires the global storage")
+	}
+
+	disassembler := insts.NewDisassembler()
+	for i := 0; i < b.numCU(); i++ {
+		computeUnit := emu.BuildComputeUnit(
+			fmt.Sprintf("%s.FastForwardCU[%d]", b.gpuName, i),
+			b.engine, disassembler, b.fastForwardPageTable,
+			b.log2PageSize, b.globalStorage, nil)
+		computeUnit.UseTimingClock(b.freq)
+
+		b.cp.RegisterFastForwardCU(computeUnit)
+		b.internalConn.PlugIn(computeUnit.ToDispatcher, 4)
+
+		b.fastForwardCUs = append(b.fastForwardCUs, computeUnit)
+		b.gpu.FastForwardCUs = append(b.gpu.FastForwardCUs, computeUnit)
+	}
+
+	warmer := b.buildFunctionalWarmer()
+	b.cp.Warmer = warmer
+	for _, computeUnit := range b.fastForwardCUs {
+		computeUnit.AcceptHook(warmer)
+	}
+}
+
+func (b *R9NanoGPUBuilder) buildFunctionalWarmer() *functionalWarmer {
+	w := &functionalWarmer{
+		storage:           b.globalStorage,
+		log2CacheLineSize: b.log2CacheLineSize,
+		memLow:            b.memAddrOffset,
+		memHigh:           b.memAddrOffset + b.dramSize,
+		targets:           make(map[sim.Hookable]cuWarmUpTargets),
+		l1vCaches:         b.l1vCaches,
+		l1sCaches:         b.l1sCaches,
+		l2Caches:          b.l2Caches,
+		l2TLBs:            b.l2TLBs,
+	}
+
+	for i, computeUnit := range b.fastForwardCUs {
+		saID := i / b.numCUPerShaderArray
+		w.targets[computeUnit] = cuWarmUpTargets{
+			l1vTLB:   b.l1vTLBs[i],
+			l1vCache: b.l1vCaches[i],
+			l1sTLB:   b.l1sTLBs[saID],
+			l1sCache: b.l1sCaches[saID],
+			l1iTLB:   b.l1iTLBs[saID],
+		}
+	}
+
+	return w
+}
+
 func (b *R9NanoGPUBuilder) numCU() int {
 	return b.numCUPerShaderArray * b.numShaderArray
 }
@@ -2,51 +2,14 @@ package runner
 
 import (
 	"sort"
-	"strings"
 
-	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/akita/v3/mem/cache"
+	"github.com/sarchlab/akita/v3/metrics"
 	"github.com/sarchlab/akita/v3/tracing"
 	"github.com/sarchlab/mgpusim/v3/timing/cu"
-	"github.com/sarchlab/mgpusim/v3/timing/rdma"
 	"github.com/tebeka/atexit"
 )
 
-type instCountTracer struct {
-	tracer *instTracer
-	cu     TraceableComponent
-}
-
-type cacheLatencyTracer struct {
-	tracer *tracing.AverageTimeTracer
-	cache  TraceableComponent
-}
-
-type cacheHitRateTracer struct {
-	tracer *tracing.StepCountTracer
-	cache  TraceableComponent
-}
-
-type tlbHitRateTracer struct {
-	tracer *tracing.StepCountTracer
-	tlb    TraceableComponent
-}
-
-type dramTransactionCountTracer struct {
-	tracer *dramTracer
-	dram   TraceableComponent
-}
-
-type rdmaTransactionCountTracer struct {
-	outgoingTracer *tracing.AverageTimeTracer
-	incomingTracer *tracing.AverageTimeTracer
-	rdmaEngine     *rdma.Comp
-}
-
-type simdBusyTimeTracer struct {
-	tracer *tracing.BusyTimeTracer
-	simd   TraceableComponent
-}
-
 type cuCPIStackTracer struct {
 	cu     TraceableComponent
 	tracer *cu.CPIStackTracer
@@ -56,29 +19,27 @@ func (r *Runner) defineMetrics() {
 	r.metricsCollector = &collector{}
 	r.addMaxInstStopper()
 	r.addKernelTimeTracer()
-	r.addInstCountTracer()
+	r.addKernelSamplers()
 	r.addCUCPIHook()
-	r.addCacheLatencyTracer()
-	r.addCacheHitRateTracer()
-	r.addTLBHitRateTracer()
-	r.addRDMAEngineTracer()
-	r.addDRAMTracer()
-	r.addSIMDBusyTimeTracer()
 
 	atexit.Register(func() { r.reportStats() })
 }
 
 func (r *Runner) addKernelTimeTracer() {
+	// The driver tells the time with its own engine, which is ahead of the
+	// platform engine when the platform runs with a conservative engine.
+	driverEngine := r.platform.Driver.Engine
+
 	if *unifiedGPUFlag != "" {
 		r.kernelTimeCounter = tracing.NewBusyTimeTracer(
-			r.platform.Engine,
+			driverEngine,
 			func(task tracing.Task) bool {
 				return task.What == "*driver.LaunchUnifiedMultiGPUKernelCommand"
 			})
 		tracing.CollectTrace(r.platform.Driver, r.kernelTimeCounter)
 	} else {
 		r.kernelTimeCounter = tracing.NewBusyTimeTracer(
-			r.platform.Engine,
+			driverEngine,
 			func(task tracing.Task) bool {
 				return task.What == "*driver.LaunchKernelCommand"
 			})
@@ -87,7 +48,7 @@ func (r *Runner) addKernelTimeTracer() {
 
 	for _, gpu := range r.platform.GPUs {
 		gpuKernelTimeCounter := tracing.NewBusyTimeTracer(
-			r.platform.Engine,
+			gpu.Engine,
 			func(task tracing.Task) bool {
 				return task.What == "*protocol.LaunchKernelReq"
 			})
@@ -97,20 +58,21 @@ func (r *Runner) addKernelTimeTracer() {
 	}
 }
 
-func (r *Runner) addInstCountTracer() {
-	if !r.ReportInstCount {
+func (r *Runner) addKernelSamplers() {
+	if !r.Timing || !r.isSampling() {
 		return
 	}
 
 	for _, gpu := range r.platform.GPUs {
-		for _, cu := range gpu.CUs {
-			tracer := newInstTracer()
-			r.instCountTracers = append(r.instCountTracers,
-				instCountTracer{
-					tracer: tracer,
-					cu:     cu,
-				})
-			tracing.CollectTrace(cu.(tracing.NamedHookable), tracer)
+		sampler := newKernelSampler(gpu.Engine,
+			r.FastForwardKernels, r.FastForwardInsts, r.SampleKernels)
+		r.kernelSamplers = append(r.kernelSamplers, sampler)
+
+		gpu.CommandProcessor.KernelSampler = sampler
+		tracing.CollectTrace(gpu.CommandProcessor, sampler)
+
+		for _, cu := range gpu.FastForwardCUs {
+			cu.AcceptHook(sampler)
 		}
 	}
 }
@@ -123,7 +85,7 @@ func (r *Runner) addCUCPIHook() {
 	for _, gpu := range r.platform.GPUs {
 		for _, cuComp := range gpu.CUs {
 			tracer := cu.NewCPIStackInstHook(
-				cuComp.(*cu.ComputeUnit), r.platform.Engine)
+				cuComp.(*cu.ComputeUnit), gpu.Engine)
 			tracing.CollectTrace(cuComp.(tracing.NamedHookable), tracer)
 
 			r.cuCPITraces = append(r.cuCPITraces,
@@ -135,230 +97,9 @@ func (r *Runner) addCUCPIHook() {
 	}
 }
 
-func (r *Runner) addCacheLatencyTracer() {
-	if !r.ReportCacheLatency {
-		return
-	}
-
-	for _, gpu := range r.platform.GPUs {
-		for _, cache := range gpu.L1ICaches {
-			tracer := tracing.NewAverageTimeTracer(
-				r.platform.Engine,
-				func(task tracing.Task) bool {
-					return task.Kind == "req_in"
-				})
-			r.cacheLatencyTracers = append(r.cacheLatencyTracers,
-				cacheLatencyTracer{tracer: tracer, cache: cache})
-			tracing.CollectTrace(cache, tracer)
-		}
-
-		for _, cache := range gpu.L1SCaches {
-			tracer := tracing.NewAverageTimeTracer(
-				r.platform.Engine,
-				func(task tracing.Task) bool {
-					return task.Kind == "req_in"
-				})
-			r.cacheLatencyTracers = append(r.cacheLatencyTracers,
-				cacheLatencyTracer{tracer: tracer, cache: cache})
-			tracing.CollectTrace(cache, tracer)
-		}
-
-		for _, cache := range gpu.L1VCaches {
-			tracer := tracing.NewAverageTimeTracer(
-				r.platform.Engine,
-				func(task tracing.Task) bool {
-					return task.Kind == "req_in"
-				})
-			r.cacheLatencyTracers = append(r.cacheLatencyTracers,
-				cacheLatencyTracer{tracer: tracer, cache: cache})
-			tracing.CollectTrace(cache, tracer)
-		}
-
-		for _, cache := range gpu.L2Caches {
-			tracer := tracing.NewAverageTimeTracer(
-				r.platform.Engine,
-				func(task tracing.Task) bool {
-					return task.Kind == "req_in"
-				})
-			r.cacheLatencyTracers = append(r.cacheLatencyTracers,
-				cacheLatencyTracer{tracer: tracer, cache: cache})
-			tracing.CollectTrace(cache, tracer)
-		}
-	}
-}
-
-func (r *Runner) addCacheHitRateTracer() {
-	if !r.ReportCacheHitRate {
-		return
-	}
-
-	for _, gpu := range r.platform.GPUs {
-		for _, cache := range gpu.L1VCaches {
-			tracer := tracing.NewStepCountTracer(
-				func(task tracing.Task) bool { return true })
-			r.cacheHitRateTracers = append(r.cacheHitRateTracers,
-				cacheHitRateTracer{tracer: tracer, cache: cache})
-			tracing.CollectTrace(cache, tracer)
-		}
-
-		for _, cache := range gpu.L1SCaches {
-			tracer := tracing.NewStepCountTracer(
-				func(task tracing.Task) bool { return true })
-			r.cacheHitRateTracers = append(r.cacheHitRateTracers,
-				cacheHitRateTracer{tracer: tracer, cache: cache})
-			tracing.CollectTrace(cache, tracer)
-		}
-
-		for _, cache := range gpu.L1ICaches {
-			tracer := tracing.NewStepCountTracer(
-				func(task tracing.Task) bool { return true })
-			r.cacheHitRateTracers = append(r.cacheHitRateTracers,
-				cacheHitRateTracer{tracer: tracer, cache: cache})
-			tracing.CollectTrace(cache, tracer)
-		}
-
-		for _, cache := range gpu.L2Caches {
-			tracer := tracing.NewStepCountTracer(
-				func(task tracing.Task) bool { return true })
-			r.cacheHitRateTracers = append(r.cacheHitRateTracers,
-				cacheHitRateTracer{tracer: tracer, cache: cache})
-			tracing.CollectTrace(cache, tracer)
-		}
-	}
-}
-
-func (r *Runner) addTLBHitRateTracer() {
-	if !r.ReportTLBHitRate {
-		return
-	}
-
-	for _, gpu := range r.platform.GPUs {
-		for _, tlb := range gpu.L1VTLBs {
-			tracer := tracing.NewStepCountTracer(
-				func(task tracing.Task) bool { return true })
-			r.tlbHitRateTracers = append(r.tlbHitRateTracers,
-				tlbHitRateTracer{tracer: tracer, tlb: tlb})
-			tracing.CollectTrace(tlb, tracer)
-		}
-
-		for _, tlb := range gpu.L1STLBs {
-			tracer := tracing.NewStepCountTracer(
-				func(task tracing.Task) bool { return true })
-			r.tlbHitRateTracers = append(r.tlbHitRateTracers,
-				tlbHitRateTracer{tracer: tracer, tlb: tlb})
-			tracing.CollectTrace(tlb, tracer)
-		}
-
-		for _, tlb := range gpu.L1ITLBs {
-			tracer := tracing.NewStepCountTracer(
-				func(task tracing.Task) bool { return true })
-			r.tlbHitRateTracers = append(r.tlbHitRateTracers,
-				tlbHitRateTracer{tracer: tracer, tlb: tlb})
-			tracing.CollectTrace(tlb, tracer)
-		}
-
-		for _, tlb := range gpu.L2TLBs {
-			tracer := tracing.NewStepCountTracer(
-				func(task tracing.Task) bool { return true })
-			r.tlbHitRateTracers = append(r.tlbHitRateTracers,
-				tlbHitRateTracer{tracer: tracer, tlb: tlb})
-			tracing.CollectTrace(tlb, tracer)
-		}
-	}
-}
-
-func (r *Runner) addRDMAEngineTracer() {
-	if !r.ReportRDMATransactionCount {
-		return
-	}
-
-	for _, gpu := range r.platform.GPUs {
-		if gpu.RDMAEngine == nil {
-			continue
-		}
-
-		t := rdmaTransactionCountTracer{}
-		t.rdmaEngine = gpu.RDMAEngine
-		t.incomingTracer = tracing.NewAverageTimeTracer(
-			r.platform.Engine,
-			func(task tracing.Task) bool {
-				if task.Kind != "req_in" {
-					return false
-				}
-
-				isFromOutside := strings.Contains(
-					task.Detail.(sim.Msg).Meta().Src.Name(), "RDMA")
-				if !isFromOutside {
-					return false
-				}
-
-				return true
-			})
-		t.outgoingTracer = tracing.NewAverageTimeTracer(
-			r.platform.Engine,
-			func(task tracing.Task) bool {
-				if task.Kind != "req_in" {
-					return false
-				}
-
-				isFromOutside := strings.Contains(
-					task.Detail.(sim.Msg).Meta().Src.Name(), "RDMA")
-				if isFromOutside {
-					return false
-				}
-
-				return true
-			})
-
-		tracing.CollectTrace(t.rdmaEngine, t.incomingTracer)
-		tracing.CollectTrace(t.rdmaEngine, t.outgoingTracer)
-
-		r.rdmaTransactionCounters = append(r.rdmaTransactionCounters, t)
-	}
-}
-
-func (r *Runner) addDRAMTracer() {
-	if !r.ReportDRAMTransactionCount {
-		return
-	}
-
-	for _, gpu := range r.platform.GPUs {
-		for _, dram := range gpu.MemControllers {
-			t := dramTransactionCountTracer{}
-			t.dram = dram.(TraceableComponent)
-			t.tracer = newDramTracer(r.platform.Engine)
-
-			tracing.CollectTrace(t.dram, t.tracer)
-
-			r.dramTracers = append(r.dramTracers, t)
-		}
-	}
-}
-
-func (r *Runner) addSIMDBusyTimeTracer() {
-	if !r.ReportSIMDBusyTime {
-		return
-	}
-
-	for _, gpu := range r.platform.GPUs {
-		for _, simd := range gpu.SIMDs {
-			perSIMDBusyTimeTracer := tracing.NewBusyTimeTracer(
-				r.platform.Engine,
-				func(task tracing.Task) bool {
-					return task.Kind == "pipeline"
-				})
-			r.simdBusyTimeTracers = append(r.simdBusyTimeTracers,
-				simdBusyTimeTracer{
-					tracer: perSIMDBusyTimeTracer,
-					simd:   simd,
-				})
-			tracing.CollectTrace(simd, perSIMDBusyTimeTracer)
-		}
-	}
-}
-
 func (r *Runner) reportStats() {
 	r.reportExecutionTime()
+	r.reportSampling()
 	r.reportInstCount()
 	r.reportCPIStack()
 	r.reportSIMDBusyTime()
@@ -371,22 +112,24 @@ func (r *Runner) reportStats() {
 }
 
 func (r *Runner) reportInstCount() {
-	kernelTime := float64(r.kernelTimeCounter.BusyTime())
-	for _, t := range r.instCountTracers {
-		cuFreq := float64(t.cu.(*cu.ComputeUnit).Freq)
-		numCycle := kernelTime * cuFreq
-
-		r.metricsCollector.Collect(
-			t.cu.Name(), "cu_inst_count", float64(t.tracer.count))
-
-		r.metricsCollector.Collect(
-			t.cu.Name(), "cu_CPI", numCycle/float64(t.tracer.count))
-
-		r.metricsCollector.Collect(
-			t.cu.Name(), "simd_inst_count", float64(t.tracer.simdCount))
+	if !r.ReportInstCount {
+		return
+	}
 
-		r.metricsCollector.Collect(
-			t.cu.Name(), "simd_CPI", numCycle/float64(t.tracer.simdCount))
+	kernelTime := float64(r.kernelTimeCounter.BusyTime())
+	for _, gpu := range r.platform.GPUs {
+		for _, c := range gpu.CUs {
+			name := c.Name()
+			cuFreq := float64(c.(*cu.ComputeUnit).Freq)
+			numCycle := kernelTime * cuFreq
+			instCount := r.registeredValue(name, "inst_count")
+			simdInstCount := r.registeredValue(name, "simd_inst_count")
+
+			r.metricsCollector.Collect(name, "cu_inst_count", instCount)
+			r.metricsCollector.Collect(name, "cu_CPI", numCycle/instCount)
+			r.metricsCollector.Collect(name, "simd_inst_count", simdInstCount)
+			r.metricsCollector.Collect(name, "simd_CPI", numCycle/simdInstCount)
+		}
 	}
 }
 
@@ -428,9 +171,14 @@ func (r *Runner) reportCPIStackEntries(
 }
 
 func (r *Runner) reportSIMDBusyTime() {
-	for _, t := range r.simdBusyTimeTracers {
-		r.metricsCollector.Collect(
-			t.simd.Name(), "busy_time", float64(t.tracer.BusyTime()))
+	if !r.ReportSIMDBusyTime {
+		return
+	}
+
+	for _, gpu := range r.platform.GPUs {
+		for _, simd := range gpu.SIMDs {
+			r.collectRegisteredMetric(simd.Name(), "busy_time", "busy_time")
+		}
 	}
 }
 
@@ -451,120 +199,233 @@ func (r *Runner) reportExecutionTime() {
 	}
 }
 
+func (r *Runner) reportSampling() {
+	for i, s := range r.kernelSamplers {
+		where := r.platform.GPUs[i].CommandProcessor.Name()
+		estimatedTime, numUnestimated := s.estimatedKernelTime()
+
+		r.metricsCollector.Collect(where, "detailed_kernel_count",
+			float64(s.numDetailedKernels()))
+		r.metricsCollector.Collect(where, "fast_forwarded_kernel_count",
+			float64(s.numFastForwardedKernels()))
+		r.metricsCollector.Collect(where, "detailed_kernel_time",
+			float64(s.detailedKernelTime()))
+		r.metricsCollector.Collect(where, "estimated_kernel_time",
+			float64(estimatedTime))
+		r.metricsCollector.Collect(where, "unestimated_kernel_count",
+			float64(numUnestimated))
+	}
+}
+
 func (r *Runner) reportCacheLatency() {
-	for _, tracer := range r.cacheLatencyTracers {
-		if tracer.tracer.AverageTime() == 0 {
-			continue
-		}
+	if !r.ReportCacheLatency {
+		return
+	}
 
-		r.metricsCollector.Collect(
-			tracer.cache.Name(),
-			"req_average_latency",
-			float64(tracer.tracer.AverageTime()),
-		)
+	for _, gpu := range r.platform.GPUs {
+		caches := concatComponents(
+			gpu.L1ICaches, gpu.L1SCaches, gpu.L1VCaches, gpu.L2Caches)
+		for _, c := range caches {
+			m, found := r.platform.Metrics.Lookup(c.Name(), "req_latency")
+			if !found || m.(*metrics.Histogram).Count() == 0 {
+				continue
+			}
+
+			r.collectRegisteredMetric(
+				c.Name(), "req_latency", "req_average_latency")
+		}
 	}
 }
 
 func (r *Runner) reportCacheHitRate() {
-	for _, tracer := range r.cacheHitRateTracers {
-		readHit := tracer.tracer.GetStepCount("read-hit")
-		readMiss := tracer.tracer.GetStepCount("read-miss")
-		readMSHRHit := tracer.tracer.GetStepCount("read-mshr-hit")
-		writeHit := tracer.tracer.GetStepCount("write-hit")
-		writeMiss := tracer.tracer.GetStepCount("write-miss")
-		writeMSHRHit := tracer.tracer.GetStepCount("write-mshr-hit")
-
-		totalTransaction := readHit + readMiss + readMSHRHit +
-			writeHit + writeMiss + writeMSHRHit
-
-		if totalTransaction == 0 {
-			continue
+	if !r.ReportCacheHitRate {
+		return
+	}
+
+	for _, gpu := range r.platform.GPUs {
+		caches := concatComponents(
+			gpu.L1VCaches, gpu.L1SCaches, gpu.L1ICaches, gpu.L2Caches)
+		for _, c := range caches {
+			r.reportCacheSteps(c.Name())
 		}
+	}
+}
 
+func (r *Runner) reportCacheSteps(name string) {
+	readHit := r.stepCount(name, "read-hit")
+	readMiss := r.stepCount(name, "read-miss")
+	readMSHRHit := r.stepCount(name, "read-mshr-hit")
+	writeHit := r.stepCount(name, "write-hit")
+	writeMiss := r.stepCount(name, "write-miss")
+	writeMSHRHit := r.stepCount(name, "write-mshr-hit")
+	readSectorMiss := r.stepCount(name, "read-sector-miss")
+	writeSectorMiss := r.stepCount(name, "write-sector-miss")
+
+	totalTransaction := readHit + readMiss + readMSHRHit +
+		writeHit + writeMiss + writeMSHRHit +
+		readSectorMiss + writeSectorMiss
+
+	if totalTransaction == 0 {
+		return
+	}
+
+	r.metricsCollector.Collect(name, "read-hit", readHit)
+	r.metricsCollector.Collect(name, "read-miss", readMiss)
+	r.metricsCollector.Collect(name, "read-mshr-hit", readMSHRHit)
+	r.metricsCollector.Collect(name, "write-hit", writeHit)
+	r.metricsCollector.Collect(name, "write-miss", writeMiss)
+	r.metricsCollector.Collect(name, "write-mshr-hit", writeMSHRHit)
+
+	// Only sectored caches can hit a line but miss a sector.
+	if readSectorMiss+writeSectorMiss > 0 {
+		r.metricsCollector.Collect(name, "read-sector-miss", readSectorMiss)
+		r.metricsCollector.Collect(name, "write-sector-miss", writeSectorMiss)
+	}
+
+	r.reportPrefetch(name, readMiss+readSectorMiss)
+}
+
+// reportPrefetch reports the accuracy, the coverage, and the lateness of the
+// prefetcher of a cache. A prefetch is useful if a demand read accesses the
+// line, either after the line arrives or while the line is being fetched.
+// The latter case is a late prefetch.
+func (r *Runner) reportPrefetch(name string, readMiss float64) {
+	issued := r.stepCount(name, "prefetch-issue")
+	if issued == 0 {
+		return
+	}
+
+	hit := r.stepCount(name, "prefetch-hit")
+	late := r.stepCount(name, "prefetch-late")
+	useful := hit + late
+
+	r.metricsCollector.Collect(name, "prefetch-issue", issued)
+	r.metricsCollector.Collect(name, "prefetch-hit", hit)
+	r.metricsCollector.Collect(name, "prefetch-late", late)
+	r.metricsCollector.Collect(name, "prefetch-accuracy", useful/issued)
+
+	if useful+readMiss > 0 {
 		r.metricsCollector.Collect(
-			tracer.cache.Name(), "read-hit", float64(readHit))
-		r.metricsCollector.Collect(
-			tracer.cache.Name(), "read-miss", float64(readMiss))
-		r.metricsCollector.Collect(
-			tracer.cache.Name(), "read-mshr-hit", float64(readMSHRHit))
-		r.metricsCollector.Collect(
-			tracer.cache.Name(), "write-hit", float64(writeHit))
-		r.metricsCollector.Collect(
-			tracer.cache.Name(), "write-miss", float64(writeMiss))
-		r.metricsCollector.Collect(
-			tracer.cache.Name(), "write-mshr-hit", float64(writeMSHRHit))
+			name, "prefetch-coverage", useful/(useful+readMiss))
+	}
+
+	if useful > 0 {
+		r.metricsCollector.Collect(name, "prefetch-lateness", late/useful)
 	}
 }
 
 func (r *Runner) reportTLBHitRate() {
-	for _, tracer := range r.tlbHitRateTracers {
-		hit := tracer.tracer.GetStepCount("hit")
-		miss := tracer.tracer.GetStepCount("miss")
-		mshrHit := tracer.tracer.GetStepCount("mshr-hit")
+	if !r.ReportTLBHitRate {
+		return
+	}
+
+	for _, gpu := range r.platform.GPUs {
+		tlbs := concatComponents(
+			gpu.L1VTLBs, gpu.L1STLBs, gpu.L1ITLBs, gpu.L2TLBs)
+		for _, tlb := range tlbs {
+			name := tlb.Name()
+			hit := r.registeredValue(name, "hit")
+			miss := r.registeredValue(name, "miss")
+			mshrHit := r.registeredValue(name, "mshr_hit")
+
+			if hit+miss+mshrHit == 0 {
+				continue
+			}
+
+			r.metricsCollector.Collect(name, "hit", hit)
+			r.metricsCollector.Collect(name, "miss", miss)
+			r.metricsCollector.Collect(name, "mshr-hit", mshrHit)
+		}
+	}
+}
 
-		totalTransaction := hit + miss + mshrHit
+func (r *Runner) reportRDMATransactionCount() {
+	if !r.ReportRDMATransactionCount {
+		return
+	}
 
-		if totalTransaction == 0 {
+	for _, gpu := range r.platform.GPUs {
+		if gpu.RDMAEngine == nil {
 			continue
 		}
 
-		r.metricsCollector.Collect(
-			tracer.tlb.Name(), "hit", float64(hit))
-		r.metricsCollector.Collect(
-			tracer.tlb.Name(), "miss", float64(miss))
-		r.metricsCollector.Collect(
-			tracer.tlb.Name(), "mshr-hit", float64(mshrHit))
+		name := gpu.RDMAEngine.Name()
+		r.collectRegisteredMetric(
+			name, "outgoing_trans_count", "outgoing_trans_count")
+		r.collectRegisteredMetric(
+			name, "incoming_trans_count", "incoming_trans_count")
 	}
 }
 
-func (r *Runner) reportRDMATransactionCount() {
-	for _, t := range r.rdmaTransactionCounters {
-		r.metricsCollector.Collect(
-			t.rdmaEngine.Name(),
-			"outgoing_trans_count",
-			float64(t.outgoingTracer.TotalCount()),
-		)
-		r.metricsCollector.Collect(
-			t.rdmaEngine.Name(),
-			"incoming_trans_count",
-			float64(t.incomingTracer.TotalCount()),
-		)
+func (r *Runner) reportDRAMTransactionCount() {
+	if !r.ReportDRAMTransactionCount {
+		return
+	}
+
+	for _, gpu := range r.platform.GPUs {
+		for _, dram := range gpu.MemControllers {
+			name := dram.(TraceableComponent).Name()
+
+			r.collectRegisteredMetric(name, "read_trans_count", "read_trans_count")
+			r.collectRegisteredMetric(name, "write_trans_count", "write_trans_count")
+			r.collectRegisteredMetric(name, "read_latency", "read_avg_latency")
+			r.collectRegisteredMetric(name, "write_latency", "write_avg_latency")
+			r.collectRegisteredMetric(name, "read_size", "read_size")
+			r.collectRegisteredMetric(name, "write_size", "write_size")
+		}
 	}
 }
 
-func (r *Runner) reportDRAMTransactionCount() {
-	for _, t := range r.dramTracers {
-		r.metricsCollector.Collect(
-			t.dram.Name(),
-			"read_trans_count",
-			float64(t.tracer.readCount),
-		)
-		r.metricsCollector.Collect(
-			t.dram.Name(),
-			"write_trans_count",
-			float64(t.tracer.writeCount),
-		)
-		r.metricsCollector.Collect(
-			t.dram.Name(),
-			"read_avg_latency",
-			float64(t.tracer.readAvgLatency),
-		)
-		r.metricsCollector.Collect(
-			t.dram.Name(),
-			"write_avg_latency",
-			float64(t.tracer.writeAvgLatency),
-		)
-		r.metricsCollector.Collect(
-			t.dram.Name(),
-			"read_size",
-			float64(t.tracer.readSize),
-		)
-		r.metricsCollector.Collect(
-			t.dram.Name(),
-			"write_size",
-			float64(t.tracer.writeSize),
-		)
+// collectRegisteredMetric reports a metric that a component registers in the
+// metrics registry of the platform with the name reportAs. Histograms are reported
+// with their mean value.
+func (r *Runner) collectRegisteredMetric(where, what, reportAs string) {
+	m, found := r.platform.Metrics.Lookup(where, what)
+	if !found {
+		return
 	}
+
+	switch m := m.(type) {
+	case *metrics.Counter:
+		r.metricsCollector.Collect(where, reportAs, m.Value())
+	case *metrics.Gauge:
+		r.metricsCollector.Collect(where, reportAs, m.Value())
+	case *metrics.Histogram:
+		r.metricsCollector.Collect(where, reportAs, m.Mean())
+	}
+}
+
+// registeredValue returns the value of a counter or a gauge that a component
+// registers in the metrics registry of the platform. It returns 0 if the
+// metric does not exist.
+func (r *Runner) registeredValue(where, what string) float64 {
+	m, found := r.platform.Metrics.Lookup(where, what)
+	if !found {
+		return 0
+	}
+
+	switch m := m.(type) {
+	case *metrics.Counter:
+		return m.Value()
+	case *metrics.Gauge:
+		return m.Value()
+	}
+
+	return 0
+}
+
+// stepCount returns how many times a cache has performed a step.
+func (r *Runner) stepCount(cacheName, step string) float64 {
+	return r.registeredValue(cacheName, cache.StepMetricName(step))
+}
+
+func concatComponents(lists ...[]TraceableComponent) []TraceableComponent {
+	var all []TraceableComponent
+	for _, l := range lists {
+		all = append(all, l...)
+	}
+
+	return all
 }
 
 func (r *Runner) dumpMetrics() {
@@ -9,7 +9,10 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/sarchlab/akita/v3/mem/cache"
+	"github.com/sarchlab/akita/v3/metrics"
 	"github.com/sarchlab/akita/v3/monitoring"
 	"github.com/sarchlab/akita/v3/sim"
 	"github.com/sarchlab/akita/v3/tracing"
@@ -30,21 +33,18 @@ type Runner struct {
 	maxInstStopper          *instTracer
 	kernelTimeCounter       *tracing.BusyTimeTracer
 	perGPUKernelTimeCounter []*tracing.BusyTimeTracer
-	instCountTracers        []instCountTracer
-	cacheLatencyTracers     []cacheLatencyTracer
-	cacheHitRateTracers     []cacheHitRateTracer
-	tlbHitRateTracers       []tlbHitRateTracer
-	rdmaTransactionCounters []rdmaTransactionCountTracer
-	dramTracers             []dramTransactionCountTracer
 	benchmarks              []benchmarks.Benchmark
 	monitor                 *monitoring.Monitor
+	watchdog                *monitoring.Watchdog
 	metricsCollector        *collector
-	simdBusyTimeTracers     []simdBusyTimeTracer
 	cuCPITraces             []cuCPIStackTracer
+	kernelSamplers          []*kernelSampler
 
 	Timing                     bool
 	Verify                     bool
 	Parallel                   bool
+	Deterministic              bool
+	Conservative               bool
 	ReportInstCount            bool
 	ReportCacheLatency         bool
 	ReportCacheHitRate         bool
@@ -55,6 +55,20 @@ type Runner struct {
 	ReportSIMDBusyTime         bool
 	ReportCPIStack             bool
 
+	// FastForwardKernels, FastForwardInsts, and SampleKernels configure the
+	// sampled simulation. See the flags with the same names for details.
+	FastForwardKernels int
+	FastForwardInsts   uint64
+	SampleKernels      int
+
+	// CheckpointAfterKernel is the number of kernels to complete before a
+	// checkpoint is saved and restored. See the flag with the same name.
+	CheckpointAfterKernel int
+
+	// RestoreCheckpoint is the file that the checkpoint is restored from. If
+	// it is empty, the checkpoint is saved instead.
+	RestoreCheckpoint string
+
 	GPUIDs []int
 }
 
@@ -65,6 +79,10 @@ func (r *Runner) Init() *Runner {
 
 	log.SetFlags(log.Llongfile | log.Ldate | log.Ltime)
 
+	if r.Parallel && r.Deterministic {
+		sim.UseDeterministicIDGenerator()
+	}
+
 	if r.Timing {
 		r.buildTimingPlatform()
 	} else {
@@ -75,6 +93,12 @@ func (r *Runner) Init() *Runner {
 
 	r.defineMetrics()
 
+	r.addWatchdog()
+
+	r.addMetricsSampler()
+
+	r.addCheckpointer()
+
 	return r
 }
 
@@ -86,6 +110,14 @@ func (r *Runner) buildEmuPlatform() {
 		b = b.WithParallelEngine()
 	}
 
+	if r.Parallel && r.Deterministic {
+		b = b.WithDeterministicParallelEngine()
+	}
+
+	if !r.Parallel && r.Deterministic {
+		b = b.WithOrderedSerialEngine()
+	}
+
 	if *isaDebug {
 		b = b.WithISADebugging()
 	}
@@ -113,6 +145,18 @@ func (r *Runner) buildTimingPlatform() {
 		b = b.WithParallelEngine()
 	}
 
+	if r.Parallel && r.Deterministic {
+		b = b.WithDeterministicParallelEngine()
+	}
+
+	if !r.Parallel && r.Deterministic {
+		b = b.WithOrderedSerialEngine()
+	}
+
+	if r.Conservative {
+		b = b.WithConservativeEngine()
+	}
+
 	if *isaDebug {
 		b = b.WithISADebugging()
 	}
@@ -141,6 +185,21 @@ func (r *Runner) buildTimingPlatform() {
 		b = b.WithMagicMemoryCopy()
 	}
 
+	// A simulation that saves a checkpoint is built in the same way as the
+	// simulation that restores the checkpoint, which fast-forwards to it.
+	if r.isSampling() || r.CheckpointAfterKernel > 0 {
+		b = b.WithFastForward()
+	}
+
+	b = b.
+		WithL1VPrefetcher(parsePrefetcher(*l1vPrefetcherFlag)).
+		WithL2Prefetcher(parsePrefetcher(*l2PrefetcherFlag)).
+		WithLog2L2SectorSize(*log2L2SectorSizeFlag)
+
+	if *coherentL2Flag {
+		b = b.WithCoherentL2()
+	}
+
 	r.platform = b.Build()
 
 	if !*disableAkitaRTM {
@@ -148,6 +207,29 @@ func (r *Runner) buildTimingPlatform() {
 	}
 }
 
+func (r *Runner) isSampling() bool {
+	return r.FastForwardKernels > 0 ||
+		r.FastForwardInsts > 0 ||
+		r.SampleKernels > 0
+}
+
+func parsePrefetcher(name string) cache.PrefetcherType {
+	switch name {
+	case "none":
+		return cache.PrefetchNone
+	case "next-line":
+		return cache.PrefetchNextLine
+	case "stride":
+		return cache.PrefetchStride
+	case "stream-buffer":
+		return cache.PrefetchStreamBuffer
+	default:
+		log.Panicf("unknown prefetcher %s", name)
+	}
+
+	return cache.PrefetchNone
+}
+
 func (*Runner) setAnalyszer(
 	b R9NanoPlatformBuilder,
 ) R9NanoPlatformBuilder {
@@ -177,6 +259,51 @@ func (r *Runner) addMaxInstStopper() {
 	}
 }
 
+func (r *Runner) addWatchdog() {
+	if *timeLimitFlag == 0 && *watchdogTimeoutFlag == 0 {
+		return
+	}
+
+	r.watchdog = monitoring.NewWatchdog(r.platform.Engine).
+		WithTimeLimit(sim.VTimeInSec(*timeLimitFlag)).
+		WithStallTimeout(
+			time.Duration(*watchdogTimeoutFlag * float64(time.Second)))
+
+	if r.monitor != nil {
+		for _, c := range r.monitor.Components() {
+			r.watchdog.RegisterComponent(c)
+		}
+	}
+
+	r.watchdog.Start()
+}
+
+func (r *Runner) addMetricsSampler() {
+	if *metricsPeriodFlag == 0 {
+		return
+	}
+
+	filename := *filenameFlag + "_series"
+
+	var exporter metrics.Exporter
+	switch *metricsFormatFlag {
+	case "csv":
+		exporter = metrics.NewCSVExporter(filename)
+	case "sqlite":
+		exporter = metrics.NewSQLiteExporter(filename)
+	default:
+		log.Panicf("unknown metrics format %s", *metricsFormatFlag)
+	}
+
+	sampler := metrics.MakeSamplerBuilder().
+		WithTimeTeller(r.platform.Engine).
+		WithRegistry(r.platform.Metrics).
+		WithPeriod(sim.VTimeInSec(*metricsPeriodFlag)).
+		WithExporter(exporter).
+		Build()
+	r.platform.Engine.AcceptHook(sampler)
+}
+
 func (r *Runner) parseGPUFlag() {
 	if *gpuFlag == "" && *unifiedGPUFlag == "" {
 		r.GPUIDs = []int{1}
@@ -263,6 +390,10 @@ func (r *Runner) Run() {
 	}
 	wg.Wait()
 
+	if r.watchdog != nil {
+		r.watchdog.Stop()
+	}
+
 	r.platform.Driver.Terminate()
 	r.platform.Engine.Finished()
 
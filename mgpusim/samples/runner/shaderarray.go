@@ -5,11 +5,13 @@ import (
 	"log"
 	"os"
 
+	"github.com/sarchlab/akita/v3/mem/cache"
 	"github.com/sarchlab/akita/v3/mem/cache/writearound"
 	"github.com/sarchlab/akita/v3/mem/cache/writethrough"
 	"github.com/sarchlab/akita/v3/mem/mem"
 	"github.com/sarchlab/akita/v3/mem/vm/addresstranslator"
 	"github.com/sarchlab/akita/v3/mem/vm/tlb"
+	"github.com/sarchlab/akita/v3/metrics"
 	"github.com/sarchlab/akita/v3/sim"
 	"github.com/sarchlab/akita/v3/tracing"
 	"github.com/sarchlab/mgpusim/v3/timing/cu"
@@ -45,10 +47,12 @@ type shaderArrayBuilder struct {
 	freq              sim.Freq
 	log2CacheLineSize uint64
 	log2PageSize      uint64
+	l1vPrefetcher     cache.PrefetcherType
 
 	isaDebugging bool
 	visTracer    tracing.Tracer
 	memTracer    tracing.Tracer
+	metrics      *metrics.Registry
 
 	connectionCount int
 }
@@ -61,6 +65,7 @@ func makeShaderArrayBuilder() shaderArrayBuilder {
 		freq:              1 * sim.GHz,
 		log2CacheLineSize: 6,
 		log2PageSize:      12,
+		metrics:           metrics.DefaultRegistry(),
 	}
 	return b
 }
@@ -99,6 +104,13 @@ func (b shaderArrayBuilder) withLog2PageSize(
 	return b
 }
 
+func (b shaderArrayBuilder) withL1VPrefetcher(
+	prefetcher cache.PrefetcherType,
+) shaderArrayBuilder {
+	b.l1vPrefetcher = prefetcher
+	return b
+}
+
 func (b shaderArrayBuilder) withIsaDebugging() shaderArrayBuilder {
 	b.isaDebugging = true
 	return b
@@ -118,6 +130,13 @@ func (b shaderArrayBuilder) withMemTracer(
 	return b
 }
 
+func (b shaderArrayBuilder) withMetricsRegistry(
+	registry *metrics.Registry,
+) shaderArrayBuilder {
+	b.metrics = registry
+	return b
+}
+
 func (b shaderArrayBuilder) Build(name string) shaderArray {
 	b.name = name
 	sa := shaderArray{}
@@ -266,6 +285,7 @@ func (b *shaderArrayBuilder) buildCUs(sa *shaderArray) {
 	cuBuilder := cu.MakeBuilder().
 		WithEngine(b.engine).
 		WithFreq(b.freq).
+		WithMetricsRegistry(b.metrics).
 		WithLog2CachelineSize(b.log2CacheLineSize)
 
 	for i := 0; i < b.numCU; i++ {
@@ -331,6 +351,7 @@ func (b *shaderArrayBuilder) buildL1VTLBs(sa *shaderArray) {
 	builder := tlb.MakeBuilder().
 		WithEngine(b.engine).
 		WithFreq(b.freq).
+		WithMetricsRegistry(b.metrics).
 		WithNumMSHREntry(4).
 		WithNumSets(1).
 		WithNumWays(64).
@@ -351,12 +372,14 @@ func (b *shaderArrayBuilder) buildL1VCaches(sa *shaderArray) {
 	builder := writearound.NewBuilder().
 		WithEngine(b.engine).
 		WithFreq(b.freq).
+		WithMetricsRegistry(b.metrics).
 		WithBankLatency(60).
 		WithNumBanks(1).
 		WithLog2BlockSize(b.log2CacheLineSize).
 		WithWayAssociativity(4).
 		WithNumMSHREntry(16).
-		WithTotalByteSize(16 * mem.KB)
+		WithTotalByteSize(16 * mem.KB).
+		WithPrefetcher(b.l1vPrefetcher)
 
 	if b.visTracer != nil {
 		builder = builder.WithVisTracer(b.visTracer)
@@ -409,6 +432,7 @@ func (b *shaderArrayBuilder) buildL1STLB(sa *shaderArray) {
 	builder := tlb.MakeBuilder().
 		WithEngine(b.engine).
 		WithFreq(b.freq).
+		WithMetricsRegistry(b.metrics).
 		WithNumMSHREntry(4).
 		WithNumSets(1).
 		WithNumWays(64).
@@ -427,6 +451,7 @@ func (b *shaderArrayBuilder) buildL1SCache(sa *shaderArray) {
 	builder := writethrough.NewBuilder().
 		WithEngine(b.engine).
 		WithFreq(b.freq).
+		WithMetricsRegistry(b.metrics).
 		WithBankLatency(1).
 		WithNumBanks(1).
 		WithLog2BlockSize(b.log2CacheLineSize).
@@ -483,6 +508,7 @@ func (b *shaderArrayBuilder) buildL1ITLB(sa *shaderArray) {
 	builder := tlb.MakeBuilder().
 		WithEngine(b.engine).
 		WithFreq(b.freq).
+		WithMetricsRegistry(b.metrics).
 		WithNumMSHREntry(4).
 		WithNumSets(1).
 		WithNumWays(64).
@@ -501,6 +527,7 @@ func (b *shaderArrayBuilder) buildL1ICache(sa *shaderArray) {
 	builder := writethrough.NewBuilder().
 		WithEngine(b.engine).
 		WithFreq(b.freq).
+		WithMetricsRegistry(b.metrics).
 		WithBankLatency(1).
 		WithNumBanks(1).
 		WithLog2BlockSize(b.log2CacheLineSize).
@@ -8,9 +8,11 @@ import (
 	memtraces "github.com/sarchlab/akita/v3/mem/trace"
 
 	"github.com/sarchlab/akita/v3/analysis"
+	"github.com/sarchlab/akita/v3/mem/cache"
 	"github.com/sarchlab/akita/v3/mem/mem"
 	"github.com/sarchlab/akita/v3/mem/vm"
 	"github.com/sarchlab/akita/v3/mem/vm/mmu"
+	"github.com/sarchlab/akita/v3/metrics"
 	"github.com/sarchlab/akita/v3/monitoring"
 	"github.com/sarchlab/akita/v3/noc/networking/pcie"
 	"github.com/sarchlab/akita/v3/sim"
@@ -21,6 +23,9 @@ import (
 // R9NanoPlatformBuilder can build a platform that equips R9Nano GPU.
 type R9NanoPlatformBuilder struct {
 	useParallelEngine                  bool
+	deterministic                      bool
+	orderedEvents                      bool
+	useConservativeEngine              bool
 	debugISA                           bool
 	traceVis                           bool
 	traceVisStartTime, traceVisEndTime sim.VTimeInSec
@@ -30,19 +35,41 @@ type R9NanoPlatformBuilder struct {
 	numCUPerSA                         int
 	useMagicMemoryCopy                 bool
 	log2PageSize                       uint64
+	l1vPrefetcher                      cache.PrefetcherType
+	l2Prefetcher                       cache.PrefetcherType
+	log2L2SectorSize                   uint64
+	fastForward                        bool
+	coherentL2                         bool
 
 	engine               sim.Engine
+	conservativeEngine   *sim.ConservativeEngine
 	monitor              *monitoring.Monitor
 	perfAnalysisFileName string
 	perfAnalyzingPeriod  float64
 	perfAnalyzer         *analysis.PerfAnalyzer
 	visTracer            tracing.Tracer
+	metrics              *metrics.Registry
 
 	globalStorage *mem.Storage
 
+	coherenceNetwork    *sim.DirectConnection
+	homeDirectoryFinder *homeDirectoryFinder
+
 	gpus []*GPU
 }
 
+// A homeDirectoryFinder finds the coherence directory that is the home of an
+// address. Each GPU is the home of a 4 GB range of the unified memory. The
+// first range belongs to the CPU and does not have directories.
+type homeDirectoryFinder struct {
+	gpuDirectoryFinders []mem.LowModuleFinder
+}
+
+// Find returns the directory that is the home of the given address.
+func (f *homeDirectoryFinder) Find(address uint64) sim.Port {
+	return f.gpuDirectoryFinders[address/(4*mem.GB)].Find(address)
+}
+
 // MakeR9NanoBuilder creates a EmuBuilder with default parameters.
 func MakeR9NanoBuilder() R9NanoPlatformBuilder {
 	b := R9NanoPlatformBuilder{
@@ -52,6 +79,7 @@ func MakeR9NanoBuilder() R9NanoPlatformBuilder {
 		log2PageSize:      12,
 		traceVisStartTime: -1,
 		traceVisEndTime:   -1,
+		metrics:           metrics.DefaultRegistry(),
 	}
 	return b
 }
@@ -62,6 +90,33 @@ func (b R9NanoPlatformBuilder) WithParallelEngine() R9NanoPlatformBuilder {
 	return b
 }
 
+// WithDeterministicParallelEngine lets the platform use a parallel engine that
+// runs in the deterministic parallel mode.
+func (b R9NanoPlatformBuilder) WithDeterministicParallelEngine() R9NanoPlatformBuilder {
+	b.useParallelEngine = true
+	b.deterministic = true
+	return b
+}
+
+// WithConservativeEngine lets the platform use a conservative parallel engine.
+// Each GPU is simulated in its own partition of the engine, and the driver,
+// the MMU, and the PCIe network are simulated in the first partition. The
+// GPUs connect to the PCIe network with partition links.
+func (b R9NanoPlatformBuilder) WithConservativeEngine() R9NanoPlatformBuilder {
+	b.useConservativeEngine = true
+	return b
+}
+
+// WithOrderedSerialEngine lets the platform use a serial engine that orders
+// the events that happen at the same time in the same way as the
+// deterministic parallel mode and the conservative engine. The GPUs connect to
+// the PCIe network with partition links, as with the conservative engine, so
+// that both engines simulate the same platform.
+func (b R9NanoPlatformBuilder) WithOrderedSerialEngine() R9NanoPlatformBuilder {
+	b.orderedEvents = true
+	return b
+}
+
 // WithISADebugging enables ISA debugging in the simulation.
 func (b R9NanoPlatformBuilder) WithISADebugging() R9NanoPlatformBuilder {
 	b.debugISA = true
@@ -126,17 +181,69 @@ func (b R9NanoPlatformBuilder) WithPerfAnalyzer(
 	return b
 }
 
+// WithMetricsRegistry sets the registry that the metrics of the components
+// are registered in. By default, the default registry is used.
+func (b R9NanoPlatformBuilder) WithMetricsRegistry(
+	registry *metrics.Registry,
+) R9NanoPlatformBuilder {
+	b.metrics = registry
+	return b
+}
+
 // WithMagicMemoryCopy uses global storage as memory components
 func (b R9NanoPlatformBuilder) WithMagicMemoryCopy() R9NanoPlatformBuilder {
 	b.useMagicMemoryCopy = true
 	return b
 }
 
+// WithL1VPrefetcher sets the prefetcher of the L1 vector caches.
+func (b R9NanoPlatformBuilder) WithL1VPrefetcher(
+	prefetcher cache.PrefetcherType,
+) R9NanoPlatformBuilder {
+	b.l1vPrefetcher = prefetcher
+	return b
+}
+
+// WithL2Prefetcher sets the prefetcher of the L2 caches.
+func (b R9NanoPlatformBuilder) WithL2Prefetcher(
+	prefetcher cache.PrefetcherType,
+) R9NanoPlatformBuilder {
+	b.l2Prefetcher = prefetcher
+	return b
+}
+
+// WithLog2L2SectorSize sets the sector size of the L2 caches as a power of 2.
+func (b R9NanoPlatformBuilder) WithLog2L2SectorSize(
+	n uint64,
+) R9NanoPlatformBuilder {
+	b.log2L2SectorSize = n
+	return b
+}
+
+// WithFastForward equips the GPUs with functional compute units so that the
+// kernels can be fast-forwarded. The kernels to fast-forward are selected by
+// the KernelSampler of the Command Processors.
+func (b R9NanoPlatformBuilder) WithFastForward() R9NanoPlatformBuilder {
+	b.fastForward = true
+	return b
+}
+
+// WithCoherentL2 keeps the L2 caches of all the GPUs coherent with a
+// directory-based protocol. The L2 caches cache the data of all the GPUs, and
+// the driver does not flush the caches before memory copies. The GPUs exchange
+// the coherence messages over a dedicated network. The L1 caches are not kept
+// coherent.
+func (b R9NanoPlatformBuilder) WithCoherentL2() R9NanoPlatformBuilder {
+	b.coherentL2 = true
+	return b
+}
+
 // Build builds a platform with R9Nano GPUs.
 func (b R9NanoPlatformBuilder) Build() *Platform {
 	b.engine = b.createEngine()
 	if b.monitor != nil {
 		b.monitor.RegisterEngine(b.engine)
+		b.monitor.RegisterMetrics(b.metrics)
 	}
 
 	b.setupPerformanceAnalyzer()
@@ -144,11 +251,17 @@ func (b R9NanoPlatformBuilder) Build() *Platform {
 
 	b.globalStorage = mem.NewStorage(uint64(1+b.numGPU) * 4 * mem.GB)
 
+	b.createCoherenceNetwork()
+
 	mmuComponent, pageTable := b.createMMU(b.engine)
 
 	gpuDriver := b.buildGPUDriver(pageTable)
 
 	gpuBuilder := b.createGPUBuilder(b.engine, gpuDriver, mmuComponent)
+	if b.fastForward {
+		gpuBuilder = gpuBuilder.WithFastForward(pageTable)
+	}
+
 	pcieConnector, rootComplexID :=
 		b.createConnection(b.engine, gpuDriver, mmuComponent)
 
@@ -165,9 +278,11 @@ func (b R9NanoPlatformBuilder) Build() *Platform {
 	pcieConnector.EstablishRoute()
 
 	return &Platform{
-		Engine: b.engine,
-		Driver: gpuDriver,
-		GPUs:   b.gpus,
+		Engine:        b.platformEngine(),
+		Driver:        gpuDriver,
+		GPUs:          b.gpus,
+		GlobalStorage: b.globalStorage,
+		Metrics:       b.metrics,
 	}
 }
 
@@ -178,14 +293,17 @@ func (b R9NanoPlatformBuilder) buildGPUDriver(
 	if b.useMagicMemoryCopy {
 		gpuDriverBuilder = gpuDriverBuilder.WithMagicMemoryCopyMiddleware()
 	}
-	gpuDriver := gpuDriverBuilder.
+	gpuDriverBuilder = gpuDriverBuilder.
 		WithEngine(b.engine).
 		WithPageTable(pageTable).
 		WithLog2PageSize(b.log2PageSize).
 		WithGlobalStorage(b.globalStorage).
 		WithD2HCycles(8500).
-		WithH2DCycles(14500).
-		Build("Driver")
+		WithH2DCycles(14500)
+	if b.coherentL2 {
+		gpuDriverBuilder = gpuDriverBuilder.WithoutCacheFlushing()
+	}
+	gpuDriver := gpuDriverBuilder.Build("Driver")
 	if b.visTracer != nil {
 		tracing.CollectTrace(gpuDriver, b.visTracer)
 	}
@@ -227,9 +345,13 @@ func (b *R9NanoPlatformBuilder) setupVisTracing() {
 		be := tracing.NewMySQLTraceWriter()
 		be.Init()
 		backend = be
+	case "chrome":
+		be := tracing.NewChromeTraceWriter(*visTracerDBFileName)
+		be.Init()
+		backend = be
 	default:
 		panic(fmt.Sprintf(
-			"Tracer database type must be [sqlite|csv|mysql]. "+
+			"Tracer database type must be [sqlite|csv|mysql|chrome]. "+
 				"Provided value %s is not supported.",
 			*visTracerDB))
 	}
@@ -240,6 +362,22 @@ func (b *R9NanoPlatformBuilder) setupVisTracing() {
 	b.visTracer = visTracer
 }
 
+func (b *R9NanoPlatformBuilder) createCoherenceNetwork() {
+	if !b.coherentL2 {
+		return
+	}
+
+	if b.useConservativeEngine {
+		panic("coherent L2 caches do not support the conservative engine")
+	}
+
+	b.coherenceNetwork = sim.NewDirectConnection(
+		"CoherenceNetwork", b.engine, 1*sim.GHz)
+	b.homeDirectoryFinder = &homeDirectoryFinder{
+		gpuDirectoryFinders: []mem.LowModuleFinder{nil},
+	}
+}
+
 func (b *R9NanoPlatformBuilder) setupPerformanceAnalyzer() {
 	if b.perfAnalysisFileName != "" {
 		b.perfAnalyzer = analysis.MakePerfAnalyzerBuilder().
@@ -301,6 +439,10 @@ func (b R9NanoPlatformBuilder) createConnection(
 		pcieConnector = pcieConnector.WithVisTracer(b.visTracer)
 	}
 
+	if b.monitor != nil {
+		pcieConnector = pcieConnector.WithMonitor(b.monitor)
+	}
+
 	pcieConnector.CreateNetwork("PCIe")
 	rootComplexID := pcieConnector.AddRootComplex(
 		[]sim.Port{
@@ -312,12 +454,22 @@ func (b R9NanoPlatformBuilder) createConnection(
 	return pcieConnector, rootComplexID
 }
 
-func (b R9NanoPlatformBuilder) createEngine() sim.Engine {
+func (b *R9NanoPlatformBuilder) createEngine() sim.Engine {
 	var engine sim.Engine
 
-	if b.useParallelEngine {
+	if b.useConservativeEngine {
+		b.conservativeEngine = sim.NewConservativeEngine()
+		return b.conservativeEngine.Partition(0)
+	}
+
+	switch {
+	case b.deterministic:
+		engine = sim.NewDeterministicParallelEngine()
+	case b.useParallelEngine:
 		engine = sim.NewParallelEngine()
-	} else {
+	case b.orderedEvents:
+		engine = sim.NewOrderedSerialEngine()
+	default:
 		engine = sim.NewSerialEngine()
 	}
 	// engine.AcceptHook(sim.NewEventLogger(log.New(os.Stdout, "", 0)))
@@ -325,6 +477,15 @@ func (b R9NanoPlatformBuilder) createEngine() sim.Engine {
 	return engine
 }
 
+// platformEngine returns the engine that runs the whole platform.
+func (b *R9NanoPlatformBuilder) platformEngine() sim.Engine {
+	if b.conservativeEngine != nil {
+		return b.conservativeEngine
+	}
+
+	return b.engine
+}
+
 func (b R9NanoPlatformBuilder) createMMU(
 	engine sim.Engine,
 ) (*mmu.MMU, vm.PageTable) {
@@ -358,7 +519,11 @@ func (b *R9NanoPlatformBuilder) createGPUBuilder(
 		WithNumMemoryBank(16).
 		WithLog2MemoryBankInterleavingSize(7).
 		WithLog2PageSize(b.log2PageSize).
-		WithGlobalStorage(b.globalStorage)
+		WithGlobalStorage(b.globalStorage).
+		WithL1VPrefetcher(b.l1vPrefetcher).
+		WithL2Prefetcher(b.l2Prefetcher).
+		WithLog2L2SectorSize(b.log2L2SectorSize).
+		WithMetricsRegistry(b.metrics)
 
 	if b.monitor != nil {
 		gpuBuilder = gpuBuilder.WithMonitor(b.monitor)
@@ -372,6 +537,11 @@ func (b *R9NanoPlatformBuilder) createGPUBuilder(
 		gpuBuilder = gpuBuilder.WithVisTracer(b.visTracer)
 	}
 
+	if b.coherentL2 {
+		gpuBuilder = gpuBuilder.WithCoherentL2(
+			b.coherenceNetwork, b.homeDirectoryFinder)
+	}
+
 	gpuBuilder = b.setMemTracer(gpuBuilder)
 	gpuBuilder = b.setISADebugger(gpuBuilder)
 
@@ -417,6 +587,12 @@ func (b *R9NanoPlatformBuilder) createGPU(
 ) *GPU {
 	name := fmt.Sprintf("GPU[%d]", index)
 	memAddrOffset := uint64(index) * 4 * mem.GB
+
+	if b.conservativeEngine != nil {
+		gpuBuilder = gpuBuilder.
+			WithEngine(b.conservativeEngine.Partition(index))
+	}
+
 	gpu := gpuBuilder.
 		WithMemAddrOffset(memAddrOffset).
 		Build(name, uint64(index))
@@ -432,7 +608,21 @@ func (b *R9NanoPlatformBuilder) createGPU(
 	b.configRDMAEngine(gpu, rdmaAddressTable)
 	b.configPMC(gpu, gpuDriver, pmcAddressTable)
 
-	pcieConnector.PlugInDevice(pcieSwitchID, gpu.Domain.Ports())
+	if b.coherentL2 {
+		b.homeDirectoryFinder.gpuDirectoryFinders = append(
+			b.homeDirectoryFinder.gpuDirectoryFinders, gpu.DirectoryFinder)
+	}
+
+	switch {
+	case b.conservativeEngine != nil:
+		pcieConnector.PlugInDeviceInPartition(pcieSwitchID,
+			gpu.Domain.Ports(), b.conservativeEngine.Partition(index))
+	case b.orderedEvents:
+		pcieConnector.PlugInDeviceInPartition(pcieSwitchID,
+			gpu.Domain.Ports(), b.engine)
+	default:
+		pcieConnector.PlugInDevice(pcieSwitchID, gpu.Domain.Ports())
+	}
 
 	b.gpus = append(b.gpus, gpu)
 
@@ -11,42 +11,52 @@ var benchmarks = []benchmark{
 			{gpus: []int{1}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+			{gpus: []int{1}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: false},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: false},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: true},
+			{gpus: []int{1}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: true},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: true},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: true},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: true},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: true},
 		},
 	},
 	{
@@ -59,42 +69,52 @@ var benchmarks = []benchmark{
 			{gpus: []int{1}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+			{gpus: []int{1}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: false},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: false},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: true},
+			{gpus: []int{1}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: true},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: true},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: true},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: true},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: true},
 		},
 	},
 	{
@@ -107,42 +127,52 @@ var benchmarks = []benchmark{
 			{gpus: []int{1}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+			{gpus: []int{1}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: false},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: false},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: true},
+			{gpus: []int{1}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: true},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: true},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: true},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: true},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: true},
 		},
 	},
 	{
@@ -155,42 +185,52 @@ var benchmarks = []benchmark{
 			{gpus: []int{1}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+			{gpus: []int{1}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: false},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: false},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: true},
+			{gpus: []int{1}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: true},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: true},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: true},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: true},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: true},
 		},
 	},
 	{
@@ -203,42 +243,52 @@ var benchmarks = []benchmark{
 			{gpus: []int{1}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+			{gpus: []int{1}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: false},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: false},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: true},
+			{gpus: []int{1}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: true},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: true},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: true},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: true},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: true},
 		},
 	},
 	{
@@ -251,42 +301,52 @@ var benchmarks = []benchmark{
 			{gpus: []int{1}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+			{gpus: []int{1}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: false},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: false},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: true},
+			{gpus: []int{1}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: true},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: true},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: true},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: true},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: true},
 		},
 	},
 	{
@@ -299,42 +359,52 @@ var benchmarks = []benchmark{
 			{gpus: []int{1}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+			{gpus: []int{1}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: false},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: false},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: true},
+			{gpus: []int{1}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: true},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: true},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: true},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: true},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: true},
 		},
 	},
 	{
@@ -347,42 +417,52 @@ var benchmarks = []benchmark{
 			{gpus: []int{1}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+			{gpus: []int{1}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: false},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: false},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: true},
+			{gpus: []int{1}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: true},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: true},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: true},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: true},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: true},
 		},
 	},
 	// {
@@ -395,42 +475,52 @@ var benchmarks = []benchmark{
 	// 		{gpus: []int{1}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 	// 		{gpus: []int{1}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 	// 		{gpus: []int{1}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+	// 		{gpus: []int{1}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 	// 		{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: false},
 	// 		{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 	// 		{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 	// 		{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+	// 		{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 	// 		{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: false},
 	// 		{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 	// 		{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 	// 		{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+	// 		{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 	// 		{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: false},
 	// 		{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: false},
 	// 		{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: false},
 	// 		{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: false},
+	// 		{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: false},
 	// 		{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: false},
 	// 		{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: false},
 	// 		{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: false},
 	// 		{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: false},
+	// 		{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: false},
 	// 		{gpus: []int{1}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: true},
 	// 		{gpus: []int{1}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: true},
 	// 		{gpus: []int{1}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: true},
 	// 		{gpus: []int{1}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: true},
+	// 		{gpus: []int{1}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: true},
 	// 		{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: true},
 	// 		{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: true},
 	// 		{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: true},
 	// 		{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: true},
+	// 		{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: true},
 	// 		{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: true},
 	// 		{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: true},
 	// 		{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: true},
 	// 		{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: true},
+	// 		{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: true},
 	// 		{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: true},
 	// 		{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: true},
 	// 		{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: true},
 	// 		{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: true},
+	// 		{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: true},
 	// 		{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: true},
 	// 		{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: true},
 	// 		{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: true},
 	// 		{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: true},
+	// 		{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: true},
 	// 	},
 	// },
 	{
@@ -443,42 +533,52 @@ var benchmarks = []benchmark{
 			{gpus: []int{1}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+			{gpus: []int{1}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: false},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: false},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: true},
+			{gpus: []int{1}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: true},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: true},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: true},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: true},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: true},
 		},
 	},
 	{
@@ -491,42 +591,52 @@ var benchmarks = []benchmark{
 			{gpus: []int{1}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+			{gpus: []int{1}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: false},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: false},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: true},
+			{gpus: []int{1}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: true},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: true},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: true},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: true},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: true},
 		},
 	},
 	{
@@ -539,42 +649,55 @@ var benchmarks = []benchmark{
 			{gpus: []int{1}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+			{gpus: []int{1}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: false},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: false},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: true},
+			{gpus: []int{1}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: true},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: true},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: true},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: true},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: true},
+			{gpus: []int{1}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false, fastForwardKernels: 1},
+			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false, fastForwardKernels: 1},
+			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: false, fastForwardKernels: 1},
 		},
 	},
 	{
@@ -587,26 +710,32 @@ var benchmarks = []benchmark{
 			{gpus: []int{1}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+			{gpus: []int{1}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: false},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: false},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: true},
+			{gpus: []int{1}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: true},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: true},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: true},
 		},
 	},
 	{
@@ -619,42 +748,52 @@ var benchmarks = []benchmark{
 			{gpus: []int{1}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+			{gpus: []int{1}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: false},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: false},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: true},
+			{gpus: []int{1}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: true},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: true},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: true},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: true},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: true},
 		},
 	},
 	{
@@ -667,42 +806,52 @@ var benchmarks = []benchmark{
 			{gpus: []int{1}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+			{gpus: []int{1}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: false},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: false},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: true},
+			{gpus: []int{1}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: true},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: true},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: true},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: true},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: true},
 		},
 	},
 	{
@@ -715,42 +864,52 @@ var benchmarks = []benchmark{
 			{gpus: []int{1}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+			{gpus: []int{1}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: false},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: false},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: true},
+			{gpus: []int{1}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: true},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: true},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: true},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: true},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: true},
 		},
 	},
 	{
@@ -763,42 +922,52 @@ var benchmarks = []benchmark{
 			{gpus: []int{1}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+			{gpus: []int{1}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: false},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: false},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: false},
 			{gpus: []int{1}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: true},
+			{gpus: []int{1}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: true},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: true},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: true},
+			{gpus: []int{1, 2}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: true, unifiedMemory: true},
 			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: true, unifiedMemory: true},
+			{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: true, unifiedMemory: true},
 		},
 	},
 	// {
@@ -811,6 +980,7 @@ var benchmarks = []benchmark{
 	// 		{gpus: []int{1}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 	// 		{gpus: []int{1}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 	// 		{gpus: []int{1}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+	// 		{gpus: []int{1}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 	// 	},
 	// },
 	// {
@@ -823,10 +993,12 @@ var benchmarks = []benchmark{
 	// 		{gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: false},
 	// 		{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: false},
 	// 		{gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: false},
+	// 		{gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: false},
 	// {gpus: []int{1, 2, 3, 4}, timing: false, parallel: false, unifiedGPU: false, unifiedMemory: true},
 	// {gpus: []int{1, 2, 3, 4}, timing: false, parallel: true, unifiedGPU: false, unifiedMemory: true},
 	// {gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, unifiedGPU: false, unifiedMemory: true},
 	// {gpus: []int{1, 2, 3, 4}, timing: true, parallel: true, unifiedGPU: false, unifiedMemory: true},
+	// {gpus: []int{1, 2, 3, 4}, timing: true, parallel: false, conservative: true, unifiedGPU: false, unifiedMemory: true},
 	// },
 	// },
 }
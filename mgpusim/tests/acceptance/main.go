@@ -43,6 +43,11 @@ type benchmarkCase struct {
 	unifiedGPU    bool
 	unifiedMemory bool
 	parallel      bool
+	conservative  bool
+
+	// fastForwardKernels is the number of kernels that are fast-forwarded
+	// before the detailed simulation starts.
+	fastForwardKernels int
 }
 
 func (b benchmark) compile() error {
@@ -140,6 +145,15 @@ func (b benchmark) populateArgs(c benchmarkCase) []string {
 		args = append(args, "-parallel=false")
 	}
 
+	if c.conservative {
+		args = append(args, "-conservative=true")
+	}
+
+	if c.fastForwardKernels > 0 {
+		args = append(args,
+			fmt.Sprintf("-fast-forward-kernels=%d", c.fastForwardKernels))
+	}
+
 	if c.unifiedMemory {
 		args = append(args, "-use-unified-memory=true")
 	} else {
@@ -183,11 +197,12 @@ func shouldRunBenchmarkCase(b benchmark, c benchmarkCase) bool {
 		return false
 	}
 
-	if *onlyParallel && !c.parallel {
+	isParallel := c.parallel || c.conservative
+	if *onlyParallel && !isParallel {
 		return false
 	}
 
-	if *noParallel && c.parallel {
+	if *noParallel && isParallel {
 		return false
 	}
 
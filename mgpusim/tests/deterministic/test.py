@@ -3,6 +3,10 @@ import subprocess
 from collections import namedtuple
 
 TestCase = namedtuple("TestCase", "dir executable arguments")
+Mode = namedtuple(
+    "Mode", "name arguments thread_counts reference check_task_ids setup",
+    defaults=[None],
+)
 
 cwd = os.getcwd()
 
@@ -11,6 +15,32 @@ cases = [
     TestCase("memcopy", "memcopy", ""),
     TestCase("../../samples/fir", "fir", "-length=64"),
     TestCase("../../samples/fir", "fir", "-length=65536"),
+    TestCase("../../samples/fir", "fir", "-length=8192 -gpus=1,2,3,4"),
+    TestCase("../../samples/atax", "atax", "-x=128 -y=128"),
+]
+
+# The serial mode runs with the default number of threads. The parallel modes
+# change the number of threads between runs, as the results should not depend
+# on it. The conservative mode must also produce the same results as the
+# ordered mode, which runs serially and orders the same-time events in the
+# same way. The checkpoint mode saves a checkpoint after the first kernel in a
+# setup run, and restores it in new processes. Neither saving nor restoring
+# the checkpoint may change the results of the serial mode. The
+# deterministic parallel mode also records the memory traces, whose task IDs
+# must not change with the number of threads either.
+modes = [
+    Mode("serial", "", [None], None, False),
+    Mode("ordered", "-deterministic", [None], None, False),
+    Mode("parallel", "-parallel -deterministic", [1, 2, 4, 8], None, True),
+    Mode("conservative", "-conservative", [1, 2, 4, 8], "ordered", False),
+    Mode(
+        "checkpoint",
+        "-checkpoint-after-kernel=1 -restore-checkpoint=checkpoint.ckpt",
+        [None],
+        "serial",
+        False,
+        "-checkpoint-after-kernel=1 -checkpoint-file=checkpoint.ckpt",
+    ),
 ]
 
 
@@ -21,33 +51,112 @@ def compile(dir):
     os.chdir(cwd)
 
 
-def run(test_case, run_index):
+def run(test_case, mode, run_index):
     """Get into the test case directory and run the executable."""
     os.chdir(test_case.dir)
+
+    env = os.environ.copy()
+    thread_count = mode.thread_counts[run_index % len(mode.thread_counts)]
+    if thread_count is not None:
+        env["GOMAXPROCS"] = str(thread_count)
+
+    trace_arguments = "-trace-mem" if mode.check_task_ids else ""
     subprocess.check_call(
-        [f"./{test_case.executable} -timing -report-all {test_case.arguments}"],
+        [
+            f"./{test_case.executable} -timing -report-all {mode.arguments} "
+            f"{trace_arguments} {test_case.arguments}"
+        ],
         shell=True,
+        env=env,
     )
+
+    metric_file = f"deterministic_metrics_{mode.name}_{run_index}.csv"
+    subprocess.check_call([f"mv metrics.csv {metric_file}"], shell=True)
+
+    if run_index > 0:
+        subprocess.check_call(
+            [
+                f"diff {metric_file} "
+                f"deterministic_metrics_{mode.name}_{run_index - 1}.csv"
+            ],
+            shell=True,
+        )
+
+    if mode.check_task_ids:
+        compare_task_ids(mode, run_index)
+
+    os.chdir(cwd)
+
+
+def setup(test_case, mode):
+    """Run the setup of the mode, whose metrics must match the reference."""
+    if mode.setup is None:
+        return
+
+    os.chdir(test_case.dir)
     subprocess.check_call(
-        [f"mv metrics.csv deterministic_metrics_{run_index}.csv"], shell=True
+        [
+            f"./{test_case.executable} -timing -report-all {mode.setup} "
+            f"{test_case.arguments}"
+        ],
+        shell=True,
     )
 
+    metric_file = f"deterministic_metrics_{mode.name}_setup.csv"
+    subprocess.check_call([f"mv metrics.csv {metric_file}"], shell=True)
+    subprocess.check_call(
+        [f"diff {metric_file} deterministic_metrics_{mode.reference}_0.csv"],
+        shell=True,
+    )
+    os.chdir(cwd)
+
+
+def compare_task_ids(mode, run_index):
+    """Check if the memory trace has the same tasks as in the previous run.
+
+    The tasks that happen at the same time may be recorded in any order when
+    the simulation runs in parallel, so the lines of the traces are sorted
+    before the comparison.
+    """
+    trace_file = f"deterministic_tasks_{mode.name}_{run_index}.trace"
+    subprocess.check_call([f"sort mem.trace > {trace_file}"], shell=True)
+    subprocess.check_call(["rm mem.trace"], shell=True)
+
     if run_index > 0:
         subprocess.check_call(
             [
-                f"diff deterministic_metrics_{run_index}.csv deterministic_metrics_{run_index - 1}.csv"
+                f"diff -q {trace_file} "
+                f"deterministic_tasks_{mode.name}_{run_index - 1}.trace"
             ],
             shell=True,
         )
 
+
+def compare_with_reference(test_case, mode):
+    """Check if the mode produces the same metrics as the reference mode."""
+    if mode.reference is None:
+        return
+
+    os.chdir(test_case.dir)
+    subprocess.check_call(
+        [
+            f"diff deterministic_metrics_{mode.name}_0.csv "
+            f"deterministic_metrics_{mode.reference}_0.csv"
+        ],
+        shell=True,
+    )
     os.chdir(cwd)
 
 
 def test(test_case):
     """Run the test case."""
     compile(test_case.dir)
-    for i in range(5):
-        run(test_case, i)
+    for mode in modes:
+        setup(test_case, mode)
+        for i in range(5):
+            run(test_case, mode, i)
+
+        compare_with_reference(test_case, mode)
 
 
 def main():
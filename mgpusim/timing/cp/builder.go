@@ -21,6 +21,7 @@ type Builder struct {
 	monitor        *monitoring.Monitor
 	perfAnalyzer   *analysis.PerfAnalyzer
 	numDispatchers int
+	fastForward    bool
 }
 
 // MakeBuilder creates a new builder with default configuration values.
@@ -66,6 +67,13 @@ func (b Builder) WithPerfAnalyzer(
 	return b
 }
 
+// WithFastForward lets the Command Processor build another set of dispatchers
+// that dispatch the fast-forwarded kernels to functional compute units.
+func (b Builder) WithFastForward() Builder {
+	b.fastForward = true
+	return b
+}
+
 // Build builds a new Command Processor
 func (b Builder) Build(name string) *CommandProcessor {
 	cp := new(CommandProcessor)
@@ -82,6 +90,10 @@ func (b Builder) Build(name string) *CommandProcessor {
 
 	b.buildDispatchers(cp)
 
+	if b.fastForward {
+		b.buildFastForwardDispatchers(cp)
+	}
+
 	if b.visTracer != nil {
 		tracing.CollectTrace(cp, b.visTracer)
 	}
@@ -158,3 +170,25 @@ func (b *Builder) buildDispatchers(cp *CommandProcessor) {
 		cp.Dispatchers = append(cp.Dispatchers, disp)
 	}
 }
+
+func (b *Builder) buildFastForwardDispatchers(cp *CommandProcessor) {
+	cuResourcePool := resource.NewCUResourcePool()
+	builder := dispatching.MakeBuilder().
+		WithCP(cp).
+		WithAlg("round-robin").
+		WithCUResourcePool(cuResourcePool).
+		WithDispatchingPort(cp.ToCUs).
+		WithRespondingPort(cp.ToDriver).
+		WithMonitor(b.monitor)
+
+	for i := 0; i < b.numDispatchers; i++ {
+		disp := builder.Build(
+			fmt.Sprintf("%s.FastForwardDispatcher%d", cp.Name(), i))
+
+		if b.visTracer != nil {
+			tracing.CollectTrace(disp, b.visTracer)
+		}
+
+		cp.FastForwardDispatchers = append(cp.FastForwardDispatchers, disp)
+	}
+}
@@ -0,0 +1,96 @@
+package cp
+
+import (
+	"fmt"
+
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/mgpusim/v3/timing/cp/internal/dispatching"
+)
+
+type checkpointState struct {
+	FastForwarding bool
+	MemoryStale    bool
+}
+
+// SaveState saves the state of the Command Processor and its dispatchers. The
+// Command Processor can only be saved when it is not processing any request
+// from the driver.
+func (p *CommandProcessor) SaveState(cp *sim.Checkpoint) error {
+	if !p.isDrained() {
+		return fmt.Errorf("command processor %s is processing requests",
+			p.Name())
+	}
+
+	state := checkpointState{
+		FastForwarding: p.fastForwarding,
+		MemoryStale:    p.memoryStale,
+	}
+
+	err := cp.Put(p.Name(), state)
+	if err != nil {
+		return err
+	}
+
+	err = p.forEachDispatcher(func(d sim.Checkpointable) error {
+		return d.SaveState(cp)
+	})
+	if err != nil {
+		return err
+	}
+
+	return p.TickScheduler.SaveStateAs(cp, p.Name()+".TickScheduler")
+}
+
+// LoadState restores the state of the Command Processor and its dispatchers.
+func (p *CommandProcessor) LoadState(cp *sim.Checkpoint) error {
+	state := checkpointState{}
+	err := cp.Get(p.Name(), &state)
+	if err != nil {
+		return err
+	}
+
+	p.fastForwarding = state.FastForwarding
+	p.memoryStale = state.MemoryStale
+
+	err = p.forEachDispatcher(func(d sim.Checkpointable) error {
+		return d.LoadState(cp)
+	})
+	if err != nil {
+		return err
+	}
+
+	return p.TickScheduler.LoadStateAs(cp, p.Name()+".TickScheduler")
+}
+
+func (p *CommandProcessor) forEachDispatcher(
+	f func(d sim.Checkpointable) error,
+) error {
+	dispatchers := []dispatching.Dispatcher{}
+	dispatchers = append(dispatchers, p.Dispatchers...)
+	dispatchers = append(dispatchers, p.FastForwardDispatchers...)
+
+	for _, d := range dispatchers {
+		checkpointable, ok := d.(sim.Checkpointable)
+		if !ok {
+			return fmt.Errorf("dispatcher %s cannot be checkpointed", d.Name())
+		}
+
+		err := f(checkpointable)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *CommandProcessor) isDrained() bool {
+	return p.currShootdownRequest == nil &&
+		p.currFlushRequest == nil &&
+		!p.shootDownInProcess &&
+		!p.flushingForFastForward &&
+		p.sampledReq == nil &&
+		len(p.bottomKernelLaunchReqIDToTopReqMap) == 0 &&
+		len(p.bottomMemCopyH2DReqIDToTopReqMap) == 0 &&
+		len(p.bottomMemCopyD2HReqIDToTopReqMap) == 0
+}
@@ -20,19 +20,22 @@ import (
 type CommandProcessor struct {
 	*sim.TickingComponent
 
-	Dispatchers        []dispatching.Dispatcher
-	DMAEngine          sim.Port
-	Driver             sim.Port
-	TLBs               []sim.Port
-	CUs                []sim.Port
-	AddressTranslators []sim.Port
-	RDMA               sim.Port
-	PMC                sim.Port
-	L1VCaches          []sim.Port
-	L1SCaches          []sim.Port
-	L1ICaches          []sim.Port
-	L2Caches           []sim.Port
-	DRAMControllers    []*idealmemcontroller.Comp
+	Dispatchers            []dispatching.Dispatcher
+	FastForwardDispatchers []dispatching.Dispatcher
+	KernelSampler          KernelSampler
+	Warmer                 Warmer
+	DMAEngine              sim.Port
+	Driver                 sim.Port
+	TLBs                   []sim.Port
+	CUs                    []sim.Port
+	AddressTranslators     []sim.Port
+	RDMA                   sim.Port
+	PMC                    sim.Port
+	L1VCaches              []sim.Port
+	L1SCaches              []sim.Port
+	L1ICaches              []sim.Port
+	L2Caches               []sim.Port
+	DRAMControllers        []*idealmemcontroller.Comp
 
 	ToDriver                   sim.Port
 	toDriverSender             sim.BufferedSender
@@ -63,6 +66,12 @@ type CommandProcessor struct {
 
 	shootDownInProcess bool
 
+	fastForwarding         bool
+	flushingForFastForward bool
+	memoryStale            bool
+	sampledReq             *protocol.LaunchKernelReq
+	sampledReqFastForward  bool
+
 	bottomKernelLaunchReqIDToTopReqMap map[string]*protocol.LaunchKernelReq
 	bottomMemCopyH2DReqIDToTopReqMap   map[string]*protocol.MemCopyH2DReq
 	bottomMemCopyD2HReqIDToTopReqMap   map[string]*protocol.MemCopyD2HReq
@@ -134,6 +143,10 @@ func (p *CommandProcessor) tickDispatchers(
 		madeProgress = d.Tick(now) || madeProgress
 	}
 
+	for _, d := range p.FastForwardDispatchers {
+		madeProgress = d.Tick(now) || madeProgress
+	}
+
 	return madeProgress
 }
 
@@ -143,6 +156,10 @@ func (p *CommandProcessor) processReqFromDriver(now sim.VTimeInSec) bool {
 		return false
 	}
 
+	if _, isKernel := msg.(*protocol.LaunchKernelReq); !isKernel {
+		p.prepareCachesForReq(msg)
+	}
+
 	switch req := msg.(type) {
 	case *protocol.LaunchKernelReq:
 		return p.processLaunchKernelReq(now, req)
@@ -292,6 +309,10 @@ func (p *CommandProcessor) processLaunchKernelReq(
 	now sim.VTimeInSec,
 	req *protocol.LaunchKernelReq,
 ) bool {
+	if p.needToSwitchMode(req) || p.needToFlushForFastForward() {
+		return p.switchMode(now)
+	}
+
 	d := p.findAvailableDispatcher()
 
 	if d == nil {
@@ -309,7 +330,12 @@ func (p *CommandProcessor) processLaunchKernelReq(
 }
 
 func (p *CommandProcessor) findAvailableDispatcher() dispatching.Dispatcher {
-	for _, d := range p.Dispatchers {
+	dispatchers := p.Dispatchers
+	if p.fastForwarding {
+		dispatchers = p.FastForwardDispatchers
+	}
+
+	for _, d := range dispatchers {
 		if !d.IsDispatching() {
 			return d
 		}
@@ -466,6 +492,13 @@ func (p *CommandProcessor) processCacheFlushRsp(
 		if p.shootDownInProcess {
 			return p.processCacheFlushCausedByTLBShootdown(now, rsp)
 		}
+
+		if p.flushingForFastForward {
+			p.flushingForFastForward = false
+			p.fastForwarding = true
+			return true
+		}
+
 		return p.processRegularCacheFlush(now, rsp)
 	}
 
@@ -771,7 +804,7 @@ func (p *CommandProcessor) cloneMemCopyH2DReq(
 	req *protocol.MemCopyH2DReq,
 ) *protocol.MemCopyH2DReq {
 	cloned := *req
-	cloned.ID = sim.GetIDGenerator().Generate()
+	cloned.ID = sim.IDGeneratorFor(p).Generate()
 	p.bottomMemCopyH2DReqIDToTopReqMap[cloned.ID] = req
 	return &cloned
 }
@@ -780,7 +813,7 @@ func (p *CommandProcessor) cloneMemCopyD2HReq(
 	req *protocol.MemCopyD2HReq,
 ) *protocol.MemCopyD2HReq {
 	cloned := *req
-	cloned.ID = sim.GetIDGenerator().Generate()
+	cloned.ID = sim.IDGeneratorFor(p).Generate()
 	p.bottomMemCopyD2HReqIDToTopReqMap[cloned.ID] = req
 	return &cloned
 }
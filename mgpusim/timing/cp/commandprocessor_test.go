@@ -176,6 +176,134 @@ var _ = Describe("CommandProcessor", func() {
 		Expect(madeProgress).To(BeFalse())
 	})
 
+	Context("when sampling kernels", func() {
+		var (
+			sampler      *MockKernelSampler
+			warmer       *MockWarmer
+			ffDispatcher *MockDispatcher
+		)
+
+		BeforeEach(func() {
+			sampler = NewMockKernelSampler(mockCtrl)
+			warmer = NewMockWarmer(mockCtrl)
+			ffDispatcher = NewMockDispatcher(mockCtrl)
+			commandProcessor.KernelSampler = sampler
+			commandProcessor.Warmer = warmer
+			commandProcessor.FastForwardDispatchers =
+				[]dispatching.Dispatcher{ffDispatcher}
+		})
+
+		It("should flush the caches before fast-forwarding", func() {
+			req := protocol.NewLaunchKernelReq(10,
+				driver, commandProcessor.ToDriver)
+
+			sampler.EXPECT().Sample(req).Return(false)
+			dispatcher.EXPECT().IsDispatching().Return(false)
+			ffDispatcher.EXPECT().IsDispatching().Return(false)
+			toCachesSender.EXPECT().
+				Send(gomock.AssignableToTypeOf(&cache.FlushReq{})).
+				Times(40)
+
+			madeProgress := commandProcessor.processLaunchKernelReq(10, req)
+
+			Expect(madeProgress).To(BeTrue())
+			Expect(commandProcessor.flushingForFastForward).To(BeTrue())
+			Expect(commandProcessor.numCacheACK).To(Equal(uint64(40)))
+		})
+
+		It("should start fast-forwarding after the caches are flushed",
+			func() {
+				rsp := cache.FlushRspBuilder{}.Build()
+				commandProcessor.numCacheACK = 1
+				commandProcessor.flushingForFastForward = true
+
+				toCaches.EXPECT().Retrieve(sim.VTimeInSec(10))
+
+				commandProcessor.processCacheFlushRsp(10, rsp)
+
+				Expect(commandProcessor.flushingForFastForward).To(BeFalse())
+				Expect(commandProcessor.fastForwarding).To(BeTrue())
+			})
+
+		It("should dispatch to the fast-forward dispatchers", func() {
+			req := protocol.NewLaunchKernelReq(10,
+				driver, commandProcessor.ToDriver)
+			commandProcessor.fastForwarding = true
+
+			sampler.EXPECT().Sample(req).Return(false)
+			ffDispatcher.EXPECT().IsDispatching().Return(false)
+			ffDispatcher.EXPECT().StartDispatching(req)
+			toDriver.EXPECT().Retrieve(sim.VTimeInSec(10))
+
+			madeProgress := commandProcessor.processLaunchKernelReq(10, req)
+
+			Expect(madeProgress).To(BeTrue())
+		})
+
+		It("should finish warming up before detailed simulation", func() {
+			req := protocol.NewLaunchKernelReq(10,
+				driver, commandProcessor.ToDriver)
+			commandProcessor.fastForwarding = true
+
+			sampler.EXPECT().Sample(req).Return(true)
+			dispatcher.EXPECT().IsDispatching().Return(false).AnyTimes()
+			ffDispatcher.EXPECT().IsDispatching().Return(false)
+			warmer.EXPECT().FinishWarmUp()
+
+			madeProgress := commandProcessor.processLaunchKernelReq(10, req)
+
+			Expect(madeProgress).To(BeTrue())
+			Expect(commandProcessor.fastForwarding).To(BeFalse())
+		})
+
+		It("should load the warmed-up lines before a memory copy", func() {
+			req := protocol.NewMemCopyH2DReq(10,
+				driver, commandProcessor.ToDriver, []byte{1}, 0x100)
+			commandProcessor.fastForwarding = true
+			commandProcessor.numCacheACK = 1
+
+			toDriver.EXPECT().Peek().Return(req)
+			warmer.EXPECT().FinishWarmUp()
+
+			commandProcessor.processReqFromDriver(10)
+
+			Expect(commandProcessor.memoryStale).To(BeTrue())
+		})
+
+		It("should flush the caches again if the memory is stale", func() {
+			req := protocol.NewLaunchKernelReq(10,
+				driver, commandProcessor.ToDriver)
+			commandProcessor.fastForwarding = true
+			commandProcessor.memoryStale = true
+
+			sampler.EXPECT().Sample(req).Return(false)
+			dispatcher.EXPECT().IsDispatching().Return(false)
+			ffDispatcher.EXPECT().IsDispatching().Return(false)
+			toCachesSender.EXPECT().
+				Send(gomock.AssignableToTypeOf(&cache.FlushReq{})).
+				Times(40)
+
+			madeProgress := commandProcessor.processLaunchKernelReq(10, req)
+
+			Expect(madeProgress).To(BeTrue())
+			Expect(commandProcessor.memoryStale).To(BeFalse())
+			Expect(commandProcessor.flushingForFastForward).To(BeTrue())
+		})
+
+		It("should not switch while a kernel is running", func() {
+			req := protocol.NewLaunchKernelReq(10,
+				driver, commandProcessor.ToDriver)
+
+			sampler.EXPECT().Sample(req).Return(false)
+			dispatcher.EXPECT().IsDispatching().Return(true)
+
+			madeProgress := commandProcessor.processLaunchKernelReq(10, req)
+
+			Expect(madeProgress).To(BeFalse())
+			Expect(commandProcessor.fastForwarding).To(BeFalse())
+		})
+	})
+
 	It("should handle a RDMA drain req from driver", func() {
 		cmd := protocol.NewRDMADrainCmdFromDriver(
 			10, nil, commandProcessor.ToDriver)
@@ -10,6 +10,7 @@ import (
 //go:generate mockgen -destination "mock_sim_test.go" -package $GOPACKAGE -write_package_comment=false github.com/sarchlab/akita/v3/sim Engine,Port,BufferedSender
 //go:generate mockgen -destination "mock_kernels_test.go" -package $GOPACKAGE -write_package_comment=false github.com/sarchlab/mgpusim/v3/kernels GridBuilder
 //go:generate mockgen -destination "mock_dispatching_test.go" -package $GOPACKAGE -write_package_comment=false github.com/sarchlab/mgpusim/v3/timing/cp/internal/dispatching Dispatcher
+//go:generate mockgen -destination "mock_cp_test.go" -self_package github.com/sarchlab/mgpusim/v3/timing/cp -package $GOPACKAGE -write_package_comment=false github.com/sarchlab/mgpusim/v3/timing/cp KernelSampler,Warmer
 
 func TestCp(t *testing.T) {
 	RegisterFailHandler(Fail)
@@ -0,0 +1,142 @@
+package cp
+
+import (
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/mgpusim/v3/protocol"
+)
+
+// A KernelSampler decides which kernels are simulated in detail. The kernels
+// that are not sampled are fast-forwarded on functional compute units.
+type KernelSampler interface {
+	// Sample is called once for each kernel. It returns true if the kernel
+	// should be simulated in detail.
+	Sample(req *protocol.LaunchKernelReq) bool
+}
+
+// A Warmer keeps the caches and the TLBs warm while the kernels are
+// fast-forwarded.
+type Warmer interface {
+	// FinishWarmUp loads the data of the warmed-up lines into the caches. It
+	// is called before the caches serve any request after fast-forwarding,
+	// which includes the memory copies and the cache flushes between the
+	// fast-forwarded kernels, and the first kernel that is simulated in
+	// detail.
+	FinishWarmUp()
+}
+
+// RegisterFastForwardCU allows the Command Processor to dispatch
+// fast-forwarded kernels to a functional CU.
+func (p *CommandProcessor) RegisterFastForwardCU(cu CUInterfaceForCP) {
+	for _, d := range p.FastForwardDispatchers {
+		d.RegisterCU(cu)
+	}
+}
+
+// needToSwitchMode returns true if the kernel should run in the mode other
+// than the current mode.
+func (p *CommandProcessor) needToSwitchMode(
+	req *protocol.LaunchKernelReq,
+) bool {
+	if p.KernelSampler == nil {
+		return false
+	}
+
+	if p.sampledReq != req {
+		p.sampledReq = req
+		p.sampledReqFastForward = !p.KernelSampler.Sample(req)
+	}
+
+	return p.sampledReqFastForward != p.fastForwarding
+}
+
+// needToFlushForFastForward returns true if the kernel is fast-forwarded
+// while the caches may hold data that is newer than the memory, as the
+// functional CUs access the memory directly.
+func (p *CommandProcessor) needToFlushForFastForward() bool {
+	return p.fastForwarding && p.memoryStale
+}
+
+// switchMode switches between fast-forwarding and detailed simulation. The
+// mode only switches when no kernel is running. Before fast-forwarding, the
+// caches are flushed so that the functional CUs see all the data in the
+// memory. After fast-forwarding, the Warmer loads the data that is changed by
+// the functional CUs into the caches. The caches are also flushed again if
+// the memory becomes stale while fast-forwarding.
+func (p *CommandProcessor) switchMode(now sim.VTimeInSec) bool {
+	if p.flushingForFastForward || p.isDispatching() {
+		return false
+	}
+
+	if p.sampledReqFastForward {
+		return p.startFastForwarding(now)
+	}
+
+	p.fastForwarding = false
+	p.finishWarmUp()
+
+	return true
+}
+
+// prepareCachesForReq keeps the caches consistent with the memory when a
+// request other than a kernel launch arrives while fast-forwarding. The
+// warmed-up lines are loaded before the caches serve the request. The
+// requests that write to the memory through the caches make the memory stale,
+// so the caches are flushed again before the next fast-forwarded kernel.
+func (p *CommandProcessor) prepareCachesForReq(req sim.Msg) {
+	if !p.fastForwarding {
+		return
+	}
+
+	p.finishWarmUp()
+
+	switch req.(type) {
+	case *protocol.MemCopyH2DReq, *protocol.PageMigrationReqToCP:
+		p.memoryStale = true
+	}
+}
+
+func (p *CommandProcessor) finishWarmUp() {
+	if p.Warmer != nil {
+		p.Warmer.FinishWarmUp()
+	}
+}
+
+func (p *CommandProcessor) isDispatching() bool {
+	for _, d := range p.Dispatchers {
+		if d.IsDispatching() {
+			return true
+		}
+	}
+
+	for _, d := range p.FastForwardDispatchers {
+		if d.IsDispatching() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *CommandProcessor) startFastForwarding(now sim.VTimeInSec) bool {
+	if p.numCacheACK > 0 {
+		return false
+	}
+
+	caches := [][]sim.Port{p.L1ICaches, p.L1SCaches, p.L1VCaches, p.L2Caches}
+	for _, ports := range caches {
+		for _, port := range ports {
+			p.flushCache(now, port)
+		}
+	}
+
+	p.memoryStale = false
+
+	if p.numCacheACK == 0 {
+		p.fastForwarding = true
+		return true
+	}
+
+	p.flushingForFastForward = true
+
+	return true
+}
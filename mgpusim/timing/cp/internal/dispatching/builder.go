@@ -94,17 +94,18 @@ func (b Builder) Build(name string) Dispatcher {
 	switch b.alg {
 	case "round-robin":
 		d.alg = &roundRobinAlgorithm{
-			gridBuilder: kernels.NewGridBuilder(),
+			gridBuilder: kernels.NewGridBuilder(sim.IDGeneratorFor(b.cp)),
 			cuPool:      b.cuResourcePool,
 		}
 	case "greedy":
 		d.alg = &greedyAlgorithm{
-			gridBuilder: kernels.NewGridBuilder(),
+			gridBuilder: kernels.NewGridBuilder(sim.IDGeneratorFor(b.cp)),
 			cuPool:      b.cuResourcePool,
 		}
 	case "partition":
 		d.alg = &partitionAlgorithm{
-			cuPool: b.cuResourcePool,
+			cuPool:      b.cuResourcePool,
+			idGenerator: sim.IDGeneratorFor(b.cp),
 		}
 	default:
 		panic("unknown dispatching algorithm " + b.alg)
@@ -0,0 +1,48 @@
+package dispatching
+
+import (
+	"fmt"
+
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+type checkpointState struct {
+	NextCU        int
+	NextPartition int
+}
+
+// SaveState saves where the dispatcher continues to dispatch work-groups. The
+// dispatcher can only be saved when it is not dispatching a kernel.
+func (d *DispatcherImpl) SaveState(cp *sim.Checkpoint) error {
+	if d.dispatching != nil || len(d.inflightWGs) > 0 {
+		return fmt.Errorf("dispatcher %s is dispatching a kernel", d.name)
+	}
+
+	state := checkpointState{}
+	switch alg := d.alg.(type) {
+	case *roundRobinAlgorithm:
+		state.NextCU = alg.nextCU
+	case *partitionAlgorithm:
+		state.NextPartition = alg.nextPartition
+	}
+
+	return cp.Put(d.name, state)
+}
+
+// LoadState restores where the dispatcher continues to dispatch work-groups.
+func (d *DispatcherImpl) LoadState(cp *sim.Checkpoint) error {
+	state := checkpointState{}
+	err := cp.Get(d.name, &state)
+	if err != nil {
+		return err
+	}
+
+	switch alg := d.alg.(type) {
+	case *roundRobinAlgorithm:
+		alg.nextCU = state.NextCU
+	case *partitionAlgorithm:
+		alg.nextPartition = state.NextPartition
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package dispatching
 
 import (
+	"github.com/sarchlab/akita/v3/sim"
 	"github.com/sarchlab/mgpusim/v3/kernels"
 	"github.com/sarchlab/mgpusim/v3/protocol"
 	"github.com/sarchlab/mgpusim/v3/timing/cp/internal/resource"
@@ -14,8 +15,9 @@ type partition struct {
 // partitionAlgorithm can dispatch workgroups to CUs in a round robin
 // fasion.
 type partitionAlgorithm struct {
-	partitions []*partition
-	cuPool     resource.CUResourcePool
+	partitions  []*partition
+	cuPool      resource.CUResourcePool
+	idGenerator sim.IDGenerator
 
 	nextPartition     int
 	currWGs           []*kernels.WorkGroup
@@ -35,7 +37,7 @@ func (a *partitionAlgorithm) RegisterCU(cu resource.DispatchableCU) {
 func (a *partitionAlgorithm) StartNewKernel(info kernels.KernelLaunchInfo) {
 	a.numDispatchedWG = 0
 
-	gb := kernels.NewGridBuilder()
+	gb := kernels.NewGridBuilder(a.idGenerator)
 	gb.SetKernel(info)
 	a.numWG = gb.NumWG()
 	numCU := a.cuPool.NumCU()
@@ -44,7 +46,7 @@ func (a *partitionAlgorithm) StartNewKernel(info kernels.KernelLaunchInfo) {
 	a.partitions = nil
 	for i := 0; i < numCU; i++ {
 		p := &partition{
-			gridBuilder: kernels.NewGridBuilder(),
+			gridBuilder: kernels.NewGridBuilder(a.idGenerator),
 		}
 
 		p.gridBuilder.SetKernel(info)
@@ -0,0 +1,113 @@
+package cu
+
+import (
+	"fmt"
+
+	"github.com/sarchlab/akita/v3/sim"
+)
+
+type checkpointState struct {
+	Running  bool
+	IsPaused bool
+}
+
+// SaveState saves the register files of the compute unit. The compute unit
+// can only be saved when no wavefront is running on it.
+func (cu *ComputeUnit) SaveState(cp *sim.Checkpoint) error {
+	cu.Lock()
+	defer cu.Unlock()
+
+	if !cu.isDrained() {
+		return fmt.Errorf("compute unit %s is running wavefronts", cu.Name())
+	}
+
+	state := checkpointState{
+		Running:  cu.running,
+		IsPaused: cu.isPaused,
+	}
+
+	err := cp.Put(cu.Name(), state)
+	if err != nil {
+		return err
+	}
+
+	err = cu.forEachRegisterFile(func(r *SimpleRegisterFile, key string) error {
+		return r.SaveStateAs(cp, key)
+	})
+	if err != nil {
+		return err
+	}
+
+	return cu.TickScheduler.SaveStateAs(cp, cu.Name()+".TickScheduler")
+}
+
+// LoadState restores the register files of the compute unit.
+func (cu *ComputeUnit) LoadState(cp *sim.Checkpoint) error {
+	cu.Lock()
+	defer cu.Unlock()
+
+	state := checkpointState{}
+	err := cp.Get(cu.Name(), &state)
+	if err != nil {
+		return err
+	}
+
+	cu.running = state.Running
+	cu.isPaused = state.IsPaused
+
+	err = cu.forEachRegisterFile(func(r *SimpleRegisterFile, key string) error {
+		return r.LoadStateAs(cp, key)
+	})
+	if err != nil {
+		return err
+	}
+
+	return cu.TickScheduler.LoadStateAs(cp, cu.Name()+".TickScheduler")
+}
+
+func (cu *ComputeUnit) forEachRegisterFile(
+	f func(r *SimpleRegisterFile, key string) error,
+) error {
+	files := []RegisterFile{cu.SRegFile}
+	keys := []string{cu.Name() + ".SRegFile"}
+
+	for i, vRegFile := range cu.VRegFile {
+		files = append(files, vRegFile)
+		keys = append(keys, fmt.Sprintf("%s.VRegFile[%d]", cu.Name(), i))
+	}
+
+	for i, file := range files {
+		r, ok := file.(*SimpleRegisterFile)
+		if !ok {
+			return fmt.Errorf("register file %T cannot be checkpointed", file)
+		}
+
+		err := f(r, keys[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (cu *ComputeUnit) isDrained() bool {
+	for _, pool := range cu.WfPools {
+		if len(pool.wfs) > 0 {
+			return false
+		}
+	}
+
+	if len(cu.InFlightInstFetch) > 0 ||
+		len(cu.InFlightScalarMemAccess) > 0 ||
+		len(cu.InFlightVectorMemAccess) > 0 ||
+		len(cu.shadowInFlightInstFetch) > 0 ||
+		len(cu.shadowInFlightScalarMemAccess) > 0 ||
+		len(cu.shadowInFlightVectorMemAccess) > 0 {
+		return false
+	}
+
+	return !cu.isFlushing &&
+		cu.inCPRequestProcessingStage == nil &&
+		cu.toSendToCP == nil
+}
@@ -0,0 +1,110 @@
+package cu
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sarchlab/akita/v3/sim"
+	"github.com/sarchlab/akita/v3/tracing"
+	"github.com/sarchlab/mgpusim/v3/insts"
+	"github.com/sarchlab/mgpusim/v3/timing/wavefront"
+)
+
+var _ = Describe("ComputeUnit Checkpoint", func() {
+	var (
+		cu           *ComputeUnit
+		checkpointer *sim.Checkpointer
+	)
+
+	BeforeEach(func() {
+		engine := sim.NewSerialEngine()
+		builder := MakeBuilder().WithEngine(engine)
+		cu = builder.Build("CheckpointCU")
+		checkpointer = sim.NewCheckpointer(engine)
+		checkpointer.Register(cu)
+	})
+
+	It("should restore the registers", func() {
+		cu.SRegFile.Write(RegisterAccess{
+			Reg:  insts.SReg(1),
+			Data: insts.Uint32ToBytes(42),
+		})
+
+		buf := bytes.NewBuffer(nil)
+		Expect(checkpointer.Save(buf)).To(Succeed())
+
+		cu.SRegFile.Write(RegisterAccess{
+			Reg:  insts.SReg(1),
+			Data: insts.Uint32ToBytes(0),
+		})
+		Expect(checkpointer.Restore(buf)).To(Succeed())
+
+		data := make([]byte, 4)
+		cu.SRegFile.Read(RegisterAccess{Reg: insts.SReg(1), Data: data})
+		Expect(insts.BytesToUint32(data)).To(Equal(uint32(42)))
+	})
+
+	It("should not save a running wavefront", func() {
+		cu.WfPools[0].AddWf(&wavefront.Wavefront{})
+
+		Expect(checkpointer.Save(bytes.NewBuffer(nil))).NotTo(Succeed())
+	})
+})
+
+type cpiStackTracerHolder struct {
+	tracer *CPIStackTracer
+}
+
+func (h *cpiStackTracerHolder) Name() string {
+	return "CPIStackTracer"
+}
+
+func (h *cpiStackTracerHolder) SaveState(cp *sim.Checkpoint) error {
+	return h.tracer.SaveStateAs(cp, h.Name())
+}
+
+func (h *cpiStackTracerHolder) LoadState(cp *sim.Checkpoint) error {
+	return h.tracer.LoadStateAs(cp, h.Name())
+}
+
+var _ = Describe("CPIStackTracer Checkpoint", func() {
+	var (
+		engine       *sim.SerialEngine
+		tracer       *CPIStackTracer
+		checkpointer *sim.Checkpointer
+	)
+
+	BeforeEach(func() {
+		engine = sim.NewSerialEngine()
+		builder := MakeBuilder().WithEngine(engine)
+		cu := builder.Build("CheckpointCU")
+		tracer = NewCPIStackInstHook(cu, engine)
+		checkpointer = sim.NewCheckpointer(engine)
+		checkpointer.Register(&cpiStackTracerHolder{tracer: tracer})
+	})
+
+	It("should restore the CPI stack", func() {
+		tracer.StartTask(tracing.Task{ID: "wf", Kind: "wavefront"})
+		tracer.StartTask(tracing.Task{ID: "inst", Kind: "inst", What: "VALU"})
+		tracer.EndTask(tracing.Task{ID: "inst"})
+		tracer.EndTask(tracing.Task{ID: "wf"})
+		stack := tracer.GetCPIStack()
+
+		buf := bytes.NewBuffer(nil)
+		Expect(checkpointer.Save(buf)).To(Succeed())
+
+		tracer.StartTask(tracing.Task{ID: "wf2", Kind: "wavefront"})
+		tracer.StartTask(tracing.Task{ID: "inst2", Kind: "inst", What: "LDS"})
+		tracer.EndTask(tracing.Task{ID: "inst2"})
+		Expect(checkpointer.Restore(buf)).To(Succeed())
+
+		Expect(tracer.GetCPIStack()).To(Equal(stack))
+	})
+
+	It("should not save with tasks in flight", func() {
+		tracer.StartTask(tracing.Task{ID: "wf", Kind: "wavefront"})
+
+		Expect(checkpointer.Save(bytes.NewBuffer(nil))).NotTo(Succeed())
+	})
+})
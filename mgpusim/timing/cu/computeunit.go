@@ -6,6 +6,7 @@ import (
 
 	"github.com/rs/xid"
 	"github.com/sarchlab/akita/v3/mem/mem"
+	"github.com/sarchlab/akita/v3/metrics"
 	"github.com/sarchlab/akita/v3/sim"
 	"github.com/sarchlab/akita/v3/tracing"
 	"github.com/sarchlab/mgpusim/v3/emu"
@@ -71,6 +72,9 @@ type ComputeUnit struct {
 
 	currentFlushReq   *protocol.CUPipelineFlushReq
 	currentRestartReq *protocol.CUPipelineRestartReq
+
+	instCount     *metrics.Counter
+	simdInstCount *metrics.Counter
 }
 
 // ControlPort returns the port that can receive controlling messages from the
@@ -492,6 +496,8 @@ func (cu *ComputeUnit) processInputFromVectorMem(now sim.VTimeInSec) bool {
 		cu.handleVectorDataLoadReturn(now, rsp)
 	case *mem.WriteDoneRsp:
 		cu.handleVectorDataStoreRsp(now, rsp)
+	case *mem.FenceRsp:
+		cu.handleVectorFenceRsp(now, rsp)
 	default:
 		log.Panicf("cannot handle request of type %s from ToInstMem port",
 			reflect.TypeOf(rsp))
@@ -583,6 +589,32 @@ func (cu *ComputeUnit) handleVectorDataStoreRsp(
 	}
 }
 
+func (cu *ComputeUnit) handleVectorFenceRsp(
+	now sim.VTimeInSec,
+	rsp *mem.FenceRsp,
+) {
+	if len(cu.InFlightVectorMemAccess) == 0 {
+		return
+	}
+
+	info := cu.InFlightVectorMemAccess[0]
+
+	if info.Fence == nil {
+		return
+	}
+
+	if info.Fence.ID != rsp.RespondTo {
+		return
+	}
+
+	cu.InFlightVectorMemAccess = cu.InFlightVectorMemAccess[1:]
+	tracing.TraceReqFinalize(info.Fence, cu)
+
+	wf := info.Wavefront
+	wf.OutstandingVectorMemAccess--
+	cu.logInstTask(now, wf, info.Inst, true)
+}
+
 // UpdatePCAndSetReady is self explained
 func (cu *ComputeUnit) UpdatePCAndSetReady(wf *wavefront.Wavefront) {
 	wf.State = wavefront.WfReady
@@ -616,6 +648,8 @@ func (cu *ComputeUnit) logInstTask(
 		return
 	}
 
+	cu.countInst(inst)
+
 	tracing.StartTaskWithSpecificLocation(
 		inst.ID,
 		wf.UID,
@@ -631,6 +665,16 @@ func (cu *ComputeUnit) logInstTask(
 	)
 }
 
+// countInst counts an issued instruction. Each instruction is issued once,
+// while the completion of some instructions is logged more than once.
+func (cu *ComputeUnit) countInst(inst *wavefront.Inst) {
+	cu.instCount.Inc()
+
+	if inst.ExeUnit == insts.ExeUnitVALU {
+		cu.simdInstCount.Inc()
+	}
+}
+
 func (cu *ComputeUnit) execUnitToString(u insts.ExeUnit) string {
 	switch u {
 	case insts.ExeUnitVALU:
@@ -723,7 +767,7 @@ func (cu *ComputeUnit) sendVectorShadowBufferAccesses(
 		info := cu.shadowInFlightVectorMemAccess[0]
 		if info.Read != nil {
 			req := info.Read
-			req.ID = sim.GetIDGenerator().Generate()
+			req.ID = sim.IDGeneratorFor(cu).Generate()
 			req.SendTime = now
 			err := cu.ToVectorMem.Send(req)
 			if err == nil {
@@ -734,7 +778,17 @@ func (cu *ComputeUnit) sendVectorShadowBufferAccesses(
 			}
 		} else if info.Write != nil {
 			req := info.Write
-			req.ID = sim.GetIDGenerator().Generate()
+			req.ID = sim.IDGeneratorFor(cu).Generate()
+			req.SendTime = now
+			err := cu.ToVectorMem.Send(req)
+			if err == nil {
+				cu.InFlightVectorMemAccess = append(cu.InFlightVectorMemAccess, info)
+				cu.shadowInFlightVectorMemAccess = cu.shadowInFlightVectorMemAccess[1:]
+				return true
+			}
+		} else if info.Fence != nil {
+			req := info.Fence
+			req.ID = sim.IDGeneratorFor(cu).Generate()
 			req.SendTime = now
 			err := cu.ToVectorMem.Send(req)
 			if err == nil {
@@ -797,6 +851,16 @@ func (cu *ComputeUnit) setWavesToReady() {
 func NewComputeUnit(
 	name string,
 	engine sim.Engine,
+) *ComputeUnit {
+	return newComputeUnit(name, engine, metrics.DefaultRegistry())
+}
+
+// newComputeUnit constructs a compute unit that registers its metrics in the
+// given registry.
+func newComputeUnit(
+	name string,
+	engine sim.Engine,
+	registry *metrics.Registry,
 ) *ComputeUnit {
 	cu := new(ComputeUnit)
 	cu.TickingComponent = sim.NewTickingComponent(
@@ -810,5 +874,8 @@ func NewComputeUnit(
 	cu.ToVectorMem = sim.NewLimitNumMsgPort(cu, 4, name+".ToVectorMem")
 	cu.ToCP = sim.NewLimitNumMsgPort(cu, 4, name+".ToCP")
 
+	cu.instCount = registry.Counter(name, "inst_count")
+	cu.simdInstCount = registry.Counter(name, "simd_inst_count")
+
 	return cu
 }
@@ -457,6 +457,39 @@ var _ = Describe("ComputeUnit", func() {
 		})
 	})
 
+	Context("handle fence respond from ToVectorMem port", func() {
+		It("should complete the fence", func() {
+			rawWf := grid.WorkGroups[0].Wavefronts[0]
+			inst := wavefront.NewInst(insts.NewInst())
+			inst.FormatType = insts.MUBUF
+			wf := wavefront.NewWavefront(rawWf)
+			wf.SetDynamicInst(inst)
+			wf.OutstandingVectorMemAccess = 1
+
+			fence := mem.FenceReqBuilder{}.
+				WithScope(mem.ScopeAgent).
+				Build()
+			info := VectorMemAccessInfo{
+				Wavefront: wf,
+				Inst:      inst,
+				Fence:     fence,
+			}
+			cu.InFlightVectorMemAccess = append(cu.InFlightVectorMemAccess, info)
+
+			rsp := mem.FenceRspBuilder{}.
+				WithSendTime(10).
+				WithRspTo(fence.ID).
+				Build()
+			toVectorMem.EXPECT().Retrieve(gomock.Any()).Return(rsp)
+
+			madeProgress := cu.processInputFromVectorMem(10)
+
+			Expect(madeProgress).To(BeTrue())
+			Expect(wf.OutstandingVectorMemAccess).To(Equal(0))
+			Expect(cu.InFlightVectorMemAccess).To(HaveLen(0))
+		})
+	})
+
 	Context("should handle flush request", func() {
 		It("should handle a pipeline flush request from CU", func() {
 			req := protocol.CUPipelineFlushReqBuilder{}.
@@ -172,6 +172,68 @@ func NewCPIStackInstHook(
 	return h
 }
 
+type cpiStackTracerState struct {
+	FirstWFStarted       bool
+	FirstWFStartTime     float64
+	LastWFEndTime        float64
+	TimeStack            map[string]float64
+	LastRecordedTime     float64
+	InFlightTaskCountMap map[taskType]uint64
+	InstCount            uint64
+	ValuInstCount        uint64
+	RunningWFCount       uint64
+}
+
+// SaveStateAs saves the collected CPI stack in the checkpoint under the key.
+// The tracer can only be saved when no task is in flight.
+func (h *CPIStackTracer) SaveStateAs(cp *sim.Checkpoint, key string) error {
+	if len(h.inflightTasks) > 0 {
+		return fmt.Errorf("CPI stack tracer of %s has tasks in flight",
+			h.cu.Name())
+	}
+
+	state := cpiStackTracerState{
+		FirstWFStarted:       h.firstWFStarted,
+		FirstWFStartTime:     h.firstWFStartTime,
+		LastWFEndTime:        h.lastWFEndTime,
+		TimeStack:            h.timeStack,
+		LastRecordedTime:     h.lastRecordedTime,
+		InFlightTaskCountMap: h.inFlightTaskCountMap,
+		InstCount:            h.instCount,
+		ValuInstCount:        h.valuInstCount,
+		RunningWFCount:       h.runningWFCount,
+	}
+
+	return cp.Put(key, state)
+}
+
+// LoadStateAs restores the collected CPI stack from the checkpoint.
+func (h *CPIStackTracer) LoadStateAs(cp *sim.Checkpoint, key string) error {
+	state := cpiStackTracerState{}
+	err := cp.Get(key, &state)
+	if err != nil {
+		return err
+	}
+
+	h.inflightTasks = make(map[string]tracing.Task)
+	h.firstWFStarted = state.FirstWFStarted
+	h.firstWFStartTime = state.FirstWFStartTime
+	h.lastWFEndTime = state.LastWFEndTime
+	h.timeStack = make(map[string]float64)
+	for k, v := range state.TimeStack {
+		h.timeStack[k] = v
+	}
+	h.lastRecordedTime = state.LastRecordedTime
+	for t := taskType(taskTypeIdle); t < taskTypeCount; t++ {
+		h.inFlightTaskCountMap[t] = state.InFlightTaskCountMap[t]
+	}
+	h.instCount = state.InstCount
+	h.valuInstCount = state.ValuInstCount
+	h.runningWFCount = state.RunningWFCount
+
+	return nil
+}
+
 func (h *CPIStackTracer) totalCycle() float64 {
 	endTime := h.lastWFEndTime
 	if h.runningWFCount > 0 {
@@ -240,8 +302,9 @@ func (h *CPIStackTracer) handleTaskStart(task tracing.Task) {
 			h.firstWFStarted = true
 			h.firstWFStartTime = float64(h.timeTeller.CurrentTime())
 			h.lastRecordedTime = h.firstWFStartTime
-			h.runningWFCount++
 		}
+
+		h.runningWFCount++
 	case "inst", "fetch":
 		h.handleRegularTaskStart(task)
 	case "req_out":
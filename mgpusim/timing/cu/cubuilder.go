@@ -3,6 +3,7 @@ package cu
 import (
 	"fmt"
 
+	"github.com/sarchlab/akita/v3/metrics"
 	"github.com/sarchlab/akita/v3/pipelining"
 	"github.com/sarchlab/akita/v3/sim"
 	"github.com/sarchlab/akita/v3/tracing"
@@ -26,6 +27,8 @@ type Builder struct {
 
 	visTracer        tracing.Tracer
 	enableVisTracing bool
+
+	metrics *metrics.Registry
 }
 
 // MakeBuilder returns a default builder object
@@ -36,6 +39,7 @@ func MakeBuilder() Builder {
 	b.sgprCount = 3200
 	b.vgprCount = []int{16384, 16384, 16384, 16384}
 	b.log2CachelineSize = 6
+	b.metrics = metrics.DefaultRegistry()
 
 	return b
 }
@@ -87,11 +91,18 @@ func (b Builder) WithVisTracer(t tracing.Tracer) Builder {
 	return b
 }
 
+// WithMetricsRegistry sets the registry that the metrics of the Compute Unit
+// are registered in. By default, the default registry is used.
+func (b Builder) WithMetricsRegistry(registry *metrics.Registry) Builder {
+	b.metrics = registry
+	return b
+}
+
 // Build returns a newly constructed compute unit according to the
 // configuration.
 func (b *Builder) Build(name string) *ComputeUnit {
 	b.name = name
-	cu := NewComputeUnit(name, b.engine)
+	cu := newComputeUnit(name, b.engine, b.metrics)
 	cu.Freq = b.freq
 	cu.Decoder = insts.NewDisassembler()
 	cu.WfDispatcher = NewWfDispatcher(cu)
@@ -140,7 +151,8 @@ func (b *Builder) equipSIMDUnits(cu *ComputeUnit) {
 	cu.VectorDecoder = vectorDecoder
 	for i := 0; i < b.simdCount; i++ {
 		name := fmt.Sprintf(b.name+".SIMD%d", i)
-		simdUnit := NewSIMDUnit(cu, name, b.scratchpadPreparer, b.alu)
+		simdUnit := newSIMDUnit(cu, name, b.scratchpadPreparer, b.alu,
+			b.metrics)
 		if b.enableVisTracing {
 			tracing.CollectTrace(simdUnit, b.visTracer)
 		}
@@ -167,6 +179,7 @@ func (b *Builder) equipVectorMemoryUnit(cu *ComputeUnit) {
 
 	coalescer := &defaultCoalescer{
 		log2CacheLineSize: b.log2CachelineSize,
+		port:              cu.ToVectorMem,
 	}
 	vectorMemoryUnit := NewVectorMemoryUnit(cu, b.scratchpadPreparer, coalescer)
 	cu.VectorMemUnit = vectorMemoryUnit
@@ -2,12 +2,18 @@ package cu
 
 import (
 	"github.com/sarchlab/akita/v3/mem/mem"
+	"github.com/sarchlab/akita/v3/sim"
 	"github.com/sarchlab/mgpusim/v3/insts"
 	"github.com/sarchlab/mgpusim/v3/timing/wavefront"
 )
 
 type defaultCoalescer struct {
 	log2CacheLineSize uint64
+
+	// port is the port that sends the requests. The requests are built with
+	// the port as the source, so that their IDs come from the ID stream of
+	// the compute unit.
+	port sim.Port
 }
 
 func (c defaultCoalescer) generateMemTransactions(
@@ -143,6 +149,7 @@ func (c defaultCoalescer) findOrCreateReadReq(
 	}
 
 	req := mem.ReadReqBuilder{}.
+		WithSrc(c.port).
 		WithAddress(c.cacheLineID(addr)).
 		WithByteSize(1 << c.log2CacheLineSize).
 		Build()
@@ -163,6 +170,7 @@ func (c defaultCoalescer) findOrCreateWriteReq(
 	}
 
 	req := mem.WriteReqBuilder{}.
+		WithSrc(c.port).
 		WithAddress(c.cacheLineID(addr)).
 		WithData(make([]byte, 1<<c.log2CacheLineSize)).
 		WithDirtyMask(make([]bool, 1<<c.log2CacheLineSize)).
@@ -1,6 +1,7 @@
 package cu
 
 import (
+	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/sarchlab/mgpusim/v3/insts"
@@ -39,6 +40,40 @@ var _ = Describe("Default Coalescer", func() {
 		Expect(memTransactions[0].laneInfo).To(HaveLen(64))
 	})
 
+	It("should build the requests with the port as the source", func() {
+		mockCtrl := gomock.NewController(GinkgoT())
+		defer mockCtrl.Finish()
+		port := NewMockPort(mockCtrl)
+		c.port = port
+
+		inst := insts.NewInst()
+		inst.FormatType = insts.FLAT
+		inst.Opcode = 28 // flat_store_dword
+		wf.SetDynamicInst(wavefront.NewInst(inst))
+
+		sp := wf.Scratchpad().AsFlat()
+		sp.EXEC = 0x3
+		sp.ADDR[0] = 0x1000
+		sp.ADDR[1] = 0x1040
+
+		memTransactions := c.generateMemTransactions(wf)
+
+		Expect(memTransactions).To(HaveLen(2))
+		for _, t := range memTransactions {
+			Expect(t.Write.Src).To(BeIdenticalTo(port))
+		}
+
+		inst.Opcode = 20 // flat_load_dword
+		inst.Dst = insts.NewVRegOperand(0, 0, 1)
+
+		memTransactions = c.generateMemTransactions(wf)
+
+		Expect(memTransactions).To(HaveLen(2))
+		for _, t := range memTransactions {
+			Expect(t.Read.Src).To(BeIdenticalTo(port))
+		}
+	})
+
 	It("should coalesce to multiple cachelines", func() {
 		inst := insts.NewInst()
 		inst.FormatType = insts.FLAT
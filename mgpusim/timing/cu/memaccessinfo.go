@@ -18,6 +18,7 @@ type VectorMemAccessInfo struct {
 	ID        string
 	Read      *mem.ReadReq
 	Write     *mem.WriteReq
+	Fence     *mem.FenceReq
 	Wavefront *wavefront.Wavefront
 	Inst      *wavefront.Inst
 	laneInfo  []vectorMemAccessLaneInfo
@@ -1,6 +1,7 @@
 package cu
 
 import (
+	"fmt"
 	"log"
 
 	"github.com/sarchlab/akita/v3/sim"
@@ -84,3 +85,27 @@ func (r *SimpleRegisterFile) getRegOffset(reg *insts.Reg, offset int, laneID int
 
 	return 0
 }
+
+// SaveStateAs saves the content of the register file in the checkpoint under
+// the key.
+func (r *SimpleRegisterFile) SaveStateAs(cp *sim.Checkpoint, key string) error {
+	return cp.Put(key, r.storage)
+}
+
+// LoadStateAs restores the content of the register file from the checkpoint.
+func (r *SimpleRegisterFile) LoadStateAs(cp *sim.Checkpoint, key string) error {
+	storage := []byte{}
+	err := cp.Get(key, &storage)
+	if err != nil {
+		return err
+	}
+
+	if len(storage) != len(r.storage) {
+		return fmt.Errorf("register file %s does not match the checkpoint",
+			key)
+	}
+
+	copy(r.storage, storage)
+
+	return nil
+}
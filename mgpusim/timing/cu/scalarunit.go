@@ -136,6 +136,11 @@ func (u *ScalarUnit) executeSMEMLoad(byteSize int, now sim.VTimeInSec) bool {
 		return false
 	}
 
+	scope := mem.ScopeWorkgroup
+	if inst.GlobalLevelCoherent {
+		scope = mem.ScopeAgent
+	}
+
 	curr := start
 	bytesLeft := uint64(byteSize)
 	regIndex := inst.Data.Register.RegIndex()
@@ -149,6 +154,7 @@ func (u *ScalarUnit) executeSMEMLoad(byteSize int, now sim.VTimeInSec) bool {
 			WithDst(u.cu.ScalarMem).
 			WithAddress(curr).
 			WithPID(u.toExec.PID()).
+			WithScope(scope).
 			WithByteSize(bytesLeftInCacheline).
 			Build()
 		if bytesLeft > 0 {
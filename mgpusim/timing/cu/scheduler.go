@@ -103,7 +103,8 @@ func (s *SchedulerImpl) DecodeNextInst(now sim.VTimeInSec) bool {
 			inst, err := s.cu.Decoder.Decode(
 				wf.InstBuffer[wf.PC-wf.InstBufferStartPC:])
 			if err == nil {
-				wf.InstToIssue = wavefront.NewInst(inst)
+				wf.InstToIssue = wavefront.NewInstWithIDGenerator(
+					inst, sim.IDGeneratorFor(s.cu))
 				// s.cu.logInstTask(now, wf, wf.InstToIssue, false)
 				madeProgress = true
 			}
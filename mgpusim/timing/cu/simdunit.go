@@ -1,6 +1,7 @@
 package cu
 
 import (
+	"github.com/sarchlab/akita/v3/metrics"
 	"github.com/sarchlab/akita/v3/sim"
 	"github.com/sarchlab/akita/v3/tracing"
 	"github.com/sarchlab/mgpusim/v3/emu"
@@ -18,12 +19,15 @@ type SIMDUnit struct {
 	scratchpadPreparer ScratchpadPreparer
 	alu                emu.ALU
 
-	toExec    *wavefront.Wavefront
-	cycleLeft int
+	toExec        *wavefront.Wavefront
+	cycleLeft     int
+	execStartTime sim.VTimeInSec
 
 	NumSinglePrecisionUnit int
 
 	isIdle bool
+
+	busyTime *metrics.Counter
 }
 
 // NewSIMDUnit creates a new branch unit, injecting the dependency of
@@ -33,6 +37,19 @@ func NewSIMDUnit(
 	name string,
 	scratchpadPreparer ScratchpadPreparer,
 	alu emu.ALU,
+) *SIMDUnit {
+	return newSIMDUnit(cu, name, scratchpadPreparer, alu,
+		metrics.DefaultRegistry())
+}
+
+// newSIMDUnit creates a SIMD unit that registers its metrics in the given
+// registry.
+func newSIMDUnit(
+	cu *ComputeUnit,
+	name string,
+	scratchpadPreparer ScratchpadPreparer,
+	alu emu.ALU,
+	registry *metrics.Registry,
 ) *SIMDUnit {
 	u := new(SIMDUnit)
 	u.name = name
@@ -42,6 +59,8 @@ func NewSIMDUnit(
 
 	u.NumSinglePrecisionUnit = 16
 
+	u.busyTime = registry.Counter(name, "busy_time")
+
 	return u
 }
 
@@ -61,6 +80,7 @@ func (u *SIMDUnit) AcceptWave(wave *wavefront.Wavefront, now sim.VTimeInSec) {
 	u.toExec = wave
 
 	u.cycleLeft = 64 / u.NumSinglePrecisionUnit
+	u.execStartTime = now
 	u.logPipelineTask(now, u.toExec.DynamicInst(), false)
 }
 
@@ -85,6 +105,7 @@ func (u *SIMDUnit) runExecStage(now sim.VTimeInSec) bool {
 	u.scratchpadPreparer.Commit(u.toExec, u.toExec)
 	u.cu.UpdatePCAndSetReady(u.toExec)
 
+	u.busyTime.Add(float64(now - u.execStartTime))
 	u.logPipelineTask(now, u.toExec.DynamicInst(), true)
 	u.cu.logInstTask(now, u.toExec, u.toExec.DynamicInst(), true)
 
@@ -3,6 +3,7 @@ package cu
 import (
 	"log"
 
+	"github.com/sarchlab/akita/v3/mem/mem"
 	"github.com/sarchlab/akita/v3/pipelining"
 	"github.com/sarchlab/akita/v3/sim"
 	"github.com/sarchlab/akita/v3/tracing"
@@ -122,6 +123,11 @@ func (u *VectorMemoryUnit) execute(now sim.VTimeInSec) (madeProgress bool) {
 		if !ok {
 			return false
 		}
+	case insts.MUBUF:
+		ok := u.executeMUBUFInsts(now, wave)
+		if !ok {
+			return false
+		}
 	default:
 		log.Panicf("running inst %s in vector memory unit is not supported", inst.String(nil))
 	}
@@ -150,6 +156,69 @@ func (u *VectorMemoryUnit) executeFlatInsts(
 	panic("never")
 }
 
+func (u *VectorMemoryUnit) executeMUBUFInsts(
+	now sim.VTimeInSec,
+	wavefront *wavefront.Wavefront,
+) bool {
+	inst := wavefront.DynamicInst()
+	switch inst.Opcode {
+	case 62, 63: // BUFFER_WBINVL1, BUFFER_WBINVL1_VOL
+		return u.executeL1Invalidate(now, wavefront)
+	default:
+		log.Panicf("Opcode %d for format MUBUF is not supported.", inst.Opcode)
+	}
+
+	panic("never")
+}
+
+// executeL1Invalidate sends an agent-scope fence to the L1 vector cache. The
+// fence travels with the memory accesses, so that the L1 cache invalidates
+// itself after the earlier accesses complete.
+func (u *VectorMemoryUnit) executeL1Invalidate(
+	now sim.VTimeInSec,
+	wave *wavefront.Wavefront,
+) bool {
+	if len(u.cu.InFlightVectorMemAccess) >= u.cu.InFlightVectorMemAccessLimit {
+		return false
+	}
+
+	fence := mem.FenceReqBuilder{}.
+		WithSrc(u.cu.ToVectorMem).
+		WithDst(u.cu.VectorMemModules.Find(0)).
+		WithPID(wave.PID()).
+		WithScope(mem.ScopeAgent).
+		WithAcquire().
+		WithRelease().
+		Build()
+	info := VectorMemAccessInfo{
+		ID:        sim.IDGeneratorFor(u.cu).Generate(),
+		Fence:     fence,
+		Wavefront: wave,
+		Inst:      wave.DynamicInst(),
+	}
+
+	wave.OutstandingVectorMemAccess++
+
+	u.cu.InFlightVectorMemAccess = append(u.cu.InFlightVectorMemAccess, info)
+	u.transactionsWaiting = append(u.transactionsWaiting, info)
+
+	return true
+}
+
+// scope returns the scope of the memory accesses of an instruction, which is
+// controlled by the GLC and the SLC bits.
+func (u *VectorMemoryUnit) scope(inst *wavefront.Inst) mem.Scope {
+	if inst.SystemLevelCoherent {
+		return mem.ScopeSystem
+	}
+
+	if inst.GlobalLevelCoherent {
+		return mem.ScopeAgent
+	}
+
+	return mem.ScopeWorkgroup
+}
+
 func (u *VectorMemoryUnit) executeFlatLoad(
 	now sim.VTimeInSec,
 	wave *wavefront.Wavefront,
@@ -183,8 +252,9 @@ func (u *VectorMemoryUnit) executeFlatLoad(
 
 		lowModule := u.cu.VectorMemModules.Find(t.Read.Address)
 		t.Read.Dst = lowModule
-		t.Read.Src = u.cu.ToVectorMem
 		t.Read.PID = wave.PID()
+		t.Read.Scope = u.scope(wave.DynamicInst())
+		t.Read.Info = mem.PCInfo{ProgramCounter: wave.PC}
 		u.transactionsWaiting = append(u.transactionsWaiting, t)
 	}
 
@@ -223,8 +293,8 @@ func (u *VectorMemoryUnit) executeFlatStore(
 		}
 		lowModule := u.cu.VectorMemModules.Find(t.Write.Address)
 		t.Write.Dst = lowModule
-		t.Write.Src = u.cu.ToVectorMem
 		t.Write.PID = wave.PID()
+		t.Write.Scope = u.scope(wave.DynamicInst())
 		u.transactionsWaiting = append(u.transactionsWaiting, t)
 	}
 
@@ -239,10 +309,13 @@ func (u *VectorMemoryUnit) sendRequest(now sim.VTimeInSec) bool {
 
 	var req sim.Msg
 	info := item.(VectorMemAccessInfo)
-	if info.Read != nil {
+	switch {
+	case info.Read != nil:
 		req = info.Read
-	} else {
+	case info.Write != nil:
 		req = info.Write
+	default:
+		req = info.Fence
 	}
 
 	req.Meta().SendTime = now
@@ -138,6 +138,83 @@ var _ = Describe("Vector Memory Unit", func() {
 		Expect(vecMemUnit.transactionsWaiting).To(HaveLen(4))
 	})
 
+	It("should set the scope of glc accesses", func() {
+		kernelWave := kernels.NewWavefront()
+		wave := wavefront.NewWavefront(kernelWave)
+		inst := wavefront.NewInst(insts.NewInst())
+		inst.Format = insts.FormatTable[insts.FLAT]
+		inst.Opcode = 20
+		inst.GlobalLevelCoherent = true
+		inst.Dst = insts.NewVRegOperand(0, 0, 1)
+		wave.SetDynamicInst(inst)
+
+		transactions := []VectorMemAccessInfo{{
+			Read: mem.ReadReqBuilder{}.
+				WithAddress(0x100).
+				WithByteSize(4).
+				Build(),
+		}}
+		coalescer.EXPECT().generateMemTransactions(wave).Return(transactions)
+		instBuffer.EXPECT().Peek().Return(vectorMemInst{wavefront: wave})
+		instBuffer.EXPECT().Pop().Return(vectorMemInst{wavefront: wave})
+
+		vecMemUnit.instToTransaction(10)
+
+		Expect(cu.InFlightVectorMemAccess[0].Read.Scope).
+			To(Equal(mem.ScopeAgent))
+	})
+
+	It("should attach the PC to the reads", func() {
+		kernelWave := kernels.NewWavefront()
+		wave := wavefront.NewWavefront(kernelWave)
+		wave.PC = 0x1234
+		inst := wavefront.NewInst(insts.NewInst())
+		inst.Format = insts.FormatTable[insts.FLAT]
+		inst.Opcode = 20
+		inst.Dst = insts.NewVRegOperand(0, 0, 1)
+		wave.SetDynamicInst(inst)
+
+		transactions := []VectorMemAccessInfo{{
+			Read: mem.ReadReqBuilder{}.
+				WithAddress(0x100).
+				WithByteSize(4).
+				Build(),
+		}}
+		coalescer.EXPECT().generateMemTransactions(wave).Return(transactions)
+		instBuffer.EXPECT().Peek().Return(vectorMemInst{wavefront: wave})
+		instBuffer.EXPECT().Pop().Return(vectorMemInst{wavefront: wave})
+
+		vecMemUnit.instToTransaction(10)
+
+		pc, ok := mem.PCOf(cu.InFlightVectorMemAccess[0].Read.Info)
+		Expect(ok).To(BeTrue())
+		Expect(pc).To(Equal(uint64(0x1234)))
+	})
+
+	It("should run buffer_wbinvl1", func() {
+		kernelWave := kernels.NewWavefront()
+		wave := wavefront.NewWavefront(kernelWave)
+		inst := wavefront.NewInst(insts.NewInst())
+		inst.Format = insts.FormatTable[insts.MUBUF]
+		inst.Opcode = 62
+		wave.SetDynamicInst(inst)
+
+		instBuffer.EXPECT().Peek().Return(vectorMemInst{wavefront: wave})
+		instBuffer.EXPECT().Pop().Return(vectorMemInst{wavefront: wave})
+
+		madeProgress := vecMemUnit.instToTransaction(10)
+
+		Expect(madeProgress).To(BeTrue())
+		Expect(wave.State).To(Equal(wavefront.WfReady))
+		Expect(wave.OutstandingVectorMemAccess).To(Equal(1))
+		Expect(cu.InFlightVectorMemAccess).To(HaveLen(1))
+		fence := cu.InFlightVectorMemAccess[0].Fence
+		Expect(fence.Scope).To(Equal(mem.ScopeAgent))
+		Expect(fence.Acquire).To(BeTrue())
+		Expect(fence.Release).To(BeTrue())
+		Expect(vecMemUnit.transactionsWaiting).To(HaveLen(1))
+	})
+
 	It("should add transactions to pipeline", func() {
 		transactions := make([]VectorMemAccessInfo, 4)
 		for i := 0; i < 4; i++ {
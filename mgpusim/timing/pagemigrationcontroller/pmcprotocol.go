@@ -77,7 +77,7 @@ func (b PageMigrationReqToPMCBuilder) WithPMCPortOfRemoteGPU(
 // Build creats a new PageMigrationReqToPMC
 func (b PageMigrationReqToPMCBuilder) Build() *PageMigrationReqToPMC {
 	r := &PageMigrationReqToPMC{}
-	r.ID = sim.GetIDGenerator().Generate()
+	r.ID = sim.IDGeneratorFor(b.src).Generate()
 	r.Src = b.src
 	r.Dst = b.dst
 	r.SendTime = b.sendTime
@@ -127,7 +127,7 @@ func (b PageMigrationRspFromPMCBuilder) WithDst(dst sim.Port) PageMigrationRspFr
 // Build creats a new PageMigrationReqToPMC
 func (b PageMigrationRspFromPMCBuilder) Build() *PageMigrationRspFromPMC {
 	r := &PageMigrationRspFromPMC{}
-	r.ID = sim.GetIDGenerator().Generate()
+	r.ID = sim.IDGeneratorFor(b.src).Generate()
 	r.Src = b.src
 	r.Dst = b.dst
 	r.SendTime = b.sendTime
@@ -190,7 +190,7 @@ func (b DataPullReqBuilder) WithDataTransferSize(dataTransferSize uint64) DataPu
 // Build creats a new DataPullReq
 func (b DataPullReqBuilder) Build() *DataPullReq {
 	r := &DataPullReq{}
-	r.ID = sim.GetIDGenerator().Generate()
+	r.ID = sim.IDGeneratorFor(b.src).Generate()
 	r.Src = b.src
 	r.Dst = b.dst
 	r.SendTime = b.sendTime
@@ -248,7 +248,7 @@ func (b DataPullRspBuilder) WithData(Data []byte) DataPullRspBuilder {
 // Build creats a new DataPullRsp
 func (b DataPullRspBuilder) Build() *DataPullRsp {
 	r := &DataPullRsp{}
-	r.ID = sim.GetIDGenerator().Generate()
+	r.ID = sim.IDGeneratorFor(b.src).Generate()
 	r.Src = b.src
 	r.Dst = b.dst
 	r.SendTime = b.sendTime
@@ -2,6 +2,7 @@ package rdma
 
 import (
 	"github.com/sarchlab/akita/v3/mem/mem"
+	"github.com/sarchlab/akita/v3/metrics"
 	"github.com/sarchlab/akita/v3/sim"
 )
 
@@ -12,6 +13,7 @@ type Builder struct {
 	localModules           mem.LowModuleFinder
 	RemoteRDMAAddressTable mem.LowModuleFinder
 	bufferSize             int
+	metrics                *metrics.Registry
 }
 
 // MakeBuilder creates a new builder with default configuration values.
@@ -19,6 +21,7 @@ func MakeBuilder() Builder {
 	return Builder{
 		freq:       1 * sim.GHz,
 		bufferSize: 128,
+		metrics:    metrics.DefaultRegistry(),
 	}
 }
 
@@ -52,6 +55,13 @@ func (b Builder) WithRemoteModules(m mem.LowModuleFinder) Builder {
 	return b
 }
 
+// WithMetricsRegistry sets the registry that the metrics of the RDMA are
+// registered in. By default, the default registry is used.
+func (b Builder) WithMetricsRegistry(registry *metrics.Registry) Builder {
+	b.metrics = registry
+	return b
+}
+
 // Build creates a RDMA with the given parameters.
 func (b Builder) Build(name string) *Comp {
 	rdma := &Comp{}
@@ -72,5 +82,8 @@ func (b Builder) Build(name string) *Comp {
 	rdma.AddPort("CtrlPort", rdma.CtrlPort)
 	rdma.AddPort("ToOutside", rdma.ToOutside)
 
+	rdma.outgoingTransCount = b.metrics.Counter(name, "outgoing_trans_count")
+	rdma.incomingTransCount = b.metrics.Counter(name, "incoming_trans_count")
+
 	return rdma
 }
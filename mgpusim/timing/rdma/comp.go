@@ -6,6 +6,7 @@ import (
 	"reflect"
 
 	"github.com/sarchlab/akita/v3/mem/mem"
+	"github.com/sarchlab/akita/v3/metrics"
 	"github.com/sarchlab/akita/v3/sim"
 	"github.com/sarchlab/akita/v3/tracing"
 )
@@ -38,6 +39,9 @@ type Comp struct {
 
 	transactionsFromOutside []transaction
 	transactionsFromInside  []transaction
+
+	outgoingTransCount *metrics.Counter
+	incomingTransCount *metrics.Counter
 }
 
 // SetLocalModuleFinder sets the table to lookup for local data.
@@ -454,6 +458,8 @@ func (c *Comp) traceOutsideInStart(req mem.AccessReq, cloned mem.AccessReq) {
 }
 
 func (c *Comp) traceInsideOutEnd(trans transaction) {
+	c.outgoingTransCount.Inc()
+
 	if len(c.Hooks()) == 0 {
 		return
 	}
@@ -463,6 +469,8 @@ func (c *Comp) traceInsideOutEnd(trans transaction) {
 }
 
 func (c *Comp) traceOutsideInEnd(trans transaction) {
+	c.incomingTransCount.Inc()
+
 	tracing.TraceReqFinalize(trans.toInside, c)
 	tracing.TraceReqComplete(trans.fromOutside, c)
 }
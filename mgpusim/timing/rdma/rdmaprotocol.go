@@ -43,7 +43,7 @@ func (b DrainReqBuilder) WithDst(dst sim.Port) DrainReqBuilder {
 // Build creats a new DrainReq
 func (b DrainReqBuilder) Build() *DrainReq {
 	r := &DrainReq{}
-	r.ID = sim.GetIDGenerator().Generate()
+	r.ID = sim.IDGeneratorFor(b.src).Generate()
 	r.Src = b.src
 	r.Dst = b.dst
 	r.SendTime = b.sendTime
@@ -89,7 +89,7 @@ func (b RestartReqBuilder) WithDst(dst sim.Port) RestartReqBuilder {
 // Build creats a new RDMADrainRsp
 func (b RestartReqBuilder) Build() *RestartReq {
 	r := &RestartReq{}
-	r.ID = sim.GetIDGenerator().Generate()
+	r.ID = sim.IDGeneratorFor(b.src).Generate()
 	r.Src = b.src
 	r.Dst = b.dst
 	r.SendTime = b.sendTime
@@ -135,7 +135,7 @@ func (b DrainRspBuilder) WithDst(dst sim.Port) DrainRspBuilder {
 // Build creats a new RDMADrainRsp
 func (b DrainRspBuilder) Build() *DrainRsp {
 	r := &DrainRsp{}
-	r.ID = sim.GetIDGenerator().Generate()
+	r.ID = sim.IDGeneratorFor(b.src).Generate()
 	r.Src = b.src
 	r.Dst = b.dst
 	r.SendTime = b.sendTime
@@ -181,7 +181,7 @@ func (b RestartRspBuilder) WithDst(dst sim.Port) RestartRspBuilder {
 // Build creats a new RDMADrainRsp
 func (b RestartRspBuilder) Build() *RestartRsp {
 	r := &RestartRsp{}
-	r.ID = sim.GetIDGenerator().Generate()
+	r.ID = sim.IDGeneratorFor(b.src).Generate()
 	r.Src = b.src
 	r.Dst = b.dst
 	r.SendTime = b.sendTime
@@ -150,7 +150,6 @@ func (b *ReorderBuffer) topDown(now sim.VTimeInSec) bool {
 	req := item.(mem.AccessReq)
 	trans := b.createTransaction(req)
 
-	trans.reqToBottom.Meta().Src = b.bottomPort
 	trans.reqToBottom.Meta().SendTime = now
 	err := b.bottomPort.Send(trans.reqToBottom)
 	if err != nil {
@@ -202,7 +201,6 @@ func (b *ReorderBuffer) bottomUp(now sim.VTimeInSec) bool {
 
 	rsp := b.duplicateRsp(trans.rspFromBottom, trans.reqFromTop.Meta().ID)
 	rsp.Meta().Dst = trans.reqFromTop.Meta().Src
-	rsp.Meta().Src = b.topPort
 	rsp.Meta().SendTime = now
 
 	err := b.topPort.Send(rsp)
@@ -245,6 +243,8 @@ func (b *ReorderBuffer) duplicateReq(req mem.AccessReq) mem.AccessReq {
 		return b.duplicateReadReq(req)
 	case *mem.WriteReq:
 		return b.duplicateWriteReq(req)
+	case *mem.FenceReq:
+		return b.duplicateFenceReq(req)
 	default:
 		panic("unsupported type")
 	}
@@ -252,23 +252,43 @@ func (b *ReorderBuffer) duplicateReq(req mem.AccessReq) mem.AccessReq {
 
 func (b *ReorderBuffer) duplicateReadReq(req *mem.ReadReq) *mem.ReadReq {
 	return mem.ReadReqBuilder{}.
+		WithSrc(b.bottomPort).
 		WithAddress(req.Address).
 		WithByteSize(req.AccessByteSize).
 		WithPID(req.PID).
+		WithScope(req.Scope).
+		WithInfo(req.Info).
 		WithDst(b.BottomUnit).
 		Build()
 }
 
 func (b *ReorderBuffer) duplicateWriteReq(req *mem.WriteReq) *mem.WriteReq {
 	return mem.WriteReqBuilder{}.
+		WithSrc(b.bottomPort).
 		WithAddress(req.Address).
 		WithPID(req.PID).
+		WithScope(req.Scope).
 		WithData(req.Data).
 		WithDirtyMask(req.DirtyMask).
 		WithDst(b.BottomUnit).
 		Build()
 }
 
+func (b *ReorderBuffer) duplicateFenceReq(req *mem.FenceReq) *mem.FenceReq {
+	builder := mem.FenceReqBuilder{}.
+		WithSrc(b.bottomPort).
+		WithPID(req.PID).
+		WithScope(req.Scope).
+		WithDst(b.BottomUnit)
+	if req.Acquire {
+		builder = builder.WithAcquire()
+	}
+	if req.Release {
+		builder = builder.WithRelease()
+	}
+	return builder.Build()
+}
+
 func (b *ReorderBuffer) duplicateRsp(
 	rsp mem.AccessRsp,
 	rspTo string,
@@ -278,6 +298,11 @@ func (b *ReorderBuffer) duplicateRsp(
 		return b.duplicateDataReadyRsp(rsp, rspTo)
 	case *mem.WriteDoneRsp:
 		return b.duplicateWriteDoneRsp(rsp, rspTo)
+	case *mem.FenceRsp:
+		return mem.FenceRspBuilder{}.
+			WithSrc(b.topPort).
+			WithRspTo(rspTo).
+			Build()
 	default:
 		panic("type not supported")
 	}
@@ -288,6 +313,7 @@ func (b *ReorderBuffer) duplicateDataReadyRsp(
 	rspTo string,
 ) *mem.DataReadyRsp {
 	return mem.DataReadyRspBuilder{}.
+		WithSrc(b.topPort).
 		WithData(rsp.Data).
 		WithRspTo(rspTo).
 		Build()
@@ -298,6 +324,7 @@ func (b *ReorderBuffer) duplicateWriteDoneRsp(
 	rspTo string,
 ) *mem.WriteDoneRsp {
 	return mem.WriteDoneRspBuilder{}.
+		WithSrc(b.topPort).
 		WithRspTo(rspTo).
 		Build()
 }
@@ -95,6 +95,29 @@ var _ = Describe("Reorder Buffer", func() {
 			Expect(rob.transactions.Len()).To(Equal(1))
 			Expect(rob.toBottomReqIDToTransactionTable).To(HaveLen(1))
 		})
+
+		It("should forward fences to bottom", func() {
+			fence := mem.FenceReqBuilder{}.
+				WithScope(mem.ScopeAgent).
+				WithAcquire().
+				Build()
+			topPort.EXPECT().Peek().Return(fence)
+			topPort.EXPECT().Retrieve(sim.VTimeInSec(10))
+			bottomPort.EXPECT().
+				Send(gomock.Any()).
+				Do(func(req *mem.FenceReq) {
+					Expect(req.Dst).To(BeIdenticalTo(rob.BottomUnit))
+					Expect(req.Scope).To(Equal(mem.ScopeAgent))
+					Expect(req.Acquire).To(BeTrue())
+					Expect(req.Release).To(BeFalse())
+				}).
+				Return(nil)
+
+			madeProgress := rob.topDown(10)
+
+			Expect(madeProgress).To(BeTrue())
+			Expect(rob.transactions.Len()).To(Equal(1))
+		})
 	})
 
 	Context("parse bottom", func() {
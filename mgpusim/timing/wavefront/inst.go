@@ -15,10 +15,16 @@ type Inst struct {
 
 // NewInst creates a newly created Inst
 func NewInst(raw *insts.Inst) *Inst {
+	return NewInstWithIDGenerator(raw, sim.GetIDGenerator())
+}
+
+// NewInstWithIDGenerator creates an Inst whose ID is generated by the given ID
+// generator.
+func NewInstWithIDGenerator(raw *insts.Inst, idGenerator sim.IDGenerator) *Inst {
 	i := new(Inst)
 	i.Inst = raw
 
-	i.ID = sim.GetIDGenerator().Generate()
+	i.ID = idGenerator.Generate()
 
 	return i
 }